@@ -77,6 +77,10 @@ var typePrefixesByPackage = map[string]string{
 	"manga_providers":        "Manga_",
 	"chapter_downloader":     "ChapterDownloader_",
 	"manga_downloader":       "MangaDownloader_",
+	"manga_scanner":          "MangaScanner_",
+	"manga_readingstate":     "MangaReadingState_",
+	"manga_mihon":            "MangaMihon_",
+	"manga_releasetracker":   "MangaReleaseTracker_",
 	"docs":                   "INTERNAL_",
 	"tvdb":                   "TVDB_",
 	"metadata":               "Metadata_",
@@ -96,6 +100,9 @@ var typePrefixesByPackage = map[string]string{
 	"torrentstream":          "Torrentstream_",
 	"extension":              "Extension_",
 	"extension_repo":         "ExtensionRepo_",
+	"goja_runtime":           "GojaRuntime_",
+	"subtitles":              "Subtitles_",
+	"tracker":                "Tracker_",
 	//"vendor_hibike_manga":        "HibikeManga_",
 	//"vendor_hibike_onlinestream": "HibikeOnlinestream_",
 	//"vendor_hibike_torrent":      "HibikeTorrent_",
@@ -106,6 +113,8 @@ var typePrefixesByPackage = map[string]string{
 	"hibiketorrent":      "HibikeTorrent_",
 	"hibikemediaplayer":  "HibikeMediaPlayer_",
 	"hibikeextension":    "HibikeExtension_",
+	"hibikesubtitle":     "HibikeSubtitle_",
+	"hibiketracker":      "HibikeTracker_",
 	"continuity":         "Continuity_",
 	"sync":               "Sync_",
 	"debrid":             "Debrid_",
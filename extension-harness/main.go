@@ -0,0 +1,50 @@
+// Runs the regression suite of a provider extension against recorded fetch fixtures.
+//
+//	go run ./extension-harness -suite path/to/suite.json -record  # Record the fixtures and expected outputs
+//	go run ./extension-harness -suite path/to/suite.json          # Replay offline and compare the outputs
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"seanime/internal/extension_playground"
+	"seanime/internal/util"
+)
+
+func main() {
+
+	var suitePath string
+	flag.StringVar(&suitePath, "suite", "", "Path to the suite file")
+
+	var record bool
+	flag.BoolVar(&record, "record", false, "Send the requests and save the fixtures and expected outputs")
+
+	flag.Parse()
+
+	if suitePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger := util.NewLogger()
+
+	res, err := extension_playground.RunHarness(logger, suitePath, record)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, c := range res.Cases {
+		if c.Passed {
+			fmt.Printf("PASS  %s (%s)\n", c.Name, c.Method)
+			continue
+		}
+		fmt.Printf("FAIL  %s (%s): %s\n", c.Name, c.Method, c.Difference)
+	}
+	fmt.Printf("\n%s [%s]: %d passed, %d failed\n", res.ExtensionID, res.Mode, res.Passed, res.Failed)
+
+	if res.Failed > 0 {
+		os.Exit(1)
+	}
+}
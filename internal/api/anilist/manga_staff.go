@@ -0,0 +1,113 @@
+package anilist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+const mangaStaffDocument = `query MangaStaff ($id: Int) {
+	Media(id: $id, type: MANGA) {
+		id
+		staff(sort: [RELEVANCE, ID], perPage: 25) {
+			edges {
+				role
+				node {
+					name {
+						full
+					}
+				}
+			}
+		}
+	}
+}`
+
+// MangaStaff contains the names of the people credited for a manga, e.g. for ComicInfo.xml.
+type MangaStaff struct {
+	Writers []string `json:"writers"`
+	Artists []string `json:"artists"`
+}
+
+// GetMangaStaff fetches the writers and artists of a manga.
+// Roles are matched loosely since AniList uses free-form roles, e.g. "Story & Art", "Story", "Art (assistant)".
+func GetMangaStaff(mediaId int, logger *zerolog.Logger, token string) (ret *MangaStaff, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"query":     mangaStaffDocument,
+		"variables": map[string]interface{}{"id": mediaId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := customQuery(requestBody, logger, token)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Media *struct {
+			Staff *struct {
+				Edges []*struct {
+					Role *string `json:"role"`
+					Node *struct {
+						Name *struct {
+							Full *string `json:"full"`
+						} `json:"name"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"staff"`
+		} `json:"Media"`
+	}
+	if err := json.Unmarshal(m, &res); err != nil {
+		return nil, err
+	}
+
+	if res.Media == nil || res.Media.Staff == nil {
+		return nil, fmt.Errorf("no data found")
+	}
+
+	ret = &MangaStaff{
+		Writers: make([]string, 0),
+		Artists: make([]string, 0),
+	}
+	for _, edge := range res.Media.Staff.Edges {
+		if edge == nil || edge.Role == nil || edge.Node == nil || edge.Node.Name == nil || edge.Node.Name.Full == nil {
+			continue
+		}
+		role := strings.ToLower(*edge.Role)
+		// Skip assistants, translators, etc.
+		if strings.Contains(role, "assistant") || strings.Contains(role, "translator") || strings.Contains(role, "lettering") {
+			continue
+		}
+		if strings.Contains(role, "story") || strings.Contains(role, "original creator") {
+			ret.Writers = appendUniqueName(ret.Writers, *edge.Node.Name.Full)
+		}
+		if strings.Contains(role, "art") {
+			ret.Artists = appendUniqueName(ret.Artists, *edge.Node.Name.Full)
+		}
+	}
+
+	return ret, nil
+}
+
+func appendUniqueName(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
@@ -0,0 +1,224 @@
+package mangaupdates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"seanime/internal/util"
+	"seanime/internal/util/comparison"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+const (
+	ApiUrl = "https://api.mangaupdates.com/v1"
+	// seriesMatchingThreshold is the minimum Sorensen-Dice rating for a series to match a title
+	seriesMatchingThreshold = 0.75
+)
+
+var ErrSeriesNotFound = errors.New("mangaupdates: Series not found")
+
+type (
+	// Client is a client for the MangaUpdates API.
+	// The series and releases endpoints do not require authentication.
+	Client struct {
+		baseUrl string
+		client  *http.Client
+		logger  *zerolog.Logger
+	}
+
+	Series struct {
+		ID            int64  `json:"series_id"`
+		Title         string `json:"title"`
+		URL           string `json:"url"`
+		Year          string `json:"year"`
+		Type          string `json:"type"`
+		LatestChapter int    `json:"latest_chapter"`
+	}
+
+	SeriesSearchResult struct {
+		Record *Series `json:"record"`
+		// HitTitle is the title or associated name that matched the query
+		HitTitle string `json:"hit_title"`
+	}
+
+	Release struct {
+		ID          int64           `json:"id"`
+		Title       string          `json:"title"`
+		Volume      string          `json:"volume"`
+		Chapter     string          `json:"chapter"`
+		Groups      []*ReleaseGroup `json:"groups"`
+		ReleaseDate string          `json:"release_date"`
+	}
+
+	ReleaseGroup struct {
+		Name    string `json:"name"`
+		GroupID int64  `json:"group_id"`
+	}
+
+	searchResponse[T any] struct {
+		TotalHits int `json:"total_hits"`
+		Results   []struct {
+			Record   T      `json:"record"`
+			HitTitle string `json:"hit_title"`
+		} `json:"results"`
+	}
+)
+
+func NewClient(logger *zerolog.Logger) *Client {
+	return &Client{
+		baseUrl: ApiUrl,
+		client:  &http.Client{Timeout: 15 * time.Second},
+		logger:  logger,
+	}
+}
+
+// SearchSeries searches series by title.
+func (c *Client) SearchSeries(query string) ([]*SeriesSearchResult, error) {
+	body := map[string]interface{}{
+		"search":  query,
+		"perpage": 10,
+	}
+
+	var res searchResponse[*Series]
+	if err := c.post("/series/search", body, &res); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*SeriesSearchResult, 0, len(res.Results))
+	for _, r := range res.Results {
+		if r.Record == nil {
+			continue
+		}
+		ret = append(ret, &SeriesSearchResult{Record: r.Record, HitTitle: r.HitTitle})
+	}
+	return ret, nil
+}
+
+// FindSeries returns the series that best matches one of the titles.
+// Titles are searched in order until a match is found.
+func (c *Client) FindSeries(titles []*string) (*Series, error) {
+	for _, title := range titles {
+		if title == nil || *title == "" {
+			continue
+		}
+
+		results, err := c.SearchSeries(*title)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized := normalizeTitle(*title)
+		var best *Series
+		bestRating := 0.0
+		for _, r := range results {
+			candidates := []*string{lo.ToPtr(normalizeTitle(r.Record.Title)), lo.ToPtr(normalizeTitle(r.HitTitle))}
+			if m, ok := comparison.FindBestMatchWithSorensenDice(&normalized, candidates); ok && m.Rating > bestRating {
+				best = r.Record
+				bestRating = m.Rating
+			}
+		}
+		if best != nil && bestRating >= seriesMatchingThreshold {
+			return best, nil
+		}
+	}
+
+	return nil, ErrSeriesNotFound
+}
+
+// GetSeriesReleases returns the latest releases of a series, most recent first.
+func (c *Client) GetSeriesReleases(seriesId int64, perPage int) ([]*Release, error) {
+	body := map[string]interface{}{
+		"search":      strconv.FormatInt(seriesId, 10),
+		"search_type": "series",
+		"perpage":     perPage,
+		"orderby":     "date",
+		"asc":         "desc",
+	}
+
+	var res searchResponse[*Release]
+	if err := c.post("/releases/search", body, &res); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*Release, 0, len(res.Results))
+	for _, r := range res.Results {
+		if r.Record != nil {
+			ret = append(ret, r.Record)
+		}
+	}
+	return ret, nil
+}
+
+func (c *Client) post(path string, body interface{}, v interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseUrl+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", util.GetRandomUserAgent())
+
+	c.logger.Trace().Str("path", path).Msg("mangaupdates: Sending request")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("mangaupdates: Unexpected status code %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// chapterNumberRegex matches the numbers of a release chapter, e.g. "12", "12.5", "120-122", "12a"
+var chapterNumberRegex = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// ParseChapterNumber returns the highest chapter number of a release, 0 if there is none.
+//
+//	"120-122" -> 122
+//	"12.5"    -> 12.5
+func ParseChapterNumber(chapter string) float64 {
+	ret := 0.0
+	for _, m := range chapterNumberRegex.FindAllString(chapter, -1) {
+		if f, err := strconv.ParseFloat(m, 64); err == nil {
+			ret = max(ret, f)
+		}
+	}
+	return ret
+}
+
+// GetGroupNames returns the names of the groups of a release, joined by " & ".
+func (r *Release) GetGroupNames() string {
+	names := make([]string, 0, len(r.Groups))
+	for _, g := range r.Groups {
+		names = append(names, g.Name)
+	}
+	return strings.Join(names, " & ")
+}
+
+func normalizeTitle(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '!', '?', ',', '\'', '"', '.', '-', '_', '~':
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
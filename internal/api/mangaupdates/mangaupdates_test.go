@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/goccy/go-json"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"net/http"
+	"net/http/httptest"
+	"seanime/internal/util"
 	"strings"
 	"testing"
 	"time"
@@ -63,3 +66,61 @@ func TestApi(t *testing.T) {
 	}
 
 }
+
+func TestParseChapterNumber(t *testing.T) {
+	tests := []struct {
+		chapter  string
+		expected float64
+	}{
+		{"12", 12},
+		{"12.5", 12.5},
+		{"120-122", 122},
+		{"12a", 12},
+		{"", 0},
+		{"Oneshot", 0},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, ParseChapterNumber(tt.chapter), tt.chapter)
+	}
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.Path {
+		case "/series/search":
+			_, _ = w.Write([]byte(`{"total_hits":2,"results":[
+				{"record":{"series_id":1,"title":"Dandadan Spin-off","url":"https://www.mangaupdates.com/series/a"},"hit_title":"Dandadan Spin-off"},
+				{"record":{"series_id":2,"title":"Dandadan","url":"https://www.mangaupdates.com/series/b","latest_chapter":170},"hit_title":"Dan Da Dan"}
+			]}`))
+		case "/releases/search":
+			require.Equal(t, "2", body["search"])
+			require.Equal(t, "series", body["search_type"])
+			_, _ = w.Write([]byte(`{"total_hits":1,"results":[
+				{"record":{"id":10,"title":"Dandadan","volume":null,"chapter":"171-172","groups":[{"name":"A","group_id":1},{"name":"B","group_id":2}],"release_date":"2024-11-01"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(util.NewLogger())
+	client.baseUrl = server.URL
+
+	series, err := client.FindSeries([]*string{lo.ToPtr("Dan Da Dan")})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), series.ID)
+
+	releases, err := client.GetSeriesReleases(series.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	require.Equal(t, 172.0, ParseChapterNumber(releases[0].Chapter))
+	require.Equal(t, "A & B", releases[0].GetGroupNames())
+
+	_, err = client.FindSeries([]*string{lo.ToPtr("Something else entirely")})
+	require.ErrorIs(t, err, ErrSeriesNotFound)
+}
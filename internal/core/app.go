@@ -23,7 +23,14 @@ import (
 	"seanime/internal/library/playbackmanager"
 	"seanime/internal/library/scanner"
 	"seanime/internal/manga"
+	manga_autodownloader "seanime/internal/manga/autodownloader"
+	"seanime/internal/manga/opds"
+	manga_pageprocessor "seanime/internal/manga/pageprocessor"
 	manga_providers "seanime/internal/manga/providers"
+	manga_readingstate "seanime/internal/manga/readingstate"
+	manga_releasetracker "seanime/internal/manga/releasetracker"
+	manga_scanner "seanime/internal/manga/scanner"
+	"seanime/internal/mediaplayers/kodi"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/mediaplayers/mpchc"
 	"seanime/internal/mediaplayers/mpv"
@@ -35,13 +42,16 @@ import (
 	"seanime/internal/platforms/platform"
 	"seanime/internal/plugin"
 	"seanime/internal/report"
+	"seanime/internal/subtitles"
 	sync2 "seanime/internal/sync"
 	"seanime/internal/torrent_clients/torrent_client"
 	"seanime/internal/torrents/torrent"
 	"seanime/internal/torrentstream"
+	"seanime/internal/tracker"
 	"seanime/internal/updater"
 	"seanime/internal/util"
 	"seanime/internal/util/filecache"
+	"seanime/internal/webhook"
 	"sync"
 
 	"seanime/internal/extension"
@@ -71,6 +81,7 @@ type (
 			VLC   *vlc.VLC
 			MpcHc *mpchc.MpcHc
 			Mpv   *mpv.Mpv
+			Kodi  *kodi.Kodi
 		}
 		MediaPlayerRepository   *mediaplayer.Repository
 		Version                 string
@@ -79,10 +90,19 @@ type (
 		PlaybackManager         *playbackmanager.PlaybackManager
 		FileCacher              *filecache.Cacher
 		OnlinestreamRepository  *onlinestream.Repository
+		SubtitleRepository      *subtitles.Repository
 		MangaRepository         *manga.Repository
 		MetadataProvider        metadata.Provider
 		DiscordPresence         *discordrpc_presence.Presence
 		MangaDownloader         *manga.Downloader
+		MangaPageProcessor      *manga_pageprocessor.Processor
+		MangaAutoDownloader     *manga_autodownloader.AutoDownloader
+		MangaReleaseTracker     *manga_releasetracker.ReleaseTracker
+		MangaLocalLibrary       *manga_scanner.LocalLibrary
+		MangaReadingState       *manga_readingstate.Manager
+		OpdsCatalog             *opds.Catalog
+		WebhookManager          *webhook.Manager
+		TrackerManager          *tracker.Manager
 		ContinuityManager       *continuity.Manager
 		Cleanups                []func()
 		OnFlushLogs             func()
@@ -249,9 +269,30 @@ func NewApp(ctx context.Context, configOpts *ConfigOptions, selfupdater *updater
 		Database:       database,
 	})
 
+	// Register Local Library manga provider as a built-in extension
+	mangaLocalLibrary := manga_scanner.NewLocalLibrary(&manga_scanner.NewLocalLibraryOptions{
+		Logger:            logger,
+		Database:          database,
+		MappingRepository: mangaRepository,
+	})
+	extensionRepository.ReloadBuiltInExtension(extension.Extension{
+		ID:          manga_scanner.ProviderID,
+		Name:        manga_scanner.ProviderName,
+		Type:        extension.TypeMangaProvider,
+		Language:    extension.LanguageGo,
+		ManifestURI: "builtin",
+	}, manga_scanner.NewProvider(mangaLocalLibrary))
+
 	// Initialize Anilist platform
 	anilistPlatform := anilist_platform.NewAnilistPlatform(anilistCW, logger)
 
+	// Initialize tracker manager, it mirrors the list changes made on AniList to the tracker extensions
+	trackerManager := tracker.NewManager(&tracker.NewManagerOptions{
+		Logger:   logger,
+		Database: database,
+		Platform: anilistPlatform,
+	})
+
 	// Update plugin context with new modules
 	plugin.GlobalAppContext.SetModulesPartial(plugin.AppContextModules{
 		AnilistPlatform:  anilistPlatform,
@@ -301,6 +342,13 @@ func NewApp(ctx context.Context, configOpts *ConfigOptions, selfupdater *updater
 		Database:         database,
 	})
 
+	// Initialize subtitle repository for subtitle provider extensions
+	subtitleRepository := subtitles.NewRepository(&subtitles.NewRepositoryOptions{
+		Logger:   logger,
+		Platform: activePlatform,
+		CacheDir: cfg.Cache.Dir,
+	})
+
 	// Initialize extension playground for testing extensions
 	extensionPlaygroundRepository := extension_playground.NewPlaygroundRepository(logger, activePlatform, activeMetadataProvider)
 
@@ -318,14 +366,23 @@ func NewApp(ctx context.Context, configOpts *ConfigOptions, selfupdater *updater
 		Updater:                       updater.New(constants.Version, logger, wsEventManager),
 		FileCacher:                    fileCacher,
 		OnlinestreamRepository:        onlinestreamRepository,
+		SubtitleRepository:            subtitleRepository,
+		TrackerManager:                trackerManager,
 		MetadataProvider:              activeMetadataProvider,
 		MangaRepository:               mangaRepository,
+		MangaLocalLibrary:             mangaLocalLibrary,
 		ExtensionRepository:           extensionRepository,
 		ExtensionPlaygroundRepository: extensionPlaygroundRepository,
 		ReportRepository:              report.NewRepository(logger),
 		TorrentRepository:             nil, // Initialized in App.initModulesOnce
 		FillerManager:                 nil, // Initialized in App.initModulesOnce
 		MangaDownloader:               nil, // Initialized in App.initModulesOnce
+		MangaPageProcessor:            nil, // Initialized in App.initModulesOnce
+		MangaAutoDownloader:           nil, // Initialized in App.initModulesOnce
+		MangaReleaseTracker:           nil, // Initialized in App.initModulesOnce
+		MangaReadingState:             nil, // Initialized in App.initModulesOnce
+		OpdsCatalog:                   nil, // Initialized in App.initModulesOnce
+		WebhookManager:                nil, // Initialized in App.initModulesOnce
 		PlaybackManager:               nil, // Initialized in App.initModulesOnce
 		AutoDownloader:                nil, // Initialized in App.initModulesOnce
 		AutoScanner:                   nil, // Initialized in App.initModulesOnce
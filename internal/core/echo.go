@@ -4,8 +4,11 @@ import (
 	"embed"
 	"io/fs"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	chapter_downloader "seanime/internal/manga/downloader"
 	"strings"
 	"time"
 
@@ -37,6 +40,7 @@ func NewEchoApp(app *App, webFS *embed.FS) *echo.Echo {
 				strings.HasPrefix(cUrl.RequestURI(), "/events") ||
 				strings.HasPrefix(cUrl.RequestURI(), "/assets") ||
 				strings.HasPrefix(cUrl.RequestURI(), "/manga-downloads") ||
+				strings.HasPrefix(cUrl.RequestURI(), "/opds") ||
 				strings.HasPrefix(cUrl.RequestURI(), "/offline-assets") {
 				return true // Continue to the next handler
 			}
@@ -60,6 +64,10 @@ func NewEchoApp(app *App, webFS *embed.FS) *echo.Echo {
 	if app.Config.Manga.DownloadDir != "" {
 		app.Logger.Info().Msgf("app: Manga downloads path: %s", app.Config.Manga.DownloadDir)
 		e.Static("/manga-downloads", app.Config.Manga.DownloadDir)
+		// Serve pages of chapters stored as CBZ archives, e.g. /manga-downloads/comick_1234_abc_13.cbz/01.jpg
+		e.GET("/manga-downloads/:chapter/:page", func(c echo.Context) error {
+			return serveMangaDownloadPage(c, app.Config.Manga.DownloadDir)
+		})
 	}
 
 	// Serve offline assets
@@ -69,6 +77,40 @@ func NewEchoApp(app *App, webFS *embed.FS) *echo.Echo {
 	return e
 }
 
+// serveMangaDownloadPage serves a page of a downloaded chapter, stored as a directory or as a CBZ archive.
+// This route takes precedence over the static route for chapter pages.
+func serveMangaDownloadPage(c echo.Context, downloadDir string) error {
+	chapter, err := url.PathUnescape(c.Param("chapter"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+	page, err := url.PathUnescape(c.Param("page"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	// Only allow files directly under the chapter directory
+	chapterPath := filepath.Join(downloadDir, filepath.Base(chapter))
+	page = filepath.Base(page)
+
+	if !strings.HasSuffix(strings.ToLower(chapterPath), chapter_downloader.ChapterArchiveExt) {
+		return c.File(filepath.Join(chapterPath, page))
+	}
+
+	r, err := chapter_downloader.OpenChapterPage(chapterPath, page)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+	defer r.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(page))
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+	return c.Stream(http.StatusOK, contentType, r)
+}
+
 type CustomJSONSerializer struct{}
 
 func (j *CustomJSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
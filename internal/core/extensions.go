@@ -5,6 +5,7 @@ import (
 	"seanime/internal/extension_repo"
 	manga_providers "seanime/internal/manga/providers"
 	onlinestream_providers "seanime/internal/onlinestream/providers"
+	"seanime/internal/subtitles"
 	"seanime/internal/torrents/animetosho"
 	"seanime/internal/torrents/nyaa"
 	"seanime/internal/torrents/seadex"
@@ -234,6 +235,23 @@ func LoadExtensions(extensionRepository *extension_repo.Repository, logger *zero
 		},
 	}, seadex.NewProvider(logger))
 
+	//
+	// Built-in subtitle providers
+	//
+
+	extensionRepository.ReloadBuiltInExtension(extension.Extension{
+		ID:          subtitles.LocalProviderID,
+		Name:        subtitles.LocalProviderName,
+		Version:     "",
+		ManifestURI: "builtin",
+		Language:    extension.LanguageGo,
+		Type:        extension.TypeSubtitleProvider,
+		Author:      "Seanime",
+		Description: "Finds subtitles next to the video file and in the folders you choose.",
+		Lang:        "multi",
+		UserConfig:  subtitles.LocalProviderUserConfig(),
+	}, subtitles.NewLocalProvider(logger))
+
 	extensionRepository.ReloadExternalExtensions()
 }
 
@@ -243,6 +261,8 @@ func (a *App) AddExtensionBankToConsumers() {
 		a.MangaRepository,
 		a.OnlinestreamRepository,
 		a.TorrentRepository,
+		a.SubtitleRepository,
+		a.TrackerManager,
 	}
 
 	for _, consumer := range consumers {
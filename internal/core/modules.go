@@ -3,6 +3,7 @@ package core
 import (
 	"runtime"
 	"seanime/internal/api/anilist"
+	"seanime/internal/api/mangaupdates"
 	"seanime/internal/continuity"
 	"seanime/internal/database/db"
 	"seanime/internal/database/db_bridge"
@@ -16,6 +17,12 @@ import (
 	"seanime/internal/library/fillermanager"
 	"seanime/internal/library/playbackmanager"
 	"seanime/internal/manga"
+	manga_autodownloader "seanime/internal/manga/autodownloader"
+	"seanime/internal/manga/opds"
+	manga_pageprocessor "seanime/internal/manga/pageprocessor"
+	manga_readingstate "seanime/internal/manga/readingstate"
+	manga_releasetracker "seanime/internal/manga/releasetracker"
+	"seanime/internal/mediaplayers/kodi"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/mediaplayers/mpchc"
 	"seanime/internal/mediaplayers/mpv"
@@ -28,6 +35,7 @@ import (
 	"seanime/internal/torrent_clients/transmission"
 	"seanime/internal/torrents/torrent"
 	"seanime/internal/torrentstream"
+	"seanime/internal/webhook"
 
 	"github.com/cli/browser"
 	"github.com/rs/zerolog"
@@ -94,13 +102,14 @@ func (a *App) initModulesOnce() {
 
 	// Playback Manager
 	a.PlaybackManager = playbackmanager.New(&playbackmanager.NewPlaybackManagerOptions{
-		Logger:            a.Logger,
-		WSEventManager:    a.WSEventManager,
-		Platform:          a.AnilistPlatform,
-		Database:          a.Database,
-		DiscordPresence:   a.DiscordPresence,
-		IsOffline:         a.IsOffline(),
-		ContinuityManager: a.ContinuityManager,
+		Logger:             a.Logger,
+		WSEventManager:     a.WSEventManager,
+		Platform:           a.AnilistPlatform,
+		Database:           a.Database,
+		DiscordPresence:    a.DiscordPresence,
+		IsOffline:          a.IsOffline(),
+		ContinuityManager:  a.ContinuityManager,
+		SubtitleRepository: a.SubtitleRepository,
 		RefreshAnimeCollectionFunc: func() {
 			_, _ = a.RefreshAnimeCollection()
 		},
@@ -170,12 +179,17 @@ func (a *App) initModulesOnce() {
 	// |  Manga Downloader   |
 	// +---------------------+
 
+	// Pages are processed after being downloaded or proxied, settings are applied in InitOrRefreshModules
+	a.MangaPageProcessor = manga_pageprocessor.NewProcessor()
+
 	a.MangaDownloader = manga.NewDownloader(&manga.NewDownloaderOptions{
 		Database:       a.Database,
 		Logger:         a.Logger,
 		WSEventManager: a.WSEventManager,
 		DownloadDir:    a.Config.Manga.DownloadDir,
 		Repository:     a.MangaRepository,
+		Platform:       a.AnilistPlatform,
+		PageProcessor:  a.MangaPageProcessor,
 	})
 
 	if !a.IsOffline() {
@@ -183,6 +197,92 @@ func (a *App) initModulesOnce() {
 		a.MangaDownloader.Start()
 	}
 
+	// +-------------------------+
+	// |  Manga Auto Downloader  |
+	// +-------------------------+
+
+	a.MangaAutoDownloader = manga_autodownloader.New(&manga_autodownloader.NewAutoDownloaderOptions{
+		Logger:          a.Logger,
+		Database:        a.Database,
+		WSEventManager:  a.WSEventManager,
+		Platform:        a.AnilistPlatform,
+		MangaRepository: a.MangaRepository,
+		MangaDownloader: a.MangaDownloader,
+	})
+
+	if !a.IsOffline() {
+		// This is run in a goroutine
+		a.MangaAutoDownloader.Start()
+	}
+
+	// +-------------------------+
+	// |  Manga Release Tracker  |
+	// +-------------------------+
+
+	a.MangaReleaseTracker = manga_releasetracker.New(&manga_releasetracker.NewReleaseTrackerOptions{
+		Logger:         a.Logger,
+		Database:       a.Database,
+		WSEventManager: a.WSEventManager,
+		Platform:       a.AnilistPlatform,
+		Client:         mangaupdates.NewClient(a.Logger),
+	})
+
+	if !a.IsOffline() {
+		// This is run in a goroutine
+		a.MangaReleaseTracker.Start()
+	}
+
+	// +-----------------------+
+	// |  Manga Reading State  |
+	// +-----------------------+
+
+	a.MangaReadingState = manga_readingstate.NewManager(&manga_readingstate.NewManagerOptions{
+		Logger:   a.Logger,
+		Database: a.Database,
+	})
+
+	// +---------------------+
+	// |    OPDS Catalog     |
+	// +---------------------+
+
+	a.OpdsCatalog = opds.NewCatalog(&opds.NewCatalogOptions{
+		Logger:      a.Logger,
+		Database:    a.Database,
+		Platform:    a.AnilistPlatform,
+		DownloadDir: a.Config.Manga.DownloadDir,
+		LocalDir: func() string {
+			if provider := a.MangaRepository.GetInternalStorageProvider(); provider != nil {
+				return provider.BaseDir()
+			}
+			return ""
+		},
+	})
+
+	// +---------------------+
+	// |      Webhooks       |
+	// +---------------------+
+
+	a.WebhookManager = webhook.NewManager(&webhook.NewManagerOptions{
+		Logger:      a.Logger,
+		Database:    a.Database,
+		HookManager: a.HookManager,
+	})
+	a.WebhookManager.Start()
+
+	a.AddCleanupFunction(func() {
+		a.WebhookManager.Stop()
+	})
+
+	// +---------------------+
+	// |      Trackers       |
+	// +---------------------+
+
+	a.TrackerManager.Start()
+
+	a.AddCleanupFunction(func() {
+		a.TrackerManager.Stop()
+	})
+
 	// +---------------------+
 	// |    Media Stream     |
 	// +---------------------+
@@ -295,6 +395,31 @@ func (a *App) InitOrRefreshModules() {
 		})
 	}
 
+	// Manga downloader storage format
+	if settings.Manga != nil && a.MangaDownloader != nil {
+		a.MangaDownloader.SetStorageFormat(settings.Manga.DownloadFormat)
+	}
+
+	// Manga page processor
+	if settings.Manga != nil && a.MangaPageProcessor != nil {
+		a.MangaPageProcessor.SetSettings(settings.Manga)
+	}
+
+	// Manga auto downloader
+	if settings.Manga != nil && a.MangaAutoDownloader != nil {
+		a.MangaAutoDownloader.SetSettings(settings.Manga)
+	}
+
+	// Manga release tracker
+	if settings.Manga != nil && a.MangaReleaseTracker != nil {
+		a.MangaReleaseTracker.SetSettings(settings.Manga)
+	}
+
+	// Manga local library
+	if settings.Manga != nil && a.MangaLocalLibrary != nil {
+		a.MangaLocalLibrary.SetLibraryDir(settings.Manga.LocalLibraryPath)
+	}
+
 	if settings.MediaPlayer != nil {
 		a.MediaPlayer.VLC = &vlc.VLC{
 			Host:     settings.MediaPlayer.Host,
@@ -310,6 +435,14 @@ func (a *App) InitOrRefreshModules() {
 			Logger: a.Logger,
 		}
 		a.MediaPlayer.Mpv = mpv.New(a.Logger, settings.MediaPlayer.MpvSocket, settings.MediaPlayer.MpvPath)
+		a.MediaPlayer.Kodi = &kodi.Kodi{
+			Host:         settings.MediaPlayer.Host,
+			Port:         settings.MediaPlayer.KodiPort,
+			Username:     settings.MediaPlayer.KodiUsername,
+			Password:     settings.MediaPlayer.KodiPassword,
+			PathMappings: settings.MediaPlayer.KodiPathMappings,
+			Logger:       a.Logger,
+		}
 
 		// Set media player repository
 		a.MediaPlayerRepository = mediaplayer.NewRepository(&mediaplayer.NewRepositoryOptions{
@@ -318,6 +451,7 @@ func (a *App) InitOrRefreshModules() {
 			VLC:               a.MediaPlayer.VLC,
 			MpcHc:             a.MediaPlayer.MpcHc,
 			Mpv:               a.MediaPlayer.Mpv, // Socket
+			Kodi:              a.MediaPlayer.Kodi,
 			WSEventManager:    a.WSEventManager,
 			ContinuityManager: a.ContinuityManager,
 		})
@@ -511,6 +645,16 @@ func (a *App) InitOrRefreshTorrentstreamSettings() {
 			IncludeInLibrary:    false,
 			StreamUrlAddress:    "",
 			SlowSeeding:         false,
+			PrefetchNextEpisode: false,
+			PrefetchThreshold:   70,
+			PrefetchSize:        50,
+			CacheMaxSize:        0,
+			CacheKeepCompleted:  false,
+			DownloadRateLimit:   0,
+			UploadRateLimit:     0,
+			MaxConnections:      0,
+			SeedRatioLimit:      0,
+			SeedTimeLimit:       0,
 		})
 		if err != nil {
 			a.Logger.Error().Err(err).Msg("app: Failed to initialize mediastream module")
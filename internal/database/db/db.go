@@ -84,14 +84,25 @@ func migrateTables(db *gorm.DB) error {
 		&models.ChapterDownloadQueueItem{},
 		&models.TorrentstreamSettings{},
 		&models.TorrentstreamHistory{},
+		&models.TorrentstreamCacheItem{},
 		&models.MediastreamSettings{},
 		&models.MediaFiller{},
 		&models.MangaMapping{},
+		&models.MangaAutoDownloaderRule{},
+		&models.MangaAutoDownloaderItem{},
+		&models.MangaLocalLibrary{},
+		&models.MangaReadingState{},
+		&models.MangaReleaseTracking{},
+		&models.MangaProviderChain{},
 		&models.OnlinestreamMapping{},
 		&models.DebridSettings{},
 		&models.DebridTorrentItem{},
 		&models.PluginData{},
 		&models.UserSession{}, // Added for multi-user session support
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.TrackerSettings{},
+		&models.TrackerSyncItem{},
 		//&models.MangaChapterContainer{},
 	)
 	if err != nil {
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+)
+
+func (db *Database) GetMangaAutoDownloaderItems() ([]*models.MangaAutoDownloaderItem, error) {
+	var res []*models.MangaAutoDownloaderItem
+	err := db.gormdb.Order("id desc").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (db *Database) GetMangaAutoDownloaderItemsByMediaId(mId int) ([]*models.MangaAutoDownloaderItem, error) {
+	var res []*models.MangaAutoDownloaderItem
+	err := db.gormdb.Where("media_id = ?", mId).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (db *Database) InsertMangaAutoDownloaderItem(item *models.MangaAutoDownloaderItem) error {
+	return db.gormdb.Create(item).Error
+}
+
+func (db *Database) DeleteMangaAutoDownloaderItem(id uint) error {
+	return db.gormdb.Delete(&models.MangaAutoDownloaderItem{}, id).Error
+}
+
+// MarkMangaAutoDownloaderItemDownloaded marks the item as downloaded once the chapter is in the download directory.
+func (db *Database) MarkMangaAutoDownloaderItemDownloaded(id uint) error {
+	return db.gormdb.Model(&models.MangaAutoDownloaderItem{}).Where("id = ?", id).Update("downloaded", true).Error
+}
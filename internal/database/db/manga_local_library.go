@@ -0,0 +1,33 @@
+package db
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetMangaLocalLibrary returns the stored local manga library, nil if it was never scanned.
+func (db *Database) GetMangaLocalLibrary() (*models.MangaLocalLibrary, error) {
+	var res models.MangaLocalLibrary
+	err := db.gormdb.Where("id = ?", 1).First(&res).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpsertMangaLocalLibrary stores the local manga library, there is only one entry.
+func (db *Database) UpsertMangaLocalLibrary(value []byte) error {
+	return db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(&models.MangaLocalLibrary{
+		BaseModel: models.BaseModel{ID: 1},
+		Value:     value,
+	}).Error
+}
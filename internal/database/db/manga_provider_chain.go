@@ -0,0 +1,35 @@
+package db
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetMangaProviderChain returns the provider chain of a manga, false if none is set.
+func (db *Database) GetMangaProviderChain(mediaId int) (*models.MangaProviderChain, bool) {
+	var res models.MangaProviderChain
+	err := db.gormdb.Where("media_id = ?", mediaId).First(&res).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			db.Logger.Error().Err(err).Msg("db: Failed to get manga provider chain")
+		}
+		return nil, false
+	}
+	return &res, true
+}
+
+// UpsertMangaProviderChain inserts or updates the provider chain with the same media ID.
+func (db *Database) UpsertMangaProviderChain(chain *models.MangaProviderChain) error {
+	return db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "media_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"providers", "updated_at"}),
+	}).Create(chain).Error
+}
+
+// DeleteMangaProviderChain deletes the provider chain of a manga.
+func (db *Database) DeleteMangaProviderChain(mediaId int) error {
+	return db.gormdb.Where("media_id = ?", mediaId).Delete(&models.MangaProviderChain{}).Error
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+)
+
+// GetMangaReadingStates returns the reading states of a media for a user.
+func (db *Database) GetMangaReadingStates(username string, mediaId int) ([]*models.MangaReadingState, error) {
+	var res []*models.MangaReadingState
+	err := db.gormdb.Where("username = ? AND media_id = ?", username, mediaId).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAllMangaReadingStates returns all the reading states of a user, most recently updated first.
+func (db *Database) GetAllMangaReadingStates(username string) ([]*models.MangaReadingState, error) {
+	var res []*models.MangaReadingState
+	err := db.gormdb.Where("username = ?", username).Order("updated_at DESC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetMangaReadingState returns the reading state of a chapter for a user.
+func (db *Database) GetMangaReadingState(username string, mediaId int, provider string, chapterId string) (*models.MangaReadingState, error) {
+	var res models.MangaReadingState
+	err := db.gormdb.Where("username = ? AND media_id = ? AND provider = ? AND chapter_id = ?", username, mediaId, provider, chapterId).
+		First(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpsertMangaReadingState inserts or updates the reading state with the same user, media, provider and chapter.
+func (db *Database) UpsertMangaReadingState(state *models.MangaReadingState) error {
+	existing, err := db.GetMangaReadingState(state.Username, state.MediaID, state.Provider, state.ChapterID)
+	if err == nil {
+		state.ID = existing.ID
+		state.CreatedAt = existing.CreatedAt
+		return db.gormdb.Save(state).Error
+	}
+
+	return db.gormdb.Create(state).Error
+}
+
+// DeleteMangaReadingStates deletes the reading states of a media for a user.
+func (db *Database) DeleteMangaReadingStates(username string, mediaId int) error {
+	return db.gormdb.Where("username = ? AND media_id = ?", username, mediaId).Delete(&models.MangaReadingState{}).Error
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// GetMangaReleaseTrackings returns the MangaUpdates series cached for each manga.
+func (db *Database) GetMangaReleaseTrackings() ([]*models.MangaReleaseTracking, error) {
+	var res []*models.MangaReleaseTracking
+	err := db.gormdb.Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpsertMangaReleaseTracking inserts or updates the entry with the same media ID.
+func (db *Database) UpsertMangaReleaseTracking(tracking *models.MangaReleaseTracking) error {
+	return db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "media_id"}},
+		UpdateAll: true,
+	}).Create(tracking).Error
+}
+
+// DeleteMangaReleaseTracking deletes the cached series of a manga.
+func (db *Database) DeleteMangaReleaseTracking(mediaId int) error {
+	return db.gormdb.Where("media_id = ?", mediaId).Delete(&models.MangaReleaseTracking{}).Error
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+)
+
+func (db *Database) GetTorrentstreamCacheItems() ([]*models.TorrentstreamCacheItem, error) {
+	var res []*models.TorrentstreamCacheItem
+	err := db.gormdb.Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (db *Database) GetTorrentstreamCacheItem(infoHash string) (*models.TorrentstreamCacheItem, error) {
+	var res models.TorrentstreamCacheItem
+	err := db.gormdb.Where("info_hash = ?", infoHash).First(&res).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpsertTorrentstreamCacheItem inserts or updates the cache item with the same info hash.
+func (db *Database) UpsertTorrentstreamCacheItem(item *models.TorrentstreamCacheItem) error {
+	var existing models.TorrentstreamCacheItem
+	if err := db.gormdb.Where("info_hash = ?", item.InfoHash).First(&existing).Error; err == nil {
+		item.ID = existing.ID
+		item.CreatedAt = existing.CreatedAt
+		return db.gormdb.Save(item).Error
+	}
+
+	return db.gormdb.Create(item).Error
+}
+
+func (db *Database) DeleteTorrentstreamCacheItem(infoHash string) error {
+	return db.gormdb.Where("info_hash = ?", infoHash).Delete(&models.TorrentstreamCacheItem{}).Error
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxTrackerSyncItems = 1000
+
+func (db *Database) GetTrackerSettings() ([]*models.TrackerSettings, error) {
+	var res []*models.TrackerSettings
+	err := db.gormdb.Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpsertTrackerSettings inserts the settings or updates the settings with the same tracker ID.
+func (db *Database) UpsertTrackerSettings(settings *models.TrackerSettings) error {
+	return db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tracker_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(settings).Error
+}
+
+func (db *Database) InsertTrackerSyncItem(item *models.TrackerSyncItem) error {
+	return db.gormdb.Create(item).Error
+}
+
+func (db *Database) UpdateTrackerSyncItem(item *models.TrackerSyncItem) error {
+	return db.gormdb.Save(item).Error
+}
+
+func (db *Database) GetTrackerSyncItem(id uint) (*models.TrackerSyncItem, error) {
+	var res models.TrackerSyncItem
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetUnsentTrackerSyncItem returns the pending or conflicting item of a media for a tracker, or nil if there is none.
+func (db *Database) GetUnsentTrackerSyncItem(trackerId string, mediaId int) (*models.TrackerSyncItem, error) {
+	var res models.TrackerSyncItem
+	err := db.gormdb.Where("tracker_id = ? AND media_id = ? AND status IN ?", trackerId, mediaId, []string{"pending", "conflict"}).
+		Order("id DESC").First(&res).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetTrackerSyncItems returns the latest items, newest first.
+// If status is not empty, only the items with that status are returned.
+func (db *Database) GetTrackerSyncItems(status string, limit int) ([]*models.TrackerSyncItem, error) {
+	var res []*models.TrackerSyncItem
+	query := db.gormdb.Order("id DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDueTrackerSyncItems returns the pending items that should be sent, oldest first.
+func (db *Database) GetDueTrackerSyncItems(now time.Time, limit int) ([]*models.TrackerSyncItem, error) {
+	var res []*models.TrackerSyncItem
+	err := db.gormdb.Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", "pending", now).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TrimTrackerSyncItems deletes the oldest sent items when there are too many.
+func (db *Database) TrimTrackerSyncItems() {
+	go func() {
+		var count int64
+		err := db.gormdb.Model(&models.TrackerSyncItem{}).Count(&count).Error
+		if err != nil {
+			db.Logger.Error().Err(err).Msg("database: Failed to count tracker sync items")
+			return
+		}
+		if count > maxTrackerSyncItems {
+			err = db.gormdb.Delete(&models.TrackerSyncItem{}, "id IN (SELECT id FROM tracker_sync_items WHERE status NOT IN (?, ?) ORDER BY id ASC LIMIT ?)", "pending", "conflict", count-maxTrackerSyncItems).Error
+			if err != nil {
+				db.Logger.Error().Err(err).Msg("database: Failed to delete old tracker sync items")
+				return
+			}
+		}
+	}()
+}
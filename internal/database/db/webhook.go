@@ -0,0 +1,97 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+	"time"
+)
+
+const maxWebhookDeliveries = 1000
+
+func (db *Database) GetWebhooks() ([]*models.Webhook, error) {
+	var res []*models.Webhook
+	err := db.gormdb.Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) GetWebhook(id uint) (*models.Webhook, error) {
+	var res models.Webhook
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SaveWebhook inserts the webhook or updates it if it has an ID.
+func (db *Database) SaveWebhook(webhook *models.Webhook) error {
+	return db.gormdb.Save(webhook).Error
+}
+
+// DeleteWebhook deletes the webhook and its deliveries.
+func (db *Database) DeleteWebhook(id uint) error {
+	err := db.gormdb.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error
+	if err != nil {
+		return err
+	}
+	return db.gormdb.Delete(&models.Webhook{}, id).Error
+}
+
+func (db *Database) InsertWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return db.gormdb.Create(delivery).Error
+}
+
+func (db *Database) UpdateWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return db.gormdb.Save(delivery).Error
+}
+
+func (db *Database) GetWebhookDelivery(id uint) (*models.WebhookDelivery, error) {
+	var res models.WebhookDelivery
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of a webhook, newest first.
+func (db *Database) GetWebhookDeliveries(webhookId uint, limit int) ([]*models.WebhookDelivery, error) {
+	var res []*models.WebhookDelivery
+	err := db.gormdb.Where("webhook_id = ?", webhookId).Order("id DESC").Limit(limit).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDueWebhookDeliveries returns the pending deliveries that should be sent, oldest first.
+func (db *Database) GetDueWebhookDeliveries(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var res []*models.WebhookDelivery
+	err := db.gormdb.Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", "pending", now).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TrimWebhookDeliveries deletes the oldest sent deliveries when there are too many.
+func (db *Database) TrimWebhookDeliveries() {
+	go func() {
+		var count int64
+		err := db.gormdb.Model(&models.WebhookDelivery{}).Count(&count).Error
+		if err != nil {
+			db.Logger.Error().Err(err).Msg("database: Failed to count webhook deliveries")
+			return
+		}
+		if count > maxWebhookDeliveries {
+			err = db.gormdb.Delete(&models.WebhookDelivery{}, "id IN (SELECT id FROM webhook_deliveries WHERE status != ? ORDER BY id ASC LIMIT ?)", "pending", count-maxWebhookDeliveries).Error
+			if err != nil {
+				db.Logger.Error().Err(err).Msg("database: Failed to delete old webhook deliveries")
+				return
+			}
+		}
+	}()
+}
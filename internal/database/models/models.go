@@ -112,10 +112,24 @@ func (o LibraryPaths) Value() (driver.Value, error) {
 type MangaSettings struct {
 	DefaultProvider    string `gorm:"column:default_manga_provider" json:"defaultMangaProvider"`
 	AutoUpdateProgress bool   `gorm:"column:manga_auto_update_progress" json:"mangaAutoUpdateProgress"`
+	// v2.9+
+	DownloadFormat         string `gorm:"column:manga_download_format" json:"mangaDownloadFormat"` // "images" (default) or "cbz"
+	AutoDownloaderEnabled  bool   `gorm:"column:manga_auto_downloader_enabled" json:"mangaAutoDownloaderEnabled"`
+	AutoDownloaderInterval int    `gorm:"column:manga_auto_downloader_interval" json:"mangaAutoDownloaderInterval"` // In minutes
+	LocalLibraryPath       string `gorm:"column:manga_local_library_path" json:"mangaLocalLibraryPath"`
+	ReleaseTrackerEnabled  bool   `gorm:"column:manga_release_tracker_enabled" json:"mangaReleaseTrackerEnabled"`
+	ReleaseTrackerInterval int    `gorm:"column:manga_release_tracker_interval" json:"mangaReleaseTrackerInterval"` // In minutes
+	PageProcessingEnabled  bool   `gorm:"column:manga_page_processing_enabled" json:"mangaPageProcessingEnabled"`
+	PageFormat             string `gorm:"column:manga_page_format" json:"mangaPageFormat"`   // "" (original) or "jpeg"
+	PageQuality            int    `gorm:"column:manga_page_quality" json:"mangaPageQuality"` // JPEG quality, 1-100
+	PageMaxWidth           int    `gorm:"column:manga_page_max_width" json:"mangaPageMaxWidth"`
+	PageSplitDoublePages   bool   `gorm:"column:manga_page_split_double_pages" json:"mangaPageSplitDoublePages"`
+	PageSplitLeftToRight   bool   `gorm:"column:manga_page_split_left_to_right" json:"mangaPageSplitLeftToRight"`
+	PageTrimBorders        bool   `gorm:"column:manga_page_trim_borders" json:"mangaPageTrimBorders"`
 }
 
 type MediaPlayerSettings struct {
-	Default     string `gorm:"column:default_player" json:"defaultPlayer"` // "vlc" or "mpc-hc"
+	Default     string `gorm:"column:default_player" json:"defaultPlayer"` // "vlc", "mpc-hc", "mpv" or "kodi"
 	Host        string `gorm:"column:player_host" json:"host"`
 	VlcUsername string `gorm:"column:vlc_username" json:"vlcUsername"`
 	VlcPassword string `gorm:"column:vlc_password" json:"vlcPassword"`
@@ -125,6 +139,11 @@ type MediaPlayerSettings struct {
 	MpcPath     string `gorm:"column:mpc_path" json:"mpcPath"`
 	MpvSocket   string `gorm:"column:mpv_socket" json:"mpvSocket"`
 	MpvPath     string `gorm:"column:mpv_path" json:"mpvPath"`
+	// v2.9+
+	KodiPort         int    `gorm:"column:kodi_port" json:"kodiPort"`
+	KodiUsername     string `gorm:"column:kodi_username" json:"kodiUsername"`
+	KodiPassword     string `gorm:"column:kodi_password" json:"kodiPassword"`
+	KodiPathMappings string `gorm:"column:kodi_path_mappings" json:"kodiPathMappings"` // "local=remote" pairs separated by new lines
 }
 
 type TorrentSettings struct {
@@ -351,6 +370,25 @@ type TorrentstreamSettings struct {
 	StreamUrlAddress string `gorm:"column:stream_url_address" json:"streamUrlAddress"`
 	// v2.7+
 	SlowSeeding bool `gorm:"column:slow_seeding" json:"slowSeeding"`
+	// v2.9+
+	PrefetchNextEpisode bool `gorm:"column:prefetch_next_episode" json:"prefetchNextEpisode"`
+	PrefetchThreshold   int  `gorm:"column:prefetch_threshold" json:"prefetchThreshold"` // Percentage of the current episode watched before prefetching
+	PrefetchSize        int  `gorm:"column:prefetch_size" json:"prefetchSize"`           // Size in MB of the beginning of the next episode to download
+	CacheMaxSize        int  `gorm:"column:cache_max_size" json:"cacheMaxSize"`          // Maximum size in GB of the download directory, 0 clears the directory on startup
+	CacheKeepCompleted  bool `gorm:"column:cache_keep_completed" json:"cacheKeepCompleted"`
+	// Bandwidth
+	DownloadRateLimit          int    `gorm:"column:download_rate_limit" json:"downloadRateLimit"` // KiB/s, 0 means unlimited
+	UploadRateLimit            int    `gorm:"column:upload_rate_limit" json:"uploadRateLimit"`     // KiB/s, 0 means unlimited
+	MaxConnections             int    `gorm:"column:max_connections" json:"maxConnections"`        // Maximum connections per torrent, 0 means default
+	BandwidthScheduleEnabled   bool   `gorm:"column:bandwidth_schedule_enabled" json:"bandwidthScheduleEnabled"`
+	BandwidthScheduleStart     string `gorm:"column:bandwidth_schedule_start" json:"bandwidthScheduleStart"` // e.g. "09:00"
+	BandwidthScheduleEnd       string `gorm:"column:bandwidth_schedule_end" json:"bandwidthScheduleEnd"`     // e.g. "17:00"
+	BandwidthScheduleDays      string `gorm:"column:bandwidth_schedule_days" json:"bandwidthScheduleDays"`   // Comma-separated days of the week (0 is Sunday), empty means every day
+	ScheduledDownloadRateLimit int    `gorm:"column:scheduled_download_rate_limit" json:"scheduledDownloadRateLimit"`
+	ScheduledUploadRateLimit   int    `gorm:"column:scheduled_upload_rate_limit" json:"scheduledUploadRateLimit"`
+	// Seeding
+	SeedRatioLimit float64 `gorm:"column:seed_ratio_limit" json:"seedRatioLimit"` // Keep seeding streamed torrents until this ratio is reached, 0 means no ratio target
+	SeedTimeLimit  int     `gorm:"column:seed_time_limit" json:"seedTimeLimit"`   // Keep seeding streamed torrents for this many minutes, 0 means no time target
 }
 
 type TorrentstreamHistory struct {
@@ -359,6 +397,18 @@ type TorrentstreamHistory struct {
 	Torrent []byte `gorm:"column:torrent" json:"torrent"`
 }
 
+// TorrentstreamCacheItem stores information about a torrent in the torrent streaming download directory.
+type TorrentstreamCacheItem struct {
+	BaseModel
+	InfoHash       string    `gorm:"column:info_hash;uniqueIndex" json:"infoHash"`
+	Name           string    `gorm:"column:name" json:"name"`
+	MediaId        int       `gorm:"column:media_id" json:"mediaId"`
+	EpisodeNumber  int       `gorm:"column:episode_number" json:"episodeNumber"`
+	FilePath       string    `gorm:"column:file_path" json:"filePath"`  // Path of the streamed file, relative to the torrent directory
+	Completed      bool      `gorm:"column:completed" json:"completed"` // Whether the streamed file was fully downloaded
+	LastStreamedAt time.Time `gorm:"column:last_streamed_at" json:"lastStreamedAt"`
+}
+
 // +---------------------+
 // |        Filler       |
 // +---------------------+
@@ -383,6 +433,73 @@ type MangaMapping struct {
 	MangaID  string `gorm:"column:manga_id" json:"mangaId"` // ID from search result, used to fetch chapters
 }
 
+type MangaAutoDownloaderRule struct {
+	BaseModel
+	Value []byte `gorm:"column:value" json:"value"`
+}
+
+type MangaAutoDownloaderItem struct {
+	BaseModel
+	RuleID        uint   `gorm:"column:rule_id" json:"ruleId"`
+	MediaID       int    `gorm:"column:media_id" json:"mediaId"`
+	Provider      string `gorm:"column:provider" json:"provider"`
+	ChapterID     string `gorm:"column:chapter_id" json:"chapterId"`
+	ChapterNumber string `gorm:"column:chapter_number" json:"chapterNumber"`
+	Scanlator     string `gorm:"column:scanlator" json:"scanlator"`
+	Language      string `gorm:"column:language" json:"language"`
+	Downloaded    bool   `gorm:"column:downloaded" json:"downloaded"`
+}
+
+// MangaLocalLibrary stores the chapters found by the local manga library scanner as JSON.
+type MangaLocalLibrary struct {
+	BaseModel
+	Value []byte `gorm:"column:value" json:"value"`
+}
+
+// MangaReadingState stores the reading state of a chapter for a user.
+// There is only one entry per user, media, provider and chapter.
+type MangaReadingState struct {
+	BaseModel
+	Username      string     `gorm:"column:username;index:idx_manga_reading_state" json:"username"`
+	MediaID       int        `gorm:"column:media_id;index:idx_manga_reading_state" json:"mediaId"`
+	Provider      string     `gorm:"column:provider" json:"provider"`
+	ChapterID     string     `gorm:"column:chapter_id" json:"chapterId"`
+	ChapterNumber string     `gorm:"column:chapter_number" json:"chapterNumber"`
+	CurrentPage   int        `gorm:"column:current_page" json:"currentPage"` // Index of the last page read, starting from 0
+	TotalPages    int        `gorm:"column:total_pages" json:"totalPages"`
+	Read          bool       `gorm:"column:read" json:"read"`
+	ReadAt        *time.Time `gorm:"column:read_at" json:"readAt"`
+}
+
+// MangaReleaseTracking caches the MangaUpdates series of a manga and its latest release.
+// SeriesID is 0 if no series was found, the media is searched again after some time.
+type MangaReleaseTracking struct {
+	BaseModel
+	MediaID           int        `gorm:"column:media_id;uniqueIndex" json:"mediaId"`
+	SeriesID          int64      `gorm:"column:series_id" json:"seriesId"`
+	SeriesTitle       string     `gorm:"column:series_title" json:"seriesTitle"`
+	SeriesURL         string     `gorm:"column:series_url" json:"seriesUrl"`
+	LatestChapter     float64    `gorm:"column:latest_chapter" json:"latestChapter"`
+	LatestGroup       string     `gorm:"column:latest_group" json:"latestGroup"`
+	LatestReleaseDate string     `gorm:"column:latest_release_date" json:"latestReleaseDate"`
+	CheckedAt         *time.Time `gorm:"column:checked_at" json:"checkedAt"`
+}
+
+// MangaProviderChain stores the providers to fall back to, in order, when a provider fails for a manga.
+type MangaProviderChain struct {
+	BaseModel
+	MediaID   int    `gorm:"column:media_id;uniqueIndex" json:"mediaId"`
+	Providers string `gorm:"column:providers" json:"providers"` // Comma-separated provider IDs
+}
+
+// GetProviders returns the provider IDs of the chain in order.
+func (c *MangaProviderChain) GetProviders() []string {
+	if c.Providers == "" {
+		return []string{}
+	}
+	return strings.Split(c.Providers, ",")
+}
+
 type MangaChapterContainer struct {
 	BaseModel
 	Provider  string `gorm:"column:provider" json:"provider"`
@@ -434,3 +551,67 @@ type PluginData struct {
 	PluginID string `gorm:"column:plugin_id;index" json:"pluginId"`
 	Data     []byte `gorm:"column:data" json:"data"`
 }
+
+// +---------------------+
+// |      Webhooks       |
+// +---------------------+
+
+// Webhook sends the hook events it subscribes to, to a URL.
+type Webhook struct {
+	BaseModel
+	Name     string `gorm:"column:name" json:"name"`
+	Enabled  bool   `gorm:"column:enabled" json:"enabled"`
+	URL      string `gorm:"column:url" json:"url"`
+	Events   string `gorm:"column:events" json:"events"`     // Comma-separated event names, e.g. "scanCompleted,autoDownloaderAfterDownloadTorrent"
+	Secret   string `gorm:"column:secret" json:"secret"`     // Optional, used to sign the body with HMAC-SHA256
+	Template string `gorm:"column:template" json:"template"` // Optional Go template of the body
+	Headers  string `gorm:"column:headers" json:"headers"`   // Optional additional headers, one "Key: Value" per line
+}
+
+// GetEvents returns the event names the webhook subscribes to.
+func (w *Webhook) GetEvents() []string {
+	if w.Events == "" {
+		return []string{}
+	}
+	return strings.Split(w.Events, ",")
+}
+
+// WebhookDelivery is a webhook request, queued or sent.
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID      uint       `gorm:"column:webhook_id;index" json:"webhookId"`
+	Event          string     `gorm:"column:event" json:"event"`
+	Body           string     `gorm:"column:body" json:"body"`
+	ContentType    string     `gorm:"column:content_type" json:"contentType"`
+	Status         string     `gorm:"column:status;index" json:"status"` // "pending", "success" or "failed"
+	Attempts       int        `gorm:"column:attempts" json:"attempts"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+	ResponseStatus int        `gorm:"column:response_status" json:"responseStatus"`
+	Response       string     `gorm:"column:response" json:"response"` // Truncated response body
+	Error          string     `gorm:"column:error" json:"error"`
+}
+
+// +---------------------+
+// |      Trackers       |
+// +---------------------+
+
+// TrackerSettings holds the settings of a tracker extension.
+type TrackerSettings struct {
+	BaseModel
+	TrackerID string `gorm:"column:tracker_id;uniqueIndex" json:"trackerId"`
+	Enabled   bool   `gorm:"column:enabled" json:"enabled"`
+}
+
+// TrackerSyncItem is a list entry change mirrored to a tracker, queued or sent.
+type TrackerSyncItem struct {
+	BaseModel
+	TrackerID     string     `gorm:"column:tracker_id;index" json:"trackerId"`
+	MediaID       int        `gorm:"column:media_id;index" json:"mediaId"`
+	Changes       []byte     `gorm:"column:changes" json:"changes"`     // JSON-encoded changes
+	Status        string     `gorm:"column:status;index" json:"status"` // "pending", "success", "failed", "conflict" or "skipped"
+	Attempts      int        `gorm:"column:attempts" json:"attempts"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+	Force         bool       `gorm:"column:force" json:"force"`   // Overwrite the tracker entry even if it conflicts
+	Remote        []byte     `gorm:"column:remote" json:"remote"` // JSON-encoded tracker entry, set when the change conflicts
+	Error         string     `gorm:"column:error" json:"error"`
+}
@@ -2,6 +2,8 @@
 package events
 
 const (
+	AddExtensionTrustedKeyEndpoint                     = "EXTENSIONS-add-extension-trusted-key"
+	AddMarketplaceRepositoryEndpoint                   = "EXTENSIONS-add-marketplace-repository"
 	AddUnknownMediaEndpoint                            = "ANIME-COLLECTION-add-unknown-media"
 	AnilistListAnimeEndpoint                           = "ANILIST-anilist-list-anime"
 	AnilistListMangaEndpoint                           = "MANGA-anilist-list-manga"
@@ -9,11 +11,16 @@ const (
 	AnilistListRecentAiringAnimeEndpoint               = "ANILIST-anilist-list-recent-airing-anime"
 	AnimeEntryBulkActionEndpoint                       = "ANIME-ENTRIES-anime-entry-bulk-action"
 	AnimeEntryManualMatchEndpoint                      = "ANIME-ENTRIES-anime-entry-manual-match"
+	ApplyMangaMihonImportEndpoint                      = "MANGA-MIHON-IMPORT-apply-manga-mihon-import"
 	CancelDiscordActivityEndpoint                      = "DISCORD-cancel-discord-activity"
 	CheckSessionEndpoint                               = "AUTH-check-session"
 	ClearAllChapterDownloadQueueEndpoint               = "MANGA-DOWNLOAD-clear-all-chapter-download-queue"
 	ClearFileCacheMediastreamVideoFilesEndpoint        = "FILECACHE-clear-file-cache-mediastream-video-files"
+	ClearTorrentstreamCacheEndpoint                    = "TORRENTSTREAM-clear-torrentstream-cache"
+	ConvertMangaDownloadsEndpoint                      = "MANGA-DOWNLOAD-convert-manga-downloads"
 	CreateAutoDownloaderRuleEndpoint                   = "AUTO-DOWNLOADER-create-auto-downloader-rule"
+	CreateMangaAutoDownloaderRuleEndpoint              = "MANGA-AUTO-DOWNLOADER-create-manga-auto-downloader-rule"
+	CreateOpdsTokenEndpoint                            = "OPDS-create-opds-token"
 	CreatePlaylistEndpoint                             = "PLAYLIST-create-playlist"
 	DebridAddTorrentsEndpoint                          = "DEBRID-debrid-add-torrents"
 	DebridCancelDownloadEndpoint                       = "DEBRID-debrid-cancel-download"
@@ -29,17 +36,24 @@ const (
 	DeleteAutoDownloaderRuleEndpoint                   = "AUTO-DOWNLOADER-delete-auto-downloader-rule"
 	DeleteLocalFilesEndpoint                           = "LOCALFILES-delete-local-files"
 	DeleteLogsEndpoint                                 = "STATUS-delete-logs"
+	DeleteMangaAutoDownloaderItemEndpoint              = "MANGA-AUTO-DOWNLOADER-delete-manga-auto-downloader-item"
+	DeleteMangaAutoDownloaderRuleEndpoint              = "MANGA-AUTO-DOWNLOADER-delete-manga-auto-downloader-rule"
 	DeleteMangaDownloadedChaptersEndpoint              = "MANGA-DOWNLOAD-delete-manga-downloaded-chapters"
 	DeletePlaylistEndpoint                             = "PLAYLIST-delete-playlist"
+	DeleteSubtitleTrackEndpoint                        = "SUBTITLES-delete-subtitle-track"
+	DeleteWebhookEndpoint                              = "WEBHOOKS-delete-webhook"
 	DirectorySelectorEndpoint                          = "DIRECTORY-SELECTOR-directory-selector"
 	DownloadIssueReportEndpoint                        = "REPORT-download-issue-report"
 	DownloadMangaChaptersEndpoint                      = "MANGA-DOWNLOAD-download-manga-chapters"
 	DownloadReleaseEndpoint                            = "DOWNLOAD-download-release"
+	DownloadSubtitleEndpoint                           = "SUBTITLES-download-subtitle"
 	DownloadTorrentFileEndpoint                        = "DOWNLOAD-download-torrent-file"
 	EditAnilistListEntryEndpoint                       = "ANILIST-edit-anilist-list-entry"
 	EditMALListEntryProgressEndpoint                   = "MAL-edit-mal-list-entry-progress"
 	EmptyMangaEntryCacheEndpoint                       = "MANGA-empty-manga-entry-cache"
 	EmptyTVDBEpisodesEndpoint                          = "METADATA-empty-tvdb-episodes"
+	EnableExtensionRuntimeEndpoint                     = "EXTENSIONS-enable-extension-runtime"
+	ExportMangaDownloadedChaptersEndpoint              = "MANGA-DOWNLOAD-export-manga-downloaded-chapters"
 	FetchAnimeEntrySuggestionsEndpoint                 = "ANIME-ENTRIES-fetch-anime-entry-suggestions"
 	FetchExternalExtensionDataEndpoint                 = "EXTENSIONS-fetch-external-extension-data"
 	GetActiveTorrentListEndpoint                       = "TORRENT-CLIENT-get-active-torrent-list"
@@ -63,8 +77,11 @@ const (
 	GetDebridSettingsEndpoint                          = "DEBRID-get-debrid-settings"
 	GetDocsEndpoint                                    = "DOCS-get-docs"
 	GetExtensionPayloadEndpoint                        = "EXTENSIONS-get-extension-payload"
+	GetExtensionRuntimeMetricsEndpoint                 = "EXTENSIONS-get-extension-runtime-metrics"
+	GetExtensionTrustedKeysEndpoint                    = "EXTENSIONS-get-extension-trusted-keys"
 	GetExtensionUpdateDataEndpoint                     = "EXTENSIONS-get-extension-update-data"
 	GetExtensionUserConfigEndpoint                     = "EXTENSIONS-get-extension-user-config"
+	GetExtensionVersionsEndpoint                       = "EXTENSIONS-get-extension-versions"
 	GetFileCacheMediastreamVideoFilesTotalSizeEndpoint = "FILECACHE-get-file-cache-mediastream-video-files-total-size"
 	GetFileCacheTotalSizeEndpoint                      = "FILECACHE-get-file-cache-total-size"
 	GetLatestLogContentEndpoint                        = "STATUS-get-latest-log-content"
@@ -72,7 +89,11 @@ const (
 	GetLibraryCollectionEndpoint                       = "ANIME-COLLECTION-get-library-collection"
 	GetLocalFilesEndpoint                              = "LOCALFILES-get-local-files"
 	GetLogFilenamesEndpoint                            = "STATUS-get-log-filenames"
+	GetMangaAutoDownloaderItemsEndpoint                = "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-items"
+	GetMangaAutoDownloaderRulesEndpoint                = "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-rules"
+	GetMangaAutoDownloaderRulesByMangaEndpoint         = "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-rules-by-manga"
 	GetMangaCollectionEndpoint                         = "MANGA-get-manga-collection"
+	GetMangaContinueReadingEndpoint                    = "MANGA-READING-STATE-get-manga-continue-reading"
 	GetMangaDownloadDataEndpoint                       = "MANGA-DOWNLOAD-get-manga-download-data"
 	GetMangaDownloadQueueEndpoint                      = "MANGA-DOWNLOAD-get-manga-download-queue"
 	GetMangaDownloadsListEndpoint                      = "MANGA-DOWNLOAD-get-manga-downloads-list"
@@ -82,8 +103,15 @@ const (
 	GetMangaEntryDownloadedChaptersEndpoint            = "MANGA-get-manga-entry-downloaded-chapters"
 	GetMangaEntryPagesEndpoint                         = "MANGA-get-manga-entry-pages"
 	GetMangaLatestChapterNumbersMapEndpoint            = "MANGA-get-manga-latest-chapter-numbers-map"
+	GetMangaLocalLibraryEndpoint                       = "MANGA-LOCAL-LIBRARY-get-manga-local-library"
 	GetMangaMappingEndpoint                            = "MANGA-get-manga-mapping"
+	GetMangaMergedChaptersEndpoint                     = "MANGA-get-manga-merged-chapters"
+	GetMangaProviderChainEndpoint                      = "MANGA-PROVIDER-CHAIN-get-manga-provider-chain"
+	GetMangaProviderHealthEndpoint                     = "MANGA-PROVIDER-CHAIN-get-manga-provider-health"
+	GetMangaReadingStateEndpoint                       = "MANGA-READING-STATE-get-manga-reading-state"
+	GetMangaReleasesEndpoint                           = "MANGA-RELEASE-TRACKER-get-manga-releases"
 	GetMarketplaceExtensionsEndpoint                   = "EXTENSIONS-get-marketplace-extensions"
+	GetMarketplaceRepositoriesEndpoint                 = "EXTENSIONS-get-marketplace-repositories"
 	GetMediastreamSettingsEndpoint                     = "MEDIASTREAM-get-mediastream-settings"
 	GetMissingEpisodesEndpoint                         = "ANIME-ENTRIES-get-missing-episodes"
 	GetOnlineStreamEpisodeListEndpoint                 = "ONLINESTREAM-get-online-stream-episode-list"
@@ -97,11 +125,20 @@ const (
 	GetScanSummariesEndpoint                           = "SCAN-SUMMARY-get-scan-summaries"
 	GetSettingsEndpoint                                = "SETTINGS-get-settings"
 	GetStatusEndpoint                                  = "STATUS-get-status"
+	GetSubtitleTracksEndpoint                          = "SUBTITLES-get-subtitle-tracks"
 	GetThemeEndpoint                                   = "THEME-get-theme"
 	GetTorrentstreamBatchHistoryEndpoint               = "TORRENTSTREAM-get-torrentstream-batch-history"
+	GetTorrentstreamCacheEndpoint                      = "TORRENTSTREAM-get-torrentstream-cache"
 	GetTorrentstreamEpisodeCollectionEndpoint          = "TORRENTSTREAM-get-torrentstream-episode-collection"
+	GetTorrentstreamSeedingEndpoint                    = "TORRENTSTREAM-get-torrentstream-seeding"
 	GetTorrentstreamSettingsEndpoint                   = "TORRENTSTREAM-get-torrentstream-settings"
 	GetTorrentstreamTorrentFilePreviewsEndpoint        = "TORRENTSTREAM-get-torrentstream-torrent-file-previews"
+	GetTrackerConflictsEndpoint                        = "TRACKERS-get-tracker-conflicts"
+	GetTrackerSyncItemsEndpoint                        = "TRACKERS-get-tracker-sync-items"
+	GetTrackersEndpoint                                = "TRACKERS-get-trackers"
+	GetWebhookDeliveriesEndpoint                       = "WEBHOOKS-get-webhook-deliveries"
+	GetWebhookEventsEndpoint                           = "WEBHOOKS-get-webhook-events"
+	GetWebhooksEndpoint                                = "WEBHOOKS-get-webhooks"
 	GettingStartedEndpoint                             = "SETTINGS-getting-started"
 	GrantPluginPermissionsEndpoint                     = "EXTENSIONS-grant-plugin-permissions"
 	ImportLocalFilesEndpoint                           = "LOCALFILES-import-local-files"
@@ -112,6 +149,8 @@ const (
 	ListExtensionDataEndpoint                          = "EXTENSIONS-list-extension-data"
 	ListMangaProviderExtensionsEndpoint                = "EXTENSIONS-list-manga-provider-extensions"
 	ListOnlinestreamProviderExtensionsEndpoint         = "EXTENSIONS-list-onlinestream-provider-extensions"
+	ListSubtitleProviderExtensionsEndpoint             = "EXTENSIONS-list-subtitle-provider-extensions"
+	ListTrackerExtensionsEndpoint                      = "EXTENSIONS-list-tracker-extensions"
 	LocalFileBulkActionEndpoint                        = "LOCALFILES-local-file-bulk-action"
 	LoginEndpoint                                      = "AUTH-login"
 	LogoutEndpoint                                     = "AUTH-logout"
@@ -119,12 +158,15 @@ const (
 	MALLogoutEndpoint                                  = "MAL-mal-logout"
 	MangaManualMappingEndpoint                         = "MANGA-manga-manual-mapping"
 	MangaManualSearchEndpoint                          = "MANGA-manga-manual-search"
+	MarkMangaChaptersEndpoint                          = "MANGA-READING-STATE-mark-manga-chapters"
+	MatchMangaLocalLibrarySeriesEndpoint               = "MANGA-LOCAL-LIBRARY-match-manga-local-library-series"
 	MediastreamShutdownTranscodeStreamEndpoint         = "MEDIASTREAM-mediastream-shutdown-transcode-stream"
 	OnlineStreamEmptyCacheEndpoint                     = "ONLINESTREAM-online-stream-empty-cache"
 	OnlinestreamManualMappingEndpoint                  = "ONLINESTREAM-onlinestream-manual-mapping"
 	OnlinestreamManualSearchEndpoint                   = "ONLINESTREAM-onlinestream-manual-search"
 	OpenAnimeEntryInExplorerEndpoint                   = "ANIME-ENTRIES-open-anime-entry-in-explorer"
 	OpenInExplorerEndpoint                             = "EXPLORER-open-in-explorer"
+	PinExtensionEndpoint                               = "EXTENSIONS-pin-extension"
 	PlaybackAutoPlayNextEpisodeEndpoint                = "PLAYBACK-MANAGER-playback-auto-play-next-episode"
 	PlaybackCancelCurrentPlaylistEndpoint              = "PLAYBACK-MANAGER-playback-cancel-current-playlist"
 	PlaybackCancelManualTrackingEndpoint               = "PLAYBACK-MANAGER-playback-cancel-manual-tracking"
@@ -139,34 +181,53 @@ const (
 	PopulateFillerDataEndpoint                         = "METADATA-populate-filler-data"
 	PopulateTVDBEpisodesEndpoint                       = "METADATA-populate-tvdb-episodes"
 	PreloadMediastreamMediaContainerEndpoint           = "MEDIASTREAM-preload-mediastream-media-container"
+	PreviewMangaMihonImportEndpoint                    = "MANGA-MIHON-IMPORT-preview-manga-mihon-import"
+	RedeliverWebhookDeliveryEndpoint                   = "WEBHOOKS-redeliver-webhook-delivery"
 	RefetchMangaChapterContainersEndpoint              = "MANGA-refetch-manga-chapter-containers"
 	ReloadExternalExtensionEndpoint                    = "EXTENSIONS-reload-external-extension"
 	ReloadExternalExtensionsEndpoint                   = "EXTENSIONS-reload-external-extensions"
 	RemoveEmptyDirectoriesEndpoint                     = "LOCALFILES-remove-empty-directories"
+	RemoveExtensionTrustedKeyEndpoint                  = "EXTENSIONS-remove-extension-trusted-key"
 	RemoveFileCacheBucketEndpoint                      = "FILECACHE-remove-file-cache-bucket"
 	RemoveFillerDataEndpoint                           = "METADATA-remove-filler-data"
 	RemoveMangaMappingEndpoint                         = "MANGA-remove-manga-mapping"
+	RemoveMarketplaceRepositoryEndpoint                = "EXTENSIONS-remove-marketplace-repository"
 	RemoveOnlinestreamMappingEndpoint                  = "ONLINESTREAM-remove-onlinestream-mapping"
+	RemoveTorrentstreamCacheItemEndpoint               = "TORRENTSTREAM-remove-torrentstream-cache-item"
 	RequestMediastreamMediaContainerEndpoint           = "MEDIASTREAM-request-mediastream-media-container"
 	ResetErroredChapterDownloadQueueEndpoint           = "MANGA-DOWNLOAD-reset-errored-chapter-download-queue"
+	ResetMangaReadingStateEndpoint                     = "MANGA-READING-STATE-reset-manga-reading-state"
+	ResolveTrackerConflictEndpoint                     = "TRACKERS-resolve-tracker-conflict"
+	RetryTrackerSyncItemEndpoint                       = "TRACKERS-retry-tracker-sync-item"
+	RollbackExtensionEndpoint                          = "EXTENSIONS-rollback-extension"
 	RunAutoDownloaderEndpoint                          = "AUTO-DOWNLOADER-run-auto-downloader"
+	RunExtensionHarnessEndpoint                        = "EXTENSIONS-run-extension-harness"
 	RunExtensionPlaygroundCodeEndpoint                 = "EXTENSIONS-run-extension-playground-code"
+	RunMangaAutoDownloaderEndpoint                     = "MANGA-AUTO-DOWNLOADER-run-manga-auto-downloader"
+	RunMangaReleaseTrackerEndpoint                     = "MANGA-RELEASE-TRACKER-run-manga-release-tracker"
 	SaveAutoDownloaderSettingsEndpoint                 = "SETTINGS-save-auto-downloader-settings"
 	SaveDebridSettingsEndpoint                         = "DEBRID-save-debrid-settings"
 	SaveExtensionUserConfigEndpoint                    = "EXTENSIONS-save-extension-user-config"
 	SaveIssueReportEndpoint                            = "REPORT-save-issue-report"
+	SaveMangaProviderChainEndpoint                     = "MANGA-PROVIDER-CHAIN-save-manga-provider-chain"
 	SaveMediastreamSettingsEndpoint                    = "MEDIASTREAM-save-mediastream-settings"
 	SaveSettingsEndpoint                               = "SETTINGS-save-settings"
 	SaveTorrentstreamSettingsEndpoint                  = "TORRENTSTREAM-save-torrentstream-settings"
+	SaveWebhookEndpoint                                = "WEBHOOKS-save-webhook"
 	ScanLocalFilesEndpoint                             = "SCAN-scan-local-files"
+	ScanMangaLocalLibraryEndpoint                      = "MANGA-LOCAL-LIBRARY-scan-manga-local-library"
+	SearchSubtitlesEndpoint                            = "SUBTITLES-search-subtitles"
 	SearchTorrentEndpoint                              = "TORRENT-SEARCH-search-torrent"
 	SetDiscordAnimeActivityWithProgressEndpoint        = "DISCORD-set-discord-anime-activity-with-progress"
 	SetDiscordLegacyAnimeActivityEndpoint              = "DISCORD-set-discord-legacy-anime-activity"
 	SetDiscordMangaActivityEndpoint                    = "DISCORD-set-discord-manga-activity"
+	SetExtensionChannelEndpoint                        = "EXTENSIONS-set-extension-channel"
 	SetPluginSettingsPinnedTraysEndpoint               = "EXTENSIONS-set-plugin-settings-pinned-trays"
+	SetTrackerEnabledEndpoint                          = "TRACKERS-set-tracker-enabled"
 	StartDefaultMediaPlayerEndpoint                    = "MEDIAPLAYER-start-default-media-player"
 	StartMangaDownloadQueueEndpoint                    = "MANGA-DOWNLOAD-start-manga-download-queue"
 	StopMangaDownloadQueueEndpoint                     = "MANGA-DOWNLOAD-stop-manga-download-queue"
+	StopTorrentstreamSeedingEndpoint                   = "TORRENTSTREAM-stop-torrentstream-seeding"
 	SyncAddMediaEndpoint                               = "SYNC-sync-add-media"
 	SyncAnilistDataEndpoint                            = "SYNC-sync-anilist-data"
 	SyncGetHasLocalChangesEndpoint                     = "SYNC-sync-get-has-local-changes"
@@ -178,6 +239,7 @@ const (
 	SyncRemoveMediaEndpoint                            = "SYNC-sync-remove-media"
 	SyncSetHasLocalChangesEndpoint                     = "SYNC-sync-set-has-local-changes"
 	TestDumpEndpoint                                   = "MANUAL-DUMP-test-dump"
+	TestWebhookEndpoint                                = "WEBHOOKS-test-webhook"
 	ToggleAnimeEntrySilenceStatusEndpoint              = "ANIME-ENTRIES-toggle-anime-entry-silence-status"
 	TorrentClientActionEndpoint                        = "TORRENT-CLIENT-torrent-client-action"
 	TorrentClientAddMagnetFromRuleEndpoint             = "TORRENT-CLIENT-torrent-client-add-magnet-from-rule"
@@ -194,7 +256,9 @@ const (
 	UpdateExtensionCodeEndpoint                        = "EXTENSIONS-update-extension-code"
 	UpdateLocalFileDataEndpoint                        = "LOCALFILES-update-local-file-data"
 	UpdateLocalFilesEndpoint                           = "LOCALFILES-update-local-files"
+	UpdateMangaAutoDownloaderRuleEndpoint              = "MANGA-AUTO-DOWNLOADER-update-manga-auto-downloader-rule"
 	UpdateMangaProgressEndpoint                        = "MANGA-update-manga-progress"
+	UpdateMangaReadingPageEndpoint                     = "MANGA-READING-STATE-update-manga-reading-page"
 	UpdatePlaylistEndpoint                             = "PLAYLIST-update-playlist"
 	UpdateThemeEndpoint                                = "THEME-update-theme"
 )
@@ -20,6 +20,8 @@ const (
 	TypeAnimeTorrentProvider Type = "anime-torrent-provider"
 	TypeMangaProvider        Type = "manga-provider"
 	TypeOnlinestreamProvider Type = "onlinestream-provider"
+	TypeSubtitleProvider     Type = "subtitle-provider"
+	TypeTracker              Type = "tracker"
 	TypePlugin               Type = "plugin"
 )
 
@@ -27,6 +29,9 @@ const (
 	LanguageJavascript Language = "javascript"
 	LanguageTypescript Language = "typescript"
 	LanguageGo         Language = "go"
+	// LanguageWasm is a WebAssembly module implementing the host ABI, see extension_repo/WASM.md.
+	// The payload is the base64-encoded module.
+	LanguageWasm Language = "wasm"
 )
 
 type Extension struct {
@@ -57,8 +62,11 @@ type Extension struct {
 	Lang string `json:"lang"`
 	// List of permissions asked by the extension.
 	// The user must grant these permissions before the extension can be loaded.
-	Permissions []string    `json:"permissions,omitempty"` // NOT IMPLEMENTED
-	UserConfig  *UserConfig `json:"userConfig,omitempty"`
+	Permissions []string `json:"permissions,omitempty"` // NOT IMPLEMENTED
+	// Network restricts the hosts the extension can reach.
+	// The user must grant these permissions before the extension can be loaded.
+	Network    *NetworkPermissions `json:"network,omitempty"`
+	UserConfig *UserConfig         `json:"userConfig,omitempty"`
 	// Payload is the content of the extension.
 	Payload string `json:"payload"`
 	// PayloadURI is the URI to the extension payload.
@@ -67,6 +75,17 @@ type Extension struct {
 	PayloadURI string `json:"payloadURI,omitempty"`
 	// Plugin is the manifest of the extension if it is a plugin.
 	Plugin *PluginManifest `json:"plugin,omitempty"`
+	// Channels maps the update channels of the extension to their manifest URI, e.g. {"beta": "https://.../beta/manifest.json"}.
+	// Extensions follow ManifestURI until the user selects a channel.
+	Channels map[string]string `json:"channels,omitempty"`
+	// Signature is the publisher's signature of the manifest and payload.
+	// Extensions with an invalid signature, or whose signing key changed since install, are refused.
+	Signature *Signature `json:"signature,omitempty"`
+	// SignatureStatus is set by the repository when listing extensions, it's not part of the manifest.
+	SignatureStatus SignatureStatus `json:"signatureStatus,omitempty"`
+	// Repository is the URL of the marketplace repository the extension was listed in or installed from.
+	// It is set by the repository, it's not part of the manifest.
+	Repository string `json:"repository,omitempty"`
 
 	// IsDevelopment is true if the extension is in development mode.
 	// If true, the extension code will be loaded from PayloadURI and allow you to edit the code from an editor and reload the extension without restarting the application.
@@ -94,6 +113,8 @@ type BaseExtension interface {
 	GetIcon() string
 	GetWebsite() string
 	GetPermissions() []string
+	GetNetworkPermissions() *NetworkPermissions
+	GetSignature() *Signature
 	GetUserConfig() *UserConfig
 	GetSavedUserConfig() *SavedUserConfig
 	GetIsDevelopment() bool
@@ -104,7 +125,7 @@ type Configurable interface {
 }
 
 func ToExtensionData(ext BaseExtension) *Extension {
-	return &Extension{
+	ret := &Extension{
 		ID:              ext.GetID(),
 		Name:            ext.GetName(),
 		Version:         ext.GetVersion(),
@@ -115,6 +136,8 @@ func ToExtensionData(ext BaseExtension) *Extension {
 		Description:     ext.GetDescription(),
 		Author:          ext.GetAuthor(),
 		Permissions:     ext.GetPermissions(),
+		Network:         ext.GetNetworkPermissions(),
+		Signature:       ext.GetSignature(),
 		UserConfig:      ext.GetUserConfig(),
 		Icon:            ext.GetIcon(),
 		Website:         ext.GetWebsite(),
@@ -123,6 +146,15 @@ func ToExtensionData(ext BaseExtension) *Extension {
 		IsDevelopment:   ext.GetIsDevelopment(),
 		SavedUserConfig: ext.GetSavedUserConfig(),
 	}
+
+	// The rest of the manifest is needed to verify the signature
+	if e, ok := ext.(interface{ GetExtension() *Extension }); ok && e.GetExtension() != nil {
+		ret.SemverConstraint = e.GetExtension().SemverConstraint
+		ret.Plugin = e.GetExtension().Plugin
+		ret.Channels = e.GetExtension().Channels
+	}
+
+	return ret
 }
 
 func GetExtensionLang(lang string) string {
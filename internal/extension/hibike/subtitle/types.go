@@ -0,0 +1,95 @@
+package hibikesubtitle
+
+type (
+	Provider interface {
+		// Search returns the subtitles matching the given media, episode and language.
+		Search(opts SearchOptions) ([]*SearchResult, error)
+		// Download returns the content of the given subtitle.
+		Download(result *SearchResult) (*Subtitle, error)
+		// GetSettings returns the provider settings.
+		GetSettings() Settings
+	}
+
+	Settings struct {
+		// ISO 639-1 language codes supported by the provider.
+		// Leave it empty if the provider supports all languages.
+		Languages []string `json:"languages,omitempty"`
+		// Whether the provider can match subtitles using the name of the video file.
+		SupportsFilenameSearch bool `json:"supportsFilenameSearch"`
+	}
+
+	SearchOptions struct {
+		// The media object provided by Seanime.
+		Media Media `json:"media"`
+		// The episode number.
+		// Will be 0 for movies or if the episode number is unknown.
+		EpisodeNumber int `json:"episodeNumber"`
+		// ISO 639-1 language code of the wanted subtitles, e.g. "en".
+		// Will be empty if any language is accepted.
+		Language string `json:"language"`
+		// The name of the video file, without the directory.
+		// Will be empty if the media is not played from a file.
+		Filename string `json:"filename,omitempty"`
+		// The path of the video file.
+		// Only set for built-in providers.
+		Path string `json:"-"`
+	}
+
+	Media struct {
+		// AniList ID of the media.
+		ID int `json:"id"`
+		// MyAnimeList ID of the media.
+		IDMal *int `json:"idMal,omitempty"`
+		// e.g. "TV", "TV_SHORT", "MOVIE", "SPECIAL", "OVA", "ONA", "MUSIC"
+		Format string `json:"format,omitempty"`
+		// e.g. "Attack on Titan"
+		// This will be undefined if the english title is unknown.
+		EnglishTitle *string `json:"englishTitle,omitempty"`
+		// e.g. "Shingeki no Kyojin"
+		RomajiTitle string `json:"romajiTitle,omitempty"`
+		// TotalEpisodes is total number of episodes of the media.
+		// This will be -1 if the total number of episodes is unknown / not applicable.
+		EpisodeCount int `json:"episodeCount,omitempty"`
+		// All alternative titles of the media.
+		Synonyms []string `json:"synonyms"`
+		// Whether the media is NSFW.
+		IsAdult bool `json:"isAdult"`
+		// Year the media started airing.
+		// Will be 0 if the year is not available.
+		Year int `json:"year,omitempty"`
+	}
+
+	SearchResult struct {
+		// "ID" of the extension.
+		Provider string `json:"provider"`
+		// ID of the subtitle, used to download it.
+		ID string `json:"id"`
+		// Name of the subtitle, usually the release it was made for.
+		Name string `json:"name"`
+		// ISO 639-1 language code of the subtitle, e.g. "en".
+		Language string `json:"language"`
+		// Format of the subtitle: "srt", "ass", "ssa" or "vtt".
+		Format string `json:"format"`
+		// Episode number the subtitle is for.
+		// Leave it empty if unknown.
+		EpisodeNumber int `json:"episodeNumber,omitempty"`
+		// URL of the subtitle file, if it can be downloaded directly.
+		URL string `json:"url,omitempty"`
+		// Number of downloads, used for sorting.
+		// Leave it empty if not available.
+		Downloads int `json:"downloads,omitempty"`
+		// Whether the subtitle was matched using the video file name.
+		IsFilenameMatch bool `json:"isFilenameMatch,omitempty"`
+	}
+
+	Subtitle struct {
+		// "ID" of the extension.
+		Provider string `json:"provider"`
+		// Format of the subtitle: "srt", "ass", "ssa" or "vtt".
+		Format string `json:"format"`
+		// ISO 639-1 language code of the subtitle.
+		Language string `json:"language"`
+		// Content of the subtitle file.
+		Content string `json:"content"`
+	}
+)
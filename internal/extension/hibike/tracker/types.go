@@ -0,0 +1,95 @@
+package hibiketracker
+
+const (
+	MediaTypeAnime MediaType = "anime"
+	MediaTypeManga MediaType = "manga"
+)
+
+const (
+	StatusCurrent   Status = "current"
+	StatusPlanning  Status = "planning"
+	StatusCompleted Status = "completed"
+	StatusDropped   Status = "dropped"
+	StatusPaused    Status = "paused"
+	StatusRepeating Status = "repeating"
+)
+
+type (
+	MediaType string
+
+	// Status is the status of a list entry, it matches the AniList list statuses.
+	Status string
+
+	Provider interface {
+		// GetEntry returns the user's list entry for the media.
+		// It should return nil if the media is not in the user's list.
+		GetEntry(media Media) (*Entry, error)
+		// UpdateEntry creates or updates the user's list entry for the media.
+		// Only the fields that are set should be changed.
+		UpdateEntry(opts UpdateEntryOptions) error
+		// GetSettings returns the tracker settings.
+		GetSettings() Settings
+	}
+
+	Settings struct {
+		// Whether the tracker can mirror anime list entries.
+		SupportsAnime bool `json:"supportsAnime"`
+		// Whether the tracker can mirror manga list entries.
+		SupportsManga bool `json:"supportsManga"`
+	}
+
+	// Media is the media object provided by Seanime.
+	// The tracker should use the IDs to find the media on its service.
+	Media struct {
+		// "anime" or "manga"
+		Type MediaType `json:"type"`
+		// AniList ID of the media.
+		ID int `json:"id"`
+		// MyAnimeList ID of the media.
+		IDMal *int `json:"idMal,omitempty"`
+		// Kitsu ID of the media.
+		// Only available for anime, it will be undefined if the mapping is unknown.
+		IDKitsu *int `json:"idKitsu,omitempty"`
+		// e.g. "TV", "MOVIE", "MANGA", "ONE_SHOT"
+		Format string `json:"format,omitempty"`
+		// e.g. "Attack on Titan"
+		// This will be undefined if the english title is unknown.
+		EnglishTitle *string `json:"englishTitle,omitempty"`
+		// e.g. "Shingeki no Kyojin"
+		RomajiTitle string `json:"romajiTitle,omitempty"`
+		// Total number of episodes, or chapters for manga.
+		// This will be 0 if the total is unknown.
+		Total int `json:"total,omitempty"`
+	}
+
+	// Entry is a list entry on the tracker.
+	Entry struct {
+		Status Status `json:"status"`
+		// Episode or chapter progress.
+		Progress int `json:"progress"`
+		// Score from 0 to 100, 0 if the entry is not scored.
+		Score int `json:"score"`
+		// Date the media was started.
+		StartedAt *FuzzyDate `json:"startedAt,omitempty"`
+		// Date the media was completed.
+		CompletedAt *FuzzyDate `json:"completedAt,omitempty"`
+	}
+
+	FuzzyDate struct {
+		Year  *int `json:"year,omitempty"`
+		Month *int `json:"month,omitempty"`
+		Day   *int `json:"day,omitempty"`
+	}
+
+	UpdateEntryOptions struct {
+		Media Media `json:"media"`
+		// The fields below are undefined if they were not changed.
+		Status *Status `json:"status,omitempty"`
+		// Episode or chapter progress.
+		Progress *int `json:"progress,omitempty"`
+		// Score from 0 to 100.
+		Score       *int       `json:"score,omitempty"`
+		StartedAt   *FuzzyDate `json:"startedAt,omitempty"`
+		CompletedAt *FuzzyDate `json:"completedAt,omitempty"`
+	}
+)
@@ -81,6 +81,14 @@ func (m *MangaProviderExtensionImpl) GetPermissions() []string {
 	return m.ext.Permissions
 }
 
+func (m *MangaProviderExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *MangaProviderExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
 func (m *MangaProviderExtensionImpl) GetUserConfig() *UserConfig {
 	return m.ext.UserConfig
 }
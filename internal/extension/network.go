@@ -0,0 +1,199 @@
+package extension
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// NetworkPermissions restricts the hosts an extension can reach with fetch.
+//
+// Extensions that don't declare network permissions can reach any public host.
+// Private, loopback and link-local addresses are always blocked unless they're covered by AllowedCIDRs.
+type NetworkPermissions struct {
+	// AllowedDomains is a list of domains the extension can reach.
+	// A domain also matches its subdomains if it starts with "*." (e.g. "*.example.com").
+	// "*" allows any domain.
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	// AllowedCIDRs is a list of IP ranges the extension can reach, e.g. "192.168.1.0/24".
+	// This is required to reach private addresses (LAN, localhost).
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+var (
+	// blockedPrefixes are the ranges that can only be reached if they're explicitly allowed.
+	// IsPrivate, IsLoopback etc. cover the rest.
+	blockedPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+		netip.MustParsePrefix("0.0.0.0/8"),
+	}
+)
+
+// IsEmpty returns true if no network permissions are declared.
+func (p *NetworkPermissions) IsEmpty() bool {
+	return p == nil || (len(p.AllowedDomains) == 0 && len(p.AllowedCIDRs) == 0)
+}
+
+// Validate returns an error if a CIDR is invalid.
+func (p *NetworkPermissions) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, cidr := range p.AllowedCIDRs {
+		if _, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+// AllowsHost returns true if the extension can send requests to the host.
+// The host can be a domain or an IP address, without port.
+// Addresses the host resolves to must also be checked with AllowsIP.
+func (p *NetworkPermissions) AllowsHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(strings.Trim(host, "[]"), "."))
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if p.inAllowedCIDRs(ip) {
+			return true
+		}
+		if p.IsEmpty() {
+			return !isRestrictedIP(ip)
+		}
+	}
+
+	if p.IsEmpty() {
+		return true
+	}
+
+	for _, domain := range p.AllowedDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		switch {
+		case domain == "*":
+			return true
+		case strings.HasPrefix(domain, "*."):
+			if host == domain[2:] || strings.HasSuffix(host, domain[1:]) {
+				return true
+			}
+		case host == domain:
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP returns true if the extension can connect to the IP address.
+// Private addresses are only allowed if they're covered by AllowedCIDRs.
+func (p *NetworkPermissions) AllowsIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	return !isRestrictedIP(addr) || p.inAllowedCIDRs(addr)
+}
+
+func (p *NetworkPermissions) inAllowedCIDRs(ip netip.Addr) bool {
+	if p == nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, cidr := range p.AllowedCIDRs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			continue
+		}
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRestrictedIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHash returns a hash of the permissions, or an empty string if no permissions are declared.
+func (p *NetworkPermissions) GetHash() string {
+	if p.IsEmpty() {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, domain := range p.AllowedDomains {
+		h.Write([]byte("domain:" + domain))
+	}
+	for _, cidr := range p.AllowedCIDRs {
+		h.Write([]byte("cidr:" + cidr))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// GetDescription returns a human-readable description of the permissions.
+func (p *NetworkPermissions) GetDescription() string {
+	if p.IsEmpty() {
+		return ""
+	}
+
+	var desc strings.Builder
+	desc.WriteString("Network:\n")
+	for _, domain := range p.AllowedDomains {
+		if domain == "*" {
+			desc.WriteString("• Any website\n")
+			continue
+		}
+		desc.WriteString("• " + domain + "\n")
+	}
+	for _, cidr := range p.AllowedCIDRs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err == nil && isRestrictedIP(prefix.Addr()) {
+			desc.WriteString("• " + cidr + " (local network)\n")
+			continue
+		}
+		desc.WriteString("• " + cidr + "\n")
+	}
+	return strings.TrimSpace(desc.String())
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetPermissionHash returns the hash of the permissions the user must grant before the extension can be loaded.
+// It returns an empty string if the extension doesn't need any permissions.
+func GetPermissionHash(ext *Extension) string {
+	pluginHash := ""
+	if ext.Plugin != nil {
+		pluginHash = ext.Plugin.Permissions.GetHash()
+	}
+
+	networkHash := ext.Network.GetHash()
+	if networkHash == "" {
+		return pluginHash
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(pluginHash+":"+networkHash)))
+}
+
+// GetPermissionDescription returns a human-readable description of the permissions the extension is asking for.
+func GetPermissionDescription(ext *Extension) string {
+	parts := make([]string, 0, 2)
+	if ext.Plugin != nil && (ext.Plugin.Permissions.GetHash() != "" || ext.Network.IsEmpty()) {
+		parts = append(parts, ext.Plugin.Permissions.GetDescription())
+	}
+	if networkDesc := ext.Network.GetDescription(); networkDesc != "" {
+		parts = append(parts, networkDesc)
+	}
+	return strings.Join(parts, "\n\n")
+}
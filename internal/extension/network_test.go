@@ -0,0 +1,83 @@
+package extension
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkPermissions_AllowsHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions *NetworkPermissions
+		host        string
+		expected    bool
+	}{
+		{"no permissions, public domain", nil, "example.com", true},
+		{"no permissions, public ip", nil, "1.1.1.1", true},
+		{"no permissions, private ip", nil, "192.168.1.10", false},
+		{"no permissions, loopback ip", nil, "127.0.0.1", false},
+		{"no permissions, ipv6 loopback", nil, "[::1]", false},
+		{"exact domain", &NetworkPermissions{AllowedDomains: []string{"example.com"}}, "example.com", true},
+		{"exact domain, case insensitive", &NetworkPermissions{AllowedDomains: []string{"Example.com"}}, "EXAMPLE.COM.", true},
+		{"exact domain, subdomain", &NetworkPermissions{AllowedDomains: []string{"example.com"}}, "api.example.com", false},
+		{"wildcard domain, subdomain", &NetworkPermissions{AllowedDomains: []string{"*.example.com"}}, "api.example.com", true},
+		{"wildcard domain, apex", &NetworkPermissions{AllowedDomains: []string{"*.example.com"}}, "example.com", true},
+		{"wildcard domain, suffix only", &NetworkPermissions{AllowedDomains: []string{"*.example.com"}}, "badexample.com", false},
+		{"any domain", &NetworkPermissions{AllowedDomains: []string{"*"}}, "example.org", true},
+		{"domain not allowed", &NetworkPermissions{AllowedDomains: []string{"example.com"}}, "example.org", false},
+		{"private ip in cidr", &NetworkPermissions{AllowedCIDRs: []string{"192.168.1.0/24"}}, "192.168.1.10", true},
+		{"private ip outside cidr", &NetworkPermissions{AllowedCIDRs: []string{"192.168.1.0/24"}}, "192.168.2.10", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.permissions.AllowsHost(tt.host))
+		})
+	}
+}
+
+func TestNetworkPermissions_AllowsIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions *NetworkPermissions
+		ip          string
+		expected    bool
+	}{
+		{"public", nil, "93.184.216.34", true},
+		{"private", nil, "10.0.0.1", false},
+		{"loopback", nil, "127.0.0.1", false},
+		{"link-local", nil, "169.254.169.254", false},
+		{"carrier-grade nat", nil, "100.64.0.1", false},
+		{"unspecified", nil, "0.0.0.0", false},
+		{"ipv4-mapped loopback", nil, "::ffff:127.0.0.1", false},
+		{"ipv6 unique local", nil, "fd00::1", false},
+		{"loopback in cidr", &NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8"}}, "127.0.0.1", true},
+		{"ipv4-mapped loopback in cidr", &NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8"}}, "::ffff:127.0.0.1", true},
+		{"private with domain allowlist", &NetworkPermissions{AllowedDomains: []string{"*"}}, "192.168.1.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.permissions.AllowsIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestGetPermissionHash(t *testing.T) {
+	ext := &Extension{Type: TypeMangaProvider}
+	assert.Empty(t, GetPermissionHash(ext))
+
+	ext.Network = &NetworkPermissions{AllowedDomains: []string{"example.com"}}
+	hash := GetPermissionHash(ext)
+	assert.NotEmpty(t, hash)
+
+	// Changing the network permissions requires a new grant
+	ext.Network.AllowedCIDRs = []string{"192.168.1.0/24"}
+	assert.NotEqual(t, hash, GetPermissionHash(ext))
+
+	// Plugins without network permissions keep their previous hash
+	plugin := &Extension{Type: TypePlugin, Plugin: &PluginManifest{Permissions: PluginPermissions{Scopes: []PluginPermissionScope{PluginPermissionStorage}}}}
+	assert.Equal(t, plugin.Plugin.Permissions.GetHash(), GetPermissionHash(plugin))
+}
@@ -81,6 +81,14 @@ func (m *OnlinestreamProviderExtensionImpl) GetPermissions() []string {
 	return m.ext.Permissions
 }
 
+func (m *OnlinestreamProviderExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *OnlinestreamProviderExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
 func (m *OnlinestreamProviderExtensionImpl) GetUserConfig() *UserConfig {
 	return m.ext.UserConfig
 }
@@ -21,6 +21,7 @@ var (
 	PluginPermissionNotification  PluginPermissionScope = "notification"   // Allows the plugin to use the notification manager
 	PluginPermissionDiscord       PluginPermissionScope = "discord"        // Allows the plugin to use the discord rpc
 	PluginPermissionTorrentClient PluginPermissionScope = "torrent-client" // Allows the plugin to use the torrent client
+	PluginPermissionHTTPRoutes    PluginPermissionScope = "http-routes"    // Allows the plugin to register HTTP routes under /api/v1/plugins/{id}
 )
 
 type PluginManifest struct {
@@ -200,6 +201,8 @@ func (p *PluginPermissions) GetDescription() string {
 				desc.WriteString("Notification: Send system notifications\n")
 			case PluginPermissionDiscord:
 				desc.WriteString("Discord: Set Discord Rich Presence\n")
+			case PluginPermissionHTTPRoutes:
+				desc.WriteString("HTTP Routes: Expose API endpoints on the server\n")
 			default:
 				desc.WriteString(string(scope) + "\n")
 			}
@@ -317,11 +320,7 @@ func NewPluginExtension(ext *Extension) PluginExtension {
 }
 
 func (m *PluginExtensionImpl) GetPermissionHash() string {
-	if m.ext.Plugin == nil {
-		return ""
-	}
-
-	return m.ext.Plugin.Permissions.GetHash()
+	return GetPermissionHash(m.ext)
 }
 
 func (m *PluginExtensionImpl) GetExtension() *Extension {
@@ -380,6 +379,14 @@ func (m *PluginExtensionImpl) GetPermissions() []string {
 	return m.ext.Permissions
 }
 
+func (m *PluginExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *PluginExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
 func (m *PluginExtensionImpl) GetUserConfig() *UserConfig {
 	return m.ext.UserConfig
 }
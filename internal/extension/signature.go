@@ -0,0 +1,139 @@
+package extension
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type SignatureStatus string
+
+const (
+	// SignatureStatusUnsigned means the manifest doesn't have a signature.
+	SignatureStatusUnsigned SignatureStatus = "unsigned"
+	// SignatureStatusVerified means the signature is valid and the key is trusted by the user.
+	SignatureStatusVerified SignatureStatus = "verified"
+	// SignatureStatusUntrusted means the signature is valid but the key isn't trusted by the user.
+	SignatureStatusUntrusted SignatureStatus = "untrusted"
+	// SignatureStatusInvalid means the signature doesn't match the manifest or payload, e.g. the payload was edited.
+	SignatureStatusInvalid SignatureStatus = "invalid"
+)
+
+var (
+	ErrInvalidSignature = errors.New("signature verification failed")
+	ErrInvalidPublicKey = errors.New("invalid ed25519 public key")
+)
+
+// Signature is an ed25519 signature of the extension, made by the publisher.
+//
+// The signed message is built by GetSignedMessage:
+//
+//	seanime-extension-v2\n{hex sha256 of the payload}\n{canonical manifest}
+//
+// The canonical manifest is the whole manifest without "signature" and with an empty "payload",
+// encoded as compact JSON with sorted keys, no HTML escaping and empty optional fields omitted.
+type Signature struct {
+	// PublicKey is the base64-encoded ed25519 public key of the publisher.
+	PublicKey string `json:"publicKey"`
+	// Value is the base64-encoded ed25519 signature.
+	Value string `json:"value"`
+}
+
+// GetSignedMessage returns the message the publisher signs.
+// The payload must be downloaded before calling this if the extension uses PayloadURI.
+func GetSignedMessage(ext *Extension) []byte {
+	payloadHash := sha256.Sum256([]byte(ext.Payload))
+	return []byte(strings.Join([]string{
+		"seanime-extension-v2",
+		fmt.Sprintf("%x", payloadHash),
+		string(getCanonicalManifest(ext)),
+	}, "\n"))
+}
+
+// getCanonicalManifest encodes the manifest without the signature and payload.
+// The fields set by the repository are left out since they're not part of the manifest.
+func getCanonicalManifest(ext *Extension) []byte {
+	manifest := *ext
+	manifest.Lang = GetExtensionLang(strings.ToLower(ext.Lang)) // Normalized when the extension is loaded
+	manifest.Payload = ""
+	manifest.Signature = nil
+	manifest.SignatureStatus = ""
+	manifest.Repository = ""
+	manifest.SavedUserConfig = nil
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil
+	}
+
+	// Decode into a generic value so that the keys are sorted at every level
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// VerifySignature returns an error if the extension's signature doesn't match its manifest and payload.
+// It returns nil if the extension isn't signed.
+func VerifySignature(ext *Extension) error {
+	if ext.Signature == nil {
+		return nil
+	}
+
+	publicKey, err := ParsePublicKey(ext.Signature.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ext.Signature.Value))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return ErrInvalidSignature
+	}
+
+	if !ed25519.Verify(publicKey, GetSignedMessage(ext), sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ParsePublicKey decodes a base64-encoded ed25519 public key.
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return b, nil
+}
+
+// GetKeyFingerprint returns a short identifier of the public key, or an empty string if the key is invalid.
+func GetKeyFingerprint(key string) string {
+	publicKey, err := ParsePublicKey(key)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(publicKey)
+	return fmt.Sprintf("%x", h[:8])
+}
+
+// GetFingerprint returns the fingerprint of the publisher's key.
+func (s *Signature) GetFingerprint() string {
+	if s == nil {
+		return ""
+	}
+	return GetKeyFingerprint(s.PublicKey)
+}
@@ -0,0 +1,120 @@
+package extension
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	newSignedExtension := func() *Extension {
+		ext := &Extension{
+			ID:          "signed-extension",
+			Version:     "1.0.0",
+			ManifestURI: "https://example.com/manifest.json",
+			Type:        TypePlugin,
+			Lang:        "en",
+			Payload:     "function init() {}",
+			Network:     &NetworkPermissions{AllowedDomains: []string{"example.com"}},
+			Plugin: &PluginManifest{
+				Version: "1",
+				Permissions: PluginPermissions{
+					Scopes: []PluginPermissionScope{PluginPermissionStorage},
+				},
+			},
+			Channels: map[string]string{"beta": "https://example.com/beta/manifest.json"},
+		}
+		ext.Signature = &Signature{
+			PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+			Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, GetSignedMessage(ext))),
+		}
+		return ext
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		assert.NoError(t, VerifySignature(&Extension{ID: "unsigned"}))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, VerifySignature(newSignedExtension()))
+	})
+
+	t.Run("payload changed", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Payload += "\nconsole.log('injected')"
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature)
+	})
+
+	t.Run("version changed", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Version = "1.0.1"
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature)
+	})
+
+	t.Run("network permissions changed", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Network = &NetworkPermissions{AllowedDomains: []string{"*"}}
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature)
+	})
+
+	t.Run("plugin permissions changed", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Plugin.Permissions.Allow.WritePaths = append(ext.Plugin.Permissions.Allow.WritePaths, "/")
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature)
+	})
+
+	t.Run("manifest fields changed", func(t *testing.T) {
+		changes := map[string]func(ext *Extension){
+			"lang":        func(ext *Extension) { ext.Lang = "fr" },
+			"payloadURI":  func(ext *Extension) { ext.PayloadURI = "https://example.com/other.js" },
+			"manifestURI": func(ext *Extension) { ext.ManifestURI = "https://example.com/other.json" },
+			"channels":    func(ext *Extension) { ext.Channels["beta"] = "https://example.com/other.json" },
+		}
+		for name, change := range changes {
+			ext := newSignedExtension()
+			change(ext)
+			assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature, name)
+		}
+	})
+
+	t.Run("fields set by the repository", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Lang = "EN"
+		ext.SignatureStatus = SignatureStatusVerified
+		ext.Repository = "https://example.com/repository.json"
+		assert.NoError(t, VerifySignature(ext))
+	})
+
+	t.Run("extension data", func(t *testing.T) {
+		ext := newSignedExtension()
+		data := ToExtensionData(NewPluginExtension(ext))
+		assert.NoError(t, VerifySignature(data))
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		ext := newSignedExtension()
+		ext.Signature.PublicKey = base64.StdEncoding.EncodeToString(otherKey)
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidSignature)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		ext := newSignedExtension()
+		ext.Signature.PublicKey = "not-a-key"
+		assert.ErrorIs(t, VerifySignature(ext), ErrInvalidPublicKey)
+	})
+
+	t.Run("fingerprint", func(t *testing.T) {
+		ext := newSignedExtension()
+		assert.Len(t, ext.Signature.GetFingerprint(), 16)
+		assert.Empty(t, GetKeyFingerprint("not-a-key"))
+	})
+}
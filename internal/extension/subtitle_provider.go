@@ -0,0 +1,106 @@
+package extension
+
+import (
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+)
+
+type SubtitleProviderExtension interface {
+	BaseExtension
+	GetProvider() hibikesubtitle.Provider
+}
+
+type SubtitleProviderExtensionImpl struct {
+	ext      *Extension
+	provider hibikesubtitle.Provider
+}
+
+func NewSubtitleProviderExtension(ext *Extension, provider hibikesubtitle.Provider) SubtitleProviderExtension {
+	return &SubtitleProviderExtensionImpl{
+		ext:      ext,
+		provider: provider,
+	}
+}
+
+func (m *SubtitleProviderExtensionImpl) GetProvider() hibikesubtitle.Provider {
+	return m.provider
+}
+
+func (m *SubtitleProviderExtensionImpl) GetExtension() *Extension {
+	return m.ext
+}
+
+func (m *SubtitleProviderExtensionImpl) GetType() Type {
+	return m.ext.Type
+}
+
+func (m *SubtitleProviderExtensionImpl) GetID() string {
+	return m.ext.ID
+}
+
+func (m *SubtitleProviderExtensionImpl) GetName() string {
+	return m.ext.Name
+}
+
+func (m *SubtitleProviderExtensionImpl) GetVersion() string {
+	return m.ext.Version
+}
+
+func (m *SubtitleProviderExtensionImpl) GetManifestURI() string {
+	return m.ext.ManifestURI
+}
+
+func (m *SubtitleProviderExtensionImpl) GetLanguage() Language {
+	return m.ext.Language
+}
+
+func (m *SubtitleProviderExtensionImpl) GetLang() string {
+	return GetExtensionLang(m.ext.Lang)
+}
+
+func (m *SubtitleProviderExtensionImpl) GetDescription() string {
+	return m.ext.Description
+}
+
+func (m *SubtitleProviderExtensionImpl) GetAuthor() string {
+	return m.ext.Author
+}
+
+func (m *SubtitleProviderExtensionImpl) GetPayload() string {
+	return m.ext.Payload
+}
+
+func (m *SubtitleProviderExtensionImpl) GetWebsite() string {
+	return m.ext.Website
+}
+
+func (m *SubtitleProviderExtensionImpl) GetIcon() string {
+	return m.ext.Icon
+}
+
+func (m *SubtitleProviderExtensionImpl) GetPermissions() []string {
+	return m.ext.Permissions
+}
+
+func (m *SubtitleProviderExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *SubtitleProviderExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
+func (m *SubtitleProviderExtensionImpl) GetUserConfig() *UserConfig {
+	return m.ext.UserConfig
+}
+
+func (m *SubtitleProviderExtensionImpl) GetSavedUserConfig() *SavedUserConfig {
+	return m.ext.SavedUserConfig
+}
+
+func (m *SubtitleProviderExtensionImpl) GetPayloadURI() string {
+	return m.ext.PayloadURI
+}
+
+func (m *SubtitleProviderExtensionImpl) GetIsDevelopment() bool {
+	return m.ext.IsDevelopment
+}
@@ -81,6 +81,14 @@ func (m *AnimeTorrentProviderExtensionImpl) GetPermissions() []string {
 	return m.ext.Permissions
 }
 
+func (m *AnimeTorrentProviderExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *AnimeTorrentProviderExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
 func (m *AnimeTorrentProviderExtensionImpl) GetUserConfig() *UserConfig {
 	return m.ext.UserConfig
 }
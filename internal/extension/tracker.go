@@ -0,0 +1,106 @@
+package extension
+
+import (
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+)
+
+type TrackerExtension interface {
+	BaseExtension
+	GetProvider() hibiketracker.Provider
+}
+
+type TrackerExtensionImpl struct {
+	ext      *Extension
+	provider hibiketracker.Provider
+}
+
+func NewTrackerExtension(ext *Extension, provider hibiketracker.Provider) TrackerExtension {
+	return &TrackerExtensionImpl{
+		ext:      ext,
+		provider: provider,
+	}
+}
+
+func (m *TrackerExtensionImpl) GetProvider() hibiketracker.Provider {
+	return m.provider
+}
+
+func (m *TrackerExtensionImpl) GetExtension() *Extension {
+	return m.ext
+}
+
+func (m *TrackerExtensionImpl) GetType() Type {
+	return m.ext.Type
+}
+
+func (m *TrackerExtensionImpl) GetID() string {
+	return m.ext.ID
+}
+
+func (m *TrackerExtensionImpl) GetName() string {
+	return m.ext.Name
+}
+
+func (m *TrackerExtensionImpl) GetVersion() string {
+	return m.ext.Version
+}
+
+func (m *TrackerExtensionImpl) GetManifestURI() string {
+	return m.ext.ManifestURI
+}
+
+func (m *TrackerExtensionImpl) GetLanguage() Language {
+	return m.ext.Language
+}
+
+func (m *TrackerExtensionImpl) GetLang() string {
+	return GetExtensionLang(m.ext.Lang)
+}
+
+func (m *TrackerExtensionImpl) GetDescription() string {
+	return m.ext.Description
+}
+
+func (m *TrackerExtensionImpl) GetAuthor() string {
+	return m.ext.Author
+}
+
+func (m *TrackerExtensionImpl) GetPayload() string {
+	return m.ext.Payload
+}
+
+func (m *TrackerExtensionImpl) GetWebsite() string {
+	return m.ext.Website
+}
+
+func (m *TrackerExtensionImpl) GetIcon() string {
+	return m.ext.Icon
+}
+
+func (m *TrackerExtensionImpl) GetPermissions() []string {
+	return m.ext.Permissions
+}
+
+func (m *TrackerExtensionImpl) GetNetworkPermissions() *NetworkPermissions {
+	return m.ext.Network
+}
+
+func (m *TrackerExtensionImpl) GetSignature() *Signature {
+	return m.ext.Signature
+}
+
+func (m *TrackerExtensionImpl) GetUserConfig() *UserConfig {
+	return m.ext.UserConfig
+}
+
+func (m *TrackerExtensionImpl) GetSavedUserConfig() *SavedUserConfig {
+	return m.ext.SavedUserConfig
+}
+
+func (m *TrackerExtensionImpl) GetPayloadURI() string {
+	return m.ext.PayloadURI
+}
+
+func (m *TrackerExtensionImpl) GetIsDevelopment() bool {
+	return m.ext.IsDevelopment
+}
@@ -0,0 +1,592 @@
+package extension_playground
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"seanime/internal/extension"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	hibikeonlinestream "seanime/internal/extension/hibike/onlinestream"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	hibiketorrent "seanime/internal/extension/hibike/torrent"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/extension_repo"
+	"seanime/internal/goja/goja_bindings"
+	goja_runtime "seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Harness
+// Runs the methods of a provider extension against recorded fetch fixtures and compares the normalized output to the expected output.
+// - Record mode: requests are sent, the fixtures and the expected outputs are saved
+// - Replay mode: the extension runs offline against the fixtures, the outputs are compared to the expected outputs
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type (
+	// HarnessSuite is a regression suite for a provider extension, stored as a JSON file.
+	HarnessSuite struct {
+		// Manifest is the path to the extension manifest, relative to the suite file.
+		// The payload is read from the manifest's payloadURI (relative to the manifest) if the payload is empty.
+		Manifest string `json:"manifest"`
+		// Fixtures is the path to the fetch fixtures, relative to the suite file.
+		// Defaults to "<suite name>.fixtures.json".
+		Fixtures string `json:"fixtures,omitempty"`
+		// IgnoreFields are the fields removed from the outputs of all cases before comparison, at any depth.
+		IgnoreFields []string       `json:"ignoreFields,omitempty"`
+		Cases        []*HarnessCase `json:"cases"`
+	}
+
+	HarnessCase struct {
+		Name string `json:"name"`
+		// Method is the provider method, e.g. "search", "findChapters", "findEpisodes".
+		Method string `json:"method"`
+		// Input is the argument of the method, e.g. {"query": "..."} for "search", {"id": "..."} for "findChapters".
+		Input json.RawMessage `json:"input,omitempty"`
+		// Expected is the normalized output, set in record mode.
+		Expected json.RawMessage `json:"expected,omitempty"`
+		// IgnoreFields are removed from the output of this case in addition to the suite's.
+		IgnoreFields []string `json:"ignoreFields,omitempty"`
+	}
+
+	HarnessRunResult struct {
+		ExtensionID string                         `json:"extensionId"`
+		Mode        goja_bindings.FetchFixtureMode `json:"mode"`
+		Passed      int                            `json:"passed"`
+		Failed      int                            `json:"failed"`
+		Cases       []*HarnessCaseResult           `json:"cases"`
+	}
+
+	HarnessCaseResult struct {
+		Name     string `json:"name"`
+		Method   string `json:"method"`
+		Passed   bool   `json:"passed"`
+		Output   string `json:"output"`
+		Expected string `json:"expected,omitempty"`
+		// Difference describes the first line that differs between the output and the expected output.
+		Difference string `json:"difference,omitempty"`
+	}
+)
+
+// RunHarness runs the suite at suitePath.
+// In record mode, the fixtures and the expected outputs are written next to the suite and all cases pass.
+func RunHarness(logger *zerolog.Logger, suitePath string, record bool) (ret *HarnessRunResult, err error) {
+	defer util.HandlePanicInModuleWithError("extension_playground/RunHarness", &err)
+
+	suite, err := LoadHarnessSuite(suitePath)
+	if err != nil {
+		return nil, err
+	}
+
+	suiteDir := filepath.Dir(suitePath)
+
+	ext, err := loadHarnessExtension(filepath.Join(suiteDir, suite.Manifest))
+	if err != nil {
+		return nil, err
+	}
+
+	fixturesPath := suite.Fixtures
+	if fixturesPath == "" {
+		fixturesPath = strings.TrimSuffix(filepath.Base(suitePath), filepath.Ext(suitePath)) + ".fixtures.json"
+	}
+	fixturesPath = filepath.Join(suiteDir, fixturesPath)
+
+	var fixtures *goja_bindings.FetchFixtures
+	if record {
+		fixtures = goja_bindings.NewFetchRecorder()
+	} else {
+		fixtures, err = goja_bindings.LoadFetchFixtures(fixturesPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Use a dedicated runtime manager so that the fixtures only apply to this run
+	runtimeManager := goja_runtime.NewManager(logger)
+	runtimeManager.SetFetchTransportWrapper(fixtures.Wrap)
+	defer runtimeManager.DeletePluginPool(ext.ID)
+
+	call, closeProvider, err := newHarnessCaller(ext, logger, runtimeManager)
+	if err != nil {
+		return nil, err
+	}
+	defer closeProvider()
+
+	ret = &HarnessRunResult{
+		ExtensionID: ext.ID,
+		Mode:        fixtures.Mode(),
+		Cases:       make([]*HarnessCaseResult, 0, len(suite.Cases)),
+	}
+
+	for _, c := range suite.Cases {
+		ignoreFields := make([]string, 0, len(suite.IgnoreFields)+len(c.IgnoreFields))
+		ignoreFields = append(ignoreFields, suite.IgnoreFields...)
+		ignoreFields = append(ignoreFields, c.IgnoreFields...)
+
+		output := normalizeHarnessOutput(call(c.Method, c.Input), ignoreFields)
+
+		res := &HarnessCaseResult{
+			Name:   c.Name,
+			Method: c.Method,
+			Output: output,
+		}
+
+		if record {
+			c.Expected = json.RawMessage(output)
+			res.Passed = true
+		} else {
+			res.Expected = normalizeHarnessJSON(c.Expected, nil)
+			res.Difference = firstDifference(res.Expected, output)
+			res.Passed = res.Difference == ""
+		}
+
+		if res.Passed {
+			ret.Passed++
+		} else {
+			ret.Failed++
+		}
+		ret.Cases = append(ret.Cases, res)
+	}
+
+	if record {
+		if err := fixtures.Save(fixturesPath); err != nil {
+			return nil, fmt.Errorf("failed to save fixtures: %w", err)
+		}
+		if err := saveHarnessSuite(suitePath, suite); err != nil {
+			return nil, fmt.Errorf("failed to save suite: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+func LoadHarnessSuite(path string) (*HarnessSuite, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite: %w", err)
+	}
+
+	var suite HarnessSuite
+	if err := json.Unmarshal(b, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse suite: %w", err)
+	}
+
+	if suite.Manifest == "" {
+		return nil, errors.New("suite has no manifest")
+	}
+
+	return &suite, nil
+}
+
+// CheckHarnessSuitePath checks that the suite at suitePath and the files it reads (manifest, payload, fixtures) are inside rootDir.
+// This is used when the suite path comes from the client.
+func CheckHarnessSuitePath(rootDir string, suitePath string) error {
+	root, err := resolveHarnessPath(rootDir)
+	if err != nil {
+		return fmt.Errorf("invalid directory: %w", err)
+	}
+
+	isInRoot := func(path string) error {
+		resolved, err := resolveHarnessPath(path)
+		if err != nil {
+			return err
+		}
+		if !util.IsSubdirectory(root, resolved) {
+			return fmt.Errorf("%s is outside of the extension directory", filepath.Base(path))
+		}
+		return nil
+	}
+
+	if err := isInRoot(suitePath); err != nil {
+		return err
+	}
+
+	suite, err := LoadHarnessSuite(suitePath)
+	if err != nil {
+		return err
+	}
+
+	suiteDir := filepath.Dir(suitePath)
+	manifestPath := filepath.Join(suiteDir, suite.Manifest)
+	if err := isInRoot(manifestPath); err != nil {
+		return err
+	}
+
+	if suite.Fixtures != "" {
+		if err := isInRoot(filepath.Join(suiteDir, suite.Fixtures)); err != nil {
+			return err
+		}
+	}
+
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var ext extension.Extension
+	if err := json.Unmarshal(b, &ext); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if ext.Payload == "" && ext.PayloadURI != "" {
+		payloadPath := ext.PayloadURI
+		if !filepath.IsAbs(payloadPath) {
+			payloadPath = filepath.Join(filepath.Dir(manifestPath), payloadPath)
+		}
+		if err := isInRoot(payloadPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveHarnessPath returns the absolute path with symlinks evaluated.
+func resolveHarnessPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+func saveHarnessSuite(path string, suite *HarnessSuite) error {
+	b, err := json.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func loadHarnessExtension(manifestPath string) (*extension.Extension, error) {
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var ext extension.Extension
+	if err := json.Unmarshal(b, &ext); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	// Only local payloads are read, the harness shouldn't need the network
+	if ext.Payload == "" && ext.PayloadURI != "" {
+		payloadPath := ext.PayloadURI
+		if !filepath.IsAbs(payloadPath) {
+			payloadPath = filepath.Join(filepath.Dir(manifestPath), payloadPath)
+		}
+		payload, err := os.ReadFile(payloadPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read payload: %w", err)
+		}
+		ext.Payload = string(payload)
+		if ext.Language == extension.LanguageWasm {
+			ext.Payload = base64.StdEncoding.EncodeToString(payload)
+		}
+	}
+
+	if ext.ID == "" || ext.Payload == "" {
+		return nil, errors.New("manifest has no id or payload")
+	}
+
+	if ext.Language != extension.LanguageJavascript && ext.Language != extension.LanguageTypescript && ext.Language != extension.LanguageWasm {
+		return nil, fmt.Errorf("unsupported language: %s", ext.Language)
+	}
+
+	return &ext, nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type harnessCaller func(method string, input json.RawMessage) interface{}
+
+// newHarnessCaller returns a function calling the methods of the provider and a function releasing the provider.
+// The calling function returns the result of the method, or an error.
+func newHarnessCaller(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (harnessCaller, func(), error) {
+	type idInput struct {
+		ID string `json:"id"`
+	}
+
+	closeProvider := func() {}
+	isWasm := ext.Language == extension.LanguageWasm
+
+	switch ext.Type {
+	case extension.TypeMangaProvider:
+		var provider hibikemanga.Provider
+		var err error
+		if isWasm {
+			var wasmProvider *extension_repo.WasmMangaProvider
+			provider, wasmProvider, err = extension_repo.NewWasmMangaProvider(ext, logger, runtimeManager)
+			if wasmProvider != nil {
+				closeProvider = wasmProvider.Close
+			}
+		} else {
+			provider, _, err = extension_repo.NewGojaMangaProvider(ext, ext.Language, logger, runtimeManager)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(method string, input json.RawMessage) interface{} {
+			switch method {
+			case "search":
+				var opts hibikemanga.SearchOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return provider.Search(opts) })
+			case "findChapters":
+				var in idInput
+				return harnessResult(unmarshalHarnessInput(input, &in), func() (interface{}, error) { return provider.FindChapters(in.ID) })
+			case "findChapterPages":
+				var in idInput
+				return harnessResult(unmarshalHarnessInput(input, &in), func() (interface{}, error) { return provider.FindChapterPages(in.ID) })
+			case "getSettings":
+				return provider.GetSettings()
+			}
+			return fmt.Errorf("unknown method: %s", method)
+		}, closeProvider, nil
+
+	case extension.TypeOnlinestreamProvider:
+		var provider hibikeonlinestream.Provider
+		var err error
+		if isWasm {
+			var wasmProvider *extension_repo.WasmOnlinestreamProvider
+			provider, wasmProvider, err = extension_repo.NewWasmOnlinestreamProvider(ext, logger, runtimeManager)
+			if wasmProvider != nil {
+				closeProvider = wasmProvider.Close
+			}
+		} else {
+			provider, _, err = extension_repo.NewGojaOnlinestreamProvider(ext, ext.Language, logger, runtimeManager)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(method string, input json.RawMessage) interface{} {
+			switch method {
+			case "search":
+				var opts hibikeonlinestream.SearchOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return provider.Search(opts) })
+			case "findEpisodes":
+				var in idInput
+				return harnessResult(unmarshalHarnessInput(input, &in), func() (interface{}, error) { return provider.FindEpisodes(in.ID) })
+			case "findEpisodeServer":
+				var in struct {
+					Episode *hibikeonlinestream.EpisodeDetails `json:"episode"`
+					Server  string                             `json:"server"`
+				}
+				return harnessResult(unmarshalHarnessInput(input, &in), func() (interface{}, error) {
+					return provider.FindEpisodeServer(in.Episode, in.Server)
+				})
+			case "getSettings":
+				return provider.GetSettings()
+			}
+			return fmt.Errorf("unknown method: %s", method)
+		}, closeProvider, nil
+
+	case extension.TypeAnimeTorrentProvider:
+		var provider hibiketorrent.AnimeProvider
+		var err error
+		if isWasm {
+			var wasmProvider *extension_repo.WasmAnimeTorrentProvider
+			provider, wasmProvider, err = extension_repo.NewWasmAnimeTorrentProvider(ext, logger, runtimeManager)
+			if wasmProvider != nil {
+				closeProvider = wasmProvider.Close
+			}
+		} else {
+			provider, _, err = extension_repo.NewGojaAnimeTorrentProvider(ext, ext.Language, logger, runtimeManager)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(method string, input json.RawMessage) interface{} {
+			switch method {
+			case "search":
+				var opts hibiketorrent.AnimeSearchOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return provider.Search(opts) })
+			case "smartSearch":
+				var opts hibiketorrent.AnimeSmartSearchOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return provider.SmartSearch(opts) })
+			case "getTorrentInfoHash":
+				var torrent hibiketorrent.AnimeTorrent
+				return harnessResult(unmarshalHarnessInput(input, &torrent), func() (interface{}, error) { return provider.GetTorrentInfoHash(&torrent) })
+			case "getTorrentMagnetLink":
+				var torrent hibiketorrent.AnimeTorrent
+				return harnessResult(unmarshalHarnessInput(input, &torrent), func() (interface{}, error) { return provider.GetTorrentMagnetLink(&torrent) })
+			case "getLatest":
+				return harnessResult(nil, func() (interface{}, error) { return provider.GetLatest() })
+			case "getSettings":
+				return provider.GetSettings()
+			}
+			return fmt.Errorf("unknown method: %s", method)
+		}, closeProvider, nil
+
+	case extension.TypeSubtitleProvider:
+		var provider hibikesubtitle.Provider
+		var err error
+		if isWasm {
+			var wasmProvider *extension_repo.WasmSubtitleProvider
+			provider, wasmProvider, err = extension_repo.NewWasmSubtitleProvider(ext, logger, runtimeManager)
+			if wasmProvider != nil {
+				closeProvider = wasmProvider.Close
+			}
+		} else {
+			provider, _, err = extension_repo.NewGojaSubtitleProvider(ext, ext.Language, logger, runtimeManager)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(method string, input json.RawMessage) interface{} {
+			switch method {
+			case "search":
+				var opts hibikesubtitle.SearchOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return provider.Search(opts) })
+			case "download":
+				var res hibikesubtitle.SearchResult
+				return harnessResult(unmarshalHarnessInput(input, &res), func() (interface{}, error) { return provider.Download(&res) })
+			case "getSettings":
+				return provider.GetSettings()
+			}
+			return fmt.Errorf("unknown method: %s", method)
+		}, closeProvider, nil
+
+	case extension.TypeTracker:
+		var provider hibiketracker.Provider
+		var err error
+		if isWasm {
+			var wasmProvider *extension_repo.WasmTracker
+			provider, wasmProvider, err = extension_repo.NewWasmTracker(ext, logger, runtimeManager)
+			if wasmProvider != nil {
+				closeProvider = wasmProvider.Close
+			}
+		} else {
+			provider, _, err = extension_repo.NewGojaTracker(ext, ext.Language, logger, runtimeManager)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return func(method string, input json.RawMessage) interface{} {
+			switch method {
+			case "getEntry":
+				var media hibiketracker.Media
+				return harnessResult(unmarshalHarnessInput(input, &media), func() (interface{}, error) { return provider.GetEntry(media) })
+			case "updateEntry":
+				var opts hibiketracker.UpdateEntryOptions
+				return harnessResult(unmarshalHarnessInput(input, &opts), func() (interface{}, error) { return nil, provider.UpdateEntry(opts) })
+			case "getSettings":
+				return provider.GetSettings()
+			}
+			return fmt.Errorf("unknown method: %s", method)
+		}, closeProvider, nil
+	}
+
+	return nil, nil, fmt.Errorf("unsupported extension type: %s", ext.Type)
+}
+
+func unmarshalHarnessInput(input json.RawMessage, target interface{}) error {
+	if len(input) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(input, target); err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+	return nil
+}
+
+func harnessResult(inputErr error, fn func() (interface{}, error)) interface{} {
+	if inputErr != nil {
+		return inputErr
+	}
+	res, err := fn()
+	if err != nil {
+		return err
+	}
+	return res
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// normalizeHarnessOutput returns the output as indented JSON with sorted keys, without the ignored fields.
+// Errors are normalized to {"error": "..."}.
+func normalizeHarnessOutput(output interface{}, ignoreFields []string) string {
+	if err, ok := output.(error); ok {
+		output = map[string]string{"error": err.Error()}
+	}
+
+	b, err := json.Marshal(output)
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"error": fmt.Sprintf("failed to marshal output: %v", err)})
+	}
+
+	return normalizeHarnessJSON(b, ignoreFields)
+}
+
+func normalizeHarnessJSON(b []byte, ignoreFields []string) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return string(b)
+	}
+
+	if len(ignoreFields) > 0 {
+		ignored := make(map[string]struct{}, len(ignoreFields))
+		for _, field := range ignoreFields {
+			ignored[field] = struct{}{}
+		}
+		v = removeHarnessFields(v, ignored)
+	}
+
+	// Maps are marshaled with sorted keys
+	ret, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return string(b)
+	}
+	return string(ret)
+}
+
+func removeHarnessFields(v interface{}, ignored map[string]struct{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := ignored[key]; ok {
+				delete(v, key)
+				continue
+			}
+			v[key] = removeHarnessFields(value, ignored)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = removeHarnessFields(v[i], ignored)
+		}
+	}
+	return v
+}
+
+// firstDifference returns a description of the first line that differs, or an empty string if both are equal.
+func firstDifference(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < max(len(expectedLines), len(actualLines)); i++ {
+		var e, a string
+		if i < len(expectedLines) {
+			e = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			a = actualLines[i]
+		}
+		if e != a {
+			return fmt.Sprintf("line %d: expected %q, got %q", i+1, strings.TrimSpace(e), strings.TrimSpace(a))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+package extension_playground
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"seanime/internal/goja/goja_bindings"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const harnessTestProvider = `
+class Provider {
+	constructor() {
+		this.api = "%s"
+	}
+	getSettings() {
+		return { supportsMultiLanguage: false, supportsMultiScanlator: false }
+	}
+	async search(opts) {
+		const res = await fetch(this.api + "/search?q=" + encodeURIComponent(opts.query))
+		const data = await res.json()
+		return data.map(m => ({ id: m.id, title: m.title, synonyms: [], year: 0, image: m.image }))
+	}
+	async findChapters(id) {
+		const res = await fetch(this.api + "/manga/" + id)
+		const data = await res.json()
+		return data.chapters.map((c, i) => ({ id: c.id, url: "", title: c.title, chapter: String(i + 1), index: i, updatedAt: c.updatedAt }))
+	}
+	async findChapterPages(id) {
+		return []
+	}
+}
+`
+
+func TestRunHarness(t *testing.T) {
+	logger := util.NewLogger()
+
+	updatedAt := "2024-01-01"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			fmt.Fprintf(w, `[{"id": "one-piece", "title": "One Piece", "image": "https://example.com/%s.png"}]`, r.URL.Query().Get("q"))
+		case "/manga/one-piece":
+			fmt.Fprintf(w, `{"chapters": [{"id": "c1", "title": "Romance Dawn", "updatedAt": %q}]}`, updatedAt)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	writeFile("provider.js", fmt.Sprintf(harnessTestProvider, server.URL))
+	writeFile("manifest.json", `{
+		"id": "harness-test",
+		"name": "Harness test",
+		"version": "1.0.0",
+		"type": "manga-provider",
+		"language": "javascript",
+		"payloadURI": "provider.js",
+		"network": {"allowedCIDRs": ["127.0.0.0/8", "::1/128"]}
+	}`)
+	writeFile("suite.json", `{
+		"manifest": "manifest.json",
+		"cases": [
+			{"name": "search", "method": "search", "input": {"query": "One Piece"}},
+			{"name": "chapters", "method": "findChapters", "input": {"id": "one-piece"}, "ignoreFields": ["updatedAt"]},
+			{"name": "unknown method", "method": "findEpisodes", "input": {"id": "one-piece"}}
+		]
+	}`)
+	suitePath := filepath.Join(dir, "suite.json")
+
+	// Record
+	res, err := RunHarness(logger, suitePath, true)
+	require.NoError(t, err)
+	assert.Equal(t, goja_bindings.FetchFixtureModeRecord, res.Mode)
+	assert.Equal(t, 3, res.Passed)
+	assert.Contains(t, res.Cases[0].Output, `"title": "One Piece"`)
+	assert.NotContains(t, res.Cases[1].Output, "updatedAt")
+	assert.Contains(t, res.Cases[2].Output, "unknown method")
+	require.FileExists(t, filepath.Join(dir, "suite.fixtures.json"))
+
+	suite, err := LoadHarnessSuite(suitePath)
+	require.NoError(t, err)
+	for _, c := range suite.Cases {
+		assert.NotEmpty(t, c.Expected, c.Name)
+	}
+
+	// Replay offline
+	server.Close()
+	res, err = RunHarness(logger, suitePath, false)
+	require.NoError(t, err)
+	assert.Equal(t, goja_bindings.FetchFixtureModeReplay, res.Mode)
+	assert.Equal(t, 3, res.Passed)
+	assert.Equal(t, 0, res.Failed)
+
+	// Changed output fails
+	suite.Cases[0].Expected = []byte(`[{"id": "other"}]`)
+	require.NoError(t, saveHarnessSuite(suitePath, suite))
+
+	res, err = RunHarness(logger, suitePath, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Failed)
+	assert.False(t, res.Cases[0].Passed)
+	assert.NotEmpty(t, res.Cases[0].Difference)
+}
+
+func TestNormalizeHarnessOutput(t *testing.T) {
+	a := normalizeHarnessOutput(map[string]interface{}{"b": 1, "a": []interface{}{map[string]interface{}{"date": "x", "n": 1.5}}}, []string{"date"})
+	b := normalizeHarnessJSON([]byte(`{"a":[{"n":1.5}],"b":1}`), nil)
+	assert.Equal(t, b, a)
+	assert.Empty(t, firstDifference(a, b))
+
+	assert.Equal(t, "{\n  \"error\": \"failed\"\n}", normalizeHarnessOutput(fmt.Errorf("failed"), nil))
+	assert.Contains(t, firstDifference("{\n  \"a\": 1\n}", "{\n  \"a\": 2\n}"), "line 2")
+}
+
+func TestCheckHarnessSuitePath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	extDir := filepath.Join(root, "my-extension")
+	require.NoError(t, os.MkdirAll(extDir, 0755))
+
+	writeFile := func(path, content string) string {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	writeFile(filepath.Join(extDir, "provider.js"), "")
+	writeFile(filepath.Join(outside, "provider.js"), "")
+	writeFile(filepath.Join(extDir, "manifest.json"), `{"id": "test", "payloadURI": "provider.js"}`)
+	writeFile(filepath.Join(extDir, "outside-payload.json"), `{"id": "test", "payloadURI": "`+filepath.ToSlash(filepath.Join(outside, "provider.js"))+`"}`)
+	writeFile(filepath.Join(outside, "manifest.json"), `{"id": "test", "payload": "x"}`)
+	writeFile(filepath.Join(outside, "fixtures.json"), `{}`)
+
+	valid := writeFile(filepath.Join(extDir, "suite.json"), `{"manifest": "manifest.json"}`)
+	assert.NoError(t, CheckHarnessSuitePath(root, valid))
+
+	// Suite outside of the directory
+	assert.Error(t, CheckHarnessSuitePath(root, writeFile(filepath.Join(outside, "suite.json"), `{"manifest": "manifest.json"}`)))
+	// Manifest outside of the directory
+	assert.Error(t, CheckHarnessSuitePath(root, writeFile(filepath.Join(extDir, "manifest-escape.json"), `{"manifest": "../../`+filepath.Base(outside)+`/manifest.json"}`)))
+	// Payload outside of the directory
+	assert.Error(t, CheckHarnessSuitePath(root, writeFile(filepath.Join(extDir, "payload-escape.json"), `{"manifest": "outside-payload.json"}`)))
+	// Fixtures outside of the directory
+	assert.Error(t, CheckHarnessSuitePath(root, writeFile(filepath.Join(extDir, "fixtures-escape.json"), `{"manifest": "manifest.json", "fixtures": "../../`+filepath.Base(outside)+`/fixtures.json"}`)))
+}
@@ -5,7 +5,9 @@ import (
 	"seanime/internal/extension"
 	hibikemanga "seanime/internal/extension/hibike/manga"
 	hibikeonlinestream "seanime/internal/extension/hibike/onlinestream"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
 	hibiketorrent "seanime/internal/extension/hibike/torrent"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
 )
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -115,6 +117,32 @@ func (r *Repository) loadBuiltInExtension(ext extension.Extension, provider inte
 		case extension.LanguageJavascript, extension.LanguageTypescript:
 			r.loadBuiltInOnlinestreamProviderExtensionJS(ext)
 		}
+	case extension.TypeSubtitleProvider:
+		switch ext.Language {
+		// Go
+		case extension.LanguageGo:
+			if provider == nil {
+				r.logger.Error().Str("id", ext.ID).Msg("extensions: Built-in subtitle provider extension requires a provider")
+				return
+			}
+			saveUserConfigInProvider(&ext, provider)
+			if subtitleProvider, ok := provider.(hibikesubtitle.Provider); ok {
+				r.loadBuiltInSubtitleProviderExtension(ext, subtitleProvider)
+			}
+		}
+	case extension.TypeTracker:
+		switch ext.Language {
+		// Go
+		case extension.LanguageGo:
+			if provider == nil {
+				r.logger.Error().Str("id", ext.ID).Msg("extensions: Built-in tracker extension requires a provider")
+				return
+			}
+			saveUserConfigInProvider(&ext, provider)
+			if tracker, ok := provider.(hibiketracker.Provider); ok {
+				r.loadBuiltInTrackerExtension(ext, tracker)
+			}
+		}
 	case extension.TypePlugin:
 		// TODO: Implement
 	}
@@ -138,6 +166,16 @@ func (r *Repository) loadBuiltInOnlinestreamProviderExtension(ext extension.Exte
 	r.logger.Debug().Str("id", ext.ID).Msg("extensions: Loaded built-in onlinestream provider extension")
 }
 
+func (r *Repository) loadBuiltInSubtitleProviderExtension(ext extension.Extension, provider hibikesubtitle.Provider) {
+	r.extensionBank.Set(ext.ID, extension.NewSubtitleProviderExtension(&ext, provider))
+	r.logger.Debug().Str("id", ext.ID).Msg("extensions: Loaded built-in subtitle provider extension")
+}
+
+func (r *Repository) loadBuiltInTrackerExtension(ext extension.Extension, provider hibiketracker.Provider) {
+	r.extensionBank.Set(ext.ID, extension.NewTrackerExtension(&ext, provider))
+	r.logger.Debug().Str("id", ext.ID).Msg("extensions: Loaded built-in tracker extension")
+}
+
 func (r *Repository) loadBuiltInOnlinestreamProviderExtensionJS(ext extension.Extension) {
 	// Load the extension as if it was an external extension
 	err := r.loadExternalOnlinestreamExtensionJS(&ext, ext.Language)
@@ -2,6 +2,7 @@ package extension_repo
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,7 +29,12 @@ import (
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func (r *Repository) FetchExternalExtensionData(manifestURI string) (*extension.Extension, error) {
-	return r.fetchExternalExtensionData(manifestURI)
+	ext, err := r.fetchExternalExtensionData(manifestURI)
+	if err != nil {
+		return nil, err
+	}
+	ext.SignatureStatus = r.getSignatureStatus(ext)
+	return ext, nil
 }
 
 func (r *Repository) fetchExternalExtensionData(manifestURI string) (*extension.Extension, error) {
@@ -73,6 +79,10 @@ func (r *Repository) fetchExternalExtensionData(manifestURI string) (*extension.
 			return nil, fmt.Errorf("downloaded payload is empty")
 		}
 		ext.Payload = payloadFromURI
+		// WASM modules are stored base64-encoded in the manifest
+		if ext.Language == extension.LanguageWasm {
+			ext.Payload = base64.StdEncoding.EncodeToString([]byte(payloadFromURI))
+		}
 	}
 
 	// Check manifest
@@ -81,6 +91,12 @@ func (r *Repository) fetchExternalExtensionData(manifestURI string) (*extension.
 		return nil, fmt.Errorf("failed sanity check, %w", err)
 	}
 
+	// Verify the signature if the extension is signed
+	if err = extension.VerifySignature(&ext); err != nil {
+		r.logger.Error().Err(err).Str("uri", manifestURI).Msg("extensions: Invalid signature")
+		return nil, fmt.Errorf("invalid signature, %w", err)
+	}
+
 	// Check if the extension is development mode
 	if ext.IsDevelopment {
 		r.logger.Error().Str("id", ext.ID).Msg("extensions: Development mode enabled, cannot install development mode extensions for security reasons")
@@ -126,7 +142,10 @@ type ExtensionInstallResponse struct {
 	Message string `json:"message"`
 }
 
-func (r *Repository) InstallExternalExtension(manifestURI string) (*ExtensionInstallResponse, error) {
+// InstallExternalExtension installs or updates the extension from the given manifest URI.
+// repository is the URL of the marketplace repository the extension was found in, it can be empty.
+// The replaced version is kept in the history of the extension.
+func (r *Repository) InstallExternalExtension(manifestURI string, repository string) (*ExtensionInstallResponse, error) {
 
 	ext, err := r.fetchExternalExtensionData(manifestURI)
 	if err != nil {
@@ -141,7 +160,25 @@ func (r *Repository) InstallExternalExtension(manifestURI string) (*ExtensionIns
 	// Check if the extension is already installed
 	// i.e. a file with the same ID exists
 	if _, err := os.Stat(filename); err == nil {
+		// Refuse the update if the signing key changed
+		if installed, err := extractExtensionFromFile(filename); err == nil {
+			if err := r.checkSigningKey(installed, ext); err != nil {
+				r.logger.Error().Err(err).Str("id", ext.ID).Msg("extensions: Refused update")
+				return nil, err
+			}
+		}
+
+		// Refuse the update if the extension is pinned to another version
+		if pinned := r.getInstallSettings(ext.ID).PinnedVersion; pinned != "" && pinned != ext.Version {
+			r.logger.Error().Str("id", ext.ID).Str("pinned", pinned).Msg("extensions: Refused update of pinned extension")
+			return nil, fmt.Errorf("%w to version %s, unpin it to update", ErrExtensionPinned, pinned)
+		}
+
 		r.logger.Debug().Str("id", ext.ID).Msg("extensions: Updating extension")
+		// Keep the old extension in the history
+		if err := r.archiveExtension(ext.ID); err != nil {
+			r.logger.Warn().Err(err).Str("id", ext.ID).Msg("extensions: Failed to archive old extension")
+		}
 		// Delete the old extension
 		err := os.Remove(filename)
 		if err != nil {
@@ -167,6 +204,18 @@ func (r *Repository) InstallExternalExtension(manifestURI string) (*ExtensionIns
 		return nil, fmt.Errorf("failed to write extension to file, %w", err)
 	}
 
+	// Remember where the extension was installed from
+	settings := r.getInstallSettings(ext.ID)
+	if !update {
+		settings = &ExtensionInstallSettings{}
+	}
+	if repository != "" {
+		settings.Repository = repository
+	}
+	if err := r.saveInstallSettings(ext.ID, settings); err != nil {
+		r.logger.Warn().Err(err).Str("id", ext.ID).Msg("extensions: Failed to save install settings")
+	}
+
 	// Reload the extensions
 	//r.loadExternalExtensions()
 
@@ -218,8 +267,12 @@ func (r *Repository) UninstallExternalExtension(id string) error {
 		// Delete the plugin data if it was a plugin
 		if ext.Type == extension.TypePlugin {
 			r.deletePluginData(id)
-			r.removePluginFromStoredSettings(id)
 		}
+		// Revoke granted permissions
+		r.removePluginFromStoredSettings(id)
+		// Delete the previous versions
+		r.deleteInstallSettings(id)
+		r.deleteHistory(id)
 	}()
 
 	r.reloadExtension(id)
@@ -251,31 +304,55 @@ func (r *Repository) checkForUpdates() (ret []UpdateData) {
 				return
 			}
 
+			// Skip pinned extensions
+			settings := r.getInstallSettings(ext.GetID())
+			if settings.PinnedVersion != "" {
+				return
+			}
+
+			// Follow the selected channel
+			manifestURI := ext.GetManifestURI()
+			if settings.Channel != "" {
+				if installed, err := extractExtensionFromFile(filepath.Join(r.extensionDir, ext.GetID()+".json")); err == nil {
+					manifestURI = r.getUpdateManifestURI(installed, settings)
+				}
+			}
+
 			// Get the extension data from the repository
-			extFromRepo, err := r.fetchExternalExtensionData(ext.GetManifestURI())
+			extFromRepo, err := r.fetchExternalExtensionData(manifestURI)
 			if err != nil {
-				r.logger.Error().Err(err).Str("id", ext.GetID()).Str("url", ext.GetManifestURI()).Msg("extensions: Failed to fetch extension data while checking for update")
+				r.logger.Error().Err(err).Str("id", ext.GetID()).Str("url", manifestURI).Msg("extensions: Failed to fetch extension data while checking for update")
 				return
 			}
 
 			// Sanity check, this checks for the version too
 			if err = manifestSanityCheck(extFromRepo); err != nil {
-				r.logger.Error().Err(err).Str("id", ext.GetID()).Str("url", ext.GetManifestURI()).Msg("extensions: Failed sanity check while checking for update")
+				r.logger.Error().Err(err).Str("id", ext.GetID()).Str("url", manifestURI).Msg("extensions: Failed sanity check while checking for update")
 				return
 			}
 
 			if extFromRepo.ID != ext.GetID() {
-				r.logger.Warn().Str("id", ext.GetID()).Str("newID", extFromRepo.ID).Str("url", ext.GetManifestURI()).Msg("extensions: Extension ID changed while checking for update")
+				r.logger.Warn().Str("id", ext.GetID()).Str("newID", extFromRepo.ID).Str("url", manifestURI).Msg("extensions: Extension ID changed while checking for update")
+				return
+			}
+
+			if err = r.checkSigningKey(extension.ToExtensionData(ext), extFromRepo); err != nil {
+				r.logger.Warn().Err(err).Str("id", ext.GetID()).Str("url", manifestURI).Msg("extensions: Ignoring update")
 				return
 			}
 
 			// If there's an update, send the update data to the channel
 			if extFromRepo.Version != ext.GetVersion() {
+				// Channel manifests are installed from the channel URI, since they can declare the default URI
+				updateURI := extFromRepo.ManifestURI
+				if settings.Channel != "" || updateURI == "" {
+					updateURI = manifestURI
+				}
 				mu.Lock()
 				ret = append(ret, UpdateData{
 					ExtensionID: extFromRepo.ID,
 					Version:     extFromRepo.Version,
-					ManifestURI: extFromRepo.ManifestURI,
+					ManifestURI: updateURI,
 				})
 				mu.Unlock()
 			}
@@ -324,6 +401,12 @@ func (r *Repository) UpdateExtensionCode(id string, payload string) error {
 		return fmt.Errorf("failed to read extension file, %w", err)
 	}
 
+	// The code of WASM extensions is compiled, it cannot be edited
+	if ext.Language == extension.LanguageWasm {
+		r.logger.Error().Str("id", id).Msg("extensions: Cannot update the code of a WASM extension")
+		return fmt.Errorf("cannot update the code of a WASM extension")
+	}
+
 	// Update the payload
 	ext.Payload = payload
 
@@ -385,6 +468,18 @@ func (r *Repository) interruptExternalGojaExtensionVMs() {
 	r.logger.Debug().Int("count", count).Msg("extensions: Killed Goja VMs")
 }
 
+// closeWasmExtensions closes the runtimes of all loaded WASM extensions & clears the WASM extensions map.
+func (r *Repository) closeWasmExtensions() {
+	defer util.HandlePanicInModuleThen("extension_repo/closeWasmExtensions", func() {})
+
+	for _, key := range r.wasmExtensions.Keys() {
+		if wasmExt, ok := r.wasmExtensions.Get(key); ok {
+			wasmExt.Close()
+			r.wasmExtensions.Delete(key)
+		}
+	}
+}
+
 // unloadExternalExtensions unloads all external extensions from the extension banks.
 func (r *Repository) unloadExternalExtensions() {
 	r.logger.Trace().Msg("extensions: Unloading external extensions")
@@ -413,6 +508,7 @@ func (r *Repository) loadExternalExtensions() {
 
 	// Interrupt all Goja VMs
 	r.interruptExternalGojaExtensionVMs()
+	r.closeWasmExtensions()
 
 	// Unload all external extensions
 	r.unloadExternalExtensions()
@@ -428,6 +524,10 @@ func (r *Repository) loadExternalExtensions() {
 		}
 
 		if d.IsDir() {
+			// Skip the previous versions of the extensions
+			if d.Name() == historyDirName {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -536,18 +636,22 @@ func (r *Repository) loadExternalExtension(filePath string) {
 			return
 		}
 		ext.Payload = string(payload)
+		if ext.Language == extension.LanguageWasm {
+			ext.Payload = base64.StdEncoding.EncodeToString(payload)
+		}
 		r.logger.Debug().Str("id", ext.ID).Msg("extensions: Loaded payload from file")
 	}
 
 	// +
-	// | Check plugin permissions
+	// | Check plugin and network permissions
 	// +
 
-	if ext.Type == extension.TypePlugin && !ext.IsDevelopment {
-		if ext.Plugin == nil { // Shouldn't happen because of sanity check, but just in case
-			r.logger.Error().Str("id", ext.ID).Msg("extensions: Plugin manifest is missing plugin object")
-			return
-		}
+	if ext.Type == extension.TypePlugin && ext.Plugin == nil { // Shouldn't happen because of sanity check, but just in case
+		r.logger.Error().Str("id", ext.ID).Msg("extensions: Plugin manifest is missing plugin object")
+		return
+	}
+
+	if !ext.IsDevelopment {
 		permissionErr := r.checkPluginPermissions(ext)
 		if permissionErr != nil {
 			r.invalidExtensions.Set(invalidExtensionID, &extension.InvalidExtension{
@@ -556,9 +660,9 @@ func (r *Repository) loadExternalExtension(filePath string) {
 				Path:                        filePath,
 				Code:                        extension.InvalidExtensionPluginPermissionsNotGranted,
 				Extension:                   *ext,
-				PluginPermissionDescription: ext.Plugin.Permissions.GetDescription(),
+				PluginPermissionDescription: extension.GetPermissionDescription(ext),
 			})
-			r.logger.Warn().Err(permissionErr).Str("id", ext.ID).Msg("extensions: Plugin permissions not granted. Please grant the permissions in the extension page.")
+			r.logger.Warn().Err(permissionErr).Str("id", ext.ID).Msg("extensions: Permissions not granted. Please grant the permissions in the extension page.")
 			return
 		}
 	}
@@ -599,6 +703,12 @@ func (r *Repository) loadExternalExtension(filePath string) {
 	case extension.TypeAnimeTorrentProvider:
 		// Load torrent provider
 		loadingErr = r.loadExternalAnimeTorrentProviderExtension(ext)
+	case extension.TypeSubtitleProvider:
+		// Load subtitle provider
+		loadingErr = r.loadExternalSubtitleProviderExtension(ext)
+	case extension.TypeTracker:
+		// Load tracker
+		loadingErr = r.loadExternalTrackerExtension(ext)
 	case extension.TypePlugin:
 		// Load plugin
 		loadingErr = r.loadPlugin(ext)
@@ -644,8 +754,15 @@ func (r *Repository) reloadExtension(id string) {
 		r.logger.Trace().Str("id", id).Msg("extensions: Killed extension's runtime")
 		r.gojaExtensions.Delete(id)
 	}
+	// Close the WASM runtime if it exists
+	if wasmExt, ok := r.wasmExtensions.Get(id); ok {
+		wasmExt.Close()
+		r.wasmExtensions.Delete(id)
+	}
 	// Remove from invalid extensions
 	r.invalidExtensions.Delete(id)
+	// Give the extension a fresh start if it was disabled after resource limit violations
+	r.gojaRuntimeManager.ResetLimiter(id)
 
 	time.Sleep(200 * time.Millisecond)
 
@@ -16,6 +16,8 @@ func (r *Repository) loadExternalAnimeTorrentProviderExtension(ext *extension.Ex
 	switch ext.Language {
 	case extension.LanguageJavascript, extension.LanguageTypescript:
 		err = r.loadExternalAnimeTorrentProviderExtensionJS(ext, ext.Language)
+	case extension.LanguageWasm:
+		err = r.loadExternalAnimeTorrentProviderExtensionWasm(ext)
 	default:
 		err = fmt.Errorf("unsupported language: %v", ext.Language)
 	}
@@ -39,3 +41,16 @@ func (r *Repository) loadExternalAnimeTorrentProviderExtensionJS(ext *extension.
 	r.gojaExtensions.Set(ext.ID, gojaExt)
 	return nil
 }
+
+func (r *Repository) loadExternalAnimeTorrentProviderExtensionWasm(ext *extension.Extension) error {
+	provider, wasmExt, err := NewWasmAnimeTorrentProvider(ext, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewAnimeTorrentProviderExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.wasmExtensions.Set(ext.ID, wasmExt)
+	return nil
+}
@@ -15,6 +15,8 @@ func (r *Repository) loadExternalMangaExtension(ext *extension.Extension) (err e
 	switch ext.Language {
 	case extension.LanguageJavascript, extension.LanguageTypescript:
 		err = r.loadExternalMangaExtensionJS(ext, ext.Language)
+	case extension.LanguageWasm:
+		err = r.loadExternalMangaExtensionWasm(ext)
 	}
 
 	if err != nil {
@@ -36,3 +38,16 @@ func (r *Repository) loadExternalMangaExtensionJS(ext *extension.Extension, lang
 	r.gojaExtensions.Set(ext.ID, gojaExt)
 	return nil
 }
+
+func (r *Repository) loadExternalMangaExtensionWasm(ext *extension.Extension) error {
+	provider, wasmExt, err := NewWasmMangaProvider(ext, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewMangaProviderExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.wasmExtensions.Set(ext.ID, wasmExt)
+	return nil
+}
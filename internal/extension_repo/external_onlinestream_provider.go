@@ -16,6 +16,8 @@ func (r *Repository) loadExternalOnlinestreamProviderExtension(ext *extension.Ex
 	switch ext.Language {
 	case extension.LanguageJavascript, extension.LanguageTypescript:
 		err = r.loadExternalOnlinestreamExtensionJS(ext, ext.Language)
+	case extension.LanguageWasm:
+		err = r.loadExternalOnlinestreamExtensionWasm(ext)
 	default:
 		err = fmt.Errorf("unsupported language: %v", ext.Language)
 	}
@@ -39,3 +41,16 @@ func (r *Repository) loadExternalOnlinestreamExtensionJS(ext *extension.Extensio
 	r.gojaExtensions.Set(ext.ID, gojaExt)
 	return nil
 }
+
+func (r *Repository) loadExternalOnlinestreamExtensionWasm(ext *extension.Extension) error {
+	provider, wasmExt, err := NewWasmOnlinestreamProvider(ext, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewOnlinestreamProviderExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.wasmExtensions.Set(ext.ID, wasmExt)
+	return nil
+}
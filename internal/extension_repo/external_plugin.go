@@ -64,18 +64,12 @@ func (r *Repository) GrantPluginPermissions(pluginId string) {
 		return
 	}
 
-	// Check if the extension is a plugin
-	if ext.Type != extension.TypePlugin {
-		r.logger.Error().Str("id", pluginId).Msg("extensions: Extension is not a plugin")
-		return
-	}
-
-	// Grant the plugin permissions
-	permissionHash := ext.Plugin.Permissions.GetHash()
+	// Grant the permissions of the plugin, or the network permissions of other extensions
+	permissionHash := extension.GetPermissionHash(ext)
 
 	r.setPluginGrantedPermissions(pluginId, permissionHash)
 
-	r.logger.Debug().Str("id", pluginId).Msg("extensions: Granted plugin permissions")
+	r.logger.Debug().Str("id", pluginId).Msg("extensions: Granted extension permissions")
 
 	// Reload the extension
 	r.reloadExtension(pluginId)
@@ -112,19 +106,17 @@ func (r *Repository) removePluginFromStoredSettings(pluginId string) {
 	r.fileCacher.SetPerm(bucket, PluginSettingsKey, settings)
 }
 
+// checkPluginPermissions checks that the permissions of the extension have been granted.
+// This covers the permissions of plugins and the network permissions of all extensions.
 func (r *Repository) checkPluginPermissions(ext *extension.Extension) (err error) {
 	defer util.HandlePanicInModuleWithError("extension_repo/checkPluginPermissions", &err)
 
-	if ext.Type != extension.TypePlugin {
-		return nil
-	}
-
-	if ext.Plugin == nil {
+	if ext.Type == extension.TypePlugin && ext.Plugin == nil {
 		return nil
 	}
 
-	// Get current plugin permission hash
-	pluginPermissionHash := ext.Plugin.Permissions.GetHash()
+	// Get current permission hash
+	pluginPermissionHash := extension.GetPermissionHash(ext)
 
 	// If the plugin has no permissions, skip the check
 	if pluginPermissionHash == "" {
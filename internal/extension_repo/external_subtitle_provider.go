@@ -0,0 +1,56 @@
+package extension_repo
+
+import (
+	"fmt"
+	"seanime/internal/extension"
+	"seanime/internal/util"
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Subtitles
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *Repository) loadExternalSubtitleProviderExtension(ext *extension.Extension) (err error) {
+	defer util.HandlePanicInModuleWithError("extension_repo/loadExternalSubtitleProviderExtension", &err)
+
+	switch ext.Language {
+	case extension.LanguageJavascript, extension.LanguageTypescript:
+		err = r.loadExternalSubtitleExtensionJS(ext, ext.Language)
+	case extension.LanguageWasm:
+		err = r.loadExternalSubtitleExtensionWasm(ext)
+	default:
+		err = fmt.Errorf("unsupported language: %v", ext.Language)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) loadExternalSubtitleExtensionJS(ext *extension.Extension, language extension.Language) error {
+	provider, gojaExt, err := NewGojaSubtitleProvider(ext, language, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewSubtitleProviderExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.gojaExtensions.Set(ext.ID, gojaExt)
+	return nil
+}
+
+func (r *Repository) loadExternalSubtitleExtensionWasm(ext *extension.Extension) error {
+	provider, wasmExt, err := NewWasmSubtitleProvider(ext, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewSubtitleProviderExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.wasmExtensions.Set(ext.ID, wasmExt)
+	return nil
+}
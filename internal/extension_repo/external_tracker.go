@@ -0,0 +1,56 @@
+package extension_repo
+
+import (
+	"fmt"
+	"seanime/internal/extension"
+	"seanime/internal/util"
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Trackers
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *Repository) loadExternalTrackerExtension(ext *extension.Extension) (err error) {
+	defer util.HandlePanicInModuleWithError("extension_repo/loadExternalTrackerExtension", &err)
+
+	switch ext.Language {
+	case extension.LanguageJavascript, extension.LanguageTypescript:
+		err = r.loadExternalTrackerExtensionJS(ext, ext.Language)
+	case extension.LanguageWasm:
+		err = r.loadExternalTrackerExtensionWasm(ext)
+	default:
+		err = fmt.Errorf("unsupported language: %v", ext.Language)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) loadExternalTrackerExtensionJS(ext *extension.Extension, language extension.Language) error {
+	provider, gojaExt, err := NewGojaTracker(ext, language, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewTrackerExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.gojaExtensions.Set(ext.ID, gojaExt)
+	return nil
+}
+
+func (r *Repository) loadExternalTrackerExtensionWasm(ext *extension.Extension) error {
+	provider, wasmExt, err := NewWasmTracker(ext, r.logger, r.gojaRuntimeManager)
+	if err != nil {
+		return err
+	}
+
+	// Add the extension to the map
+	retExt := extension.NewTrackerExtension(ext, provider)
+	r.extensionBank.Set(ext.ID, retExt)
+	r.wasmExtensions.Set(ext.ID, wasmExt)
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"reflect"
 	"seanime/internal/extension"
 	goja_bindings "seanime/internal/goja/goja_bindings"
+	"seanime/internal/goja/goja_runtime"
 	"seanime/internal/library/anime"
 	"seanime/internal/plugin"
 	"sync"
@@ -58,7 +60,22 @@ func BindUserConfig(vm *goja.Runtime, ext *extension.Extension, logger *zerolog.
 
 // ShareBinds binds the shared bindings to the VM
 // This is called once per VM
-func ShareBinds(vm *goja.Runtime, logger *zerolog.Logger) {
+//   - ext can be nil, in which case fetch uses the default network permissions
+//   - runtimeManager can be nil, in which case outstanding fetch requests are only limited per VM
+func ShareBinds(vm *goja.Runtime, logger *zerolog.Logger, ext *extension.Extension, runtimeManager *goja_runtime.Manager) {
+	var networkPermissions *extension.NetworkPermissions
+	var limiter *goja_runtime.Limiter
+	var wrapFetchTransport func(http.RoundTripper) http.RoundTripper
+	if ext != nil {
+		networkPermissions = ext.Network
+		if runtimeManager != nil {
+			limiter = runtimeManager.GetLimiter(ext.ID)
+		}
+	}
+	if runtimeManager != nil {
+		wrapFetchTransport = runtimeManager.GetFetchTransportWrapper()
+	}
+
 	registry := new(gojarequire.Registry)
 	registry.Enable(vm)
 
@@ -72,7 +89,16 @@ func ShareBinds(vm *goja.Runtime, logger *zerolog.Logger) {
 	}{
 		{"url", func(vm *goja.Runtime) error { gojaurl.Enable(vm); return nil }},
 		{"buffer", func(vm *goja.Runtime) error { gojabuffer.Enable(vm); return nil }},
-		{"fetch", func(vm *goja.Runtime) error { goja_bindings.BindFetch(vm); return nil }},
+		{"fetch", func(vm *goja.Runtime) error {
+			f := goja_bindings.BindFetch(vm, networkPermissions)
+			if sem := limiter.FetchSemaphore(); sem != nil {
+				f.SetSharedSemaphore(sem)
+			}
+			if wrapFetchTransport != nil {
+				f.WrapTransport(wrapFetchTransport)
+			}
+			return nil
+		}},
 		{"console", func(vm *goja.Runtime) error { goja_bindings.BindConsole(vm, logger); return nil }},
 		{"formData", func(vm *goja.Runtime) error { goja_bindings.BindFormData(vm); return nil }},
 		{"document", func(vm *goja.Runtime) error { goja_bindings.BindDocument(vm); return nil }},
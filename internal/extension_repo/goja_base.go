@@ -48,7 +48,7 @@ func initializeProviderBase(ext *extension.Extension, language extension.Languag
 		vm := goja.New()
 		vm.SetParserOptions(parser.WithDisableSourceMaps)
 		// Bind the shared bindings
-		ShareBinds(vm, logger)
+		ShareBinds(vm, logger, ext, runtimeManager)
 		BindUserConfig(vm, ext, logger)
 		return vm
 	}
@@ -77,15 +77,34 @@ func (g *gojaProviderBase) callClassMethod(ctx context.Context, methodName strin
 		ctx = context.Background()
 	}
 
-	vm, err := g.pool.Get(ctx)
+	// Enforce the resource limits of the extension
+	call, err := g.runtimeManager.GetLimiter(g.ext.ID).StartCall(ctx)
+	if err != nil {
+		g.logger.Error().Err(err).Str("id", g.ext.ID).Str("method", methodName).Msg("extension: Call refused")
+		return nil, err
+	}
+
+	vm, err := g.pool.Get(call.Context())
 	if err != nil {
 		g.logger.Error().Err(err).Str("id", g.ext.ID).Msg("extension: Failed to get VM")
-		return nil, fmt.Errorf("failed to get VM: %w", err)
+		return nil, call.End(fmt.Errorf("failed to get VM: %w", err))
 	}
 	defer func() {
 		g.pool.Put(vm)
 	}()
 
+	call.Watch(vm)
+
+	ret, err := g.runClassMethod(call.Context(), vm, methodName, args...)
+	if err = call.End(err); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// runClassMethod calls the method of a new Provider instance and waits for the result if it's a promise.
+func (g *gojaProviderBase) runClassMethod(ctx context.Context, vm *goja.Runtime, methodName string, args ...interface{}) (goja.Value, error) {
+
 	// Ensure the Provider class is defined only once per VM
 	providerType, err := vm.RunString("typeof Provider")
 	if err != nil {
@@ -134,7 +153,8 @@ func (g *gojaProviderBase) callClassMethod(ctx context.Context, methodName strin
 
 	// g.runtimeManager.PrintBasePoolMetrics()
 
-	return result, nil
+	// Wait for the promise while the call is still watched
+	return g.waitForPromiseWithContext(ctx, result)
 }
 
 // unmarshalValue unmarshals a Goja value to a target interface
@@ -158,23 +178,29 @@ func (g *gojaProviderBase) unmarshalValue(value goja.Value, target interface{})
 
 // waitForPromise waits for a promise to resolve and returns the result
 func (g *gojaProviderBase) waitForPromise(value goja.Value) (goja.Value, error) {
+	return g.waitForPromiseWithContext(context.Background(), value)
+}
+
+// waitForPromiseWithContext waits for a promise to resolve and returns the result.
+// It returns the context's error if the context is done before the promise settles.
+func (g *gojaProviderBase) waitForPromiseWithContext(ctx context.Context, value goja.Value) (goja.Value, error) {
 	if value == nil {
 		return nil, fmt.Errorf("cannot wait for nil promise")
 	}
 
 	// If the value is a promise, wait for it to resolve
 	if promise, ok := value.Export().(*goja.Promise); ok {
-		doneCh := make(chan struct{})
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
 
 		// Wait for the promise to resolve
-		go func() {
-			for promise.State() == goja.PromiseStatePending {
-				time.Sleep(10 * time.Millisecond)
+		for promise.State() == goja.PromiseStatePending {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-ticker.C:
 			}
-			close(doneCh)
-		}()
-
-		<-doneCh
+		}
 
 		// If the promise is rejected, return the error
 		if promise.State() == goja.PromiseStateRejected {
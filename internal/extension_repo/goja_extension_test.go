@@ -5,12 +5,15 @@ import (
 	"seanime/internal/extension"
 	hibikemanga "seanime/internal/extension/hibike/manga"
 	hibikeonlinestream "seanime/internal/extension/hibike/onlinestream"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
 	"seanime/internal/extension_repo"
 	"seanime/internal/goja/goja_runtime"
 	"seanime/internal/util"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -160,3 +163,104 @@ func TestGojaOnlinestreamExtension2(t *testing.T) {
 
 	spew.Dump(server)
 }
+
+func TestGojaSubtitleExtension(t *testing.T) {
+	runtimeManager := goja_runtime.NewManager(util.NewLogger())
+	// Get the script
+	filepath := "./goja_subtitle_test/my-subtitle-provider.ts"
+	fileB, err := os.ReadFile(filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ext := &extension.Extension{
+		ID:          "my-subtitle-provider",
+		Name:        "MySubtitleProvider",
+		Version:     "0.1.0",
+		ManifestURI: "",
+		Language:    extension.LanguageTypescript,
+		Type:        extension.TypeSubtitleProvider,
+		Description: "",
+		Author:      "",
+		Payload:     string(fileB),
+	}
+
+	// Create the provider
+	provider, _, err := extension_repo.NewGojaSubtitleProvider(ext, ext.Language, util.NewLogger(), runtimeManager)
+	require.NoError(t, err)
+
+	settings := provider.GetSettings()
+	require.Equal(t, []string{"en", "fr"}, settings.Languages)
+	require.True(t, settings.SupportsFilenameSearch)
+
+	results, err := provider.Search(hibikesubtitle.SearchOptions{
+		Media:         hibikesubtitle.Media{ID: 21, RomajiTitle: "One Piece"},
+		EpisodeNumber: 3,
+		Language:      "fr",
+		Filename:      "[Group] One Piece - 03.mkv",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "my-subtitle-provider", results[0].Provider)
+	require.Equal(t, "One Piece - 03", results[0].Name)
+	require.True(t, results[0].IsFilenameMatch)
+
+	subtitle, err := provider.Download(results[0])
+	require.NoError(t, err)
+	require.Equal(t, "my-subtitle-provider", subtitle.Provider)
+	require.Equal(t, "fr", subtitle.Language)
+	require.Contains(t, subtitle.Content, "Episode 3 (fr)")
+}
+
+func TestGojaTrackerExtension(t *testing.T) {
+	runtimeManager := goja_runtime.NewManager(util.NewLogger())
+	// Get the script
+	filepath := "./goja_tracker_test/my-tracker.ts"
+	fileB, err := os.ReadFile(filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ext := &extension.Extension{
+		ID:          "my-tracker",
+		Name:        "MyTracker",
+		Version:     "0.1.0",
+		ManifestURI: "",
+		Language:    extension.LanguageTypescript,
+		Type:        extension.TypeTracker,
+		Description: "",
+		Author:      "",
+		Payload:     string(fileB),
+	}
+
+	// Create the provider
+	provider, _, err := extension_repo.NewGojaTracker(ext, ext.Language, util.NewLogger(), runtimeManager)
+	require.NoError(t, err)
+
+	settings := provider.GetSettings()
+	require.True(t, settings.SupportsAnime)
+	require.False(t, settings.SupportsManga)
+
+	media := hibiketracker.Media{Type: hibiketracker.MediaTypeAnime, ID: 21, IDMal: lo.ToPtr(23)}
+
+	entry, err := provider.GetEntry(media)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	require.Equal(t, hibiketracker.StatusCurrent, entry.Status)
+	require.Equal(t, 3, entry.Progress)
+	require.Equal(t, 80, entry.Score)
+	require.Equal(t, 2024, *entry.StartedAt.Year)
+	require.Nil(t, entry.CompletedAt)
+
+	// Not in the list
+	entry, err = provider.GetEntry(hibiketracker.Media{Type: hibiketracker.MediaTypeAnime, ID: 21})
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	err = provider.UpdateEntry(hibiketracker.UpdateEntryOptions{Media: media, Progress: lo.ToPtr(4)})
+	require.NoError(t, err)
+
+	// Errors thrown by the extension are returned
+	err = provider.UpdateEntry(hibiketracker.UpdateEntryOptions{Media: media, Progress: lo.ToPtr(-1)})
+	require.ErrorContains(t, err, "invalid progress")
+}
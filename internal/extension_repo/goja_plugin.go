@@ -140,7 +140,7 @@ func NewGojaPlugin(
 
 	// 2. Create a new loader for the plugin
 	// Bind shared APIs to the loader
-	ShareBinds(p.loader, logger)
+	ShareBinds(p.loader, logger, ext, runtimeManager)
 	BindUserConfig(p.loader, ext, logger)
 	// Bind hooks to the loader
 	p.bindHooks()
@@ -160,7 +160,7 @@ func NewGojaPlugin(
 	var err error
 	p.pool, err = runtimeManager.GetOrCreatePrivatePool(ext.ID, func() *goja.Runtime {
 		runtime := goja.New()
-		ShareBinds(runtime, logger)
+		ShareBinds(runtime, logger, ext, runtimeManager)
 		BindUserConfig(runtime, ext, logger)
 		p.BindPluginAPIs(runtime, logger)
 		return runtime
@@ -176,7 +176,7 @@ func NewGojaPlugin(
 	uiVM := goja.New()
 	uiVM.SetParserOptions(parser.WithDisableSourceMaps)
 	// Bind shared APIs
-	ShareBinds(uiVM, logger)
+	ShareBinds(uiVM, logger, ext, runtimeManager)
 	BindUserConfig(uiVM, ext, logger)
 	// Bind the store to the UI VM
 	p.BindPluginAPIs(uiVM, logger)
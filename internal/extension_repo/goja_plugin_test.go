@@ -43,6 +43,7 @@ type TestPluginOptions struct {
 	Payload     string
 	Language    extension.Language
 	Permissions extension.PluginPermissions
+	Network     *extension.NetworkPermissions
 	PoolSize    int
 	SetupHooks  bool
 }
@@ -73,6 +74,7 @@ func InitTestPlugin(t testing.TB, opts TestPluginOptions) (*GojaPlugin, *zerolog
 		Payload:  opts.Payload,
 		Language: opts.Language,
 		Plugin:   &extension.PluginManifest{},
+		Network:  opts.Network,
 	}
 
 	if len(opts.Permissions.Scopes) > 0 {
@@ -384,6 +386,8 @@ func TestGojaPluginUIAndHooks(t *testing.T) {
 
 	opts := DefaultTestPluginOptions()
 	opts.Payload = payload
+	// Allow the plugin to reach the local test server
+	opts.Network = &extension.NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8", "::1/128"}}
 
 	_, _, manager, anilistPlatform, _, err := InitTestPlugin(t, opts)
 	require.NoError(t, err)
@@ -185,6 +185,9 @@ declare namespace $app {
     /**
      * @event PostUpdateEntryEvent
      * @file internal/platforms/anilist_platform/hook_events.go
+     * @description
+     * PostUpdateEntryEvent is triggered after an entry is updated.
+     * The fields are the values sent to AniList, after the changes made in PreUpdateEntryEvent.
      */
     function onPostUpdateEntry(cb: (event: PostUpdateEntryEvent) => void): void;
 
@@ -192,6 +195,11 @@ declare namespace $app {
         next(): void;
 
         mediaId?: number;
+        status?: AL_MediaListStatus;
+        scoreRaw?: number;
+        progress?: number;
+        startedAt?: AL_FuzzyDateInput;
+        completedAt?: AL_FuzzyDateInput;
     }
 
     /**
@@ -217,6 +225,9 @@ declare namespace $app {
     /**
      * @event PostUpdateEntryProgressEvent
      * @file internal/platforms/anilist_platform/hook_events.go
+     * @description
+     * PostUpdateEntryProgressEvent is triggered after an entry's progress is updated.
+     * The fields are the values sent to AniList, after the changes made in PreUpdateEntryProgressEvent.
      */
     function onPostUpdateEntryProgress(cb: (event: PostUpdateEntryProgressEvent) => void): void;
 
@@ -224,6 +235,9 @@ declare namespace $app {
         next(): void;
 
         mediaId?: number;
+        progress?: number;
+        totalCount?: number;
+        status?: AL_MediaListStatus;
     }
 
     /**
@@ -41,6 +41,11 @@ declare namespace $ui {
          */
         notification: Notification
 
+        /**
+         * HTTP routes, requires the "http-routes" permission
+         */
+        http: HTTP
+
         /**
          * Manga
          */
@@ -1024,6 +1029,80 @@ declare namespace $ui {
         onReady(callback: () => void): void
     }
 
+    interface HTTP {
+        /**
+         * Registers a GET route under /api/v1/plugins/{id}
+         * @param path - The path of the route, ":name" captures a segment and a trailing "*" captures the rest of the path
+         * @param handler - Called on each request, can return a promise
+         */
+        get(path: string, handler: HTTPHandler): void
+
+        /**
+         * Registers a POST route under /api/v1/plugins/{id}
+         */
+        post(path: string, handler: HTTPHandler): void
+
+        /**
+         * Registers a PUT route under /api/v1/plugins/{id}
+         */
+        put(path: string, handler: HTTPHandler): void
+
+        /**
+         * Registers a PATCH route under /api/v1/plugins/{id}
+         */
+        patch(path: string, handler: HTTPHandler): void
+
+        /**
+         * Registers a DELETE route under /api/v1/plugins/{id}
+         */
+        delete(path: string, handler: HTTPHandler): void
+
+        /**
+         * Registers a route for the given method, "*" matches all methods
+         */
+        handle(method: string, path: string, handler: HTTPHandler): void
+    }
+
+    /**
+     * Handles a request to a plugin route.
+     * Returning nothing sends "204 No Content", returning a string sends it as text.
+     * Requests time out after 30 seconds.
+     */
+    type HTTPHandler = (req: HTTPRequest) => HTTPResponse | string | void | Promise<HTTPResponse | string | void>
+
+    interface HTTPRequest {
+        /** Uppercase method */
+        method: string
+        /** Path relative to /api/v1/plugins/{id} */
+        path: string
+        /** Captured path params, the wildcard is stored in "*" */
+        params: Record<string, string>
+        query: Record<string, string>
+        /** Request headers, cookies and the Authorization header are not included */
+        headers: Record<string, string>
+        /** Raw body */
+        body: string
+
+        /**
+         * Parses the body as JSON
+         * @throws TypeError if the body is not valid JSON
+         */
+        json<T = any>(): T
+    }
+
+    interface HTTPResponse {
+        /** Defaults to 200 */
+        status?: number
+        /**
+         * Response headers.
+         * Only caching headers, Content-Disposition, Content-Language and custom "X-" headers are sent, cookies and security headers are dropped.
+         * Content types that could run scripts (e.g. HTML, SVG, XML) are sent as "text/plain" or "application/octet-stream".
+         */
+        headers?: Record<string, string>
+        /** Strings are sent as is, other values are encoded as JSON */
+        body?: any
+    }
+
     interface Notification {
         /**
          * Sends a system notification
@@ -694,6 +694,7 @@ declare namespace $downloader {
      * @param options Download options
      * @returns A unique download ID
      * @throws Error if the destination path is not authorized for writing
+     * @throws Error if the host is not allowed by the network permissions of the plugin
      */
     function download(url: string, destination: string, options?: DownloadOptions): string
 
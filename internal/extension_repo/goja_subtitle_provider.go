@@ -0,0 +1,98 @@
+package extension_repo
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/extension"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type GojaSubtitleProvider struct {
+	*gojaProviderBase
+}
+
+func NewGojaSubtitleProvider(ext *extension.Extension, language extension.Language, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibikesubtitle.Provider, *GojaSubtitleProvider, error) {
+	base, err := initializeProviderBase(ext, language, logger, runtimeManager)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &GojaSubtitleProvider{
+		gojaProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *GojaSubtitleProvider) Search(opts hibikesubtitle.SearchOptions) (ret []*hibikesubtitle.SearchResult, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Search", &err)
+
+	method, err := g.callClassMethod(context.Background(), "search", structToMap(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call search method: %w", err)
+	}
+
+	promiseRes, err := g.waitForPromise(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for promise: %w", err)
+	}
+
+	ret = make([]*hibikesubtitle.SearchResult, 0)
+	err = g.unmarshalValue(promiseRes, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search results: %w", err)
+	}
+
+	for _, result := range ret {
+		result.Provider = g.ext.ID
+	}
+
+	return ret, nil
+}
+
+func (g *GojaSubtitleProvider) Download(result *hibikesubtitle.SearchResult) (ret *hibikesubtitle.Subtitle, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Download", &err)
+
+	method, err := g.callClassMethod(context.Background(), "download", structToMap(result))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call download method: %w", err)
+	}
+
+	promiseRes, err := g.waitForPromise(method)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.unmarshalValue(promiseRes, &ret)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("no subtitle returned")
+	}
+
+	ret.Provider = g.ext.ID
+
+	return
+}
+
+func (g *GojaSubtitleProvider) GetSettings() (ret hibikesubtitle.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibikesubtitle.Settings{}
+	})
+
+	method, err := g.callClassMethod(context.Background(), "getSettings")
+	if err != nil {
+		return
+	}
+
+	err = g.unmarshalValue(method, &ret)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,34 @@
+/// <reference path="./subtitle-provider.d.ts" />
+
+class Provider {
+
+    getSettings(): Settings {
+        return {
+            languages: ["en", "fr"],
+            supportsFilenameSearch: true,
+        }
+    }
+
+    async search(opts: SearchOptions): Promise<SearchResult[]> {
+        const languages = opts.language ? [opts.language] : this.getSettings().languages!
+
+        return languages.map(lang => ({
+            id: `${opts.media.id}$${opts.episodeNumber}$${lang}`,
+            name: `${opts.media.romajiTitle} - ${String(opts.episodeNumber).padStart(2, "0")}`,
+            language: lang,
+            format: "srt",
+            episodeNumber: opts.episodeNumber,
+            isFilenameMatch: !!opts.filename,
+        }))
+    }
+
+    async download(result: SearchResult): Promise<Subtitle> {
+        const [, episode, lang] = result.id.split("$")
+
+        return {
+            format: "srt",
+            language: lang,
+            content: `1\n00:00:01,000 --> 00:00:03,000\nEpisode ${episode} (${lang})\n`,
+        }
+    }
+}
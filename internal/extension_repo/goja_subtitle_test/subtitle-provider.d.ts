@@ -0,0 +1,55 @@
+declare type SubtitleFormat = "srt" | "ass" | "ssa" | "vtt"
+
+declare type SearchResult = {
+    id: string
+    name: string
+    // ISO 639-1 language code, e.g. "en"
+    language: string
+    format: SubtitleFormat
+    episodeNumber?: number
+    url?: string
+    downloads?: number
+    isFilenameMatch?: boolean
+}
+
+declare type Subtitle = {
+    format: SubtitleFormat
+    language: string
+    content: string
+}
+
+declare interface Media {
+    id: number
+    idMal?: number
+    format?: string
+    englishTitle?: string
+    romajiTitle?: string
+    episodeCount?: number
+    synonyms: string[]
+    isAdult: boolean
+    year?: number
+}
+
+declare type SearchOptions = {
+    media: Media
+    // 0 for movies or if the episode number is unknown
+    episodeNumber: number
+    // ISO 639-1 language code, empty if any language is accepted
+    language: string
+    // Name of the video file, if any
+    filename?: string
+}
+
+declare type Settings = {
+    // ISO 639-1 language codes supported by the provider, empty if all languages are supported
+    languages?: string[]
+    supportsFilenameSearch: boolean
+}
+
+declare abstract class SubtitleProvider {
+    search(opts: SearchOptions): Promise<SearchResult[]>
+
+    download(result: SearchResult): Promise<Subtitle>
+
+    getSettings(): Settings
+}
@@ -0,0 +1,84 @@
+package extension_repo
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/extension"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type GojaTracker struct {
+	*gojaProviderBase
+}
+
+func NewGojaTracker(ext *extension.Extension, language extension.Language, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibiketracker.Provider, *GojaTracker, error) {
+	base, err := initializeProviderBase(ext, language, logger, runtimeManager)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &GojaTracker{
+		gojaProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *GojaTracker) GetEntry(media hibiketracker.Media) (ret *hibiketracker.Entry, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".GetEntry", &err)
+
+	method, err := g.callClassMethod(context.Background(), "getEntry", structToMap(media))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getEntry method: %w", err)
+	}
+
+	promiseRes, err := g.waitForPromise(method)
+	if err != nil {
+		return nil, err
+	}
+
+	// The media is not in the user's list
+	if promiseRes == nil || promiseRes.Export() == nil {
+		return nil, nil
+	}
+
+	err = g.unmarshalValue(promiseRes, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal entry: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (g *GojaTracker) UpdateEntry(opts hibiketracker.UpdateEntryOptions) (err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".UpdateEntry", &err)
+
+	method, err := g.callClassMethod(context.Background(), "updateEntry", structToMap(opts))
+	if err != nil {
+		return fmt.Errorf("failed to call updateEntry method: %w", err)
+	}
+
+	_, err = g.waitForPromise(method)
+	return err
+}
+
+func (g *GojaTracker) GetSettings() (ret hibiketracker.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibiketracker.Settings{}
+	})
+
+	method, err := g.callClassMethod(context.Background(), "getSettings")
+	if err != nil {
+		return
+	}
+
+	err = g.unmarshalValue(method, &ret)
+	if err != nil {
+		return
+	}
+
+	return
+}
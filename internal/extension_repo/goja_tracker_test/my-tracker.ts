@@ -0,0 +1,34 @@
+/// <reference path="./tracker.d.ts" />
+
+class Provider {
+
+    getSettings(): Settings {
+        return {
+            supportsAnime: true,
+            supportsManga: false,
+        }
+    }
+
+    async getEntry(media: Media): Promise<Entry | null> {
+        // A real tracker would look up the media on its service, e.g. using the MAL ID
+        if (!media.idMal) {
+            return null
+        }
+
+        return {
+            status: "current",
+            progress: media.idMal % 10,
+            score: 80,
+            startedAt: { year: 2024, month: 1, day: 2 },
+        }
+    }
+
+    async updateEntry(opts: UpdateEntryOptions): Promise<void> {
+        if (opts.media.type !== "anime") {
+            throw new Error("unsupported media type")
+        }
+        if (opts.progress !== undefined && opts.progress < 0) {
+            throw new Error("invalid progress")
+        }
+    }
+}
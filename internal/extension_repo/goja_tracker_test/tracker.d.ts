@@ -0,0 +1,58 @@
+declare type MediaType = "anime" | "manga"
+
+declare type Status = "current" | "planning" | "completed" | "dropped" | "paused" | "repeating"
+
+declare interface Media {
+    type: MediaType
+    // AniList ID
+    id: number
+    idMal?: number
+    // Only available for anime
+    idKitsu?: number
+    format?: string
+    englishTitle?: string
+    romajiTitle?: string
+    // Total number of episodes or chapters, 0 if unknown
+    total?: number
+}
+
+declare type FuzzyDate = {
+    year?: number
+    month?: number
+    day?: number
+}
+
+declare type Entry = {
+    status: Status
+    // Episode or chapter progress
+    progress: number
+    // Score from 0 to 100, 0 if not scored
+    score: number
+    startedAt?: FuzzyDate
+    completedAt?: FuzzyDate
+}
+
+declare type UpdateEntryOptions = {
+    media: Media
+    // Fields that were not changed are undefined
+    status?: Status
+    progress?: number
+    // Score from 0 to 100
+    score?: number
+    startedAt?: FuzzyDate
+    completedAt?: FuzzyDate
+}
+
+declare type Settings = {
+    supportsAnime: boolean
+    supportsManga: boolean
+}
+
+declare abstract class Tracker {
+    // Returns null if the media is not in the user's list
+    getEntry(media: Media): Promise<Entry | null>
+
+    updateEntry(opts: UpdateEntryOptions): Promise<void>
+
+    getSettings(): Settings
+}
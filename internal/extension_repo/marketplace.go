@@ -1,25 +1,171 @@
 package extension_repo
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"seanime/internal/constants"
 	"seanime/internal/extension"
 	"seanime/internal/util"
+	"seanime/internal/util/filecache"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/samber/lo"
 )
 
+const MarketplaceRepositoriesKey = "1"
+const MarketplaceRepositoriesBucket = "extension-marketplace-repositories"
+
+type (
+	// MarketplaceRepository is an index of extensions, a JSON list of manifests.
+	MarketplaceRepository struct {
+		Name    string    `json:"name"`
+		URL     string    `json:"url"`
+		AddedAt time.Time `json:"addedAt"`
+		// IsDefault is true for the official repository, which cannot be removed
+		IsDefault bool `json:"isDefault"`
+	}
+)
+
+// GetMarketplaceRepositories returns the official repository followed by the repositories added by the user.
+func (r *Repository) GetMarketplaceRepositories() []*MarketplaceRepository {
+	ret := []*MarketplaceRepository{
+		{
+			Name:      "Official",
+			URL:       constants.DefaultExtensionMarketplaceURL,
+			IsDefault: true,
+		},
+	}
+	return append(ret, r.getUserMarketplaceRepositories()...)
+}
+
+func (r *Repository) getUserMarketplaceRepositories() []*MarketplaceRepository {
+	if r.fileCacher == nil {
+		return make([]*MarketplaceRepository, 0)
+	}
+
+	bucket := filecache.NewPermanentBucket(MarketplaceRepositoriesBucket)
+
+	var repositories []*MarketplaceRepository
+	found, _ := r.fileCacher.GetPerm(bucket, MarketplaceRepositoriesKey, &repositories)
+	if !found || repositories == nil {
+		return make([]*MarketplaceRepository, 0)
+	}
+
+	return repositories
+}
+
+// AddMarketplaceRepository adds a repository after checking that its index can be read.
+func (r *Repository) AddMarketplaceRepository(name string, repositoryUrl string) (*MarketplaceRepository, error) {
+	repositoryUrl = strings.TrimSpace(repositoryUrl)
+
+	u, err := url.Parse(repositoryUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid repository URL: %s", repositoryUrl)
+	}
+
+	if _, found := lo.Find(r.GetMarketplaceRepositories(), func(item *MarketplaceRepository) bool { return item.URL == repositoryUrl }); found {
+		return nil, errors.New("repository already added")
+	}
+
+	if _, err := r.getMarketplaceExtensions(repositoryUrl); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = u.Host
+	}
+
+	repository := &MarketplaceRepository{
+		Name:    name,
+		URL:     repositoryUrl,
+		AddedAt: time.Now(),
+	}
+
+	if r.fileCacher == nil {
+		return nil, errors.New("file cacher is not set")
+	}
+
+	repositories := append(r.getUserMarketplaceRepositories(), repository)
+	bucket := filecache.NewPermanentBucket(MarketplaceRepositoriesBucket)
+	if err := r.fileCacher.SetPerm(bucket, MarketplaceRepositoriesKey, repositories); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug().Str("url", repositoryUrl).Msg("marketplace: Added repository")
+
+	return repository, nil
+}
+
+// RemoveMarketplaceRepository removes a repository added by the user.
+// Extensions installed from it are kept.
+func (r *Repository) RemoveMarketplaceRepository(repositoryUrl string) error {
+	if repositoryUrl == constants.DefaultExtensionMarketplaceURL {
+		return errors.New("the official repository cannot be removed")
+	}
+
+	if r.fileCacher == nil {
+		return errors.New("file cacher is not set")
+	}
+
+	repositories := lo.Filter(r.getUserMarketplaceRepositories(), func(item *MarketplaceRepository, _ int) bool {
+		return item.URL != repositoryUrl
+	})
+
+	bucket := filecache.NewPermanentBucket(MarketplaceRepositoriesBucket)
+	return r.fileCacher.SetPerm(bucket, MarketplaceRepositoriesKey, repositories)
+}
+
+// GetMarketplaceExtensions returns the extensions listed in the given repository.
+// If url is empty, it returns the extensions of all repositories, in the order of the repositories.
+// Repositories that can't be read are skipped, an error is returned only if none can be read.
 func (r *Repository) GetMarketplaceExtensions(url string) (extensions []*extension.Extension, err error) {
 	defer util.HandlePanicInModuleWithError("extension_repo/GetMarketplaceExtensions", &err)
 
-	marketplaceUrl := constants.DefaultExtensionMarketplaceURL
 	if url != "" {
-		marketplaceUrl = url
+		extensions, err = r.getMarketplaceExtensions(url)
+		if err != nil {
+			return nil, err
+		}
+		for _, ext := range extensions {
+			ext.Repository = url
+		}
+		return extensions, nil
+	}
+
+	repositories := r.GetMarketplaceRepositories()
+
+	results := make([][]*extension.Extension, len(repositories))
+	errs := make([]error, len(repositories))
+
+	wg := sync.WaitGroup{}
+	for i, repository := range repositories {
+		wg.Add(1)
+		go func(i int, repository *MarketplaceRepository) {
+			defer wg.Done()
+			results[i], errs[i] = r.getMarketplaceExtensions(repository.URL)
+			for _, ext := range results[i] {
+				ext.Repository = repository.URL
+			}
+		}(i, repository)
+	}
+	wg.Wait()
+
+	extensions = make([]*extension.Extension, 0)
+	for i := range repositories {
+		extensions = append(extensions, results[i]...)
+	}
+
+	if len(extensions) == 0 && lo.EveryBy(errs, func(err error) bool { return err != nil }) {
+		return nil, errs[0]
 	}
 
-	return r.getMarketplaceExtensions(marketplaceUrl)
+	return extensions, nil
 }
 
 func (r *Repository) getMarketplaceExtensions(url string) (extensions []*extension.Extension, err error) {
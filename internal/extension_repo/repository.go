@@ -2,14 +2,18 @@ package extension_repo
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"seanime/internal/events"
 	"seanime/internal/extension"
 	hibikemanga "seanime/internal/extension/hibike/manga"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
 	hibiketorrent "seanime/internal/extension/hibike/torrent"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
 	"seanime/internal/goja/goja_runtime"
 	"seanime/internal/hook"
+	plugin_ui "seanime/internal/plugin/ui"
 	"seanime/internal/util"
 	"seanime/internal/util/filecache"
 	"seanime/internal/util/result"
@@ -31,6 +35,9 @@ type (
 		// Store all active Goja VMs
 		// - When reloading extensions, all VMs are interrupted
 		gojaExtensions *result.Map[string, GojaExtension]
+		// Store all loaded WASM extensions
+		// - When reloading extensions, their runtimes are closed
+		wasmExtensions *result.Map[string, WasmExtension]
 
 		gojaRuntimeManager *goja_runtime.Manager
 		// Extension bank
@@ -96,6 +103,20 @@ type (
 		Lang     string                              `json:"lang"` // ISO 639-1 language code
 		Settings hibiketorrent.AnimeProviderSettings `json:"settings"`
 	}
+
+	SubtitleProviderExtensionItem struct {
+		ID       string                  `json:"id"`
+		Name     string                  `json:"name"`
+		Lang     string                  `json:"lang"` // ISO 639-1 language code
+		Settings hibikesubtitle.Settings `json:"settings"`
+	}
+
+	TrackerExtensionItem struct {
+		ID       string                 `json:"id"`
+		Name     string                 `json:"name"`
+		Icon     string                 `json:"icon"`
+		Settings hibiketracker.Settings `json:"settings"`
+	}
 )
 
 type NewRepositoryOptions struct {
@@ -116,6 +137,7 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 		extensionDir:       opts.ExtensionDir,
 		wsEventManager:     opts.WSEventManager,
 		gojaExtensions:     result.NewResultMap[string, GojaExtension](),
+		wasmExtensions:     result.NewResultMap[string, WasmExtension](),
 		gojaRuntimeManager: goja_runtime.NewManager(opts.Logger),
 		extensionBank:      extension.NewUnifiedBank(),
 		invalidExtensions:  result.NewResultMap[string, *extension.InvalidExtension](),
@@ -126,6 +148,11 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 		updateData:         make([]UpdateData, 0),
 	}
 
+	// Notify the user when an extension is disabled after repeated resource limit violations
+	ret.gojaRuntimeManager.SetOnDisable(func(extID string, reason string) {
+		ret.wsEventManager.SendEvent(events.WarningToast, fmt.Sprintf("Extension %s was disabled: %s", extID, reason))
+	})
+
 	firstExtensionLoadedCtx, firstExtensionLoadedCancel := context.WithCancel(context.Background())
 	ret.firstExternalExtensionLoadedFunc = firstExtensionLoadedCancel
 
@@ -192,6 +219,10 @@ func (r *Repository) GetUpdateData() (ret []UpdateData) {
 func (r *Repository) ListExtensionData() (ret []*extension.Extension) {
 	r.extensionBank.Range(func(key string, ext extension.BaseExtension) bool {
 		retExt := extension.ToExtensionData(ext)
+		if ext.GetManifestURI() != "builtin" {
+			retExt.SignatureStatus = r.getSignatureStatus(retExt)
+			retExt.Repository = r.getInstallSettings(ext.GetID()).Repository
+		}
 		retExt.Payload = ""
 		ret = append(ret, retExt)
 		return true
@@ -296,6 +327,40 @@ func (r *Repository) ListAnimeTorrentProviderExtensions() []*AnimeTorrentProvide
 	return ret
 }
 
+func (r *Repository) ListSubtitleProviderExtensions() []*SubtitleProviderExtensionItem {
+	ret := make([]*SubtitleProviderExtensionItem, 0)
+
+	extension.RangeExtensions(r.extensionBank, func(key string, ext extension.SubtitleProviderExtension) bool {
+		settings := ext.GetProvider().GetSettings()
+		ret = append(ret, &SubtitleProviderExtensionItem{
+			ID:       ext.GetID(),
+			Name:     ext.GetName(),
+			Lang:     extension.GetExtensionLang(ext.GetLang()),
+			Settings: settings,
+		})
+		return true
+	})
+
+	return ret
+}
+
+func (r *Repository) ListTrackerExtensions() []*TrackerExtensionItem {
+	ret := make([]*TrackerExtensionItem, 0)
+
+	extension.RangeExtensions(r.extensionBank, func(key string, ext extension.TrackerExtension) bool {
+		settings := ext.GetProvider().GetSettings()
+		ret = append(ret, &TrackerExtensionItem{
+			ID:       ext.GetID(),
+			Name:     ext.GetName(),
+			Icon:     ext.GetIcon(),
+			Settings: settings,
+		})
+		return true
+	})
+
+	return ret
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetLoadedExtension returns the loaded extension by ID.
@@ -310,6 +375,32 @@ func (r *Repository) GetLoadedExtension(id string) (extension.BaseExtension, boo
 	return nil, false
 }
 
+// GetRuntimeMetrics returns the runtime metrics of the JavaScript extensions.
+func (r *Repository) GetRuntimeMetrics() []*goja_runtime.ExtensionMetrics {
+	return r.gojaRuntimeManager.GetMetrics()
+}
+
+// EnableExtensionRuntime re-enables an extension that was disabled after repeated resource limit violations.
+func (r *Repository) EnableExtensionRuntime(id string) {
+	r.gojaRuntimeManager.ResetLimiter(id)
+}
+
+// HandlePluginHTTPRequest calls the route registered by the plugin with the given ID.
+// It returns plugin_ui.ErrHTTPRouteNotFound if the plugin isn't loaded or doesn't handle the route.
+func (r *Repository) HandlePluginHTTPRequest(ctx context.Context, id string, req *plugin_ui.HTTPRequest) (*plugin_ui.HTTPResponse, error) {
+	gojaExt, found := r.gojaExtensions.Get(id)
+	if !found {
+		return nil, plugin_ui.ErrHTTPRouteNotFound
+	}
+
+	p, ok := gojaExt.(*GojaPlugin)
+	if !ok || p.ui == nil {
+		return nil, plugin_ui.ErrHTTPRouteNotFound
+	}
+
+	return p.ui.HandleHTTPRequest(ctx, req)
+}
+
 func (r *Repository) GetExtensionBank() *extension.UnifiedBank {
 	return r.extensionBank
 }
@@ -329,6 +420,16 @@ func (r *Repository) GetAnimeTorrentProviderExtensionByID(id string) (extension.
 	return ext, found
 }
 
+func (r *Repository) GetSubtitleProviderExtensionByID(id string) (extension.SubtitleProviderExtension, bool) {
+	ext, found := extension.GetExtension[extension.SubtitleProviderExtension](r.extensionBank, id)
+	return ext, found
+}
+
+func (r *Repository) GetTrackerExtensionByID(id string) (extension.TrackerExtension, bool) {
+	ext, found := extension.GetExtension[extension.TrackerExtension](r.extensionBank, id)
+	return ext, found
+}
+
 func (r *Repository) loadPlugin(ext *extension.Extension) (err error) {
 	defer util.HandlePanicInModuleWithError("extension_repo/loadPlugin", &err)
 
@@ -0,0 +1,131 @@
+package extension_repo
+
+import (
+	"errors"
+	"fmt"
+	"seanime/internal/extension"
+	"seanime/internal/util/filecache"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const TrustedKeysKey = "1"
+const TrustedKeysBucket = "extension-trusted-keys"
+
+var (
+	ErrSigningKeyChanged = errors.New("the signing key of the extension changed since it was installed")
+	ErrSignatureRemoved  = errors.New("the extension was signed when it was installed but the new version is not")
+)
+
+type (
+	// TrustedKey is a publisher key the user trusts.
+	TrustedKey struct {
+		Name        string    `json:"name"`
+		PublicKey   string    `json:"publicKey"`
+		Fingerprint string    `json:"fingerprint"`
+		AddedAt     time.Time `json:"addedAt"`
+	}
+)
+
+// GetTrustedKeys returns the publisher keys trusted by the user.
+func (r *Repository) GetTrustedKeys() []*TrustedKey {
+	bucket := filecache.NewPermanentBucket(TrustedKeysBucket)
+
+	var keys []*TrustedKey
+	found, _ := r.fileCacher.GetPerm(bucket, TrustedKeysKey, &keys)
+	if !found || keys == nil {
+		return make([]*TrustedKey, 0)
+	}
+
+	return keys
+}
+
+// AddTrustedKey adds a publisher key to the trusted keys.
+func (r *Repository) AddTrustedKey(name string, publicKey string) (*TrustedKey, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	fingerprint := extension.GetKeyFingerprint(publicKey)
+	if fingerprint == "" {
+		return nil, extension.ErrInvalidPublicKey
+	}
+
+	keys := r.GetTrustedKeys()
+	if existing, found := lo.Find(keys, func(k *TrustedKey) bool { return k.Fingerprint == fingerprint }); found {
+		return existing, nil
+	}
+
+	key := &TrustedKey{
+		Name:        strings.TrimSpace(name),
+		PublicKey:   publicKey,
+		Fingerprint: fingerprint,
+		AddedAt:     time.Now(),
+	}
+	keys = append(keys, key)
+
+	bucket := filecache.NewPermanentBucket(TrustedKeysBucket)
+	if err := r.fileCacher.SetPerm(bucket, TrustedKeysKey, keys); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug().Str("fingerprint", fingerprint).Msg("extensions: Added trusted key")
+
+	return key, nil
+}
+
+// RemoveTrustedKey removes a publisher key from the trusted keys.
+func (r *Repository) RemoveTrustedKey(fingerprint string) error {
+	keys := lo.Filter(r.GetTrustedKeys(), func(k *TrustedKey, _ int) bool {
+		return k.Fingerprint != fingerprint
+	})
+
+	bucket := filecache.NewPermanentBucket(TrustedKeysBucket)
+	return r.fileCacher.SetPerm(bucket, TrustedKeysKey, keys)
+}
+
+func (r *Repository) isTrustedKey(fingerprint string) bool {
+	if fingerprint == "" {
+		return false
+	}
+	_, found := lo.Find(r.GetTrustedKeys(), func(k *TrustedKey) bool { return k.Fingerprint == fingerprint })
+	return found
+}
+
+// getSignatureStatus verifies the signature of the extension against its manifest and payload.
+func (r *Repository) getSignatureStatus(ext *extension.Extension) extension.SignatureStatus {
+	if ext.Signature == nil {
+		return extension.SignatureStatusUnsigned
+	}
+	if err := extension.VerifySignature(ext); err != nil {
+		return extension.SignatureStatusInvalid
+	}
+	if r.isTrustedKey(ext.Signature.GetFingerprint()) {
+		return extension.SignatureStatusVerified
+	}
+	return extension.SignatureStatusUntrusted
+}
+
+// checkSigningKey returns an error if the extension can't replace the installed version because its signing key changed.
+// The key can only change if the user trusts the new key, e.g. when a publisher rotates their key.
+func (r *Repository) checkSigningKey(installed *extension.Extension, ext *extension.Extension) error {
+	if installed == nil || installed.Signature == nil {
+		return nil
+	}
+
+	if ext.Signature == nil {
+		return ErrSignatureRemoved
+	}
+
+	installedFingerprint := installed.Signature.GetFingerprint()
+	fingerprint := ext.Signature.GetFingerprint()
+	if installedFingerprint == fingerprint {
+		return nil
+	}
+
+	if r.isTrustedKey(fingerprint) {
+		r.logger.Warn().Str("id", ext.ID).Str("from", installedFingerprint).Str("to", fingerprint).Msg("extensions: Signing key changed to a trusted key")
+		return nil
+	}
+
+	return fmt.Errorf("%w (%s -> %s)", ErrSigningKeyChanged, installedFingerprint, fingerprint)
+}
@@ -65,14 +65,22 @@ func manifestSanityCheck(ext *extension.Extension) error {
 	// Check language
 	if ext.Language != extension.LanguageGo &&
 		ext.Language != extension.LanguageJavascript &&
-		ext.Language != extension.LanguageTypescript {
+		ext.Language != extension.LanguageTypescript &&
+		ext.Language != extension.LanguageWasm {
 		return fmt.Errorf("unsupported language: %v", ext.Language)
 	}
 
+	// Plugins rely on the JS APIs
+	if ext.Type == extension.TypePlugin && ext.Language == extension.LanguageWasm {
+		return fmt.Errorf("plugins cannot be written in %v", ext.Language)
+	}
+
 	// Check type
 	if ext.Type != extension.TypeMangaProvider &&
 		ext.Type != extension.TypeOnlinestreamProvider &&
 		ext.Type != extension.TypeAnimeTorrentProvider &&
+		ext.Type != extension.TypeSubtitleProvider &&
+		ext.Type != extension.TypeTracker &&
 		ext.Type != extension.TypePlugin {
 		return fmt.Errorf("unsupported extension type: %v", ext.Type)
 	}
@@ -83,6 +91,10 @@ func manifestSanityCheck(ext *extension.Extension) error {
 		}
 	}
 
+	if err := ext.Network.Validate(); err != nil {
+		return fmt.Errorf("invalid network permissions: %w", err)
+	}
+
 	ext.Lang = strings.ToLower(ext.Lang)
 
 	return nil
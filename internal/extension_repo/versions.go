@@ -0,0 +1,384 @@
+package extension_repo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"seanime/internal/extension"
+	"seanime/internal/util/filecache"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const InstallSettingsBucket = "extension-install-settings"
+
+const (
+	// MaxExtensionHistory is the number of previous versions kept on disk for each extension
+	MaxExtensionHistory = 3
+	// historyDirName is the directory containing the previous versions, in the extension directory.
+	// It is skipped when loading extensions.
+	historyDirName = ".history"
+)
+
+var (
+	ErrExtensionPinned   = errors.New("the extension is pinned")
+	ErrNoPreviousVersion = errors.New("no previous version of the extension is available")
+)
+
+type (
+	// ExtensionInstallSettings holds the settings of an installed extension that are not part of its manifest.
+	ExtensionInstallSettings struct {
+		// Repository is the URL of the marketplace repository the extension was installed from.
+		// It is empty if the extension was installed from its manifest URI.
+		Repository string `json:"repository,omitempty"`
+		// Channel is the update channel selected by the user, empty for the default channel.
+		Channel string `json:"channel,omitempty"`
+		// PinnedVersion is the version the extension is pinned to, updates are ignored while it is set.
+		PinnedVersion string `json:"pinnedVersion,omitempty"`
+	}
+
+	// ExtensionHistoryEntry is a previous version of an extension kept on disk.
+	ExtensionHistoryEntry struct {
+		Version    string    `json:"version"`
+		ReplacedAt time.Time `json:"replacedAt"`
+	}
+
+	// ExtensionVersions describes the installed version of an extension, its update settings and its previous versions.
+	ExtensionVersions struct {
+		ID                string                   `json:"id"`
+		Version           string                   `json:"version"`
+		Repository        string                   `json:"repository"`
+		Channel           string                   `json:"channel"`
+		Channels          []string                 `json:"channels"` // Channels offered by the installed version
+		PinnedVersion     string                   `json:"pinnedVersion"`
+		UpdateManifestURI string                   `json:"updateManifestURI"` // Manifest URI of the selected channel
+		History           []*ExtensionHistoryEntry `json:"history"`           // Newest first
+	}
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Install settings
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getInstallSettings returns the install settings of the extension, it never returns nil.
+func (r *Repository) getInstallSettings(id string) *ExtensionInstallSettings {
+	settings := &ExtensionInstallSettings{}
+	if r.fileCacher == nil {
+		return settings
+	}
+	bucket := filecache.NewPermanentBucket(InstallSettingsBucket)
+	_, _ = r.fileCacher.GetPerm(bucket, id, settings)
+	return settings
+}
+
+func (r *Repository) saveInstallSettings(id string, settings *ExtensionInstallSettings) error {
+	if r.fileCacher == nil {
+		return errors.New("file cacher is not set")
+	}
+	bucket := filecache.NewPermanentBucket(InstallSettingsBucket)
+	return r.fileCacher.SetPerm(bucket, id, settings)
+}
+
+func (r *Repository) deleteInstallSettings(id string) {
+	if r.fileCacher == nil {
+		return
+	}
+	bucket := filecache.NewPermanentBucket(InstallSettingsBucket)
+	_ = r.fileCacher.DeletePerm(bucket, id)
+}
+
+// getUpdateManifestURI returns the manifest URI of the channel selected for the extension.
+func (r *Repository) getUpdateManifestURI(ext *extension.Extension, settings *ExtensionInstallSettings) string {
+	if settings.Channel != "" {
+		if uri, ok := ext.Channels[settings.Channel]; ok && uri != "" {
+			return uri
+		}
+	}
+	return ext.ManifestURI
+}
+
+// removeUpdateData removes the pending update of the extension, e.g. when it's pinned or its channel changed.
+func (r *Repository) removeUpdateData(id string) {
+	r.updateDataMu.Lock()
+	defer r.updateDataMu.Unlock()
+	r.updateData = lo.Filter(r.updateData, func(item UpdateData, _ int) bool {
+		return item.ExtensionID != id
+	})
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// History
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *Repository) getHistoryDir(id string) string {
+	return filepath.Join(r.extensionDir, historyDirName, id)
+}
+
+// historyFilename returns the file name of a version in the history, the version is sanitized since it comes from the manifest.
+func historyFilename(version string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '+' {
+			return r
+		}
+		return '_'
+	}, version) + ".json"
+}
+
+// archiveExtension copies the installed manifest of the extension to its history and removes the oldest versions.
+func (r *Repository) archiveExtension(id string) error {
+	filename := filepath.Join(r.extensionDir, id+".json")
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	ext, err := extractExtensionFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	dir := r.getHistoryDir(id)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, historyFilename(ext.Version)), data, 0644); err != nil {
+		return err
+	}
+
+	// Remove the oldest versions
+	entries, err := r.readHistory(id)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries[min(len(entries), MaxExtensionHistory):] {
+		_ = os.Remove(filepath.Join(dir, historyFilename(entry.Version)))
+	}
+
+	r.logger.Debug().Str("id", id).Str("version", ext.Version).Msg("extensions: Archived extension")
+
+	return nil
+}
+
+// readHistory returns the previous versions of the extension, newest first.
+func (r *Repository) readHistory(id string) ([]*ExtensionHistoryEntry, error) {
+	ret := make([]*ExtensionHistoryEntry, 0)
+
+	entries, err := os.ReadDir(r.getHistoryDir(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ret, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		ext, err := extractExtensionFromFile(filepath.Join(r.getHistoryDir(id), entry.Name()))
+		if err != nil {
+			r.logger.Warn().Err(err).Str("id", id).Str("file", entry.Name()).Msg("extensions: Invalid file in extension history")
+			continue
+		}
+		ret = append(ret, &ExtensionHistoryEntry{
+			Version:    ext.Version,
+			ReplacedAt: info.ModTime(),
+		})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].ReplacedAt.After(ret[j].ReplacedAt)
+	})
+
+	return ret, nil
+}
+
+func (r *Repository) deleteHistory(id string) {
+	_ = os.RemoveAll(r.getHistoryDir(id))
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetExtensionVersions returns the installed version of an external extension, its update settings and its previous versions.
+func (r *Repository) GetExtensionVersions(id string) (*ExtensionVersions, error) {
+	if err := isValidExtensionID(id); err != nil {
+		return nil, err
+	}
+
+	ext, err := extractExtensionFromFile(filepath.Join(r.extensionDir, id+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("extension not found")
+	}
+
+	history, err := r.readHistory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := r.getInstallSettings(id)
+
+	channels := lo.Keys(ext.Channels)
+	sort.Strings(channels)
+
+	return &ExtensionVersions{
+		ID:                ext.ID,
+		Version:           ext.Version,
+		Repository:        settings.Repository,
+		Channel:           settings.Channel,
+		Channels:          channels,
+		PinnedVersion:     settings.PinnedVersion,
+		UpdateManifestURI: r.getUpdateManifestURI(ext, settings),
+		History:           history,
+	}, nil
+}
+
+// RollbackExtension restores a previous version of the extension from its history.
+// If version is empty, the most recent previous version is restored.
+// The replaced version is kept in the history, so the rollback can be undone.
+func (r *Repository) RollbackExtension(id string, version string) (*ExtensionInstallResponse, error) {
+	if err := isValidExtensionID(id); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Join(r.extensionDir, id+".json")
+	if _, err := os.Stat(filename); err != nil {
+		return nil, fmt.Errorf("extension not found")
+	}
+
+	history, err := r.readHistory(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, ErrNoPreviousVersion
+	}
+
+	entry := history[0]
+	if version != "" {
+		var found bool
+		entry, found = lo.Find(history, func(e *ExtensionHistoryEntry) bool { return e.Version == version })
+		if !found {
+			return nil, fmt.Errorf("version %s is not in the history of the extension", version)
+		}
+	}
+
+	// Read the previous version before archiving the installed one, which can remove it from the history
+	previousFilename := filepath.Join(r.getHistoryDir(id), historyFilename(entry.Version))
+	data, err := os.ReadFile(previousFilename)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := extractExtensionFromFile(previousFilename)
+	if err != nil {
+		return nil, err
+	}
+	if previous.ID != id {
+		return nil, fmt.Errorf("extension ID mismatch in history, expected %s, got %s", id, previous.ID)
+	}
+
+	// The previous version replaces the installed one like an update, so its signature and signing key are checked the same way
+	if err := extension.VerifySignature(previous); err != nil {
+		r.logger.Error().Err(err).Str("id", id).Str("version", previous.Version).Msg("extensions: Invalid signature in history")
+		return nil, fmt.Errorf("invalid signature, %w", err)
+	}
+	if installed, err := extractExtensionFromFile(filename); err == nil {
+		if err := r.checkSigningKey(installed, previous); err != nil {
+			r.logger.Error().Err(err).Str("id", id).Str("version", previous.Version).Msg("extensions: Refused rollback")
+			return nil, err
+		}
+	}
+	_ = os.Remove(previousFilename)
+
+	if err := r.archiveExtension(id); err != nil {
+		r.logger.Error().Err(err).Str("id", id).Msg("extensions: Failed to archive extension")
+		return nil, fmt.Errorf("failed to archive extension, %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		r.logger.Error().Err(err).Str("id", id).Msg("extensions: Failed to write extension file")
+		return nil, fmt.Errorf("failed to write extension file, %w", err)
+	}
+
+	// The pin follows the version the user rolled back to
+	settings := r.getInstallSettings(id)
+	if settings.PinnedVersion != "" {
+		settings.PinnedVersion = previous.Version
+		_ = r.saveInstallSettings(id, settings)
+	}
+
+	r.reloadExtension(id)
+
+	r.logger.Info().Str("id", id).Str("version", previous.Version).Msg("extensions: Rolled back extension")
+
+	return &ExtensionInstallResponse{
+		Message: fmt.Sprintf("Rolled back %s to %s", previous.Name, previous.Version),
+	}, nil
+}
+
+// PinExtension pins the extension to a version, updates are ignored while it's pinned.
+// If the version is in the history, the extension is rolled back to it first.
+// An empty version unpins the extension.
+func (r *Repository) PinExtension(id string, version string) error {
+	if err := isValidExtensionID(id); err != nil {
+		return err
+	}
+
+	ext, err := extractExtensionFromFile(filepath.Join(r.extensionDir, id+".json"))
+	if err != nil {
+		return fmt.Errorf("extension not found")
+	}
+
+	if version != "" && version != ext.Version {
+		if _, err := r.RollbackExtension(id, version); err != nil {
+			return err
+		}
+	}
+
+	settings := r.getInstallSettings(id)
+	settings.PinnedVersion = version
+	if err := r.saveInstallSettings(id, settings); err != nil {
+		return err
+	}
+
+	if version != "" {
+		r.removeUpdateData(id)
+	}
+
+	return nil
+}
+
+// SetExtensionChannel selects the update channel of the extension, an empty channel selects the default one.
+func (r *Repository) SetExtensionChannel(id string, channel string) error {
+	if err := isValidExtensionID(id); err != nil {
+		return err
+	}
+
+	ext, err := extractExtensionFromFile(filepath.Join(r.extensionDir, id+".json"))
+	if err != nil {
+		return fmt.Errorf("extension not found")
+	}
+
+	if _, ok := ext.Channels[channel]; channel != "" && !ok {
+		return fmt.Errorf("unknown channel: %s", channel)
+	}
+
+	settings := r.getInstallSettings(id)
+	settings.Channel = channel
+	if err := r.saveInstallSettings(id, settings); err != nil {
+		return err
+	}
+
+	// The pending update came from the previous channel
+	r.removeUpdateData(id)
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package extension_repo_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"seanime/internal/events"
+	"seanime/internal/extension"
+	"seanime/internal/extension_repo"
+	"seanime/internal/util"
+	"seanime/internal/util/filecache"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testManifestServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	version  string
+	channels map[string]string
+	key      ed25519.PrivateKey // Signs the manifests if set
+}
+
+func newTestManifestServer(t *testing.T) *testManifestServer {
+	s := &testManifestServer{version: "1.0.0"}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		ext := &extension.Extension{
+			ID:          "test-provider",
+			Name:        "Test Provider",
+			Version:     s.version,
+			ManifestURI: s.URL + "/manifest.json",
+			Language:    extension.LanguageJavascript,
+			Type:        extension.TypeMangaProvider,
+			Author:      "Seanime",
+			Payload:     "class Provider {}",
+			Channels:    s.channels,
+		}
+		if s.key != nil {
+			ext.Signature = &extension.Signature{
+				PublicKey: base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey)),
+				Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, extension.GetSignedMessage(ext))),
+			}
+		}
+
+		switch r.URL.Path {
+		case "/index.json":
+			_ = json.NewEncoder(w).Encode([]*extension.Extension{ext})
+		case "/beta/manifest.json":
+			ext.Version = "2.0.0"
+			_ = json.NewEncoder(w).Encode(ext)
+		default:
+			_ = json.NewEncoder(w).Encode(ext)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testManifestServer) setVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version = version
+}
+
+func (s *testManifestServer) setKey(key ed25519.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+}
+
+func getVersionsTestRepo(t *testing.T) *extension_repo.Repository {
+	logger := util.NewLogger()
+
+	fileCacher, err := filecache.NewCacher(t.TempDir())
+	require.NoError(t, err)
+
+	return extension_repo.NewRepository(&extension_repo.NewRepositoryOptions{
+		Logger:         logger,
+		ExtensionDir:   filepath.Join(t.TempDir(), "extensions"),
+		WSEventManager: events.NewMockWSEventManager(logger),
+		FileCacher:     fileCacher,
+	})
+}
+
+func historyVersions(v *extension_repo.ExtensionVersions) []string {
+	ret := make([]string, 0)
+	for _, entry := range v.History {
+		ret = append(ret, entry.Version)
+	}
+	return ret
+}
+
+func TestExtensionVersions_HistoryAndRollback(t *testing.T) {
+	server := newTestManifestServer(t)
+	repo := getVersionsTestRepo(t)
+
+	manifestURI := server.URL + "/manifest.json"
+	repositoryURL := server.URL + "/index.json"
+
+	_, err := repo.InstallExternalExtension(manifestURI, repositoryURL)
+	require.NoError(t, err)
+
+	versions, err := repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", versions.Version)
+	assert.Equal(t, repositoryURL, versions.Repository)
+	assert.Empty(t, versions.History)
+
+	// Update twice, the repository is kept
+	for _, version := range []string{"1.1.0", "1.2.0"} {
+		server.setVersion(version)
+		_, err = repo.InstallExternalExtension(manifestURI, "")
+		require.NoError(t, err)
+	}
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", versions.Version)
+	assert.Equal(t, repositoryURL, versions.Repository)
+	assert.Equal(t, []string{"1.1.0", "1.0.0"}, historyVersions(versions))
+
+	// Roll back to the previous version
+	_, err = repo.RollbackExtension("test-provider", "")
+	require.NoError(t, err)
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", versions.Version)
+	assert.Equal(t, []string{"1.2.0", "1.0.0"}, historyVersions(versions))
+
+	_, err = repo.RollbackExtension("test-provider", "0.9.0")
+	assert.Error(t, err)
+
+	// Only the latest versions are kept
+	for _, version := range []string{"1.3.0", "1.4.0", "1.5.0"} {
+		server.setVersion(version)
+		_, err = repo.InstallExternalExtension(manifestURI, "")
+		require.NoError(t, err)
+	}
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0", versions.Version)
+	assert.Equal(t, []string{"1.4.0", "1.3.0", "1.1.0"}, historyVersions(versions))
+	assert.Len(t, versions.History, extension_repo.MaxExtensionHistory)
+
+	// The history is not loaded as extensions
+	repo.ReloadExternalExtensions()
+	assert.Equal(t, 1, len(repo.ListExtensionData())+len(repo.ListInvalidExtensions()))
+}
+
+func TestExtensionVersions_RollbackChecksSigningKey(t *testing.T) {
+	server := newTestManifestServer(t)
+	repo := getVersionsTestRepo(t)
+
+	manifestURI := server.URL + "/manifest.json"
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// 1.0.0 is unsigned, 1.1.0 is signed with the other key, 1.2.0 is signed with the key
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+	server.setVersion("1.1.0")
+	server.setKey(otherKey)
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+	// The publisher rotated their key, the user trusts the new one
+	_, err = repo.AddTrustedKey("Test", base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+	server.setVersion("1.2.0")
+	server.setKey(key)
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+
+	// The previous versions can't replace the installed one, the key changed or the signature was removed
+	_, err = repo.RollbackExtension("test-provider", "1.1.0")
+	assert.ErrorIs(t, err, extension_repo.ErrSigningKeyChanged)
+	_, err = repo.RollbackExtension("test-provider", "1.0.0")
+	assert.ErrorIs(t, err, extension_repo.ErrSignatureRemoved)
+
+	versions, err := repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", versions.Version)
+	assert.Equal(t, []string{"1.1.0", "1.0.0"}, historyVersions(versions))
+}
+
+func TestExtensionVersions_Pin(t *testing.T) {
+	server := newTestManifestServer(t)
+	repo := getVersionsTestRepo(t)
+
+	manifestURI := server.URL + "/manifest.json"
+
+	_, err := repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+	server.setVersion("1.1.0")
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+
+	// Pinning to a previous version rolls back
+	require.NoError(t, repo.PinExtension("test-provider", "1.0.0"))
+
+	versions, err := repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", versions.Version)
+	assert.Equal(t, "1.0.0", versions.PinnedVersion)
+
+	// Updates are refused while pinned
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	assert.ErrorIs(t, err, extension_repo.ErrExtensionPinned)
+
+	// Unpin
+	require.NoError(t, repo.PinExtension("test-provider", ""))
+	_, err = repo.InstallExternalExtension(manifestURI, "")
+	require.NoError(t, err)
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", versions.Version)
+	assert.Empty(t, versions.PinnedVersion)
+
+	assert.Error(t, repo.PinExtension("test-provider", "2.0.0"))
+}
+
+func TestExtensionVersions_Channel(t *testing.T) {
+	server := newTestManifestServer(t)
+	server.channels = map[string]string{"beta": server.URL + "/beta/manifest.json"}
+	repo := getVersionsTestRepo(t)
+
+	_, err := repo.InstallExternalExtension(server.URL+"/manifest.json", "")
+	require.NoError(t, err)
+
+	versions, err := repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beta"}, versions.Channels)
+
+	assert.Error(t, repo.SetExtensionChannel("test-provider", "nightly"))
+	require.NoError(t, repo.SetExtensionChannel("test-provider", "beta"))
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "beta", versions.Channel)
+	assert.Equal(t, server.URL+"/beta/manifest.json", versions.UpdateManifestURI)
+
+	// Installing from the channel URI keeps the channel
+	_, err = repo.InstallExternalExtension(server.URL+"/beta/manifest.json", "")
+	require.NoError(t, err)
+
+	versions, err = repo.GetExtensionVersions("test-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", versions.Version)
+	assert.Equal(t, "beta", versions.Channel)
+}
+
+func TestMarketplaceRepositories(t *testing.T) {
+	server := newTestManifestServer(t)
+	repo := getVersionsTestRepo(t)
+
+	repositories := repo.GetMarketplaceRepositories()
+	require.Len(t, repositories, 1)
+	assert.True(t, repositories[0].IsDefault)
+
+	_, err := repo.AddMarketplaceRepository("Test", "not a url")
+	assert.Error(t, err)
+
+	added, err := repo.AddMarketplaceRepository("Test", server.URL+"/index.json")
+	require.NoError(t, err)
+	assert.Equal(t, "Test", added.Name)
+
+	_, err = repo.AddMarketplaceRepository("Test", server.URL+"/index.json")
+	assert.Error(t, err)
+
+	repositories = repo.GetMarketplaceRepositories()
+	require.Len(t, repositories, 2)
+	assert.Equal(t, server.URL+"/index.json", repositories[1].URL)
+
+	extensions, err := repo.GetMarketplaceExtensions(server.URL + "/index.json")
+	require.NoError(t, err)
+	require.Len(t, extensions, 1)
+	assert.Equal(t, server.URL+"/index.json", extensions[0].Repository)
+
+	assert.Error(t, repo.RemoveMarketplaceRepository(repositories[0].URL))
+	require.NoError(t, repo.RemoveMarketplaceRepository(server.URL+"/index.json"))
+	assert.Len(t, repo.GetMarketplaceRepositories(), 1)
+}
@@ -0,0 +1,95 @@
+package extension_repo
+
+import (
+	"context"
+	"seanime/internal/extension"
+	hibiketorrent "seanime/internal/extension/hibike/torrent"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type WasmAnimeTorrentProvider struct {
+	*wasmProviderBase
+}
+
+func NewWasmAnimeTorrentProvider(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibiketorrent.AnimeProvider, *WasmAnimeTorrentProvider, error) {
+	base, err := initializeWasmProviderBase(ext, logger, runtimeManager, []string{"search", "smartSearch", "getTorrentInfoHash", "getTorrentMagnetLink", "getLatest", "getSettings"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &WasmAnimeTorrentProvider{
+		wasmProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *WasmAnimeTorrentProvider) Search(opts hibiketorrent.AnimeSearchOptions) (ret []*hibiketorrent.AnimeTorrent, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Search", &err)
+
+	err = g.callMethod(context.Background(), "search", &ret, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+	}
+
+	return
+}
+
+func (g *WasmAnimeTorrentProvider) SmartSearch(opts hibiketorrent.AnimeSmartSearchOptions) (ret []*hibiketorrent.AnimeTorrent, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".SmartSearch", &err)
+
+	err = g.callMethod(context.Background(), "smartSearch", &ret, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+	}
+
+	return
+}
+
+func (g *WasmAnimeTorrentProvider) GetTorrentInfoHash(torrent *hibiketorrent.AnimeTorrent) (ret string, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".GetTorrentInfoHash", &err)
+
+	err = g.callMethod(context.Background(), "getTorrentInfoHash", &ret, torrent)
+	return
+}
+
+func (g *WasmAnimeTorrentProvider) GetTorrentMagnetLink(torrent *hibiketorrent.AnimeTorrent) (ret string, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".GetTorrentMagnetLink", &err)
+
+	err = g.callMethod(context.Background(), "getTorrentMagnetLink", &ret, torrent)
+	return
+}
+
+func (g *WasmAnimeTorrentProvider) GetLatest() (ret []*hibiketorrent.AnimeTorrent, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".GetLatest", &err)
+
+	err = g.callMethod(context.Background(), "getLatest", &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+	}
+
+	return
+}
+
+func (g *WasmAnimeTorrentProvider) GetSettings() (ret hibiketorrent.AnimeProviderSettings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibiketorrent.AnimeProviderSettings{}
+	})
+
+	_ = g.callMethod(context.Background(), "getSettings", &ret)
+	return
+}
@@ -0,0 +1,483 @@
+package extension_repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"seanime/internal/extension"
+	"seanime/internal/goja/goja_bindings"
+	"seanime/internal/goja/goja_runtime"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// WASM extensions
+// See WASM.md for the host ABI.
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// wasmHostModuleName is the name of the module importing the host functions.
+	wasmHostModuleName = "seanime"
+	// wasmMemoryLimitPages is the maximum memory of an instance, 64KiB per page.
+	wasmMemoryLimitPages = 8192 // 512 MB
+	// wasmMaxIdleInstances is the maximum number of instances kept for reuse.
+	wasmMaxIdleInstances = 4
+	wasmFetchTimeout     = 35 * time.Second
+)
+
+// wasmCompilationCache is shared by all runtimes so that reloading an extension doesn't compile its module again.
+var wasmCompilationCache = wazero.NewCompilationCache()
+
+const (
+	wasmLogLevelDebug uint32 = iota
+	wasmLogLevelInfo
+	wasmLogLevelWarn
+	wasmLogLevelError
+)
+
+// WasmExtension is a loaded WASM extension.
+type WasmExtension interface {
+	// Close closes the runtime of the extension and all its instances.
+	Close()
+	GetExtension() *extension.Extension
+}
+
+type (
+	wasmProviderBase struct {
+		ext            *extension.Extension
+		logger         *zerolog.Logger
+		runtime        wazero.Runtime
+		compiled       wazero.CompiledModule
+		runtimeManager *goja_runtime.Manager
+		// instances are the idle instances of the module.
+		// An instance only runs one call at a time, new instances are created when all of them are busy.
+		instances chan api.Module
+
+		client                     *http.Client
+		clientWithCloudFlareBypass *http.Client
+	}
+
+	// wasmResult is the envelope of the output of a method.
+	wasmResult struct {
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error,omitempty"`
+	}
+
+	wasmFetchRequest struct {
+		URL                string            `json:"url"`
+		Method             string            `json:"method"`
+		Headers            map[string]string `json:"headers"`
+		Body               string            `json:"body"`
+		NoCloudflareBypass bool              `json:"noCloudflareBypass"`
+		// Timeout is in seconds.
+		Timeout int `json:"timeout"`
+	}
+
+	wasmFetchResponse struct {
+		OK         bool              `json:"ok"`
+		Status     int               `json:"status"`
+		StatusText string            `json:"statusText"`
+		URL        string            `json:"url"`
+		Headers    map[string]string `json:"headers"`
+		Body       string            `json:"body"`
+		// Error is set if the request couldn't be sent.
+		Error string `json:"error,omitempty"`
+	}
+)
+
+// initializeWasmProviderBase compiles the module and checks that it exports the memory, the allocator and the given methods.
+func initializeWasmProviderBase(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager, methods []string) (*wasmProviderBase, error) {
+	wasmBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ext.Payload))
+	if err != nil {
+		logger.Error().Err(err).Str("id", ext.ID).Msg("extensions: Failed to decode WASM payload")
+		return nil, fmt.Errorf("invalid payload, the WASM module should be base64-encoded: %w", err)
+	}
+
+	ctx := context.Background()
+
+	g := &wasmProviderBase{
+		ext:            ext,
+		logger:         logger,
+		runtimeManager: runtimeManager,
+		instances:      make(chan api.Module, wasmMaxIdleInstances),
+	}
+	g.client, g.clientWithCloudFlareBypass = g.getHTTPClients()
+
+	// Calls are interrupted when their context is done, i.e. when they exceed the time limit
+	cfg := wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasmMemoryLimitPages).
+		WithCompilationCache(wasmCompilationCache)
+	g.runtime = wazero.NewRuntimeWithConfig(ctx, cfg)
+
+	// Rust and TinyGo modules targeting WASI import it even if they don't use the filesystem
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, g.runtime); err != nil {
+		g.Close()
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	_, err = g.runtime.NewHostModuleBuilder(wasmHostModuleName).
+		NewFunctionBuilder().WithFunc(g.hostLog).Export("log").
+		NewFunctionBuilder().WithFunc(g.hostFetch).Export("fetch").
+		NewFunctionBuilder().WithFunc(g.hostGetUserPreference).Export("get_user_preference").
+		Instantiate(ctx)
+	if err != nil {
+		g.Close()
+		return nil, fmt.Errorf("failed to instantiate host module: %w", err)
+	}
+
+	g.compiled, err = g.runtime.CompileModule(ctx, wasmBytes)
+	if err != nil {
+		logger.Error().Err(err).Str("id", ext.ID).Msg("extensions: Failed to compile WASM module")
+		g.Close()
+		return nil, fmt.Errorf("compilation failed: %w", err)
+	}
+
+	if _, ok := g.compiled.ExportedMemories()["memory"]; !ok {
+		g.Close()
+		return nil, errors.New("module does not export its memory")
+	}
+	exported := g.compiled.ExportedFunctions()
+	for _, name := range append([]string{"alloc"}, methods...) {
+		if _, ok := exported[name]; !ok {
+			g.Close()
+			return nil, fmt.Errorf("module does not export %s", name)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *wasmProviderBase) GetExtension() *extension.Extension {
+	return g.ext
+}
+
+func (g *wasmProviderBase) Close() {
+	if g.runtime == nil {
+		return
+	}
+	// Closing the runtime closes all the instances
+	_ = g.runtime.Close(context.Background())
+}
+
+func (g *wasmProviderBase) getHTTPClients() (*http.Client, *http.Client) {
+	client, clientWithCloudFlareBypass := goja_bindings.GetRestrictedClients(g.ext.Network)
+
+	if wrap := g.runtimeManager.GetFetchTransportWrapper(); wrap != nil {
+		wrapClient := func(client *http.Client) *http.Client {
+			return &http.Client{
+				Transport:     wrap(client.Transport),
+				CheckRedirect: client.CheckRedirect,
+			}
+		}
+		return wrapClient(client), wrapClient(clientWithCloudFlareBypass)
+	}
+
+	return client, clientWithCloudFlareBypass
+}
+
+func (g *wasmProviderBase) getInstance(ctx context.Context) (api.Module, error) {
+	select {
+	case mod := <-g.instances:
+		return mod, nil
+	default:
+	}
+
+	config := wazero.NewModuleConfig().
+		WithName(""). // Anonymous so that multiple instances can exist
+		WithStartFunctions("_initialize").
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+
+	mod, err := g.runtime.InstantiateModule(ctx, g.compiled, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+	return mod, nil
+}
+
+func (g *wasmProviderBase) putInstance(mod api.Module) {
+	select {
+	case g.instances <- mod:
+	default:
+		_ = mod.Close(context.Background())
+	}
+}
+
+// callMethod calls the exported method with the JSON-encoded arguments and unmarshals its result into ret.
+func (g *wasmProviderBase) callMethod(ctx context.Context, methodName string, ret interface{}, args ...interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Enforce the resource limits of the extension
+	call, err := g.runtimeManager.GetLimiter(g.ext.ID).StartCall(ctx)
+	if err != nil {
+		g.logger.Error().Err(err).Str("id", g.ext.ID).Str("method", methodName).Msg("extension: Call refused")
+		return err
+	}
+
+	mod, err := g.getInstance(call.Context())
+	if err != nil {
+		g.logger.Error().Err(err).Str("id", g.ext.ID).Msg("extension: Failed to get WASM instance")
+		return call.End(err)
+	}
+
+	output, err := g.runMethod(call.Context(), mod, methodName, args...)
+	if err != nil {
+		// The instance may be in an inconsistent state after a trap
+		_ = mod.Close(context.Background())
+		g.logger.Error().Err(err).Str("id", g.ext.ID).Str("method", methodName).Msg("extension: Method execution failed")
+		return call.End(fmt.Errorf("method %s execution failed: %w", methodName, err))
+	}
+	g.putInstance(mod)
+
+	if err = call.End(nil); err != nil {
+		return err
+	}
+
+	var res wasmResult
+	if err := json.Unmarshal(output, &res); err != nil {
+		return fmt.Errorf("method %s returned invalid JSON: %w", methodName, err)
+	}
+	if res.Error != "" {
+		return fmt.Errorf("method %s failed: %s", methodName, res.Error)
+	}
+	if ret == nil || len(res.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res.Result, ret)
+}
+
+func (g *wasmProviderBase) runMethod(ctx context.Context, mod api.Module, methodName string, args ...interface{}) ([]byte, error) {
+	if args == nil {
+		args = []interface{}{}
+	}
+	input, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+
+	inputPtr, err := wasmWrite(ctx, mod, input)
+	if err != nil {
+		return nil, err
+	}
+	defer wasmFree(ctx, mod, inputPtr, uint32(len(input)))
+
+	res, err := mod.ExportedFunction(methodName).Call(ctx, uint64(inputPtr), uint64(len(input)))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("method %s should return a single value", methodName)
+	}
+
+	outputPtr, outputLen := wasmUnpack(res[0])
+	output, ok := mod.Memory().Read(outputPtr, outputLen)
+	if !ok {
+		return nil, fmt.Errorf("method %s returned an out of range result", methodName)
+	}
+	// The memory is reused by the next calls
+	output = bytes.Clone(output)
+	wasmFree(ctx, mod, outputPtr, outputLen)
+
+	return output, nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Host functions
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// hostLog logs a message at the given level.
+func (g *wasmProviderBase) hostLog(_ context.Context, mod api.Module, level, ptr, size uint32) {
+	msg, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return
+	}
+
+	var event *zerolog.Event
+	switch level {
+	case wasmLogLevelDebug:
+		event = g.logger.Debug()
+	case wasmLogLevelWarn:
+		event = g.logger.Warn()
+	case wasmLogLevelError:
+		event = g.logger.Error()
+	default:
+		event = g.logger.Info()
+	}
+	event.Str("id", g.ext.ID).Msgf("extension: %s", string(msg))
+}
+
+// hostFetch sends the JSON-encoded request and returns the JSON-encoded response.
+// The request is subject to the network permissions of the extension.
+func (g *wasmProviderBase) hostFetch(ctx context.Context, mod api.Module, ptr, size uint32) uint64 {
+	var res wasmFetchResponse
+
+	input, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		res.Error = "request out of range"
+		return g.writeHostResult(ctx, mod, res)
+	}
+
+	var req wasmFetchRequest
+	if err := json.Unmarshal(input, &req); err != nil {
+		res.Error = fmt.Sprintf("invalid request: %v", err)
+		return g.writeHostResult(ctx, mod, res)
+	}
+
+	if err := g.fetch(ctx, &req, &res); err != nil {
+		res.Error = err.Error()
+	}
+	return g.writeHostResult(ctx, mod, res)
+}
+
+func (g *wasmProviderBase) fetch(ctx context.Context, req *wasmFetchRequest, res *wasmFetchResponse) error {
+	if err := goja_bindings.CheckFetchURL(g.ext.Network, req.URL); err != nil {
+		return err
+	}
+
+	// Share the limit of outstanding requests with the other calls of the extension
+	if sem := g.runtimeManager.GetLimiter(g.ext.ID).FetchSemaphore(); sem != nil {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	timeout := wasmFetchTimeout
+	if req.Timeout > 0 {
+		timeout = time.Duration(req.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
+
+	g.logger.Trace().Str("url", req.URL).Str("method", method).Msgf("extension: Network request")
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, req.URL, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	client := g.clientWithCloudFlareBypass
+	if req.NoCloudflareBypass {
+		client = g.client
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	res.OK = resp.StatusCode >= 200 && resp.StatusCode < 300
+	res.Status = resp.StatusCode
+	res.StatusText = resp.Status
+	res.URL = resp.Request.URL.String()
+	res.Body = string(rawBody)
+	res.Headers = make(map[string]string, len(resp.Header))
+	for k, v := range resp.Header {
+		if len(v) > 0 {
+			res.Headers[k] = v[0]
+		}
+	}
+
+	return nil
+}
+
+// hostGetUserPreference returns the value of the user config field, or an empty result if it's not set.
+func (g *wasmProviderBase) hostGetUserPreference(ctx context.Context, mod api.Module, ptr, size uint32) uint64 {
+	key, ok := mod.Memory().Read(ptr, size)
+	if !ok || g.ext.SavedUserConfig == nil {
+		return 0
+	}
+
+	value, found := g.ext.SavedUserConfig.Values[string(key)]
+	if !found || value == "" {
+		return 0
+	}
+
+	valuePtr, err := wasmWrite(ctx, mod, []byte(value))
+	if err != nil {
+		return 0
+	}
+	return wasmPack(valuePtr, uint32(len(value)))
+}
+
+// writeHostResult writes the JSON-encoded value to the guest memory, the guest owns the memory.
+func (g *wasmProviderBase) writeHostResult(ctx context.Context, mod api.Module, v interface{}) uint64 {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	ptr, err := wasmWrite(ctx, mod, data)
+	if err != nil {
+		g.logger.Error().Err(err).Str("id", g.ext.ID).Msg("extension: Failed to write host result")
+		return 0
+	}
+	return wasmPack(ptr, uint32(len(data)))
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// wasmWrite allocates guest memory with the exported allocator and copies data into it.
+func wasmWrite(ctx context.Context, mod api.Module, data []byte) (uint32, error) {
+	res, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate memory: %w", err)
+	}
+	ptr := uint32(res[0])
+	if !mod.Memory().Write(ptr, data) {
+		return 0, errors.New("allocated memory out of range")
+	}
+	return ptr, nil
+}
+
+// wasmFree releases guest memory with the exported deallocator, if any.
+func wasmFree(ctx context.Context, mod api.Module, ptr, size uint32) {
+	if dealloc := mod.ExportedFunction("dealloc"); dealloc != nil {
+		_, _ = dealloc.Call(ctx, uint64(ptr), uint64(size))
+	}
+}
+
+// wasmPack packs a pointer and a length into a single value, the pointer being in the high 32 bits.
+func wasmPack(ptr, size uint32) uint64 {
+	return uint64(ptr)<<32 | uint64(size)
+}
+
+func wasmUnpack(v uint64) (ptr uint32, size uint32) {
+	return uint32(v >> 32), uint32(v)
+}
@@ -0,0 +1,113 @@
+package extension_repo_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"seanime/internal/extension"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/extension_repo"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildWasmTestProvider compiles the example provider in ./wasm_manga_test and returns the base64-encoded module.
+func buildWasmTestProvider(t *testing.T) string {
+	t.Helper()
+
+	out := filepath.Join(t.TempDir(), "provider.wasm")
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", out, ".")
+	cmd.Dir = "./wasm_manga_test"
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOFLAGS=")
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("cannot build the WASM test provider (requires Go 1.24+): %v\n%s", err, b)
+	}
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestWasmMangaProvider(t *testing.T) {
+	payload := buildWasmTestProvider(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id": "one-piece", "title": %q, "synonyms": ["Wan Pisu"]}]`, r.URL.Query().Get("q"))
+	}))
+	defer server.Close()
+
+	newExt := func(network *extension.NetworkPermissions) *extension.Extension {
+		return &extension.Extension{
+			ID:              "wasm-manga-provider",
+			Name:            "WasmMangaProvider",
+			Version:         "0.1.0",
+			Language:        extension.LanguageWasm,
+			Type:            extension.TypeMangaProvider,
+			Payload:         payload,
+			Network:         network,
+			SavedUserConfig: &extension.SavedUserConfig{Values: map[string]string{"api": server.URL}},
+		}
+	}
+
+	t.Run("provider methods", func(t *testing.T) {
+		ext := newExt(&extension.NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8", "::1/128"}})
+		runtimeManager := goja_runtime.NewManager(util.NewLogger())
+
+		provider, wasmExt, err := extension_repo.NewWasmMangaProvider(ext, util.NewLogger(), runtimeManager)
+		require.NoError(t, err)
+		defer wasmExt.Close()
+
+		assert.Equal(t, hibikemanga.Settings{SupportsMultiLanguage: true}, provider.GetSettings())
+
+		results, err := provider.Search(hibikemanga.SearchOptions{Query: "One Piece"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "One Piece", results[0].Title)
+		assert.Equal(t, ext.ID, results[0].Provider)
+		assert.Equal(t, 1.0, results[0].SearchRating)
+
+		chapters, err := provider.FindChapters("one-piece")
+		require.NoError(t, err)
+		require.Len(t, chapters, 1)
+		assert.Equal(t, "one-piece-1", chapters[0].ID)
+		assert.Equal(t, ext.ID, chapters[0].Provider)
+
+		_, err = provider.FindChapterPages("one-piece-1")
+		assert.ErrorContains(t, err, "not supported")
+
+		metrics := runtimeManager.GetLimiter(ext.ID).Metrics()
+		assert.EqualValues(t, 4, metrics.Calls)
+		assert.EqualValues(t, 0, metrics.Violations)
+	})
+
+	t.Run("network permissions", func(t *testing.T) {
+		provider, wasmExt, err := extension_repo.NewWasmMangaProvider(newExt(nil), util.NewLogger(), goja_runtime.NewManager(util.NewLogger()))
+		require.NoError(t, err)
+		defer wasmExt.Close()
+
+		_, err = provider.Search(hibikemanga.SearchOptions{Query: "One Piece"})
+		assert.ErrorContains(t, err, "not allowed")
+	})
+
+	t.Run("missing exports", func(t *testing.T) {
+		ext := newExt(nil)
+		ext.Type = extension.TypeOnlinestreamProvider
+		_, _, err := extension_repo.NewWasmOnlinestreamProvider(ext, util.NewLogger(), goja_runtime.NewManager(util.NewLogger()))
+		assert.ErrorContains(t, err, "does not export findEpisodes")
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		ext := newExt(nil)
+		ext.Payload = "not a module"
+		_, _, err := extension_repo.NewWasmMangaProvider(ext, util.NewLogger(), goja_runtime.NewManager(util.NewLogger()))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package extension_repo
+
+import (
+	"context"
+	"seanime/internal/extension"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+	"seanime/internal/util/comparison"
+
+	"github.com/rs/zerolog"
+)
+
+type WasmMangaProvider struct {
+	*wasmProviderBase
+}
+
+func NewWasmMangaProvider(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibikemanga.Provider, *WasmMangaProvider, error) {
+	base, err := initializeWasmProviderBase(ext, logger, runtimeManager, []string{"search", "findChapters", "findChapterPages", "getSettings"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &WasmMangaProvider{
+		wasmProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *WasmMangaProvider) GetSettings() (ret hibikemanga.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibikemanga.Settings{}
+	})
+
+	_ = g.callMethod(context.Background(), "getSettings", &ret)
+	return
+}
+
+func (g *WasmMangaProvider) Search(opts hibikemanga.SearchOptions) (ret []*hibikemanga.SearchResult, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Search", &err)
+
+	err = g.callMethod(context.Background(), "search", &ret, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the provider & search rating
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+
+		synonyms := ret[i].Synonyms
+		if synonyms == nil {
+			continue
+		}
+
+		compTitles := []*string{&ret[i].Title}
+		for _, syn := range synonyms {
+			compTitles = append(compTitles, &syn)
+		}
+
+		compRes, ok := comparison.FindBestMatchWithSorensenDice(&opts.Query, compTitles)
+		if ok {
+			ret[i].SearchRating = compRes.Rating
+		}
+	}
+
+	return ret, nil
+}
+
+func (g *WasmMangaProvider) FindChapters(id string) (ret []*hibikemanga.ChapterDetails, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".FindChapters", &err)
+
+	err = g.callMethod(context.Background(), "findChapters", &ret, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the provider
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+	}
+
+	return ret, nil
+}
+
+func (g *WasmMangaProvider) FindChapterPages(id string) (ret []*hibikemanga.ChapterPage, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".FindChapterPages", &err)
+
+	err = g.callMethod(context.Background(), "findChapterPages", &ret, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the provider
+	for i := range ret {
+		ret[i].Provider = g.ext.ID
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,3 @@
+module wasm-manga-test
+
+go 1.24
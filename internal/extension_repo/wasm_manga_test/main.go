@@ -0,0 +1,153 @@
+// Example manga provider implementing the WASM host ABI (see ../WASM.md).
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o provider.wasm .
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"unsafe"
+)
+
+//go:wasmimport seanime log
+func hostLog(level, ptr, size uint32)
+
+//go:wasmimport seanime fetch
+func hostFetch(ptr, size uint32) uint64
+
+//go:wasmimport seanime get_user_preference
+func hostGetUserPreference(ptr, size uint32) uint64
+
+// allocations keeps the memory handed to the host alive until it's released.
+var allocations = map[uint32][]byte{}
+
+//go:wasmexport alloc
+func alloc(size uint32) uint32 {
+	buf := make([]byte, max(size, 1))
+	ptr := uint32(uintptr(unsafe.Pointer(&buf[0])))
+	allocations[ptr] = buf
+	return ptr
+}
+
+//go:wasmexport dealloc
+func dealloc(ptr, size uint32) {
+	delete(allocations, ptr)
+}
+
+func read(ptr, size uint32) []byte {
+	if size == 0 {
+		return nil
+	}
+	return allocations[ptr][:size]
+}
+
+func write(data []byte) uint64 {
+	ptr := alloc(uint32(len(data)))
+	copy(allocations[ptr], data)
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func unpack(v uint64) []byte {
+	ptr, size := uint32(v>>32), uint32(v)
+	data := append([]byte(nil), read(ptr, size)...)
+	dealloc(ptr, size)
+	return data
+}
+
+func log(msg string) {
+	b := []byte(msg)
+	ptr := write(b)
+	hostLog(1, uint32(ptr>>32), uint32(len(b)))
+	dealloc(uint32(ptr>>32), uint32(len(b)))
+}
+
+func getUserPreference(key string) string {
+	ptr := write([]byte(key))
+	defer dealloc(uint32(ptr>>32), uint32(len(key)))
+	return string(unpack(hostGetUserPreference(uint32(ptr>>32), uint32(len(key)))))
+}
+
+type fetchResponse struct {
+	OK     bool   `json:"ok"`
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+	Error  string `json:"error"`
+}
+
+func fetch(rawURL string) (*fetchResponse, error) {
+	req, _ := json.Marshal(map[string]interface{}{"url": rawURL, "noCloudflareBypass": true})
+	ptr := write(req)
+	defer dealloc(uint32(ptr>>32), uint32(len(req)))
+
+	var res fetchResponse
+	if err := json.Unmarshal(unpack(hostFetch(uint32(ptr>>32), uint32(len(req)))), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// handle decodes the arguments, runs fn and encodes its result in the output envelope.
+func handle(ptr, size uint32, fn func(args []json.RawMessage) (interface{}, string)) uint64 {
+	var args []json.RawMessage
+	if err := json.Unmarshal(read(ptr, size), &args); err != nil {
+		return respond(nil, err.Error())
+	}
+	return respond(fn(args))
+}
+
+func respond(result interface{}, errMsg string) uint64 {
+	out, _ := json.Marshal(map[string]interface{}{"result": result, "error": errMsg})
+	return write(out)
+}
+
+//go:wasmexport getSettings
+func getSettings(ptr, size uint32) uint64 {
+	return handle(ptr, size, func(args []json.RawMessage) (interface{}, string) {
+		return map[string]bool{"supportsMultiLanguage": true, "supportsMultiScanlator": false}, ""
+	})
+}
+
+//go:wasmexport search
+func search(ptr, size uint32) uint64 {
+	return handle(ptr, size, func(args []json.RawMessage) (interface{}, string) {
+		var opts struct {
+			Query string `json:"query"`
+		}
+		_ = json.Unmarshal(args[0], &opts)
+		log("searching " + opts.Query)
+
+		res, err := fetch(getUserPreference("api") + "/search?q=" + url.QueryEscape(opts.Query))
+		if err != nil {
+			return nil, err.Error()
+		}
+		if res.Error != "" {
+			return nil, res.Error
+		}
+
+		var results []map[string]interface{}
+		if err := json.Unmarshal([]byte(res.Body), &results); err != nil {
+			return nil, err.Error()
+		}
+		return results, ""
+	})
+}
+
+//go:wasmexport findChapters
+func findChapters(ptr, size uint32) uint64 {
+	return handle(ptr, size, func(args []json.RawMessage) (interface{}, string) {
+		var id string
+		_ = json.Unmarshal(args[0], &id)
+		return []map[string]interface{}{
+			{"id": id + "-1", "url": "", "title": "Chapter 1", "chapter": "1", "index": 0},
+		}, ""
+	})
+}
+
+//go:wasmexport findChapterPages
+func findChapterPages(ptr, size uint32) uint64 {
+	return handle(ptr, size, func(args []json.RawMessage) (interface{}, string) {
+		return nil, "not supported"
+	})
+}
+
+func main() {}
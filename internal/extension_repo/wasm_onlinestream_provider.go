@@ -0,0 +1,80 @@
+package extension_repo
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/extension"
+	hibikeonlinestream "seanime/internal/extension/hibike/onlinestream"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type WasmOnlinestreamProvider struct {
+	*wasmProviderBase
+}
+
+func NewWasmOnlinestreamProvider(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibikeonlinestream.Provider, *WasmOnlinestreamProvider, error) {
+	base, err := initializeWasmProviderBase(ext, logger, runtimeManager, []string{"search", "findEpisodes", "findEpisodeServer", "getSettings"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &WasmOnlinestreamProvider{
+		wasmProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *WasmOnlinestreamProvider) Search(opts hibikeonlinestream.SearchOptions) (ret []*hibikeonlinestream.SearchResult, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Search", &err)
+
+	ret = make([]*hibikeonlinestream.SearchResult, 0)
+	err = g.callMethod(context.Background(), "search", &ret, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call search method: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (g *WasmOnlinestreamProvider) FindEpisodes(id string) (ret []*hibikeonlinestream.EpisodeDetails, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".FindEpisodes", &err)
+
+	err = g.callMethod(context.Background(), "findEpisodes", &ret, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, episode := range ret {
+		episode.Provider = g.ext.ID
+	}
+
+	return
+}
+
+func (g *WasmOnlinestreamProvider) FindEpisodeServer(episode *hibikeonlinestream.EpisodeDetails, server string) (ret *hibikeonlinestream.EpisodeServer, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".FindEpisodeServer", &err)
+
+	err = g.callMethod(context.Background(), "findEpisodeServer", &ret, episode, server)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("no episode server returned")
+	}
+
+	ret.Provider = g.ext.ID
+
+	return
+}
+
+func (g *WasmOnlinestreamProvider) GetSettings() (ret hibikeonlinestream.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibikeonlinestream.Settings{}
+	})
+
+	_ = g.callMethod(context.Background(), "getSettings", &ret)
+	return
+}
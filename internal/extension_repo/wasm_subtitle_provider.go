@@ -0,0 +1,69 @@
+package extension_repo
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/extension"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type WasmSubtitleProvider struct {
+	*wasmProviderBase
+}
+
+func NewWasmSubtitleProvider(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibikesubtitle.Provider, *WasmSubtitleProvider, error) {
+	base, err := initializeWasmProviderBase(ext, logger, runtimeManager, []string{"search", "download", "getSettings"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &WasmSubtitleProvider{
+		wasmProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *WasmSubtitleProvider) Search(opts hibikesubtitle.SearchOptions) (ret []*hibikesubtitle.SearchResult, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Search", &err)
+
+	ret = make([]*hibikesubtitle.SearchResult, 0)
+	err = g.callMethod(context.Background(), "search", &ret, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call search method: %w", err)
+	}
+
+	for _, result := range ret {
+		result.Provider = g.ext.ID
+	}
+
+	return ret, nil
+}
+
+func (g *WasmSubtitleProvider) Download(result *hibikesubtitle.SearchResult) (ret *hibikesubtitle.Subtitle, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".Download", &err)
+
+	err = g.callMethod(context.Background(), "download", &ret, result)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("no subtitle returned")
+	}
+
+	ret.Provider = g.ext.ID
+
+	return
+}
+
+func (g *WasmSubtitleProvider) GetSettings() (ret hibikesubtitle.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibikesubtitle.Settings{}
+	})
+
+	_ = g.callMethod(context.Background(), "getSettings", &ret)
+	return
+}
@@ -0,0 +1,59 @@
+package extension_repo
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/extension"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/goja/goja_runtime"
+	"seanime/internal/util"
+
+	"github.com/rs/zerolog"
+)
+
+type WasmTracker struct {
+	*wasmProviderBase
+}
+
+func NewWasmTracker(ext *extension.Extension, logger *zerolog.Logger, runtimeManager *goja_runtime.Manager) (hibiketracker.Provider, *WasmTracker, error) {
+	base, err := initializeWasmProviderBase(ext, logger, runtimeManager, []string{"getEntry", "updateEntry", "getSettings"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := &WasmTracker{
+		wasmProviderBase: base,
+	}
+	return provider, provider, nil
+}
+
+func (g *WasmTracker) GetEntry(media hibiketracker.Media) (ret *hibiketracker.Entry, err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".GetEntry", &err)
+
+	err = g.callMethod(context.Background(), "getEntry", &ret, media)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getEntry method: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (g *WasmTracker) UpdateEntry(opts hibiketracker.UpdateEntryOptions) (err error) {
+	defer util.HandlePanicInModuleWithError(g.ext.ID+".UpdateEntry", &err)
+
+	err = g.callMethod(context.Background(), "updateEntry", nil, opts)
+	if err != nil {
+		return fmt.Errorf("failed to call updateEntry method: %w", err)
+	}
+
+	return nil
+}
+
+func (g *WasmTracker) GetSettings() (ret hibiketracker.Settings) {
+	defer util.HandlePanicInModuleThen(g.ext.ID+".GetSettings", func() {
+		ret = hibiketracker.Settings{}
+	})
+
+	_ = g.callMethod(context.Background(), "getSettings", &ret)
+	return
+}
@@ -20,7 +20,7 @@ func setupTestVM(t *testing.T) *goja.Runtime {
 	vm := goja.New()
 	vm.SetParserOptions(parser.WithDisableSourceMaps)
 	// Bind the shared bindings
-	extension_repo.ShareBinds(vm, util.NewLogger())
+	extension_repo.ShareBinds(vm, util.NewLogger(), nil, nil)
 	fm := extension_repo.FieldMapper{}
 	vm.SetFieldNameMapper(fm)
 	return vm
@@ -94,7 +94,7 @@ func TestUserConfig(t *testing.T) {
 			},
 		},
 	}
-	extension_repo.ShareBinds(vm, util.NewLogger())
+	extension_repo.ShareBinds(vm, util.NewLogger(), nil, nil)
 	extension_repo.BindUserConfig(vm, ext, util.NewLogger())
 
 	vm.RunString(`
@@ -116,7 +116,7 @@ func TestByteSliceToUint8Array(t *testing.T) {
 	// Set the byte slice in the Goja VM
 	vm.Set("data", data)
 
-	extension_repo.ShareBinds(vm, util.NewLogger())
+	extension_repo.ShareBinds(vm, util.NewLogger(), nil, nil)
 
 	// JavaScript code to verify the type and contents of 'data'
 	jsCode := `
@@ -4,10 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"seanime/internal/extension"
 	"seanime/internal/util"
+	"seanime/internal/util/result"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/dop251/goja"
@@ -23,26 +29,137 @@ const (
 // Fetch
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-var (
-	clientWithCloudFlareBypass = &http.Client{
-		Transport: util.AddCloudFlareByPass(http.DefaultTransport),
-	}
-)
-
 type Fetch struct {
 	vm           *goja.Runtime
 	fetchSem     chan struct{}
 	vmResponseCh chan func()
+	// sharedSem limits the outstanding requests across all runtimes of an extension
+	sharedSem chan struct{}
+	// permissions restricts the hosts that can be reached.
+	// Private addresses are blocked when nil.
+	permissions                *extension.NetworkPermissions
+	client                     *http.Client
+	clientWithCloudFlareBypass *http.Client
 }
 
-func NewFetch(vm *goja.Runtime) *Fetch {
+func NewFetch(vm *goja.Runtime, permissions *extension.NetworkPermissions) *Fetch {
+	client, clientWithCloudFlareBypass := GetRestrictedClients(permissions)
 	return &Fetch{
-		vm:           vm,
-		fetchSem:     make(chan struct{}, maxConcurrentRequests),
-		vmResponseCh: make(chan func(), maxConcurrentRequests),
+		vm:                         vm,
+		fetchSem:                   make(chan struct{}, maxConcurrentRequests),
+		vmResponseCh:               make(chan func(), maxConcurrentRequests),
+		permissions:                permissions,
+		client:                     client,
+		clientWithCloudFlareBypass: clientWithCloudFlareBypass,
 	}
 }
 
+type restrictedClients struct {
+	client                     *http.Client
+	clientWithCloudFlareBypass *http.Client
+}
+
+// restrictedClientsCache shares the clients (and their connection pools) between VMs with the same permissions.
+var restrictedClientsCache = result.NewResultMap[string, *restrictedClients]()
+
+// GetRestrictedClients returns the http clients that can only reach the hosts allowed by the permissions.
+// The second client bypasses Cloudflare.
+func GetRestrictedClients(permissions *extension.NetworkPermissions) (*http.Client, *http.Client) {
+	key := permissions.GetHash()
+	if clients, ok := restrictedClientsCache.Get(key); ok {
+		return clients.client, clients.clientWithCloudFlareBypass
+	}
+	client, clientWithCloudFlareBypass := newRestrictedClients(permissions)
+	restrictedClientsCache.Set(key, &restrictedClients{
+		client:                     client,
+		clientWithCloudFlareBypass: clientWithCloudFlareBypass,
+	})
+	return client, clientWithCloudFlareBypass
+}
+
+// newRestrictedClients returns http clients that can only reach the hosts allowed by the permissions.
+// The resolved address is checked when dialing so that DNS records and redirects cannot point to private addresses.
+func newRestrictedClients(permissions *extension.NetworkPermissions) (client *http.Client, clientWithCloudFlareBypass *http.Client) {
+	newTransport := func() *http.Transport {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || !permissions.AllowsIP(ip) {
+					return fmt.Errorf("network access to %s is not allowed", host)
+				}
+				return nil
+			},
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = dialer.DialContext
+		// Requests going through a proxy would only check the address of the proxy
+		transport.Proxy = nil
+		return transport
+	}
+
+	checkRedirect := func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		if !permissions.AllowsHost(req.URL.Hostname()) {
+			return fmt.Errorf("network access to %s is not allowed", req.URL.Hostname())
+		}
+		return nil
+	}
+
+	client = &http.Client{
+		Transport:     newTransport(),
+		CheckRedirect: checkRedirect,
+	}
+	clientWithCloudFlareBypass = &http.Client{
+		Transport:     util.AddCloudFlareByPass(newTransport()),
+		CheckRedirect: checkRedirect,
+	}
+	return
+}
+
+// checkURL returns an error if the extension is not allowed to send requests to the URL.
+func (f *Fetch) checkURL(rawURL string) error {
+	return CheckFetchURL(f.permissions, rawURL)
+}
+
+// CheckFetchURL returns an error if the permissions don't allow sending requests to the URL.
+func CheckFetchURL(permissions *extension.NetworkPermissions, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported protocol scheme %q", u.Scheme)
+	}
+	if !permissions.AllowsHost(u.Hostname()) {
+		return fmt.Errorf("network access to %s is not allowed", u.Hostname())
+	}
+	return nil
+}
+
+// WrapTransport wraps the transport used to send the requests, e.g. to record or replay them with FetchFixtures.Wrap.
+func (f *Fetch) WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	wrapClient := func(client *http.Client) *http.Client {
+		return &http.Client{
+			Transport:     wrap(client.Transport),
+			CheckRedirect: client.CheckRedirect,
+		}
+	}
+	f.client = wrapClient(f.client)
+	f.clientWithCloudFlareBypass = wrapClient(f.clientWithCloudFlareBypass)
+}
+
+// SetSharedSemaphore limits the outstanding requests with a semaphore shared by other runtimes.
+func (f *Fetch) SetSharedSemaphore(sem chan struct{}) {
+	f.sharedSem = sem
+}
+
 func (f *Fetch) ResponseChannel() <-chan func() {
 	return f.vmResponseCh
 }
@@ -71,9 +188,9 @@ type fetchResult struct {
 }
 
 // BindFetch binds the fetch function to the VM
-func BindFetch(vm *goja.Runtime) *Fetch {
+func BindFetch(vm *goja.Runtime, permissions *extension.NetworkPermissions) *Fetch {
 	// Create a new Fetch instance
-	f := NewFetch(vm)
+	f := NewFetch(vm, permissions)
 	_ = vm.Set("fetch", f.Fetch)
 
 	go func() {
@@ -113,6 +230,12 @@ func (f *Fetch) Fetch(call goja.FunctionCall) goja.Value {
 		PanicThrowTypeError(f.vm, "URL parameter must be a string")
 	}
 
+	// Check the network permissions before sending the request
+	if err := f.checkURL(url); err != nil {
+		_ = reject(NewError(f.vm, err))
+		return f.vm.ToValue(promise)
+	}
+
 	// Parse options
 	options := fetchOptions{
 		Method:             "GET",
@@ -195,6 +318,10 @@ func (f *Fetch) Fetch(call goja.FunctionCall) goja.Value {
 		// Acquire semaphore
 		f.fetchSem <- struct{}{}
 		defer func() { <-f.fetchSem }()
+		if f.sharedSem != nil {
+			f.sharedSem <- struct{}{}
+			defer func() { <-f.sharedSem }()
+		}
 
 		log.Trace().Str("url", url).Str("method", options.Method).Msgf("extension: Network request")
 
@@ -221,9 +348,9 @@ func (f *Fetch) Fetch(call goja.FunctionCall) goja.Value {
 
 		var client *http.Client
 		if options.NoCloudFlareBypass {
-			client = http.DefaultClient
+			client = f.client
 		} else {
-			client = clientWithCloudFlareBypass
+			client = f.clientWithCloudFlareBypass
 		}
 
 		resp, err := client.Do(req)
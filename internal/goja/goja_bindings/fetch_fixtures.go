@@ -0,0 +1,230 @@
+package goja_bindings
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Fetch fixtures
+// - Record mode: requests are sent and the request/response pairs are saved
+// - Replay mode: requests are answered from the saved pairs, no request is sent
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type FetchFixtureMode string
+
+const (
+	FetchFixtureModeRecord FetchFixtureMode = "record"
+	FetchFixtureModeReplay FetchFixtureMode = "replay"
+)
+
+type (
+	// FetchFixtures records or replays the requests of the fetch binding.
+	FetchFixtures struct {
+		mode FetchFixtureMode
+		mu   sync.Mutex
+		data FetchFixturesData
+		// replayed is the number of times each fixture was replayed, used to replay identical requests in order
+		replayed map[int]int
+	}
+
+	FetchFixturesData struct {
+		Fixtures []*FetchFixture `json:"fixtures"`
+	}
+
+	FetchFixture struct {
+		Request  FetchFixtureRequest  `json:"request"`
+		Response FetchFixtureResponse `json:"response"`
+	}
+
+	FetchFixtureRequest struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		// BodyHash is the hex sha256 of the request body, empty if there's no body
+		BodyHash string `json:"bodyHash,omitempty"`
+	}
+
+	FetchFixtureResponse struct {
+		Status  int                 `json:"status"`
+		Headers map[string][]string `json:"headers"`
+		// Body is base64-encoded
+		Body string `json:"body"`
+	}
+
+	fixtureTransport struct {
+		fixtures *FetchFixtures
+		inner    http.RoundTripper
+	}
+)
+
+// NewFetchRecorder returns fixtures that record the requests sent by the fetch binding.
+func NewFetchRecorder() *FetchFixtures {
+	return &FetchFixtures{
+		mode:     FetchFixtureModeRecord,
+		replayed: make(map[int]int),
+	}
+}
+
+// LoadFetchFixtures loads recorded fixtures from a file for replay.
+func LoadFetchFixtures(path string) (*FetchFixtures, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var data FetchFixturesData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+
+	return &FetchFixtures{
+		mode:     FetchFixtureModeReplay,
+		data:     data,
+		replayed: make(map[int]int),
+	}, nil
+}
+
+func (f *FetchFixtures) Mode() FetchFixtureMode {
+	return f.mode
+}
+
+// Fixtures returns the recorded fixtures.
+func (f *FetchFixtures) Fixtures() []*FetchFixture {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*FetchFixture(nil), f.data.Fixtures...)
+}
+
+// Save writes the fixtures to a file.
+func (f *FetchFixtures) Save(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, err := json.MarshalIndent(f.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0644)
+}
+
+// Wrap returns a transport that records the requests sent through inner, or replays them without using inner.
+func (f *FetchFixtures) Wrap(inner http.RoundTripper) http.RoundTripper {
+	return &fixtureTransport{
+		fixtures: f,
+		inner:    inner,
+	}
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	key := FetchFixtureRequest{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		BodyHash: hashFixtureBody(body),
+	}
+
+	if t.fixtures.mode == FetchFixtureModeReplay {
+		return t.fixtures.replay(req, key)
+	}
+
+	return t.fixtures.record(t.inner, req, key)
+}
+
+func (f *FetchFixtures) record(inner http.RoundTripper, req *http.Request, key FetchFixtureRequest) (*http.Response, error) {
+	resp, err := inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	f.mu.Lock()
+	f.data.Fixtures = append(f.data.Fixtures, &FetchFixture{
+		Request: key,
+		Response: FetchFixtureResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+			Body:    base64.StdEncoding.EncodeToString(respBody),
+		},
+	})
+	f.mu.Unlock()
+
+	return resp, nil
+}
+
+func (f *FetchFixtures) replay(req *http.Request, key FetchFixtureRequest) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Identical requests are replayed in the order they were recorded, the last one is repeated
+	matches := make([]int, 0)
+	for i, fixture := range f.data.Fixtures {
+		if fixture.Request == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no fixture recorded for %s %s", key.Method, key.URL)
+	}
+
+	n := f.replayed[matches[0]]
+	f.replayed[matches[0]] = n + 1
+	fixture := f.data.Fixtures[matches[min(n, len(matches)-1)]]
+
+	body, err := base64.StdEncoding.DecodeString(fixture.Response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture body for %s %s: %w", key.Method, key.URL, err)
+	}
+
+	header := http.Header(fixture.Response.Headers).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.Status, http.StatusText(fixture.Response.Status)),
+		StatusCode:    fixture.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func hashFixtureBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(body))
+}
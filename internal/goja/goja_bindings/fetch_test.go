@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"seanime/internal/extension"
 	"seanime/internal/util"
 	"sync"
 	"testing"
@@ -17,6 +19,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// loopbackPermissions allows fetch to reach the local test servers
+var loopbackPermissions = &extension.NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8", "::1/128"}}
+
 func TestFetch_ThreadSafety(t *testing.T) {
 	// Create a test server that simulates different response times
 	var serverRequestCount int
@@ -61,7 +66,7 @@ func TestFetch_ThreadSafety(t *testing.T) {
 		t.Run(fmt.Sprintf("Iteration_%d", i), func(t *testing.T) {
 			// Create a new VM for each iteration
 			vm := goja.New()
-			BindFetch(vm)
+			BindFetch(vm, loopbackPermissions)
 
 			// Execute the JavaScript code
 			v, err := vm.RunString(jsCode)
@@ -119,7 +124,7 @@ func TestFetch_VMIsolation(t *testing.T) {
 
 			// Create a new VM for this goroutine
 			vm := goja.New()
-			BindFetch(vm)
+			BindFetch(vm, loopbackPermissions)
 
 			// Create JavaScript code that makes multiple requests
 			jsCode := fmt.Sprintf(`
@@ -158,7 +163,7 @@ func TestFetch_VMIsolation(t *testing.T) {
 func TestGojaPromiseAll(t *testing.T) {
 	vm := goja.New()
 
-	BindFetch(vm)
+	BindFetch(vm, nil)
 
 	registry := new(gojarequire.Registry)
 	registry.Enable(vm)
@@ -204,7 +209,7 @@ func TestGojaPromiseAll(t *testing.T) {
 
 func TestGojaFormDataAndFetch(t *testing.T) {
 	vm := goja.New()
-	BindFetch(vm)
+	BindFetch(vm, nil)
 
 	registry := new(gojarequire.Registry)
 	registry.Enable(vm)
@@ -272,7 +277,7 @@ async function run() {
 func TestGojaFetchPostJSON(t *testing.T) {
 	vm := goja.New()
 
-	BindFetch(vm)
+	BindFetch(vm, nil)
 
 	registry := new(gojarequire.Registry)
 	registry.Enable(vm)
@@ -322,3 +327,134 @@ async function run() {
 		spew.Dump(err)
 	}
 }
+
+func TestFetch_NetworkPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://localhost.test/", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		permissions *extension.NetworkPermissions
+		url         string
+		expected    goja.PromiseState
+	}{
+		{
+			name:        "private address blocked by default",
+			permissions: nil,
+			url:         server.URL,
+			expected:    goja.PromiseStateRejected,
+		},
+		{
+			name:        "private address allowed by CIDR",
+			permissions: loopbackPermissions,
+			url:         server.URL,
+			expected:    goja.PromiseStateFulfilled,
+		},
+		{
+			name:        "domain not in allowlist",
+			permissions: &extension.NetworkPermissions{AllowedDomains: []string{"example.com"}, AllowedCIDRs: []string{"127.0.0.0/8"}},
+			url:         "http://localhost.test/",
+			expected:    goja.PromiseStateRejected,
+		},
+		{
+			name:        "redirect to domain not in allowlist",
+			permissions: &extension.NetworkPermissions{AllowedDomains: []string{"example.com"}, AllowedCIDRs: []string{"127.0.0.0/8"}},
+			url:         server.URL + "/redirect",
+			expected:    goja.PromiseStateRejected,
+		},
+		{
+			name:        "unsupported scheme",
+			permissions: nil,
+			url:         "file:///etc/passwd",
+			expected:    goja.PromiseStateRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := goja.New()
+			BindFetch(vm, tt.permissions)
+
+			v, err := vm.RunString(fmt.Sprintf(`fetch(%q, { noCloudflareBypass: true }).then(r => r.text())`, tt.url))
+			require.NoError(t, err)
+
+			promise, ok := v.Export().(*goja.Promise)
+			require.True(t, ok)
+
+			for promise.State() == goja.PromiseStatePending {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			assert.Equal(t, tt.expected, promise.State())
+			if tt.expected == goja.PromiseStateFulfilled {
+				assert.Equal(t, "ok", promise.Result().Export())
+			}
+		})
+	}
+}
+
+func TestFetch_Fixtures(t *testing.T) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("X-Count", fmt.Sprint(count))
+		fmt.Fprintf(w, "%s %s %d", r.Method, r.URL.Path, count)
+	}))
+	defer server.Close()
+
+	fixturesPath := filepath.Join(t.TempDir(), "fixtures.json")
+
+	run := func(fixtures *FetchFixtures, script string) (goja.PromiseState, interface{}) {
+		vm := goja.New()
+		f := BindFetch(vm, loopbackPermissions)
+		f.WrapTransport(fixtures.Wrap)
+
+		v, err := vm.RunString(script)
+		require.NoError(t, err)
+
+		promise, ok := v.Export().(*goja.Promise)
+		require.True(t, ok)
+
+		for promise.State() == goja.PromiseStatePending {
+			time.Sleep(10 * time.Millisecond)
+		}
+		return promise.State(), promise.Result().Export()
+	}
+
+	script := fmt.Sprintf(`(async () => {
+		const a = await fetch(%q, { noCloudflareBypass: true }).then(r => r.text())
+		const b = await fetch(%q, { noCloudflareBypass: true }).then(r => r.text())
+		const c = await fetch(%q, { method: "POST", body: "data", noCloudflareBypass: true })
+		return [a, b, await c.text(), c.headers["X-Count"]].join(",")
+	})()`, server.URL+"/a", server.URL+"/a", server.URL+"/b")
+
+	// Record
+	recorder := NewFetchRecorder()
+	state, res := run(recorder, script)
+	require.Equal(t, goja.PromiseStateFulfilled, state, res)
+	assert.Equal(t, "GET /a 1,GET /a 2,POST /b 3,3", res)
+	require.Len(t, recorder.Fixtures(), 3)
+	require.NoError(t, recorder.Save(fixturesPath))
+
+	// Replay offline, identical requests are replayed in order
+	server.Close()
+	fixtures, err := LoadFetchFixtures(fixturesPath)
+	require.NoError(t, err)
+	assert.Equal(t, FetchFixtureModeReplay, fixtures.Mode())
+
+	state, res = run(fixtures, script)
+	require.Equal(t, goja.PromiseStateFulfilled, state, res)
+	assert.Equal(t, "GET /a 1,GET /a 2,POST /b 3,3", res)
+
+	// Requests that weren't recorded fail
+	fixtures, err = LoadFetchFixtures(fixturesPath)
+	require.NoError(t, err)
+	state, _ = run(fixtures, fmt.Sprintf(`fetch(%q, { noCloudflareBypass: true })`, server.URL+"/c"))
+	assert.Equal(t, goja.PromiseStateRejected, state)
+}
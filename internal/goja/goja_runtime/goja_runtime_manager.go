@@ -3,8 +3,11 @@ package goja_runtime
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 	"seanime/internal/util/result"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -17,6 +20,11 @@ type Manager struct {
 	pluginPools *result.Map[string, *Pool]
 	basePool    *Pool
 	logger      *zerolog.Logger
+	// limiters enforces the resource limits of each extension
+	limiters  *result.Map[string, *Limiter]
+	onDisable func(extID string, reason string)
+	// fetchTransportWrapper wraps the transport of the fetch binding of all runtimes, used to record or replay requests
+	fetchTransportWrapper func(http.RoundTripper) http.RoundTripper
 }
 
 type Pool struct {
@@ -38,7 +46,48 @@ type metrics struct {
 
 func NewManager(logger *zerolog.Logger) *Manager {
 	return &Manager{
-		logger: logger,
+		logger:   logger,
+		limiters: result.NewResultMap[string, *Limiter](),
+	}
+}
+
+// SetOnDisable sets the function called when an extension is disabled after repeated violations.
+func (m *Manager) SetOnDisable(fn func(extID string, reason string)) {
+	m.onDisable = fn
+}
+
+// SetFetchTransportWrapper wraps the transport of the fetch binding of the runtimes created after this call.
+func (m *Manager) SetFetchTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) {
+	m.fetchTransportWrapper = wrap
+}
+
+// GetFetchTransportWrapper returns the wrapper set with SetFetchTransportWrapper, or nil.
+func (m *Manager) GetFetchTransportWrapper() func(http.RoundTripper) http.RoundTripper {
+	return m.fetchTransportWrapper
+}
+
+// GetLimiter returns the limiter of the extension, creating it with the default limits if needed.
+func (m *Manager) GetLimiter(extID string) *Limiter {
+	limiter, _ := m.limiters.GetOrSet(extID, func() (*Limiter, error) {
+		l := newLimiter(extID, DefaultLimits)
+		l.onDisable = func(extID string, reason string) {
+			m.logger.Warn().Str("id", extID).Str("reason", reason).Msg("goja runtime: Extension disabled")
+			if m.onDisable != nil {
+				m.onDisable(extID, reason)
+			}
+		}
+		l.onHeapWarning = func(extID string, growth uint64) {
+			m.logger.Warn().Str("id", extID).Uint64("growthMB", growth>>20).Msg("goja runtime: Heap grew over the memory limit during an extension call, this may be caused by other work")
+		}
+		return l, nil
+	})
+	return limiter
+}
+
+// ResetLimiter re-enables the extension and resets its violation counter.
+func (m *Manager) ResetLimiter(extID string) {
+	if limiter, ok := m.limiters.Get(extID); ok {
+		limiter.Enable()
 	}
 }
 
@@ -90,6 +139,7 @@ func (m *Manager) DeletePluginPool(extID string) {
 
 	// Delete the pool
 	m.pluginPools.Delete(extID)
+	m.limiters.Delete(extID)
 	runtime.GC()
 }
 
@@ -106,13 +156,21 @@ func (m *Manager) Run(ctx context.Context, extID string, fn func(*goja.Runtime)
 	if !ok {
 		return fmt.Errorf("plugin pool not found for extension ID: %s", extID)
 	}
-	runtime, err := pool.Get(ctx)
-	pool.metrics.invocations.Add(1)
+
+	call, err := m.GetLimiter(extID).StartCall(ctx)
 	if err != nil {
 		return err
 	}
+
+	runtime, err := pool.Get(call.Context())
+	pool.metrics.invocations.Add(1)
+	if err != nil {
+		return call.End(err)
+	}
 	defer pool.Put(runtime)
-	return fn(runtime)
+
+	call.Watch(runtime)
+	return call.End(fn(runtime))
 }
 
 func (m *Manager) RunShared(ctx context.Context, fn func(*goja.Runtime) error) error {
@@ -146,6 +204,36 @@ func (m *Manager) PrintPluginPoolMetrics(extID string) {
 		Msg("goja runtime: VM Pool Metrics")
 }
 
+// ExtensionMetrics are the runtime metrics of an extension.
+type ExtensionMetrics struct {
+	ExtensionID string           `json:"extensionId"`
+	Pool        map[string]int64 `json:"pool"`
+	LimiterMetrics
+}
+
+// GetMetrics returns the runtime metrics of all extensions, ordered by extension ID.
+func (m *Manager) GetMetrics() []*ExtensionMetrics {
+	ret := make([]*ExtensionMetrics, 0)
+	m.limiters.Range(func(extID string, limiter *Limiter) bool {
+		metrics := &ExtensionMetrics{
+			ExtensionID:    extID,
+			Pool:           map[string]int64{},
+			LimiterMetrics: limiter.Metrics(),
+		}
+		if m.pluginPools != nil {
+			if pool, ok := m.pluginPools.Get(extID); ok {
+				metrics.Pool = pool.Stats()
+			}
+		}
+		ret = append(ret, metrics)
+		return true
+	})
+	slices.SortFunc(ret, func(a, b *ExtensionMetrics) int {
+		return strings.Compare(a.ExtensionID, b.ExtensionID)
+	})
+	return ret
+}
+
 func (m *Manager) PrintBasePoolMetrics() {
 	if m.basePool == nil {
 		return
@@ -0,0 +1,333 @@
+package goja_runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	runtimemetrics "runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+var (
+	ErrExtensionDisabled      = errors.New("extension disabled after repeated resource limit violations")
+	ErrTooManyConcurrentCalls = errors.New("too many concurrent calls")
+	ErrCallTimeout            = errors.New("call exceeded the execution time limit")
+)
+
+// Limits are the resource limits of an extension.
+// A zero value disables the corresponding limit.
+type Limits struct {
+	// MaxCallDuration is the maximum execution time of a call, including the time spent waiting for promises.
+	MaxCallDuration time.Duration `json:"maxCallDuration"`
+	// MaxConcurrentCalls is the maximum number of calls running at the same time.
+	MaxConcurrentCalls int `json:"maxConcurrentCalls"`
+	// MaxPendingFetches is the maximum number of outstanding fetch requests across all runtimes of the extension.
+	// Additional requests wait for a slot.
+	MaxPendingFetches int `json:"maxPendingFetches"`
+	// MaxHeapGrowth is the growth of the heap during a call after which a warning is logged, in bytes.
+	// This is advisory since the heap is shared by the whole process, the growth can come from other work (scans, streaming),
+	// so it's never counted as a violation.
+	MaxHeapGrowth uint64 `json:"maxHeapGrowth"`
+	// MaxViolations is the number of consecutive violations after which the extension is disabled.
+	MaxViolations int `json:"maxViolations"`
+}
+
+var DefaultLimits = Limits{
+	MaxCallDuration:    2 * time.Minute,
+	MaxConcurrentCalls: 20,
+	MaxPendingFetches:  50,
+	MaxHeapGrowth:      512 << 20, // 512 MB
+	MaxViolations:      5,
+}
+
+// heapSampleInterval is how often the heap is sampled while a call is running.
+const heapSampleInterval = 250 * time.Millisecond
+
+// Limiter enforces the resource limits of an extension and records its metrics.
+type Limiter struct {
+	extID    string
+	limits   Limits
+	callSem  chan struct{}
+	fetchSem chan struct{}
+
+	calls        atomic.Int64
+	errors       atomic.Int64
+	timeouts     atomic.Int64
+	violations   atomic.Int64
+	heapWarnings atomic.Int64
+	totalLatency atomic.Int64 // nanoseconds
+	maxLatency   atomic.Int64 // nanoseconds
+
+	mu                    sync.Mutex
+	consecutiveViolations int
+	disabled              bool
+	disabledReason        string
+	onDisable             func(extID string, reason string)
+	onHeapWarning         func(extID string, growth uint64)
+}
+
+func newLimiter(extID string, limits Limits) *Limiter {
+	l := &Limiter{
+		extID:  extID,
+		limits: limits,
+	}
+	if limits.MaxConcurrentCalls > 0 {
+		l.callSem = make(chan struct{}, limits.MaxConcurrentCalls)
+	}
+	if limits.MaxPendingFetches > 0 {
+		l.fetchSem = make(chan struct{}, limits.MaxPendingFetches)
+	}
+	return l
+}
+
+// FetchSemaphore returns the semaphore limiting the outstanding fetch requests of the extension.
+// It returns nil if there's no limit.
+func (l *Limiter) FetchSemaphore() chan struct{} {
+	if l == nil {
+		return nil
+	}
+	return l.fetchSem
+}
+
+// IsDisabled returns true if the extension was disabled after repeated violations.
+func (l *Limiter) IsDisabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.disabled
+}
+
+// Enable re-enables the extension and resets the violation counter.
+func (l *Limiter) Enable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.disabled = false
+	l.disabledReason = ""
+	l.consecutiveViolations = 0
+}
+
+// Call is a single call to an extension, started with Limiter.StartCall.
+type Call struct {
+	l         *Limiter
+	ctx       context.Context
+	cancel    context.CancelFunc
+	start     time.Time
+	acquired  bool
+	violation atomic.Pointer[error]
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+// StartCall starts a call, waiting for a slot if the extension has reached its concurrent calls limit.
+// Call.End must be called when the call is done.
+func (l *Limiter) StartCall(ctx context.Context) (*Call, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	l.mu.Lock()
+	disabled := l.disabled
+	l.mu.Unlock()
+	if disabled {
+		return nil, ErrExtensionDisabled
+	}
+
+	c := &Call{
+		l:      l,
+		stopCh: make(chan struct{}),
+	}
+	if l.limits.MaxCallDuration > 0 {
+		c.ctx, c.cancel = context.WithTimeout(ctx, l.limits.MaxCallDuration)
+	} else {
+		c.ctx, c.cancel = context.WithCancel(ctx)
+	}
+
+	if l.callSem != nil {
+		select {
+		case l.callSem <- struct{}{}:
+			c.acquired = true
+		case <-c.ctx.Done():
+			c.cancel()
+			return nil, ErrTooManyConcurrentCalls
+		}
+	}
+
+	c.start = time.Now()
+	l.calls.Add(1)
+	return c, nil
+}
+
+// Context returns the context of the call, it is canceled when the call exceeds its execution time limit.
+func (c *Call) Context() context.Context {
+	return c.ctx
+}
+
+// Watch interrupts the runtime if the call exceeds its execution time limit.
+// It also warns once if the heap grows over the advisory memory limit during the call.
+func (c *Call) Watch(vm *goja.Runtime) {
+	go func() {
+		var ticker *time.Ticker
+		var tickCh <-chan time.Time
+		var heapStart uint64
+		if c.l.limits.MaxHeapGrowth > 0 {
+			heapStart = readHeapBytes()
+			ticker = time.NewTicker(heapSampleInterval)
+			tickCh = ticker.C
+			defer ticker.Stop()
+		}
+
+		for {
+			select {
+			case <-c.stopCh:
+				return
+			case <-c.ctx.Done():
+				if errors.Is(c.ctx.Err(), context.DeadlineExceeded) {
+					c.setViolation(ErrCallTimeout)
+					vm.Interrupt(ErrCallTimeout)
+				}
+				return
+			case <-tickCh:
+				if heap := readHeapBytes(); heap > heapStart && heap-heapStart > c.l.limits.MaxHeapGrowth {
+					c.l.warnHeapGrowth(heap - heapStart)
+					// Keep watching the execution time only
+					ticker.Stop()
+					tickCh = nil
+				}
+			}
+		}
+	}()
+}
+
+func (l *Limiter) warnHeapGrowth(growth uint64) {
+	l.heapWarnings.Add(1)
+	l.mu.Lock()
+	onHeapWarning := l.onHeapWarning
+	l.mu.Unlock()
+	if onHeapWarning != nil {
+		onHeapWarning(l.extID, growth)
+	}
+}
+
+func (c *Call) setViolation(err error) {
+	c.violation.CompareAndSwap(nil, &err)
+}
+
+// End ends the call and records its metrics.
+// It returns the violation error if the call exceeded a limit, or err otherwise.
+func (c *Call) End(err error) error {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+
+	// The deadline may have passed while the call wasn't watched (e.g. while waiting for a promise)
+	if c.ctx.Err() != nil && errors.Is(c.ctx.Err(), context.DeadlineExceeded) {
+		c.setViolation(ErrCallTimeout)
+	}
+	c.cancel()
+
+	if c.acquired {
+		<-c.l.callSem
+	}
+
+	l := c.l
+	latency := time.Since(c.start).Nanoseconds()
+	l.totalLatency.Add(latency)
+	for {
+		current := l.maxLatency.Load()
+		if latency <= current || l.maxLatency.CompareAndSwap(current, latency) {
+			break
+		}
+	}
+
+	violation := c.violation.Load()
+	if violation == nil {
+		if err != nil {
+			l.errors.Add(1)
+		}
+		l.mu.Lock()
+		l.consecutiveViolations = 0
+		l.mu.Unlock()
+		return err
+	}
+
+	l.errors.Add(1)
+	l.violations.Add(1)
+	if errors.Is(*violation, ErrCallTimeout) {
+		l.timeouts.Add(1)
+	}
+
+	l.mu.Lock()
+	l.consecutiveViolations++
+	var onDisable func(string, string)
+	reason := ""
+	if !l.disabled && l.limits.MaxViolations > 0 && l.consecutiveViolations >= l.limits.MaxViolations {
+		l.disabled = true
+		reason = fmt.Sprintf("%d consecutive violations, last: %s", l.consecutiveViolations, (*violation).Error())
+		l.disabledReason = reason
+		onDisable = l.onDisable
+	}
+	l.mu.Unlock()
+
+	if onDisable != nil {
+		onDisable(l.extID, reason)
+	}
+
+	return *violation
+}
+
+// Metrics returns the call metrics of the extension.
+func (l *Limiter) Metrics() LimiterMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	calls := l.calls.Load()
+	avgLatency := int64(0)
+	if calls > 0 {
+		avgLatency = l.totalLatency.Load() / calls
+	}
+
+	return LimiterMetrics{
+		Calls:          calls,
+		Errors:         l.errors.Load(),
+		Timeouts:       l.timeouts.Load(),
+		Violations:     l.violations.Load(),
+		HeapWarnings:   l.heapWarnings.Load(),
+		ActiveCalls:    len(l.callSem),
+		PendingFetches: len(l.fetchSem),
+		AvgLatencyMs:   time.Duration(avgLatency).Milliseconds(),
+		MaxLatencyMs:   time.Duration(l.maxLatency.Load()).Milliseconds(),
+		Disabled:       l.disabled,
+		DisabledReason: l.disabledReason,
+		Limits:         l.limits,
+	}
+}
+
+type LimiterMetrics struct {
+	Calls          int64  `json:"calls"`
+	Errors         int64  `json:"errors"`
+	Timeouts       int64  `json:"timeouts"`
+	Violations     int64  `json:"violations"`
+	HeapWarnings   int64  `json:"heapWarnings"` // Calls during which the heap grew over the advisory limit
+	ActiveCalls    int    `json:"activeCalls"`
+	PendingFetches int    `json:"pendingFetches"`
+	AvgLatencyMs   int64  `json:"avgLatencyMs"`
+	MaxLatencyMs   int64  `json:"maxLatencyMs"`
+	Disabled       bool   `json:"disabled"`
+	DisabledReason string `json:"disabledReason,omitempty"`
+	Limits         Limits `json:"limits"`
+}
+
+var heapMetricSample = []runtimemetrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+var heapMetricMu sync.Mutex
+
+// readHeapBytes returns the memory occupied by live and unswept heap objects.
+func readHeapBytes() uint64 {
+	heapMetricMu.Lock()
+	defer heapMetricMu.Unlock()
+	runtimemetrics.Read(heapMetricSample)
+	if heapMetricSample[0].Value.Kind() != runtimemetrics.KindUint64 {
+		return 0
+	}
+	return heapMetricSample[0].Value.Uint64()
+}
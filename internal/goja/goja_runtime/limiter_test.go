@@ -0,0 +1,146 @@
+package goja_runtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_CallTimeout(t *testing.T) {
+	limits := DefaultLimits
+	limits.MaxCallDuration = 100 * time.Millisecond
+	limits.MaxViolations = 2
+	l := newLimiter("test", limits)
+
+	disabled := make(chan string, 1)
+	l.onDisable = func(extID string, reason string) {
+		disabled <- extID
+	}
+
+	runLoop := func() error {
+		call, err := l.StartCall(context.Background())
+		if err != nil {
+			return err
+		}
+		vm := goja.New()
+		call.Watch(vm)
+		_, err = vm.RunString(`while (true) {}`)
+		return call.End(err)
+	}
+
+	// The infinite loop is interrupted
+	assert.ErrorIs(t, runLoop(), ErrCallTimeout)
+	assert.False(t, l.IsDisabled())
+
+	// The extension is disabled after the second consecutive violation
+	assert.ErrorIs(t, runLoop(), ErrCallTimeout)
+	assert.True(t, l.IsDisabled())
+	assert.Equal(t, "test", <-disabled)
+
+	// Calls are refused while disabled
+	assert.ErrorIs(t, runLoop(), ErrExtensionDisabled)
+
+	metrics := l.Metrics()
+	assert.Equal(t, int64(2), metrics.Calls)
+	assert.Equal(t, int64(2), metrics.Timeouts)
+	assert.Equal(t, int64(2), metrics.Violations)
+	assert.True(t, metrics.Disabled)
+	assert.NotEmpty(t, metrics.DisabledReason)
+
+	l.Enable()
+	assert.False(t, l.IsDisabled())
+}
+
+func TestLimiter_ViolationsResetOnSuccess(t *testing.T) {
+	limits := DefaultLimits
+	limits.MaxCallDuration = 50 * time.Millisecond
+	limits.MaxViolations = 2
+	l := newLimiter("test", limits)
+
+	run := func(script string) error {
+		call, err := l.StartCall(context.Background())
+		require.NoError(t, err)
+		vm := goja.New()
+		call.Watch(vm)
+		_, err = vm.RunString(script)
+		return call.End(err)
+	}
+
+	assert.ErrorIs(t, run(`while (true) {}`), ErrCallTimeout)
+	assert.NoError(t, run(`1 + 1`))
+	assert.ErrorIs(t, run(`while (true) {}`), ErrCallTimeout)
+	assert.False(t, l.IsDisabled())
+}
+
+func TestLimiter_HeapGrowthIsAdvisory(t *testing.T) {
+	limits := DefaultLimits
+	limits.MaxHeapGrowth = 1
+	limits.MaxViolations = 1
+	l := newLimiter("test", limits)
+
+	warned := make(chan uint64, 1)
+	l.onHeapWarning = func(extID string, growth uint64) {
+		warned <- growth
+	}
+
+	run := func() error {
+		call, err := l.StartCall(context.Background())
+		require.NoError(t, err)
+		vm := goja.New()
+		call.Watch(vm)
+		_, err = vm.RunString(`
+			const arr = [];
+			const start = Date.now();
+			while (Date.now() - start < 600) { arr.push("x".repeat(1024)); }
+		`)
+		return call.End(err)
+	}
+
+	// The heap is shared by the whole process, growing over the limit only logs a warning
+	assert.NoError(t, run())
+	assert.NoError(t, run())
+	assert.Greater(t, <-warned, uint64(0))
+	assert.False(t, l.IsDisabled())
+
+	metrics := l.Metrics()
+	assert.Equal(t, int64(0), metrics.Violations)
+	assert.GreaterOrEqual(t, metrics.HeapWarnings, int64(1))
+}
+
+func TestLimiter_ConcurrentCalls(t *testing.T) {
+	limits := DefaultLimits
+	limits.MaxConcurrentCalls = 2
+	limits.MaxCallDuration = 100 * time.Millisecond
+	l := newLimiter("test", limits)
+
+	first, err := l.StartCall(context.Background())
+	require.NoError(t, err)
+	second, err := l.StartCall(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.Metrics().ActiveCalls)
+
+	// The third call waits for a slot until its deadline
+	_, err = l.StartCall(context.Background())
+	assert.ErrorIs(t, err, ErrTooManyConcurrentCalls)
+
+	// A slot is released when a call ends
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(20 * time.Millisecond)
+		_ = first.End(nil)
+	}()
+	third, err := l.StartCall(context.Background())
+	require.NoError(t, err)
+	wg.Wait()
+
+	_ = second.End(nil)
+	_ = third.End(nil)
+	assert.Equal(t, 0, l.Metrics().ActiveCalls)
+}
@@ -35,11 +35,13 @@ func (h *Handler) HandleFetchExternalExtensionData(c echo.Context) error {
 // HandleInstallExternalExtension
 //
 //	@summary installs the extension from the given manifest uri.
+//	@desc If the extension is already installed, the replaced version is kept in its history.
 //	@route /api/v1/extensions/external/install [POST]
 //	@returns extension_repo.ExtensionInstallResponse
 func (h *Handler) HandleInstallExternalExtension(c echo.Context) error {
 	type body struct {
 		ManifestURI string `json:"manifestUri"`
+		Repository  string `json:"repository,omitempty"` // The URL of the marketplace repository the extension was found in
 	}
 
 	var b body
@@ -47,7 +49,7 @@ func (h *Handler) HandleInstallExternalExtension(c echo.Context) error {
 		return h.RespondWithError(c, err)
 	}
 
-	res, err := h.App.ExtensionRepository.InstallExternalExtension(b.ManifestURI)
+	res, err := h.App.ExtensionRepository.InstallExternalExtension(b.ManifestURI, b.Repository)
 	if err != nil {
 		return h.RespondWithError(c, err)
 	}
@@ -218,6 +220,26 @@ func (h *Handler) HandleListAnimeTorrentProviderExtensions(c echo.Context) error
 	return h.RespondWithData(c, extensions)
 }
 
+// HandleListSubtitleProviderExtensions
+//
+//	@summary returns the installed subtitle providers.
+//	@route /api/v1/extensions/list/subtitle-provider [GET]
+//	@returns []extension_repo.SubtitleProviderExtensionItem
+func (h *Handler) HandleListSubtitleProviderExtensions(c echo.Context) error {
+	extensions := h.App.ExtensionRepository.ListSubtitleProviderExtensions()
+	return h.RespondWithData(c, extensions)
+}
+
+// HandleListTrackerExtensions
+//
+//	@summary returns the installed trackers.
+//	@route /api/v1/extensions/list/tracker [GET]
+//	@returns []extension_repo.TrackerExtensionItem
+func (h *Handler) HandleListTrackerExtensions(c echo.Context) error {
+	extensions := h.App.ExtensionRepository.ListTrackerExtensions()
+	return h.RespondWithData(c, extensions)
+}
+
 // HandleGetPluginSettings
 //
 //	@summary returns the plugin settings.
@@ -266,6 +288,91 @@ func (h *Handler) HandleGrantPluginPermissions(c echo.Context) error {
 	return h.RespondWithData(c, true)
 }
 
+// HandleGetExtensionRuntimeMetrics
+//
+//	@summary returns the runtime metrics of the JavaScript extensions.
+//	@desc This includes the number of calls, latency, errors, timeouts and resource limit violations of each extension.
+//	@route /api/v1/extensions/runtime-metrics [GET]
+//	@returns []goja_runtime.ExtensionMetrics
+func (h *Handler) HandleGetExtensionRuntimeMetrics(c echo.Context) error {
+	return h.RespondWithData(c, h.App.ExtensionRepository.GetRuntimeMetrics())
+}
+
+// HandleEnableExtensionRuntime
+//
+//	@summary re-enables an extension that was disabled after repeated resource limit violations.
+//	@route /api/v1/extensions/runtime-metrics/enable [POST]
+//	@returns bool
+func (h *Handler) HandleEnableExtensionRuntime(c echo.Context) error {
+	type body struct {
+		ID string `json:"id"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	h.App.ExtensionRepository.EnableExtensionRuntime(b.ID)
+	return h.RespondWithData(c, true)
+}
+
+// HandleGetExtensionTrustedKeys
+//
+//	@summary returns the publisher keys trusted by the user.
+//	@route /api/v1/extensions/trusted-keys [GET]
+//	@returns []extension_repo.TrustedKey
+func (h *Handler) HandleGetExtensionTrustedKeys(c echo.Context) error {
+	return h.RespondWithData(c, h.App.ExtensionRepository.GetTrustedKeys())
+}
+
+// HandleAddExtensionTrustedKey
+//
+//	@summary adds a publisher key to the trusted keys.
+//	@desc The key must be a base64-encoded ed25519 public key.
+//	@route /api/v1/extensions/trusted-keys [POST]
+//	@returns extension_repo.TrustedKey
+func (h *Handler) HandleAddExtensionTrustedKey(c echo.Context) error {
+	type body struct {
+		Name      string `json:"name"`
+		PublicKey string `json:"publicKey"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	key, err := h.App.ExtensionRepository.AddTrustedKey(b.Name, b.PublicKey)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, key)
+}
+
+// HandleRemoveExtensionTrustedKey
+//
+//	@summary removes a publisher key from the trusted keys.
+//	@route /api/v1/extensions/trusted-keys [DELETE]
+//	@returns bool
+func (h *Handler) HandleRemoveExtensionTrustedKey(c echo.Context) error {
+	type body struct {
+		Fingerprint string `json:"fingerprint"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.ExtensionRepository.RemoveTrustedKey(b.Fingerprint); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // HandleRunExtensionPlaygroundCode
@@ -292,6 +399,40 @@ func (h *Handler) HandleRunExtensionPlaygroundCode(c echo.Context) error {
 	return h.RespondWithData(c, res)
 }
 
+// HandleRunExtensionHarness
+//
+//	@summary runs a regression suite of a provider extension against recorded fetch fixtures.
+//	@desc The extension runs offline and the outputs are compared to the expected outputs.
+//	@desc The suite and the files it reads must be in the extension directory.
+//	@desc Recording the fixtures is only available through the 'extension-harness' command.
+//	@route /api/v1/extensions/harness/run [POST]
+//	@returns extension_playground.HarnessRunResult
+func (h *Handler) HandleRunExtensionHarness(c echo.Context) error {
+	type body struct {
+		SuitePath string `json:"suitePath"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if b.SuitePath == "" {
+		return h.RespondWithError(c, fmt.Errorf("suite path is required"))
+	}
+
+	if err := extension_playground.CheckHarnessSuitePath(h.App.Config.Extensions.Dir, b.SuitePath); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	res, err := extension_playground.RunHarness(h.App.Logger, b.SuitePath, false)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // HandleGetExtensionUserConfig
@@ -343,6 +484,7 @@ func (h *Handler) HandleSaveExtensionUserConfig(c echo.Context) error {
 // HandleGetMarketplaceExtensions
 //
 //	@summary returns the marketplace extensions.
+//	@desc If no repository URL is given, the extensions of all repositories are returned.
 //	@route /api/v1/extensions/marketplace [GET]
 //	@returns []extension_repo.MarketplaceExtension
 func (h *Handler) HandleGetMarketplaceExtensions(c echo.Context) error {
@@ -360,3 +502,152 @@ func (h *Handler) HandleGetMarketplaceExtensions(c echo.Context) error {
 
 	return h.RespondWithData(c, extensions)
 }
+
+// HandleGetMarketplaceRepositories
+//
+//	@summary returns the marketplace repositories.
+//	@desc The official repository is always first.
+//	@route /api/v1/extensions/marketplace/repositories [GET]
+//	@returns []extension_repo.MarketplaceRepository
+func (h *Handler) HandleGetMarketplaceRepositories(c echo.Context) error {
+	return h.RespondWithData(c, h.App.ExtensionRepository.GetMarketplaceRepositories())
+}
+
+// HandleAddMarketplaceRepository
+//
+//	@summary adds a marketplace repository.
+//	@desc The URL must point to a JSON list of extension manifests.
+//	@route /api/v1/extensions/marketplace/repositories [POST]
+//	@returns extension_repo.MarketplaceRepository
+func (h *Handler) HandleAddMarketplaceRepository(c echo.Context) error {
+	type body struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	repository, err := h.App.ExtensionRepository.AddMarketplaceRepository(b.Name, b.URL)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, repository)
+}
+
+// HandleRemoveMarketplaceRepository
+//
+//	@summary removes a marketplace repository.
+//	@desc Extensions installed from the repository are not uninstalled.
+//	@route /api/v1/extensions/marketplace/repositories [DELETE]
+//	@returns bool
+func (h *Handler) HandleRemoveMarketplaceRepository(c echo.Context) error {
+	type body struct {
+		URL string `json:"url"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.ExtensionRepository.RemoveMarketplaceRepository(b.URL); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HandleGetExtensionVersions
+//
+//	@summary returns the installed version of an external extension, its update settings and its previous versions.
+//	@route /api/v1/extensions/versions/{id} [GET]
+//	@param id - string - true - "The extension ID"
+//	@returns extension_repo.ExtensionVersions
+func (h *Handler) HandleGetExtensionVersions(c echo.Context) error {
+	res, err := h.App.ExtensionRepository.GetExtensionVersions(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandleRollbackExtension
+//
+//	@summary restores a previous version of an external extension.
+//	@desc If no version is given, the most recent previous version is restored.
+//	@route /api/v1/extensions/rollback [POST]
+//	@returns extension_repo.ExtensionInstallResponse
+func (h *Handler) HandleRollbackExtension(c echo.Context) error {
+	type body struct {
+		ID      string `json:"id"`
+		Version string `json:"version,omitempty"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	res, err := h.App.ExtensionRepository.RollbackExtension(b.ID, b.Version)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandlePinExtension
+//
+//	@summary pins an external extension to a version.
+//	@desc Updates are ignored while the extension is pinned. If the version is a previous version, the extension is rolled back to it.
+//	@desc An empty version unpins the extension.
+//	@route /api/v1/extensions/pin [POST]
+//	@returns bool
+func (h *Handler) HandlePinExtension(c echo.Context) error {
+	type body struct {
+		ID      string `json:"id"`
+		Version string `json:"version"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.ExtensionRepository.PinExtension(b.ID, b.Version); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleSetExtensionChannel
+//
+//	@summary selects the update channel of an external extension.
+//	@desc An empty channel selects the default channel.
+//	@route /api/v1/extensions/channel [POST]
+//	@returns bool
+func (h *Handler) HandleSetExtensionChannel(c echo.Context) error {
+	type body struct {
+		ID      string `json:"id"`
+		Channel string `json:"channel"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.ExtensionRepository.SetExtensionChannel(b.ID, b.Channel); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
@@ -225,6 +225,8 @@ func (h *Handler) HandleEmptyMangaEntryCache(c echo.Context) error {
 // HandleGetMangaEntryChapters
 //
 //	@summary returns the chapters for a manga entry based on the provider.
+//	@desc If the manga has a provider chain, the next providers are used when the provider fails or is missing chapters.
+//	@desc The provider of the returned container can differ from the requested one.
 //	@route /api/v1/manga/chapters [POST]
 //	@returns manga.ChapterContainer
 func (h *Handler) HandleGetMangaEntryChapters(c echo.Context) error {
@@ -239,25 +241,64 @@ func (h *Handler) HandleGetMangaEntryChapters(c echo.Context) error {
 		return h.RespondWithError(c, err)
 	}
 
-	var titles []*string
-	baseManga, found := baseMangaCache.Get(b.MediaId)
-	if !found {
-		var err error
-		baseManga, err = h.App.AnilistPlatform.GetManga(b.MediaId)
-		if err != nil {
-			return h.RespondWithError(c, err)
-		}
-		titles = baseManga.GetAllTitles()
-		baseMangaCache.SetT(b.MediaId, baseManga, 24*time.Hour)
-	} else {
-		titles = baseManga.GetAllTitles()
+	baseManga, err := h.getBaseManga(b.MediaId)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	expectedChapters := 0
+	if baseManga.Chapters != nil {
+		expectedChapters = *baseManga.Chapters
+	}
+
+	container, err := h.App.MangaRepository.GetMangaChapterContainerWithFallback(&manga.GetMangaChapterContainerOptions{
+		Provider: b.Provider,
+		MediaId:  b.MediaId,
+		Titles:   baseManga.GetAllTitles(),
+		Year:     baseManga.GetStartYearSafe(),
+	}, expectedChapters)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, container)
+}
+
+// HandleGetMangaMergedChapters
+//
+//	@summary returns the chapters of a manga entry merged across the provider and its fallback providers.
+//	@desc Chapters are deduplicated by number, the preferred chapter is selected by scanlator, then language, then provider.
+//	@desc Chapters in languages that aren't preferred are ignored.
+//	@desc The response also contains the gaps in the chapter numbering.
+//	@route /api/v1/manga/merged-chapters [POST]
+//	@returns manga.MergedChapterContainer
+func (h *Handler) HandleGetMangaMergedChapters(c echo.Context) error {
+
+	type body struct {
+		MediaId    int      `json:"mediaId"`
+		Provider   string   `json:"provider"`
+		Scanlators []string `json:"scanlators"`
+		Languages  []string `json:"languages"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	baseManga, err := h.getBaseManga(b.MediaId)
+	if err != nil {
+		return h.RespondWithError(c, err)
 	}
 
-	container, err := h.App.MangaRepository.GetMangaChapterContainer(&manga.GetMangaChapterContainerOptions{
+	container, err := h.App.MangaRepository.GetMergedChapterContainer(&manga.GetMangaChapterContainerOptions{
 		Provider: b.Provider,
 		MediaId:  b.MediaId,
-		Titles:   titles,
+		Titles:   baseManga.GetAllTitles(),
 		Year:     baseManga.GetStartYearSafe(),
+	}, &manga.ChapterPreferences{
+		Scanlators: b.Scanlators,
+		Languages:  b.Languages,
 	})
 	if err != nil {
 		return h.RespondWithError(c, err)
@@ -274,6 +315,7 @@ func (h *Handler) HandleGetMangaEntryChapters(c echo.Context) error {
 //	@desc If the app is online and the chapter is not downloaded, it will return the pages from the provider.
 //	@desc If the chapter is downloaded, it will return the appropriate struct.
 //	@desc If 'double page' is requested, it will fetch image sizes and include the dimensions in the response.
+//	@desc If the provider fails and the manga has a provider chain, the pages of the same chapter are fetched from the next providers.
 //	@route /api/v1/manga/pages [POST]
 //	@returns manga.PageContainer
 func (h *Handler) HandleGetMangaEntryPages(c echo.Context) error {
@@ -290,7 +332,22 @@ func (h *Handler) HandleGetMangaEntryPages(c echo.Context) error {
 		return h.RespondWithError(c, err)
 	}
 
-	container, err := h.App.MangaRepository.GetMangaPageContainer(b.Provider, b.MediaId, b.ChapterId, b.DoublePage, h.App.IsOffline())
+	opts := &manga.GetMangaPageContainerWithFallbackOptions{
+		Provider:   b.Provider,
+		MediaId:    b.MediaId,
+		ChapterId:  b.ChapterId,
+		DoublePage: b.DoublePage,
+		IsOffline:  h.App.IsOffline(),
+	}
+	// Titles are only needed to find the chapters of fallback providers
+	if !opts.IsOffline && len(h.App.MangaRepository.GetProviderChain(b.MediaId)) > 0 {
+		if baseManga, err := h.getBaseManga(b.MediaId); err == nil {
+			opts.Titles = baseManga.GetAllTitles()
+			opts.Year = baseManga.GetStartYearSafe()
+		}
+	}
+
+	container, err := h.App.MangaRepository.GetMangaPageContainerWithFallback(opts)
 	if err != nil {
 		return h.RespondWithError(c, err)
 	}
@@ -326,6 +383,20 @@ func (h *Handler) HandleGetMangaEntryDownloadedChapters(c echo.Context) error {
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// getBaseManga returns the manga from the cache or AniList.
+func (h *Handler) getBaseManga(mediaId int) (*anilist.BaseManga, error) {
+	baseManga, found := baseMangaCache.Get(mediaId)
+	if found {
+		return baseManga, nil
+	}
+	baseManga, err := h.App.AnilistPlatform.GetManga(mediaId)
+	if err != nil {
+		return nil, err
+	}
+	baseMangaCache.SetT(mediaId, baseManga, 24*time.Hour)
+	return baseManga, nil
+}
+
 var (
 	anilistListMangaCache = result.NewCache[string, *anilist.ListManga]()
 )
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"seanime/internal/events"
 	"seanime/internal/manga"
 	chapter_downloader "seanime/internal/manga/downloader"
@@ -208,3 +209,73 @@ func (h *Handler) HandleGetMangaDownloadsList(c echo.Context) error {
 
 	return h.RespondWithData(c, res)
 }
+
+// HandleExportMangaDownloadedChapters
+//
+//	@summary packs downloaded chapters into CBZ or EPUB files.
+//	@desc If 'chapters' is empty, all downloaded chapters of the media are exported.
+//	@desc If 'bundle' is true, the chapters are exported as a single file for the whole series.
+//	@desc The files are written to the 'exports' folder in the download directory.
+//	@route /api/v1/manga/export [POST]
+//	@returns manga.ExportResult
+func (h *Handler) HandleExportMangaDownloadedChapters(c echo.Context) error {
+
+	type body struct {
+		MediaId  int                             `json:"mediaId"`
+		Format   manga.ExportFormat              `json:"format"` // "cbz" or "epub"
+		Chapters []chapter_downloader.DownloadID `json:"chapters"`
+		Bundle   bool                            `json:"bundle"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if b.MediaId <= 0 {
+		return h.RespondWithError(c, errors.New("invalid media id"))
+	}
+
+	res, err := h.App.MangaDownloader.Export(&manga.ExportOptions{
+		MediaId:  b.MediaId,
+		Format:   b.Format,
+		Chapters: b.Chapters,
+		Bundle:   b.Bundle,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandleConvertMangaDownloads
+//
+//	@summary converts downloaded chapters stored as images to CBZ archives.
+//	@desc This runs in the background, the client is notified when the conversion is done.
+//	@desc If 'mediaId' is 0, all downloaded chapters are converted.
+//	@desc If 'copyToInternalStorage' is true, the archives are also copied to the internal storage directory.
+//	@route /api/v1/manga/downloads/convert [POST]
+//	@returns bool
+func (h *Handler) HandleConvertMangaDownloads(c echo.Context) error {
+
+	type body struct {
+		MediaId               int  `json:"mediaId"`
+		CopyToInternalStorage bool `json:"copyToInternalStorage"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	err := h.App.MangaDownloader.ConvertDownloads(&manga.ConvertDownloadsOptions{
+		MediaId:               b.MediaId,
+		CopyToInternalStorage: b.CopyToInternalStorage,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
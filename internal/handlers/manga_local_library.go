@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path/filepath"
+	manga_scanner "seanime/internal/manga/scanner"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandleGetMangaLocalLibrary
+//
+//	@summary returns the series found in the local manga library.
+//	@desc It returns the series of the last scan with their matched AniList media ID.
+//	@route /api/v1/manga/local-library [GET]
+//	@returns []manga_scanner.LocalSeries
+func (h *Handler) HandleGetMangaLocalLibrary(c echo.Context) error {
+	return h.RespondWithData(c, h.App.MangaLocalLibrary.GetSeries())
+}
+
+// HandleScanMangaLocalLibrary
+//
+//	@summary scans the local manga library and matches the series to the manga collection.
+//	@desc Manual matches are kept. Matched series can be read using the 'local-library' provider.
+//	@route /api/v1/manga/local-library/scan [POST]
+//	@returns []manga_scanner.LocalSeries
+func (h *Handler) HandleScanMangaLocalLibrary(c echo.Context) error {
+
+	collection, err := h.App.GetMangaCollection(false)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if _, err := h.App.MangaLocalLibrary.Scan(collection); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, h.App.MangaLocalLibrary.GetSeries())
+}
+
+// HandleMatchMangaLocalLibrarySeries
+//
+//	@summary manually matches a local series to an AniList manga.
+//	@desc A media ID of 0 unmatches the series. Manual matches are not changed by later scans.
+//	@route /api/v1/manga/local-library/match [POST]
+//	@returns []manga_scanner.LocalSeries
+func (h *Handler) HandleMatchMangaLocalLibrarySeries(c echo.Context) error {
+
+	type body struct {
+		SeriesKey string `json:"seriesKey"`
+		MediaId   int    `json:"mediaId"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.MangaLocalLibrary.MatchSeries(b.SeriesKey, b.MediaId); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, h.App.MangaLocalLibrary.GetSeries())
+}
+
+func (h *Handler) ServeMangaLocalLibraryPage(c echo.Context) error {
+	chapter, ok := h.App.MangaLocalLibrary.GetChapter(c.QueryParam("id"))
+	if !ok {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	index, err := strconv.Atoi(c.QueryParam("index"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	names, err := manga_scanner.ListPages(chapter)
+	if err != nil {
+		if errors.Is(err, manga_scanner.ErrUnsupportedFormat) {
+			return c.String(http.StatusUnsupportedMediaType, err.Error())
+		}
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	if index < 0 || index >= len(names) {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	data, err := manga_scanner.ReadPage(chapter, names[index])
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(names[index]))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	return c.Blob(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"seanime/internal/extension"
+	manga_mihon "seanime/internal/manga/mihon"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandlePreviewMangaMihonImport
+//
+//	@summary decodes a Tachiyomi/Mihon backup and returns how its manga would be imported.
+//	@desc The backup (.tachibk or .proto.gz) is sent as base64 and decoded locally.
+//	@desc Manga are matched to AniList using the AniList tracker, the manga collection or a title search.
+//	@desc Sources are mapped to the installed manga providers using the manga URL when possible, or a title search.
+//	@desc Nothing is changed until the preview is applied.
+//	@route /api/v1/manga/mihon-import/preview [POST]
+//	@returns manga_mihon.Preview
+func (h *Handler) HandlePreviewMangaMihonImport(c echo.Context) error {
+
+	type body struct {
+		Data string `json:"data"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(b.Data)
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid backup data"))
+	}
+
+	backup, err := manga_mihon.ParseBackup(data)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	collection, err := h.App.GetMangaCollection(false)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	providers := make(map[string]string)
+	extension.RangeExtensions(h.App.MangaRepository.GetProviderExtensionBank(), func(id string, ext extension.MangaProviderExtension) bool {
+		providers[id] = ext.GetName()
+		return true
+	})
+
+	preview := h.newMangaMihonImporter().Preview(&manga_mihon.PreviewOptions{
+		Backup:          backup,
+		MangaCollection: collection,
+		Providers:       providers,
+	})
+
+	return h.RespondWithData(c, preview)
+}
+
+// HandleApplyMangaMihonImport
+//
+//	@summary imports the selected entries of a Tachiyomi/Mihon backup preview.
+//	@desc AniList progress is updated through the active platform when the backup is ahead, and provider mappings are created.
+//	@route /api/v1/manga/mihon-import/apply [POST]
+//	@returns manga_mihon.ApplyResult
+func (h *Handler) HandleApplyMangaMihonImport(c echo.Context) error {
+
+	type body struct {
+		Entries []*manga_mihon.ApplyEntry `json:"entries"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	collection, err := h.App.GetMangaCollection(false)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	res := h.newMangaMihonImporter().Apply(b.Entries, collection)
+
+	return h.RespondWithData(c, res)
+}
+
+func (h *Handler) newMangaMihonImporter() *manga_mihon.Importer {
+	return manga_mihon.NewImporter(&manga_mihon.NewImporterOptions{
+		Logger:     h.App.Logger,
+		Platform:   h.App.AnilistPlatform,
+		Repository: h.App.MangaRepository,
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandleGetMangaProviderHealth
+//
+//	@summary returns the health of the manga providers used since the server started.
+//	@route /api/v1/manga/provider-health [GET]
+//	@returns []manga.ProviderHealth
+func (h *Handler) HandleGetMangaProviderHealth(c echo.Context) error {
+	return h.RespondWithData(c, h.App.MangaRepository.GetProviderHealth())
+}
+
+// HandleGetMangaProviderChain
+//
+//	@summary returns the fallback providers of a manga, in order.
+//	@desc It returns an empty array if the manga has no provider chain.
+//	@route /api/v1/manga/provider-chain/{id} [GET]
+//	@param id - int - true - "AniList manga media ID"
+//	@returns []string
+func (h *Handler) HandleGetMangaProviderChain(c echo.Context) error {
+
+	mId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, h.App.MangaRepository.GetProviderChain(mId))
+}
+
+// HandleSaveMangaProviderChain
+//
+//	@summary sets the fallback providers of a manga, in order.
+//	@desc An empty array removes the provider chain.
+//	@route /api/v1/manga/provider-chain [POST]
+//	@returns bool
+func (h *Handler) HandleSaveMangaProviderChain(c echo.Context) error {
+
+	type body struct {
+		MediaId   int      `json:"mediaId"`
+		Providers []string `json:"providers"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	err := h.App.MangaRepository.SetProviderChain(b.MediaId, b.Providers)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	manga_readingstate "seanime/internal/manga/readingstate"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getSessionUsername returns the username of the current session, empty if there is no session.
+func getSessionUsername(c echo.Context) string {
+	username, _ := c.Get("Username").(string)
+	return username
+}
+
+// HandleGetMangaReadingState
+//
+//	@summary returns the reading state of the chapters of a manga.
+//	@desc The 'resume' field is the chapter and page to resume reading from, if the last chapter opened was not finished.
+//	@route /api/v1/manga/reading-state/{id} [GET]
+//	@param id - int - true - "AniList manga media ID"
+//	@returns manga_readingstate.MediaReadingState
+func (h *Handler) HandleGetMangaReadingState(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	res, err := h.App.MangaReadingState.GetMediaReadingState(getSessionUsername(c), id)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandleUpdateMangaReadingPage
+//
+//	@summary saves the current page of a chapter.
+//	@desc The chapter is marked as read when the last page is reached.
+//	@route /api/v1/manga/reading-state/page [PATCH]
+//	@returns models.MangaReadingState
+func (h *Handler) HandleUpdateMangaReadingPage(c echo.Context) error {
+	type body struct {
+		MediaId       int    `json:"mediaId"`
+		Provider      string `json:"provider"`
+		ChapterId     string `json:"chapterId"`
+		ChapterNumber string `json:"chapterNumber"`
+		CurrentPage   int    `json:"currentPage"`
+		TotalPages    int    `json:"totalPages"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	res, err := h.App.MangaReadingState.UpdatePage(getSessionUsername(c), &manga_readingstate.UpdatePageOptions{
+		MediaId:       b.MediaId,
+		Provider:      b.Provider,
+		ChapterId:     b.ChapterId,
+		ChapterNumber: b.ChapterNumber,
+		CurrentPage:   b.CurrentPage,
+		TotalPages:    b.TotalPages,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandleMarkMangaChapters
+//
+//	@summary marks chapters as read or unread.
+//	@desc If 'syncProgress' is true and chapters are marked as read, the progress is updated to the highest chapter read if it is ahead.
+//	@desc When offline, the progress is updated locally and synced later.
+//	@route /api/v1/manga/reading-state/mark [POST]
+//	@returns manga_readingstate.MediaReadingState
+func (h *Handler) HandleMarkMangaChapters(c echo.Context) error {
+	type body struct {
+		MediaId       int                                      `json:"mediaId"`
+		Provider      string                                   `json:"provider"`
+		Chapters      []*manga_readingstate.MarkChapterOptions `json:"chapters"`
+		Read          bool                                     `json:"read"`
+		SyncProgress  bool                                     `json:"syncProgress"`
+		TotalChapters int                                      `json:"totalChapters"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	res, err := h.App.MangaReadingState.MarkChapters(getSessionUsername(c), &manga_readingstate.MarkChaptersOptions{
+		MediaId:  b.MediaId,
+		Provider: b.Provider,
+		Chapters: b.Chapters,
+		Read:     b.Read,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if b.Read && b.SyncProgress && res.HighestReadChapter > 0 {
+		progress := int(res.HighestReadChapter)
+		if progress > h.getMangaProgress(b.MediaId) {
+			err = h.App.AnilistPlatform.UpdateEntryProgress(b.MediaId, progress, &b.TotalChapters)
+			if err != nil {
+				return h.RespondWithError(c, err)
+			}
+			_, _ = h.App.RefreshMangaCollection()
+		}
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// HandleResetMangaReadingState
+//
+//	@summary deletes the reading state of the chapters of a manga.
+//	@desc The AniList progress is not changed.
+//	@route /api/v1/manga/reading-state/{id} [DELETE]
+//	@param id - int - true - "AniList manga media ID"
+//	@returns bool
+func (h *Handler) HandleResetMangaReadingState(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	if err := h.App.MangaReadingState.ResetMediaReadingState(getSessionUsername(c), id); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleGetMangaContinueReading
+//
+//	@summary returns the unfinished chapters, most recently read first.
+//	@desc Only the last chapter opened of each manga is returned.
+//	@route /api/v1/manga/reading-state/continue [GET]
+//	@returns []manga_readingstate.ContinueReadingItem
+func (h *Handler) HandleGetMangaContinueReading(c echo.Context) error {
+	collection, _ := h.App.GetMangaCollection(false)
+
+	res, err := h.App.MangaReadingState.GetContinueReading(getSessionUsername(c), collection)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, res)
+}
+
+// getMangaProgress returns the progress of a manga entry from the collection, 0 if not found.
+func (h *Handler) getMangaProgress(mediaId int) int {
+	collection, err := h.App.GetMangaCollection(false)
+	if err != nil {
+		return 0
+	}
+	entry, found := collection.GetListEntryFromMangaId(mediaId)
+	if !found || entry.GetProgress() == nil {
+		return 0
+	}
+	return *entry.GetProgress()
+}
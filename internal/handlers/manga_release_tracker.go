@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// HandleGetMangaReleases
+//
+//	@summary returns the latest MangaUpdates releases of the manga being read.
+//	@desc Only manga that have been matched to a MangaUpdates series by the release tracker are returned.
+//	@route /api/v1/manga/release-tracker [GET]
+//	@returns []manga_releasetracker.MediaRelease
+func (h *Handler) HandleGetMangaReleases(c echo.Context) error {
+
+	collection, err := h.App.GetMangaCollection(false)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	releases, err := h.App.MangaReleaseTracker.GetReleases(collection)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, releases)
+}
+
+// HandleRunMangaReleaseTracker
+//
+//	@summary tells the manga release tracker to check for new releases if enabled.
+//	@desc It does nothing if the manga release tracker is disabled.
+//	@route /api/v1/manga/release-tracker/run [POST]
+//	@returns bool
+func (h *Handler) HandleRunMangaReleaseTracker(c echo.Context) error {
+
+	h.App.MangaReleaseTracker.Run()
+
+	return h.RespondWithData(c, true)
+}
@@ -7,6 +7,7 @@ import (
 // HandleStartDefaultMediaPlayer
 //
 //	@summary launches the default media player (vlc or mpc-hc).
+//	@desc For Kodi, this only checks that it's reachable.
 //	@route /api/v1/media-player/start [POST]
 //	@returns bool
 func (h *Handler) HandleStartDefaultMediaPlayer(c echo.Context) error {
@@ -28,6 +29,11 @@ func (h *Handler) HandleStartDefaultMediaPlayer(c echo.Context) error {
 		if err != nil {
 			return h.RespondWithError(c, err)
 		}
+	case "kodi":
+		err = h.App.MediaPlayer.Kodi.Start()
+		if err != nil {
+			return h.RespondWithError(c, err)
+		}
 	}
 
 	return h.RespondWithData(c, true)
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -46,3 +50,29 @@ func (h *Handler) SessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// RequireSessionMiddleware rejects requests without a valid session.
+// It should be used after SessionMiddleware, the session can also be passed as a Bearer token.
+func (h *Handler) RequireSessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if sessionID, ok := c.Get("SessionID").(string); ok && sessionID != "" {
+			return next(c)
+		}
+
+		auth := c.Request().Header.Get(echo.HeaderAuthorization)
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return c.JSON(http.StatusUnauthorized, NewErrorResponse(errors.New("unauthorized")))
+		}
+
+		session, err := h.App.Database.GetUserSessionByID(strings.TrimPrefix(auth, "Bearer "))
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, NewErrorResponse(errors.New("unauthorized")))
+		}
+
+		h.App.UpdateAnilistClientToken(session.Token)
+		c.Set("Username", session.Username)
+		c.Set("SessionID", session.SessionID)
+
+		return next(c)
+	}
+}
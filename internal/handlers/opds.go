@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"seanime/internal/database/models"
+	"seanime/internal/manga/opds"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// opdsTokenDuration is the lifetime of the sessions created for OPDS clients
+const opdsTokenDuration = 365 * 24 * time.Hour
+
+// OpdsAuthMiddleware only lets requests with a valid session through.
+// E-ink readers cannot use the session cookie, so the session ID is also accepted as the password of HTTP Basic auth,
+// as a Bearer token or as the 'token' query parameter.
+func (h *Handler) OpdsAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		sessionId := getOpdsSessionId(c)
+		if sessionId == "" {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Seanime OPDS"`)
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		session, err := h.App.Database.GetUserSessionByID(sessionId)
+		if err != nil {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Seanime OPDS"`)
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		h.App.UpdateAnilistClientToken(session.Token)
+		c.Set("Username", session.Username)
+		c.Set("SessionID", session.SessionID)
+
+		return next(c)
+	}
+}
+
+func getOpdsSessionId(c echo.Context) string {
+	if token := c.QueryParam("token"); token != "" {
+		return token
+	}
+	if _, password, ok := c.Request().BasicAuth(); ok && password != "" {
+		return password
+	}
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	if cookie, err := c.Cookie("Seanime-Session-Id"); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// HandleCreateOpdsToken
+//
+//	@summary creates a long-lived session for OPDS clients.
+//	@desc The token is used as the password of the OPDS catalog, with any username.
+//	@desc It requires a valid session.
+//	@route /api/v1/opds/token [POST]
+//	@returns handlers.OpdsToken
+func (h *Handler) HandleCreateOpdsToken(c echo.Context) error {
+
+	sessionId, _ := c.Get("SessionID").(string)
+	if sessionId == "" {
+		return h.RespondWithError(c, errors.New("you must be logged in to create an OPDS token"))
+	}
+
+	session, err := h.App.Database.GetUserSessionByID(sessionId)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	opdsSession := &models.UserSession{
+		SessionID:  uuid.New().String(),
+		Username:   session.Username,
+		Token:      session.Token,
+		Viewer:     session.Viewer,
+		ExpiresAt:  time.Now().Add(opdsTokenDuration),
+		LastActive: time.Now(),
+	}
+
+	if _, err := h.App.Database.CreateUserSession(opdsSession); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, &OpdsToken{
+		Token:     opdsSession.SessionID,
+		Path:      opds.BasePath,
+		ExpiresAt: opdsSession.ExpiresAt,
+	})
+}
+
+type OpdsToken struct {
+	Token     string    `json:"token"`
+	Path      string    `json:"path"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+//
+// Catalog
+//
+
+func (h *Handler) opdsFeed(c echo.Context, feed *opds.Feed, err error) error {
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+
+	// Propagate the token so that clients that don't support authentication can follow the links
+	if token := c.QueryParam("token"); token != "" {
+		feed.SetToken(token)
+	}
+
+	data, err := feed.Marshal()
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+
+	kind := opds.MimeTypeNavigation
+	for _, link := range feed.Links {
+		if link.Rel == opds.RelSelf {
+			kind = link.Type
+		}
+	}
+	return c.Blob(http.StatusOK, kind+";charset=utf-8", data)
+}
+
+func (h *Handler) opdsError(c echo.Context, err error) error {
+	if errors.Is(err, opds.ErrNotFound) {
+		return c.NoContent(http.StatusNotFound)
+	}
+	h.App.Logger.Error().Err(err).Msg("opds: Request failed")
+	return c.String(http.StatusInternalServerError, err.Error())
+}
+
+func (h *Handler) OpdsRoot(c echo.Context) error {
+	return h.opdsFeed(c, h.App.OpdsCatalog.RootFeed(), nil)
+}
+
+func (h *Handler) OpdsList(c echo.Context) error {
+	feed, err := h.App.OpdsCatalog.ListFeed(c.Param("status"))
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsDownloads(c echo.Context) error {
+	feed, err := h.App.OpdsCatalog.DownloadsFeed()
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsSeries(c echo.Context) error {
+	mediaId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	feed, err := h.App.OpdsCatalog.SeriesFeed(mediaId)
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsLocal(c echo.Context) error {
+	feed, err := h.App.OpdsCatalog.LocalFeed()
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsLocalSeries(c echo.Context) error {
+	series, err := url.PathUnescape(c.Param("series"))
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	feed, err := h.App.OpdsCatalog.LocalSeriesFeed(series)
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsSearch(c echo.Context) error {
+	feed, err := h.App.OpdsCatalog.SearchFeed(c.QueryParam("q"))
+	return h.opdsFeed(c, feed, err)
+}
+
+func (h *Handler) OpdsOpenSearch(c echo.Context) error {
+	description := h.App.OpdsCatalog.OpenSearch()
+	if token := c.QueryParam("token"); token != "" {
+		description.SetToken(token)
+	}
+	data, err := description.Marshal()
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	return c.Blob(http.StatusOK, opds.MimeTypeOpenSearch+";charset=utf-8", data)
+}
+
+//
+// Chapters
+//
+
+func (h *Handler) OpdsDownloadedChapter(c echo.Context) error {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	path, err := h.App.OpdsCatalog.ResolveDownloadedChapter(name)
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	return h.opdsServeChapter(c, path, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+func (h *Handler) OpdsDownloadedChapterPage(c echo.Context) error {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	path, err := h.App.OpdsCatalog.ResolveDownloadedChapter(name)
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	return h.opdsServePage(c, path)
+}
+
+func (h *Handler) OpdsLocalChapter(c echo.Context) error {
+	path, err := h.opdsResolveLocalChapter(c)
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	return h.opdsServeChapter(c, path, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+func (h *Handler) OpdsLocalChapterPage(c echo.Context) error {
+	path, err := h.opdsResolveLocalChapter(c)
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	return h.opdsServePage(c, path)
+}
+
+func (h *Handler) opdsResolveLocalChapter(c echo.Context) (string, error) {
+	series, err := url.PathUnescape(c.Param("series"))
+	if err != nil {
+		return "", opds.ErrNotFound
+	}
+	file, err := url.PathUnescape(c.Param("file"))
+	if err != nil {
+		return "", opds.ErrNotFound
+	}
+	return h.App.OpdsCatalog.ResolveLocalChapter(series, file)
+}
+
+// opdsServeChapter streams a chapter as a CBZ archive.
+func (h *Handler) opdsServeChapter(c echo.Context, path string, name string) error {
+	c.Response().Header().Set(echo.HeaderContentType, opds.MimeTypeCBZ)
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": name + ".cbz"}))
+	c.Response().WriteHeader(http.StatusOK)
+	if err := h.App.OpdsCatalog.WriteChapterArchive(c.Response(), path); err != nil {
+		// The headers have already been sent
+		h.App.Logger.Error().Err(err).Str("path", path).Msg("opds: Failed to stream chapter")
+	}
+	return nil
+}
+
+// opdsServePage serves a page of a chapter for OPDS-PSE clients, page numbers start at 0.
+func (h *Handler) opdsServePage(c echo.Context, path string) error {
+	index, err := strconv.Atoi(c.Param("page"))
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	r, filename, err := h.App.OpdsCatalog.OpenPage(path, index)
+	if err != nil {
+		return h.opdsError(c, err)
+	}
+	defer r.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", 86400))
+	return c.Stream(http.StatusOK, contentType, r)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	plugin_ui "seanime/internal/plugin/ui"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const maxPluginRouteBodySize = 10 << 20 // 10 MB
+
+var (
+	// pluginRouteAllowedHeaders are the response headers a plugin can set, besides Content-Type and non-security "X-" headers.
+	// Cookies, CORS, CSP and the other security headers are dropped since the routes are served on the Seanime origin.
+	pluginRouteAllowedHeaders = map[string]struct{}{
+		"Cache-Control":       {},
+		"Content-Disposition": {},
+		"Content-Language":    {},
+		"Etag":                {},
+		"Expires":             {},
+		"Last-Modified":       {},
+		"Retry-After":         {},
+	}
+	pluginRouteBlockedXHeaders = map[string]struct{}{
+		"X-Frame-Options":                   {},
+		"X-Content-Type-Options":            {},
+		"X-Xss-Protection":                  {},
+		"X-Permitted-Cross-Domain-Policies": {},
+		"X-Dns-Prefetch-Control":            {},
+		"X-Download-Options":                {},
+	}
+	// pluginRouteSafeContentTypes are the content types that can't run scripts in the browser.
+	// HTML, SVG, XML and others are served as plain text or binary data.
+	pluginRouteSafeContentTypes = map[string]struct{}{
+		"application/json":         {},
+		"application/octet-stream": {},
+		"application/zip":          {},
+		"text/plain":               {},
+		"text/csv":                 {},
+		"text/calendar":            {},
+		"image/png":                {},
+		"image/jpeg":               {},
+		"image/gif":                {},
+		"image/webp":               {},
+		"image/avif":               {},
+	}
+)
+
+// HandlePluginHTTPRoute calls the route registered by a plugin with the "http-routes" permission.
+// The response is written as returned by the plugin, except for the headers filtered by setPluginRouteHeaders.
+//
+// route /api/v1/plugins/{id}/*
+func (h *Handler) HandlePluginHTTPRoute(c echo.Context) error {
+	id := c.Param("id")
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxPluginRouteBodySize+1))
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+	if len(body) > maxPluginRouteBodySize {
+		return c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(errors.New("request body too large")))
+	}
+
+	req := &plugin_ui.HTTPRequest{
+		Method:  c.Request().Method,
+		Path:    c.Param("*"),
+		Query:   make(map[string]string),
+		Headers: make(map[string]string),
+		Body:    body,
+	}
+	for key := range c.QueryParams() {
+		req.Query[key] = c.QueryParam(key)
+	}
+	for key := range c.Request().Header {
+		// Don't expose the session to the plugin
+		if key == echo.HeaderCookie || key == echo.HeaderAuthorization {
+			continue
+		}
+		req.Headers[key] = c.Request().Header.Get(key)
+	}
+
+	res, err := h.App.ExtensionRepository.HandlePluginHTTPRequest(c.Request().Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, plugin_ui.ErrHTTPRouteNotFound):
+			return c.JSON(http.StatusNotFound, NewErrorResponse(err))
+		case errors.Is(err, plugin_ui.ErrHTTPRouteTimeout):
+			return c.JSON(http.StatusGatewayTimeout, NewErrorResponse(err))
+		}
+		h.App.Logger.Warn().Err(err).Str("id", id).Msg("extensions: Plugin route handler failed")
+		return h.RespondWithError(c, err)
+	}
+
+	setPluginRouteHeaders(c.Response().Header(), res.Headers)
+	c.Response().WriteHeader(res.Status)
+	_, err = c.Response().Write(res.Body)
+	return err
+}
+
+// setPluginRouteHeaders copies the headers returned by a plugin route that are safe to send on the Seanime origin.
+// The content type is replaced if it could run scripts, and the response can't be sniffed or rendered as a document.
+func setPluginRouteHeaders(dst http.Header, headers map[string]string) {
+	for key, value := range headers {
+		key = http.CanonicalHeaderKey(key)
+		if _, ok := pluginRouteAllowedHeaders[key]; ok {
+			dst.Set(key, value)
+			continue
+		}
+		if _, blocked := pluginRouteBlockedXHeaders[key]; !blocked && strings.HasPrefix(key, "X-") {
+			dst.Set(key, value)
+		}
+	}
+
+	dst.Set(echo.HeaderContentType, getPluginRouteContentType(headers[echo.HeaderContentType]))
+	dst.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	dst.Set(echo.HeaderContentSecurityPolicy, "default-src 'none'; sandbox")
+}
+
+func getPluginRouteContentType(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	_, safe := pluginRouteSafeContentTypes[mediaType]
+	if !safe && (strings.HasPrefix(mediaType, "audio/") || strings.HasPrefix(mediaType, "video/")) {
+		safe = true
+	}
+	if !safe {
+		if strings.HasPrefix(mediaType, "text/") {
+			return "text/plain; charset=utf-8"
+		}
+		return "application/octet-stream"
+	}
+
+	if charset, ok := params["charset"]; ok {
+		return mime.FormatMediaType(mediaType, map[string]string{"charset": charset})
+	}
+	return mediaType
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"seanime/internal/core"
+	"seanime/internal/manga/opds"
 	util "seanime/internal/util/proxies"
 	"strings"
 	"time"
@@ -107,9 +108,40 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 
 	e.GET("/events", h.webSocketEventHandler)
 
+	//
+	// OPDS
+	//
+
+	opdsGroup := e.Group(opds.BasePath, h.OpdsAuthMiddleware)
+	opdsGroup.GET("", h.OpdsRoot)
+	opdsGroup.GET("/", h.OpdsRoot)
+	opdsGroup.GET("/opensearch.xml", h.OpdsOpenSearch)
+	opdsGroup.GET("/search", h.OpdsSearch)
+	opdsGroup.GET("/lists/:status", h.OpdsList)
+	opdsGroup.GET("/downloads", h.OpdsDownloads)
+	opdsGroup.GET("/series/:id", h.OpdsSeries)
+	opdsGroup.GET("/chapters/:name", h.OpdsDownloadedChapter)
+	opdsGroup.GET("/chapters/:name/pages/:page", h.OpdsDownloadedChapterPage)
+	opdsGroup.GET("/local", h.OpdsLocal)
+	opdsGroup.GET("/local/:series", h.OpdsLocalSeries)
+	opdsGroup.GET("/local/:series/:file", h.OpdsLocalChapter)
+	opdsGroup.GET("/local/:series/:file/pages/:page", h.OpdsLocalChapterPage)
+
 	v1 := e.Group("/api").Group("/v1") // Base API group
 
-	imageProxy := &util.ImageProxy{}
+	imageProxy := &util.ImageProxy{
+		// Manga pages are transformed by the page processor if it's enabled, double pages are not split
+		ProcessImage: func(data []byte) ([]byte, string, error) {
+			page, err := h.App.MangaPageProcessor.ProcessSingle(data)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Data, page.Format.ContentType(), nil
+		},
+		ProcessImageKey: func() string {
+			return h.App.MangaPageProcessor.GetCacheKey()
+		},
+	}
 	v1.GET("/image-proxy", imageProxy.ProxyImage)
 
 	v1.GET("/proxy", util.VideoProxy)
@@ -133,6 +165,9 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	protected.GET("/settings", h.HandleGetSettings)
 	protected.PATCH("/settings", h.HandleSaveSettings)
 	protected.POST("/start", h.HandleGettingStarted)
+
+	// OPDS
+	protected.POST("/opds/token", h.HandleCreateOpdsToken)
 	protected.PATCH("/settings/auto-downloader", h.HandleSaveAutoDownloaderSettings)
 
 	// Auto Downloader - protected routes
@@ -300,6 +335,15 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	v1.POST("/onlinestream/get-mapping", h.HandleGetOnlinestreamMapping)
 	v1.POST("/onlinestream/remove-mapping", h.HandleRemoveOnlinestreamMapping)
 
+	//
+	// Subtitles
+	//
+
+	v1.POST("/subtitles/search", h.HandleSearchSubtitles)
+	v1.POST("/subtitles/download", h.HandleDownloadSubtitle)
+	v1.POST("/subtitles/tracks", h.HandleGetSubtitleTracks)
+	v1.DELETE("/subtitles/tracks", h.HandleDeleteSubtitleTrack)
+
 	//
 	// Metadata Provider
 	//
@@ -325,6 +369,7 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	v1Manga.GET("/entry/:id/details", h.HandleGetMangaEntryDetails)
 	v1Manga.DELETE("/entry/cache", h.HandleEmptyMangaEntryCache)
 	v1Manga.POST("/chapters", h.HandleGetMangaEntryChapters)
+	v1Manga.POST("/merged-chapters", h.HandleGetMangaMergedChapters)
 	v1Manga.POST("/pages", h.HandleGetMangaEntryPages)
 	v1Manga.POST("/update-progress", h.HandleUpdateMangaProgress)
 	// DISABLED: Internal storage manga reading functionality has been disabled
@@ -355,6 +400,32 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	v1Manga.POST("/download-queue/stop", h.HandleStopMangaDownloadQueue)
 	v1Manga.DELETE("/download-queue", h.HandleClearAllChapterDownloadQueue)
 	v1Manga.POST("/download-queue/reset-errored", h.HandleResetErroredChapterDownloadQueue)
+	v1Manga.POST("/export", h.HandleExportMangaDownloadedChapters)
+	v1Manga.POST("/downloads/convert", h.HandleConvertMangaDownloads)
+
+	v1Manga.POST("/auto-downloader/run", h.HandleRunMangaAutoDownloader)
+	v1Manga.GET("/auto-downloader/rules", h.HandleGetMangaAutoDownloaderRules)
+	v1Manga.GET("/auto-downloader/rule/manga/:id", h.HandleGetMangaAutoDownloaderRulesByManga)
+	v1Manga.POST("/auto-downloader/rule", h.HandleCreateMangaAutoDownloaderRule)
+	v1Manga.PATCH("/auto-downloader/rule", h.HandleUpdateMangaAutoDownloaderRule)
+	v1Manga.DELETE("/auto-downloader/rule/:id", h.HandleDeleteMangaAutoDownloaderRule)
+	v1Manga.GET("/auto-downloader/items", h.HandleGetMangaAutoDownloaderItems)
+	v1Manga.DELETE("/auto-downloader/item", h.HandleDeleteMangaAutoDownloaderItem)
+
+	v1Manga.GET("/local-library", h.HandleGetMangaLocalLibrary)
+	v1Manga.POST("/local-library/scan", h.HandleScanMangaLocalLibrary)
+	v1Manga.POST("/local-library/match", h.HandleMatchMangaLocalLibrarySeries)
+	v1Manga.GET("/local-library/page", h.ServeMangaLocalLibraryPage)
+
+	v1Manga.POST("/mihon-import/preview", h.HandlePreviewMangaMihonImport)
+	v1Manga.POST("/mihon-import/apply", h.HandleApplyMangaMihonImport)
+
+	v1Manga.GET("/release-tracker", h.HandleGetMangaReleases)
+	v1Manga.POST("/release-tracker/run", h.HandleRunMangaReleaseTracker)
+
+	v1Manga.GET("/provider-health", h.HandleGetMangaProviderHealth)
+	v1Manga.GET("/provider-chain/:id", h.HandleGetMangaProviderChain)
+	v1Manga.POST("/provider-chain", h.HandleSaveMangaProviderChain)
 
 	v1Manga.POST("/search", h.HandleMangaManualSearch)
 	v1Manga.POST("/manual-mapping", h.HandleMangaManualMapping)
@@ -417,6 +488,11 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	protected.POST("/torrentstream/drop", h.HandleTorrentstreamDropTorrent)
 	protected.POST("/torrentstream/torrent-file-previews", h.HandleGetTorrentstreamTorrentFilePreviews)
 	protected.POST("/torrentstream/batch-history", h.HandleGetTorrentstreamBatchHistory)
+	protected.GET("/torrentstream/cache", h.HandleGetTorrentstreamCache)
+	protected.DELETE("/torrentstream/cache", h.HandleClearTorrentstreamCache)
+	protected.DELETE("/torrentstream/cache/item", h.HandleRemoveTorrentstreamCacheItem)
+	protected.GET("/torrentstream/seeding", h.HandleGetTorrentstreamSeeding)
+	protected.POST("/torrentstream/seeding/stop", h.HandleStopTorrentstreamSeeding)
 	protected.GET("/torrentstream/stream/*", echo.WrapHandler(h.HandleTorrentstreamServeStream()))
 
 	//
@@ -425,6 +501,7 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 
 	v1Extensions := protected.Group("/extensions")
 	v1Extensions.POST("/playground/run", h.HandleRunExtensionPlaygroundCode)
+	v1Extensions.POST("/harness/run", h.HandleRunExtensionHarness)
 	v1Extensions.POST("/external/fetch", h.HandleFetchExternalExtensionData)
 	v1Extensions.POST("/external/install", h.HandleInstallExternalExtension)
 	v1Extensions.POST("/external/uninstall", h.HandleUninstallExternalExtension)
@@ -439,12 +516,57 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	v1Extensions.GET("/list/manga-provider", h.HandleListMangaProviderExtensions)
 	v1Extensions.GET("/list/onlinestream-provider", h.HandleListOnlinestreamProviderExtensions)
 	v1Extensions.GET("/list/anime-torrent-provider", h.HandleListAnimeTorrentProviderExtensions)
+	v1Extensions.GET("/list/subtitle-provider", h.HandleListSubtitleProviderExtensions)
+	v1Extensions.GET("/list/tracker", h.HandleListTrackerExtensions)
 	v1Extensions.GET("/user-config/:id", h.HandleGetExtensionUserConfig)
 	v1Extensions.POST("/user-config", h.HandleSaveExtensionUserConfig)
 	v1Extensions.GET("/marketplace", h.HandleGetMarketplaceExtensions)
+	v1Extensions.GET("/marketplace/repositories", h.HandleGetMarketplaceRepositories)
+	v1Extensions.POST("/marketplace/repositories", h.HandleAddMarketplaceRepository)
+	v1Extensions.DELETE("/marketplace/repositories", h.HandleRemoveMarketplaceRepository)
+	v1Extensions.GET("/versions/:id", h.HandleGetExtensionVersions)
+	v1Extensions.POST("/rollback", h.HandleRollbackExtension)
+	v1Extensions.POST("/pin", h.HandlePinExtension)
+	v1Extensions.POST("/channel", h.HandleSetExtensionChannel)
 	v1Extensions.GET("/plugin-settings", h.HandleGetPluginSettings)
 	v1Extensions.POST("/plugin-settings/pinned-trays", h.HandleSetPluginSettingsPinnedTrays)
 	v1Extensions.POST("/plugin-permissions/grant", h.HandleGrantPluginPermissions)
+	v1Extensions.GET("/runtime-metrics", h.HandleGetExtensionRuntimeMetrics)
+	v1Extensions.POST("/runtime-metrics/enable", h.HandleEnableExtensionRuntime)
+	v1Extensions.GET("/trusted-keys", h.HandleGetExtensionTrustedKeys)
+	v1Extensions.POST("/trusted-keys", h.HandleAddExtensionTrustedKey)
+	v1Extensions.DELETE("/trusted-keys", h.HandleRemoveExtensionTrustedKey)
+
+	//
+	// Webhooks
+	//
+
+	v1Webhooks := protected.Group("/webhooks")
+	v1Webhooks.GET("", h.HandleGetWebhooks)
+	v1Webhooks.POST("", h.HandleSaveWebhook)
+	v1Webhooks.GET("/events", h.HandleGetWebhookEvents)
+	v1Webhooks.DELETE("/:id", h.HandleDeleteWebhook)
+	v1Webhooks.POST("/:id/test", h.HandleTestWebhook)
+	v1Webhooks.GET("/:id/deliveries", h.HandleGetWebhookDeliveries)
+	v1Webhooks.POST("/deliveries/:id/redeliver", h.HandleRedeliverWebhookDelivery)
+
+	//
+	// Trackers
+	//
+
+	v1Trackers := protected.Group("/trackers")
+	v1Trackers.GET("", h.HandleGetTrackers)
+	v1Trackers.POST("/:id/enabled", h.HandleSetTrackerEnabled)
+	v1Trackers.GET("/sync-items", h.HandleGetTrackerSyncItems)
+	v1Trackers.GET("/conflicts", h.HandleGetTrackerConflicts)
+	v1Trackers.POST("/sync-items/:id/resolve", h.HandleResolveTrackerConflict)
+	v1Trackers.POST("/sync-items/:id/retry", h.HandleRetryTrackerSyncItem)
+
+	//
+	// Plugin routes
+	//
+
+	protected.Any("/plugins/:id/*", h.HandlePluginHTTPRoute, h.RequireSessionMiddleware)
 
 	//
 	// Continuity
@@ -454,6 +576,16 @@ func InitRoutes(app *core.App, e *echo.Echo) {
 	v1Continuity.GET("/item/:id", h.HandleGetContinuityWatchHistoryItem)
 	v1Continuity.GET("/history", h.HandleGetContinuityWatchHistory)
 
+	//
+	// Manga reading state
+	//
+	v1MangaReadingState := protected.Group("/manga/reading-state")
+	v1MangaReadingState.GET("/continue", h.HandleGetMangaContinueReading)
+	v1MangaReadingState.PATCH("/page", h.HandleUpdateMangaReadingPage)
+	v1MangaReadingState.POST("/mark", h.HandleMarkMangaChapters)
+	v1MangaReadingState.GET("/:id", h.HandleGetMangaReadingState)
+	v1MangaReadingState.DELETE("/:id", h.HandleResetMangaReadingState)
+
 	//
 	// Sync
 	//
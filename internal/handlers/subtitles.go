@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/subtitles"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandleSearchSubtitles
+//
+//	@summary searches subtitles for the given media, episode and language.
+//	@desc All subtitle providers are queried concurrently unless a provider is given.
+//	@desc Providers that fail are listed in the response errors, the other results are still returned.
+//	@desc The path of the video file is optional, it is used to match subtitles by file name.
+//	@route /api/v1/subtitles/search [POST]
+//	@returns subtitles.SearchResponse
+func (h *Handler) HandleSearchSubtitles(c echo.Context) error {
+
+	type body struct {
+		MediaId       int    `json:"mediaId"`
+		EpisodeNumber int    `json:"episodeNumber"`
+		Language      string `json:"language"`
+		Path          string `json:"path"`
+		Provider      string `json:"provider"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if b.MediaId == 0 {
+		return h.RespondWithError(c, errors.New("media id is required"))
+	}
+
+	ret, err := h.App.SubtitleRepository.Search(&subtitles.SearchOptions{
+		MediaId:       b.MediaId,
+		EpisodeNumber: b.EpisodeNumber,
+		Language:      b.Language,
+		Path:          b.Path,
+		Provider:      b.Provider,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, ret)
+}
+
+// HandleDownloadSubtitle
+//
+//	@summary downloads a subtitle for the given video file.
+//	@desc The subtitle is cached next to the media info of the file.
+//	@desc It is offered as an external track when the file is played with the transcoder, direct play, VLC or MPV.
+//	@route /api/v1/subtitles/download [POST]
+//	@returns subtitles.Track
+func (h *Handler) HandleDownloadSubtitle(c echo.Context) error {
+
+	type body struct {
+		Path   string                       `json:"path"`
+		Result *hibikesubtitle.SearchResult `json:"result"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	ret, err := h.App.SubtitleRepository.Download(b.Path, b.Result)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, ret)
+}
+
+// HandleGetSubtitleTracks
+//
+//	@summary returns the subtitles downloaded for the given video file.
+//	@route /api/v1/subtitles/tracks [POST]
+//	@returns []subtitles.Track
+func (h *Handler) HandleGetSubtitleTracks(c echo.Context) error {
+
+	type body struct {
+		Path string `json:"path"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	ret, err := h.App.SubtitleRepository.GetTracks(b.Path)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, ret)
+}
+
+// HandleDeleteSubtitleTrack
+//
+//	@summary deletes a subtitle downloaded for the given video file.
+//	@route /api/v1/subtitles/tracks [DELETE]
+//	@returns bool
+func (h *Handler) HandleDeleteSubtitleTrack(c echo.Context) error {
+
+	type body struct {
+		Path     string `json:"path"`
+		Filename string `json:"filename"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	err := h.App.SubtitleRepository.DeleteTrack(b.Path, b.Filename)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
@@ -90,6 +90,13 @@ func (h *Handler) HandleSaveTorrentstreamSettings(c echo.Context) error {
 		}
 	}
 
+	// Validate the bandwidth schedule
+	if b.Settings.BandwidthScheduleEnabled {
+		if err := torrentstream.ValidateBandwidthSchedule(b.Settings.BandwidthScheduleStart, b.Settings.BandwidthScheduleEnd); err != nil {
+			return h.RespondWithError(c, err)
+		}
+	}
+
 	settings, err := h.App.Database.UpsertTorrentstreamSettings(&b.Settings)
 	if err != nil {
 		return h.RespondWithError(c, err)
@@ -245,6 +252,91 @@ func (h *Handler) HandleGetTorrentstreamBatchHistory(c echo.Context) error {
 	return h.RespondWithData(c, ret)
 }
 
+// HandleGetTorrentstreamCache
+//
+//	@summary returns the contents of the torrent streaming download directory.
+//	@desc This returns the torrents stored in the download directory and the total size.
+//	@returns torrentstream.CacheInfo
+//	@route /api/v1/torrentstream/cache [GET]
+func (h *Handler) HandleGetTorrentstreamCache(c echo.Context) error {
+	ret, err := h.App.TorrentstreamRepository.GetCacheInfo()
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, ret)
+}
+
+// HandleRemoveTorrentstreamCacheItem
+//
+//	@summary removes a torrent from the torrent streaming download directory.
+//	@desc The torrent being streamed cannot be removed.
+//	@returns bool
+//	@route /api/v1/torrentstream/cache/item [DELETE]
+func (h *Handler) HandleRemoveTorrentstreamCacheItem(c echo.Context) error {
+	type body struct {
+		InfoHash string `json:"infoHash"`
+	}
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	err := h.App.TorrentstreamRepository.RemoveCacheItem(b.InfoHash)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleClearTorrentstreamCache
+//
+//	@summary removes all torrents from the torrent streaming download directory.
+//	@desc The torrent being streamed is kept.
+//	@returns bool
+//	@route /api/v1/torrentstream/cache [DELETE]
+func (h *Handler) HandleClearTorrentstreamCache(c echo.Context) error {
+	err := h.App.TorrentstreamRepository.ClearCache()
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleGetTorrentstreamSeeding
+//
+//	@summary returns the streamed torrents that are still seeding.
+//	@desc Torrents are kept seeding after playback until the ratio or time target set in the settings is reached.
+//	@returns []torrentstream.SeedingTorrent
+//	@route /api/v1/torrentstream/seeding [GET]
+func (h *Handler) HandleGetTorrentstreamSeeding(c echo.Context) error {
+	return h.RespondWithData(c, h.App.TorrentstreamRepository.GetSeedingTorrents())
+}
+
+// HandleStopTorrentstreamSeeding
+//
+//	@summary stops seeding a torrent before its target is reached.
+//	@returns bool
+//	@route /api/v1/torrentstream/seeding/stop [POST]
+func (h *Handler) HandleStopTorrentstreamSeeding(c echo.Context) error {
+	type body struct {
+		InfoHash string `json:"infoHash"`
+	}
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	err := h.App.TorrentstreamRepository.StopSeeding(b.InfoHash)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
 // route /api/v1/torrentstream/stream/*
 func (h *Handler) HandleTorrentstreamServeStream() http.Handler {
 	return h.App.TorrentstreamRepository.HTTPStreamHandler()
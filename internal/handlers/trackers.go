@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/tracker"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandleGetTrackers
+//
+//	@summary returns the installed trackers.
+//	@desc Trackers are tracker extensions that mirror the AniList list changes to third-party services.
+//	@route /api/v1/trackers [GET]
+//	@returns []tracker.TrackerItem
+func (h *Handler) HandleGetTrackers(c echo.Context) error {
+	return h.RespondWithData(c, h.App.TrackerManager.ListTrackers())
+}
+
+// HandleSetTrackerEnabled
+//
+//	@summary enables or disables mirroring to a tracker.
+//	@route /api/v1/trackers/{id}/enabled [POST]
+//	@param id - string - true - "The extension ID of the tracker"
+//	@returns bool
+func (h *Handler) HandleSetTrackerEnabled(c echo.Context) error {
+	type body struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.TrackerManager.SetEnabled(c.Param("id"), b.Enabled); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleGetTrackerSyncItems
+//
+//	@summary returns the sync log of the trackers.
+//	@desc It returns the latest 200 changes, newest first.
+//	@route /api/v1/trackers/sync-items [GET]
+//	@returns []tracker.SyncItem
+func (h *Handler) HandleGetTrackerSyncItems(c echo.Context) error {
+	items, err := h.App.TrackerManager.GetSyncItems("", 200)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, items)
+}
+
+// HandleGetTrackerConflicts
+//
+//	@summary returns the conflict report.
+//	@desc These are the changes that were not sent because the tracker entry looks more recent.
+//	@desc 'remote' holds the tracker entry at the time of the check.
+//	@route /api/v1/trackers/conflicts [GET]
+//	@returns []tracker.SyncItem
+func (h *Handler) HandleGetTrackerConflicts(c echo.Context) error {
+	items, err := h.App.TrackerManager.GetSyncItems(tracker.StatusConflict, 200)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, items)
+}
+
+// HandleResolveTrackerConflict
+//
+//	@summary resolves a conflicting change.
+//	@desc If 'overwrite' is true, the change is sent anyway, otherwise it is skipped.
+//	@route /api/v1/trackers/sync-items/{id}/resolve [POST]
+//	@param id - int - true - "The DB id of the change"
+//	@returns bool
+func (h *Handler) HandleResolveTrackerConflict(c echo.Context) error {
+	type body struct {
+		Overwrite bool `json:"overwrite"`
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	if err := h.App.TrackerManager.ResolveConflict(uint(id), b.Overwrite); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleRetryTrackerSyncItem
+//
+//	@summary queues a failed change again.
+//	@route /api/v1/trackers/sync-items/{id}/retry [POST]
+//	@param id - int - true - "The DB id of the change"
+//	@returns bool
+func (h *Handler) HandleRetryTrackerSyncItem(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	if err := h.App.TrackerManager.Retry(uint(id)); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HandleGetWebhooks
+//
+//	@summary returns all webhooks.
+//	@route /api/v1/webhooks [GET]
+//	@returns []models.Webhook
+func (h *Handler) HandleGetWebhooks(c echo.Context) error {
+	webhooks, err := h.App.WebhookManager.GetWebhooks()
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, webhooks)
+}
+
+// HandleGetWebhookEvents
+//
+//	@summary returns the names of the events webhooks can subscribe to.
+//	@desc The names are the hook names without the "on" prefix, e.g. "scanCompleted".
+//	@route /api/v1/webhooks/events [GET]
+//	@returns []string
+func (h *Handler) HandleGetWebhookEvents(c echo.Context) error {
+	return h.RespondWithData(c, h.App.WebhookManager.ListEvents())
+}
+
+// HandleSaveWebhook
+//
+//	@summary creates or updates a webhook.
+//	@desc The webhook is created if the ID is 0.
+//	@desc 'events' is a comma-separated list of event names, 'headers' holds one "Key: Value" per line.
+//	@desc 'template' is an optional Go template of the body, the data is the default JSON payload: .Event, .Timestamp and .Data.
+//	@route /api/v1/webhooks [POST]
+//	@returns models.Webhook
+func (h *Handler) HandleSaveWebhook(c echo.Context) error {
+	type body struct {
+		ID       uint   `json:"id"`
+		Name     string `json:"name"`
+		Enabled  bool   `json:"enabled"`
+		URL      string `json:"url"`
+		Events   string `json:"events"`
+		Secret   string `json:"secret"`
+		Template string `json:"template"`
+		Headers  string `json:"headers"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	webhook, err := h.App.WebhookManager.SaveWebhook(&models.Webhook{
+		BaseModel: models.BaseModel{ID: b.ID},
+		Name:      b.Name,
+		Enabled:   b.Enabled,
+		URL:       b.URL,
+		Events:    b.Events,
+		Secret:    b.Secret,
+		Template:  b.Template,
+		Headers:   b.Headers,
+	})
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, webhook)
+}
+
+// HandleDeleteWebhook
+//
+//	@summary deletes a webhook and its delivery log.
+//	@route /api/v1/webhooks/{id} [DELETE]
+//	@param id - int - true - "The DB id of the webhook"
+//	@returns bool
+func (h *Handler) HandleDeleteWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	if err := h.App.WebhookManager.DeleteWebhook(uint(id)); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleTestWebhook
+//
+//	@summary queues a delivery of the "test" event to a webhook.
+//	@desc The delivery is sent even if the webhook is disabled.
+//	@route /api/v1/webhooks/{id}/test [POST]
+//	@param id - int - true - "The DB id of the webhook"
+//	@returns bool
+func (h *Handler) HandleTestWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	if err := h.App.WebhookManager.Test(uint(id)); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
+
+// HandleGetWebhookDeliveries
+//
+//	@summary returns the delivery log of a webhook.
+//	@desc It returns the latest 200 deliveries, newest first.
+//	@route /api/v1/webhooks/{id}/deliveries [GET]
+//	@param id - int - true - "The DB id of the webhook"
+//	@returns []models.WebhookDelivery
+func (h *Handler) HandleGetWebhookDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	deliveries, err := h.App.WebhookManager.GetDeliveries(uint(id), 200)
+	if err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, deliveries)
+}
+
+// HandleRedeliverWebhookDelivery
+//
+//	@summary queues a delivery again.
+//	@desc The attempts are reset and the same body is sent.
+//	@route /api/v1/webhooks/deliveries/{id}/redeliver [POST]
+//	@param id - int - true - "The DB id of the delivery"
+//	@returns bool
+func (h *Handler) HandleRedeliverWebhookDelivery(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.RespondWithError(c, errors.New("invalid id"))
+	}
+
+	if err := h.App.WebhookManager.Redeliver(uint(id)); err != nil {
+		return h.RespondWithError(c, err)
+	}
+
+	return h.RespondWithData(c, true)
+}
@@ -14,11 +14,13 @@ import (
 	"seanime/internal/library/anime"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/platforms/platform"
+	"seanime/internal/subtitles"
 	"seanime/internal/util"
 	"seanime/internal/util/result"
 	"sync"
 
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
 	"github.com/samber/mo"
 )
 
@@ -47,6 +49,7 @@ type (
 		Database              *db.Database
 		MediaPlayerRepository *mediaplayer.Repository // MediaPlayerRepository is used to control the media player
 		continuityManager     *continuity.Manager
+		subtitleRepository    *subtitles.Repository // Used to add the downloaded subtitles to the media player (can be nil)
 
 		settings *Settings
 
@@ -144,6 +147,7 @@ type (
 		DiscordPresence            *discordrpc_presence.Presence
 		IsOffline                  bool
 		ContinuityManager          *continuity.Manager
+		SubtitleRepository         *subtitles.Repository
 	}
 
 	Settings struct {
@@ -175,6 +179,7 @@ func New(opts *NewPlaybackManagerOptions) *PlaybackManager {
 		currentLocalFileWrapperEntry:   mo.None[*anime.LocalFileWrapperEntry](),
 		currentMediaListEntry:          mo.None[*anilist.AnimeListEntry](),
 		continuityManager:              opts.ContinuityManager,
+		subtitleRepository:             opts.SubtitleRepository,
 		playbackStatusSubscribers:      result.NewResultMap[string, *PlaybackStatusSubscriber](),
 	}
 
@@ -275,6 +280,15 @@ func (pm *PlaybackManager) StartPlayingUsingMediaPlayer(opts *StartPlayingOption
 		return err
 	}
 
+	// Offer the downloaded subtitles as external tracks
+	if pm.subtitleRepository != nil {
+		if tracks, err := pm.subtitleRepository.GetTracks(opts.Payload); err == nil {
+			pm.MediaPlayerRepository.AddSubtitles(lo.Map(tracks, func(track *subtitles.Track, _ int) string {
+				return track.Path
+			}))
+		}
+	}
+
 	trackingEvent := &PlaybackBeforeTrackingEvent{
 		IsStream: false,
 	}
@@ -0,0 +1,327 @@
+package manga_autodownloader
+
+import (
+	"fmt"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"seanime/internal/database/db_bridge"
+	"seanime/internal/database/models"
+	"seanime/internal/events"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/manga"
+	"seanime/internal/notifier"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultInterval = 60 // minutes
+	minInterval     = 15 // minutes
+	// maxChaptersPerRule is the maximum number of chapters queued for a rule in a single run
+	maxChaptersPerRule = 20
+)
+
+type (
+	// AutoDownloader periodically refreshes the chapter containers of tracked manga and queues new chapters.
+	AutoDownloader struct {
+		logger            *zerolog.Logger
+		database          *db.Database
+		wsEventManager    events.WSEventManagerInterface
+		platform          platform.Platform
+		mangaRepository   *manga.Repository
+		mangaDownloader   *manga.Downloader
+		enabled           bool
+		interval          int
+		settingsUpdatedCh chan struct{}
+		startCh           chan struct{}
+		mu                sync.Mutex
+		runMu             sync.Mutex
+	}
+
+	NewAutoDownloaderOptions struct {
+		Logger          *zerolog.Logger
+		Database        *db.Database
+		WSEventManager  events.WSEventManagerInterface
+		Platform        platform.Platform
+		MangaRepository *manga.Repository
+		MangaDownloader *manga.Downloader
+	}
+)
+
+func New(opts *NewAutoDownloaderOptions) *AutoDownloader {
+	return &AutoDownloader{
+		logger:            opts.Logger,
+		database:          opts.Database,
+		wsEventManager:    opts.WSEventManager,
+		platform:          opts.Platform,
+		mangaRepository:   opts.MangaRepository,
+		mangaDownloader:   opts.MangaDownloader,
+		enabled:           false, // Will be set in SetSettings
+		interval:          defaultInterval,
+		settingsUpdatedCh: make(chan struct{}, 1),
+		startCh:           make(chan struct{}, 1),
+	}
+}
+
+// SetSettings should be called after the settings are fetched and updated from the database.
+func (ad *AutoDownloader) SetSettings(settings *models.MangaSettings) {
+	if ad == nil || settings == nil {
+		return
+	}
+
+	ad.mu.Lock()
+	wasEnabled := ad.enabled
+	ad.enabled = settings.AutoDownloaderEnabled
+	ad.interval = settings.AutoDownloaderInterval
+	if ad.interval <= 0 {
+		ad.interval = defaultInterval
+	}
+	ad.interval = max(ad.interval, minInterval)
+	ad.mu.Unlock()
+
+	select {
+	case ad.settingsUpdatedCh <- struct{}{}:
+	default:
+	}
+
+	// Check right away when the module is enabled
+	if !wasEnabled && settings.AutoDownloaderEnabled {
+		ad.Run()
+	}
+}
+
+// Start spins up the goroutine that checks for new chapters at the configured interval.
+func (ad *AutoDownloader) Start() {
+	if ad == nil {
+		return
+	}
+	go ad.start()
+}
+
+// Run tells the auto downloader to check for new chapters if it's enabled.
+func (ad *AutoDownloader) Run() {
+	if ad == nil {
+		return
+	}
+	select {
+	case ad.startCh <- struct{}{}:
+	default:
+	}
+}
+
+func (ad *AutoDownloader) isEnabled() bool {
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+	return ad.enabled
+}
+
+func (ad *AutoDownloader) start() {
+	defer util.HandlePanicInModuleThen("manga/autodownloader/start", func() {})
+
+	for {
+		ad.mu.Lock()
+		interval := ad.interval
+		ad.mu.Unlock()
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+		select {
+		case <-ad.settingsUpdatedCh:
+			// Restart the loop with the new interval
+		case <-ad.startCh:
+			if ad.isEnabled() {
+				ad.checkForNewChapters()
+			}
+		case <-ticker.C:
+			if ad.isEnabled() {
+				ad.checkForNewChapters()
+			}
+		}
+		ticker.Stop()
+	}
+}
+
+func (ad *AutoDownloader) checkForNewChapters() {
+	defer util.HandlePanicInModuleThen("manga/autodownloader/checkForNewChapters", func() {})
+
+	// Only one run at a time
+	if !ad.runMu.TryLock() {
+		return
+	}
+	defer ad.runMu.Unlock()
+
+	rules, err := db_bridge.GetMangaAutoDownloaderRules(ad.database)
+	if err != nil {
+		ad.logger.Error().Err(err).Msg("manga autodownloader: Failed to fetch rules from the database")
+		return
+	}
+
+	enabledRules := make([]*manga.AutoDownloaderRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled && rule.Provider != "" {
+			enabledRules = append(enabledRules, rule)
+		}
+	}
+	if len(enabledRules) == 0 {
+		ad.logger.Debug().Msg("manga autodownloader: No rules found")
+		return
+	}
+
+	mangaCollection, err := ad.platform.GetMangaCollection(false)
+	if err != nil {
+		ad.logger.Error().Err(err).Msg("manga autodownloader: Failed to get manga collection")
+		return
+	}
+
+	ad.logger.Debug().Int("rules", len(enabledRules)).Msg("manga autodownloader: Checking for new chapters")
+
+	queued := 0
+	for _, rule := range enabledRules {
+		queued += ad.checkRule(rule, mangaCollection)
+	}
+
+	if queued > 0 {
+		ad.mangaDownloader.RunChapterDownloadQueue()
+		notifier.GlobalNotifier.Notify(
+			notifier.MangaAutoDownloader,
+			fmt.Sprintf("%d %s %s been added to the queue.", queued, util.Pluralize(queued, "chapter", "chapters"), util.Pluralize(queued, "has", "have")),
+		)
+	}
+}
+
+// checkRule refreshes the chapter container of the rule's media and queues the chapters to download.
+// It returns the number of chapters queued.
+func (ad *AutoDownloader) checkRule(rule *manga.AutoDownloaderRule, mangaCollection *anilist.MangaCollection) (queued int) {
+	defer util.HandlePanicInModuleThen("manga/autodownloader/checkRule", func() {})
+
+	listEntry, found := mangaCollection.GetListEntryFromMangaId(rule.MediaId)
+	if !found || listEntry.GetStatus() == nil || *listEntry.GetStatus() != anilist.MediaListStatusCurrent {
+		return 0
+	}
+
+	container, err := ad.mangaRepository.RefetchMangaChapterContainer(&manga.GetMangaChapterContainerOptions{
+		Provider: rule.Provider,
+		MediaId:  rule.MediaId,
+		Titles:   listEntry.GetMedia().GetAllTitles(),
+		Year:     listEntry.GetMedia().GetStartYearSafe(),
+	})
+	if err != nil {
+		ad.logger.Error().Err(err).Int("mediaId", rule.MediaId).Msg("manga autodownloader: Failed to fetch chapters")
+		return 0
+	}
+
+	// Set the baseline on the first check so that only chapters released afterward are downloaded
+	if rule.ChapterType != manga.AutoDownloaderRuleChapterTypeAfterProgress && rule.BaselineChapter == nil {
+		baseline := getLatestChapterNumber(container.Chapters, rule)
+		rule.BaselineChapter = &baseline
+		if err := db_bridge.UpdateMangaAutoDownloaderRule(ad.database, rule.DbID, rule); err != nil {
+			ad.logger.Error().Err(err).Msg("manga autodownloader: Failed to update rule")
+		}
+		ad.logger.Debug().Int("mediaId", rule.MediaId).Float64("baseline", baseline).Msg("manga autodownloader: Set baseline chapter")
+		return 0
+	}
+
+	items, err := ad.database.GetMangaAutoDownloaderItemsByMediaId(rule.MediaId)
+	if err != nil {
+		items = make([]*models.MangaAutoDownloaderItem, 0)
+	}
+
+	downloadData, err := ad.mangaDownloader.GetMediaDownloads(rule.MediaId, true)
+	if err != nil {
+		return 0
+	}
+	ad.markDownloadedItems(items, downloadData)
+
+	progress := 0
+	if listEntry.GetProgress() != nil {
+		progress = *listEntry.GetProgress()
+	}
+
+	chapters := selectChapters(ad.fillChapterGaps(container, rule, listEntry, progress), rule, progress, getExistingChapterNumbers(items, downloadData))
+	if len(chapters) > maxChaptersPerRule {
+		chapters = chapters[:maxChaptersPerRule]
+	}
+
+	for _, chapter := range chapters {
+		err := ad.mangaDownloader.DownloadChapter(manga.DownloadChapterOptions{
+			Provider:  chapter.Provider,
+			MediaId:   rule.MediaId,
+			ChapterId: chapter.ID,
+		})
+		if err != nil {
+			ad.logger.Error().Err(err).Str("chapter", chapter.Chapter).Msg("manga autodownloader: Failed to queue chapter")
+			continue
+		}
+
+		_ = ad.database.InsertMangaAutoDownloaderItem(&models.MangaAutoDownloaderItem{
+			RuleID:        rule.DbID,
+			MediaID:       rule.MediaId,
+			Provider:      chapter.Provider,
+			ChapterID:     chapter.ID,
+			ChapterNumber: normalizeChapterNumber(chapter.Chapter),
+			Scanlator:     chapter.Scanlator,
+			Language:      chapter.Language,
+		})
+		queued++
+
+		time.Sleep(400 * time.Millisecond) // Sleep to avoid rate limiting
+	}
+
+	if queued > 0 {
+		ad.logger.Info().Int("mediaId", rule.MediaId).Int("chapters", queued).Msg("manga autodownloader: Queued new chapters")
+	}
+
+	return queued
+}
+
+// fillChapterGaps returns the chapters of the container, along with the chapters of the manga's provider chain
+// that fill gaps in the container's numbering after the threshold.
+// The chain is only fetched when there are such gaps.
+func (ad *AutoDownloader) fillChapterGaps(container *manga.ChapterContainer, rule *manga.AutoDownloaderRule, listEntry *anilist.MangaListEntry, progress int) []*hibikemanga.ChapterDetails {
+	threshold, ok := getChapterThreshold(rule, progress)
+	if !ok {
+		return container.Chapters
+	}
+
+	gaps := lo.Filter(manga.DetectChapterGaps(container.Chapters), func(gap *manga.ChapterGap, _ int) bool {
+		return float64(gap.End) > threshold
+	})
+	if len(gaps) == 0 {
+		return container.Chapters
+	}
+
+	fallbacks := ad.mangaRepository.GetProviderChainChapterContainers(&manga.GetMangaChapterContainerOptions{
+		Provider: rule.Provider,
+		MediaId:  rule.MediaId,
+		Titles:   listEntry.GetMedia().GetAllTitles(),
+		Year:     listEntry.GetMedia().GetStartYearSafe(),
+	})
+	fillers := getGapFillers(gaps, fallbacks)
+	if len(fillers) > 0 {
+		ad.logger.Debug().Int("mediaId", rule.MediaId).Int("chapters", len(fillers)).Msg("manga autodownloader: Filling gaps with fallback providers")
+	}
+
+	// The provider's chapters come first so that they are preferred
+	return append(slices.Clone(container.Chapters), fillers...)
+}
+
+// markDownloadedItems marks the history items whose chapters are in the download directory.
+func (ad *AutoDownloader) markDownloadedItems(items []*models.MangaAutoDownloaderItem, downloadData manga.MediaDownloadData) {
+	for _, item := range items {
+		if item.Downloaded {
+			continue
+		}
+		for _, chapter := range downloadData.Downloaded[item.Provider] {
+			if chapter.ChapterID == item.ChapterID {
+				_ = ad.database.MarkMangaAutoDownloaderItemDownloaded(item.ID)
+				item.Downloaded = true
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package manga_autodownloader
+
+import (
+	"cmp"
+	"seanime/internal/database/models"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/manga"
+	manga_providers "seanime/internal/manga/providers"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// selectChapters returns the chapters to download, one per chapter number, ordered by chapter number.
+//   - Chapters that don't match the rule's scanlators and languages are ignored.
+//   - When several chapters have the same number, the one with the preferred scanlator, then language, is selected.
+//   - Chapters that are already downloaded, queued or in the history are ignored.
+func selectChapters(
+	chapters []*hibikemanga.ChapterDetails,
+	rule *manga.AutoDownloaderRule,
+	progress int,
+	existing map[string]struct{},
+) []*hibikemanga.ChapterDetails {
+
+	threshold, ok := getChapterThreshold(rule, progress)
+	if !ok {
+		return nil
+	}
+
+	best := make(map[string]*hibikemanga.ChapterDetails)
+	for _, chapter := range chapters {
+		if getPreferenceRank(rule.Scanlators, chapter.Scanlator) < 0 || getPreferenceRank(rule.Languages, chapter.Language) < 0 {
+			continue
+		}
+
+		number := normalizeChapterNumber(chapter.Chapter)
+		numberFloat, err := strconv.ParseFloat(number, 64)
+		if err != nil || numberFloat <= threshold {
+			continue
+		}
+		if _, found := existing[number]; found {
+			continue
+		}
+
+		if current, found := best[number]; !found || isPreferredChapter(chapter, current, rule) {
+			best[number] = chapter
+		}
+	}
+
+	ret := make([]*hibikemanga.ChapterDetails, 0, len(best))
+	for _, chapter := range best {
+		ret = append(ret, chapter)
+	}
+	slices.SortStableFunc(ret, func(a, b *hibikemanga.ChapterDetails) int {
+		an, _ := strconv.ParseFloat(normalizeChapterNumber(a.Chapter), 64)
+		bn, _ := strconv.ParseFloat(normalizeChapterNumber(b.Chapter), 64)
+		return cmp.Compare(an, bn)
+	})
+	return ret
+}
+
+// getChapterThreshold returns the chapter number after which chapters are downloaded.
+// It returns false if the rule's baseline hasn't been set yet.
+func getChapterThreshold(rule *manga.AutoDownloaderRule, progress int) (float64, bool) {
+	if rule.ChapterType == manga.AutoDownloaderRuleChapterTypeAfterProgress {
+		return float64(progress), true
+	}
+	if rule.BaselineChapter == nil {
+		return 0, false
+	}
+	return *rule.BaselineChapter, true
+}
+
+// getGapFillers returns the chapters of the fallback containers that are in the gaps.
+func getGapFillers(gaps []*manga.ChapterGap, fallbacks []*manga.ChapterContainer) []*hibikemanga.ChapterDetails {
+	ret := make([]*hibikemanga.ChapterDetails, 0)
+	for _, container := range fallbacks {
+		for _, chapter := range container.Chapters {
+			for _, gap := range gaps {
+				if gap.Contains(chapter.Chapter) {
+					ret = append(ret, chapter)
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// getLatestChapterNumber returns the highest chapter number matching the rule's preferences.
+func getLatestChapterNumber(chapters []*hibikemanga.ChapterDetails, rule *manga.AutoDownloaderRule) float64 {
+	latest := 0.0
+	for _, chapter := range chapters {
+		if getPreferenceRank(rule.Scanlators, chapter.Scanlator) < 0 || getPreferenceRank(rule.Languages, chapter.Language) < 0 {
+			continue
+		}
+		if n, err := strconv.ParseFloat(normalizeChapterNumber(chapter.Chapter), 64); err == nil {
+			latest = max(latest, n)
+		}
+	}
+	return latest
+}
+
+// getExistingChapterNumbers returns the chapter numbers that are downloaded, queued or in the history, across providers.
+func getExistingChapterNumbers(items []*models.MangaAutoDownloaderItem, downloadData manga.MediaDownloadData) map[string]struct{} {
+	ret := make(map[string]struct{})
+	for _, item := range items {
+		ret[normalizeChapterNumber(item.ChapterNumber)] = struct{}{}
+	}
+	for _, providerMap := range []manga.ProviderDownloadMap{downloadData.Downloaded, downloadData.Queued} {
+		for _, chapters := range providerMap {
+			for _, chapter := range chapters {
+				ret[normalizeChapterNumber(chapter.ChapterNumber)] = struct{}{}
+			}
+		}
+	}
+	return ret
+}
+
+// isPreferredChapter returns true if a is preferred over b based on the rule's scanlator then language preferences.
+func isPreferredChapter(a, b *hibikemanga.ChapterDetails, rule *manga.AutoDownloaderRule) bool {
+	if c := cmp.Compare(getPreferenceRank(rule.Scanlators, a.Scanlator), getPreferenceRank(rule.Scanlators, b.Scanlator)); c != 0 {
+		return c < 0
+	}
+	return getPreferenceRank(rule.Languages, a.Language) < getPreferenceRank(rule.Languages, b.Language)
+}
+
+// getPreferenceRank returns the index of the value in the preferences, or -1 if it's not accepted.
+// Any value is accepted with the same rank if there are no preferences.
+func getPreferenceRank(preferences []string, value string) int {
+	if len(preferences) == 0 {
+		return 0
+	}
+	for i, p := range preferences {
+		if strings.EqualFold(strings.TrimSpace(p), strings.TrimSpace(value)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeChapterNumber(chapter string) string {
+	return manga_providers.GetNormalizedChapter(strings.TrimSpace(chapter))
+}
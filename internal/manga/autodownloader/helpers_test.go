@@ -0,0 +1,128 @@
+package manga_autodownloader
+
+import (
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/manga"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectChapters(t *testing.T) {
+	chapters := []*hibikemanga.ChapterDetails{
+		{ID: "1", Chapter: "10", Scanlator: "A", Language: "en"},
+		{ID: "2", Chapter: "11", Scanlator: "B", Language: "en"},
+		{ID: "3", Chapter: "11", Scanlator: "A", Language: "en"},
+		{ID: "4", Chapter: "12", Scanlator: "C", Language: "en"},
+		{ID: "5", Chapter: "12", Scanlator: "B", Language: "fr"},
+		{ID: "6", Chapter: "12.5", Scanlator: "B", Language: "en"},
+		{ID: "7", Chapter: "013", Scanlator: "B", Language: "en"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     *manga.AutoDownloaderRule
+		progress int
+		existing []string
+		expected []string
+	}{
+		{
+			name:     "after progress, no preferences",
+			rule:     &manga.AutoDownloaderRule{ChapterType: manga.AutoDownloaderRuleChapterTypeAfterProgress},
+			progress: 10,
+			expected: []string{"2", "4", "6", "7"},
+		},
+		{
+			name: "scanlator preference",
+			rule: &manga.AutoDownloaderRule{
+				ChapterType: manga.AutoDownloaderRuleChapterTypeAfterProgress,
+				Scanlators:  []string{"a", "b"},
+			},
+			progress: 10,
+			expected: []string{"3", "5", "6", "7"},
+		},
+		{
+			name: "language filter",
+			rule: &manga.AutoDownloaderRule{
+				ChapterType: manga.AutoDownloaderRuleChapterTypeAfterProgress,
+				Scanlators:  []string{"B"},
+				Languages:   []string{"en"},
+			},
+			progress: 0,
+			expected: []string{"2", "6", "7"},
+		},
+		{
+			name: "new chapters after baseline",
+			rule: &manga.AutoDownloaderRule{
+				ChapterType:     manga.AutoDownloaderRuleChapterTypeNew,
+				BaselineChapter: lo.ToPtr(12.0),
+			},
+			expected: []string{"6", "7"},
+		},
+		{
+			name:     "new chapters without baseline",
+			rule:     &manga.AutoDownloaderRule{ChapterType: manga.AutoDownloaderRuleChapterTypeNew},
+			expected: []string{},
+		},
+		{
+			name:     "skip existing chapters",
+			rule:     &manga.AutoDownloaderRule{ChapterType: manga.AutoDownloaderRuleChapterTypeAfterProgress},
+			progress: 10,
+			existing: []string{"11", "13"},
+			expected: []string{"4", "6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := make(map[string]struct{})
+			for _, n := range tt.existing {
+				existing[n] = struct{}{}
+			}
+
+			ret := selectChapters(chapters, tt.rule, tt.progress, existing)
+			ids := lo.Map(ret, func(c *hibikemanga.ChapterDetails, _ int) string { return c.ID })
+			require.Len(t, ids, len(tt.expected))
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestGetLatestChapterNumber(t *testing.T) {
+	chapters := []*hibikemanga.ChapterDetails{
+		{ID: "1", Chapter: "10", Scanlator: "A"},
+		{ID: "2", Chapter: "14", Scanlator: "B"},
+		{ID: "3", Chapter: "12.5", Scanlator: "A"},
+	}
+
+	assert.Equal(t, 14.0, getLatestChapterNumber(chapters, &manga.AutoDownloaderRule{}))
+	assert.Equal(t, 12.5, getLatestChapterNumber(chapters, &manga.AutoDownloaderRule{Scanlators: []string{"A"}}))
+}
+
+func TestGetGapFillers(t *testing.T) {
+	gaps := []*manga.ChapterGap{{Start: 3, End: 4}}
+	fallbacks := []*manga.ChapterContainer{
+		{
+			Provider: "b",
+			Chapters: []*hibikemanga.ChapterDetails{
+				{Provider: "b", ID: "b2", Chapter: "2"},
+				{Provider: "b", ID: "b3", Chapter: "3"},
+				{Provider: "b", ID: "b4", Chapter: "4"},
+				{Provider: "b", ID: "b5", Chapter: "5"},
+			},
+		},
+	}
+
+	fillers := getGapFillers(gaps, fallbacks)
+	assert.Equal(t, []string{"b3", "b4"}, lo.Map(fillers, func(c *hibikemanga.ChapterDetails, _ int) string { return c.ID }))
+
+	// The provider's chapters are preferred over the fillers
+	chapters := append([]*hibikemanga.ChapterDetails{
+		{Provider: "a", ID: "a2", Chapter: "2"},
+		{Provider: "a", ID: "a5", Chapter: "5"},
+	}, fillers...)
+	selected := selectChapters(chapters, &manga.AutoDownloaderRule{ChapterType: manga.AutoDownloaderRuleChapterTypeAfterProgress}, 1, map[string]struct{}{})
+	assert.Equal(t, []string{"a2", "b3", "b4", "a5"}, lo.Map(selected, func(c *hibikemanga.ChapterDetails, _ int) string { return c.ID }))
+}
@@ -0,0 +1,29 @@
+package manga
+
+type (
+	AutoDownloaderRuleChapterType string
+
+	// AutoDownloaderRule is a rule used by the manga auto downloader to queue new chapters of a series.
+	// The structs are sent to the client, thus adding `dbId` to facilitate mutations.
+	AutoDownloaderRule struct {
+		DbID     uint   `json:"dbId"` // Will be set when fetched from the database
+		Enabled  bool   `json:"enabled"`
+		MediaId  int    `json:"mediaId"`
+		Provider string `json:"provider"`
+		// Scanlators in order of preference, any scanlator is accepted if empty
+		Scanlators []string `json:"scanlators"`
+		// Languages in order of preference, any language is accepted if empty
+		Languages   []string                      `json:"languages"`
+		ChapterType AutoDownloaderRuleChapterType `json:"chapterType"`
+		// BaselineChapter is the latest chapter found when the rule was first checked.
+		// Only chapters after it are downloaded when ChapterType is "new".
+		BaselineChapter *float64 `json:"baselineChapter,omitempty"`
+	}
+)
+
+const (
+	// AutoDownloaderRuleChapterTypeNew downloads chapters released after the rule was created
+	AutoDownloaderRuleChapterTypeNew AutoDownloaderRuleChapterType = "new"
+	// AutoDownloaderRuleChapterTypeAfterProgress downloads all chapters after the user's progress
+	AutoDownloaderRuleChapterTypeAfterProgress AutoDownloaderRuleChapterType = "after-progress"
+)
@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 )
@@ -157,10 +158,12 @@ func (r *Repository) GetMangaChapterContainer(opts *GetMangaChapterContainerOpti
 		for _, title := range titles {
 			var _searchRes []*hibikemanga.SearchResult
 
+			start := time.Now()
 			_searchRes, err = providerExtension.GetProvider().Search(hibikemanga.SearchOptions{
 				Query: *title,
 				Year:  opts.Year,
 			})
+			r.providerHealth.Record(provider, time.Since(start), err)
 			if err == nil {
 
 				HydrateSearchResultSearchRating(_searchRes, title)
@@ -194,7 +197,9 @@ func (r *Repository) GetMangaChapterContainer(opts *GetMangaChapterContainerOpti
 	// |    Get chapters     |
 	// +---------------------+
 
+	start := time.Now()
 	chapterList, err := providerExtension.GetProvider().FindChapters(mangaId)
+	r.providerHealth.Record(provider, time.Since(start), err)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("manga: Failed to get chapters")
 		return nil, ErrNoChapters
@@ -235,6 +240,15 @@ func (r *Repository) GetMangaChapterContainer(opts *GetMangaChapterContainerOpti
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// RefetchMangaChapterContainer deletes the cached chapter container of a media for a provider and fetches it again.
+// This is used to check for new chapters without emptying the cache of other providers.
+func (r *Repository) RefetchMangaChapterContainer(opts *GetMangaChapterContainerOptions) (ret *ChapterContainer, err error) {
+	containerBucket := r.getFcProviderBucket(opts.Provider, opts.MediaId, bucketTypeChapter)
+	_ = r.fileCacher.Delete(containerBucket, getMangaChapterContainerCacheKey(opts.Provider, opts.MediaId))
+
+	return r.GetMangaChapterContainer(opts)
+}
+
 // RefreshChapterContainers deletes all cached chapter containers and refetches them based on the selected provider map.
 func (r *Repository) RefreshChapterContainers(mangaCollection *anilist.MangaCollection, selectedProviderMap map[int]string) (err error) {
 	defer util.HandlePanicInModuleWithError("manga/RefreshChapterContainers", &err)
@@ -0,0 +1,253 @@
+package manga
+
+import (
+	"cmp"
+	"math"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	manga_providers "seanime/internal/manga/providers"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ChapterPreferences are used to select a chapter when several chapters have the same number.
+	ChapterPreferences struct {
+		// Scanlators in order of preference.
+		// Chapters from other scanlators are only selected when no preferred scanlator has the chapter.
+		Scanlators []string `json:"scanlators"`
+		// Languages in order of preference.
+		// Chapters in other languages are ignored, any language is accepted if empty.
+		Languages []string `json:"languages"`
+	}
+
+	// MergedChapterContainer contains the chapters of several providers, deduplicated by chapter number.
+	MergedChapterContainer struct {
+		MediaId int `json:"mediaId"`
+		// Providers that were merged, in order of priority
+		Providers []string         `json:"providers"`
+		Chapters  []*MergedChapter `json:"chapters"`
+		Gaps      []*ChapterGap    `json:"gaps"`
+	}
+
+	// MergedChapter is a chapter number with the preferred chapter and its alternatives.
+	MergedChapter struct {
+		Number string `json:"number"`
+		// Chapter is the preferred chapter
+		Chapter *hibikemanga.ChapterDetails `json:"chapter"`
+		// Alternatives are the other chapters with the same number, in order of preference
+		Alternatives []*hibikemanga.ChapterDetails `json:"alternatives"`
+	}
+
+	// ChapterGap is a range of missing chapter numbers, inclusive.
+	ChapterGap struct {
+		Start int `json:"start"`
+		End   int `json:"end"`
+	}
+)
+
+// GetMergedChapterContainer returns the chapters of the requested provider merged with the chapters of the
+// manga's provider chain.
+func (r *Repository) GetMergedChapterContainer(opts *GetMangaChapterContainerOptions, prefs *ChapterPreferences) (*MergedChapterContainer, error) {
+	containers := make([]*ChapterContainer, 0)
+
+	container, err := r.GetMangaChapterContainer(opts)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("provider", opts.Provider).Msg("manga: Failed to get chapters to merge")
+	} else {
+		containers = append(containers, container)
+	}
+
+	containers = append(containers, r.GetProviderChainChapterContainers(opts)...)
+	if len(containers) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrNoChapters
+	}
+
+	ret := MergeChapters(containers, prefs)
+	ret.MediaId = opts.MediaId
+	return ret, nil
+}
+
+// GetProviderChainChapterContainers returns the chapter containers of the manga's provider chain, in order.
+// The requested provider and providers that fail are skipped.
+func (r *Repository) GetProviderChainChapterContainers(opts *GetMangaChapterContainerOptions) []*ChapterContainer {
+	ret := make([]*ChapterContainer, 0)
+	for _, provider := range r.GetProviderChain(opts.MediaId) {
+		if provider == opts.Provider {
+			continue
+		}
+
+		providerOpts := *opts
+		providerOpts.Provider = provider
+
+		container, err := r.GetMangaChapterContainer(&providerOpts)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("provider", provider).Msg("manga: Failed to get chapters of fallback provider")
+			continue
+		}
+		ret = append(ret, container)
+	}
+	return ret
+}
+
+// MergeChapters deduplicates the chapters of the containers by chapter number.
+//   - The preferred chapter is selected by scanlator, then language, then order of the containers.
+//   - Chapters in languages that aren't preferred are ignored.
+//   - Chapters are sorted by number, chapters without a valid number are put last.
+func MergeChapters(containers []*ChapterContainer, prefs *ChapterPreferences) *MergedChapterContainer {
+	if prefs == nil {
+		prefs = &ChapterPreferences{}
+	}
+
+	type candidate struct {
+		chapter       *hibikemanga.ChapterDetails
+		scanlatorRank int
+		languageRank  int
+		providerRank  int
+	}
+
+	ret := &MergedChapterContainer{
+		Providers: make([]string, 0, len(containers)),
+		Chapters:  make([]*MergedChapter, 0),
+		Gaps:      make([]*ChapterGap, 0),
+	}
+
+	candidates := make(map[string][]*candidate)
+	numbers := make([]string, 0)
+	for providerRank, container := range containers {
+		if container == nil {
+			continue
+		}
+		ret.Providers = append(ret.Providers, container.Provider)
+
+		for _, chapter := range container.Chapters {
+			languageRank := getChapterPreferenceRank(prefs.Languages, chapter.Language)
+			if languageRank < 0 {
+				continue
+			}
+			scanlatorRank := getChapterPreferenceRank(prefs.Scanlators, chapter.Scanlator)
+			if scanlatorRank < 0 {
+				scanlatorRank = len(prefs.Scanlators)
+			}
+
+			number := getMergeChapterNumber(chapter.Chapter)
+			if _, found := candidates[number]; !found {
+				numbers = append(numbers, number)
+			}
+			candidates[number] = append(candidates[number], &candidate{
+				chapter:       chapter,
+				scanlatorRank: scanlatorRank,
+				languageRank:  languageRank,
+				providerRank:  providerRank,
+			})
+		}
+	}
+
+	for _, number := range numbers {
+		c := candidates[number]
+		slices.SortStableFunc(c, func(a, b *candidate) int {
+			return cmp.Or(
+				cmp.Compare(a.scanlatorRank, b.scanlatorRank),
+				cmp.Compare(a.languageRank, b.languageRank),
+				cmp.Compare(a.providerRank, b.providerRank),
+			)
+		})
+
+		merged := &MergedChapter{
+			Number:       number,
+			Chapter:      c[0].chapter,
+			Alternatives: make([]*hibikemanga.ChapterDetails, 0, len(c)-1),
+		}
+		for _, alt := range c[1:] {
+			merged.Alternatives = append(merged.Alternatives, alt.chapter)
+		}
+		ret.Chapters = append(ret.Chapters, merged)
+	}
+
+	slices.SortStableFunc(ret.Chapters, func(a, b *MergedChapter) int {
+		an, aErr := strconv.ParseFloat(a.Number, 64)
+		bn, bErr := strconv.ParseFloat(b.Number, 64)
+		switch {
+		case aErr != nil && bErr != nil:
+			return 0
+		case aErr != nil:
+			return 1
+		case bErr != nil:
+			return -1
+		}
+		return cmp.Compare(an, bn)
+	})
+
+	chapters := make([]*hibikemanga.ChapterDetails, 0, len(ret.Chapters))
+	for _, c := range ret.Chapters {
+		chapters = append(chapters, c.Chapter)
+	}
+	ret.Gaps = DetectChapterGaps(chapters)
+
+	return ret
+}
+
+// DetectChapterGaps returns the ranges of whole chapter numbers missing between chapter 1 and the latest chapter.
+// Decimal chapters (e.g. "10.5") don't fill gaps.
+func DetectChapterGaps(chapters []*hibikemanga.ChapterDetails) []*ChapterGap {
+	present := make(map[int]struct{})
+	latest := 0
+	for _, chapter := range chapters {
+		n, err := strconv.ParseFloat(getMergeChapterNumber(chapter.Chapter), 64)
+		if err != nil || n < 1 || n != math.Trunc(n) {
+			continue
+		}
+		present[int(n)] = struct{}{}
+		latest = max(latest, int(n))
+	}
+
+	ret := make([]*ChapterGap, 0)
+	var current *ChapterGap
+	for n := 1; n < latest; n++ {
+		if _, found := present[n]; found {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &ChapterGap{Start: n}
+			ret = append(ret, current)
+		}
+		current.End = n
+	}
+	return ret
+}
+
+// Contains returns true if the chapter number is in the gap.
+func (g *ChapterGap) Contains(chapter string) bool {
+	n, err := strconv.ParseFloat(getMergeChapterNumber(chapter), 64)
+	if err != nil {
+		return false
+	}
+	return n >= float64(g.Start) && n < float64(g.End+1)
+}
+
+// getMergeChapterNumber returns the chapter number used to deduplicate chapters, e.g. "01.50" -> "1.5".
+func getMergeChapterNumber(chapter string) string {
+	number := manga_providers.GetNormalizedChapter(strings.TrimSpace(chapter))
+	if n, err := strconv.ParseFloat(number, 64); err == nil {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return number
+}
+
+// getChapterPreferenceRank returns the index of the value in the preferences, or -1 if it's not in the preferences.
+// Any value has the same rank if there are no preferences.
+func getChapterPreferenceRank(preferences []string, value string) int {
+	if len(preferences) == 0 {
+		return 0
+	}
+	for i, p := range preferences {
+		if strings.EqualFold(strings.TrimSpace(p), strings.TrimSpace(value)) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,77 @@
+package manga
+
+import (
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeChapters(t *testing.T) {
+	containers := []*ChapterContainer{
+		{
+			Provider: "a",
+			Chapters: []*hibikemanga.ChapterDetails{
+				{Provider: "a", ID: "a1", Chapter: "1", Scanlator: "X", Language: "en"},
+				{Provider: "a", ID: "a2-x", Chapter: "2", Scanlator: "X", Language: "en"},
+				{Provider: "a", ID: "a2-y", Chapter: "2", Scanlator: "Y", Language: "en"},
+				{Provider: "a", ID: "a3-fr", Chapter: "3", Scanlator: "Y", Language: "fr"},
+				{Provider: "a", ID: "a7", Chapter: "7", Scanlator: "X", Language: "en"},
+				{Provider: "a", ID: "a-extra", Chapter: "extra", Language: "en"},
+			},
+		},
+		{
+			Provider: "b",
+			Chapters: []*hibikemanga.ChapterDetails{
+				{Provider: "b", ID: "b1", Chapter: "01", Language: "en"},
+				{Provider: "b", ID: "b2", Chapter: "2.0", Scanlator: "Y", Language: "en"},
+				{Provider: "b", ID: "b3", Chapter: "3", Language: "en"},
+				{Provider: "b", ID: "b3.5", Chapter: "3.5", Language: "en"},
+			},
+		},
+	}
+
+	t.Run("no preferences", func(t *testing.T) {
+		ret := MergeChapters(containers, nil)
+
+		assert.Equal(t, []string{"a", "b"}, ret.Providers)
+		assert.Equal(t, []string{"1", "2", "3", "3.5", "7", "extra"}, lo.Map(ret.Chapters, func(c *MergedChapter, _ int) string { return c.Number }))
+
+		// The first provider is preferred
+		assert.Equal(t, "a1", ret.Chapters[0].Chapter.ID)
+		assert.Equal(t, "a2-x", ret.Chapters[1].Chapter.ID)
+		assert.Equal(t, []string{"a2-y", "b2"}, lo.Map(ret.Chapters[1].Alternatives, func(c *hibikemanga.ChapterDetails, _ int) string { return c.ID }))
+		assert.Equal(t, "a3-fr", ret.Chapters[2].Chapter.ID)
+
+		require.Len(t, ret.Gaps, 1)
+		assert.Equal(t, &ChapterGap{Start: 4, End: 6}, ret.Gaps[0])
+	})
+
+	t.Run("preferences", func(t *testing.T) {
+		ret := MergeChapters(containers, &ChapterPreferences{Scanlators: []string{"y"}, Languages: []string{"en"}})
+
+		assert.Equal(t, "a2-y", ret.Chapters[1].Chapter.ID)
+		assert.Equal(t, []string{"b2", "a2-x"}, lo.Map(ret.Chapters[1].Alternatives, func(c *hibikemanga.ChapterDetails, _ int) string { return c.ID }))
+		// French chapters are ignored
+		assert.Equal(t, "b3", ret.Chapters[2].Chapter.ID)
+		assert.Empty(t, ret.Chapters[2].Alternatives)
+	})
+}
+
+func TestDetectChapterGaps(t *testing.T) {
+	chapters := lo.Map([]string{"0", "2", "3", "5.5", "9", "10", "14", "12.5"}, func(n string, _ int) *hibikemanga.ChapterDetails {
+		return &hibikemanga.ChapterDetails{Chapter: n}
+	})
+
+	gaps := DetectChapterGaps(chapters)
+	assert.Equal(t, []*ChapterGap{{Start: 1, End: 1}, {Start: 4, End: 8}, {Start: 11, End: 13}}, gaps)
+
+	assert.True(t, gaps[1].Contains("5.5"))
+	assert.True(t, gaps[1].Contains("08"))
+	assert.False(t, gaps[1].Contains("9"))
+	assert.False(t, gaps[1].Contains("3"))
+
+	assert.Empty(t, DetectChapterGaps(nil))
+}
@@ -8,6 +8,7 @@ import (
 	"seanime/internal/util"
 	"strings"
 	"sync"
+	"time"
 
 	hibikemanga "seanime/internal/extension/hibike/manga"
 )
@@ -154,7 +155,9 @@ func (r *Repository) GetMangaPageContainer(
 	// Get the chapter pages
 	var pages []*hibikemanga.ChapterPage
 
+	start := time.Now()
 	pages, err = providerExtension.GetProvider().FindChapterPages(chapter.ID)
+	r.providerHealth.Record(provider, time.Since(start), err)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("manga: Could not get chapter pages")
 		return nil, err
@@ -10,9 +10,13 @@ import (
 	"seanime/internal/events"
 	"seanime/internal/hook"
 	chapter_downloader "seanime/internal/manga/downloader"
+	manga_export "seanime/internal/manga/export"
+	manga_pageprocessor "seanime/internal/manga/pageprocessor"
 	manga_providers "seanime/internal/manga/providers"
+	"seanime/internal/platforms/platform"
 	"seanime/internal/util"
 	"seanime/internal/util/filecache"
+	"seanime/internal/util/result"
 	"sync"
 
 	"github.com/rs/zerolog"
@@ -33,6 +37,11 @@ type (
 
 		chapterDownloadedCh chan chapter_downloader.DownloadID
 		readingDownloadDir  bool
+
+		platform      platform.Platform
+		metadataCache *result.Cache[int, *manga_export.Metadata] // Used for ComicInfo.xml and exports
+		convertMu     sync.Mutex
+		converting    bool
 	}
 
 	// MediaMap is created after reading the download directory.
@@ -66,6 +75,8 @@ type (
 		WSEventManager events.WSEventManagerInterface
 		DownloadDir    string
 		Repository     *Repository
+		Platform       platform.Platform
+		PageProcessor  *manga_pageprocessor.Processor // Optional
 	}
 
 	DownloadChapterOptions struct {
@@ -88,6 +99,8 @@ func NewDownloader(opts *NewDownloaderOptions) *Downloader {
 		repository:     opts.Repository,
 		mediaMap:       new(MediaMap),
 		filecacher:     filecacher,
+		platform:       opts.Platform,
+		metadataCache:  result.NewCache[int, *manga_export.Metadata](),
 	}
 
 	d.chapterDownloader = chapter_downloader.NewDownloader(&chapter_downloader.NewDownloaderOptions{
@@ -96,6 +109,8 @@ func NewDownloader(opts *NewDownloaderOptions) *Downloader {
 		Database:       opts.Database,
 		DownloadDir:    opts.DownloadDir,
 	})
+	d.chapterDownloader.SetComicInfoFunc(d.getComicInfo)
+	d.chapterDownloader.SetPageProcessor(opts.PageProcessor)
 
 	go d.hydrateMediaMap()
 
@@ -406,12 +421,9 @@ func (d *Downloader) hydrateMediaMap() {
 		go func(file os.DirEntry) {
 			defer wg.Done()
 
-			if file.IsDir() {
-				// e.g. comick_1234_abc_13.5
-				id, ok := chapter_downloader.ParseChapterDirName(file.Name())
-				if !ok {
-					return
-				}
+			// e.g. comick_1234_abc_13.5 or comick_1234_abc_13.5.cbz
+			id, _, ok := chapter_downloader.ParseChapterEntryName(file.Name(), file.IsDir())
+			if ok {
 
 				mu.Lock()
 				newMapInfo := ProviderDownloadMapChapterInfo{
@@ -0,0 +1,279 @@
+package chapter_downloader
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	manga_export "seanime/internal/manga/export"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// 📁 cache/manga
+// └── 📄 {provider}_{mediaId}_{chapterId}_{chapterNumber}.cbz  <- When the storage format is StorageFormatCBZ
+//     ├── 📄 registry.json
+//     ├── 📄 ComicInfo.xml
+//     ├── 📄 01.jpg
+//     └── 📄 ...
+
+type (
+	// StorageFormat is how downloaded chapters are stored in the download directory.
+	StorageFormat string
+
+	// ComicInfoFunc returns the ComicInfo.xml metadata of a chapter packed into a CBZ archive.
+	ComicInfoFunc func(id DownloadID, pageCount int) *manga_export.ComicInfo
+)
+
+const (
+	StorageFormatImages StorageFormat = "images" // Loose images in a folder (default)
+	StorageFormatCBZ    StorageFormat = "cbz"    // CBZ archive with ComicInfo.xml
+
+	ChapterArchiveExt = ".cbz"
+	registryFilename  = "registry.json"
+)
+
+var ErrNotChapterArchive = errors.New("chapter downloader: Not a chapter archive")
+
+// SetStorageFormat sets the format used for new chapter downloads.
+func (cd *Downloader) SetStorageFormat(format StorageFormat) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	cd.storageFormat = format
+}
+
+// SetComicInfoFunc sets the function used to build ComicInfo.xml when packing chapters.
+func (cd *Downloader) SetComicInfoFunc(fn ComicInfoFunc) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	cd.comicInfoFunc = fn
+}
+
+func (cd *Downloader) getChapterArchivePath(downloadId DownloadID) string {
+	return cd.getChapterDownloadDir(downloadId) + ChapterArchiveExt
+}
+
+// packDownloadedChapter packs the chapter directory into a CBZ archive if the storage format requires it.
+func (cd *Downloader) packDownloadedChapter(downloadId DownloadID, destination string, pageCount int) {
+	cd.mu.Lock()
+	format := cd.storageFormat
+	comicInfoFunc := cd.comicInfoFunc
+	cd.mu.Unlock()
+
+	if format != StorageFormatCBZ {
+		return
+	}
+
+	var info *manga_export.ComicInfo
+	if comicInfoFunc != nil {
+		info = comicInfoFunc(downloadId, pageCount)
+	}
+
+	if _, err := PackChapter(destination, info); err != nil {
+		cd.logger.Error().Err(err).Msgf("chapter downloader: Failed to pack chapter %s, keeping images", downloadId.ChapterId)
+	}
+}
+
+// ParseChapterEntryName parses the name of a chapter directory or archive in the download directory.
+//
+//	e.g. comick_1234_abc_13.5 -> {Provider: "comick", MediaId: 1234, ChapterId: "abc", ChapterNumber: "13.5"}, false
+//	e.g. comick_1234_abc_13.5.cbz -> {Provider: "comick", MediaId: 1234, ChapterId: "abc", ChapterNumber: "13.5"}, true
+func ParseChapterEntryName(name string, isDir bool) (id DownloadID, isArchive bool, ok bool) {
+	if isDir {
+		id, ok = ParseChapterDirName(name)
+		return id, false, ok
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ChapterArchiveExt) {
+		return id, false, false
+	}
+	id, ok = ParseChapterDirName(name[:len(name)-len(ChapterArchiveExt)])
+	return id, true, ok
+}
+
+// PackChapter converts a downloaded chapter directory into a CBZ archive and removes the directory.
+// The registry is kept in the archive so that page dimensions don't need to be decoded again.
+func PackChapter(dir string, info *manga_export.ComicInfo) (string, error) {
+	registry, err := readRegistryFile(filepath.Join(dir, registryFilename))
+	if err != nil {
+		return "", err
+	}
+
+	pages := make([]*manga_export.Page, 0, len(registry))
+	for _, pageInfo := range registry.sorted() {
+		pages = append(pages, manga_export.NewFilePage(filepath.Join(dir, pageInfo.Filename), pageInfo.Width, pageInfo.Height))
+	}
+
+	registryData, err := json.Marshal(registry)
+	if err != nil {
+		return "", err
+	}
+
+	if info != nil {
+		info.PageCount = len(pages)
+	}
+
+	dest := filepath.Clean(dir) + ChapterArchiveExt
+	err = manga_export.WriteFile(dest, func(w io.Writer) error {
+		return manga_export.WriteCBZ(w, pages, info, &manga_export.ExtraFile{Filename: registryFilename, Data: registryData})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return dest, os.RemoveAll(dir)
+}
+
+// ReadChapterRegistry returns the registry of a downloaded chapter, stored as a directory or as an archive.
+func ReadChapterRegistry(path string) (Registry, error) {
+	if !strings.HasSuffix(strings.ToLower(path), ChapterArchiveExt) {
+		return readRegistryFile(filepath.Join(path, registryFilename))
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != registryFilename {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		var registry Registry
+		if err := json.NewDecoder(r).Decode(&registry); err != nil {
+			return nil, err
+		}
+		return registry, nil
+	}
+
+	// Archives that were not created by Seanime don't have a registry
+	registry := make(Registry)
+	images := make([]*zip.File, 0)
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() && isImageFile(f.Name) {
+			images = append(images, f)
+		}
+	}
+	slices.SortStableFunc(images, func(a, b *zip.File) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i, f := range images {
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		config, _, _ := image.DecodeConfig(r)
+		_ = r.Close()
+		registry[i] = PageInfo{
+			Index:    i,
+			Filename: f.Name,
+			Size:     int64(f.UncompressedSize64),
+			Width:    config.Width,
+			Height:   config.Height,
+		}
+	}
+	return registry, nil
+}
+
+// OpenChapterPage opens a page of a downloaded chapter, stored as a directory or as an archive.
+func OpenChapterPage(path string, filename string) (io.ReadCloser, error) {
+	if !strings.HasSuffix(strings.ToLower(path), ChapterArchiveExt) {
+		return os.Open(filepath.Join(path, filepath.Base(filename)))
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if f.Name != filename {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			_ = zr.Close()
+			return nil, err
+		}
+		return &archiveReadCloser{ReadCloser: r, archive: zr}, nil
+	}
+	_ = zr.Close()
+	return nil, fmt.Errorf("chapter downloader: Page %s not found in archive", filename)
+}
+
+// GetChapterPages returns the pages of a downloaded chapter in order, to be packed into another archive.
+func GetChapterPages(path string) ([]*manga_export.Page, error) {
+	registry, err := ReadChapterRegistry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*manga_export.Page, 0, len(registry))
+	for _, pageInfo := range registry.sorted() {
+		ret = append(ret, &manga_export.Page{
+			Filename: filepath.Base(pageInfo.Filename),
+			Width:    pageInfo.Width,
+			Height:   pageInfo.Height,
+			Open: func() (io.ReadCloser, error) {
+				return OpenChapterPage(path, pageInfo.Filename)
+			},
+		})
+	}
+	return ret, nil
+}
+
+func readRegistryFile(path string) (Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var registry Registry
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// sorted returns the pages ordered by index.
+func (r Registry) sorted() []PageInfo {
+	ret := make([]PageInfo, 0, len(r))
+	for _, pageInfo := range r {
+		ret = append(ret, pageInfo)
+	}
+	slices.SortStableFunc(ret, func(a, b PageInfo) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	return ret
+}
+
+func isImageFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".bmp", ".tiff":
+		return true
+	}
+	return false
+}
+
+// archiveReadCloser closes the archive along with the entry.
+type archiveReadCloser struct {
+	io.ReadCloser
+	archive *zip.ReadCloser
+}
+
+func (r *archiveReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	_ = r.archive.Close()
+	return err
+}
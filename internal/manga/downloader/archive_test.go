@@ -0,0 +1,72 @@
+package chapter_downloader
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	manga_export "seanime/internal/manga/export"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackChapter(t *testing.T) {
+	downloadDir := t.TempDir()
+
+	dirName := FormatChapterDirName("comick", 101517, "abc", "13.5")
+	dir := filepath.Join(downloadDir, dirName)
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+
+	registry := Registry{
+		0: {Index: 0, Filename: "01.jpg", Width: 700, Height: 1000},
+		1: {Index: 1, Filename: "02.jpg", Width: 700, Height: 1000},
+	}
+	for _, page := range registry {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, page.Filename), []byte(page.Filename), 0644))
+	}
+	data, _ := json.Marshal(registry)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, registryFilename), data, 0644))
+
+	archivePath, err := PackChapter(dir, &manga_export.ComicInfo{Series: "Jujutsu Kaisen"})
+	require.NoError(t, err)
+	assert.Equal(t, dir+ChapterArchiveExt, archivePath)
+
+	// The directory is replaced by the archive
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	id, isArchive, ok := ParseChapterEntryName(filepath.Base(archivePath), false)
+	require.True(t, ok)
+	assert.True(t, isArchive)
+	assert.Equal(t, "13.5", id.ChapterNumber)
+	assert.Equal(t, 101517, id.MediaId)
+
+	readRegistry, err := ReadChapterRegistry(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, registry, readRegistry)
+
+	r, err := OpenChapterPage(archivePath, "02.jpg")
+	require.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	_ = r.Close()
+	assert.Equal(t, "02.jpg", string(b))
+
+	pages, err := GetChapterPages(archivePath)
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	assert.Equal(t, "01.jpg", pages[0].Filename)
+
+	_, err = OpenChapterPage(archivePath, "03.jpg")
+	assert.Error(t, err)
+}
+
+func TestParseChapterEntryName(t *testing.T) {
+	_, _, ok := ParseChapterEntryName("comick_1_abc_1.zip", false)
+	assert.False(t, ok)
+
+	_, isArchive, ok := ParseChapterEntryName("comick_1_abc_1", true)
+	assert.True(t, ok)
+	assert.False(t, isArchive)
+}
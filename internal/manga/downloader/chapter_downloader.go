@@ -1,20 +1,20 @@
 package chapter_downloader
 
 import (
-	"bytes"
+	"cmp"
 	"fmt"
-	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
-	"io"
 	"os"
 	"path/filepath"
 	"seanime/internal/database/db"
 	"seanime/internal/events"
 	hibikemanga "seanime/internal/extension/hibike/manga"
+	manga_pageprocessor "seanime/internal/manga/pageprocessor"
 	manga_providers "seanime/internal/manga/providers"
 	"seanime/internal/util"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +23,7 @@ import (
 	"github.com/rs/zerolog"
 	_ "golang.org/x/image/bmp"  // Register BMP format
 	_ "golang.org/x/image/tiff" // Register Tiff format
+	_ "golang.org/x/image/webp" // Register WebP format
 )
 
 // 📁 cache/manga
@@ -48,6 +49,9 @@ type (
 		cancelCh            chan struct{}   // Close to cancel the download process
 		runCh               chan *QueueInfo // Receives a signal to download the next item
 		chapterDownloadedCh chan DownloadID // Sends a signal when a chapter has been downloaded
+		storageFormat       StorageFormat
+		comicInfoFunc       ComicInfoFunc
+		pageProcessor       *manga_pageprocessor.Processor
 	}
 
 	//+-------------------------------------------------------------------------------------------------------------------+
@@ -100,6 +104,7 @@ func NewDownloader(opts *NewDownloaderOptions) *Downloader {
 		runCh:               runCh,
 		queue:               NewQueue(opts.Database, opts.Logger, opts.WSEventManager, runCh),
 		chapterDownloadedCh: make(chan DownloadID, 100),
+		storageFormat:       StorageFormatImages,
 	}
 
 	return d
@@ -138,6 +143,10 @@ func (cd *Downloader) AddToQueue(opts DownloadOptions) error {
 		// Delete folder
 		_ = os.RemoveAll(cd.getChapterDownloadDir(downloadId))
 	}
+	if _, err := os.Stat(cd.getChapterArchivePath(downloadId)); err == nil {
+		cd.logger.Warn().Msg("chapter downloader: archive already exists, deleting")
+		_ = os.Remove(cd.getChapterArchivePath(downloadId))
+	}
 
 	// Start download
 	cd.logger.Debug().Msgf("chapter downloader: Adding chapter to download queue: %s", opts.ChapterId)
@@ -153,6 +162,7 @@ func (cd *Downloader) DeleteChapter(id DownloadID) error {
 	cd.logger.Debug().Msgf("chapter downloader: Deleting chapter %s", id.ChapterId)
 
 	_ = os.RemoveAll(cd.getChapterDownloadDir(id))
+	_ = os.Remove(cd.getChapterArchivePath(id))
 	cd.logger.Debug().Msgf("chapter downloader: Removed chapter %s", id.ChapterId)
 	return nil
 }
@@ -225,7 +235,9 @@ func (cd *Downloader) downloadChapterImages(queueInfo *QueueInfo) (err error) {
 
 	cd.logger.Debug().Msgf("chapter downloader: Downloading chapter %s images to %s", queueInfo.ChapterId, destination)
 
-	registry := make(Registry)
+	// Downloaded pages, by page index
+	// A page can be split in two by the page processor
+	downloaded := make(map[int][]PageInfo)
 
 	// calculateBatchSize calculates the batch size based on the number of URLs.
 	calculateBatchSize := func(numURLs int) int {
@@ -247,7 +259,7 @@ func (cd *Downloader) downloadChapterImages(queueInfo *QueueInfo) (err error) {
 	for _, page := range queueInfo.Pages {
 		semaphore <- struct{}{} // Acquire semaphore
 		wg.Add(1)
-		go func(page *hibikemanga.ChapterPage, registry *Registry) {
+		go func(page *hibikemanga.ChapterPage) {
 			defer func() {
 				<-semaphore // Release semaphore
 				wg.Done()
@@ -257,14 +269,23 @@ func (cd *Downloader) downloadChapterImages(queueInfo *QueueInfo) (err error) {
 				//cd.logger.Warn().Msg("chapter downloader: Download goroutine canceled")
 				return
 			default:
-				cd.downloadPage(page, destination, registry)
+				cd.downloadPage(page, destination, downloaded)
 			}
-		}(page, &registry)
+		}(page)
 	}
 	wg.Wait()
 
-	// Write the registry
-	_ = registry.save(queueInfo, destination, cd.logger)
+	registry, ok := newRegistry(queueInfo.Pages, downloaded)
+	if !ok {
+		// Clean up downloaded images
+		cd.logger.Error().Msg("chapter downloader: Not all images have been downloaded, aborting")
+		queueInfo.Status = QueueStatusErrored
+		// Delete directory
+		go os.RemoveAll(destination)
+	} else if err := registry.save(destination); err == nil {
+		// Pack the chapter if it should be stored as an archive
+		cd.packDownloadedChapter(queueInfo.DownloadID, destination, len(registry))
+	}
 
 	cd.queue.HasCompleted(queueInfo)
 
@@ -280,8 +301,8 @@ func (cd *Downloader) downloadChapterImages(queueInfo *QueueInfo) (err error) {
 }
 
 // downloadPage downloads a single page from the URL and saves it to the destination directory.
-// It also updates the Registry with the page information.
-func (cd *Downloader) downloadPage(page *hibikemanga.ChapterPage, destination string, registry *Registry) {
+// The page is transformed by the page processor if it's enabled, in which case it can be split in two.
+func (cd *Downloader) downloadPage(page *hibikemanga.ChapterPage, destination string, downloaded map[int][]PageInfo) {
 
 	defer util.HandlePanicInModuleThen("manga/downloader/downloadImage", func() {
 	})
@@ -296,72 +317,93 @@ func (cd *Downloader) downloadPage(page *hibikemanga.ChapterPage, destination st
 		return
 	}
 
-	// Get the image format
-	config, format, err := image.DecodeConfig(bytes.NewReader(buf))
+	// Process the image, this only reads the image format and dimensions if processing is disabled
+	processed, err := cd.processPage(buf)
 	if err != nil {
 		cd.logger.Error().Err(err).Msgf("chapter downloader: Failed to decode image format from URL %s", page.URL)
 		return
 	}
 
-	filename := imgID + "." + format
+	infos := make([]PageInfo, 0, len(processed))
+	for i, p := range processed {
+		filename := imgID + "." + string(p.Format)
+		if len(processed) > 1 {
+			filename = fmt.Sprintf("%s-%d.%s", imgID, i+1, p.Format)
+		}
 
-	// Create the file
-	filePath := filepath.Join(destination, filename)
-	file, err := os.Create(filePath)
-	if err != nil {
-		cd.logger.Error().Err(err).Msgf("chapter downloader: Failed to create file for image %s", imgID)
-		return
-	}
-	defer file.Close()
+		// Write the image data to the file
+		filePath := filepath.Join(destination, filename)
+		if err := os.WriteFile(filePath, p.Data, 0644); err != nil {
+			cd.logger.Error().Err(err).Msgf("image downloader: Failed to write image data to file for image from %s", page.URL)
+			return
+		}
 
-	// Copy the image data to the file
-	_, err = io.Copy(file, bytes.NewReader(buf))
-	if err != nil {
-		cd.logger.Error().Err(err).Msgf("image downloader: Failed to write image data to file for image from %s", page.URL)
-		return
+		infos = append(infos, PageInfo{
+			Index:       page.Index,
+			Width:       p.Width,
+			Height:      p.Height,
+			Filename:    filename,
+			OriginalURL: page.URL,
+			Size:        int64(len(p.Data)),
+		})
 	}
 
 	// Update registry
 	cd.downloadMu.Lock()
-	(*registry)[page.Index] = PageInfo{
-		Index:       page.Index,
-		Width:       config.Width,
-		Height:      config.Height,
-		Filename:    filename,
-		OriginalURL: page.URL,
-		Size:        int64(len(buf)),
-	}
+	downloaded[page.Index] = infos
 	cd.downloadMu.Unlock()
 
 	return
 }
 
-////////////////////////
+// processPage runs the page processor on the image.
+func (cd *Downloader) processPage(buf []byte) ([]*manga_pageprocessor.Page, error) {
+	processor := cd.pageProcessor
+	if processor == nil {
+		processor = manga_pageprocessor.NewProcessor() // Disabled
+	}
+	return processor.Process(buf)
+}
 
-// save saves the Registry content to a file in the chapter directory.
-func (r *Registry) save(queueInfo *QueueInfo, destination string, logger *zerolog.Logger) (err error) {
+// SetPageProcessor sets the processor used to transform downloaded pages.
+// It should be called before the downloader is started, the processor's settings can be updated afterward.
+func (cd *Downloader) SetPageProcessor(processor *manga_pageprocessor.Processor) {
+	cd.pageProcessor = processor
+}
 
-	defer util.HandlePanicInModuleThen("manga/downloader/save", func() {
-		err = fmt.Errorf("chapter downloader: Failed to save registry content")
+////////////////////////
+
+// newRegistry returns the Registry of the downloaded pages.
+// Pages that were split by the page processor are given consecutive indexes.
+// It returns false if not all pages have been downloaded.
+func newRegistry(pages []*hibikemanga.ChapterPage, downloaded map[int][]PageInfo) (Registry, bool) {
+	sortedPages := slices.Clone(pages)
+	slices.SortStableFunc(sortedPages, func(a, b *hibikemanga.ChapterPage) int {
+		return cmp.Compare(a.Index, b.Index)
 	})
 
-	// Verify all images have been downloaded
-	allDownloaded := true
-	for _, page := range queueInfo.Pages {
-		if _, ok := (*r)[page.Index]; !ok {
-			allDownloaded = false
-			break
+	registry := make(Registry)
+	index := 0
+	for _, page := range sortedPages {
+		infos, ok := downloaded[page.Index]
+		if !ok {
+			return nil, false
+		}
+		for _, info := range infos {
+			info.Index = index
+			registry[index] = info
+			index++
 		}
 	}
+	return registry, true
+}
 
-	if !allDownloaded {
-		// Clean up downloaded images
-		logger.Error().Msg("chapter downloader: Not all images have been downloaded, aborting")
-		queueInfo.Status = QueueStatusErrored
-		// Delete directory
-		go os.RemoveAll(destination)
-		return fmt.Errorf("chapter downloader: Not all images have been downloaded, operation aborted")
-	}
+// save saves the Registry content to a file in the chapter directory.
+func (r *Registry) save(destination string) (err error) {
+
+	defer util.HandlePanicInModuleThen("manga/downloader/save", func() {
+		err = fmt.Errorf("chapter downloader: Failed to save registry content")
+	})
 
 	// Create registry file
 	var data []byte
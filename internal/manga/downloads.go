@@ -10,8 +10,7 @@ import (
 	"seanime/internal/hook"
 	chapter_downloader "seanime/internal/manga/downloader"
 	"slices"
-
-	"github.com/goccy/go-json"
+	"strings"
 )
 
 // GetDownloadedMangaChapterContainers retrieves downloaded chapter containers for a specific manga ID.
@@ -68,15 +67,17 @@ func (r *Repository) GetDownloadedChapterContainers(mangaCollection *anilist.Man
 		return nil, err
 	}
 
-	// Get all chapter directories
-	// e.g. manga_comick_123_10010_13
+	// Get all chapter directories and archives
+	// e.g. manga_comick_123_10010_13, manga_comick_123_10010_14.cbz
 	chapterDirs := make([]string, 0)
 	for _, file := range files {
-		if file.IsDir() {
-			_, ok := chapter_downloader.ParseChapterDirName(file.Name())
-			if !ok {
-				continue
-			}
+		_, isArchive, ok := chapter_downloader.ParseChapterEntryName(file.Name(), file.IsDir())
+		if !ok {
+			continue
+		}
+		if isArchive {
+			chapterDirs = append(chapterDirs, strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())))
+		} else {
 			chapterDirs = append(chapterDirs, file.Name())
 		}
 	}
@@ -219,22 +220,19 @@ func (r *Repository) getDownloadedMangaPageContainer(
 		return nil, err
 	}
 
-	chapterDir := "" // e.g. manga_comick_123_10010_13
+	chapterDir := "" // e.g. manga_comick_123_10010_13 or manga_comick_123_10010_13.cbz
 	for _, file := range files {
-		if file.IsDir() {
-
-			downloadId, ok := chapter_downloader.ParseChapterDirName(file.Name())
-			if !ok {
-				continue
-			}
+		downloadId, _, ok := chapter_downloader.ParseChapterEntryName(file.Name(), file.IsDir())
+		if !ok {
+			continue
+		}
 
-			if downloadId.Provider == provider &&
-				downloadId.MediaId == mediaId &&
-				downloadId.ChapterId == chapterId {
-				found = true
-				chapterDir = file.Name()
-				break
-			}
+		if downloadId.Provider == provider &&
+			downloadId.MediaId == mediaId &&
+			downloadId.ChapterId == chapterId {
+			found = true
+			chapterDir = file.Name()
+			break
 		}
 	}
 
@@ -244,21 +242,12 @@ func (r *Repository) getDownloadedMangaPageContainer(
 
 	r.logger.Debug().Msg("manga: Found downloaded chapter directory")
 
-	// Open registry file
-	registryFile, err := os.Open(filepath.Join(r.downloadDir, chapterDir, "registry.json"))
-	if err != nil {
-		r.logger.Error().Err(err).Msg("manga: Failed to open registry file")
-		return nil, err
-	}
-	defer registryFile.Close()
-
 	r.logger.Debug().Str("chapterId", chapterId).Msg("manga: Reading registry file")
 
-	// Read registry file
-	var pageRegistry *chapter_downloader.Registry
-	err = json.NewDecoder(registryFile).Decode(&pageRegistry)
+	// Read registry file, from the directory or the archive
+	pageRegistry, err := chapter_downloader.ReadChapterRegistry(filepath.Join(r.downloadDir, chapterDir))
 	if err != nil {
-		r.logger.Error().Err(err).Msg("manga: Failed to decode registry file")
+		r.logger.Error().Err(err).Msg("manga: Failed to read registry file")
 		return nil, err
 	}
 
@@ -266,7 +255,7 @@ func (r *Repository) getDownloadedMangaPageContainer(
 	pageDimensions := make(map[int]*PageDimension)
 
 	// Get the downloaded pages
-	for pageIndex, pageInfo := range *pageRegistry {
+	for pageIndex, pageInfo := range pageRegistry {
 		pageList = append(pageList, &hibikemanga.ChapterPage{
 			Index:    pageIndex,
 			URL:      filepath.Join(chapterDir, pageInfo.Filename),
@@ -0,0 +1,452 @@
+package manga
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"seanime/internal/api/anilist"
+	"seanime/internal/events"
+	chapter_downloader "seanime/internal/manga/downloader"
+	manga_export "seanime/internal/manga/export"
+	"seanime/internal/util"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+// 📁 cache/manga
+// └── 📁 exports                                   <- Export destination, ignored by the downloader
+//     ├── 📄 {series} - Chapter {number}.cbz
+//     └── 📄 {series}.epub
+
+const (
+	ExportFormatCBZ  ExportFormat = "cbz"
+	ExportFormatEPUB ExportFormat = "epub"
+
+	exportDirName             = "exports"
+	internalStorageProviderId = "internal-storage"
+)
+
+var ErrConversionRunning = errors.New("manga: Conversion is already running")
+
+type (
+	ExportFormat string
+
+	ExportOptions struct {
+		MediaId int
+		Format  ExportFormat
+		// Chapters to export, all downloaded chapters of the media are exported if empty
+		Chapters []chapter_downloader.DownloadID
+		// Bundle exports all the chapters into a single file
+		Bundle bool
+	}
+
+	ExportResult struct {
+		Files []string `json:"files"`
+	}
+
+	ConvertDownloadsOptions struct {
+		// MediaId is the media to convert, all downloads are converted if 0
+		MediaId int
+		// CopyToInternalStorage copies the archives to the internal storage provider's directory
+		CopyToInternalStorage bool
+	}
+
+	// downloadedChapter is a chapter in the download directory, stored as a directory or as an archive.
+	downloadedChapter struct {
+		id        chapter_downloader.DownloadID
+		path      string
+		isArchive bool
+	}
+)
+
+// SetStorageFormat sets the format used for new chapter downloads, "images" or "cbz".
+func (d *Downloader) SetStorageFormat(format string) {
+	switch chapter_downloader.StorageFormat(format) {
+	case chapter_downloader.StorageFormatCBZ:
+		d.chapterDownloader.SetStorageFormat(chapter_downloader.StorageFormatCBZ)
+	default:
+		d.chapterDownloader.SetStorageFormat(chapter_downloader.StorageFormatImages)
+	}
+}
+
+// Export packs downloaded chapters into CBZ or EPUB files.
+func (d *Downloader) Export(opts *ExportOptions) (ret *ExportResult, err error) {
+	defer util.HandlePanicInModuleWithError("manga/Export", &err)
+
+	if opts.MediaId <= 0 {
+		return nil, errors.New("manga: Invalid media ID")
+	}
+
+	if opts.Format != ExportFormatCBZ && opts.Format != ExportFormatEPUB {
+		return nil, fmt.Errorf("manga: Unsupported export format %q", opts.Format)
+	}
+
+	chapters, err := d.getDownloadedChapters(opts.MediaId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Chapters) > 0 {
+		chapters = filterChapters(chapters, opts.Chapters)
+	}
+	if len(chapters) == 0 {
+		return nil, errors.New("manga: No downloaded chapters to export")
+	}
+
+	// Exports are always written to the download directory
+	destination := filepath.Join(d.downloadDir, exportDirName)
+
+	meta := d.getSeriesMetadata(opts.MediaId)
+	series := manga_export.SanitizeFilename(meta.Series)
+
+	d.logger.Debug().Int("mediaId", opts.MediaId).Int("chapters", len(chapters)).Msgf("manga: Exporting chapters as %s", opts.Format)
+
+	ret = &ExportResult{Files: make([]string, 0)}
+
+	if opts.Bundle {
+		dest := filepath.Join(destination, fmt.Sprintf("%s.%s", series, opts.Format))
+		if err := d.exportBundle(dest, opts.Format, meta, chapters); err != nil {
+			return nil, err
+		}
+		ret.Files = append(ret.Files, dest)
+		return ret, nil
+	}
+
+	for _, chapter := range chapters {
+		dest := filepath.Join(destination, fmt.Sprintf("%s - Chapter %s.%s", series, padChapterNumber(chapter.id.ChapterNumber), opts.Format))
+		if err := d.exportChapter(dest, opts.Format, d.getChapterMetadata(meta, chapter.id), chapter); err != nil {
+			return nil, err
+		}
+		ret.Files = append(ret.Files, dest)
+	}
+
+	return ret, nil
+}
+
+func (d *Downloader) exportChapter(dest string, format ExportFormat, meta *manga_export.Metadata, chapter *downloadedChapter) error {
+	pages, err := chapter_downloader.GetChapterPages(chapter.path)
+	if err != nil {
+		return fmt.Errorf("manga: Failed to read chapter %s: %w", chapter.id.ChapterNumber, err)
+	}
+
+	return manga_export.WriteFile(dest, func(w io.Writer) error {
+		if format == ExportFormatEPUB {
+			return manga_export.WriteEPUB(w, &manga_export.EpubOptions{
+				Identifier: fmt.Sprintf("seanime:manga:%d:%s:%s", chapter.id.MediaId, chapter.id.Provider, chapter.id.ChapterId),
+				Metadata:   meta,
+				Chapters:   []*manga_export.Chapter{{Title: getChapterTitle(meta), Pages: pages}},
+			})
+		}
+		return manga_export.WriteCBZ(w, pages, manga_export.NewComicInfo(meta, len(pages)))
+	})
+}
+
+func (d *Downloader) exportBundle(dest string, format ExportFormat, meta *manga_export.Metadata, chapters []*downloadedChapter) error {
+	epubChapters := make([]*manga_export.Chapter, 0, len(chapters))
+	allPages := make([]*manga_export.Page, 0)
+
+	for _, chapter := range chapters {
+		pages, err := chapter_downloader.GetChapterPages(chapter.path)
+		if err != nil {
+			return fmt.Errorf("manga: Failed to read chapter %s: %w", chapter.id.ChapterNumber, err)
+		}
+		epubChapters = append(epubChapters, &manga_export.Chapter{
+			Title: getChapterTitle(d.getChapterMetadata(meta, chapter.id)),
+			Pages: pages,
+		})
+		// Prefix the pages with the chapter number so that they are unique and ordered in the archive
+		for _, page := range pages {
+			allPages = append(allPages, &manga_export.Page{
+				Filename: fmt.Sprintf("%s_%s", padChapterNumber(chapter.id.ChapterNumber), page.Filename),
+				Width:    page.Width,
+				Height:   page.Height,
+				Open:     page.Open,
+			})
+		}
+	}
+
+	return manga_export.WriteFile(dest, func(w io.Writer) error {
+		if format == ExportFormatEPUB {
+			return manga_export.WriteEPUB(w, &manga_export.EpubOptions{
+				Identifier: fmt.Sprintf("seanime:manga:%d", chapters[0].id.MediaId),
+				Metadata:   meta,
+				Chapters:   epubChapters,
+			})
+		}
+		return manga_export.WriteCBZ(w, allPages, manga_export.NewComicInfo(meta, len(allPages)))
+	})
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Conversion
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConvertDownloads converts existing image folder downloads to CBZ archives in the background.
+// The archives can also be copied to the internal storage provider's directory so that they are listed as local chapters.
+func (d *Downloader) ConvertDownloads(opts *ConvertDownloadsOptions) error {
+	d.convertMu.Lock()
+	if d.converting {
+		d.convertMu.Unlock()
+		return ErrConversionRunning
+	}
+	d.converting = true
+	d.convertMu.Unlock()
+
+	go func() {
+		defer func() {
+			d.convertMu.Lock()
+			d.converting = false
+			d.convertMu.Unlock()
+		}()
+		defer util.HandlePanicInModuleThen("manga/ConvertDownloads", func() {
+			d.wsEventManager.SendEvent(events.ErrorToast, "Failed to convert manga downloads")
+		})
+
+		converted, err := d.convertDownloads(opts)
+		if err != nil {
+			d.logger.Error().Err(err).Msg("manga: Failed to convert downloads")
+			d.wsEventManager.SendEvent(events.ErrorToast, fmt.Sprintf("Failed to convert manga downloads: %s", err.Error()))
+		} else {
+			d.wsEventManager.SendEvent(events.SuccessToast, fmt.Sprintf("Converted %d chapters", converted))
+		}
+
+		d.hydrateMediaMap()
+	}()
+
+	return nil
+}
+
+func (d *Downloader) convertDownloads(opts *ConvertDownloadsOptions) (converted int, err error) {
+	chapters, err := d.getDownloadedChapters(opts.MediaId)
+	if err != nil {
+		return 0, err
+	}
+
+	d.logger.Info().Int("chapters", len(chapters)).Msg("manga: Converting downloads to CBZ")
+
+	copiedMediaIds := make(map[int]struct{})
+	for _, chapter := range chapters {
+		if !chapter.isArchive {
+			meta := d.getChapterMetadata(d.getSeriesMetadata(chapter.id.MediaId), chapter.id)
+			dest, err := chapter_downloader.PackChapter(chapter.path, manga_export.NewComicInfo(meta, 0))
+			if err != nil {
+				d.logger.Error().Err(err).Msgf("manga: Failed to convert chapter %s", chapter.path)
+				continue
+			}
+			chapter.path = dest
+			chapter.isArchive = true
+			converted++
+		}
+
+		if opts.CopyToInternalStorage {
+			if err := d.copyToInternalStorage(chapter); err != nil {
+				d.logger.Error().Err(err).Msgf("manga: Failed to copy chapter %s to internal storage", chapter.path)
+				continue
+			}
+			copiedMediaIds[chapter.id.MediaId] = struct{}{}
+		}
+	}
+
+	if len(copiedMediaIds) > 0 {
+		// Refresh the list of series and chapters seen by the internal storage provider
+		if provider := d.repository.GetInternalStorageProvider(); provider != nil {
+			provider.BuildCacheSync()
+		}
+		for mediaId := range copiedMediaIds {
+			_ = d.repository.fileCacher.Delete(d.repository.getFcProviderBucket(internalStorageProviderId, mediaId, bucketTypeChapter),
+				getMangaChapterContainerCacheKey(internalStorageProviderId, mediaId))
+		}
+	}
+
+	return converted, nil
+}
+
+// copyToInternalStorage copies a chapter archive to the internal storage directory.
+//
+//	e.g. /media/manga/{series}/{series} - Chapter 0012.cbz
+func (d *Downloader) copyToInternalStorage(chapter *downloadedChapter) error {
+	provider := d.repository.GetInternalStorageProvider()
+	if provider == nil || provider.BaseDir() == "" {
+		return errors.New("internal storage is not available")
+	}
+
+	meta := d.getSeriesMetadata(chapter.id.MediaId)
+	series := manga_export.SanitizeFilename(meta.Series)
+	seriesDir := filepath.Join(provider.BaseDir(), series)
+
+	if err := os.MkdirAll(seriesDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Write the info file used by the internal storage provider for matching
+	infoPath := filepath.Join(seriesDir, "info.json")
+	if _, err := os.Stat(infoPath); os.IsNotExist(err) {
+		data, _ := json.Marshal(map[string]string{"romaji": meta.Series})
+		_ = os.WriteFile(infoPath, data, 0644)
+	}
+
+	dest := filepath.Join(seriesDir, fmt.Sprintf("%s - Chapter %s%s", series, padChapterNumber(chapter.id.ChapterNumber), chapter_downloader.ChapterArchiveExt))
+
+	return manga_export.WriteFile(dest, func(w io.Writer) error {
+		f, err := os.Open(chapter.path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Metadata
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getComicInfo returns the ComicInfo.xml of a chapter, used when chapters are downloaded as CBZ.
+func (d *Downloader) getComicInfo(id chapter_downloader.DownloadID, pageCount int) *manga_export.ComicInfo {
+	meta := d.getChapterMetadata(d.getSeriesMetadata(id.MediaId), id)
+	return manga_export.NewComicInfo(meta, pageCount)
+}
+
+// getSeriesMetadata returns the metadata of a series from AniList.
+// The result is cached, and falls back to the media ID if AniList cannot be reached.
+func (d *Downloader) getSeriesMetadata(mediaId int) *manga_export.Metadata {
+	if meta, ok := d.metadataCache.Get(mediaId); ok {
+		return meta
+	}
+
+	meta := &manga_export.Metadata{
+		Series:      fmt.Sprintf("Manga %d", mediaId),
+		RightToLeft: true,
+	}
+
+	if d.platform == nil {
+		return meta
+	}
+
+	media, err := d.platform.GetManga(mediaId)
+	if err != nil || media == nil {
+		d.logger.Warn().Err(err).Int("mediaId", mediaId).Msg("manga: Could not fetch metadata for export")
+		return meta
+	}
+
+	meta.Series = media.GetRomajiTitleSafe()
+	meta.Summary = lo.FromPtr(media.GetDescription())
+	meta.SiteURL = lo.FromPtr(media.GetSiteURL())
+	meta.IsAdult = media.GetIsAdult() != nil && *media.GetIsAdult()
+	if media.GetChapters() != nil {
+		meta.ChapterCount = *media.GetChapters()
+	}
+	if media.GetStartDate() != nil {
+		meta.Year = lo.FromPtr(media.GetStartDate().GetYear())
+		meta.Month = lo.FromPtr(media.GetStartDate().GetMonth())
+	}
+	for _, genre := range media.GetGenres() {
+		if genre != nil {
+			meta.Genres = append(meta.Genres, *genre)
+		}
+	}
+	// Only Japanese manga are read from right to left
+	meta.RightToLeft = media.GetCountryOfOrigin() == nil || *media.GetCountryOfOrigin() == "JP"
+
+	if staff, err := anilist.GetMangaStaff(mediaId, d.logger, ""); err == nil {
+		meta.Writers = staff.Writers
+		meta.Artists = staff.Artists
+	}
+
+	d.metadataCache.SetT(mediaId, meta, time.Hour)
+	return meta
+}
+
+// getChapterMetadata returns a copy of the series metadata with the chapter details.
+func (d *Downloader) getChapterMetadata(series *manga_export.Metadata, id chapter_downloader.DownloadID) *manga_export.Metadata {
+	meta := *series
+	meta.ChapterNumber = id.ChapterNumber
+
+	container, found := d.repository.getChapterContainerFromPermanentFilecache(id.Provider, id.MediaId)
+	if !found {
+		container, found = d.repository.getChapterContainerFromFilecache(id.Provider, id.MediaId)
+	}
+	if found {
+		if chapter, ok := container.GetChapter(id.ChapterId); ok {
+			meta.ChapterTitle = chapter.Title
+			meta.Scanlator = chapter.Scanlator
+			meta.Language = chapter.Language
+		}
+	}
+
+	return &meta
+}
+
+func getChapterTitle(meta *manga_export.Metadata) string {
+	if meta.ChapterTitle != "" {
+		return meta.ChapterTitle
+	}
+	return "Chapter " + meta.ChapterNumber
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getDownloadedChapters returns the downloaded chapters of a media ordered by chapter number.
+// All downloaded chapters are returned if mediaId is 0.
+func (d *Downloader) getDownloadedChapters(mediaId int) ([]*downloadedChapter, error) {
+	files, err := os.ReadDir(d.downloadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*downloadedChapter, 0)
+	for _, file := range files {
+		id, isArchive, ok := chapter_downloader.ParseChapterEntryName(file.Name(), file.IsDir())
+		if !ok || (mediaId != 0 && id.MediaId != mediaId) {
+			continue
+		}
+		ret = append(ret, &downloadedChapter{
+			id:        id,
+			path:      filepath.Join(d.downloadDir, file.Name()),
+			isArchive: isArchive,
+		})
+	}
+
+	slices.SortStableFunc(ret, func(a, b *downloadedChapter) int {
+		if c := cmp.Compare(a.id.MediaId, b.id.MediaId); c != 0 {
+			return c
+		}
+		an, _ := strconv.ParseFloat(a.id.ChapterNumber, 64)
+		bn, _ := strconv.ParseFloat(b.id.ChapterNumber, 64)
+		return cmp.Compare(an, bn)
+	})
+
+	return ret, nil
+}
+
+func filterChapters(chapters []*downloadedChapter, ids []chapter_downloader.DownloadID) []*downloadedChapter {
+	return slices.DeleteFunc(chapters, func(chapter *downloadedChapter) bool {
+		return !slices.ContainsFunc(ids, func(id chapter_downloader.DownloadID) bool {
+			return id.Provider == chapter.id.Provider && id.ChapterId == chapter.id.ChapterId
+		})
+	})
+}
+
+// padChapterNumber pads the integer part of a chapter number so that files are sorted correctly.
+//
+//	e.g. "12.5" -> "0012.5"
+func padChapterNumber(number string) string {
+	intPart, decPart, hasDec := strings.Cut(number, ".")
+	n, err := strconv.Atoi(intPart)
+	if err != nil {
+		return number
+	}
+	if hasDec {
+		return fmt.Sprintf("%04d.%s", n, decPart)
+	}
+	return fmt.Sprintf("%04d", n)
+}
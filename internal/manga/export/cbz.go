@@ -0,0 +1,132 @@
+package manga_export
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const ComicInfoFilename = "ComicInfo.xml"
+
+type (
+	// Page is an image to pack into an archive.
+	Page struct {
+		// Name of the file in the archive, e.g. "01.jpg"
+		Filename string
+		Width    int
+		Height   int
+		// Open returns the image data, the source can be a file or an entry in another archive
+		Open func() (io.ReadCloser, error)
+	}
+
+	// ExtraFile is a non-image file added to an archive, e.g. the download registry.
+	ExtraFile struct {
+		Filename string
+		Data     []byte
+	}
+)
+
+// NewFilePage creates a Page that reads from a file on disk.
+func NewFilePage(path string, width int, height int) *Page {
+	return &Page{
+		Filename: filepath.Base(path),
+		Width:    width,
+		Height:   height,
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+// WriteCBZ writes the pages and ComicInfo.xml to a CBZ archive.
+// Images are stored without compression since they are already compressed.
+func WriteCBZ(w io.Writer, pages []*Page, info *ComicInfo, extra ...*ExtraFile) error {
+	zw := zip.NewWriter(w)
+
+	for _, page := range pages {
+		if err := copyToZip(zw, page.Filename, zip.Store, page.Open); err != nil {
+			return err
+		}
+	}
+
+	if info != nil {
+		data, err := info.Marshal()
+		if err != nil {
+			return err
+		}
+		extra = append(extra, &ExtraFile{Filename: ComicInfoFilename, Data: data})
+	}
+
+	for _, file := range extra {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.Filename, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(file.Data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// WriteFile writes a file atomically by writing to a temporary file first.
+// This avoids leaving a truncated archive behind if the process is interrupted.
+func WriteFile(dest string, write func(w io.Writer) error) (err error) {
+	if err = os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dest)
+}
+
+// SanitizeFilename removes characters that are not allowed in file names.
+func SanitizeFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < 32 {
+			return -1
+		}
+		return r
+	}, name)
+	return strings.TrimRight(strings.TrimSpace(name), ". ")
+}
+
+func copyToZip(zw *zip.Writer, name string, method uint16, open func() (io.ReadCloser, error)) error {
+	r, err := open()
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", name, err)
+	}
+	defer r.Close()
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, r)
+	return err
+}
@@ -0,0 +1,109 @@
+package manga_export
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// ComicInfo is the metadata file read by comic readers (Komga, Kavita, Tachiyomi, etc.).
+// It is stored as ComicInfo.xml at the root of CBZ archives.
+//
+//	https://anansi-project.github.io/docs/comicinfo/schemas/v2.0
+type ComicInfo struct {
+	XMLName     xml.Name `xml:"ComicInfo"`
+	XmlnsXsi    string   `xml:"xmlns:xsi,attr"`
+	XmlnsXsd    string   `xml:"xmlns:xsd,attr"`
+	Title       string   `xml:"Title,omitempty"`
+	Series      string   `xml:"Series,omitempty"`
+	Number      string   `xml:"Number,omitempty"`
+	Count       int      `xml:"Count,omitempty"`
+	Volume      int      `xml:"Volume,omitempty"`
+	Summary     string   `xml:"Summary,omitempty"`
+	Year        int      `xml:"Year,omitempty"`
+	Month       int      `xml:"Month,omitempty"`
+	Writer      string   `xml:"Writer,omitempty"`
+	Penciller   string   `xml:"Penciller,omitempty"`
+	Translator  string   `xml:"Translator,omitempty"`
+	Genre       string   `xml:"Genre,omitempty"`
+	Web         string   `xml:"Web,omitempty"`
+	PageCount   int      `xml:"PageCount,omitempty"`
+	LanguageISO string   `xml:"LanguageISO,omitempty"`
+	Manga       string   `xml:"Manga,omitempty"` // "Yes" or "YesAndRightToLeft"
+	AgeRating   string   `xml:"AgeRating,omitempty"`
+}
+
+// Metadata is the series and chapter information used to build ComicInfo.xml and EPUB metadata.
+type Metadata struct {
+	Series        string
+	ChapterTitle  string
+	ChapterNumber string
+	ChapterCount  int // Total number of chapters in the series, 0 if unknown
+	Summary       string
+	Year          int
+	Month         int
+	Writers       []string
+	Artists       []string
+	Scanlator     string
+	Genres        []string
+	SiteURL       string
+	Language      string
+	IsAdult       bool
+	RightToLeft   bool
+}
+
+// NewComicInfo creates a ComicInfo from the metadata.
+func NewComicInfo(meta *Metadata, pageCount int) *ComicInfo {
+	ret := &ComicInfo{
+		XmlnsXsi:    "http://www.w3.org/2001/XMLSchema-instance",
+		XmlnsXsd:    "http://www.w3.org/2001/XMLSchema",
+		Title:       meta.ChapterTitle,
+		Series:      meta.Series,
+		Number:      meta.ChapterNumber,
+		Count:       meta.ChapterCount,
+		Summary:     cleanSummary(meta.Summary),
+		Year:        meta.Year,
+		Month:       meta.Month,
+		Writer:      strings.Join(meta.Writers, ", "),
+		Penciller:   strings.Join(meta.Artists, ", "),
+		Translator:  meta.Scanlator,
+		Genre:       strings.Join(meta.Genres, ", "),
+		Web:         meta.SiteURL,
+		PageCount:   pageCount,
+		LanguageISO: meta.Language,
+		Manga:       "Yes",
+	}
+	if meta.RightToLeft {
+		ret.Manga = "YesAndRightToLeft"
+	}
+	if meta.IsAdult {
+		ret.AgeRating = "Adults Only 18+"
+	}
+	return ret
+}
+
+// Marshal returns the XML document.
+func (c *ComicInfo) Marshal() ([]byte, error) {
+	data, err := xml.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// cleanSummary removes the HTML tags used in AniList descriptions.
+func cleanSummary(s string) string {
+	s = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n").Replace(s)
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,254 @@
+package manga_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type (
+	// Chapter is a group of pages, used to build the table of contents of EPUB files.
+	Chapter struct {
+		Title string
+		Pages []*Page
+	}
+
+	EpubOptions struct {
+		Identifier string // Unique identifier of the book, e.g. "seanime:manga:1234:chapter:56"
+		Metadata   *Metadata
+		Chapters   []*Chapter
+		Modified   time.Time
+	}
+
+	epubPage struct {
+		Id        string
+		Href      string
+		ImageId   string
+		ImageHref string
+		MediaType string
+		Width     int
+		Height    int
+		IsCover   bool
+	}
+
+	epubNavItem struct {
+		Title string
+		Href  string
+	}
+)
+
+// WriteEPUB writes a fixed-layout EPUB 3 where each page is an image filling the viewport.
+func WriteEPUB(w io.Writer, opts *EpubOptions) error {
+	if opts.Metadata == nil {
+		opts.Metadata = &Metadata{}
+	}
+	if opts.Modified.IsZero() {
+		opts.Modified = time.Now()
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype must be the first entry and must not be compressed
+	if err := writeZipEntry(zw, "mimetype", zip.Store, []byte("application/epub+zip")); err != nil {
+		return err
+	}
+	if err := writeZipEntry(zw, "META-INF/container.xml", zip.Deflate, []byte(epubContainer)); err != nil {
+		return err
+	}
+
+	pages := make([]*epubPage, 0)
+	nav := make([]*epubNavItem, 0)
+	for _, chapter := range opts.Chapters {
+		for i, page := range chapter.Pages {
+			n := len(pages) + 1
+			ext := strings.ToLower(path.Ext(page.Filename))
+			p := &epubPage{
+				Id:        fmt.Sprintf("page%04d", n),
+				Href:      fmt.Sprintf("pages/page%04d.xhtml", n),
+				ImageId:   fmt.Sprintf("image%04d", n),
+				ImageHref: fmt.Sprintf("images/page%04d%s", n, ext),
+				MediaType: imageMediaType(ext),
+				Width:     page.Width,
+				Height:    page.Height,
+				IsCover:   n == 1,
+			}
+			// Fall back to a common page size if the dimensions are unknown
+			if p.Width <= 0 || p.Height <= 0 {
+				p.Width, p.Height = 800, 1200
+			}
+
+			if err := copyToZip(zw, "OEBPS/"+p.ImageHref, zip.Store, page.Open); err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := epubPageTemplate.Execute(&buf, p); err != nil {
+				return err
+			}
+			if err := writeZipEntry(zw, "OEBPS/"+p.Href, zip.Deflate, buf.Bytes()); err != nil {
+				return err
+			}
+
+			if i == 0 {
+				nav = append(nav, &epubNavItem{Title: chapter.Title, Href: p.Href})
+			}
+			pages = append(pages, p)
+		}
+	}
+
+	if len(pages) == 0 {
+		return fmt.Errorf("no pages to export")
+	}
+
+	var buf bytes.Buffer
+	if err := epubNavTemplate.Execute(&buf, map[string]interface{}{
+		"Title": opts.Metadata.Series,
+		"Items": nav,
+	}); err != nil {
+		return err
+	}
+	if err := writeZipEntry(zw, "OEBPS/nav.xhtml", zip.Deflate, buf.Bytes()); err != nil {
+		return err
+	}
+
+	title := opts.Metadata.Series
+	if opts.Metadata.ChapterNumber != "" {
+		title = fmt.Sprintf("%s - Chapter %s", title, opts.Metadata.ChapterNumber)
+	}
+	language := opts.Metadata.Language
+	if language == "" {
+		language = "en"
+	}
+	direction := "ltr"
+	if opts.Metadata.RightToLeft {
+		direction = "rtl"
+	}
+
+	buf.Reset()
+	if err := epubPackageTemplate.Execute(&buf, map[string]interface{}{
+		"Identifier":  opts.Identifier,
+		"Title":       title,
+		"Language":    language,
+		"Creators":    append(append([]string{}, opts.Metadata.Writers...), opts.Metadata.Artists...),
+		"Description": cleanSummary(opts.Metadata.Summary),
+		"Subjects":    opts.Metadata.Genres,
+		"Modified":    opts.Modified.UTC().Format("2006-01-02T15:04:05Z"),
+		"Direction":   direction,
+		"Pages":       pages,
+	}); err != nil {
+		return err
+	}
+	if err := writeZipEntry(zw, "OEBPS/content.opf", zip.Deflate, buf.Bytes()); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeZipEntry(zw *zip.Writer, name string, method uint16, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+func imageMediaType(ext string) string {
+	switch ext {
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
+var epubFuncs = template.FuncMap{
+	"escape": html.EscapeString,
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+var epubPageTemplate = template.Must(template.New("page").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>{{.Id}}</title>
+  <meta name="viewport" content="width={{.Width}}, height={{.Height}}"/>
+  <style>html, body { margin: 0; padding: 0; } img { display: block; width: {{.Width}}px; height: {{.Height}}px; }</style>
+</head>
+<body>
+  <img src="../{{.ImageHref}}" alt=""/>
+</body>
+</html>
+`))
+
+var epubNavTemplate = template.Must(template.New("nav").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>{{escape .Title}}</title>
+</head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <ol>
+{{- range .Items}}
+      <li><a href="{{.Href}}">{{escape .Title}}</a></li>
+{{- end}}
+    </ol>
+  </nav>
+</body>
+</html>
+`))
+
+var epubPackageTemplate = template.Must(template.New("opf").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" prefix="rendition: http://www.idpf.org/vocab/rendition/#">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">{{escape .Identifier}}</dc:identifier>
+    <dc:title>{{escape .Title}}</dc:title>
+    <dc:language>{{escape .Language}}</dc:language>
+{{- range .Creators}}
+    <dc:creator>{{escape .}}</dc:creator>
+{{- end}}
+{{- if .Description}}
+    <dc:description>{{escape .Description}}</dc:description>
+{{- end}}
+{{- range .Subjects}}
+    <dc:subject>{{escape .}}</dc:subject>
+{{- end}}
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+    <meta property="rendition:layout">pre-paginated</meta>
+    <meta property="rendition:orientation">portrait</meta>
+    <meta property="rendition:spread">none</meta>
+    <meta name="cover" content="image0001"/>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+{{- range .Pages}}
+    <item id="{{.ImageId}}" href="{{.ImageHref}}" media-type="{{.MediaType}}"{{if .IsCover}} properties="cover-image"{{end}}/>
+    <item id="{{.Id}}" href="{{.Href}}" media-type="application/xhtml+xml"/>
+{{- end}}
+  </manifest>
+  <spine page-progression-direction="{{.Direction}}">
+{{- range .Pages}}
+    <itemref idref="{{.Id}}"/>
+{{- end}}
+  </spine>
+</package>
+`))
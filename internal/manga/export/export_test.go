@@ -0,0 +1,119 @@
+package manga_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPages(n int) []*Page {
+	ret := make([]*Page, 0, n)
+	for i := 0; i < n; i++ {
+		data := []byte{byte(i)}
+		ret = append(ret, &Page{
+			Filename: string(rune('a'+i)) + ".jpg",
+			Width:    700,
+			Height:   1000,
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		})
+	}
+	return ret
+}
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	ret := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+		ret[f.Name] = string(b)
+	}
+	return ret
+}
+
+func TestWriteCBZ(t *testing.T) {
+	meta := &Metadata{
+		Series:        "Sousou no Frieren",
+		ChapterTitle:  "The End of the Journey",
+		ChapterNumber: "1",
+		Summary:       "An elf<br>and her <i>companions</i>",
+		Year:          2020,
+		Writers:       []string{"Kanehito Yamada"},
+		Artists:       []string{"Tsukasa Abe"},
+		Genres:        []string{"Adventure", "Fantasy"},
+		Language:      "en",
+		RightToLeft:   true,
+	}
+
+	var buf bytes.Buffer
+	err := WriteCBZ(&buf, testPages(3), NewComicInfo(meta, 3), &ExtraFile{Filename: "registry.json", Data: []byte("{}")})
+	require.NoError(t, err)
+
+	files := readZip(t, buf.Bytes())
+	assert.Len(t, files, 5)
+	assert.Equal(t, "\x01", files["b.jpg"])
+	assert.Equal(t, "{}", files["registry.json"])
+
+	info := files[ComicInfoFilename]
+	assert.Contains(t, info, "<Series>Sousou no Frieren</Series>")
+	assert.Contains(t, info, "<Number>1</Number>")
+	assert.Contains(t, info, "<Writer>Kanehito Yamada</Writer>")
+	assert.Contains(t, info, "<Penciller>Tsukasa Abe</Penciller>")
+	assert.Contains(t, info, "<Genre>Adventure, Fantasy</Genre>")
+	assert.Contains(t, info, "<PageCount>3</PageCount>")
+	assert.Contains(t, info, "<Manga>YesAndRightToLeft</Manga>")
+	assert.Contains(t, info, "<Summary>An elf&#xA;and her companions</Summary>")
+}
+
+func TestWriteEPUB(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteEPUB(&buf, &EpubOptions{
+		Identifier: "seanime:manga:1",
+		Metadata:   &Metadata{Series: "Frieren & Co", RightToLeft: true},
+		Chapters: []*Chapter{
+			{Title: "Chapter 1", Pages: testPages(2)},
+			{Title: "Chapter 2", Pages: testPages(1)},
+		},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	// The mimetype must be the first, uncompressed entry
+	require.NotEmpty(t, zr.File)
+	assert.Equal(t, "mimetype", zr.File[0].Name)
+	assert.Equal(t, zip.Store, zr.File[0].Method)
+
+	files := readZip(t, buf.Bytes())
+	assert.Equal(t, "application/epub+zip", files["mimetype"])
+	assert.Contains(t, files, "META-INF/container.xml")
+	assert.Contains(t, files, "OEBPS/images/page0003.jpg")
+	assert.Contains(t, files["OEBPS/pages/page0001.xhtml"], "width=700, height=1000")
+
+	opf := files["OEBPS/content.opf"]
+	assert.Contains(t, opf, "pre-paginated")
+	assert.Contains(t, opf, `page-progression-direction="rtl"`)
+	assert.Contains(t, opf, "Frieren &amp; Co")
+
+	nav := files["OEBPS/nav.xhtml"]
+	assert.Equal(t, 2, strings.Count(nav, "<li>"))
+	assert.Contains(t, nav, "pages/page0003.xhtml")
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	assert.Equal(t, "Re_Zero - Chapter 1", SanitizeFilename("Re:Zero - Chapter 1"))
+	assert.Equal(t, "Why_", SanitizeFilename("Why? ."))
+}
@@ -0,0 +1,329 @@
+package manga_mihon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The backup is decoded field by field instead of using generated code.
+// Only the fields needed for the import are read, unknown fields are skipped.
+// Field numbers follow Mihon's backup models, which are compatible with Tachiyomi's.
+
+const (
+	// TrackerAnilist is the sync ID of the AniList tracker in Tachiyomi/Mihon
+	TrackerAnilist = 2
+	// maxBackupSize is the maximum size of a decompressed backup
+	maxBackupSize = 256 << 20
+)
+
+var ErrInvalidBackup = errors.New("mihon: Invalid backup file")
+
+type (
+	Backup struct {
+		Manga   []*BackupManga
+		Sources []*BackupSource
+	}
+
+	BackupManga struct {
+		Source    int64
+		URL       string
+		Title     string
+		Favorite  bool
+		Chapters  []*BackupChapter
+		Trackings []*BackupTracking
+	}
+
+	BackupChapter struct {
+		URL           string
+		Name          string
+		Scanlator     string
+		Read          bool
+		LastPageRead  int64
+		ChapterNumber float32
+	}
+
+	BackupTracking struct {
+		SyncID          int32
+		MediaID         int64
+		Title           string
+		LastChapterRead float32
+		TotalChapters   int32
+	}
+
+	BackupSource struct {
+		Name     string
+		SourceID int64
+	}
+)
+
+// ParseBackup decodes a .tachibk or .proto.gz backup.
+// Uncompressed protobuf data is also accepted.
+func ParseBackup(data []byte) (*Backup, error) {
+	// Gzip magic number
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+		defer gr.Close()
+		data, err = io.ReadAll(io.LimitReader(gr, maxBackupSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+		if len(data) > maxBackupSize {
+			return nil, fmt.Errorf("%w: Backup is too large", ErrInvalidBackup)
+		}
+	}
+
+	ret := &Backup{}
+	err := rangeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			m, err := parseManga(v)
+			if err != nil {
+				return 0, err
+			}
+			ret.Manga = append(ret.Manga, m)
+			return n, nil
+		case num == 101 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			s, err := parseSource(v)
+			if err != nil {
+				return 0, err
+			}
+			ret.Sources = append(ret.Sources, s)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetSourceName returns the name of a source, empty if the backup does not list it.
+func (b *Backup) GetSourceName(sourceId int64) string {
+	for _, s := range b.Sources {
+		if s.SourceID == sourceId {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// GetAnilistID returns the AniList ID from the AniList tracker, 0 if the manga is not tracked.
+func (m *BackupManga) GetAnilistID() int {
+	for _, t := range m.Trackings {
+		if t.SyncID == TrackerAnilist && t.MediaID > 0 {
+			return int(t.MediaID)
+		}
+	}
+	return 0
+}
+
+// GetAnilistProgress returns the last chapter read according to the AniList tracker.
+func (m *BackupManga) GetAnilistProgress() int {
+	for _, t := range m.Trackings {
+		if t.SyncID == TrackerAnilist {
+			return int(t.LastChapterRead)
+		}
+	}
+	return 0
+}
+
+// GetReadChapters returns the chapters marked as read.
+func (m *BackupManga) GetReadChapters() []*BackupChapter {
+	ret := make([]*BackupChapter, 0)
+	for _, c := range m.Chapters {
+		if c.Read {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// GetHighestReadChapter returns the number of the highest chapter read, decimals are truncated.
+// Chapters with an unknown number (-1) are ignored.
+func (m *BackupManga) GetHighestReadChapter() int {
+	ret := 0
+	for _, c := range m.Chapters {
+		if c.Read && c.ChapterNumber > 0 {
+			ret = max(ret, int(c.ChapterNumber))
+		}
+	}
+	return ret
+}
+
+func parseManga(data []byte) (*BackupManga, error) {
+	ret := &BackupManga{}
+	err := rangeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.Source = int64(v)
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.URL = v
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.Title = v
+			return n, nil
+		case num == 16 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			c, err := parseChapter(v)
+			if err != nil {
+				return 0, err
+			}
+			ret.Chapters = append(ret.Chapters, c)
+			return n, nil
+		case num == 18 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			t, err := parseTracking(v)
+			if err != nil {
+				return 0, err
+			}
+			ret.Trackings = append(ret.Trackings, t)
+			return n, nil
+		case num == 100 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.Favorite = protowire.DecodeBool(v)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	return ret, err
+}
+
+func parseChapter(data []byte) (*BackupChapter, error) {
+	ret := &BackupChapter{}
+	err := rangeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.URL = v
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.Name = v
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.Scanlator = v
+			return n, nil
+		case num == 4 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.Read = protowire.DecodeBool(v)
+			return n, nil
+		case num == 6 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.LastPageRead = int64(v)
+			return n, nil
+		case num == 9 && typ == protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(b)
+			ret.ChapterNumber = math.Float32frombits(v)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	return ret, err
+}
+
+func parseTracking(data []byte) (*BackupTracking, error) {
+	ret := &BackupTracking{}
+	err := rangeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.SyncID = int32(v)
+			return n, nil
+		// Deprecated int32 media ID, only used if the int64 one is missing
+		case num == 3 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if ret.MediaID == 0 {
+				ret.MediaID = int64(int32(v))
+			}
+			return n, nil
+		case num == 5 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.Title = v
+			return n, nil
+		case num == 6 && typ == protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(b)
+			ret.LastChapterRead = math.Float32frombits(v)
+			return n, nil
+		case num == 7 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.TotalChapters = int32(v)
+			return n, nil
+		case num == 100 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.MediaID = int64(v)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	return ret, err
+}
+
+func parseSource(data []byte) (*BackupSource, error) {
+	ret := &BackupSource{}
+	err := rangeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			ret.Name = v
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.SourceID = int64(v)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	return ret, err
+}
+
+// rangeFields calls f for each field of a message.
+// f receives the data following the tag and returns the length of the field value, negative on error.
+func rangeFields(data []byte, f func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		n, err := f(num, typ, data)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, protowire.ParseError(n))
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package manga_mihon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"seanime/internal/api/anilist"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendFloat(b []byte, num protowire.Number, v float32) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+	return protowire.AppendFixed32(b, math.Float32bits(v))
+}
+
+func appendMessage(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func testChapter(number float32, read bool) []byte {
+	var b []byte
+	b = appendString(b, 1, "/chapter/x")
+	b = appendString(b, 2, "Chapter")
+	b = appendVarint(b, 4, protowire.EncodeBool(read))
+	b = appendFloat(b, 9, number)
+	return b
+}
+
+// testBackup returns a gzipped backup with two manga from MangaDex and an unknown source.
+func testBackup(t *testing.T) []byte {
+	var tracked []byte
+	tracked = appendVarint(tracked, 1, 2345)
+	tracked = appendString(tracked, 2, "/manga/801513ba-a712-498c-8f57-cae55b38cc92")
+	tracked = appendString(tracked, 3, "Berserk")
+	tracked = appendMessage(tracked, 16, testChapter(1, true))
+	tracked = appendMessage(tracked, 16, testChapter(2, true))
+	tracked = appendMessage(tracked, 16, testChapter(3, false))
+	var tracking []byte
+	tracking = appendVarint(tracking, 1, TrackerAnilist)
+	tracking = appendFloat(tracking, 6, 2)
+	tracking = appendVarint(tracking, 100, 30002)
+	tracked = appendMessage(tracked, 18, tracking)
+	tracked = appendVarint(tracked, 100, protowire.EncodeBool(true))
+
+	var untracked []byte
+	untracked = appendVarint(untracked, 1, 999)
+	untracked = appendString(untracked, 2, "/title/abc")
+	untracked = appendString(untracked, 3, "One Piece")
+	untracked = appendMessage(untracked, 16, testChapter(1050.5, true))
+	// Unknown field, should be skipped
+	untracked = appendVarint(untracked, 50, 1)
+
+	// Not in the library and nothing read, should not be listed
+	var skipped []byte
+	skipped = appendVarint(skipped, 1, 2345)
+	skipped = appendString(skipped, 3, "Skipped")
+
+	var source []byte
+	source = appendString(source, 1, "MangaDex")
+	source = appendVarint(source, 2, 2345)
+
+	var b []byte
+	b = appendMessage(b, 1, tracked)
+	b = appendMessage(b, 1, untracked)
+	b = appendMessage(b, 1, skipped)
+	b = appendMessage(b, 101, source)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestParseBackup(t *testing.T) {
+	backup, err := ParseBackup(testBackup(t))
+	require.NoError(t, err)
+
+	require.Len(t, backup.Manga, 3)
+	require.Len(t, backup.Sources, 1)
+
+	berserk := backup.Manga[0]
+	assert.Equal(t, "Berserk", berserk.Title)
+	assert.Equal(t, "MangaDex", backup.GetSourceName(berserk.Source))
+	assert.True(t, berserk.Favorite)
+	assert.Len(t, berserk.Chapters, 3)
+	assert.Len(t, berserk.GetReadChapters(), 2)
+	assert.Equal(t, 2, berserk.GetHighestReadChapter())
+	assert.Equal(t, 30002, berserk.GetAnilistID())
+	assert.Equal(t, 2, berserk.GetAnilistProgress())
+
+	onePiece := backup.Manga[1]
+	assert.Equal(t, "", backup.GetSourceName(onePiece.Source))
+	assert.Equal(t, 1050, onePiece.GetHighestReadChapter())
+	assert.Equal(t, 0, onePiece.GetAnilistID())
+
+	_, err = ParseBackup([]byte{0xff, 0xff, 0xff})
+	assert.ErrorIs(t, err, ErrInvalidBackup)
+}
+
+func TestParseSourceURL(t *testing.T) {
+	tests := []struct {
+		source   string
+		url      string
+		expected string
+		ok       bool
+	}{
+		{"MangaDex", "/manga/801513ba-a712-498c-8f57-cae55b38cc92", "801513ba-a712-498c-8f57-cae55b38cc92", true},
+		{"Comick", "/comic/00-one-piece#", "00-one-piece", true},
+		{"Mangapill", "/manga/2/one-piece", "2$one-piece", true},
+		{"Weeb Central", "https://weebcentral.com/series/01J76XY7E9FNDZ1DBBM6PBJPFK/One-Piece", "01J76XY7E9FNDZ1DBBM6PBJPFK", true},
+		{"MangaDex", "/title/abc", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source+tt.url, func(t *testing.T) {
+			known, ok := knownSources[normalizeSourceName(tt.source)]
+			require.True(t, ok)
+			id, ok := known.ParseURL(tt.url)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+type fakeRepository struct {
+	results map[string][]*hibikemanga.SearchResult
+	mapped  map[string]string
+}
+
+func (r *fakeRepository) ManualSearch(provider string, query string) ([]*hibikemanga.SearchResult, error) {
+	return r.results[provider], nil
+}
+
+func (r *fakeRepository) ManualMapping(provider string, mediaId int, mangaId string) error {
+	r.mapped[provider] = mangaId
+	return nil
+}
+
+func (r *fakeRepository) RemoveMapping(provider string, mediaId int) error {
+	delete(r.mapped, provider)
+	return nil
+}
+
+func TestPreview(t *testing.T) {
+	backup, err := ParseBackup(testBackup(t))
+	require.NoError(t, err)
+
+	// List the unknown source under the name of an installed provider
+	backup.Sources = append(backup.Sources, &BackupSource{Name: "Weeb Central (EN)", SourceID: 999})
+
+	collection := &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: []*anilist.MangaCollection_MediaListCollection_Lists{
+				{
+					Entries: []*anilist.MangaCollection_MediaListCollection_Lists_Entries{
+						{
+							Progress: lo.ToPtr(5),
+							Media: &anilist.BaseManga{
+								ID:    30002,
+								Title: &anilist.BaseManga_Title{English: lo.ToPtr("Berserk")},
+							},
+						},
+						{
+							Progress: lo.ToPtr(1000),
+							Media: &anilist.BaseManga{
+								ID:    30013,
+								Title: &anilist.BaseManga_Title{English: lo.ToPtr("One Piece")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	repo := &fakeRepository{
+		results: map[string][]*hibikemanga.SearchResult{
+			"weebcentral": {{ID: "wc-one-piece", Title: "One Piece"}},
+		},
+		mapped: make(map[string]string),
+	}
+
+	importer := NewImporter(&NewImporterOptions{
+		Logger:     util.NewLogger(),
+		Repository: repo,
+	})
+
+	preview := importer.Preview(&PreviewOptions{
+		Backup:          backup,
+		MangaCollection: collection,
+		Providers:       map[string]string{"mangadex": "MangaDex", "weebcentral": "WeebCentral"},
+	})
+
+	require.Len(t, preview.Entries, 2)
+
+	berserk := preview.Entries[0]
+	assert.Equal(t, 30002, berserk.MediaId)
+	assert.Equal(t, MatchTypeTracker, berserk.MatchedBy)
+	assert.Equal(t, 5, berserk.CurrentProgress)
+	assert.Equal(t, 2, berserk.Progress)
+	assert.Equal(t, "mangadex", berserk.Provider)
+	assert.Equal(t, "801513ba-a712-498c-8f57-cae55b38cc92", berserk.ProviderMangaId)
+	assert.Equal(t, MatchTypeURL, berserk.ProviderMatchedBy)
+
+	onePiece := preview.Entries[1]
+	assert.Equal(t, 30013, onePiece.MediaId)
+	assert.Equal(t, MatchTypeCollection, onePiece.MatchedBy)
+	assert.Equal(t, 1050, onePiece.Progress)
+	// The URL cannot be parsed, the provider is searched by title
+	assert.Equal(t, "weebcentral", onePiece.Provider)
+	assert.Equal(t, "wc-one-piece", onePiece.ProviderMangaId)
+	assert.Equal(t, MatchTypeSearch, onePiece.ProviderMatchedBy)
+}
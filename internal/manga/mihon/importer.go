@@ -0,0 +1,391 @@
+package manga_mihon
+
+import (
+	"context"
+	"fmt"
+	"seanime/internal/api/anilist"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util/comparison"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+const (
+	// matchingThreshold is the minimum Sorensen-Dice rating for a title match
+	matchingThreshold = 0.6
+	// previewWorkers is the number of manga resolved concurrently, kept low to avoid rate limits
+	previewWorkers = 4
+)
+
+type MatchType string
+
+const (
+	MatchTypeNone       MatchType = ""
+	MatchTypeTracker    MatchType = "tracker"    // AniList tracker of the backup
+	MatchTypeCollection MatchType = "collection" // Title match in the user's manga collection
+	MatchTypeSearch     MatchType = "search"     // Title search on AniList or on the provider
+	MatchTypeURL        MatchType = "url"        // Provider manga ID parsed from the backup URL
+)
+
+type (
+	// Importer imports reading history from Tachiyomi/Mihon backups.
+	Importer struct {
+		logger     *zerolog.Logger
+		platform   platform.Platform
+		repository ProviderRepository
+	}
+
+	// ProviderRepository searches and maps manga providers.
+	// Implemented by manga.Repository.
+	ProviderRepository interface {
+		ManualSearch(provider string, query string) ([]*hibikemanga.SearchResult, error)
+		ManualMapping(provider string, mediaId int, mangaId string) error
+		RemoveMapping(provider string, mediaId int) error
+	}
+
+	NewImporterOptions struct {
+		Logger     *zerolog.Logger
+		Platform   platform.Platform
+		Repository ProviderRepository
+	}
+
+	PreviewOptions struct {
+		Backup          *Backup
+		MangaCollection *anilist.MangaCollection
+		// Providers maps the IDs of the installed manga providers to their names
+		Providers map[string]string
+	}
+
+	// Preview lists the manga of a backup and how they would be imported.
+	// Nothing is changed until Apply is called.
+	Preview struct {
+		Entries []*PreviewEntry `json:"entries"`
+	}
+
+	PreviewEntry struct {
+		Title      string `json:"title"`
+		SourceName string `json:"sourceName"`
+		URL        string `json:"url"`
+		// ReadChapters is the number of chapters marked as read
+		ReadChapters int `json:"readChapters"`
+		// Progress is the highest chapter read, the value AniList progress would be set to
+		Progress int `json:"progress"`
+		// MediaId is the matched AniList ID, 0 if not matched
+		MediaId   int                `json:"mediaId"`
+		Media     *anilist.BaseManga `json:"media,omitempty"`
+		MatchedBy MatchType          `json:"matchedBy"`
+		// CurrentProgress is the progress in the user's collection, progress is only updated if the backup is ahead
+		CurrentProgress int  `json:"currentProgress"`
+		InCollection    bool `json:"inCollection"`
+		// Provider and ProviderMangaId are the Seanime provider entry mapped to the media, empty if not found
+		Provider          string    `json:"provider"`
+		ProviderMangaId   string    `json:"providerMangaId"`
+		ProviderMatchedBy MatchType `json:"providerMatchedBy"`
+	}
+
+	// ApplyEntry is an entry of the preview selected by the user.
+	ApplyEntry struct {
+		MediaId         int    `json:"mediaId"`
+		Progress        int    `json:"progress"`
+		TotalChapters   int    `json:"totalChapters"`
+		Provider        string `json:"provider"`
+		ProviderMangaId string `json:"providerMangaId"`
+	}
+
+	ApplyResult struct {
+		ProgressUpdated int      `json:"progressUpdated"`
+		Mapped          int      `json:"mapped"`
+		Errors          []string `json:"errors"`
+	}
+)
+
+func NewImporter(opts *NewImporterOptions) *Importer {
+	return &Importer{
+		logger:     opts.Logger,
+		platform:   opts.Platform,
+		repository: opts.Repository,
+	}
+}
+
+// Preview matches the manga of a backup to AniList media and Seanime providers.
+// Manga without read chapters are only listed if they are in the Tachiyomi/Mihon library.
+func (i *Importer) Preview(opts *PreviewOptions) *Preview {
+	collectionMedia := getCollectionMedia(opts.MangaCollection)
+
+	entries := make([]*PreviewEntry, 0)
+	mangas := make([]*BackupManga, 0)
+	for _, m := range opts.Backup.Manga {
+		readChapters := len(m.GetReadChapters())
+		if readChapters == 0 && !m.Favorite {
+			continue
+		}
+		entries = append(entries, &PreviewEntry{
+			Title:        m.Title,
+			SourceName:   opts.Backup.GetSourceName(m.Source),
+			URL:          m.URL,
+			ReadChapters: readChapters,
+			Progress:     max(m.GetHighestReadChapter(), m.GetAnilistProgress()),
+		})
+		mangas = append(mangas, m)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, previewWorkers)
+	for idx := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(entry *PreviewEntry, m *BackupManga) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			i.resolveMedia(entry, m, collectionMedia)
+			i.resolveProvider(entry, opts.Providers)
+		}(entries[idx], mangas[idx])
+	}
+	wg.Wait()
+
+	// Matched entries first
+	slices.SortStableFunc(entries, func(a, b *PreviewEntry) int {
+		if (a.MediaId == 0) != (b.MediaId == 0) {
+			if a.MediaId == 0 {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+
+	return &Preview{Entries: entries}
+}
+
+// resolveMedia finds the AniList media of a backup manga, using the tracker, the collection and then AniList search.
+func (i *Importer) resolveMedia(entry *PreviewEntry, m *BackupManga, collectionMedia map[int]*collectionMediaItem) {
+	if id := m.GetAnilistID(); id != 0 {
+		entry.MediaId = id
+		entry.MatchedBy = MatchTypeTracker
+		if item, ok := collectionMedia[id]; ok {
+			entry.Media = item.media
+		} else if i.platform != nil {
+			if media, err := i.platform.GetManga(id); err == nil {
+				entry.Media = media
+			}
+		}
+	} else if media, ok := findBestMatch(m.Title, lo.MapToSlice(collectionMedia, func(_ int, item *collectionMediaItem) *anilist.BaseManga {
+		return item.media
+	})); ok {
+		entry.MediaId = media.GetID()
+		entry.Media = media
+		entry.MatchedBy = MatchTypeCollection
+	} else if media, ok := i.searchAnilist(m.Title); ok {
+		entry.MediaId = media.GetID()
+		entry.Media = media
+		entry.MatchedBy = MatchTypeSearch
+	}
+
+	if item, ok := collectionMedia[entry.MediaId]; ok && entry.MediaId != 0 {
+		entry.InCollection = true
+		entry.CurrentProgress = item.progress
+	}
+}
+
+// resolveProvider finds the Seanime provider entry of a backup manga.
+// The manga ID is parsed from the URL for known sources, other installed providers with the same name are searched by title.
+func (i *Importer) resolveProvider(entry *PreviewEntry, providers map[string]string) {
+	if entry.SourceName == "" {
+		return
+	}
+	sourceName := normalizeSourceName(entry.SourceName)
+
+	if known, ok := knownSources[sourceName]; ok {
+		if _, installed := providers[known.Provider]; installed {
+			if mangaId, ok := known.ParseURL(entry.URL); ok {
+				entry.Provider = known.Provider
+				entry.ProviderMangaId = mangaId
+				entry.ProviderMatchedBy = MatchTypeURL
+				return
+			}
+			i.searchProvider(entry, known.Provider)
+			return
+		}
+	}
+
+	for id, name := range providers {
+		if normalizeSourceName(name) == sourceName || normalizeSourceName(id) == sourceName {
+			i.searchProvider(entry, id)
+			return
+		}
+	}
+}
+
+func (i *Importer) searchProvider(entry *PreviewEntry, provider string) {
+	if i.repository == nil {
+		return
+	}
+
+	results, err := i.repository.ManualSearch(provider, entry.Title)
+	if err != nil {
+		i.logger.Debug().Err(err).Str("provider", provider).Str("title", entry.Title).Msg("mihon: Provider search failed")
+		return
+	}
+
+	title := entry.Title
+	var best *hibikemanga.SearchResult
+	bestRating := 0.0
+	for _, res := range results {
+		titles := []*string{&res.Title}
+		for _, s := range res.Synonyms {
+			titles = append(titles, &s)
+		}
+		if r, ok := comparison.FindBestMatchWithSorensenDice(&title, titles); ok && r.Rating > bestRating {
+			best = res
+			bestRating = r.Rating
+		}
+	}
+	if best == nil || bestRating < matchingThreshold {
+		return
+	}
+
+	entry.Provider = provider
+	entry.ProviderMangaId = best.ID
+	entry.ProviderMatchedBy = MatchTypeSearch
+}
+
+func (i *Importer) searchAnilist(title string) (*anilist.BaseManga, bool) {
+	if i.platform == nil || title == "" {
+		return nil, false
+	}
+
+	res, err := i.platform.GetAnilistClient().SearchBaseManga(context.Background(), lo.ToPtr(1), lo.ToPtr(5), []*anilist.MediaSort{lo.ToPtr(anilist.MediaSortSearchMatch)}, &title, nil)
+	if err != nil {
+		i.logger.Debug().Err(err).Str("title", title).Msg("mihon: AniList search failed")
+		return nil, false
+	}
+
+	return findBestMatch(title, res.GetPage().GetMedia())
+}
+
+// Apply updates the AniList progress and the provider mappings of the selected entries.
+// Progress is only updated if it is ahead of the progress in the collection.
+func (i *Importer) Apply(entries []*ApplyEntry, collection *anilist.MangaCollection) *ApplyResult {
+	ret := &ApplyResult{Errors: make([]string, 0)}
+	collectionMedia := getCollectionMedia(collection)
+
+	for _, entry := range entries {
+		if entry.MediaId == 0 {
+			continue
+		}
+
+		if entry.Provider != "" && entry.ProviderMangaId != "" && i.repository != nil {
+			// Remove the previous mapping first, a media has one mapping per provider
+			_ = i.repository.RemoveMapping(entry.Provider, entry.MediaId)
+			if err := i.repository.ManualMapping(entry.Provider, entry.MediaId, entry.ProviderMangaId); err != nil {
+				ret.Errors = append(ret.Errors, fmt.Sprintf("Failed to map %d to %s: %s", entry.MediaId, entry.Provider, err.Error()))
+			} else {
+				ret.Mapped++
+			}
+		}
+
+		if entry.Progress <= 0 {
+			continue
+		}
+		if item, ok := collectionMedia[entry.MediaId]; ok && item.progress >= entry.Progress {
+			continue
+		}
+
+		progress := entry.Progress
+		var totalChapters *int
+		if entry.TotalChapters > 0 {
+			totalChapters = &entry.TotalChapters
+			progress = min(progress, entry.TotalChapters)
+		}
+		if err := i.platform.UpdateEntryProgress(entry.MediaId, progress, totalChapters); err != nil {
+			ret.Errors = append(ret.Errors, fmt.Sprintf("Failed to update progress of %d: %s", entry.MediaId, err.Error()))
+			continue
+		}
+		ret.ProgressUpdated++
+	}
+
+	if ret.ProgressUpdated > 0 {
+		if _, err := i.platform.RefreshMangaCollection(); err != nil {
+			i.logger.Warn().Err(err).Msg("mihon: Failed to refresh manga collection")
+		}
+	}
+
+	i.logger.Info().Int("progressUpdated", ret.ProgressUpdated).Int("mapped", ret.Mapped).Int("errors", len(ret.Errors)).Msg("mihon: Backup imported")
+
+	return ret
+}
+
+type collectionMediaItem struct {
+	media    *anilist.BaseManga
+	progress int
+}
+
+func getCollectionMedia(collection *anilist.MangaCollection) map[int]*collectionMediaItem {
+	ret := make(map[int]*collectionMediaItem)
+	if collection == nil {
+		return ret
+	}
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		for _, entry := range list.GetEntries() {
+			if entry.GetMedia() == nil {
+				continue
+			}
+			ret[entry.GetMedia().GetID()] = &collectionMediaItem{
+				media:    entry.GetMedia(),
+				progress: lo.FromPtr(entry.GetProgress()),
+			}
+		}
+	}
+	return ret
+}
+
+// findBestMatch returns the media whose titles best match the given title, false if none is above the threshold.
+func findBestMatch(title string, media []*anilist.BaseManga) (*anilist.BaseManga, bool) {
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return nil, false
+	}
+
+	var best *anilist.BaseManga
+	bestRating := 0.0
+	for _, m := range media {
+		titles := make([]*string, 0)
+		for _, t := range m.GetAllTitles() {
+			if t == nil {
+				continue
+			}
+			n := normalizeTitle(*t)
+			titles = append(titles, &n)
+		}
+		if len(titles) == 0 {
+			continue
+		}
+		res, ok := comparison.FindBestMatchWithSorensenDice(&normalized, titles)
+		if ok && res.Rating > bestRating {
+			bestRating = res.Rating
+			best = m
+		}
+	}
+
+	if best == nil || bestRating < matchingThreshold {
+		return nil, false
+	}
+	return best, true
+}
+
+func normalizeTitle(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '!', '?', ',', '\'', '"', '.', '-', '_', '~':
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
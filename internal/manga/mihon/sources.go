@@ -0,0 +1,89 @@
+package manga_mihon
+
+import (
+	"net/url"
+	"strings"
+
+	manga_providers "seanime/internal/manga/providers"
+)
+
+// knownSources maps the normalized names of Tachiyomi/Mihon sources to the built-in providers.
+// The URL parser returns the provider's manga ID from the manga URL stored in the backup, false if it cannot be parsed.
+var knownSources = map[string]struct {
+	Provider string
+	ParseURL func(path string) (string, bool)
+}{
+	"mangadex": {
+		Provider: manga_providers.MangadexProvider,
+		// e.g. "/manga/{uuid}"
+		ParseURL: func(path string) (string, bool) {
+			return pathSegment(path, "manga", 0)
+		},
+	},
+	"comick": {
+		Provider: manga_providers.ComickProvider,
+		// e.g. "/comic/{hid}#"
+		ParseURL: func(path string) (string, bool) {
+			return pathSegment(path, "comic", 0)
+		},
+	},
+	"mangapill": {
+		Provider: manga_providers.MangapillProvider,
+		// e.g. "/manga/{id}/{slug}" -> "{id}${slug}"
+		ParseURL: func(path string) (string, bool) {
+			id, ok := pathSegment(path, "manga", 0)
+			if !ok {
+				return "", false
+			}
+			slug, ok := pathSegment(path, "manga", 1)
+			if !ok {
+				return "", false
+			}
+			return id + "$" + slug, true
+		},
+	},
+	"weebcentral": {
+		Provider: manga_providers.WeebCentralProvider,
+		// e.g. "/series/{id}/{slug}"
+		ParseURL: func(path string) (string, bool) {
+			return pathSegment(path, "series", 0)
+		},
+	},
+}
+
+// pathSegment returns the nth segment after the given segment of a URL or path.
+func pathSegment(rawUrl string, after string, n int) (string, bool) {
+	path := rawUrl
+	if u, err := url.Parse(rawUrl); err == nil {
+		path = u.Path
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range parts {
+		if p != after {
+			continue
+		}
+		if i+1+n < len(parts) && parts[i+1+n] != "" {
+			return parts[i+1+n], true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// normalizeSourceName lowercases a source name and removes spaces and language suffixes.
+//
+//	"Weeb Central" -> "weebcentral"
+//	"MangaDex (EN)" -> "mangadex"
+func normalizeSourceName(name string) string {
+	name = strings.ToLower(name)
+	if idx := strings.Index(name, "("); idx > 0 {
+		name = name[:idx]
+	}
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' || r == '.' {
+			return -1
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,521 @@
+package opds
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	chapter_downloader "seanime/internal/manga/downloader"
+	manga_export "seanime/internal/manga/export"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// BasePath is the path under which the catalog is served.
+const BasePath = "/opds"
+
+const internalStorageProviderId = "internal-storage"
+
+var ErrNotFound = errors.New("opds: Not found")
+
+type (
+	// Catalog builds the OPDS feeds of the manga library.
+	//   - The AniList manga collection, grouped by list status
+	//   - Chapters in the download directory, stored as image folders or CBZ archives
+	//   - Chapters in the internal storage directory (CBZ archives)
+	Catalog struct {
+		logger      *zerolog.Logger
+		database    *db.Database
+		platform    platform.Platform
+		downloadDir string
+		// localDir returns the internal storage directory, empty if unavailable
+		localDir func() string
+	}
+
+	NewCatalogOptions struct {
+		Logger      *zerolog.Logger
+		Database    *db.Database
+		Platform    platform.Platform
+		DownloadDir string
+		LocalDir    func() string
+	}
+
+	// downloadedChapter is a chapter in the download directory.
+	downloadedChapter struct {
+		id   chapter_downloader.DownloadID
+		name string // e.g. manga_comick_123_10010_13 or manga_comick_123_10010_13.cbz
+	}
+
+	listStatus struct {
+		slug   string
+		title  string
+		status anilist.MediaListStatus
+	}
+)
+
+var listStatuses = []listStatus{
+	{slug: "current", title: "Reading", status: anilist.MediaListStatusCurrent},
+	{slug: "repeating", title: "Rereading", status: anilist.MediaListStatusRepeating},
+	{slug: "planning", title: "Planning", status: anilist.MediaListStatusPlanning},
+	{slug: "paused", title: "Paused", status: anilist.MediaListStatusPaused},
+	{slug: "completed", title: "Completed", status: anilist.MediaListStatusCompleted},
+	{slug: "dropped", title: "Dropped", status: anilist.MediaListStatusDropped},
+}
+
+func NewCatalog(opts *NewCatalogOptions) *Catalog {
+	localDir := opts.LocalDir
+	if localDir == nil {
+		localDir = func() string { return "" }
+	}
+	return &Catalog{
+		logger:      opts.Logger,
+		database:    opts.Database,
+		platform:    opts.Platform,
+		downloadDir: opts.DownloadDir,
+		localDir:    localDir,
+	}
+}
+
+// RootFeed returns the navigation feed listing the sections of the catalog.
+func (c *Catalog) RootFeed() *Feed {
+	feed := NewFeed("urn:seanime:opds:root", "Seanime", BasePath, MimeTypeNavigation)
+
+	for _, ls := range listStatuses {
+		feed.AddEntry(newNavigationEntry("urn:seanime:opds:list:"+ls.slug, ls.title, "", BasePath+"/lists/"+ls.slug))
+	}
+	feed.AddEntry(newNavigationEntry("urn:seanime:opds:downloads", "Downloaded", "Series with downloaded chapters", BasePath+"/downloads"))
+	if c.localDir() != "" {
+		feed.AddEntry(newNavigationEntry("urn:seanime:opds:local", "Local library", "Series in the internal storage", BasePath+"/local"))
+	}
+
+	return feed
+}
+
+// ListFeed returns the navigation feed of the series in a list of the AniList collection.
+func (c *Catalog) ListFeed(slug string) (*Feed, error) {
+	ls, ok := getListStatus(slug)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	collection, err := c.platform.GetMangaCollection(false)
+	if err != nil {
+		return nil, fmt.Errorf("opds: Failed to get manga collection: %w", err)
+	}
+
+	feed := NewFeed("urn:seanime:opds:list:"+ls.slug, ls.title, BasePath+"/lists/"+ls.slug, MimeTypeNavigation)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath, Type: MimeTypeNavigation})
+
+	downloadCounts := c.getDownloadCounts()
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		if list.GetStatus() == nil || *list.GetStatus() != ls.status || list.GetIsCustomList() != nil && *list.GetIsCustomList() {
+			continue
+		}
+		for _, entry := range list.GetEntries() {
+			feed.AddEntry(newSeriesEntry(entry.GetMedia(), downloadCounts[entry.GetMedia().GetID()]))
+		}
+	}
+
+	return feed, nil
+}
+
+// DownloadsFeed returns the navigation feed of the series with downloaded chapters.
+func (c *Catalog) DownloadsFeed() (*Feed, error) {
+	feed := NewFeed("urn:seanime:opds:downloads", "Downloaded", BasePath+"/downloads", MimeTypeNavigation)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath, Type: MimeTypeNavigation})
+
+	downloadCounts := c.getDownloadCounts()
+	if len(downloadCounts) == 0 {
+		return feed, nil
+	}
+
+	mediaIds := make([]int, 0, len(downloadCounts))
+	for mediaId := range downloadCounts {
+		mediaIds = append(mediaIds, mediaId)
+	}
+	slices.Sort(mediaIds)
+
+	collection, _ := c.platform.GetMangaCollection(false)
+	for _, mediaId := range mediaIds {
+		if entry, found := collection.GetListEntryFromMangaId(mediaId); found {
+			feed.AddEntry(newSeriesEntry(entry.GetMedia(), downloadCounts[mediaId]))
+			continue
+		}
+		// The series is not in the collection
+		feed.AddEntry(newSeriesEntry(&anilist.BaseManga{ID: mediaId}, downloadCounts[mediaId]))
+	}
+
+	return feed, nil
+}
+
+// SeriesFeed returns the acquisition feed of the downloaded and local chapters of a series.
+func (c *Catalog) SeriesFeed(mediaId int) (*Feed, error) {
+	title := fmt.Sprintf("Manga %d", mediaId)
+	var media *anilist.BaseManga
+	if collection, err := c.platform.GetMangaCollection(false); err == nil {
+		if entry, found := collection.GetListEntryFromMangaId(mediaId); found {
+			media = entry.GetMedia()
+			title = media.GetPreferredTitle()
+		}
+	}
+
+	feed := NewFeed(fmt.Sprintf("urn:seanime:opds:series:%d", mediaId), title, fmt.Sprintf("%s/series/%d", BasePath, mediaId), MimeTypeAcquisition)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath + "/downloads", Type: MimeTypeNavigation})
+	if media != nil && media.GetCoverImageSafe() != "" {
+		feed.Icon = media.GetCoverImageSafe()
+	}
+
+	for _, chapter := range c.getDownloadedChapters(mediaId) {
+		href := BasePath + "/chapters/" + url.PathEscape(chapter.name)
+		entry := &Entry{
+			ID:    fmt.Sprintf("urn:seanime:opds:chapter:%d:%s:%s", mediaId, chapter.id.Provider, chapter.id.ChapterId),
+			Title: fmt.Sprintf("Chapter %s", chapter.id.ChapterNumber),
+			Content: &Content{
+				Type:  "text",
+				Value: fmt.Sprintf("%s - %s", title, chapter.id.Provider),
+			},
+		}
+		entry.Links = c.getChapterLinks(href, filepath.Join(c.downloadDir, chapter.name), media)
+		feed.AddEntry(entry)
+	}
+
+	// Add the local chapters if the series is matched with a directory of the internal storage
+	if mapping, found := c.database.GetMangaMapping(internalStorageProviderId, mediaId); found && mapping.MangaID != "" {
+		for _, entry := range c.getLocalChapterEntries(mapping.MangaID, media) {
+			feed.AddEntry(entry)
+		}
+	}
+
+	return feed, nil
+}
+
+// LocalFeed returns the navigation feed of the series in the internal storage.
+func (c *Catalog) LocalFeed() (*Feed, error) {
+	feed := NewFeed("urn:seanime:opds:local", "Local library", BasePath+"/local", MimeTypeNavigation)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath, Type: MimeTypeNavigation})
+
+	for _, series := range c.getLocalSeries() {
+		feed.AddEntry(newNavigationEntry("urn:seanime:opds:local:"+series, series, "", BasePath+"/local/"+url.PathEscape(series)))
+	}
+
+	return feed, nil
+}
+
+// LocalSeriesFeed returns the acquisition feed of the chapters of a series in the internal storage.
+func (c *Catalog) LocalSeriesFeed(series string) (*Feed, error) {
+	if _, err := c.resolveLocalPath(series); err != nil {
+		return nil, err
+	}
+
+	feed := NewFeed("urn:seanime:opds:local:"+series, series, BasePath+"/local/"+url.PathEscape(series), MimeTypeAcquisition)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath + "/local", Type: MimeTypeNavigation})
+
+	for _, entry := range c.getLocalChapterEntries(series, nil) {
+		feed.AddEntry(entry)
+	}
+
+	return feed, nil
+}
+
+// SearchFeed returns the navigation feed of the series matching the query.
+// It searches the titles of the AniList collection and the internal storage series.
+func (c *Catalog) SearchFeed(query string) (*Feed, error) {
+	feed := NewFeed("urn:seanime:opds:search", fmt.Sprintf("Search: %s", query), BasePath+"/search?q="+url.QueryEscape(query), MimeTypeNavigation)
+	feed.AddLink(&Link{Rel: RelUp, Href: BasePath, Type: MimeTypeNavigation})
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return feed, nil
+	}
+
+	downloadCounts := c.getDownloadCounts()
+	if collection, err := c.platform.GetMangaCollection(false); err == nil {
+		seen := make(map[int]struct{})
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				media := entry.GetMedia()
+				if _, found := seen[media.GetID()]; found || !matchesQuery(query, media.GetAllTitles()) {
+					continue
+				}
+				seen[media.GetID()] = struct{}{}
+				feed.AddEntry(newSeriesEntry(media, downloadCounts[media.GetID()]))
+			}
+		}
+	}
+
+	for _, series := range c.getLocalSeries() {
+		if matchesQuery(query, []*string{&series}) {
+			feed.AddEntry(newNavigationEntry("urn:seanime:opds:local:"+series, series, "Local library", BasePath+"/local/"+url.PathEscape(series)))
+		}
+	}
+
+	return feed, nil
+}
+
+// OpenSearch returns the OpenSearch description of the catalog.
+func (c *Catalog) OpenSearch() *OpenSearchDescription {
+	return &OpenSearchDescription{
+		Xmlns:          "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:      "Seanime",
+		Description:    "Search the manga library",
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		URL: OpenSearchURL{
+			Type:     MimeTypeNavigation,
+			Template: BasePath + "/search?q={searchTerms}",
+		},
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Files
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ResolveDownloadedChapter returns the path of a chapter in the download directory.
+func (c *Catalog) ResolveDownloadedChapter(name string) (string, error) {
+	name = filepath.Base(name)
+	info, err := os.Stat(filepath.Join(c.downloadDir, name))
+	if err != nil {
+		return "", ErrNotFound
+	}
+	if _, _, ok := chapter_downloader.ParseChapterEntryName(name, info.IsDir()); !ok {
+		return "", ErrNotFound
+	}
+	return filepath.Join(c.downloadDir, name), nil
+}
+
+// ResolveLocalChapter returns the path of a chapter archive in the internal storage.
+func (c *Catalog) ResolveLocalChapter(series string, file string) (string, error) {
+	if !strings.HasSuffix(strings.ToLower(file), chapter_downloader.ChapterArchiveExt) {
+		return "", ErrNotFound
+	}
+	seriesDir, err := c.resolveLocalPath(series)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(seriesDir, filepath.Base(file))
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrNotFound
+	}
+	return path, nil
+}
+
+// WriteChapterArchive writes a chapter as a CBZ archive.
+// Chapters stored as image folders are packed on the fly.
+func (c *Catalog) WriteChapterArchive(w io.Writer, path string) error {
+	if strings.HasSuffix(strings.ToLower(path), chapter_downloader.ChapterArchiveExt) {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	}
+
+	pages, err := chapter_downloader.GetChapterPages(path)
+	if err != nil {
+		return err
+	}
+	return manga_export.WriteCBZ(w, pages, nil)
+}
+
+// OpenPage opens a page of a chapter, index starts at 0.
+func (c *Catalog) OpenPage(path string, index int) (io.ReadCloser, string, error) {
+	pages, err := chapter_downloader.GetChapterPages(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if index < 0 || index >= len(pages) {
+		return nil, "", ErrNotFound
+	}
+	r, err := pages[index].Open()
+	if err != nil {
+		return nil, "", err
+	}
+	return r, pages[index].Filename, nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getChapterLinks returns the acquisition and page streaming links of a chapter.
+func (c *Catalog) getChapterLinks(href string, path string, media *anilist.BaseManga) []*Link {
+	links := []*Link{
+		{Rel: RelAcquisition, Href: href, Type: MimeTypeCBZ},
+	}
+	if pages, err := chapter_downloader.GetChapterPages(path); err == nil && len(pages) > 0 {
+		links = append(links, &Link{
+			Rel:      RelPageStream,
+			Href:     href + "/pages/{pageNumber}?width={maxWidth}",
+			Type:     "image/jpeg",
+			PseCount: len(pages),
+		})
+	}
+	if media != nil && media.GetCoverImageSafe() != "" {
+		links = append(links, &Link{Rel: RelImage, Href: media.GetCoverImageSafe(), Type: "image/jpeg"})
+		links = append(links, &Link{Rel: RelThumbnail, Href: media.GetCoverImageSafe(), Type: "image/jpeg"})
+	}
+	return links
+}
+
+func (c *Catalog) getLocalChapterEntries(series string, media *anilist.BaseManga) []*Entry {
+	seriesDir, err := c.resolveLocalPath(series)
+	if err != nil {
+		return nil
+	}
+	files, err := os.ReadDir(seriesDir)
+	if err != nil {
+		return nil
+	}
+
+	ret := make([]*Entry, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(strings.ToLower(file.Name()), chapter_downloader.ChapterArchiveExt) {
+			continue
+		}
+		href := BasePath + "/local/" + url.PathEscape(series) + "/" + url.PathEscape(file.Name())
+		ret = append(ret, &Entry{
+			ID:      "urn:seanime:opds:local:" + series + "/" + file.Name(),
+			Title:   strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())),
+			Content: &Content{Type: "text", Value: series + " - Local library"},
+			Links:   c.getChapterLinks(href, filepath.Join(seriesDir, file.Name()), media),
+		})
+	}
+	return ret
+}
+
+func (c *Catalog) getLocalSeries() []string {
+	baseDir := c.localDir()
+	if baseDir == "" {
+		return nil
+	}
+	files, err := os.ReadDir(baseDir)
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			ret = append(ret, file.Name())
+		}
+	}
+	return ret
+}
+
+// resolveLocalPath returns the path of a series directory in the internal storage.
+func (c *Catalog) resolveLocalPath(series string) (string, error) {
+	baseDir := c.localDir()
+	if baseDir == "" || series == "" || series != filepath.Base(series) || series == ".." {
+		return "", ErrNotFound
+	}
+	path := filepath.Join(baseDir, series)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return "", ErrNotFound
+	}
+	return path, nil
+}
+
+// getDownloadedChapters returns the chapters in the download directory ordered by chapter number.
+// All chapters are returned if mediaId is 0.
+func (c *Catalog) getDownloadedChapters(mediaId int) []*downloadedChapter {
+	files, err := os.ReadDir(c.downloadDir)
+	if err != nil {
+		return nil
+	}
+
+	ret := make([]*downloadedChapter, 0)
+	for _, file := range files {
+		id, _, ok := chapter_downloader.ParseChapterEntryName(file.Name(), file.IsDir())
+		if !ok || (mediaId != 0 && id.MediaId != mediaId) {
+			continue
+		}
+		ret = append(ret, &downloadedChapter{id: id, name: file.Name()})
+	}
+
+	slices.SortStableFunc(ret, func(a, b *downloadedChapter) int {
+		an, _ := strconv.ParseFloat(a.id.ChapterNumber, 64)
+		bn, _ := strconv.ParseFloat(b.id.ChapterNumber, 64)
+		if c := cmp.Compare(an, bn); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id.Provider, b.id.Provider)
+	})
+	return ret
+}
+
+// getDownloadCounts returns the number of downloaded chapters of each series.
+func (c *Catalog) getDownloadCounts() map[int]int {
+	ret := make(map[int]int)
+	for _, chapter := range c.getDownloadedChapters(0) {
+		ret[chapter.id.MediaId]++
+	}
+	return ret
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func getListStatus(slug string) (listStatus, bool) {
+	for _, ls := range listStatuses {
+		if ls.slug == slug {
+			return ls, true
+		}
+	}
+	return listStatus{}, false
+}
+
+func newNavigationEntry(id string, title string, content string, href string) *Entry {
+	entry := &Entry{
+		ID:    id,
+		Title: title,
+		Links: []*Link{{Rel: RelSubsection, Href: href, Type: MimeTypeNavigation}},
+	}
+	if content != "" {
+		entry.Content = &Content{Type: "text", Value: content}
+	}
+	return entry
+}
+
+func newSeriesEntry(media *anilist.BaseManga, downloadCount int) *Entry {
+	title := media.GetPreferredTitle()
+	if title == "" {
+		title = fmt.Sprintf("Manga %d", media.GetID())
+	}
+	entry := &Entry{
+		ID:      fmt.Sprintf("urn:seanime:opds:series:%d", media.GetID()),
+		Title:   title,
+		Content: &Content{Type: "text", Value: fmt.Sprintf("%d downloaded %s", downloadCount, util.Pluralize(downloadCount, "chapter", "chapters"))},
+		Links: []*Link{
+			{Rel: RelSubsection, Href: fmt.Sprintf("%s/series/%d", BasePath, media.GetID()), Type: MimeTypeAcquisition},
+		},
+	}
+	if cover := media.GetCoverImageSafe(); cover != "" {
+		entry.Links = append(entry.Links,
+			&Link{Rel: RelImage, Href: cover, Type: "image/jpeg"},
+			&Link{Rel: RelThumbnail, Href: cover, Type: "image/jpeg"},
+		)
+	}
+	return entry
+}
+
+func matchesQuery(query string, titles []*string) bool {
+	query = strings.ToLower(query)
+	for _, title := range titles {
+		if title == nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(*title), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package opds
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	chapter_downloader "seanime/internal/manga/downloader"
+	"seanime/internal/util"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCatalog(t *testing.T) (*Catalog, string, string) {
+	downloadDir := t.TempDir()
+	localDir := t.TempDir()
+
+	// Downloaded chapter stored as an image folder
+	dir := filepath.Join(downloadDir, chapter_downloader.FormatChapterDirName("comick", 101517, "abc", "13"))
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+	registry := chapter_downloader.Registry{
+		0: {Index: 0, Filename: "01.jpg", Width: 700, Height: 1000},
+		1: {Index: 1, Filename: "02.jpg", Width: 700, Height: 1000},
+	}
+	for _, page := range registry {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, page.Filename), []byte(page.Filename), 0644))
+	}
+	data, _ := json.Marshal(registry)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "registry.json"), data, 0644))
+
+	// Local chapter archive without a registry
+	seriesDir := filepath.Join(localDir, "Jujutsu Kaisen")
+	require.NoError(t, os.MkdirAll(seriesDir, os.ModePerm))
+	f, err := os.Create(filepath.Join(seriesDir, "Jujutsu Kaisen - Chapter 0001.cbz"))
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"001.png", "002.png", "003.png"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(name))
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	catalog := NewCatalog(&NewCatalogOptions{
+		Logger:      util.NewLogger(),
+		DownloadDir: downloadDir,
+		LocalDir:    func() string { return localDir },
+	})
+	return catalog, downloadDir, localDir
+}
+
+func TestCatalog_RootFeed(t *testing.T) {
+	catalog, _, _ := newTestCatalog(t)
+
+	data, err := catalog.RootFeed().Marshal()
+	require.NoError(t, err)
+
+	var feed Feed
+	require.NoError(t, xml.Unmarshal(data, &feed))
+	assert.Equal(t, "urn:seanime:opds:root", feed.ID)
+	// Lists, downloads and local library
+	assert.Len(t, feed.Entries, len(listStatuses)+2)
+	assert.Contains(t, string(data), `xmlns:pse="http://vaemendis.net/opds-pse/ns"`)
+}
+
+func TestCatalog_LocalSeriesFeed(t *testing.T) {
+	catalog, _, _ := newTestCatalog(t)
+
+	feed, err := catalog.LocalSeriesFeed("Jujutsu Kaisen")
+	require.NoError(t, err)
+	require.Len(t, feed.Entries, 1)
+
+	links := feed.Entries[0].Links
+	require.Len(t, links, 2)
+	assert.Equal(t, RelAcquisition, links[0].Rel)
+	assert.Equal(t, "/opds/local/Jujutsu%20Kaisen/Jujutsu%20Kaisen%20-%20Chapter%200001.cbz", links[0].Href)
+	assert.Equal(t, RelPageStream, links[1].Rel)
+	assert.Equal(t, 3, links[1].PseCount)
+
+	_, err = catalog.LocalSeriesFeed("..")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCatalog_Chapters(t *testing.T) {
+	catalog, _, _ := newTestCatalog(t)
+
+	name := chapter_downloader.FormatChapterDirName("comick", 101517, "abc", "13")
+	path, err := catalog.ResolveDownloadedChapter(name)
+	require.NoError(t, err)
+
+	_, err = catalog.ResolveDownloadedChapter("../something")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Image folders are packed on the fly
+	var buf bytes.Buffer
+	require.NoError(t, catalog.WriteChapterArchive(&buf, path))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	assert.Equal(t, "01.jpg", zr.File[0].Name)
+
+	r, filename, err := catalog.OpenPage(path, 1)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	assert.Equal(t, "02.jpg", filename)
+	assert.Equal(t, "02.jpg", string(data))
+
+	_, _, err = catalog.OpenPage(path, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Local archives
+	localPath, err := catalog.ResolveLocalChapter("Jujutsu Kaisen", "Jujutsu Kaisen - Chapter 0001.cbz")
+	require.NoError(t, err)
+	r, filename, err = catalog.OpenPage(localPath, 0)
+	require.NoError(t, err)
+	_ = r.Close()
+	assert.Equal(t, "001.png", filename)
+}
+
+func TestFeed_SetToken(t *testing.T) {
+	feed := NewFeed("id", "title", BasePath+"/search?q=a", MimeTypeNavigation)
+	feed.AddEntry(&Entry{Links: []*Link{
+		{Href: BasePath + "/chapters/a/pages/{pageNumber}?width={maxWidth}"},
+		{Href: "https://s4.anilist.co/cover.jpg"},
+	}})
+	feed.SetToken("abc")
+
+	assert.Equal(t, BasePath+"/search?q=a&token=abc", feed.Links[0].Href)
+	assert.True(t, strings.HasSuffix(feed.Entries[0].Links[0].Href, "{maxWidth}&token=abc"))
+	assert.Equal(t, "https://s4.anilist.co/cover.jpg", feed.Entries[0].Links[1].Href)
+}
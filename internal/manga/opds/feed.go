@@ -0,0 +1,180 @@
+package opds
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OPDS 1.2 catalog feeds are Atom feeds.
+// Navigation feeds link to other feeds, acquisition feeds list downloadable publications.
+//
+//	https://specs.opds.io/opds-1.2
+//	https://github.com/anansi-project/opds-pse (page streaming)
+
+const (
+	MimeTypeNavigation  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	MimeTypeAcquisition = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	MimeTypeOpenSearch  = "application/opensearchdescription+xml"
+	MimeTypeCBZ         = "application/vnd.comicbook+zip"
+
+	RelStart       = "start"
+	RelSelf        = "self"
+	RelUp          = "up"
+	RelSubsection  = "subsection"
+	RelSearch      = "search"
+	RelAcquisition = "http://opds-spec.org/acquisition"
+	RelImage       = "http://opds-spec.org/image"
+	RelThumbnail   = "http://opds-spec.org/image/thumbnail"
+	RelPageStream  = "http://vaemendis.net/opds-pse/stream"
+)
+
+type (
+	Feed struct {
+		XMLName         xml.Name `xml:"feed"`
+		Xmlns           string   `xml:"xmlns,attr"`
+		XmlnsOpds       string   `xml:"xmlns:opds,attr"`
+		XmlnsPse        string   `xml:"xmlns:pse,attr"`
+		XmlnsOpenSearch string   `xml:"xmlns:opensearch,attr"`
+		XmlnsDc         string   `xml:"xmlns:dc,attr"`
+		ID              string   `xml:"id"`
+		Title           string   `xml:"title"`
+		Updated         string   `xml:"updated"`
+		Icon            string   `xml:"icon,omitempty"`
+		Author          *Author  `xml:"author,omitempty"`
+		Links           []*Link  `xml:"link"`
+		Entries         []*Entry `xml:"entry"`
+	}
+
+	Author struct {
+		Name string `xml:"name"`
+		URI  string `xml:"uri,omitempty"`
+	}
+
+	Link struct {
+		Rel   string `xml:"rel,attr,omitempty"`
+		Href  string `xml:"href,attr"`
+		Type  string `xml:"type,attr,omitempty"`
+		Title string `xml:"title,attr,omitempty"`
+		// PseCount is the number of pages of a streamable publication
+		PseCount int `xml:"pse:count,attr,omitempty"`
+	}
+
+	Entry struct {
+		ID       string   `xml:"id"`
+		Title    string   `xml:"title"`
+		Updated  string   `xml:"updated"`
+		Authors  []Author `xml:"author,omitempty"`
+		Language string   `xml:"dc:language,omitempty"`
+		Content  *Content `xml:"content,omitempty"`
+		Links    []*Link  `xml:"link"`
+	}
+
+	Content struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	// OpenSearchDescription describes the search endpoint of the catalog.
+	//	https://github.com/dewitt/opensearch/blob/master/opensearch-1-1-draft-6.md
+	OpenSearchDescription struct {
+		XMLName        xml.Name      `xml:"OpenSearchDescription"`
+		Xmlns          string        `xml:"xmlns,attr"`
+		ShortName      string        `xml:"ShortName"`
+		Description    string        `xml:"Description"`
+		InputEncoding  string        `xml:"InputEncoding"`
+		OutputEncoding string        `xml:"OutputEncoding"`
+		URL            OpenSearchURL `xml:"Url"`
+	}
+
+	OpenSearchURL struct {
+		Type     string `xml:"type,attr"`
+		Template string `xml:"template,attr"`
+	}
+)
+
+// NewFeed creates a feed with the namespaces and the navigation links shared by all feeds.
+func NewFeed(id string, title string, self string, kind string) *Feed {
+	return &Feed{
+		Xmlns:           "http://www.w3.org/2005/Atom",
+		XmlnsOpds:       "http://opds-spec.org/2010/catalog",
+		XmlnsPse:        "http://vaemendis.net/opds-pse/ns",
+		XmlnsOpenSearch: "http://a9.com/-/spec/opensearch/1.1/",
+		XmlnsDc:         "http://purl.org/dc/terms/",
+		ID:              id,
+		Title:           title,
+		Updated:         formatTime(time.Now()),
+		Author:          &Author{Name: "Seanime", URI: "https://seanime.rahim.app"},
+		Links: []*Link{
+			{Rel: RelSelf, Href: self, Type: kind},
+			{Rel: RelStart, Href: BasePath, Type: MimeTypeNavigation},
+			{Rel: RelSearch, Href: BasePath + "/opensearch.xml", Type: MimeTypeOpenSearch},
+		},
+		Entries: make([]*Entry, 0),
+	}
+}
+
+// AddLink adds a link to the feed.
+func (f *Feed) AddLink(link *Link) {
+	f.Links = append(f.Links, link)
+}
+
+// AddEntry adds an entry to the feed.
+func (f *Feed) AddEntry(entry *Entry) {
+	if entry.Updated == "" {
+		entry.Updated = f.Updated
+	}
+	f.Entries = append(f.Entries, entry)
+}
+
+// Marshal encodes the feed with the XML header.
+func (f *Feed) Marshal() ([]byte, error) {
+	return marshalXML(f)
+}
+
+// Marshal encodes the description with the XML header.
+func (d *OpenSearchDescription) Marshal() ([]byte, error) {
+	return marshalXML(d)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// SetToken adds the token to the links of the catalog, for clients that don't support authentication.
+func (f *Feed) SetToken(token string) {
+	for _, link := range f.Links {
+		link.Href = withToken(link.Href, token)
+	}
+	for _, entry := range f.Entries {
+		for _, link := range entry.Links {
+			link.Href = withToken(link.Href, token)
+		}
+	}
+}
+
+// SetToken adds the token to the search URL template.
+func (d *OpenSearchDescription) SetToken(token string) {
+	d.URL.Template = withToken(d.URL.Template, token)
+}
+
+// withToken adds the token query parameter to the links of the catalog, other links are unchanged.
+func withToken(href string, token string) string {
+	if !strings.HasPrefix(href, BasePath) {
+		return href
+	}
+	sep := "?"
+	if strings.Contains(href, "?") {
+		sep = "&"
+	}
+	return href + sep + "token=" + url.QueryEscape(token)
+}
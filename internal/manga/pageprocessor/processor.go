@@ -0,0 +1,371 @@
+package manga_pageprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"seanime/internal/database/models"
+	"sync"
+
+	_ "golang.org/x/image/bmp" // Register BMP format
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff" // Register Tiff format
+	_ "golang.org/x/image/webp" // Register WebP format, pages can only be decoded from WebP
+)
+
+// Format is the image format pages are converted to.
+// JPEG is the only lossy format since there is no maintained pure Go WebP encoder, WebP pages are only decoded.
+type Format string
+
+const (
+	FormatOriginal Format = ""     // Keep the original format when possible
+	FormatJPEG     Format = "jpeg" // Lossy, uses the quality setting
+	FormatPNG      Format = "png"  // Lossless, used when the original format cannot be re-encoded
+)
+
+const (
+	defaultQuality = 85
+	// whiteThreshold is the minimum luminance of a pixel considered white when trimming borders
+	whiteThreshold = 235
+	// maxContentRatio is the maximum ratio of non-white pixels in a row or column considered part of a border.
+	// This ignores specks left by scanners.
+	maxContentRatio = 0.005
+	// spreadRatio is the minimum width/height ratio of a page considered a double-page spread
+	spreadRatio = 1.1
+)
+
+type (
+	// Processor transforms manga pages after they are fetched.
+	// It is used by the chapter downloader and the image proxy.
+	Processor struct {
+		options Options
+		mu      sync.RWMutex
+	}
+
+	Options struct {
+		Enabled bool
+		Format  Format
+		// Quality of JPEG images, 1-100
+		Quality int
+		// MaxWidth is the maximum width of a page in pixels, 0 to disable
+		MaxWidth int
+		// SplitDoublePages splits landscape pages in two
+		SplitDoublePages bool
+		// SplitLeftToRight puts the left half first when splitting pages.
+		// Manga are read right to left so the right half comes first by default.
+		SplitLeftToRight bool
+		// TrimBorders removes white borders
+		TrimBorders bool
+	}
+
+	// Page is a processed page.
+	Page struct {
+		Data   []byte
+		Format Format
+		Width  int
+		Height int
+	}
+)
+
+func NewProcessor() *Processor {
+	return &Processor{}
+}
+
+// SetSettings should be called after the settings are fetched and updated from the database.
+func (p *Processor) SetSettings(settings *models.MangaSettings) {
+	if p == nil || settings == nil {
+		return
+	}
+
+	p.SetOptions(Options{
+		Enabled:          settings.PageProcessingEnabled,
+		Format:           Format(settings.PageFormat),
+		Quality:          settings.PageQuality,
+		MaxWidth:         settings.PageMaxWidth,
+		SplitDoublePages: settings.PageSplitDoublePages,
+		SplitLeftToRight: settings.PageSplitLeftToRight,
+		TrimBorders:      settings.PageTrimBorders,
+	})
+}
+
+func (p *Processor) SetOptions(options Options) {
+	switch options.Format {
+	case FormatJPEG:
+	case "webp":
+		// WebP output is no longer supported, use the lossy format for existing settings
+		options.Format = FormatJPEG
+	default:
+		options.Format = FormatOriginal
+	}
+	if options.Quality <= 0 || options.Quality > 100 {
+		options.Quality = defaultQuality
+	}
+	options.MaxWidth = max(options.MaxWidth, 0)
+
+	p.mu.Lock()
+	p.options = options
+	p.mu.Unlock()
+}
+
+func (p *Processor) getOptions() Options {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.options
+}
+
+// IsEnabled returns true if pages should be processed.
+func (p *Processor) IsEnabled() bool {
+	if p == nil {
+		return false
+	}
+	return p.getOptions().Enabled
+}
+
+// GetCacheKey returns a key identifying the current options, or an empty string if processing is disabled.
+// Pages processed with the same key are identical.
+func (p *Processor) GetCacheKey() string {
+	if p == nil {
+		return ""
+	}
+	options := p.getOptions()
+	if !options.Enabled {
+		return ""
+	}
+	return fmt.Sprintf("%+v", options)
+}
+
+// Process transforms a page, it returns two pages when a double-page spread is split.
+// If processing is disabled or nothing needs to be changed, the original image is returned.
+func (p *Processor) Process(data []byte) ([]*Page, error) {
+	if p == nil {
+		return originalPage(data)
+	}
+	return p.process(data, p.getOptions())
+}
+
+// ProcessSingle transforms a page without splitting it.
+// Used when a page must map to a single image, e.g. by the image proxy.
+func (p *Processor) ProcessSingle(data []byte) (*Page, error) {
+	if p == nil {
+		pages, err := originalPage(data)
+		if err != nil {
+			return nil, err
+		}
+		return pages[0], nil
+	}
+	options := p.getOptions()
+	options.SplitDoublePages = false
+	pages, err := p.process(data, options)
+	if err != nil {
+		return nil, err
+	}
+	return pages[0], nil
+}
+
+func (p *Processor) process(data []byte, options Options) ([]*Page, error) {
+	if !options.Enabled {
+		return originalPage(data)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	modified := false
+	bounds := img.Bounds()
+
+	if options.TrimBorders {
+		if trimmed := trimBorders(img); trimmed != bounds {
+			bounds = trimmed
+			modified = true
+		}
+	}
+
+	parts := []image.Rectangle{bounds}
+	if options.SplitDoublePages && float64(bounds.Dx()) >= float64(bounds.Dy())*spreadRatio {
+		parts = splitSpread(bounds, options.SplitLeftToRight)
+		modified = true
+	}
+
+	if options.MaxWidth > 0 {
+		for _, r := range parts {
+			if r.Dx() > options.MaxWidth {
+				modified = true
+			}
+		}
+	}
+
+	outputFormat := options.Format
+	if outputFormat == FormatOriginal {
+		if !modified {
+			return originalPage(data)
+		}
+		switch format {
+		case "jpeg", "webp":
+			outputFormat = FormatJPEG
+		default:
+			outputFormat = FormatPNG
+		}
+	}
+
+	ret := make([]*Page, 0, len(parts))
+	for _, r := range parts {
+		var part image.Image = subImage(img, r)
+		if options.MaxWidth > 0 && r.Dx() > options.MaxWidth {
+			part = resize(part, options.MaxWidth)
+		}
+		page, err := encode(part, outputFormat, options.Quality)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, page)
+	}
+
+	return ret, nil
+}
+
+// originalPage returns the page as is.
+func originalPage(data []byte) ([]*Page, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return []*Page{{
+		Data:   data,
+		Format: Format(format),
+		Width:  config.Width,
+		Height: config.Height,
+	}}, nil
+}
+
+func encode(img image.Image, format Format, quality int) (*Page, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	default:
+		format = FormatPNG
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Page{
+		Data:   buf.Bytes(),
+		Format: format,
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}, nil
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == "" {
+		return "application/octet-stream"
+	}
+	return "image/" + string(f)
+}
+
+//----------------------------------------------------------------------------------------------------------------------
+
+// trimBorders returns the bounds of the image without its white borders.
+// Blank pages are left untouched.
+func trimBorders(img image.Image) image.Rectangle {
+	b := img.Bounds()
+
+	isWhite := func(x, y int) bool {
+		return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y >= whiteThreshold
+	}
+	isBorderRow := func(y int) bool {
+		maxContent := int(math.Ceil(float64(b.Dx()) * maxContentRatio))
+		content := 0
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !isWhite(x, y) {
+				content++
+				if content > maxContent {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	isBorderColumn := func(x int, minY, maxY int) bool {
+		maxContent := int(math.Ceil(float64(maxY-minY) * maxContentRatio))
+		content := 0
+		for y := minY; y < maxY; y++ {
+			if !isWhite(x, y) {
+				content++
+				if content > maxContent {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	top := b.Min.Y
+	for top < b.Max.Y && isBorderRow(top) {
+		top++
+	}
+	if top == b.Max.Y {
+		return b
+	}
+	bottom := b.Max.Y
+	for bottom > top && isBorderRow(bottom-1) {
+		bottom--
+	}
+	left := b.Min.X
+	for left < b.Max.X && isBorderColumn(left, top, bottom) {
+		left++
+	}
+	right := b.Max.X
+	for right > left && isBorderColumn(right-1, top, bottom) {
+		right--
+	}
+	if left >= right {
+		return b
+	}
+
+	return image.Rect(left, top, right, bottom)
+}
+
+// splitSpread splits a double-page spread in two, in reading order.
+func splitSpread(r image.Rectangle, leftToRight bool) []image.Rectangle {
+	mid := r.Min.X + r.Dx()/2
+	left := image.Rect(r.Min.X, r.Min.Y, mid, r.Max.Y)
+	right := image.Rect(mid, r.Min.Y, r.Max.X, r.Max.Y)
+	if leftToRight {
+		return []image.Rectangle{left, right}
+	}
+	return []image.Rectangle{right, left}
+}
+
+func subImage(img image.Image, r image.Rectangle) image.Image {
+	if r == img.Bounds() {
+		return img
+	}
+	if s, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return s.SubImage(r)
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, r.Min, draw.Src)
+	return dst
+}
+
+// resize scales the image down to the width, keeping its aspect ratio.
+func resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	height := max(b.Dy()*width/b.Dx(), 1)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
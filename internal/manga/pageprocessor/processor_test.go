@@ -0,0 +1,126 @@
+package manga_pageprocessor
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPage returns a white page with a black rectangle, and a red rectangle in the right half.
+func testPage(width, height int, content image.Rectangle) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if image.Pt(x, y).In(content) {
+				c = color.NRGBA{A: 255}
+				if x >= width/2 {
+					c.R = 255
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcessor_Process(t *testing.T) {
+	spread := encodePNG(t, testPage(200, 100, image.Rect(20, 10, 180, 90)))
+
+	t.Run("disabled", func(t *testing.T) {
+		p := NewProcessor()
+		pages, err := p.Process(spread)
+		require.NoError(t, err)
+		require.Len(t, pages, 1)
+		assert.Equal(t, spread, pages[0].Data)
+		assert.Equal(t, Format("png"), pages[0].Format)
+		assert.Equal(t, 200, pages[0].Width)
+	})
+
+	t.Run("nothing to change", func(t *testing.T) {
+		p := NewProcessor()
+		p.SetOptions(Options{Enabled: true, MaxWidth: 500})
+		pages, err := p.Process(spread)
+		require.NoError(t, err)
+		require.Len(t, pages, 1)
+		assert.Equal(t, spread, pages[0].Data)
+	})
+
+	t.Run("trim and split", func(t *testing.T) {
+		p := NewProcessor()
+		p.SetOptions(Options{Enabled: true, TrimBorders: true, SplitDoublePages: true})
+		pages, err := p.Process(spread)
+		require.NoError(t, err)
+		require.Len(t, pages, 2)
+
+		for _, page := range pages {
+			assert.Equal(t, FormatPNG, page.Format)
+			assert.Equal(t, 80, page.Width)
+			assert.Equal(t, 80, page.Height)
+		}
+
+		// Right half first
+		first, err := png.Decode(bytes.NewReader(pages[0].Data))
+		require.NoError(t, err)
+		assert.Equal(t, uint8(255), color.NRGBAModel.Convert(first.At(0, 0)).(color.NRGBA).R)
+	})
+
+	t.Run("webp setting uses jpeg", func(t *testing.T) {
+		p := NewProcessor()
+		p.SetOptions(Options{Enabled: true, Format: "webp", TrimBorders: true})
+		pages, err := p.Process(spread)
+		require.NoError(t, err)
+		require.Len(t, pages, 1)
+		assert.Equal(t, FormatJPEG, pages[0].Format)
+	})
+
+	t.Run("split left to right and resize", func(t *testing.T) {
+		p := NewProcessor()
+		p.SetOptions(Options{Enabled: true, Format: FormatJPEG, Quality: 90, SplitDoublePages: true, SplitLeftToRight: true, MaxWidth: 50})
+		pages, err := p.Process(spread)
+		require.NoError(t, err)
+		require.Len(t, pages, 2)
+
+		assert.Equal(t, FormatJPEG, pages[0].Format)
+		assert.Equal(t, 50, pages[0].Width)
+		assert.Equal(t, 50, pages[0].Height)
+
+		first, _, err := image.Decode(bytes.NewReader(pages[0].Data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 50, 50), first.Bounds())
+		// Left half first, black content
+		r, _, _, _ := first.At(25, 25).RGBA()
+		assert.Less(t, r>>8, uint32(100))
+	})
+
+	t.Run("single page is not split", func(t *testing.T) {
+		p := NewProcessor()
+		p.SetOptions(Options{Enabled: true, SplitDoublePages: true})
+		page, err := p.ProcessSingle(spread)
+		require.NoError(t, err)
+		assert.Equal(t, 200, page.Width)
+		assert.Equal(t, "image/png", page.Format.ContentType())
+	})
+}
+
+func TestTrimBorders(t *testing.T) {
+	img := testPage(100, 100, image.Rect(10, 20, 90, 70))
+	// A speck in the border should be ignored
+	img.SetNRGBA(95, 5, color.NRGBA{A: 255})
+	assert.Equal(t, image.Rect(10, 20, 90, 70), trimBorders(img))
+
+	// Blank pages are not trimmed
+	blank := testPage(50, 50, image.Rectangle{})
+	assert.Equal(t, blank.Bounds(), trimBorders(blank))
+}
@@ -0,0 +1,198 @@
+package manga
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+	hibikemanga "seanime/internal/extension/hibike/manga"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// GetProviderChain returns the providers to fall back to for a manga, in order.
+// It returns an empty slice if no chain is set.
+func (r *Repository) GetProviderChain(mediaId int) []string {
+	chain, found := r.db.GetMangaProviderChain(mediaId)
+	if !found {
+		return []string{}
+	}
+	return chain.GetProviders()
+}
+
+// SetProviderChain sets the providers to fall back to for a manga, in order.
+// An empty chain removes the fallback.
+func (r *Repository) SetProviderChain(mediaId int, providers []string) error {
+	providers = lo.Uniq(lo.Filter(providers, func(p string, _ int) bool {
+		return p != "" && !strings.Contains(p, ",")
+	}))
+
+	if len(providers) == 0 {
+		return r.db.DeleteMangaProviderChain(mediaId)
+	}
+
+	return r.db.UpsertMangaProviderChain(&models.MangaProviderChain{
+		MediaID:   mediaId,
+		Providers: strings.Join(providers, ","),
+	})
+}
+
+// getFallbackProviders returns the providers to try, starting with the requested one, followed by the chain.
+// Unhealthy providers are moved to the end so that they're only tried when the others fail.
+func (r *Repository) getFallbackProviders(requested string, chain []string) []string {
+	providers := lo.Uniq(append([]string{requested}, chain...))
+	providers = lo.Filter(providers, func(p string, _ int) bool { return p != "" })
+
+	healthy := make([]string, 0, len(providers))
+	unhealthy := make([]string, 0)
+	for _, p := range providers {
+		if r.providerHealth.IsHealthy(p) {
+			healthy = append(healthy, p)
+		} else {
+			unhealthy = append(unhealthy, p)
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		r.logger.Debug().Strs("providers", unhealthy).Msg("manga: Skipping unhealthy providers")
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// GetMangaChapterContainerWithFallback returns the ChapterContainer of the requested provider,
+// falling back to the providers of the manga's provider chain if it fails or is missing chapters.
+//
+// A provider is missing chapters if its latest chapter is lower than expectedChapters (0 if unknown).
+// If all providers are missing chapters, the container with the most recent chapter is returned.
+func (r *Repository) GetMangaChapterContainerWithFallback(opts *GetMangaChapterContainerOptions, expectedChapters int) (*ChapterContainer, error) {
+	chain := r.GetProviderChain(opts.MediaId)
+	if len(chain) == 0 {
+		return r.GetMangaChapterContainer(opts)
+	}
+
+	var best *ChapterContainer
+	var lastErr error
+	for _, provider := range r.getFallbackProviders(opts.Provider, chain) {
+		providerOpts := *opts
+		providerOpts.Provider = provider
+
+		container, err := r.GetMangaChapterContainer(&providerOpts)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("provider", provider).Int("mediaId", opts.MediaId).Msg("manga: Provider failed, falling back")
+			lastErr = err
+			continue
+		}
+		if container == nil || len(container.Chapters) == 0 {
+			r.logger.Warn().Str("provider", provider).Int("mediaId", opts.MediaId).Msg("manga: No chapters found, falling back")
+			continue
+		}
+
+		latest := getLatestChapterNumber(container.Chapters)
+		if expectedChapters <= 0 || latest >= float64(expectedChapters) {
+			return container, nil
+		}
+
+		r.logger.Debug().Str("provider", provider).Float64("latest", latest).Int("expected", expectedChapters).Msg("manga: Provider is missing chapters, falling back")
+		if best == nil || latest > getLatestChapterNumber(best.Chapters) {
+			best = container
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrNoChapters
+}
+
+type GetMangaPageContainerWithFallbackOptions struct {
+	Provider   string
+	MediaId    int
+	ChapterId  string
+	DoublePage bool
+	IsOffline  bool
+	// Titles and Year are used to find the chapters of the fallback providers
+	Titles []*string
+	Year   int
+}
+
+// GetMangaPageContainerWithFallback returns the PageContainer of a chapter.
+// If the provider fails, the pages of the chapter with the same number are fetched from the
+// providers of the manga's provider chain.
+// The returned container has the provider and chapter ID of the provider that was used.
+func (r *Repository) GetMangaPageContainerWithFallback(opts *GetMangaPageContainerWithFallbackOptions) (*PageContainer, error) {
+	ret, err := r.GetMangaPageContainer(opts.Provider, opts.MediaId, opts.ChapterId, opts.DoublePage, opts.IsOffline)
+	if err == nil || opts.IsOffline {
+		return ret, err
+	}
+
+	chain := r.GetProviderChain(opts.MediaId)
+	if len(chain) == 0 {
+		return nil, err
+	}
+
+	// Find the number of the chapter
+	container, found := r.getChapterContainerFromFilecache(opts.Provider, opts.MediaId)
+	if !found {
+		return nil, err
+	}
+	chapter, found := lo.Find(container.Chapters, func(c *hibikemanga.ChapterDetails) bool {
+		return c.ID == opts.ChapterId
+	})
+	if !found {
+		return nil, err
+	}
+	chapterNumber, parseErr := strconv.ParseFloat(chapter.Chapter, 64)
+	if parseErr != nil {
+		return nil, err
+	}
+
+	originalErr := err
+	for _, provider := range r.getFallbackProviders(opts.Provider, chain) {
+		if provider == opts.Provider {
+			continue
+		}
+
+		fallbackContainer, err := r.GetMangaChapterContainer(&GetMangaChapterContainerOptions{
+			Provider: provider,
+			MediaId:  opts.MediaId,
+			Titles:   opts.Titles,
+			Year:     opts.Year,
+		})
+		if err != nil {
+			continue
+		}
+
+		fallbackChapter, found := lo.Find(fallbackContainer.Chapters, func(c *hibikemanga.ChapterDetails) bool {
+			n, err := strconv.ParseFloat(c.Chapter, 64)
+			return err == nil && n == chapterNumber
+		})
+		if !found {
+			continue
+		}
+
+		ret, err = r.GetMangaPageContainer(provider, opts.MediaId, fallbackChapter.ID, opts.DoublePage, opts.IsOffline)
+		if err != nil {
+			continue
+		}
+
+		r.logger.Info().Str("provider", provider).Str("chapter", chapter.Chapter).Msg("manga: Using fallback provider for pages")
+		return ret, nil
+	}
+
+	return nil, errors.Join(originalErr, errors.New("manga: All fallback providers failed"))
+}
+
+// getLatestChapterNumber returns the highest chapter number of the chapters.
+func getLatestChapterNumber(chapters []*hibikemanga.ChapterDetails) float64 {
+	ret := 0.0
+	for _, c := range chapters {
+		if n, err := strconv.ParseFloat(c.Chapter, 64); err == nil && n > ret {
+			ret = n
+		}
+	}
+	return ret
+}
@@ -0,0 +1,168 @@
+package manga
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// providerHealthWindow is the number of recent requests used to compute the success rate of a provider
+	providerHealthWindow = 20
+	// providerHealthMinRequests is the number of requests needed before a provider can be considered unhealthy
+	providerHealthMinRequests = 3
+	// providerHealthMinSuccessRate is the success rate under which a provider is considered unhealthy
+	providerHealthMinSuccessRate = 0.5
+	// providerHealthRetryAfter is the delay after which an unhealthy provider is tried again
+	providerHealthRetryAfter = 10 * time.Minute
+)
+
+type (
+	// ProviderHealthTracker records the outcome of requests made to manga providers.
+	// Stats are kept in memory.
+	ProviderHealthTracker struct {
+		mu        sync.RWMutex
+		providers map[string]*providerHealthRecord
+		now       func() time.Time
+	}
+
+	providerHealthRecord struct {
+		// results of the most recent requests, true if successful
+		results       []bool
+		latencies     []time.Duration
+		totalRequests int
+		totalFailures int
+		lastError     string
+		lastErrorAt   *time.Time
+		lastSuccessAt *time.Time
+	}
+
+	// ProviderHealth is the health of a manga provider.
+	ProviderHealth struct {
+		Provider string `json:"provider"`
+		// Requests is the total number of requests made to the provider since the server started
+		Requests int `json:"requests"`
+		Failures int `json:"failures"`
+		// SuccessRate is the success rate of the most recent requests, between 0 and 1
+		SuccessRate float64 `json:"successRate"`
+		// AverageLatency is the average latency of the most recent requests, in milliseconds
+		AverageLatency int64      `json:"averageLatency"`
+		LastError      string     `json:"lastError"`
+		LastErrorAt    *time.Time `json:"lastErrorAt"`
+		LastSuccessAt  *time.Time `json:"lastSuccessAt"`
+		Healthy        bool       `json:"healthy"`
+	}
+)
+
+func NewProviderHealthTracker() *ProviderHealthTracker {
+	return &ProviderHealthTracker{
+		providers: make(map[string]*providerHealthRecord),
+		now:       time.Now,
+	}
+}
+
+// Record records the outcome of a request made to a provider.
+func (t *ProviderHealthTracker) Record(provider string, latency time.Duration, err error) {
+	if t == nil || provider == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.providers[provider]
+	if !ok {
+		record = &providerHealthRecord{}
+		t.providers[provider] = record
+	}
+
+	now := t.now()
+	record.totalRequests++
+	record.results = append(record.results, err == nil)
+	record.latencies = append(record.latencies, latency)
+	if len(record.results) > providerHealthWindow {
+		record.results = record.results[1:]
+		record.latencies = record.latencies[1:]
+	}
+	if err != nil {
+		record.totalFailures++
+		record.lastError = err.Error()
+		record.lastErrorAt = &now
+	} else {
+		record.lastSuccessAt = &now
+	}
+}
+
+// IsHealthy returns false if most recent requests to the provider failed.
+// Unhealthy providers are considered healthy again after some time so that they can recover.
+func (t *ProviderHealthTracker) IsHealthy(provider string) bool {
+	if t == nil {
+		return true
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	record, ok := t.providers[provider]
+	if !ok {
+		return true
+	}
+	return t.isHealthy(record)
+}
+
+func (t *ProviderHealthTracker) isHealthy(record *providerHealthRecord) bool {
+	if len(record.results) < providerHealthMinRequests || record.successRate() >= providerHealthMinSuccessRate {
+		return true
+	}
+	return record.lastErrorAt == nil || t.now().Sub(*record.lastErrorAt) > providerHealthRetryAfter
+}
+
+// GetAll returns the health of all providers that have been used, sorted by provider.
+func (t *ProviderHealthTracker) GetAll() []*ProviderHealth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	ret := make([]*ProviderHealth, 0, len(t.providers))
+	for provider, record := range t.providers {
+		ret = append(ret, &ProviderHealth{
+			Provider:       provider,
+			Requests:       record.totalRequests,
+			Failures:       record.totalFailures,
+			SuccessRate:    record.successRate(),
+			AverageLatency: record.averageLatency().Milliseconds(),
+			LastError:      record.lastError,
+			LastErrorAt:    record.lastErrorAt,
+			LastSuccessAt:  record.lastSuccessAt,
+			Healthy:        t.isHealthy(record),
+		})
+	}
+	slices.SortFunc(ret, func(a, b *ProviderHealth) int {
+		return strings.Compare(a.Provider, b.Provider)
+	})
+	return ret
+}
+
+func (r *providerHealthRecord) successRate() float64 {
+	if len(r.results) == 0 {
+		return 1
+	}
+	successes := 0
+	for _, ok := range r.results {
+		if ok {
+			successes++
+		}
+	}
+	return float64(successes) / float64(len(r.results))
+}
+
+func (r *providerHealthRecord) averageLatency() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, l := range r.latencies {
+		total += l
+	}
+	return total / time.Duration(len(r.latencies))
+}
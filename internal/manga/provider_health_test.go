@@ -0,0 +1,71 @@
+package manga
+
+import (
+	"errors"
+	"seanime/internal/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderHealthTracker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewProviderHealthTracker()
+	tracker.now = func() time.Time { return now }
+
+	errFailed := errors.New("failed")
+
+	// Unknown providers are healthy
+	assert.True(t, tracker.IsHealthy("mangadex"))
+
+	// Not enough requests to be considered unhealthy
+	tracker.Record("mangadex", 100*time.Millisecond, errFailed)
+	tracker.Record("mangadex", 300*time.Millisecond, errFailed)
+	assert.True(t, tracker.IsHealthy("mangadex"))
+
+	tracker.Record("mangadex", 200*time.Millisecond, errFailed)
+	assert.False(t, tracker.IsHealthy("mangadex"))
+
+	tracker.Record("comick", 50*time.Millisecond, nil)
+	tracker.Record("comick", 50*time.Millisecond, errFailed)
+	tracker.Record("comick", 50*time.Millisecond, nil)
+	assert.True(t, tracker.IsHealthy("comick"))
+
+	all := tracker.GetAll()
+	require.Len(t, all, 2)
+	assert.Equal(t, "comick", all[0].Provider)
+	assert.InDelta(t, 2.0/3.0, all[0].SuccessRate, 0.001)
+	assert.Equal(t, "mangadex", all[1].Provider)
+	assert.Equal(t, 3, all[1].Failures)
+	assert.Equal(t, int64(200), all[1].AverageLatency)
+	assert.Equal(t, "failed", all[1].LastError)
+	assert.False(t, all[1].Healthy)
+
+	// Unhealthy providers are tried again after some time
+	now = now.Add(providerHealthRetryAfter + time.Second)
+	assert.True(t, tracker.IsHealthy("mangadex"))
+
+	// Old requests are dropped from the window
+	for i := 0; i < providerHealthWindow; i++ {
+		tracker.Record("mangadex", 0, nil)
+	}
+	all = tracker.GetAll()
+	assert.Equal(t, 1.0, all[1].SuccessRate)
+	assert.Equal(t, providerHealthWindow+3, all[1].Requests)
+}
+
+func TestGetFallbackProviders(t *testing.T) {
+	r := &Repository{
+		logger:         util.NewLogger(),
+		providerHealth: NewProviderHealthTracker(),
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, r.getFallbackProviders("a", []string{"b", "a", "c", ""}))
+
+	for i := 0; i < providerHealthMinRequests; i++ {
+		r.providerHealth.Record("a", 0, errors.New("failed"))
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, r.getFallbackProviders("a", []string{"b", "c"}))
+}
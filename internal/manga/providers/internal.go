@@ -155,6 +155,11 @@ func NewInternalStorage(ctx context.Context, logger *zerolog.Logger, baseDir str
 	return provider
 }
 
+// BaseDir returns the directory scanned by the provider
+func (p *InternalStorageProvider) BaseDir() string {
+	return p.baseDir
+}
+
 // BuildCacheSync builds the manga cache synchronously (for startup)
 func (p *InternalStorageProvider) BuildCacheSync() {
 	dirs, err := os.ReadDir(p.baseDir)
@@ -0,0 +1,257 @@
+package manga_readingstate
+
+import (
+	"errors"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const MaxContinueReadingItems = 20
+
+type (
+	// Manager stores the page-level reading state of manga chapters for each user.
+	// Unlike the AniList progress, it keeps track of the last page read and of chapters read out of order.
+	Manager struct {
+		logger   *zerolog.Logger
+		database *db.Database
+		mu       sync.Mutex
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+	}
+
+	// MediaReadingState is the reading state of all the chapters of a media.
+	MediaReadingState struct {
+		MediaId int `json:"mediaId"`
+		// Chapters are the reading states of the chapters, most recently updated first
+		Chapters []*models.MangaReadingState `json:"chapters"`
+		// Resume is the chapter to resume reading from, nil if the last chapter opened was finished
+		Resume *models.MangaReadingState `json:"resume,omitempty"`
+		// HighestReadChapter is the highest chapter number marked as read, 0 if none
+		HighestReadChapter float64 `json:"highestReadChapter"`
+	}
+
+	// ContinueReadingItem is a chapter that was left unfinished.
+	ContinueReadingItem struct {
+		State *models.MangaReadingState `json:"state"`
+		Media *anilist.BaseManga        `json:"media,omitempty"`
+	}
+
+	UpdatePageOptions struct {
+		MediaId       int    `json:"mediaId"`
+		Provider      string `json:"provider"`
+		ChapterId     string `json:"chapterId"`
+		ChapterNumber string `json:"chapterNumber"`
+		// Index of the current page, starting from 0
+		CurrentPage int `json:"currentPage"`
+		TotalPages  int `json:"totalPages"`
+	}
+
+	MarkChaptersOptions struct {
+		MediaId  int                   `json:"mediaId"`
+		Provider string                `json:"provider"`
+		Chapters []*MarkChapterOptions `json:"chapters"`
+		Read     bool                  `json:"read"`
+	}
+
+	MarkChapterOptions struct {
+		ChapterId     string `json:"chapterId"`
+		ChapterNumber string `json:"chapterNumber"`
+	}
+)
+
+var ErrInvalidChapter = errors.New("manga reading state: Invalid chapter")
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	return &Manager{
+		logger:   opts.Logger,
+		database: opts.Database,
+	}
+}
+
+// UpdatePage saves the current page of a chapter.
+// The chapter is marked as read when the last page is reached.
+func (m *Manager) UpdatePage(username string, opts *UpdatePageOptions) (*models.MangaReadingState, error) {
+	if opts.MediaId == 0 || opts.Provider == "" || opts.ChapterId == "" {
+		return nil, ErrInvalidChapter
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, err := m.database.GetMangaReadingState(username, opts.MediaId, opts.Provider, opts.ChapterId)
+	if err != nil {
+		state = &models.MangaReadingState{
+			Username:  username,
+			MediaID:   opts.MediaId,
+			Provider:  opts.Provider,
+			ChapterID: opts.ChapterId,
+		}
+	}
+
+	state.ChapterNumber = opts.ChapterNumber
+	state.CurrentPage = max(opts.CurrentPage, 0)
+	state.TotalPages = opts.TotalPages
+
+	if opts.TotalPages > 0 && opts.CurrentPage >= opts.TotalPages-1 && !state.Read {
+		now := time.Now()
+		state.Read = true
+		state.ReadAt = &now
+	}
+
+	if err := m.database.UpsertMangaReadingState(state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// MarkChapters marks chapters as read or unread.
+// Marking a chapter as unread resets its page.
+func (m *Manager) MarkChapters(username string, opts *MarkChaptersOptions) (*MediaReadingState, error) {
+	if opts.MediaId == 0 || opts.Provider == "" {
+		return nil, ErrInvalidChapter
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, ch := range opts.Chapters {
+		if ch == nil || ch.ChapterId == "" {
+			continue
+		}
+
+		state, err := m.database.GetMangaReadingState(username, opts.MediaId, opts.Provider, ch.ChapterId)
+		if err != nil {
+			state = &models.MangaReadingState{
+				Username:  username,
+				MediaID:   opts.MediaId,
+				Provider:  opts.Provider,
+				ChapterID: ch.ChapterId,
+			}
+		}
+
+		state.ChapterNumber = ch.ChapterNumber
+		state.Read = opts.Read
+		if opts.Read {
+			if state.ReadAt == nil {
+				state.ReadAt = &now
+			}
+		} else {
+			state.ReadAt = nil
+			state.CurrentPage = 0
+		}
+
+		if err := m.database.UpsertMangaReadingState(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.getMediaReadingState(username, opts.MediaId)
+}
+
+// GetMediaReadingState returns the reading state of the chapters of a media.
+func (m *Manager) GetMediaReadingState(username string, mediaId int) (*MediaReadingState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.getMediaReadingState(username, mediaId)
+}
+
+// ResetMediaReadingState deletes the reading state of the chapters of a media.
+func (m *Manager) ResetMediaReadingState(username string, mediaId int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.database.DeleteMangaReadingStates(username, mediaId)
+}
+
+// GetContinueReading returns the unfinished chapters, one per media, most recently read first.
+// The media is set from the collection if found.
+func (m *Manager) GetContinueReading(username string, collection *anilist.MangaCollection) ([]*ContinueReadingItem, error) {
+	states, err := m.database.GetAllMangaReadingStates(username)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make(map[int]*anilist.BaseManga)
+	if collection != nil {
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia() != nil {
+					media[entry.GetMedia().GetID()] = entry.GetMedia()
+				}
+			}
+		}
+	}
+
+	ret := make([]*ContinueReadingItem, 0)
+	seen := make(map[int]struct{})
+	for _, state := range states {
+		if _, ok := seen[state.MediaID]; ok {
+			continue
+		}
+		seen[state.MediaID] = struct{}{}
+
+		// Only the last chapter opened for each media is considered
+		if !isUnfinished(state) {
+			continue
+		}
+
+		ret = append(ret, &ContinueReadingItem{
+			State: state,
+			Media: media[state.MediaID],
+		})
+		if len(ret) >= MaxContinueReadingItems {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+func (m *Manager) getMediaReadingState(username string, mediaId int) (*MediaReadingState, error) {
+	states, err := m.database.GetMangaReadingStates(username, mediaId)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(states, func(a, b *models.MangaReadingState) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	ret := &MediaReadingState{
+		MediaId:  mediaId,
+		Chapters: states,
+	}
+
+	if len(states) > 0 && isUnfinished(states[0]) {
+		ret.Resume = states[0]
+	}
+
+	for _, state := range states {
+		if !state.Read {
+			continue
+		}
+		if n, err := strconv.ParseFloat(state.ChapterNumber, 64); err == nil && n > ret.HighestReadChapter {
+			ret.HighestReadChapter = n
+		}
+	}
+
+	return ret, nil
+}
+
+// isUnfinished returns true if the chapter was opened but not finished.
+func isUnfinished(state *models.MangaReadingState) bool {
+	return !state.Read && state.CurrentPage > 0
+}
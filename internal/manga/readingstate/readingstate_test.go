@@ -0,0 +1,106 @@
+package manga_readingstate
+
+import (
+	"seanime/internal/database/db"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	logger := util.NewLogger()
+	database, err := db.NewDatabase(t.TempDir(), "seanime-test", logger)
+	require.NoError(t, err)
+
+	return NewManager(&NewManagerOptions{
+		Logger:   logger,
+		Database: database,
+	})
+}
+
+func TestManager_UpdatePage(t *testing.T) {
+	m := newTestManager(t)
+
+	state, err := m.UpdatePage("user", &UpdatePageOptions{
+		MediaId:       1,
+		Provider:      "mangadex",
+		ChapterId:     "ch-1",
+		ChapterNumber: "1",
+		CurrentPage:   4,
+		TotalPages:    20,
+	})
+	require.NoError(t, err)
+	assert.False(t, state.Read)
+
+	res, err := m.GetMediaReadingState("user", 1)
+	require.NoError(t, err)
+	require.NotNil(t, res.Resume)
+	assert.Equal(t, "ch-1", res.Resume.ChapterID)
+	assert.Equal(t, 4, res.Resume.CurrentPage)
+
+	// Other users have their own state
+	res, err = m.GetMediaReadingState("other", 1)
+	require.NoError(t, err)
+	assert.Empty(t, res.Chapters)
+
+	// Reaching the last page marks the chapter as read
+	state, err = m.UpdatePage("user", &UpdatePageOptions{
+		MediaId:       1,
+		Provider:      "mangadex",
+		ChapterId:     "ch-1",
+		ChapterNumber: "1",
+		CurrentPage:   19,
+		TotalPages:    20,
+	})
+	require.NoError(t, err)
+	assert.True(t, state.Read)
+	assert.NotNil(t, state.ReadAt)
+
+	res, err = m.GetMediaReadingState("user", 1)
+	require.NoError(t, err)
+	assert.Len(t, res.Chapters, 1)
+	assert.Nil(t, res.Resume)
+	assert.Equal(t, 1.0, res.HighestReadChapter)
+}
+
+func TestManager_MarkChapters(t *testing.T) {
+	m := newTestManager(t)
+
+	res, err := m.MarkChapters("user", &MarkChaptersOptions{
+		MediaId:  1,
+		Provider: "mangadex",
+		Chapters: []*MarkChapterOptions{
+			{ChapterId: "ch-1", ChapterNumber: "1"},
+			{ChapterId: "ch-5", ChapterNumber: "5"},
+		},
+		Read: true,
+	})
+	require.NoError(t, err)
+	assert.Len(t, res.Chapters, 2)
+	assert.Equal(t, 5.0, res.HighestReadChapter)
+
+	res, err = m.MarkChapters("user", &MarkChaptersOptions{
+		MediaId:  1,
+		Provider: "mangadex",
+		Chapters: []*MarkChapterOptions{{ChapterId: "ch-5", ChapterNumber: "5"}},
+		Read:     false,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, res.HighestReadChapter)
+}
+
+func TestManager_GetContinueReading(t *testing.T) {
+	m := newTestManager(t)
+
+	_, err := m.UpdatePage("user", &UpdatePageOptions{MediaId: 1, Provider: "p", ChapterId: "a", ChapterNumber: "1", CurrentPage: 3, TotalPages: 10})
+	require.NoError(t, err)
+	_, err = m.UpdatePage("user", &UpdatePageOptions{MediaId: 2, Provider: "p", ChapterId: "b", ChapterNumber: "1", CurrentPage: 9, TotalPages: 10})
+	require.NoError(t, err)
+
+	items, err := m.GetContinueReading("user", nil)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 1, items[0].State.MediaID)
+}
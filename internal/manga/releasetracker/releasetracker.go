@@ -0,0 +1,387 @@
+package manga_releasetracker
+
+import (
+	"errors"
+	"fmt"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mangaupdates"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/events"
+	"seanime/internal/notifier"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultInterval = 360 // minutes
+	minInterval     = 60  // minutes
+	// retryNotFoundAfter is the delay before searching again for a manga that was not found on MangaUpdates
+	retryNotFoundAfter = 7 * 24 * time.Hour
+	// requestDelay is the delay between two requests to MangaUpdates
+	requestDelay = time.Second
+	// maxNotifiedTitles is the maximum number of titles listed in a notification
+	maxNotifiedTitles = 3
+)
+
+type (
+	// ReleaseTracker periodically polls MangaUpdates for new releases of the manga the user is reading.
+	// It does not depend on any manga provider.
+	ReleaseTracker struct {
+		logger            *zerolog.Logger
+		database          *db.Database
+		wsEventManager    events.WSEventManagerInterface
+		platform          platform.Platform
+		client            Client
+		enabled           bool
+		interval          int
+		settingsUpdatedCh chan struct{}
+		startCh           chan struct{}
+		mu                sync.Mutex
+		runMu             sync.Mutex
+		// sleep is replaced in tests
+		sleep func(d time.Duration)
+	}
+
+	// Client is the MangaUpdates client.
+	// Implemented by mangaupdates.Client.
+	Client interface {
+		FindSeries(titles []*string) (*mangaupdates.Series, error)
+		GetSeriesReleases(seriesId int64, perPage int) ([]*mangaupdates.Release, error)
+	}
+
+	NewReleaseTrackerOptions struct {
+		Logger         *zerolog.Logger
+		Database       *db.Database
+		WSEventManager events.WSEventManagerInterface
+		Platform       platform.Platform
+		Client         Client
+	}
+
+	// MediaRelease is the latest release of a tracked manga.
+	MediaRelease struct {
+		MediaId           int     `json:"mediaId"`
+		SeriesId          int64   `json:"seriesId"`
+		SeriesTitle       string  `json:"seriesTitle"`
+		SeriesURL         string  `json:"seriesUrl"`
+		LatestChapter     float64 `json:"latestChapter"`
+		LatestGroup       string  `json:"latestGroup"`
+		LatestReleaseDate string  `json:"latestReleaseDate"`
+		// NewChapters is the number of chapters released after the user's progress
+		NewChapters int `json:"newChapters"`
+	}
+
+	newRelease struct {
+		title   string
+		chapter float64
+	}
+)
+
+func New(opts *NewReleaseTrackerOptions) *ReleaseTracker {
+	return &ReleaseTracker{
+		logger:            opts.Logger,
+		database:          opts.Database,
+		wsEventManager:    opts.WSEventManager,
+		platform:          opts.Platform,
+		client:            opts.Client,
+		enabled:           false, // Will be set in SetSettings
+		interval:          defaultInterval,
+		settingsUpdatedCh: make(chan struct{}, 1),
+		startCh:           make(chan struct{}, 1),
+		sleep:             time.Sleep,
+	}
+}
+
+// SetSettings should be called after the settings are fetched and updated from the database.
+func (rt *ReleaseTracker) SetSettings(settings *models.MangaSettings) {
+	if rt == nil || settings == nil {
+		return
+	}
+
+	rt.mu.Lock()
+	wasEnabled := rt.enabled
+	rt.enabled = settings.ReleaseTrackerEnabled
+	rt.interval = settings.ReleaseTrackerInterval
+	if rt.interval <= 0 {
+		rt.interval = defaultInterval
+	}
+	rt.interval = max(rt.interval, minInterval)
+	rt.mu.Unlock()
+
+	select {
+	case rt.settingsUpdatedCh <- struct{}{}:
+	default:
+	}
+
+	// Check right away when the module is enabled
+	if !wasEnabled && settings.ReleaseTrackerEnabled {
+		rt.Run()
+	}
+}
+
+// Start spins up the goroutine that checks for new releases at the configured interval.
+func (rt *ReleaseTracker) Start() {
+	if rt == nil {
+		return
+	}
+	go rt.start()
+}
+
+// Run tells the release tracker to check for new releases if it's enabled.
+func (rt *ReleaseTracker) Run() {
+	if rt == nil {
+		return
+	}
+	select {
+	case rt.startCh <- struct{}{}:
+	default:
+	}
+}
+
+func (rt *ReleaseTracker) isEnabled() bool {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return rt.enabled
+}
+
+func (rt *ReleaseTracker) start() {
+	defer util.HandlePanicInModuleThen("manga/releasetracker/start", func() {})
+
+	for {
+		rt.mu.Lock()
+		interval := rt.interval
+		rt.mu.Unlock()
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+		select {
+		case <-rt.settingsUpdatedCh:
+			// Restart the loop with the new interval
+		case <-rt.startCh:
+			if rt.isEnabled() {
+				rt.checkForNewReleases()
+			}
+		case <-ticker.C:
+			if rt.isEnabled() {
+				rt.checkForNewReleases()
+			}
+		}
+		ticker.Stop()
+	}
+}
+
+func (rt *ReleaseTracker) checkForNewReleases() {
+	defer util.HandlePanicInModuleThen("manga/releasetracker/checkForNewReleases", func() {})
+
+	mangaCollection, err := rt.platform.GetMangaCollection(false)
+	if err != nil {
+		rt.logger.Error().Err(err).Msg("manga release tracker: Failed to get manga collection")
+		return
+	}
+
+	newReleases, err := rt.Check(mangaCollection)
+	if err != nil {
+		rt.logger.Error().Err(err).Msg("manga release tracker: Failed to check for new releases")
+		return
+	}
+
+	if len(newReleases) == 0 {
+		return
+	}
+
+	titles := make([]string, 0, maxNotifiedTitles)
+	for _, r := range newReleases[:min(len(newReleases), maxNotifiedTitles)] {
+		titles = append(titles, fmt.Sprintf("%s (Ch. %s)", r.title, formatChapter(r.chapter)))
+	}
+	message := "New chapters: " + strings.Join(titles, ", ")
+	if len(newReleases) > maxNotifiedTitles {
+		message += fmt.Sprintf(" and %d more", len(newReleases)-maxNotifiedTitles)
+	}
+
+	notifier.GlobalNotifier.Notify(notifier.MangaReleaseTracker, message)
+	if rt.wsEventManager != nil {
+		rt.wsEventManager.SendEvent(events.InfoToast, message)
+		rt.wsEventManager.SendEvent(events.InvalidateQueries, []string{events.GetMangaReleasesEndpoint})
+	}
+}
+
+// Check resolves the MangaUpdates series of the manga being read and fetches their latest release.
+// It returns the releases that are newer than the ones previously seen.
+// The first release seen for a series is not considered new.
+func (rt *ReleaseTracker) Check(mangaCollection *anilist.MangaCollection) (ret []*newRelease, err error) {
+	defer util.HandlePanicInModuleWithError("manga/releasetracker/Check", &err)
+
+	// Only one run at a time
+	if !rt.runMu.TryLock() {
+		return nil, errors.New("manga release tracker: A check is already in progress")
+	}
+	defer rt.runMu.Unlock()
+
+	trackings, err := rt.getTrackingMap()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := getTrackedEntries(mangaCollection)
+	rt.logger.Debug().Int("count", len(entries)).Msg("manga release tracker: Checking for new releases")
+
+	ret = make([]*newRelease, 0)
+	for _, entry := range entries {
+		media := entry.GetMedia()
+		tracking, found := trackings[media.GetID()]
+		if !found {
+			tracking = &models.MangaReleaseTracking{MediaID: media.GetID()}
+		}
+
+		// Resolve the series if it has not been found yet
+		if tracking.SeriesID == 0 {
+			if found && tracking.CheckedAt != nil && time.Since(*tracking.CheckedAt) < retryNotFoundAfter {
+				continue
+			}
+			rt.resolveSeries(tracking, media)
+			rt.sleep(requestDelay)
+			if tracking.SeriesID == 0 {
+				rt.save(tracking)
+				continue
+			}
+		}
+
+		previousChapter := tracking.LatestChapter
+		if err := rt.updateLatestRelease(tracking); err != nil {
+			rt.logger.Warn().Err(err).Int("mediaId", media.GetID()).Msg("manga release tracker: Failed to fetch releases")
+			rt.sleep(requestDelay)
+			continue
+		}
+		rt.save(tracking)
+		rt.sleep(requestDelay)
+
+		if previousChapter > 0 && tracking.LatestChapter > previousChapter {
+			ret = append(ret, &newRelease{
+				title:   media.GetPreferredTitle(),
+				chapter: tracking.LatestChapter,
+			})
+		}
+	}
+
+	rt.logger.Debug().Int("count", len(ret)).Msg("manga release tracker: Found new releases")
+
+	return ret, nil
+}
+
+// resolveSeries finds the MangaUpdates series of a media from its titles.
+func (rt *ReleaseTracker) resolveSeries(tracking *models.MangaReleaseTracking, media *anilist.BaseManga) {
+	tracking.CheckedAt = lo.ToPtr(time.Now())
+
+	series, err := rt.client.FindSeries(media.GetAllTitles())
+	if err != nil {
+		if !errors.Is(err, mangaupdates.ErrSeriesNotFound) {
+			rt.logger.Warn().Err(err).Int("mediaId", media.GetID()).Msg("manga release tracker: Failed to search series")
+		}
+		return
+	}
+
+	tracking.SeriesID = series.ID
+	tracking.SeriesTitle = series.Title
+	tracking.SeriesURL = series.URL
+
+	rt.logger.Trace().Int("mediaId", media.GetID()).Int64("seriesId", series.ID).Msg("manga release tracker: Resolved series")
+}
+
+// updateLatestRelease sets the latest chapter released for the series.
+func (rt *ReleaseTracker) updateLatestRelease(tracking *models.MangaReleaseTracking) error {
+	releases, err := rt.client.GetSeriesReleases(tracking.SeriesID, 20)
+	if err != nil {
+		return err
+	}
+
+	tracking.CheckedAt = lo.ToPtr(time.Now())
+	for _, r := range releases {
+		chapter := mangaupdates.ParseChapterNumber(r.Chapter)
+		if chapter > tracking.LatestChapter {
+			tracking.LatestChapter = chapter
+			tracking.LatestGroup = r.GetGroupNames()
+			tracking.LatestReleaseDate = r.ReleaseDate
+		}
+	}
+	return nil
+}
+
+// GetReleases returns the latest releases of the manga in the collection.
+func (rt *ReleaseTracker) GetReleases(mangaCollection *anilist.MangaCollection) ([]*MediaRelease, error) {
+	trackings, err := rt.getTrackingMap()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*MediaRelease, 0)
+	for _, entry := range getTrackedEntries(mangaCollection) {
+		tracking, ok := trackings[entry.GetMedia().GetID()]
+		if !ok || tracking.SeriesID == 0 || tracking.LatestChapter <= 0 {
+			continue
+		}
+		progress := lo.FromPtr(entry.GetProgress())
+		ret = append(ret, &MediaRelease{
+			MediaId:           tracking.MediaID,
+			SeriesId:          tracking.SeriesID,
+			SeriesTitle:       tracking.SeriesTitle,
+			SeriesURL:         tracking.SeriesURL,
+			LatestChapter:     tracking.LatestChapter,
+			LatestGroup:       tracking.LatestGroup,
+			LatestReleaseDate: tracking.LatestReleaseDate,
+			NewChapters:       max(int(tracking.LatestChapter)-progress, 0),
+		})
+	}
+	return ret, nil
+}
+
+func (rt *ReleaseTracker) getTrackingMap() (map[int]*models.MangaReleaseTracking, error) {
+	trackings, err := rt.database.GetMangaReleaseTrackings()
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*models.MangaReleaseTracking, len(trackings))
+	for _, t := range trackings {
+		ret[t.MediaID] = t
+	}
+	return ret, nil
+}
+
+func (rt *ReleaseTracker) save(tracking *models.MangaReleaseTracking) {
+	if err := rt.database.UpsertMangaReleaseTracking(tracking); err != nil {
+		rt.logger.Error().Err(err).Int("mediaId", tracking.MediaID).Msg("manga release tracker: Failed to save release")
+	}
+}
+
+// getTrackedEntries returns the entries of the manga being read or reread.
+func getTrackedEntries(mangaCollection *anilist.MangaCollection) []*anilist.MangaListEntry {
+	ret := make([]*anilist.MangaListEntry, 0)
+	if mangaCollection == nil {
+		return ret
+	}
+	for _, list := range mangaCollection.GetMediaListCollection().GetLists() {
+		if list.GetStatus() == nil {
+			continue
+		}
+		if *list.GetStatus() != anilist.MediaListStatusCurrent && *list.GetStatus() != anilist.MediaListStatusRepeating {
+			continue
+		}
+		for _, entry := range list.GetEntries() {
+			if entry.GetMedia() != nil {
+				ret = append(ret, entry)
+			}
+		}
+	}
+	return ret
+}
+
+func formatChapter(chapter float64) string {
+	if chapter == float64(int(chapter)) {
+		return fmt.Sprintf("%d", int(chapter))
+	}
+	return fmt.Sprintf("%.1f", chapter)
+}
@@ -0,0 +1,134 @@
+package manga_releasetracker
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mangaupdates"
+	"seanime/internal/database/db"
+	"seanime/internal/util"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	series   map[string]*mangaupdates.Series
+	releases map[int64][]*mangaupdates.Release
+	searches int
+}
+
+func (c *fakeClient) FindSeries(titles []*string) (*mangaupdates.Series, error) {
+	c.searches++
+	for _, title := range titles {
+		if title == nil {
+			continue
+		}
+		if s, ok := c.series[*title]; ok {
+			return s, nil
+		}
+	}
+	return nil, mangaupdates.ErrSeriesNotFound
+}
+
+func (c *fakeClient) GetSeriesReleases(seriesId int64, perPage int) ([]*mangaupdates.Release, error) {
+	return c.releases[seriesId], nil
+}
+
+func testCollection() *anilist.MangaCollection {
+	return &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: []*anilist.MangaCollection_MediaListCollection_Lists{
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+					Entries: []*anilist.MangaCollection_MediaListCollection_Lists_Entries{
+						{
+							Progress: lo.ToPtr(100),
+							Media: &anilist.BaseManga{
+								ID:    30013,
+								Title: &anilist.BaseManga_Title{English: lo.ToPtr("One Piece")},
+							},
+						},
+						{
+							Progress: lo.ToPtr(3),
+							Media: &anilist.BaseManga{
+								ID:    1,
+								Title: &anilist.BaseManga_Title{English: lo.ToPtr("Unknown")},
+							},
+						},
+					},
+				},
+				{
+					// Completed manga are not tracked
+					Status: lo.ToPtr(anilist.MediaListStatusCompleted),
+					Entries: []*anilist.MangaCollection_MediaListCollection_Lists_Entries{
+						{
+							Media: &anilist.BaseManga{
+								ID:    30002,
+								Title: &anilist.BaseManga_Title{English: lo.ToPtr("Berserk")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReleaseTracker_Check(t *testing.T) {
+	database, err := db.NewDatabase(t.TempDir(), "seanime-test", util.NewLogger())
+	require.NoError(t, err)
+
+	client := &fakeClient{
+		series: map[string]*mangaupdates.Series{
+			"One Piece": {ID: 55, Title: "One Piece", URL: "https://www.mangaupdates.com/series/one-piece"},
+			"Berserk":   {ID: 66, Title: "Berserk"},
+		},
+		releases: map[int64][]*mangaupdates.Release{
+			55: {
+				{Chapter: "110", Groups: []*mangaupdates.ReleaseGroup{{Name: "TCB"}}, ReleaseDate: "2026-10-01"},
+				{Chapter: "108-109", ReleaseDate: "2026-09-20"},
+			},
+		},
+	}
+
+	rt := New(&NewReleaseTrackerOptions{
+		Logger:   util.NewLogger(),
+		Database: database,
+		Client:   client,
+	})
+	rt.sleep = func(time.Duration) {}
+
+	collection := testCollection()
+
+	// The first release seen is not new
+	newReleases, err := rt.Check(collection)
+	require.NoError(t, err)
+	assert.Empty(t, newReleases)
+	assert.Equal(t, 2, client.searches)
+
+	releases, err := rt.GetReleases(collection)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, 30013, releases[0].MediaId)
+	assert.Equal(t, 110.0, releases[0].LatestChapter)
+	assert.Equal(t, "TCB", releases[0].LatestGroup)
+	assert.Equal(t, 10, releases[0].NewChapters)
+
+	// A new chapter is released
+	client.releases[55] = append([]*mangaupdates.Release{{Chapter: "111", ReleaseDate: "2026-10-08"}}, client.releases[55]...)
+
+	newReleases, err = rt.Check(collection)
+	require.NoError(t, err)
+	require.Len(t, newReleases, 1)
+	assert.Equal(t, "One Piece", newReleases[0].title)
+	assert.Equal(t, 111.0, newReleases[0].chapter)
+	// Series that were not found are not searched again right away
+	assert.Equal(t, 2, client.searches)
+}
+
+func TestFormatChapter(t *testing.T) {
+	assert.Equal(t, "12", formatChapter(12))
+	assert.Equal(t, "12.5", formatChapter(12.5))
+}
@@ -42,6 +42,7 @@ type (
 		mu                    sync.Mutex
 		downloadDir           string
 		db                    *db.Database
+		providerHealth        *ProviderHealthTracker
 	}
 
 	NewRepositoryOptions struct {
@@ -65,10 +66,16 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 		downloadDir:           opts.DownloadDir,
 		providerExtensionBank: extension.NewUnifiedBank(),
 		db:                    opts.Database,
+		providerHealth:        NewProviderHealthTracker(),
 	}
 	return r
 }
 
+// GetProviderHealth returns the health of the manga providers that have been used.
+func (r *Repository) GetProviderHealth() []*ProviderHealth {
+	return r.providerHealth.GetAll()
+}
+
 func (r *Repository) InitExtensionBank(bank *extension.UnifiedBank) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
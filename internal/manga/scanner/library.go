@@ -0,0 +1,288 @@
+package manga_scanner
+
+import (
+	"errors"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrScanInProgress = errors.New("manga scanner: A scan is already in progress")
+	ErrSeriesNotFound = errors.New("manga scanner: Series not found")
+)
+
+type (
+	// LocalLibrary holds the chapters of the local manga library and keeps them in sync with the database.
+	LocalLibrary struct {
+		logger            *zerolog.Logger
+		database          *db.Database
+		mappingRepository MappingRepository
+		libraryDir        string
+		chapters          []*LocalChapter
+		mu                sync.RWMutex
+		scanMu            sync.Mutex
+	}
+
+	// MappingRepository stores the provider mappings of the manga entries.
+	// Implemented by manga.Repository.
+	MappingRepository interface {
+		ManualMapping(provider string, mediaId int, mangaId string) error
+		RemoveMapping(provider string, mediaId int) error
+	}
+
+	NewLocalLibraryOptions struct {
+		Logger            *zerolog.Logger
+		Database          *db.Database
+		MappingRepository MappingRepository
+	}
+
+	// LocalSeries is a group of local chapters belonging to the same series.
+	LocalSeries struct {
+		SeriesKey    string `json:"seriesKey"`
+		SeriesTitle  string `json:"seriesTitle"`
+		MediaId      int    `json:"mediaId"`
+		Locked       bool   `json:"locked"`
+		ChapterCount int    `json:"chapterCount"`
+		// UnsupportedChapterCount is the number of chapters that are listed but can't be read (CB7, PDF).
+		// They are not included in ChapterCount and are not offered by the provider.
+		UnsupportedChapterCount int `json:"unsupportedChapterCount"`
+	}
+)
+
+func NewLocalLibrary(opts *NewLocalLibraryOptions) *LocalLibrary {
+	ret := &LocalLibrary{
+		logger:            opts.Logger,
+		database:          opts.Database,
+		mappingRepository: opts.MappingRepository,
+		chapters:          make([]*LocalChapter, 0),
+	}
+
+	ret.load()
+
+	return ret
+}
+
+func (l *LocalLibrary) SetLibraryDir(dir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.libraryDir = dir
+}
+
+func (l *LocalLibrary) GetLibraryDir() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.libraryDir
+}
+
+// GetChapters returns all the chapters found by the last scan.
+func (l *LocalLibrary) GetChapters() []*LocalChapter {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.chapters)
+}
+
+// GetMediaChapters returns the chapters matched to a media, in reading order.
+func (l *LocalLibrary) GetMediaChapters(mediaId int) []*LocalChapter {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ret := make([]*LocalChapter, 0)
+	if mediaId == 0 {
+		return ret
+	}
+	for _, c := range l.chapters {
+		if c.MediaId == mediaId {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// GetChapter returns a chapter by its ID.
+func (l *LocalLibrary) GetChapter(id string) (*LocalChapter, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, c := range l.chapters {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// GetSeries returns the series found by the last scan.
+func (l *LocalLibrary) GetSeries() []*LocalSeries {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ret := make([]*LocalSeries, 0)
+	seriesMap := make(map[string]*LocalSeries)
+	for _, c := range l.chapters {
+		s, ok := seriesMap[c.SeriesKey]
+		if !ok {
+			s = &LocalSeries{
+				SeriesKey:   c.SeriesKey,
+				SeriesTitle: c.SeriesTitle,
+				MediaId:     c.MediaId,
+				Locked:      c.Locked,
+			}
+			seriesMap[c.SeriesKey] = s
+			ret = append(ret, s)
+		}
+		if c.IsReadable() {
+			s.ChapterCount++
+		} else {
+			s.UnsupportedChapterCount++
+		}
+	}
+	return ret
+}
+
+// Scan scans the library directory and matches the series to the manga collection.
+// Manual matches are kept.
+func (l *LocalLibrary) Scan(collection *anilist.MangaCollection) ([]*LocalChapter, error) {
+	if !l.scanMu.TryLock() {
+		return nil, ErrScanInProgress
+	}
+	defer l.scanMu.Unlock()
+
+	scanner := &Scanner{
+		LibraryDir:       l.GetLibraryDir(),
+		MangaCollection:  collection,
+		ExistingChapters: l.GetChapters(),
+		Logger:           l.logger,
+	}
+
+	chapters, err := scanner.Scan()
+	if err != nil {
+		return nil, err
+	}
+
+	l.setChapters(chapters)
+
+	l.logger.Info().Int("count", len(chapters)).Msg("manga scanner: Local library scanned")
+
+	return chapters, nil
+}
+
+// MatchSeries manually matches a series to a media.
+// A media ID of 0 unmatches the series, it will not be matched automatically by later scans.
+func (l *LocalLibrary) MatchSeries(seriesKey string, mediaId int) error {
+	l.scanMu.Lock()
+	defer l.scanMu.Unlock()
+
+	chapters := make([]*LocalChapter, 0)
+	found := false
+	for _, c := range l.GetChapters() {
+		c = c.clone()
+		if c.SeriesKey == seriesKey {
+			c.MediaId = mediaId
+			c.Locked = true
+			found = true
+		}
+		chapters = append(chapters, c)
+	}
+
+	if !found {
+		return ErrSeriesNotFound
+	}
+
+	l.setChapters(chapters)
+
+	return nil
+}
+
+// setChapters replaces the chapters, persists them and updates the mappings of the media whose chapters changed.
+func (l *LocalLibrary) setChapters(chapters []*LocalChapter) {
+	l.mu.Lock()
+	previous := l.chapters
+	l.chapters = chapters
+	l.mu.Unlock()
+
+	l.save()
+	l.updateMappings(previous, chapters)
+}
+
+// updateMappings maps the matched media to the local library provider so that the chapters show up in the entry,
+// and removes the mappings of media that no longer have local chapters.
+func (l *LocalLibrary) updateMappings(previous []*LocalChapter, current []*LocalChapter) {
+	if l.mappingRepository == nil {
+		return
+	}
+
+	previousIds := make(map[int]struct{})
+	for _, c := range previous {
+		if c.MediaId != 0 {
+			previousIds[c.MediaId] = struct{}{}
+		}
+	}
+	currentIds := make(map[int]struct{})
+	for _, c := range current {
+		if c.MediaId != 0 {
+			currentIds[c.MediaId] = struct{}{}
+		}
+	}
+
+	for mediaId := range previousIds {
+		if _, ok := currentIds[mediaId]; !ok {
+			_ = l.mappingRepository.RemoveMapping(ProviderID, mediaId)
+		}
+	}
+	for mediaId := range currentIds {
+		// Remove the previous mapping first, this also clears the cached chapter container
+		_ = l.mappingRepository.RemoveMapping(ProviderID, mediaId)
+		if err := l.mappingRepository.ManualMapping(ProviderID, mediaId, strconv.Itoa(mediaId)); err != nil {
+			l.logger.Error().Err(err).Int("mediaId", mediaId).Msg("manga scanner: Failed to map media")
+		}
+	}
+}
+
+func (l *LocalLibrary) load() {
+	if l.database == nil {
+		return
+	}
+
+	res, err := l.database.GetMangaLocalLibrary()
+	if err != nil {
+		l.logger.Error().Err(err).Msg("manga scanner: Failed to load local library")
+		return
+	}
+	if res == nil {
+		return
+	}
+
+	var chapters []*LocalChapter
+	if err := json.Unmarshal(res.Value, &chapters); err != nil {
+		l.logger.Error().Err(err).Msg("manga scanner: Failed to unmarshal local library")
+		return
+	}
+
+	l.mu.Lock()
+	l.chapters = chapters
+	l.mu.Unlock()
+}
+
+func (l *LocalLibrary) save() {
+	if l.database == nil {
+		return
+	}
+
+	l.mu.RLock()
+	value, err := json.Marshal(l.chapters)
+	l.mu.RUnlock()
+	if err != nil {
+		l.logger.Error().Err(err).Msg("manga scanner: Failed to marshal local library")
+		return
+	}
+
+	if err := l.database.UpsertMangaLocalLibrary(value); err != nil {
+		l.logger.Error().Err(err).Msg("manga scanner: Failed to save local library")
+	}
+}
@@ -0,0 +1,141 @@
+package manga_scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format is the on-disk format of a local chapter.
+type Format string
+
+const (
+	FormatCBZ    Format = "cbz"    // .cbz, .zip
+	FormatCBR    Format = "cbr"    // .cbr, .rar
+	FormatCB7    Format = "cb7"    // .cb7, .7z (listed, not readable)
+	FormatPDF    Format = "pdf"    // .pdf (listed, not readable)
+	FormatImages Format = "images" // Folder of images
+)
+
+var archiveFormats = map[string]Format{
+	".cbz": FormatCBZ,
+	".zip": FormatCBZ,
+	".cbr": FormatCBR,
+	".rar": FormatCBR,
+	".cb7": FormatCB7,
+	".7z":  FormatCB7,
+	".pdf": FormatPDF,
+}
+
+var imageExtensions = map[string]struct{}{
+	".jpg":  {},
+	".jpeg": {},
+	".png":  {},
+	".webp": {},
+	".gif":  {},
+	".avif": {},
+	".bmp":  {},
+}
+
+var (
+	// e.g. "[Group]", "(2019)", "{Digital}"
+	bracketRegex = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|\{[^}]*\}`)
+	// e.g. "Vol. 2", "Volume 02", "v02"
+	volumeRegex = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:volume|vol\.?|v)\s*(\d+(?:\.\d+)?)`)
+	// e.g. "Ch. 12", "Chapter 12.5", "c012", "#12"
+	chapterRegex = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:chapter|chap\.?|ch\.?|c|#)\s*(\d+(?:\.\d+)?)`)
+	// Any standalone number, used as a fallback for the chapter number
+	numberRegex = regexp.MustCompile(`(?i)(?:^|[^a-z0-9.])(\d+(?:\.\d+)?)(?:$|[^a-z0-9])`)
+	// Separators left over after removing tokens
+	separatorRegex = regexp.MustCompile(`[\s_\-.]+`)
+)
+
+// ParsedName holds the information parsed from a folder or file name.
+type ParsedName struct {
+	Title   string // Series title, empty if the name only contains numbers
+	Volume  string // e.g. "2", empty if not found
+	Chapter string // e.g. "12.5", empty if not found
+}
+
+// ParseSeriesName returns the series title of a series folder name.
+// Unlike ParseName, numbers are kept since they are usually part of the title, e.g. "Mob Psycho 100".
+func ParseSeriesName(name string) string {
+	return cleanTitle(bracketRegex.ReplaceAllString(strings.ReplaceAll(name, "_", " "), " "))
+}
+
+// ParseName parses the series title, volume and chapter from a folder or file name.
+// The extension should be stripped beforehand.
+//
+//	"[Group] Series Name v02 c012 (2019)" -> {Title: "Series Name", Volume: "2", Chapter: "12"}
+//	"Series Name - 045"                   -> {Title: "Series Name", Chapter: "45"}
+func ParseName(name string) ParsedName {
+	ret := ParsedName{}
+
+	cleaned := bracketRegex.ReplaceAllString(name, " ")
+	cleaned = strings.ReplaceAll(cleaned, "_", " ")
+
+	// The title is everything before the first volume, chapter or number token
+	titleEnd := len(cleaned)
+
+	if loc := volumeRegex.FindStringSubmatchIndex(cleaned); loc != nil {
+		ret.Volume = normalizeNumber(cleaned[loc[2]:loc[3]])
+		titleEnd = min(titleEnd, tokenStart(cleaned, loc))
+	}
+
+	if loc := chapterRegex.FindStringSubmatchIndex(cleaned); loc != nil {
+		ret.Chapter = normalizeNumber(cleaned[loc[2]:loc[3]])
+		titleEnd = min(titleEnd, tokenStart(cleaned, loc))
+	}
+
+	// Fallback to the last standalone number when there is no explicit token
+	if ret.Volume == "" && ret.Chapter == "" {
+		if locs := numberRegex.FindAllStringSubmatchIndex(cleaned, -1); len(locs) > 0 {
+			loc := locs[len(locs)-1]
+			ret.Chapter = normalizeNumber(cleaned[loc[2]:loc[3]])
+			titleEnd = loc[2]
+		}
+	}
+
+	ret.Title = cleanTitle(cleaned[:titleEnd])
+	return ret
+}
+
+// tokenStart returns the start of the matched token, excluding the leading separator matched by the regex.
+func tokenStart(s string, loc []int) int {
+	start := loc[0]
+	if start < len(s) && !isAlphaNum(s[start]) && s[start] != '#' {
+		start++
+	}
+	return start
+}
+
+func isAlphaNum(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// cleanTitle removes leftover separators and whitespace from a title.
+func cleanTitle(s string) string {
+	s = separatorRegex.ReplaceAllString(s, " ")
+	return strings.Trim(s, ",:# ")
+}
+
+// normalizeNumber removes leading zeros, e.g. "012" -> "12", "005.5" -> "5.5".
+func normalizeNumber(s string) string {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return s
+}
+
+// GetFormat returns the format of a chapter file, false if the file is not a chapter.
+func GetFormat(path string) (Format, bool) {
+	f, ok := archiveFormats[strings.ToLower(filepath.Ext(path))]
+	return f, ok
+}
+
+// IsImage returns true if the file is an image that can be displayed by the reader.
+func IsImage(path string) bool {
+	_, ok := imageExtensions[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
@@ -0,0 +1,151 @@
+package manga_scanner
+
+import (
+	"cmp"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"seanime/internal/util/comparison"
+	"strconv"
+
+	hibikemanga "seanime/internal/extension/hibike/manga"
+)
+
+const (
+	ProviderID   = "local-library"
+	ProviderName = "Local Library"
+
+	// PageRoute is the route serving the pages of local chapters
+	PageRoute = "/api/v1/manga/local-library/page"
+)
+
+// Provider exposes the local manga library as a manga provider.
+// The manga ID is the AniList ID of the matched media.
+type Provider struct {
+	library *LocalLibrary
+}
+
+func NewProvider(library *LocalLibrary) hibikemanga.Provider {
+	return &Provider{library: library}
+}
+
+func (p *Provider) GetSettings() hibikemanga.Settings {
+	return hibikemanga.Settings{
+		SupportsMultiScanlator: false,
+		SupportsMultiLanguage:  false,
+	}
+}
+
+// Search returns the matched series whose title is similar to the query.
+func (p *Provider) Search(opts hibikemanga.SearchOptions) ([]*hibikemanga.SearchResult, error) {
+	ret := make([]*hibikemanga.SearchResult, 0)
+
+	query := normalizeTitle(opts.Query)
+	for _, s := range p.library.GetSeries() {
+		if s.MediaId == 0 {
+			continue
+		}
+		title := normalizeTitle(s.SeriesTitle)
+		rating := 1.0
+		if query != "" {
+			res, ok := comparison.FindBestMatchWithSorensenDice(&query, []*string{&title})
+			if !ok || res.Rating < defaultMatchingThreshold {
+				continue
+			}
+			rating = res.Rating
+		}
+		ret = append(ret, &hibikemanga.SearchResult{
+			Provider:     ProviderID,
+			ID:           strconv.Itoa(s.MediaId),
+			Title:        s.SeriesTitle,
+			SearchRating: rating,
+		})
+	}
+
+	return ret, nil
+}
+
+// FindChapters returns the local chapters matched to the media.
+func (p *Provider) FindChapters(id string) ([]*hibikemanga.ChapterDetails, error) {
+	mediaId, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("manga scanner: Invalid manga ID: %s", id)
+	}
+
+	ret := make([]*hibikemanga.ChapterDetails, 0)
+	for i, c := range p.library.GetMediaChapters(mediaId) {
+		if !c.IsReadable() {
+			continue
+		}
+		ret = append(ret, &hibikemanga.ChapterDetails{
+			Provider: ProviderID,
+			ID:       EncodeChapterID(c.ID),
+			Title:    providerChapterTitle(c),
+			// Volume-only releases are numbered by volume
+			Chapter: cmp.Or(c.Chapter, c.Volume, strconv.Itoa(i+1)),
+			Index:   uint(len(ret)),
+		})
+	}
+
+	return ret, nil
+}
+
+// FindChapterPages returns the URLs of the pages, served by the local library page route.
+func (p *Provider) FindChapterPages(id string) ([]*hibikemanga.ChapterPage, error) {
+	chapterId, err := DecodeChapterID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	chapter, ok := p.library.GetChapter(chapterId)
+	if !ok {
+		return nil, fmt.Errorf("manga scanner: Chapter not found: %s", chapterId)
+	}
+
+	names, err := ListPages(chapter)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*hibikemanga.ChapterPage, 0, len(names))
+	for i := range names {
+		ret = append(ret, &hibikemanga.ChapterPage{
+			Provider: ProviderID,
+			URL:      GetPageURL(chapter.ID, i),
+			Index:    i,
+		})
+	}
+
+	return ret, nil
+}
+
+// GetPageURL returns the URL of a page of a local chapter.
+func GetPageURL(chapterId string, index int) string {
+	return fmt.Sprintf("%s?id=%s&index=%d", PageRoute, url.QueryEscape(chapterId), index)
+}
+
+// EncodeChapterID encodes the relative path of a chapter so that it can be used as a provider chapter ID.
+// Provider chapter IDs should not contain slashes.
+func EncodeChapterID(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func DecodeChapterID(id string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", fmt.Errorf("manga scanner: Invalid chapter ID: %s", id)
+	}
+	return string(b), nil
+}
+
+func providerChapterTitle(c *LocalChapter) string {
+	switch {
+	case c.Chapter != "" && c.Volume != "":
+		return "Chapter " + c.Chapter + " (Vol. " + c.Volume + ")"
+	case c.Chapter != "":
+		return "Chapter " + c.Chapter
+	case c.Volume != "":
+		return "Volume " + c.Volume
+	}
+	return c.Title
+}
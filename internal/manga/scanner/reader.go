@@ -0,0 +1,167 @@
+package manga_scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nwaples/rardecode/v2"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("manga scanner: Unsupported chapter format")
+	ErrPageNotFound      = errors.New("manga scanner: Page not found")
+)
+
+// ListPages returns the names of the pages of a chapter in reading order.
+// For archives, names are the paths of the entries in the archive.
+func ListPages(chapter *LocalChapter) ([]string, error) {
+	var names []string
+
+	switch chapter.Format {
+	case FormatImages:
+		entries, err := os.ReadDir(chapter.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && IsImage(e.Name()) {
+				names = append(names, e.Name())
+			}
+		}
+	case FormatCBZ:
+		zr, err := zip.OpenReader(chapter.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() && IsImage(f.Name) {
+				names = append(names, f.Name)
+			}
+		}
+	case FormatCBR:
+		r, err := rardecode.OpenReader(chapter.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		for {
+			header, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !header.IsDir && IsImage(header.Name) {
+				names = append(names, header.Name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, chapter.Format)
+	}
+
+	slices.SortStableFunc(names, compareNatural)
+
+	return names, nil
+}
+
+// ReadPage returns the content of a page of a chapter.
+// The name should be one returned by ListPages.
+func ReadPage(chapter *LocalChapter, name string) ([]byte, error) {
+	switch chapter.Format {
+	case FormatImages:
+		return os.ReadFile(filepath.Join(chapter.Path, filepath.Base(name)))
+	case FormatCBZ:
+		zr, err := zip.OpenReader(chapter.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if f.Name != name {
+				continue
+			}
+			r, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return io.ReadAll(r)
+		}
+	case FormatCBR:
+		// RAR archives can only be read sequentially
+		r, err := rardecode.OpenReader(chapter.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		for {
+			header, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if header.Name == name {
+				var buf bytes.Buffer
+				if _, err := io.Copy(&buf, r); err != nil {
+					return nil, err
+				}
+				return buf.Bytes(), nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, chapter.Format)
+	}
+
+	return nil, ErrPageNotFound
+}
+
+// compareNatural compares two strings, ordering runs of digits by their numeric value.
+// e.g. "2.jpg" < "10.jpg"
+func compareNatural(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for a != "" && b != "" {
+		ra, rb := rune(a[0]), rune(b[0])
+		if unicode.IsDigit(ra) && unicode.IsDigit(rb) {
+			na, restA := leadingNumber(a)
+			nb, restB := leadingNumber(b)
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+			a, b = restA, restB
+			continue
+		}
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+func leadingNumber(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return n, s[i:]
+}
@@ -0,0 +1,334 @@
+package manga_scanner
+
+import (
+	"cmp"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"seanime/internal/api/anilist"
+	"seanime/internal/util/comparison"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+const defaultMatchingThreshold = 0.5
+
+// LocalChapter is a chapter file or image folder found in the local manga library.
+type LocalChapter struct {
+	// ID is the path relative to the library directory, using forward slashes
+	ID string `json:"id"`
+	// Path is the absolute path of the file or folder
+	Path string `json:"path"`
+	// SeriesKey groups chapters of the same series, e.g. the top-level folder name
+	SeriesKey   string `json:"seriesKey"`
+	SeriesTitle string `json:"seriesTitle"`
+	Volume      string `json:"volume"`
+	Chapter     string `json:"chapter"`
+	// Title is the display title of the chapter, e.g. "Vol. 1 Ch. 2"
+	Title  string `json:"title"`
+	Format Format `json:"format"`
+	// MediaId is the AniList ID of the matched manga, 0 if unmatched
+	MediaId int `json:"mediaId"`
+	// Locked is true if the match was set manually, a scan will not change it
+	Locked bool `json:"locked"`
+}
+
+// IsReadable returns true if the pages of the chapter can be read.
+func (c *LocalChapter) IsReadable() bool {
+	return c.Format != FormatCB7 && c.Format != FormatPDF
+}
+
+func (c *LocalChapter) clone() *LocalChapter {
+	ret := *c
+	return &ret
+}
+
+type (
+	// Scanner walks the local manga library and matches the series to the user's manga collection.
+	Scanner struct {
+		LibraryDir      string
+		MangaCollection *anilist.MangaCollection
+		// ExistingChapters are the chapters from the previous scan, used to keep manual matches
+		ExistingChapters []*LocalChapter
+		Logger           *zerolog.Logger
+		// Threshold is the minimum Sorensen-Dice rating for a match, defaults to 0.5
+		Threshold float64
+	}
+)
+
+// Scan walks the library directory and returns the chapters found, sorted by series, volume and chapter.
+func (s *Scanner) Scan() ([]*LocalChapter, error) {
+	if s.LibraryDir == "" {
+		return nil, errors.New("manga scanner: Library path is not set")
+	}
+
+	info, err := os.Stat(s.LibraryDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("manga scanner: Library path is not a directory")
+	}
+
+	chapters, err := s.walk()
+	if err != nil {
+		return nil, err
+	}
+
+	s.match(chapters)
+
+	slices.SortStableFunc(chapters, func(a, b *LocalChapter) int {
+		if c := strings.Compare(strings.ToLower(a.SeriesTitle), strings.ToLower(b.SeriesTitle)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(parseFloat(a.Volume), parseFloat(b.Volume)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(parseFloat(a.Chapter), parseFloat(b.Chapter)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	s.Logger.Debug().Int("count", len(chapters)).Msg("manga scanner: Scanned local library")
+
+	return chapters, nil
+}
+
+// walk finds the chapter files and image folders in the library directory.
+func (s *Scanner) walk() ([]*LocalChapter, error) {
+	chapters := make([]*LocalChapter, 0)
+
+	err := filepath.WalkDir(s.LibraryDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			s.Logger.Warn().Err(err).Str("path", path).Msg("manga scanner: Failed to read path")
+			return nil
+		}
+
+		// Skip hidden files and folders
+		if strings.HasPrefix(d.Name(), ".") && path != s.LibraryDir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if path == s.LibraryDir || !containsImages(path) {
+				return nil
+			}
+			if chapter, ok := s.newChapter(path, FormatImages); ok {
+				chapters = append(chapters, chapter)
+			}
+			return filepath.SkipDir
+		}
+
+		format, ok := GetFormat(path)
+		if !ok {
+			return nil
+		}
+		if chapter, ok := s.newChapter(path, format); ok {
+			chapters = append(chapters, chapter)
+		}
+		return nil
+	})
+
+	return chapters, err
+}
+
+// newChapter parses a chapter from its path.
+//
+//	{library}/{series}/{chapter}
+//	{library}/{series}/{volume}/{chapter}
+//	{library}/{series}           <- Image folder, treated as a single chapter
+//	{library}/{chapter}          <- The series title is parsed from the file name
+func (s *Scanner) newChapter(path string, format Format) (*LocalChapter, bool) {
+	rel, err := filepath.Rel(s.LibraryDir, path)
+	if err != nil {
+		return nil, false
+	}
+	rel = filepath.ToSlash(rel)
+	parts := strings.Split(rel, "/")
+
+	name := parts[len(parts)-1]
+	if format != FormatImages {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	parsed := ParseName(name)
+
+	ret := &LocalChapter{
+		ID:      rel,
+		Path:    path,
+		Volume:  parsed.Volume,
+		Chapter: parsed.Chapter,
+		Format:  format,
+	}
+
+	if len(parts) == 1 {
+		ret.SeriesTitle = parsed.Title
+		// A top-level image folder without numbers is a one-shot
+		if format == FormatImages && parsed.Volume == "" && parsed.Chapter == "" {
+			ret.SeriesTitle = ParseSeriesName(name)
+		}
+		ret.SeriesKey = ret.SeriesTitle
+	} else {
+		ret.SeriesKey = parts[0]
+		ret.SeriesTitle = ParseSeriesName(parts[0])
+		// Get the volume from the intermediate folders, e.g. {series}/Volume 01/Chapter 001.cbz
+		if ret.Volume == "" {
+			for _, dir := range parts[1 : len(parts)-1] {
+				if p := ParseName(dir); p.Volume != "" {
+					ret.Volume = p.Volume
+					break
+				}
+			}
+		}
+	}
+
+	if ret.SeriesTitle == "" {
+		return nil, false
+	}
+
+	ret.Title = chapterTitle(ret, name)
+
+	return ret, true
+}
+
+func chapterTitle(c *LocalChapter, name string) string {
+	var parts []string
+	if c.Volume != "" {
+		parts = append(parts, "Vol. "+c.Volume)
+	}
+	if c.Chapter != "" {
+		parts = append(parts, "Ch. "+c.Chapter)
+	}
+	if len(parts) == 0 {
+		return name
+	}
+	return strings.Join(parts, " ")
+}
+
+// match sets the MediaId of the chapters.
+// Manual matches from the previous scan are kept, other series are matched against the manga collection.
+func (s *Scanner) match(chapters []*LocalChapter) {
+	locked := make(map[string]int)
+	for _, c := range s.ExistingChapters {
+		if c.Locked {
+			locked[c.SeriesKey] = c.MediaId
+		}
+	}
+
+	threshold := s.Threshold
+	if threshold <= 0 {
+		threshold = defaultMatchingThreshold
+	}
+
+	media := s.getCollectionMedia()
+
+	matched := make(map[string]int)
+	for _, c := range chapters {
+		if mediaId, ok := locked[c.SeriesKey]; ok {
+			c.MediaId = mediaId
+			c.Locked = true
+			continue
+		}
+
+		mediaId, ok := matched[c.SeriesKey]
+		if !ok {
+			mediaId = findBestMatch(c.SeriesTitle, media, threshold)
+			matched[c.SeriesKey] = mediaId
+			if mediaId != 0 {
+				s.Logger.Trace().Str("series", c.SeriesTitle).Int("mediaId", mediaId).Msg("manga scanner: Matched series")
+			} else {
+				s.Logger.Trace().Str("series", c.SeriesTitle).Msg("manga scanner: No match found for series")
+			}
+		}
+		c.MediaId = mediaId
+	}
+}
+
+func (s *Scanner) getCollectionMedia() []*anilist.BaseManga {
+	ret := make([]*anilist.BaseManga, 0)
+	if s.MangaCollection == nil {
+		return ret
+	}
+	for _, list := range s.MangaCollection.GetMediaListCollection().GetLists() {
+		for _, entry := range list.GetEntries() {
+			if entry.GetMedia() != nil {
+				ret = append(ret, entry.GetMedia())
+			}
+		}
+	}
+	return ret
+}
+
+// findBestMatch returns the ID of the media whose titles best match the series title, 0 if none is above the threshold.
+func findBestMatch(title string, media []*anilist.BaseManga, threshold float64) int {
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return 0
+	}
+
+	bestId := 0
+	bestRating := 0.0
+	for _, m := range media {
+		titles := make([]*string, 0)
+		for _, t := range m.GetAllTitles() {
+			if t == nil {
+				continue
+			}
+			n := normalizeTitle(*t)
+			titles = append(titles, &n)
+		}
+		if len(titles) == 0 {
+			continue
+		}
+		res, ok := comparison.FindBestMatchWithSorensenDice(&normalized, titles)
+		if ok && res.Rating > bestRating {
+			bestRating = res.Rating
+			bestId = m.GetID()
+		}
+	}
+
+	if bestRating < threshold {
+		return 0
+	}
+	return bestId
+}
+
+func normalizeTitle(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '!', '?', ',', '\'', '"', '.', '-', '_', '~':
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func containsImages(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !e.IsDir() && IsImage(e.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return -1
+	}
+	return f
+}
@@ -0,0 +1,173 @@
+package manga_scanner
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"seanime/internal/api/anilist"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected ParsedName
+	}{
+		{"[Group] Series Name v02 c012 (2019)", ParsedName{Title: "Series Name", Volume: "2", Chapter: "12"}},
+		{"Series Name - 045", ParsedName{Title: "Series Name", Chapter: "45"}},
+		{"Series Name Vol. 3", ParsedName{Title: "Series Name", Volume: "3"}},
+		{"Series_Name_Chapter_10.5", ParsedName{Title: "Series Name", Chapter: "10.5"}},
+		{"Chapter 001", ParsedName{Chapter: "1"}},
+		{"Ch.12", ParsedName{Chapter: "12"}},
+		{"Volume 01", ParsedName{Volume: "1"}},
+		{"Kaiju No. 8 #12", ParsedName{Title: "Kaiju No 8", Chapter: "12"}},
+		{"Oneshot", ParsedName{Title: "Oneshot"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseName(tt.name))
+		})
+	}
+}
+
+func TestParseSeriesName(t *testing.T) {
+	assert.Equal(t, "Mob Psycho 100", ParseSeriesName("[Group] Mob_Psycho_100 (2012)"))
+}
+
+func TestScanner_Scan(t *testing.T) {
+	dir := t.TempDir()
+
+	writeCBZ(t, filepath.Join(dir, "Mob Psycho 100", "Mob Psycho 100 c002.cbz"), "2.jpg", "10.jpg", "1.jpg")
+	writeCBZ(t, filepath.Join(dir, "Mob Psycho 100", "Volume 01", "Chapter 001.cbz"), "1.jpg")
+	writeFile(t, filepath.Join(dir, "Mob Psycho 100", "Extra", "01.png"))
+	writeFile(t, filepath.Join(dir, "Unknown Series v01.pdf"))
+	writeFile(t, filepath.Join(dir, "Mob Psycho 100", "notes.txt"))
+
+	collection := newMangaCollection(
+		&anilist.BaseManga{ID: 1, Title: &anilist.BaseManga_Title{Romaji: lo.ToPtr("Mob Psycho 100")}},
+		&anilist.BaseManga{ID: 2, Title: &anilist.BaseManga_Title{Romaji: lo.ToPtr("One Piece")}},
+	)
+
+	scanner := &Scanner{
+		LibraryDir:      dir,
+		MangaCollection: collection,
+		Logger:          util.NewLogger(),
+	}
+
+	chapters, err := scanner.Scan()
+	require.NoError(t, err)
+	require.Len(t, chapters, 4)
+
+	byId := make(map[string]*LocalChapter)
+	for _, c := range chapters {
+		byId[c.ID] = c
+	}
+
+	ch1 := byId["Mob Psycho 100/Volume 01/Chapter 001.cbz"]
+	require.NotNil(t, ch1)
+	assert.Equal(t, "1", ch1.Volume)
+	assert.Equal(t, "1", ch1.Chapter)
+	assert.Equal(t, 1, ch1.MediaId)
+	assert.Equal(t, FormatCBZ, ch1.Format)
+
+	ch2 := byId["Mob Psycho 100/Mob Psycho 100 c002.cbz"]
+	require.NotNil(t, ch2)
+	assert.Equal(t, "2", ch2.Chapter)
+	assert.Equal(t, 1, ch2.MediaId)
+
+	extra := byId["Mob Psycho 100/Extra"]
+	require.NotNil(t, extra)
+	assert.Equal(t, FormatImages, extra.Format)
+
+	unknown := byId["Unknown Series v01.pdf"]
+	require.NotNil(t, unknown)
+	assert.Equal(t, "Unknown Series", unknown.SeriesTitle)
+	assert.Equal(t, 0, unknown.MediaId)
+	assert.False(t, unknown.IsReadable())
+
+	// Unreadable chapters are counted separately
+	library := &LocalLibrary{chapters: chapters}
+	series := library.GetSeries()
+	unknownSeries, found := lo.Find(series, func(s *LocalSeries) bool { return s.SeriesKey == unknown.SeriesKey })
+	require.True(t, found)
+	assert.Equal(t, 0, unknownSeries.ChapterCount)
+	assert.Equal(t, 1, unknownSeries.UnsupportedChapterCount)
+
+	_, err = ListPages(unknown)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	pages, err := ListPages(ch2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.jpg", "2.jpg", "10.jpg"}, pages)
+
+	content, err := ReadPage(ch2, "10.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "10.jpg", string(content))
+
+	// Manual matches are kept by later scans
+	for _, c := range chapters {
+		if c.SeriesKey == "Mob Psycho 100" {
+			c.MediaId = 2
+			c.Locked = true
+		}
+	}
+	scanner.ExistingChapters = chapters
+	chapters, err = scanner.Scan()
+	require.NoError(t, err)
+	for _, c := range chapters {
+		if c.SeriesKey == "Mob Psycho 100" {
+			assert.Equal(t, 2, c.MediaId)
+			assert.True(t, c.Locked)
+		}
+	}
+}
+
+func TestEncodeChapterID(t *testing.T) {
+	id := "Series/Volume 01/Chapter 001.cbz"
+	encoded := EncodeChapterID(id)
+	assert.NotContains(t, encoded, "/")
+
+	decoded, err := DecodeChapterID(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, id, decoded)
+}
+
+func newMangaCollection(media ...*anilist.BaseManga) *anilist.MangaCollection {
+	entries := make([]*anilist.MangaCollection_MediaListCollection_Lists_Entries, 0, len(media))
+	for _, m := range media {
+		entries = append(entries, &anilist.MangaCollection_MediaListCollection_Lists_Entries{Media: m})
+	}
+	return &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: []*anilist.MangaCollection_MediaListCollection_Lists{{Entries: entries}},
+		},
+	}
+}
+
+func writeFile(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(filepath.Base(path)), 0644))
+}
+
+// writeCBZ creates an archive whose entries contain their own name.
+func writeCBZ(t *testing.T, path string, names ...string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
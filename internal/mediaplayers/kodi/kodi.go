@@ -0,0 +1,203 @@
+package kodi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Kodi controls a Kodi instance through its JSON-RPC HTTP API.
+// The web server must be enabled in Kodi (Settings > Services > Control > Allow remote control via HTTP).
+type Kodi struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// PathMappings maps local paths to paths Kodi can access, e.g. "/mnt/anime=smb://nas/anime".
+	// Multiple mappings are separated by new lines or semicolons.
+	PathMappings string
+	Logger       *zerolog.Logger
+
+	requestId atomic.Int64
+}
+
+type (
+	rpcRequest struct {
+		JsonRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params,omitempty"`
+		Id      int64       `json:"id"`
+	}
+
+	rpcResponse struct {
+		Id     int64           `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+
+	RPCError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("kodi: %s (%d)", e.Message, e.Code)
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+const defaultPort = 8080
+
+func (k *Kodi) url() string {
+	port := k.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	return fmt.Sprintf("http://%s:%d/jsonrpc", k.Host, port)
+}
+
+// Call sends a JSON-RPC request to Kodi and decodes the result into ret.
+// ret can be nil if the result is not needed.
+func (k *Kodi) Call(method string, params interface{}, ret interface{}) error {
+	body, err := json.Marshal(&rpcRequest{
+		JsonRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		Id:      k.requestId.Add(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, k.url(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if k.Username != "" || k.Password != "" {
+		req.SetBasicAuth(k.Username, k.Password)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		k.Logger.Error().Err(err).Str("method", method).Msg("kodi: Failed to send request")
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.New("kodi: Invalid username or password")
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("kodi: http error code: %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("kodi: error reading response: %w", err)
+	}
+
+	var rpcRes rpcResponse
+	if err := json.Unmarshal(data, &rpcRes); err != nil {
+		return fmt.Errorf("kodi: invalid response: %w", err)
+	}
+
+	if rpcRes.Error != nil {
+		k.Logger.Error().Err(rpcRes.Error).Str("method", method).Msg("kodi: Request failed")
+		return rpcRes.Error
+	}
+
+	if ret != nil && len(rpcRes.Result) > 0 {
+		if err := json.Unmarshal(rpcRes.Result, ret); err != nil {
+			return fmt.Errorf("kodi: invalid result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Start checks that Kodi is reachable.
+// Kodi usually runs on another device, so it cannot be launched by Seanime.
+func (k *Kodi) Start() error {
+	var ret string
+	if err := k.Call("JSONRPC.Ping", nil, &ret); err != nil {
+		return fmt.Errorf("kodi: could not connect to Kodi, make sure remote control via HTTP is enabled: %w", err)
+	}
+	return nil
+}
+
+// OpenAndPlay plays a local file or a stream URL.
+// Local paths are translated using the path mappings.
+func (k *Kodi) OpenAndPlay(path string) error {
+	file := k.MapPath(path)
+	k.Logger.Trace().Str("file", file).Msg("kodi: Opening and playing")
+
+	return k.Call("Player.Open", map[string]interface{}{
+		"item": map[string]interface{}{
+			"file": file,
+		},
+	}, nil)
+}
+
+// Pause pauses the video player, it does nothing if it's already paused.
+func (k *Kodi) Pause() error {
+	return k.setPlaying(false)
+}
+
+// Resume resumes the video player, it does nothing if it's already playing.
+func (k *Kodi) Resume() error {
+	return k.setPlaying(true)
+}
+
+func (k *Kodi) setPlaying(play bool) error {
+	playerId, err := k.getVideoPlayerId()
+	if err != nil {
+		return err
+	}
+
+	return k.Call("Player.PlayPause", map[string]interface{}{
+		"playerid": playerId,
+		"play":     play,
+	}, nil)
+}
+
+// Seek seeks to the given position in seconds.
+func (k *Kodi) Seek(seconds float64) error {
+	playerId, err := k.getVideoPlayerId()
+	if err != nil {
+		return err
+	}
+
+	total := int(seconds * 1000)
+	return k.Call("Player.Seek", map[string]interface{}{
+		"playerid": playerId,
+		"value": map[string]interface{}{
+			"time": &Time{
+				Hours:        total / 3_600_000,
+				Minutes:      total / 60_000 % 60,
+				Seconds:      total / 1000 % 60,
+				Milliseconds: total % 1000,
+			},
+		},
+	}, nil)
+}
+
+// Stop stops the video player.
+func (k *Kodi) Stop() error {
+	playerId, err := k.getVideoPlayerId()
+	if err != nil {
+		return err
+	}
+
+	return k.Call("Player.Stop", map[string]interface{}{
+		"playerid": playerId,
+	}, nil)
+}
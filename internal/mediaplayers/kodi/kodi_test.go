@@ -0,0 +1,187 @@
+package kodi
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"seanime/internal/util"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKodi is a minimal JSON-RPC server that mimics Kodi's video player.
+type fakeKodi struct {
+	mu      sync.Mutex
+	file    string
+	playing bool
+	seekTo  *Time
+	calls   []string
+}
+
+func (f *fakeKodi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, _ := r.BasicAuth()
+	if user != "kodi" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		Id     int64           `json:"id"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, req.Method)
+
+	var result interface{} = "OK"
+	switch req.Method {
+	case "JSONRPC.Ping":
+		result = "pong"
+	case "Player.Open":
+		var params struct {
+			Item struct {
+				File string `json:"file"`
+			} `json:"item"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		f.file = params.Item.File
+		f.playing = true
+	case "Player.GetActivePlayers":
+		if f.file == "" {
+			result = []interface{}{}
+		} else {
+			result = []interface{}{map[string]interface{}{"playerid": 1, "type": "video"}}
+		}
+	case "Player.PlayPause":
+		var params struct {
+			Play bool `json:"play"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		f.playing = params.Play
+	case "Player.Seek":
+		var params struct {
+			Value struct {
+				Time *Time `json:"time"`
+			} `json:"value"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		f.seekTo = params.Value.Time
+	case "Player.GetProperties":
+		speed := 0
+		if f.playing {
+			speed = 1
+		}
+		result = map[string]interface{}{
+			"time":       Time{Minutes: 12, Seconds: 30},
+			"totaltime":  Time{Minutes: 24},
+			"speed":      speed,
+			"percentage": 52.08,
+		}
+	case "Player.GetItem":
+		result = map[string]interface{}{"item": map[string]interface{}{"file": f.file, "label": "Episode 1", "type": "unknown"}}
+	default:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.Id, "error": map[string]interface{}{"code": -32601, "message": "Method not found."}})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.Id, "result": result})
+}
+
+func newTestKodi(t *testing.T, fake *fakeKodi) *Kodi {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	portInt, _ := strconv.Atoi(port)
+
+	return &Kodi{
+		Host:         host,
+		Port:         portInt,
+		Username:     "kodi",
+		Password:     "secret",
+		PathMappings: "/mnt/anime=smb://nas/anime",
+		Logger:       util.NewLogger(),
+	}
+}
+
+func TestKodi_Playback(t *testing.T) {
+	fake := &fakeKodi{}
+	k := newTestKodi(t, fake)
+
+	require.NoError(t, k.Start())
+
+	_, err := k.GetPlaybackStatus()
+	require.ErrorIs(t, err, ErrNoActivePlayer)
+
+	require.NoError(t, k.OpenAndPlay("/mnt/anime/Frieren/Frieren - 01.mkv"))
+	require.Equal(t, "smb://nas/anime/Frieren/Frieren - 01.mkv", fake.file)
+
+	status, err := k.GetPlaybackStatus()
+	require.NoError(t, err)
+	require.Equal(t, &Playback{
+		Filename:  "Frieren - 01.mkv",
+		Paused:    false,
+		Position:  750,
+		Duration:  1440,
+		IsRunning: true,
+		Filepath:  "/mnt/anime/Frieren/Frieren - 01.mkv",
+	}, status)
+
+	require.NoError(t, k.Pause())
+	status, err = k.GetPlaybackStatus()
+	require.NoError(t, err)
+	require.True(t, status.Paused)
+
+	require.NoError(t, k.Seek(3723.5))
+	require.Equal(t, &Time{Hours: 1, Minutes: 2, Seconds: 3, Milliseconds: 500}, fake.seekTo)
+
+	require.NoError(t, k.Resume())
+	require.True(t, fake.playing)
+
+	// Stream URLs are not mapped
+	require.NoError(t, k.OpenAndPlay("http://192.168.1.10:43211/api/v1/torrentstream/stream/Frieren%20-%2002.mkv"))
+	require.Equal(t, "http://192.168.1.10:43211/api/v1/torrentstream/stream/Frieren%20-%2002.mkv", fake.file)
+
+	require.Error(t, k.Call("Player.Unknown", nil, nil))
+}
+
+func TestKodi_InvalidCredentials(t *testing.T) {
+	k := newTestKodi(t, &fakeKodi{})
+	k.Password = "wrong"
+
+	require.Error(t, k.Start())
+}
+
+func TestKodi_MapPath(t *testing.T) {
+	k := &Kodi{
+		PathMappings: "D:\\Anime=smb://nas/anime\n/mnt/media/=/storage/media",
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "D:\\Anime\\Frieren\\01.mkv", expected: "smb://nas/anime/Frieren/01.mkv"},
+		{path: "d:/anime/Frieren/01.mkv", expected: "smb://nas/anime/Frieren/01.mkv"},
+		{path: "D:\\AnimeMovies\\01.mkv", expected: "D:\\AnimeMovies\\01.mkv"},
+		{path: "/mnt/media/Show/01.mkv", expected: "/storage/media/Show/01.mkv"},
+		{path: "http://localhost:43211/stream", expected: "http://localhost:43211/stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.expected, k.MapPath(tt.path))
+		})
+	}
+
+	require.Equal(t, "D:\\Anime\\Frieren\\01.mkv", k.UnmapPath("smb://nas/anime/Frieren/01.mkv"))
+	require.Equal(t, "/mnt/media/Show/01.mkv", k.UnmapPath("/storage/media/Show/01.mkv"))
+}
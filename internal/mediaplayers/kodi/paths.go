@@ -0,0 +1,100 @@
+package kodi
+
+import (
+	"strings"
+)
+
+type pathMapping struct {
+	Local  string
+	Remote string
+}
+
+// parsePathMappings parses "local=remote" pairs separated by new lines or semicolons.
+func parsePathMappings(value string) []*pathMapping {
+	ret := make([]*pathMapping, 0)
+	for _, line := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == '\n' || r == ';'
+	}) {
+		local, remote, found := strings.Cut(line, "=")
+		local = strings.TrimSpace(local)
+		remote = strings.TrimSpace(remote)
+		if !found || local == "" || remote == "" {
+			continue
+		}
+		ret = append(ret, &pathMapping{Local: local, Remote: remote})
+	}
+	return ret
+}
+
+// MapPath translates a local path to a path Kodi can access.
+// URLs and paths that don't match any mapping are returned unchanged.
+func (k *Kodi) MapPath(path string) string {
+	if isURL(path) {
+		return path
+	}
+
+	for _, m := range parsePathMappings(k.PathMappings) {
+		rest, ok := cutPathPrefix(path, m.Local)
+		if !ok {
+			continue
+		}
+		// Network paths (smb://, nfs://) and Linux/Android paths use forward slashes
+		if isURL(m.Remote) || strings.HasPrefix(m.Remote, "/") {
+			rest = strings.ReplaceAll(rest, "\\", "/")
+		}
+		return joinPath(m.Remote, rest)
+	}
+
+	return path
+}
+
+// UnmapPath translates a path reported by Kodi back to a local path.
+// It's the reverse of [Kodi.MapPath].
+func (k *Kodi) UnmapPath(path string) string {
+	for _, m := range parsePathMappings(k.PathMappings) {
+		rest, ok := cutPathPrefix(path, m.Remote)
+		if !ok {
+			continue
+		}
+		// Windows paths use backslashes
+		if strings.Contains(m.Local, "\\") {
+			rest = strings.ReplaceAll(rest, "/", "\\")
+		}
+		return joinPath(m.Local, rest)
+	}
+
+	return path
+}
+
+// cutPathPrefix removes the prefix from the path if it matches a whole path segment.
+// The comparison is case-insensitive and ignores the slash style.
+func cutPathPrefix(path string, prefix string) (string, bool) {
+	prefix = strings.TrimRight(prefix, "/\\")
+	if len(path) < len(prefix) || !strings.EqualFold(normalizeSlashes(path[:len(prefix)]), normalizeSlashes(prefix)) {
+		return "", false
+	}
+	rest := path[len(prefix):]
+	if rest != "" && rest[0] != '/' && rest[0] != '\\' {
+		return "", false
+	}
+	return strings.TrimLeft(rest, "/\\"), true
+}
+
+func joinPath(base string, rest string) string {
+	if rest == "" {
+		return base
+	}
+	sep := "/"
+	if strings.Contains(base, "\\") && !isURL(base) {
+		sep = "\\"
+	}
+	return strings.TrimRight(base, "/\\") + sep + rest
+}
+
+func normalizeSlashes(path string) string {
+	return strings.ReplaceAll(path, "\\", "/")
+}
+
+func isURL(path string) bool {
+	return strings.Contains(path, "://")
+}
@@ -0,0 +1,107 @@
+package kodi
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	Playback struct {
+		Filename  string
+		Paused    bool
+		Position  float64 // in seconds
+		Duration  float64 // in seconds
+		IsRunning bool
+		Filepath  string // Local path if the file matches a path mapping, otherwise the path or URL reported by Kodi
+	}
+
+	Time struct {
+		Hours        int `json:"hours"`
+		Minutes      int `json:"minutes"`
+		Seconds      int `json:"seconds"`
+		Milliseconds int `json:"milliseconds"`
+	}
+
+	activePlayer struct {
+		PlayerId int    `json:"playerid"`
+		Type     string `json:"type"`
+	}
+
+	playerProperties struct {
+		Time       Time    `json:"time"`
+		TotalTime  Time    `json:"totaltime"`
+		Speed      int     `json:"speed"`
+		Percentage float64 `json:"percentage"`
+	}
+
+	playerItem struct {
+		Item struct {
+			File  string `json:"file"`
+			Label string `json:"label"`
+		} `json:"item"`
+	}
+)
+
+var ErrNoActivePlayer = errors.New("kodi: No video is playing")
+
+func (t Time) InSeconds() float64 {
+	return float64(t.Hours*3600+t.Minutes*60+t.Seconds) + float64(t.Milliseconds)/1000
+}
+
+// GetPlaybackStatus returns the status of the video player.
+func (k *Kodi) GetPlaybackStatus() (*Playback, error) {
+	playerId, err := k.getVideoPlayerId()
+	if err != nil {
+		return nil, err
+	}
+
+	var props playerProperties
+	err = k.Call("Player.GetProperties", map[string]interface{}{
+		"playerid":   playerId,
+		"properties": []string{"time", "totaltime", "speed", "percentage"},
+	}, &props)
+	if err != nil {
+		return nil, err
+	}
+
+	var item playerItem
+	err = k.Call("Player.GetItem", map[string]interface{}{
+		"playerid":   playerId,
+		"properties": []string{"file"},
+	}, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Playback{
+		Paused:    props.Speed == 0,
+		Position:  props.Time.InSeconds(),
+		Duration:  props.TotalTime.InSeconds(),
+		IsRunning: true,
+		Filepath:  k.UnmapPath(item.Item.File),
+	}
+
+	ret.Filename = item.Item.Label
+	if name := filepath.Base(strings.ReplaceAll(item.Item.File, "\\", "/")); item.Item.File != "" && !strings.Contains(name, "?") {
+		ret.Filename = name
+	}
+
+	return ret, nil
+}
+
+// getVideoPlayerId returns the id of the active video player.
+func (k *Kodi) getVideoPlayerId() (int, error) {
+	var players []*activePlayer
+	if err := k.Call("Player.GetActivePlayers", nil, &players); err != nil {
+		return 0, err
+	}
+
+	for _, p := range players {
+		if p.Type == "video" {
+			return p.PlayerId, nil
+		}
+	}
+
+	return 0, ErrNoActivePlayer
+}
@@ -7,6 +7,7 @@ import (
 	"seanime/internal/continuity"
 	"seanime/internal/events"
 	"seanime/internal/hook"
+	"seanime/internal/mediaplayers/kodi"
 	mpchc2 "seanime/internal/mediaplayers/mpchc"
 	"seanime/internal/mediaplayers/mpv"
 	vlc2 "seanime/internal/mediaplayers/vlc"
@@ -29,6 +30,7 @@ type (
 		VLC                   *vlc2.VLC
 		MpcHc                 *mpchc2.MpcHc
 		Mpv                   *mpv.Mpv
+		Kodi                  *kodi.Kodi
 		wsEventManager        events.WSEventManagerInterface
 		continuityManager     *continuity.Manager
 		playerInUse           string
@@ -47,6 +49,7 @@ type (
 		VLC               *vlc2.VLC
 		MpcHc             *mpchc2.MpcHc
 		Mpv               *mpv.Mpv
+		Kodi              *kodi.Kodi
 		WSEventManager    events.WSEventManagerInterface
 		ContinuityManager *continuity.Manager
 	}
@@ -86,6 +89,7 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 		VLC:                   opts.VLC,
 		MpcHc:                 opts.MpcHc,
 		Mpv:                   opts.Mpv,
+		Kodi:                  opts.Kodi,
 		wsEventManager:        opts.WSEventManager,
 		continuityManager:     opts.ContinuityManager,
 		completionThreshold:   0.8,
@@ -227,6 +231,21 @@ func (m *Repository) Play(path string) error {
 			}
 		}
 
+		return nil
+	case "kodi":
+		err := m.Kodi.OpenAndPlay(path)
+		if err != nil {
+			m.Logger.Error().Err(err).Msg("media player: Could not open and play video using Kodi")
+			return fmt.Errorf("could not open and play video, %w", err)
+		}
+
+		if m.continuityManager.GetSettings().WatchContinuityEnabled {
+			if lastWatched.Found {
+				time.Sleep(1 * time.Second)
+				_ = m.Kodi.Seek(lastWatched.Item.CurrentTime)
+			}
+		}
+
 		return nil
 	default:
 		return errors.New("no default media player set")
@@ -234,6 +253,43 @@ func (m *Repository) Play(path string) error {
 
 }
 
+// AddSubtitles adds external subtitle files to the file being played.
+// Players are given some time to load the file, the subtitles are skipped if it takes too long.
+// MPC-HC and Kodi don't support adding subtitles remotely.
+func (m *Repository) AddSubtitles(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	var addSubtitle func(path string) error
+	switch m.Default {
+	case "vlc":
+		addSubtitle = m.VLC.AddSubtitle
+	case "mpv":
+		addSubtitle = m.Mpv.AddSubtitle
+	default:
+		m.Logger.Debug().Str("player", m.Default).Msg("media player: Adding subtitles is not supported")
+		return
+	}
+
+	go func() {
+		for _, path := range paths {
+			var err error
+			for i := 0; i < 5; i++ {
+				time.Sleep(500 * time.Millisecond)
+				if err = addSubtitle(path); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				m.Logger.Warn().Err(err).Str("path", path).Msg("media player: Could not add subtitle")
+				continue
+			}
+			m.Logger.Debug().Str("path", path).Msg("media player: Added subtitle")
+		}
+	}()
+}
+
 func (m *Repository) Pause() error {
 	switch m.Default {
 	case "vlc":
@@ -242,6 +298,8 @@ func (m *Repository) Pause() error {
 		return m.MpcHc.Pause()
 	case "mpv":
 		return m.Mpv.Pause()
+	case "kodi":
+		return m.Kodi.Pause()
 	default:
 		return errors.New("no default media player set")
 	}
@@ -255,6 +313,8 @@ func (m *Repository) Resume() error {
 		return m.MpcHc.Play()
 	case "mpv":
 		return m.Mpv.Resume()
+	case "kodi":
+		return m.Kodi.Resume()
 	default:
 		return errors.New("no default media player set")
 	}
@@ -268,6 +328,8 @@ func (m *Repository) Seek(seconds float64) error {
 		return m.MpcHc.Seek(int(seconds))
 	case "mpv":
 		return m.Mpv.Seek(seconds)
+	case "kodi":
+		return m.Kodi.Seek(seconds)
 	default:
 		return errors.New("no default media player set")
 	}
@@ -286,6 +348,9 @@ func (m *Repository) Stream(streamUrl string, episode int, mediaId int, windowTi
 		_, err = m.MpcHc.OpenAndPlay(streamUrl)
 	case "mpv":
 		// MPV does not need to be started
+	case "kodi":
+		// Kodi cannot be started, only check that it's reachable
+		err = m.Kodi.Start()
 	default:
 		return errors.New("no default media player set")
 	}
@@ -340,6 +405,16 @@ func (m *Repository) Stream(streamUrl string, episode int, mediaId int, windowTi
 			err = m.Mpv.OpenAndPlay(streamUrl, args...)
 		}
 
+	case "kodi":
+		err = m.Kodi.OpenAndPlay(streamUrl)
+
+		if err == nil && m.continuityManager.GetSettings().WatchContinuityEnabled {
+			if lastWatched.Found {
+				time.Sleep(1 * time.Second)
+				_ = m.Kodi.Seek(lastWatched.Item.CurrentTime)
+			}
+		}
+
 	}
 
 	if err != nil {
@@ -746,6 +821,8 @@ func (m *Repository) getStatus() (interface{}, error) {
 		return m.MpcHc.GetVariables()
 	case "mpv":
 		return m.Mpv.GetPlaybackStatus()
+	case "kodi":
+		return m.Kodi.GetPlaybackStatus()
 	}
 	return nil, errors.New("unsupported media player")
 }
@@ -801,6 +878,23 @@ func (m *Repository) processStatus(player string, status interface{}) bool {
 		m.currentPlaybackStatus.CurrentTimeInSeconds = st.Position
 		m.currentPlaybackStatus.DurationInSeconds = st.Duration
 
+		return true
+	case "kodi":
+		// Process Kodi status
+		st, ok := status.(*kodi.Playback)
+		if !ok || st == nil || st.Duration == 0 || !st.IsRunning {
+			return false
+		}
+
+		m.currentPlaybackStatus.CompletionPercentage = st.Position / st.Duration
+		m.currentPlaybackStatus.Playing = !st.Paused
+		m.currentPlaybackStatus.Filename = st.Filename
+		m.currentPlaybackStatus.Duration = int(st.Duration * 1000)
+		m.currentPlaybackStatus.Filepath = st.Filepath
+
+		m.currentPlaybackStatus.CurrentTimeInSeconds = st.Position
+		m.currentPlaybackStatus.DurationInSeconds = st.Duration
+
 		return true
 	default:
 		return false
@@ -859,6 +953,23 @@ func (m *Repository) processStreamStatus(player string, status interface{}) bool
 		m.currentPlaybackStatus.CurrentTimeInSeconds = st.Position
 		m.currentPlaybackStatus.DurationInSeconds = st.Duration
 
+		return true
+	case "kodi":
+		// Process Kodi status
+		st, ok := status.(*kodi.Playback)
+		if !ok || st == nil || st.Duration == 0 || !st.IsRunning {
+			return false
+		}
+
+		m.currentPlaybackStatus.CompletionPercentage = st.Position / st.Duration
+		m.currentPlaybackStatus.Playing = !st.Paused
+		m.currentPlaybackStatus.Filename = st.Filename
+		m.currentPlaybackStatus.Duration = int(st.Duration * 1000)
+		m.currentPlaybackStatus.Filepath = st.Filepath
+
+		m.currentPlaybackStatus.CurrentTimeInSeconds = st.Position
+		m.currentPlaybackStatus.DurationInSeconds = st.Duration
+
 		return true
 	default:
 		return false
@@ -2,7 +2,9 @@ package mediaplayer
 
 import (
 	"github.com/stretchr/testify/assert"
+	"seanime/internal/mediaplayers/kodi"
 	"seanime/internal/test_utils"
+	"seanime/internal/util"
 	"testing"
 	"time"
 )
@@ -22,3 +24,29 @@ func TestRepository_StartTracking(t *testing.T) {
 		repo.Stop()
 	}()
 }
+
+func TestRepository_ProcessKodiStatus(t *testing.T) {
+	repo := NewRepository(&NewRepositoryOptions{
+		Logger:  util.NewLogger(),
+		Default: "kodi",
+		Kodi:    &kodi.Kodi{},
+	})
+
+	ok := repo.processStatus("kodi", &kodi.Playback{
+		Filename:  "Frieren - 01.mkv",
+		Position:  1200,
+		Duration:  1440,
+		IsRunning: true,
+		Filepath:  "/mnt/anime/Frieren/Frieren - 01.mkv",
+	})
+	assert.True(t, ok)
+
+	status := repo.GetStatus()
+	assert.InDelta(t, 0.833, status.CompletionPercentage, 0.001)
+	assert.True(t, status.Playing)
+	assert.Equal(t, 1440000, status.Duration)
+	assert.Equal(t, "/mnt/anime/Frieren/Frieren - 01.mkv", status.Filepath)
+
+	// Not playing anything
+	assert.False(t, repo.processStreamStatus("kodi", &kodi.Playback{}))
+}
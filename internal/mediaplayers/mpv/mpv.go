@@ -270,6 +270,24 @@ func (m *Mpv) Resume() error {
 	return nil
 }
 
+// AddSubtitle adds an external subtitle file to the file being played.
+func (m *Mpv) AddSubtitle(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conn == nil || m.conn.IsClosed() {
+		return errors.New("mpv is not running")
+	}
+
+	// "auto" adds the track without selecting it
+	_, err := m.conn.Call("sub-add", path, "auto")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SeekTo seeks to the given position in the file by first pausing the player and unpausing it after seeking.
 func (m *Mpv) SeekTo(position float64) error {
 	m.mu.Lock()
@@ -6,10 +6,15 @@ import (
 	"path/filepath"
 	"seanime/internal/events"
 	"seanime/internal/mediastream/videofile"
+	"seanime/internal/subtitles"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
+// externalSubtitlesLinkPrefix is the prefix of the links of the subtitles downloaded from subtitle providers.
+const externalSubtitlesLinkPrefix = "/external/"
+
 func (r *Repository) ServeEchoExtractedSubtitles(c echo.Context) error {
 
 	if !r.IsInitialized() {
@@ -31,6 +36,15 @@ func (r *Repository) ServeEchoExtractedSubtitles(c echo.Context) error {
 		return errors.New("no file has been loaded")
 	}
 
+	// Subtitles downloaded from subtitle providers
+	if filename, ok := strings.CutPrefix(subFilePath, strings.TrimPrefix(externalSubtitlesLinkPrefix, "/")); ok {
+		filename, _ = url.PathUnescape(filename)
+		if filename == "" || filename != filepath.Base(filename) {
+			return errors.New("invalid subtitle path")
+		}
+		return c.File(filepath.Join(subtitles.GetCacheDir(r.cacheDir, mediaContainer.Hash), filename))
+	}
+
 	retPath := videofile.GetFileSubsCacheDir(r.cacheDir, mediaContainer.Hash)
 
 	if retPath == "" {
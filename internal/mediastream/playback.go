@@ -3,10 +3,14 @@ package mediastream
 import (
 	"errors"
 	"fmt"
-	"github.com/rs/zerolog"
-	"github.com/samber/mo"
 	"seanime/internal/mediastream/videofile"
+	"seanime/internal/subtitles"
 	"seanime/internal/util/result"
+	"slices"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
 )
 
 const (
@@ -114,7 +118,8 @@ func (p *PlaybackManager) newMediaContainer(filepath string, streamType StreamTy
 	// Check the cache ONLY if the stream type is the same.
 	if mc, ok := p.mediaContainers.Get(hash); ok && mc.StreamType == streamType {
 		p.logger.Debug().Str("hash", hash).Msg("mediastream: Media container cache HIT")
-		return mc, nil
+		// Subtitles might have been downloaded since the container was created
+		return p.withExternalSubtitles(mc), nil
 	}
 
 	p.logger.Trace().Str("hash", hash).Msg("mediastream: Creating media container")
@@ -171,5 +176,39 @@ func (p *PlaybackManager) newMediaContainer(filepath string, streamType StreamTy
 	// Store the media container in the map.
 	p.mediaContainers.Set(hash, ret)
 
-	return
+	return p.withExternalSubtitles(ret), nil
+}
+
+// withExternalSubtitles returns a copy of the media container with the subtitles downloaded from subtitle providers
+// added as external tracks.
+func (p *PlaybackManager) withExternalSubtitles(mc *MediaContainer) *MediaContainer {
+	if mc.MediaInfo == nil {
+		return mc
+	}
+
+	tracks := subtitles.ReadTracks(p.repository.cacheDir, mc.Hash)
+	if len(tracks) == 0 {
+		return mc
+	}
+
+	mediaInfo := *mc.MediaInfo
+	mediaInfo.Subtitles = slices.Clone(mc.MediaInfo.Subtitles)
+
+	index := uint32(len(mediaInfo.Subtitles))
+	for _, track := range tracks {
+		mediaInfo.Subtitles = append(mediaInfo.Subtitles, videofile.Subtitle{
+			Index:      index,
+			Title:      lo.ToPtr(fmt.Sprintf("%s (%s)", track.Name, track.Provider)),
+			Language:   lo.EmptyableToPtr(track.Language),
+			Codec:      track.Format,
+			Extension:  lo.ToPtr(track.Format),
+			IsExternal: true,
+			Link:       lo.ToPtr(externalSubtitlesLinkPrefix + track.Filename),
+		})
+		index++
+	}
+
+	ret := *mc
+	ret.MediaInfo = &mediaInfo
+	return &ret
 }
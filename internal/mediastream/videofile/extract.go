@@ -18,6 +18,11 @@ func GetFileAttCacheDir(outDir string, hash string) string {
 	return filepath.Join(outDir, "videofiles", hash, "/att")
 }
 
+// GetFileExternalSubsCacheDir returns the directory where subtitles fetched from subtitle providers are stored.
+func GetFileExternalSubsCacheDir(outDir string, hash string) string {
+	return filepath.Join(outDir, "videofiles", hash, "/extsubs")
+}
+
 func ExtractAttachment(ffmpegPath string, path string, hash string, mediaInfo *MediaInfo, cacheDir string, logger *zerolog.Logger) (err error) {
 	logger.Debug().Str("hash", hash).Msgf("videofile: Starting media attachment extraction")
 
@@ -21,9 +21,11 @@ type (
 )
 
 const (
-	AutoDownloader Notification = "Auto Downloader"
-	AutoScanner    Notification = "Auto Scanner"
-	Debrid         Notification = "Debrid"
+	AutoDownloader      Notification = "Auto Downloader"
+	AutoScanner         Notification = "Auto Scanner"
+	Debrid              Notification = "Debrid"
+	MangaAutoDownloader Notification = "Manga Auto Downloader"
+	MangaReleaseTracker Notification = "Manga Release Tracker"
 )
 
 var GlobalNotifier = NewNotifier()
@@ -64,10 +66,12 @@ func (n *Notifier) canProceed(id Notification) bool {
 	}
 
 	switch id {
-	case AutoDownloader:
+	case AutoDownloader, MangaAutoDownloader:
 		return !n.settings.MustGet().DisableAutoDownloaderNotifications
 	case AutoScanner:
 		return !n.settings.MustGet().DisableAutoScannerNotifications
+	case MangaReleaseTracker:
+		return true
 	}
 
 	return false
@@ -86,6 +86,11 @@ func (ap *AnilistPlatform) UpdateEntry(mediaID int, status *anilist.MediaListSta
 
 	postEvent := new(PostUpdateEntryEvent)
 	postEvent.MediaID = &mediaID
+	postEvent.Status = event.Status
+	postEvent.ScoreRaw = event.ScoreRaw
+	postEvent.Progress = event.Progress
+	postEvent.StartedAt = event.StartedAt
+	postEvent.CompletedAt = event.CompletedAt
 
 	err = hook.GlobalHookManager.OnPostUpdateEntry().Trigger(postEvent)
 
@@ -115,21 +120,10 @@ func (ap *AnilistPlatform) UpdateEntryProgress(mediaID int, progress int, totalC
 		realTotalCount = *totalCount
 	}
 
-	// Check if the anime is in the repeating list
+	// Check if the media is in the repeating list
 	// If it is, set the status to repeating
-	if ap.rawAnimeCollection.IsPresent() {
-		for _, list := range ap.rawAnimeCollection.MustGet().MediaListCollection.Lists {
-			if list.Status != nil && *list.Status == anilist.MediaListStatusRepeating {
-				if list.Entries != nil {
-					for _, entry := range list.Entries {
-						if entry.GetMedia().GetID() == mediaID {
-							*event.Status = anilist.MediaListStatusRepeating
-							break
-						}
-					}
-				}
-			}
-		}
+	if ap.isRepeating(mediaID) {
+		*event.Status = anilist.MediaListStatusRepeating
 	}
 	if realTotalCount > 0 && progress >= realTotalCount {
 		*event.Status = anilist.MediaListStatusCompleted
@@ -151,6 +145,9 @@ func (ap *AnilistPlatform) UpdateEntryProgress(mediaID int, progress int, totalC
 
 	postEvent := new(PostUpdateEntryProgressEvent)
 	postEvent.MediaID = &mediaID
+	postEvent.Progress = event.Progress
+	postEvent.TotalCount = event.TotalCount
+	postEvent.Status = event.Status
 
 	err = hook.GlobalHookManager.OnPostUpdateEntryProgress().Trigger(postEvent)
 	if err != nil {
@@ -160,6 +157,22 @@ func (ap *AnilistPlatform) UpdateEntryProgress(mediaID int, progress int, totalC
 	return nil
 }
 
+// isRepeating returns true if the media is in the repeating list of the cached anime or manga collection.
+// AniList media IDs are unique across types, so the manga collection is checked if the media isn't in the anime collection.
+func (ap *AnilistPlatform) isRepeating(mediaID int) bool {
+	if ap.rawAnimeCollection.IsPresent() {
+		if entry, ok := ap.rawAnimeCollection.MustGet().GetListEntryFromAnimeId(mediaID); ok {
+			return entry.GetStatus() != nil && *entry.GetStatus() == anilist.MediaListStatusRepeating
+		}
+	}
+	if ap.rawMangaCollection.IsPresent() {
+		if entry, ok := ap.rawMangaCollection.MustGet().GetListEntryFromMangaId(mediaID); ok {
+			return entry.GetStatus() != nil && *entry.GetStatus() == anilist.MediaListStatusRepeating
+		}
+	}
+	return false
+}
+
 func (ap *AnilistPlatform) UpdateEntryRepeat(mediaID int, repeat int) error {
 	ap.logger.Trace().Msg("anilist platform: Updating entry repeat")
 
@@ -86,9 +86,16 @@ type PreUpdateEntryEvent struct {
 	CompletedAt *anilist.FuzzyDateInput  `json:"completedAt"`
 }
 
+// PostUpdateEntryEvent is triggered after an entry is updated.
+// The fields are the values sent to AniList, after the changes made in PreUpdateEntryEvent.
 type PostUpdateEntryEvent struct {
 	hook_resolver.Event
-	MediaID *int `json:"mediaId"`
+	MediaID     *int                     `json:"mediaId"`
+	Status      *anilist.MediaListStatus `json:"status"`
+	ScoreRaw    *int                     `json:"scoreRaw"`
+	Progress    *int                     `json:"progress"`
+	StartedAt   *anilist.FuzzyDateInput  `json:"startedAt"`
+	CompletedAt *anilist.FuzzyDateInput  `json:"completedAt"`
 }
 
 // PreUpdateEntryProgressEvent is triggered when an entry's progress is about to be updated.
@@ -102,9 +109,14 @@ type PreUpdateEntryProgressEvent struct {
 	Status *anilist.MediaListStatus `json:"status"`
 }
 
+// PostUpdateEntryProgressEvent is triggered after an entry's progress is updated.
+// The fields are the values sent to AniList, after the changes made in PreUpdateEntryProgressEvent.
 type PostUpdateEntryProgressEvent struct {
 	hook_resolver.Event
-	MediaID *int `json:"mediaId"`
+	MediaID    *int                     `json:"mediaId"`
+	Progress   *int                     `json:"progress"`
+	TotalCount *int                     `json:"totalCount"`
+	Status     *anilist.MediaListStatus `json:"status"`
 }
 
 // PreUpdateEntryRepeatEvent is triggered when an entry's repeat is about to be updated.
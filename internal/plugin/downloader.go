@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"seanime/internal/extension"
+	"seanime/internal/goja/goja_bindings"
 	goja_util "seanime/internal/util/goja"
 	"sync"
 	"time"
@@ -136,6 +137,11 @@ func (a *AppContextImpl) BindDownloaderToContextObj(vm *goja.Runtime, obj *goja.
 			return "", ErrPathNotAuthorized
 		}
 
+		// Same restrictions as fetch, the hosts must be allowed by the network permissions of the plugin
+		if err := goja_bindings.CheckFetchURL(ext.Network, url); err != nil {
+			return "", err
+		}
+
 		// Generate unique download ID
 		downloadID := uuid.New().String()
 
@@ -190,8 +196,8 @@ func (a *AppContextImpl) BindDownloaderToContextObj(vm *goja.Runtime, obj *goja.
 				}
 			}
 
-			// Execute request
-			client := &http.Client{}
+			// Execute request, the resolved addresses and redirects are checked against the network permissions
+			client, _ := goja_bindings.GetRestrictedClients(ext.Network)
 			resp, err := client.Do(req)
 			if err != nil {
 				progress.Status = string(DownloadStatusError)
@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"seanime/internal/extension"
+	"seanime/internal/util"
+	goja_util "seanime/internal/util/goja"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader_NetworkPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	download := func(t *testing.T, network *extension.NetworkPermissions, destination string) error {
+		ext := &extension.Extension{
+			ID:      "download-plugin",
+			Network: network,
+			Plugin: &extension.PluginManifest{
+				Permissions: extension.PluginPermissions{
+					Scopes: []extension.PluginPermissionScope{extension.PluginPermissionSystem},
+					Allow:  extension.PluginAllowlist{WritePaths: []string{filepath.ToSlash(dir) + "/**"}},
+				},
+			},
+		}
+
+		vm := goja.New()
+		scheduler := goja_util.NewScheduler()
+		t.Cleanup(scheduler.Stop)
+		obj := vm.NewObject()
+		newMockAppContext(nil).BindDownloaderToContextObj(vm, obj, util.NewLogger(), ext, scheduler)
+		require.NoError(t, vm.Set("$downloader", obj.Get("downloader")))
+		require.NoError(t, vm.Set("url", server.URL))
+		require.NoError(t, vm.Set("destination", destination))
+
+		_, err := vm.RunString(`$downloader.download(url, destination, {})`)
+		return err
+	}
+
+	t.Run("loopback is not allowed by default", func(t *testing.T) {
+		destination := filepath.Join(dir, "blocked.txt")
+		err := download(t, nil, destination)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not allowed")
+		assert.NoFileExists(t, destination)
+	})
+
+	t.Run("allowed by the network permissions", func(t *testing.T) {
+		destination := filepath.Join(dir, "allowed.txt")
+		require.NoError(t, download(t, &extension.NetworkPermissions{AllowedCIDRs: []string{"127.0.0.0/8", "::1/128"}}, destination))
+		require.Eventually(t, func() bool {
+			content, err := os.ReadFile(destination)
+			return err == nil && string(content) == "content"
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+}
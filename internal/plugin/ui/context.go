@@ -61,6 +61,7 @@ type Context struct {
 	commandPaletteManager *CommandPaletteManager // Register and manage command palette
 	domManager            *DOMManager            // DOM manipulation manager
 	notificationManager   *NotificationManager   // Register and manage notifications
+	httpRouteManager      *HTTPRouteManager      // Register and handle HTTP routes
 
 	atomicCleanupCounter atomic.Int64
 	onCleanupFns         *result.Map[int64, func()]
@@ -118,6 +119,7 @@ func NewContext(ui *UI) *Context {
 	ret.commandPaletteManager = NewCommandPaletteManager(ret)
 	ret.domManager = NewDOMManager(ret)
 	ret.notificationManager = NewNotificationManager(ret)
+	ret.httpRouteManager = NewHTTPRouteManager(ret)
 
 	return ret
 }
@@ -187,6 +189,9 @@ func (c *Context) createAndBindContextObject(vm *goja.Runtime) {
 			case extension.PluginPermissionTorrentClient:
 				// Bind torrent client to the context object
 				plugin.GlobalAppContext.BindTorrentClientToContextObj(vm, obj, c.logger, c.ext, c.scheduler)
+			case extension.PluginPermissionHTTPRoutes:
+				// Bind HTTP routes to the context object
+				c.httpRouteManager.bind(obj)
 			}
 		}
 	}
@@ -7,7 +7,7 @@ import (
 )
 
 func (c *Context) bindFetch(obj *goja.Object) {
-	f := goja_bindings.NewFetch(c.vm)
+	f := goja_bindings.NewFetch(c.vm, c.ext.Network)
 
 	_ = obj.Set("fetch", f.Fetch)
 
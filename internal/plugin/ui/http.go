@@ -0,0 +1,397 @@
+package plugin_ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/goccy/go-json"
+)
+
+var (
+	ErrHTTPRouteNotFound = errors.New("plugin: route not found")
+	ErrHTTPRouteTimeout  = errors.New("plugin: route handler timed out")
+)
+
+const (
+	HTTPRouteTimeout = 30 * time.Second // Maximum time a route handler can take to respond, including its promise
+	// A job still running on the scheduler when a route times out while its promise is pending is considered stuck
+	// if it has been running for this long, it is most likely the handler's continuation
+	httpRouteStuckJobDuration = time.Second
+)
+
+const (
+	httpJobPending = iota
+	httpJobRunning
+	httpJobDone
+	httpJobCanceled
+)
+
+// HTTPRequest is a request sent to a route registered by a plugin.
+// Path is relative to /api/v1/plugins/{id}.
+type HTTPRequest struct {
+	Method  string
+	Path    string
+	Query   map[string]string
+	Headers map[string]string
+	Body    []byte
+}
+
+// HTTPResponse is the response returned by a plugin route handler.
+type HTTPResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+type httpRoute struct {
+	method   string   // Uppercase method, "*" matches any method
+	segments []string // Path segments, ":name" captures a segment and a trailing "*" captures the rest of the path
+	handler  goja.Callable
+}
+
+// HTTPRouteManager lets plugins register routes under /api/v1/plugins/{id}.
+// Handlers are called on the plugin's scheduler.
+type HTTPRouteManager struct {
+	ctx    *Context
+	mu     sync.RWMutex
+	routes []*httpRoute
+}
+
+func NewHTTPRouteManager(ctx *Context) *HTTPRouteManager {
+	return &HTTPRouteManager{
+		ctx:    ctx,
+		routes: make([]*httpRoute, 0),
+	}
+}
+
+func (m *HTTPRouteManager) bind(contextObj *goja.Object) {
+	httpObj := m.ctx.vm.NewObject()
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		_ = httpObj.Set(strings.ToLower(method), m.jsRegisterMethod(method))
+	}
+	_ = httpObj.Set("handle", m.jsHandle)
+
+	_ = contextObj.Set("http", httpObj)
+
+	m.ctx.registerOnCleanup(func() {
+		m.ctx.logger.Trace().Msg("plugin: Removing HTTP routes")
+		m.mu.Lock()
+		m.routes = make([]*httpRoute, 0)
+		m.mu.Unlock()
+	})
+}
+
+// jsRegisterMethod returns a function that registers a route for the given method
+//
+//	Example:
+//	ctx.http.get("/items/:id", (req) => {
+//		return { status: 200, body: { id: req.params.id } }
+//	})
+func (m *HTTPRouteManager) jsRegisterMethod(method string) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		m.register(method, call.Argument(0), call.Argument(1))
+		return goja.Undefined()
+	}
+}
+
+// jsHandle registers a route for any method, "*" matches all methods
+//
+//	Example:
+//	ctx.http.handle("*", "/proxy/*", (req) => {
+//		return { body: req.params["*"] }
+//	})
+func (m *HTTPRouteManager) jsHandle(call goja.FunctionCall) goja.Value {
+	method, ok := call.Argument(0).Export().(string)
+	if !ok || method == "" {
+		m.ctx.handleTypeError("http.handle: method must be a string")
+	}
+	m.register(strings.ToUpper(method), call.Argument(1), call.Argument(2))
+	return goja.Undefined()
+}
+
+func (m *HTTPRouteManager) register(method string, pathValue goja.Value, handlerValue goja.Value) {
+	path, ok := pathValue.Export().(string)
+	if !ok {
+		m.ctx.handleTypeError("http: path must be a string")
+	}
+	handler, ok := goja.AssertFunction(handlerValue)
+	if !ok {
+		m.ctx.handleTypeError("http: handler must be a function")
+	}
+
+	segments := splitRoutePath(path)
+	for i, segment := range segments {
+		if segment == "*" && i != len(segments)-1 {
+			m.ctx.handleTypeError("http: wildcard must be the last segment of the path")
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Replace the route if it's already registered
+	for _, route := range m.routes {
+		if route.method == method && strings.Join(route.segments, "/") == strings.Join(segments, "/") {
+			route.handler = handler
+			return
+		}
+	}
+
+	m.routes = append(m.routes, &httpRoute{
+		method:   method,
+		segments: segments,
+		handler:  handler,
+	})
+}
+
+// match returns the first route matching the method and path, and the captured params.
+// It returns false for the second value if a route matches the path but not the method.
+func (m *HTTPRouteManager) match(method string, path string) (*httpRoute, map[string]string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	segments := splitRoutePath(path)
+	pathMatched := false
+
+	for _, route := range m.routes {
+		params, ok := matchRouteSegments(route.segments, segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if route.method == "*" || route.method == method || (method == http.MethodHead && route.method == http.MethodGet) {
+			return route, params, true
+		}
+	}
+
+	return nil, nil, pathMatched
+}
+
+func splitRoutePath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+func matchRouteSegments(pattern []string, segments []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, p := range pattern {
+		if p == "*" {
+			params["*"] = strings.Join(segments[i:], "/")
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if strings.HasPrefix(p, ":") {
+			params[p[1:]] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// Handle calls the handler of the route matching the request.
+// The handler runs on the plugin's scheduler, if it returns a promise, it is awaited.
+// It returns ErrHTTPRouteNotFound if no route matches and ErrHTTPRouteTimeout if the handler doesn't respond in time.
+// On timeout, the handler is not called if it's still queued, and interrupted if it's still running so that it doesn't block the scheduler.
+func (m *HTTPRouteManager) Handle(ctx context.Context, req *HTTPRequest) (*HTTPResponse, error) {
+	route, params, ok := m.match(strings.ToUpper(req.Method), req.Path)
+	if route == nil {
+		if ok {
+			return &HTTPResponse{Status: http.StatusMethodNotAllowed}, nil
+		}
+		return nil, ErrHTTPRouteNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, HTTPRouteTimeout)
+	defer cancel()
+
+	// Call the handler
+	var stateMu sync.Mutex
+	state := httpJobPending
+	valueCh := make(chan goja.Value, 1)
+	errCh := make(chan error, 1)
+	m.ctx.scheduler.ScheduleAsync(func() error {
+		stateMu.Lock()
+		if state == httpJobCanceled {
+			stateMu.Unlock()
+			return nil
+		}
+		state = httpJobRunning
+		stateMu.Unlock()
+
+		defer func() {
+			stateMu.Lock()
+			state = httpJobDone
+			stateMu.Unlock()
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("http: handler panicked: %v", r)
+			}
+		}()
+		value, err := route.handler(goja.Undefined(), m.newJSRequest(req, params))
+		if err != nil {
+			errCh <- err
+			return nil
+		}
+		valueCh <- value
+		return nil
+	})
+
+	var value goja.Value
+	select {
+	case <-ctx.Done():
+		stateMu.Lock()
+		switch state {
+		case httpJobPending:
+			state = httpJobCanceled
+		case httpJobRunning:
+			m.ctx.scheduler.Interrupt(m.ctx.vm, ErrHTTPRouteTimeout, 0)
+		}
+		stateMu.Unlock()
+		return nil, ErrHTTPRouteTimeout
+	case err := <-errCh:
+		return nil, err
+	case value = <-valueCh:
+	}
+
+	// Wait for the promise to settle
+	if promise, isPromise := value.Export().(*goja.Promise); isPromise {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+
+		for promise.State() == goja.PromiseStatePending {
+			select {
+			case <-ctx.Done():
+				// The continuation runs in another job, interrupt it if it's blocking the scheduler
+				if m.ctx.scheduler.Interrupt(m.ctx.vm, ErrHTTPRouteTimeout, httpRouteStuckJobDuration) {
+					m.ctx.logger.Warn().Msg("plugin: Interrupted a route handler that blocked the scheduler")
+				}
+				return nil, ErrHTTPRouteTimeout
+			case <-ticker.C:
+			}
+		}
+		if promise.State() == goja.PromiseStateRejected {
+			return nil, fmt.Errorf("promise rejected: %v", promise.Result())
+		}
+		value = promise.Result()
+	}
+
+	// Read the response on the scheduler since it uses the VM
+	var res *HTTPResponse
+	deadline, _ := ctx.Deadline()
+	err := m.ctx.scheduler.ScheduleWithTimeout(func() (err error) {
+		res, err = m.toResponse(value)
+		return err
+	}, time.Until(deadline))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// newJSRequest creates the request object passed to the handler.
+func (m *HTTPRouteManager) newJSRequest(req *HTTPRequest, params map[string]string) *goja.Object {
+	vm := m.ctx.vm
+	obj := vm.NewObject()
+	_ = obj.Set("method", strings.ToUpper(req.Method))
+	_ = obj.Set("path", "/"+strings.Trim(req.Path, "/"))
+	_ = obj.Set("params", params)
+	_ = obj.Set("query", orEmpty(req.Query))
+	_ = obj.Set("headers", orEmpty(req.Headers))
+	_ = obj.Set("body", string(req.Body))
+	_ = obj.Set("json", func() goja.Value {
+		var data interface{}
+		if err := json.Unmarshal(req.Body, &data); err != nil {
+			panic(vm.NewTypeError("http: invalid JSON body: %s", err.Error()))
+		}
+		return vm.ToValue(data)
+	})
+	return obj
+}
+
+func orEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// toResponse converts the value returned by a handler.
+//   - undefined or null: 204 No Content
+//   - string: 200 with a text body
+//   - { status, headers, body }: a string body is sent as is, other values are encoded as JSON
+func (m *HTTPRouteManager) toResponse(value goja.Value) (*HTTPResponse, error) {
+	res := &HTTPResponse{
+		Status:  http.StatusOK,
+		Headers: make(map[string]string),
+	}
+
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		res.Status = http.StatusNoContent
+		return res, nil
+	}
+
+	if s, ok := value.Export().(string); ok {
+		res.Headers["Content-Type"] = "text/plain; charset=utf-8"
+		res.Body = []byte(s)
+		return res, nil
+	}
+
+	obj := value.ToObject(m.ctx.vm)
+
+	if status := obj.Get("status"); status != nil && !goja.IsUndefined(status) {
+		res.Status = int(status.ToInteger())
+		if res.Status < 100 || res.Status > 599 {
+			return nil, fmt.Errorf("http: invalid status code %d", res.Status)
+		}
+	}
+
+	if headers := obj.Get("headers"); headers != nil && !goja.IsUndefined(headers) && !goja.IsNull(headers) {
+		headersObj := headers.ToObject(m.ctx.vm)
+		for _, key := range headersObj.Keys() {
+			res.Headers[http.CanonicalHeaderKey(key)] = headersObj.Get(key).String()
+		}
+	}
+
+	body := obj.Get("body")
+	if body == nil || goja.IsUndefined(body) || goja.IsNull(body) {
+		return res, nil
+	}
+
+	if s, ok := body.Export().(string); ok {
+		if _, ok := res.Headers["Content-Type"]; !ok {
+			res.Headers["Content-Type"] = "text/plain; charset=utf-8"
+		}
+		res.Body = []byte(s)
+		return res, nil
+	}
+
+	b, err := json.Marshal(body.Export())
+	if err != nil {
+		return nil, fmt.Errorf("http: cannot encode body: %w", err)
+	}
+	if _, ok := res.Headers["Content-Type"]; !ok {
+		res.Headers["Content-Type"] = "application/json"
+	}
+	res.Body = b
+
+	return res, nil
+}
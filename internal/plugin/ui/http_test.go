@@ -0,0 +1,147 @@
+package plugin_ui
+
+import (
+	"context"
+	"net/http"
+	"seanime/internal/events"
+	"seanime/internal/extension"
+	"seanime/internal/util"
+	goja_util "seanime/internal/util/goja"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHTTPUI(t *testing.T, scopes []extension.PluginPermissionScope, callback string) *UI {
+	logger := util.NewLogger()
+	vm := goja.New()
+	scheduler := goja_util.NewScheduler()
+	t.Cleanup(scheduler.Stop)
+
+	ui := NewUI(NewUIOptions{
+		Logger:    logger,
+		VM:        vm,
+		WSManager: events.NewMockWSEventManager(logger),
+		Scheduler: scheduler,
+		Extension: &extension.Extension{
+			ID:     "http-plugin",
+			Name:   "HTTP Plugin",
+			Plugin: &extension.PluginManifest{Permissions: extension.PluginPermissions{Scopes: scopes}},
+		},
+	})
+
+	err := scheduler.Schedule(func() error {
+		return ui.Register(callback)
+	})
+	require.NoError(t, err)
+
+	return ui
+}
+
+func TestHTTPRoutes(t *testing.T) {
+	ui := newTestHTTPUI(t, []extension.PluginPermissionScope{extension.PluginPermissionHTTPRoutes}, `function(ctx) {
+		ctx.http.get("/items/:id", (req) => {
+			return { body: { id: req.params.id, q: req.query.q } }
+		})
+		ctx.http.post("/items", (req) => {
+			const data = req.json()
+			return { status: 201, headers: { "x-created": data.name }, body: "created " + data.name }
+		})
+		ctx.http.get("/async", async (req) => {
+			await new Promise((resolve) => resolve())
+			return "done"
+		})
+		ctx.http.handle("*", "/files/*", (req) => req.method + " " + req.params["*"])
+		ctx.http.get("/empty", () => {})
+		ctx.http.get("/throw", () => { throw new Error("boom") })
+		ctx.http.get("/slow", () => new Promise(() => {}))
+		ctx.http.get("/loop", () => { while (true) {} })
+	}`)
+
+	send := func(req *HTTPRequest) (*HTTPResponse, error) {
+		return ui.HandleHTTPRequest(context.Background(), req)
+	}
+
+	t.Run("path params and query", func(t *testing.T) {
+		res, err := send(&HTTPRequest{Method: http.MethodGet, Path: "items/42", Query: map[string]string{"q": "one piece"}})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "application/json", res.Headers["Content-Type"])
+		assert.JSONEq(t, `{"id": "42", "q": "one piece"}`, string(res.Body))
+	})
+
+	t.Run("json body", func(t *testing.T) {
+		res, err := send(&HTTPRequest{Method: http.MethodPost, Path: "/items/", Body: []byte(`{"name": "foo"}`)})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "foo", res.Headers["X-Created"])
+		assert.Equal(t, "created foo", string(res.Body))
+	})
+
+	t.Run("promise", func(t *testing.T) {
+		res, err := send(&HTTPRequest{Method: http.MethodGet, Path: "async"})
+		require.NoError(t, err)
+		assert.Equal(t, "done", string(res.Body))
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		res, err := send(&HTTPRequest{Method: http.MethodDelete, Path: "files/a/b.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "DELETE a/b.txt", string(res.Body))
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		res, err := send(&HTTPRequest{Method: http.MethodGet, Path: "empty"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+
+	t.Run("not found and method not allowed", func(t *testing.T) {
+		_, err := send(&HTTPRequest{Method: http.MethodGet, Path: "unknown"})
+		assert.ErrorIs(t, err, ErrHTTPRouteNotFound)
+
+		res, err := send(&HTTPRequest{Method: http.MethodPut, Path: "items/42"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Status)
+	})
+
+	t.Run("exception", func(t *testing.T) {
+		_, err := send(&HTTPRequest{Method: http.MethodGet, Path: "throw"})
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := ui.HandleHTTPRequest(ctx, &HTTPRequest{Method: http.MethodGet, Path: "slow"})
+		assert.ErrorIs(t, err, ErrHTTPRouteTimeout)
+	})
+
+	t.Run("stuck handler is interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := ui.HandleHTTPRequest(ctx, &HTTPRequest{Method: http.MethodGet, Path: "loop"})
+		assert.ErrorIs(t, err, ErrHTTPRouteTimeout)
+
+		// The scheduler is free again and the VM can be used
+		ctx2, cancel2 := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel2()
+		res, err := ui.HandleHTTPRequest(ctx2, &HTTPRequest{Method: http.MethodGet, Path: "empty"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+}
+
+func TestHTTPRoutes_PermissionRequired(t *testing.T) {
+	ui := newTestHTTPUI(t, nil, `function(ctx) {
+		if (ctx.http !== undefined) {
+			throw new Error("ctx.http should not be bound")
+		}
+	}`)
+
+	_, err := ui.HandleHTTPRequest(context.Background(), &HTTPRequest{Method: http.MethodGet, Path: "items"})
+	assert.ErrorIs(t, err, ErrHTTPRouteNotFound)
+}
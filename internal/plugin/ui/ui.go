@@ -1,6 +1,7 @@
 package plugin_ui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"seanime/internal/database/db"
@@ -112,6 +113,18 @@ func (u *UI) UnloadFromInside(signalDestroyed bool) {
 	}
 }
 
+// HandleHTTPRequest calls the handler of the plugin route matching the request.
+func (u *UI) HandleHTTPRequest(ctx context.Context, req *HTTPRequest) (*HTTPResponse, error) {
+	u.mu.RLock()
+	destroyed := u.destroyed
+	u.mu.RUnlock()
+	if destroyed {
+		return nil, ErrHTTPRouteNotFound
+	}
+
+	return u.context.httpRouteManager.Handle(ctx, req)
+}
+
 // Destroyed returns a channel that is closed when the UI is destroyed
 func (u *UI) Destroyed() <-chan struct{} {
 	return u.destroyedCh
@@ -0,0 +1,151 @@
+package subtitles
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"seanime/internal/mediastream/videofile"
+	"slices"
+	"time"
+)
+
+const (
+	tracksIndexFilename = "tracks.json"
+	// MaxSubtitleSize is the maximum size of a downloaded subtitle file.
+	MaxSubtitleSize = 10 * 1024 * 1024
+)
+
+var (
+	// SupportedFormats are the subtitle formats that can be offered as external tracks.
+	SupportedFormats = []string{"srt", "ass", "ssa", "vtt"}
+
+	ErrTrackNotFound = errors.New("subtitle track not found")
+)
+
+type (
+	// Track is a subtitle fetched from a subtitle provider and cached next to the media info of a video file.
+	Track struct {
+		// Name of the file in the cache directory, e.g. "opensubtitles-3f2a9c01de.srt"
+		Filename string    `json:"filename"`
+		Provider string    `json:"provider"`
+		Name     string    `json:"name"`
+		Language string    `json:"language"` // ISO 639-1 language code
+		Format   string    `json:"format"`
+		AddedAt  time.Time `json:"addedAt"`
+		// Absolute path of the file
+		Path string `json:"-"`
+	}
+)
+
+// GetCacheDir returns the directory where the subtitles of the video file with the given hash are stored.
+func GetCacheDir(cacheDir string, hash string) string {
+	return videofile.GetFileExternalSubsCacheDir(cacheDir, hash)
+}
+
+// ReadTracks returns the subtitle tracks cached for the video file with the given hash.
+// Tracks whose file is missing are skipped.
+func ReadTracks(cacheDir string, hash string) []*Track {
+	ret := make([]*Track, 0)
+
+	dir := GetCacheDir(cacheDir, hash)
+	data, err := os.ReadFile(filepath.Join(dir, tracksIndexFilename))
+	if err != nil {
+		return ret
+	}
+
+	var tracks []*Track
+	if err := json.Unmarshal(data, &tracks); err != nil {
+		return ret
+	}
+
+	for _, track := range tracks {
+		track.Path = filepath.Join(dir, track.Filename)
+		if _, err := os.Stat(track.Path); err != nil {
+			continue
+		}
+		ret = append(ret, track)
+	}
+
+	return ret
+}
+
+func writeTracks(cacheDir string, hash string, tracks []*Track) error {
+	data, err := json.Marshal(tracks)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(GetCacheDir(cacheDir, hash), tracksIndexFilename), data, 0644)
+}
+
+// trackFilename returns a stable file name for a subtitle, so that downloading it twice replaces the cached file.
+func trackFilename(provider string, id string, format string) string {
+	h := sha1.Sum([]byte(provider + "$" + id))
+	return fmt.Sprintf("%s-%s.%s", sanitizeFilename(provider), hex.EncodeToString(h[:])[:10], format)
+}
+
+// saveTrack writes the subtitle content to the cache directory and adds it to the index.
+func saveTrack(cacheDir string, hash string, track *Track, content []byte) (*Track, error) {
+	if !slices.Contains(SupportedFormats, track.Format) {
+		return nil, fmt.Errorf("unsupported subtitle format: %q", track.Format)
+	}
+	if len(content) == 0 {
+		return nil, errors.New("subtitle is empty")
+	}
+	if len(content) > MaxSubtitleSize {
+		return nil, errors.New("subtitle is too large")
+	}
+
+	dir := GetCacheDir(cacheDir, hash)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	track.Path = filepath.Join(dir, track.Filename)
+	if err := os.WriteFile(track.Path, content, 0644); err != nil {
+		return nil, err
+	}
+
+	tracks := slices.DeleteFunc(ReadTracks(cacheDir, hash), func(t *Track) bool {
+		return t.Filename == track.Filename
+	})
+	tracks = append(tracks, track)
+
+	if err := writeTracks(cacheDir, hash, tracks); err != nil {
+		return nil, err
+	}
+
+	return track, nil
+}
+
+// deleteTrack removes a cached subtitle track.
+func deleteTrack(cacheDir string, hash string, filename string) error {
+	tracks := ReadTracks(cacheDir, hash)
+
+	idx := slices.IndexFunc(tracks, func(t *Track) bool {
+		return t.Filename == filename
+	})
+	if idx == -1 {
+		return ErrTrackNotFound
+	}
+
+	_ = os.Remove(tracks[idx].Path)
+
+	return writeTracks(cacheDir, hash, slices.Delete(tracks, idx, idx+1))
+}
+
+func sanitizeFilename(s string) string {
+	ret := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			ret = append(ret, r)
+		default:
+			ret = append(ret, '_')
+		}
+	}
+	return string(ret)
+}
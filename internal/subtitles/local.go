@@ -0,0 +1,293 @@
+package subtitles
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"seanime/internal/extension"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/util/comparison"
+	"seanime/internal/util/result"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/5rahim/habari"
+	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
+)
+
+const (
+	LocalProviderID   = "local-subtitles"
+	LocalProviderName = "Local subtitles"
+
+	// Maximum depth of the subtitle folders that are scanned
+	localMaxDepth = 3
+)
+
+var (
+	// Folders next to the video file that commonly contain subtitles
+	localSubFolderNames = []string{"subs", "subtitles", "sub"}
+)
+
+// LocalProvider is a built-in subtitle provider that finds subtitles in local folders.
+// It looks in the directory of the video file, its "Subs" folder and the folders set by the user.
+type LocalProvider struct {
+	logger  *zerolog.Logger
+	mu      sync.RWMutex
+	folders []string
+	// Files returned by Search, only these can be read by Download
+	found *result.Map[string, struct{}]
+}
+
+func NewLocalProvider(logger *zerolog.Logger) hibikesubtitle.Provider {
+	return &LocalProvider{
+		logger: logger,
+		found:  result.NewResultMap[string, struct{}](),
+	}
+}
+
+// LocalProviderUserConfig is the user config of the built-in local subtitle provider.
+func LocalProviderUserConfig() *extension.UserConfig {
+	return &extension.UserConfig{
+		Version: 1,
+		Fields: []extension.ConfigField{
+			{
+				Name:    "folders",
+				Label:   "Subtitle folders (separated by ';')",
+				Type:    extension.ConfigFieldTypeText,
+				Default: "",
+			},
+		},
+	}
+}
+
+func (p *LocalProvider) SetSavedUserConfig(config extension.SavedUserConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.folders = make([]string, 0)
+	for _, folder := range strings.Split(config.Values["folders"], ";") {
+		folder = strings.TrimSpace(folder)
+		if folder == "" {
+			continue
+		}
+		p.folders = append(p.folders, filepath.Clean(folder))
+	}
+}
+
+func (p *LocalProvider) GetSettings() hibikesubtitle.Settings {
+	return hibikesubtitle.Settings{
+		SupportsFilenameSearch: true,
+	}
+}
+
+func (p *LocalProvider) Search(opts hibikesubtitle.SearchOptions) ([]*hibikesubtitle.SearchResult, error) {
+	ret := make([]*hibikesubtitle.SearchResult, 0)
+	added := make(map[string]struct{})
+
+	add := func(path string, isFilenameMatch bool) {
+		if _, ok := added[path]; ok {
+			return
+		}
+		lang := languageFromFilename(path)
+		if opts.Language != "" && lang != "" && lang != opts.Language {
+			return
+		}
+		added[path] = struct{}{}
+		p.found.Set(path, struct{}{})
+		ret = append(ret, &hibikesubtitle.SearchResult{
+			Provider:        LocalProviderID,
+			ID:              path,
+			Name:            filepath.Base(path),
+			Language:        lang,
+			Format:          formatFromFilename(path),
+			EpisodeNumber:   opts.EpisodeNumber,
+			IsFilenameMatch: isFilenameMatch,
+		})
+	}
+
+	// Subtitles next to the video file
+	if opts.Path != "" {
+		videoDir := filepath.Dir(opts.Path)
+		videoName := strings.TrimSuffix(filepath.Base(opts.Path), filepath.Ext(opts.Path))
+
+		dirs := []string{videoDir}
+		entries, _ := os.ReadDir(videoDir)
+		for _, entry := range entries {
+			if entry.IsDir() && slices.Contains(localSubFolderNames, strings.ToLower(entry.Name())) {
+				dirs = append(dirs, filepath.Join(videoDir, entry.Name()))
+			}
+		}
+
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if entry.IsDir() || formatFromFilename(entry.Name()) == "" {
+					continue
+				}
+				path := filepath.Join(dir, entry.Name())
+				// e.g. "Episode 01.en.srt" for "Episode 01.mkv"
+				if strings.HasPrefix(entry.Name(), videoName+".") {
+					add(path, true)
+					continue
+				}
+				if p.matchesEpisode(entry.Name(), nil, opts.EpisodeNumber) {
+					add(path, false)
+				}
+			}
+		}
+	}
+
+	// Subtitles in the user's folders
+	p.mu.RLock()
+	folders := slices.Clone(p.folders)
+	p.mu.RUnlock()
+
+	titles := p.getTitles(opts.Media)
+
+	for _, folder := range folders {
+		_ = filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				rel, _ := filepath.Rel(folder, path)
+				if rel != "." && strings.Count(rel, string(filepath.Separator)) >= localMaxDepth {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if formatFromFilename(d.Name()) == "" {
+				return nil
+			}
+			if p.matchesEpisode(d.Name(), titles, opts.EpisodeNumber) || p.matchesEpisode(filepath.Base(filepath.Dir(path))+" - "+d.Name(), titles, opts.EpisodeNumber) {
+				add(path, false)
+			}
+			return nil
+		})
+	}
+
+	// Put the files matching the video file name first
+	slices.SortStableFunc(ret, func(a, b *hibikesubtitle.SearchResult) int {
+		if a.IsFilenameMatch == b.IsFilenameMatch {
+			return 0
+		}
+		if a.IsFilenameMatch {
+			return -1
+		}
+		return 1
+	})
+
+	return ret, nil
+}
+
+func (p *LocalProvider) Download(res *hibikesubtitle.SearchResult) (*hibikesubtitle.Subtitle, error) {
+	// Only serve files that were found by Search
+	if _, ok := p.found.Get(res.ID); !ok {
+		return nil, errors.New("subtitle not found")
+	}
+
+	info, err := os.Stat(res.ID)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > MaxSubtitleSize {
+		return nil, errors.New("subtitle is too large")
+	}
+
+	content, err := os.ReadFile(res.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hibikesubtitle.Subtitle{
+		Provider: LocalProviderID,
+		Format:   formatFromFilename(res.ID),
+		Language: languageFromFilename(res.ID),
+		Content:  string(content),
+	}, nil
+}
+
+func (p *LocalProvider) getTitles(media hibikesubtitle.Media) []*string {
+	ret := make([]*string, 0)
+	if media.RomajiTitle != "" {
+		ret = append(ret, &media.RomajiTitle)
+	}
+	if media.EnglishTitle != nil && *media.EnglishTitle != "" {
+		ret = append(ret, media.EnglishTitle)
+	}
+	for _, synonym := range media.Synonyms {
+		ret = append(ret, &synonym)
+	}
+	return ret
+}
+
+// matchesEpisode returns true if the file name is for the given episode.
+// If titles are given, the parsed title must also match one of them.
+func (p *LocalProvider) matchesEpisode(name string, titles []*string, episodeNumber int) bool {
+	metadata := habari.Parse(strings.TrimSuffix(name, filepath.Ext(name)))
+
+	if episodeNumber > 0 {
+		found := false
+		for _, ep := range metadata.EpisodeNumber {
+			if n, err := strconv.ParseFloat(ep, 64); err == nil && int(n) == episodeNumber {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if titles == nil {
+		return true
+	}
+	if metadata.Title == "" || len(titles) == 0 {
+		return false
+	}
+
+	match, ok := comparison.FindBestMatchWithSorensenDice(&metadata.Title, titles)
+	return ok && match.Rating >= 0.6
+}
+
+// formatFromFilename returns the subtitle format of the file, or an empty string if it is not a supported subtitle file.
+func formatFromFilename(name string) string {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	if slices.Contains(SupportedFormats, ext) {
+		return ext
+	}
+	return ""
+}
+
+// languageFromFilename returns the ISO 639-1 language code in the file name, e.g. "en" for "Episode 01.eng.srt".
+func languageFromFilename(name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	idx := strings.LastIndex(base, ".")
+	if idx == -1 {
+		return ""
+	}
+	return normalizeLanguage(base[idx+1:])
+}
+
+// normalizeLanguage returns the ISO 639-1 code of the language, or an empty string if it is not a language.
+func normalizeLanguage(s string) string {
+	if len(s) < 2 || len(s) > 3 {
+		return ""
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return ""
+	}
+	base, confidence := tag.Base()
+	if confidence == language.No {
+		return ""
+	}
+	return base.String()
+}
@@ -0,0 +1,246 @@
+package subtitles
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"seanime/internal/api/anilist"
+	"seanime/internal/extension"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/mediastream/videofile"
+	"seanime/internal/platforms/platform"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrNoProvider = errors.New("no subtitle provider found")
+)
+
+type (
+	// Repository searches subtitles using the subtitle provider extensions and caches the downloaded ones.
+	Repository struct {
+		logger                *zerolog.Logger
+		providerExtensionBank *extension.UnifiedBank
+		platform              platform.Platform
+		cacheDir              string
+		anilistBaseAnimeCache *anilist.BaseAnimeCache
+	}
+
+	NewRepositoryOptions struct {
+		Logger   *zerolog.Logger
+		Platform platform.Platform
+		// CacheDir is the directory where the media info of video files is stored
+		CacheDir string
+	}
+
+	SearchOptions struct {
+		MediaId       int    `json:"mediaId"`
+		EpisodeNumber int    `json:"episodeNumber"`
+		Language      string `json:"language"` // ISO 639-1 language code, empty for any language
+		// Path of the video file, optional
+		Path string `json:"path"`
+		// ID of the provider to search with, empty to search with all providers
+		Provider string `json:"provider"`
+	}
+
+	SearchResponse struct {
+		Results []*hibikesubtitle.SearchResult `json:"results"`
+		// Providers that failed, keyed by ID
+		Errors map[string]string `json:"errors,omitempty"`
+	}
+)
+
+func NewRepository(opts *NewRepositoryOptions) *Repository {
+	return &Repository{
+		logger:                opts.Logger,
+		providerExtensionBank: extension.NewUnifiedBank(),
+		platform:              opts.Platform,
+		cacheDir:              opts.CacheDir,
+		anilistBaseAnimeCache: anilist.NewBaseAnimeCache(),
+	}
+}
+
+func (r *Repository) InitExtensionBank(bank *extension.UnifiedBank) {
+	r.providerExtensionBank = bank
+
+	r.logger.Debug().Msg("subtitles: Initialized provider extension bank")
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Search searches subtitles with all subtitle providers, or the one in SearchOptions.Provider.
+// Providers are queried concurrently, failing providers are reported in SearchResponse.Errors.
+func (r *Repository) Search(opts *SearchOptions) (*SearchResponse, error) {
+	providers := make([]extension.SubtitleProviderExtension, 0)
+	extension.RangeExtensions(r.providerExtensionBank, func(id string, ext extension.SubtitleProviderExtension) bool {
+		if opts.Provider == "" || opts.Provider == id {
+			providers = append(providers, ext)
+		}
+		return true
+	})
+	if len(providers) == 0 {
+		return nil, ErrNoProvider
+	}
+
+	media, err := r.anilistBaseAnimeCache.GetOrSet(opts.MediaId, func() (*anilist.BaseAnime, error) {
+		return r.platform.GetAnime(opts.MediaId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	searchOpts := hibikesubtitle.SearchOptions{
+		Media:         toProviderMedia(media),
+		EpisodeNumber: opts.EpisodeNumber,
+		Language:      normalizeLanguage(opts.Language),
+		Path:          opts.Path,
+	}
+	if opts.Path != "" {
+		searchOpts.Filename = filepath.Base(opts.Path)
+	}
+
+	ret := &SearchResponse{
+		Results: make([]*hibikesubtitle.SearchResult, 0),
+		Errors:  make(map[string]string),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, ext := range providers {
+		wg.Add(1)
+		go func(ext extension.SubtitleProviderExtension) {
+			defer wg.Done()
+
+			// Only built-in providers can access the file system
+			providerOpts := searchOpts
+			if ext.GetManifestURI() != "builtin" {
+				providerOpts.Path = ""
+			}
+
+			results, err := ext.GetProvider().Search(providerOpts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				r.logger.Warn().Err(err).Str("provider", ext.GetID()).Msg("subtitles: Provider search failed")
+				ret.Errors[ext.GetID()] = err.Error()
+				return
+			}
+			for _, res := range results {
+				if res == nil {
+					continue
+				}
+				res.Provider = ext.GetID()
+				ret.Results = append(ret.Results, res)
+			}
+		}(ext)
+	}
+	wg.Wait()
+
+	// Put the results matching the video file name first, then the most downloaded
+	slices.SortStableFunc(ret.Results, func(a, b *hibikesubtitle.SearchResult) int {
+		if a.IsFilenameMatch != b.IsFilenameMatch {
+			if a.IsFilenameMatch {
+				return -1
+			}
+			return 1
+		}
+		return b.Downloads - a.Downloads
+	})
+
+	r.logger.Debug().Int("count", len(ret.Results)).Int("mediaId", opts.MediaId).Int("episode", opts.EpisodeNumber).Msg("subtitles: Search done")
+
+	return ret, nil
+}
+
+// Download downloads the subtitle and caches it next to the media info of the video file.
+// The cached subtitle is offered as an external track when the file is played.
+func (r *Repository) Download(path string, res *hibikesubtitle.SearchResult) (*Track, error) {
+	if res == nil {
+		return nil, errors.New("no subtitle given")
+	}
+
+	ext, ok := extension.GetExtension[extension.SubtitleProviderExtension](r.providerExtensionBank, res.Provider)
+	if !ok {
+		return nil, ErrNoProvider
+	}
+
+	hash, err := videofile.GetHashFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read video file: %w", err)
+	}
+
+	subtitle, err := ext.GetProvider().Download(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download subtitle: %w", err)
+	}
+
+	format := strings.ToLower(subtitle.Format)
+	if format == "" {
+		format = strings.ToLower(res.Format)
+	}
+	lang := normalizeLanguage(subtitle.Language)
+	if lang == "" {
+		lang = normalizeLanguage(res.Language)
+	}
+
+	track, err := saveTrack(r.cacheDir, hash, &Track{
+		Filename: trackFilename(ext.GetID(), res.ID, format),
+		Provider: ext.GetID(),
+		Name:     res.Name,
+		Language: lang,
+		Format:   format,
+		AddedAt:  time.Now(),
+	}, []byte(subtitle.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug().Str("path", path).Str("filename", track.Filename).Msg("subtitles: Saved subtitle")
+
+	return track, nil
+}
+
+// GetTracks returns the subtitles downloaded for the video file.
+func (r *Repository) GetTracks(path string) ([]*Track, error) {
+	hash, err := videofile.GetHashFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read video file: %w", err)
+	}
+	return ReadTracks(r.cacheDir, hash), nil
+}
+
+// DeleteTrack deletes a subtitle downloaded for the video file.
+func (r *Repository) DeleteTrack(path string, filename string) error {
+	hash, err := videofile.GetHashFromPath(path)
+	if err != nil {
+		return fmt.Errorf("failed to read video file: %w", err)
+	}
+	return deleteTrack(r.cacheDir, hash, filename)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func toProviderMedia(media *anilist.BaseAnime) hibikesubtitle.Media {
+	ret := hibikesubtitle.Media{
+		ID:           media.ID,
+		IDMal:        media.GetIDMal(),
+		EnglishTitle: media.GetTitle().GetEnglish(),
+		RomajiTitle:  media.GetRomajiTitleSafe(),
+		EpisodeCount: media.GetTotalEpisodeCount(),
+		Synonyms:     media.GetSynonymsDeref(),
+		Year:         media.GetStartYearSafe(),
+	}
+	if media.GetFormat() != nil {
+		ret.Format = string(*media.GetFormat())
+	}
+	if media.GetIsAdult() != nil {
+		ret.IsAdult = *media.GetIsAdult()
+	}
+	return ret
+}
@@ -0,0 +1,172 @@
+package subtitles
+
+import (
+	"os"
+	"path/filepath"
+	"seanime/internal/extension"
+	hibikesubtitle "seanime/internal/extension/hibike/subtitle"
+	"seanime/internal/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSrt = "1\n00:00:01,000 --> 00:00:03,000\nHello\n"
+
+func writeTestFiles(t *testing.T, root string, files ...string) {
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(testSrt), 0644))
+	}
+}
+
+func TestLocalProvider_Search(t *testing.T) {
+	videoDir := t.TempDir()
+	userDir := t.TempDir()
+
+	writeTestFiles(t, videoDir,
+		"[Group] Sousou no Frieren - 03 [1080p].mkv",
+		"[Group] Sousou no Frieren - 03 [1080p].en.srt",
+		"[Group] Sousou no Frieren - 03 [1080p].fr.ass",
+		"Subs/Sousou no Frieren - 04.en.srt",
+		"Subs/Sousou no Frieren - 03.de.srt",
+		"notes.txt",
+	)
+	writeTestFiles(t, userDir,
+		"Sousou no Frieren/Sousou no Frieren - 03.en.ass",
+		"Sousou no Frieren/Sousou no Frieren - 05.en.ass",
+		"Other Show/Other Show - 03.en.ass",
+	)
+
+	provider := NewLocalProvider(util.NewLogger())
+	provider.(extension.Configurable).SetSavedUserConfig(extension.SavedUserConfig{
+		Values: map[string]string{"folders": " ; " + userDir},
+	})
+
+	opts := hibikesubtitle.SearchOptions{
+		Media:         hibikesubtitle.Media{ID: 154587, RomajiTitle: "Sousou no Frieren"},
+		EpisodeNumber: 3,
+		Path:          filepath.Join(videoDir, "[Group] Sousou no Frieren - 03 [1080p].mkv"),
+	}
+
+	results, err := provider.Search(opts)
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, res := range results {
+		names = append(names, res.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"[Group] Sousou no Frieren - 03 [1080p].en.srt",
+		"[Group] Sousou no Frieren - 03 [1080p].fr.ass",
+		"Sousou no Frieren - 03.de.srt",
+		"Sousou no Frieren - 03.en.ass",
+	}, names)
+	// Files named after the video file come first
+	assert.True(t, results[0].IsFilenameMatch)
+	assert.True(t, results[1].IsFilenameMatch)
+	assert.False(t, results[2].IsFilenameMatch)
+
+	// Filter by language
+	opts.Language = "en"
+	results, err = provider.Search(opts)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.Equal(t, "en", res.Language)
+	}
+
+	subtitle, err := provider.Download(results[0])
+	require.NoError(t, err)
+	assert.Equal(t, testSrt, subtitle.Content)
+
+	// Files that were not found by Search cannot be read
+	_, err = provider.Download(&hibikesubtitle.SearchResult{ID: filepath.Join(videoDir, "notes.txt")})
+	assert.Error(t, err)
+}
+
+func TestLanguageFromFilename(t *testing.T) {
+	tests := map[string]string{
+		"Episode 01.en.srt":      "en",
+		"Episode 01.eng.srt":     "en",
+		"Episode 01.fre.ass":     "fr",
+		"Episode 01.srt":         "",
+		"Episode 01.v2.srt":      "",
+		"[Group] Show 01.ja.vtt": "ja",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, languageFromFilename(name), name)
+	}
+}
+
+type testProvider struct {
+	content string
+}
+
+func (p *testProvider) Search(hibikesubtitle.SearchOptions) ([]*hibikesubtitle.SearchResult, error) {
+	return nil, nil
+}
+
+func (p *testProvider) Download(res *hibikesubtitle.SearchResult) (*hibikesubtitle.Subtitle, error) {
+	return &hibikesubtitle.Subtitle{Format: "SRT", Language: "eng", Content: p.content}, nil
+}
+
+func (p *testProvider) GetSettings() hibikesubtitle.Settings {
+	return hibikesubtitle.Settings{}
+}
+
+func TestRepository_DownloadAndTracks(t *testing.T) {
+	cacheDir := t.TempDir()
+	videoPath := filepath.Join(t.TempDir(), "episode.mkv")
+	require.NoError(t, os.WriteFile(videoPath, []byte("video"), 0644))
+
+	repo := NewRepository(&NewRepositoryOptions{
+		Logger:   util.NewLogger(),
+		CacheDir: cacheDir,
+	})
+
+	bank := extension.NewUnifiedBank()
+	bank.Set("test-provider", extension.NewSubtitleProviderExtension(&extension.Extension{
+		ID:   "test-provider",
+		Type: extension.TypeSubtitleProvider,
+	}, &testProvider{content: testSrt}))
+	repo.InitExtensionBank(bank)
+
+	result := &hibikesubtitle.SearchResult{Provider: "test-provider", ID: "../../sub/1", Name: "Episode 1"}
+
+	track, err := repo.Download(videoPath, result)
+	require.NoError(t, err)
+	assert.Equal(t, "srt", track.Format)
+	assert.Equal(t, "en", track.Language)
+	// Stored next to the media info of the video file
+	assert.Equal(t, "extsubs", filepath.Base(filepath.Dir(track.Path)))
+	assert.Equal(t, filepath.Join(cacheDir, "videofiles"), filepath.Dir(filepath.Dir(filepath.Dir(track.Path))))
+	assert.NotContains(t, track.Filename, "/")
+
+	// Downloading the same subtitle again replaces it
+	_, err = repo.Download(videoPath, result)
+	require.NoError(t, err)
+
+	tracks, err := repo.GetTracks(videoPath)
+	require.NoError(t, err)
+	require.Len(t, tracks, 1)
+	assert.Equal(t, "Episode 1", tracks[0].Name)
+
+	content, err := os.ReadFile(tracks[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, testSrt, string(content))
+
+	require.NoError(t, repo.DeleteTrack(videoPath, track.Filename))
+	assert.ErrorIs(t, repo.DeleteTrack(videoPath, track.Filename), ErrTrackNotFound)
+
+	tracks, err = repo.GetTracks(videoPath)
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+
+	// Empty subtitles are refused
+	bank.Set("test-provider", extension.NewSubtitleProviderExtension(&extension.Extension{ID: "test-provider"}, &testProvider{}))
+	_, err = repo.Download(videoPath, result)
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package torrentstream
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/torrent"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxConnections = 50
+	slowSeedingUploadRate = 1024 // KiB/s
+)
+
+type (
+	// bandwidthLimits are the limits applied to the torrent client at a given time.
+	bandwidthLimits struct {
+		DownloadRate   int // KiB/s, 0 means unlimited
+		UploadRate     int // KiB/s, 0 means unlimited
+		MaxConnections int
+		Scheduled      bool // Whether the limits come from the schedule
+	}
+)
+
+// newRateLimiters creates the rate limiters used by the torrent client.
+// They are updated in place by [Client.applyBandwidthLimits] so that the limits can change without restarting the client.
+func (c *Client) newRateLimiters() {
+	c.downloadRateLimiter = rate.NewLimiter(rate.Inf, 0)
+	c.uploadRateLimiter = rate.NewLimiter(rate.Inf, 0)
+	c.dialRateLimiter = rate.NewLimiter(rate.Inf, 0)
+}
+
+// applyBandwidthLimits updates the rate limiters and connection limits of the running client from the settings.
+func (c *Client) applyBandwidthLimits() {
+	settings, ok := c.repository.settings.Get()
+	if !ok || c.downloadRateLimiter == nil {
+		return
+	}
+
+	limits := getBandwidthLimits(&settings, time.Now())
+
+	if limits.Scheduled != c.bandwidthScheduleActive {
+		if limits.Scheduled {
+			c.repository.logger.Debug().Msg("torrentstream: Bandwidth schedule started")
+		} else {
+			c.repository.logger.Debug().Msg("torrentstream: Bandwidth schedule ended")
+		}
+		c.bandwidthScheduleActive = limits.Scheduled
+	}
+
+	setRateLimit(c.downloadRateLimiter, limits.DownloadRate, 1<<16)
+	setRateLimit(c.uploadRateLimiter, limits.UploadRate, 256<<10)
+
+	if settings.SlowSeeding {
+		c.dialRateLimiter.SetLimit(rate.Limit(1))
+		c.dialRateLimiter.SetBurst(1)
+	} else {
+		c.dialRateLimiter.SetLimit(rate.Inf)
+	}
+
+	if client, ok := c.torrentClient.Get(); ok {
+		for _, t := range client.Torrents() {
+			c.applyMaxConnections(t, limits.MaxConnections)
+		}
+	}
+}
+
+func (c *Client) applyMaxConnections(t *torrent.Torrent, maxConnections int) {
+	if maxConnections <= 0 {
+		maxConnections = defaultMaxConnections
+	}
+	t.SetMaxEstablishedConns(maxConnections)
+}
+
+// getMaxConnections returns the connection limit from the settings.
+func (c *Client) getMaxConnections() int {
+	settings, ok := c.repository.settings.Get()
+	if !ok {
+		return 0
+	}
+	return settings.MaxConnections
+}
+
+// setRateLimit updates a limiter, a limit of 0 means unlimited.
+func setRateLimit(l *rate.Limiter, kibPerSecond int, burst int) {
+	if kibPerSecond <= 0 {
+		l.SetLimit(rate.Inf)
+		return
+	}
+	l.SetLimit(rate.Limit(kibPerSecond * 1024))
+	l.SetBurst(burst)
+}
+
+// getBandwidthLimits returns the limits that should be applied at the given time.
+func getBandwidthLimits(settings *Settings, now time.Time) bandwidthLimits {
+	ret := bandwidthLimits{
+		DownloadRate:   settings.DownloadRateLimit,
+		UploadRate:     settings.UploadRateLimit,
+		MaxConnections: settings.MaxConnections,
+	}
+
+	if settings.BandwidthScheduleEnabled && isInBandwidthSchedule(now, settings.BandwidthScheduleStart, settings.BandwidthScheduleEnd, settings.BandwidthScheduleDays) {
+		ret.DownloadRate = settings.ScheduledDownloadRateLimit
+		ret.UploadRate = settings.ScheduledUploadRateLimit
+		ret.Scheduled = true
+	}
+
+	// Slow seeding caps the upload rate if no lower limit is set
+	if settings.SlowSeeding && (ret.UploadRate <= 0 || ret.UploadRate > slowSeedingUploadRate) {
+		ret.UploadRate = slowSeedingUploadRate
+	}
+
+	return ret
+}
+
+// isInBandwidthSchedule returns true if the time is within the schedule.
+// The window can span midnight, e.g. "22:00" to "06:00".
+func isInBandwidthSchedule(now time.Time, start string, end string, days string) bool {
+	startMinutes, err := parseScheduleTime(start)
+	if err != nil {
+		return false
+	}
+	endMinutes, err := parseScheduleTime(end)
+	if err != nil {
+		return false
+	}
+
+	nowMinutes := now.Hour()*60 + now.Minute()
+	weekday := now.Weekday()
+
+	var inWindow bool
+	switch {
+	case startMinutes == endMinutes:
+		inWindow = true
+	case startMinutes < endMinutes:
+		inWindow = nowMinutes >= startMinutes && nowMinutes < endMinutes
+	default:
+		inWindow = nowMinutes >= startMinutes || nowMinutes < endMinutes
+		// After midnight, the window belongs to the previous day
+		if nowMinutes < endMinutes {
+			weekday = (weekday + 6) % 7
+		}
+	}
+	if !inWindow {
+		return false
+	}
+
+	scheduleDays := parseScheduleDays(days)
+	return len(scheduleDays) == 0 || slices.Contains(scheduleDays, weekday)
+}
+
+// ValidateBandwidthSchedule returns an error if the schedule times are not in the "HH:MM" format.
+func ValidateBandwidthSchedule(start string, end string) error {
+	if _, err := parseScheduleTime(start); err != nil {
+		return err
+	}
+	if _, err := parseScheduleTime(end); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseScheduleTime parses "HH:MM" into minutes since midnight.
+func parseScheduleTime(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule time %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseScheduleDays parses comma-separated days of the week, e.g. "1,2,3,4,5".
+func parseScheduleDays(value string) []time.Weekday {
+	ret := make([]time.Weekday, 0)
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || day < 0 || day > 6 {
+			continue
+		}
+		ret = append(ret, time.Weekday(day))
+	}
+	return ret
+}
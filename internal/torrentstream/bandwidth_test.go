@@ -0,0 +1,68 @@
+package torrentstream
+
+import (
+	"seanime/internal/database/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsInBandwidthSchedule(t *testing.T) {
+	// 2025-06-02 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 2, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		start    string
+		end      string
+		days     string
+		expected bool
+	}{
+		{name: "within work hours", now: monday(10, 0), start: "09:00", end: "17:00", expected: true},
+		{name: "before work hours", now: monday(8, 59), start: "09:00", end: "17:00", expected: false},
+		{name: "end is exclusive", now: monday(17, 0), start: "09:00", end: "17:00", expected: false},
+		{name: "weekday included", now: monday(10, 0), start: "09:00", end: "17:00", days: "1,2,3,4,5", expected: true},
+		{name: "weekday excluded", now: monday(10, 0), start: "09:00", end: "17:00", days: "0,6", expected: false},
+		{name: "overnight before midnight", now: monday(23, 0), start: "22:00", end: "06:00", expected: true},
+		{name: "overnight after midnight", now: monday(5, 0), start: "22:00", end: "06:00", expected: true},
+		{name: "overnight outside", now: monday(12, 0), start: "22:00", end: "06:00", expected: false},
+		{name: "overnight after midnight belongs to previous day", now: monday(5, 0), start: "22:00", end: "06:00", days: "0", expected: true},
+		{name: "invalid time", now: monday(10, 0), start: "9am", end: "17:00", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isInBandwidthSchedule(tt.now, tt.start, tt.end, tt.days))
+		})
+	}
+}
+
+func TestGetBandwidthLimits(t *testing.T) {
+	settings := &Settings{
+		TorrentstreamSettings: models.TorrentstreamSettings{
+			DownloadRateLimit:          0,
+			UploadRateLimit:            2048,
+			MaxConnections:             30,
+			BandwidthScheduleEnabled:   true,
+			BandwidthScheduleStart:     "09:00",
+			BandwidthScheduleEnd:       "17:00",
+			ScheduledDownloadRateLimit: 512,
+			ScheduledUploadRateLimit:   128,
+		},
+	}
+
+	limits := getBandwidthLimits(settings, time.Date(2025, 6, 2, 20, 0, 0, 0, time.Local))
+	require.Equal(t, bandwidthLimits{DownloadRate: 0, UploadRate: 2048, MaxConnections: 30}, limits)
+
+	limits = getBandwidthLimits(settings, time.Date(2025, 6, 2, 10, 0, 0, 0, time.Local))
+	require.Equal(t, bandwidthLimits{DownloadRate: 512, UploadRate: 128, MaxConnections: 30, Scheduled: true}, limits)
+
+	// Slow seeding caps the upload rate
+	settings.SlowSeeding = true
+	limits = getBandwidthLimits(settings, time.Date(2025, 6, 2, 20, 0, 0, 0, time.Local))
+	require.Equal(t, slowSeedingUploadRate, limits.UploadRate)
+}
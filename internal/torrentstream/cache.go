@@ -0,0 +1,388 @@
+package torrentstream
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"seanime/internal/database/models"
+	"seanime/internal/util"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anacrolix/torrent"
+)
+
+type (
+	// cacheManager keeps the download directory under the maximum size set in the settings.
+	// Torrents are evicted from least to most recently streamed.
+	// When the cache is disabled (max size is 0), the download directory is cleared by [Client.dropTorrents] instead.
+	cacheManager struct {
+		repository *Repository
+		mu         sync.Mutex
+	}
+
+	CacheItem struct {
+		InfoHash       string    `json:"infoHash"`
+		Name           string    `json:"name"`
+		MediaId        int       `json:"mediaId"`
+		EpisodeNumber  int       `json:"episodeNumber"`
+		Size           int64     `json:"size"`
+		Completed      bool      `json:"completed"` // Whether the streamed file was fully downloaded
+		IsActive       bool      `json:"isActive"`  // Whether the torrent is added to the torrent client
+		LastStreamedAt time.Time `json:"lastStreamedAt"`
+	}
+
+	CacheInfo struct {
+		Enabled     bool         `json:"enabled"`
+		DownloadDir string       `json:"downloadDir"`
+		TotalSize   int64        `json:"totalSize"`
+		MaxSize     int64        `json:"maxSize"`
+		Items       []*CacheItem `json:"items"`
+	}
+)
+
+func newCacheManager(repository *Repository) *cacheManager {
+	return &cacheManager{
+		repository: repository,
+	}
+}
+
+// isEnabled returns true if a maximum cache size is set.
+func (m *cacheManager) isEnabled() bool {
+	settings, ok := m.repository.settings.Get()
+	return ok && settings.CacheMaxSize > 0
+}
+
+func (m *cacheManager) maxSize() int64 {
+	settings, ok := m.repository.settings.Get()
+	if !ok {
+		return 0
+	}
+	return int64(settings.CacheMaxSize) * 1024 * 1024 * 1024
+}
+
+// touch records that a torrent has just been streamed.
+func (m *cacheManager) touch(mediaId int, episodeNumber int, t *torrent.Torrent, f *torrent.File) {
+	if m.repository.db == nil {
+		return
+	}
+
+	err := m.repository.db.UpsertTorrentstreamCacheItem(&models.TorrentstreamCacheItem{
+		InfoHash:       t.InfoHash().HexString(),
+		Name:           t.Name(),
+		MediaId:        mediaId,
+		EpisodeNumber:  episodeNumber,
+		FilePath:       f.Path(),
+		Completed:      f.BytesCompleted() == f.Length(),
+		LastStreamedAt: time.Now(),
+	})
+	if err != nil {
+		m.repository.logger.Warn().Err(err).Msg("torrentstream: Failed to save cache item")
+	}
+}
+
+// markCompleted updates the completion status of the streamed file.
+func (m *cacheManager) markCompleted(t *torrent.Torrent, f *torrent.File) {
+	if m.repository.db == nil || f.Length() == 0 || f.BytesCompleted() != f.Length() {
+		return
+	}
+
+	item, err := m.repository.db.GetTorrentstreamCacheItem(t.InfoHash().HexString())
+	if err != nil || item.FilePath != f.Path() {
+		return
+	}
+
+	item.Completed = true
+	_ = m.repository.db.UpsertTorrentstreamCacheItem(item)
+}
+
+// getActiveInfoHashes returns the info hashes of the torrents added to the client.
+func (m *cacheManager) getActiveInfoHashes() map[string]struct{} {
+	ret := make(map[string]struct{})
+	client, ok := m.repository.client.torrentClient.Get()
+	if !ok {
+		return ret
+	}
+	for _, t := range client.Torrents() {
+		ret[t.InfoHash().HexString()] = struct{}{}
+	}
+	return ret
+}
+
+// GetCacheInfo returns the contents of the download directory.
+func (r *Repository) GetCacheInfo() (ret *CacheInfo, err error) {
+	defer util.HandlePanicInModuleWithError("torrentstream/GetCacheInfo", &err)
+
+	r.cacheManager.mu.Lock()
+	defer r.cacheManager.mu.Unlock()
+
+	return r.cacheManager.getCacheInfo()
+}
+
+func (m *cacheManager) getCacheInfo() (*CacheInfo, error) {
+	downloadDir := m.repository.GetDownloadDir()
+
+	ret := &CacheInfo{
+		Enabled:     m.isEnabled(),
+		DownloadDir: downloadDir,
+		MaxSize:     m.maxSize(),
+		Items:       make([]*CacheItem, 0),
+	}
+
+	entries, err := os.ReadDir(downloadDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ret, nil
+		}
+		return nil, err
+	}
+
+	dbItems := make(map[string]*models.TorrentstreamCacheItem)
+	if m.repository.db != nil {
+		items, _ := m.repository.db.GetTorrentstreamCacheItems()
+		for _, item := range items {
+			dbItems[item.InfoHash] = item
+		}
+	}
+
+	activeInfoHashes := m.getActiveInfoHashes()
+
+	for _, entry := range entries {
+		// Each torrent is stored in a directory named after its info hash
+		if !entry.IsDir() {
+			continue
+		}
+
+		size, _ := util.DirSize(filepath.Join(downloadDir, entry.Name()))
+
+		item := &CacheItem{
+			InfoHash: entry.Name(),
+			Name:     entry.Name(),
+			Size:     int64(size),
+		}
+		if info, err := entry.Info(); err == nil {
+			item.LastStreamedAt = info.ModTime()
+		}
+		if dbItem, found := dbItems[entry.Name()]; found {
+			item.Name = dbItem.Name
+			item.MediaId = dbItem.MediaId
+			item.EpisodeNumber = dbItem.EpisodeNumber
+			item.Completed = dbItem.Completed
+			item.LastStreamedAt = dbItem.LastStreamedAt
+		}
+		_, item.IsActive = activeInfoHashes[entry.Name()]
+
+		ret.TotalSize += item.Size
+		ret.Items = append(ret.Items, item)
+	}
+
+	// Sort from most to least recently streamed
+	slices.SortStableFunc(ret.Items, func(a, b *CacheItem) int {
+		return b.LastStreamedAt.Compare(a.LastStreamedAt)
+	})
+
+	return ret, nil
+}
+
+// evict removes the least recently streamed torrents until the download directory is under the maximum size.
+// Torrents that are added to the client are never evicted.
+func (m *cacheManager) evict() {
+	defer util.HandlePanicInModuleThen("torrentstream/evict", func() {})
+
+	if !m.isEnabled() {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, err := m.getCacheInfo()
+	if err != nil {
+		m.repository.logger.Warn().Err(err).Msg("torrentstream: Failed to get cache info")
+		return
+	}
+
+	if info.TotalSize <= info.MaxSize {
+		return
+	}
+
+	m.repository.logger.Debug().Msgf("torrentstream: Cache size %s exceeds %s, evicting torrents", util.Bytes(uint64(info.TotalSize)), util.Bytes(uint64(info.MaxSize)))
+
+	settings := m.repository.settings.MustGet()
+
+	for _, item := range getEvictionCandidates(info.Items, info.TotalSize, info.MaxSize, settings.CacheKeepCompleted && !settings.AddToLibrary) {
+		// Move completed episodes to the library instead of deleting them
+		if item.Completed && settings.CacheKeepCompleted && settings.AddToLibrary {
+			if err := m.moveToLibrary(item); err != nil {
+				m.repository.logger.Warn().Err(err).Msgf("torrentstream: Failed to move %s to the library, keeping it", item.Name)
+				continue
+			}
+		}
+
+		if err := m.removeItem(item.InfoHash); err != nil {
+			m.repository.logger.Warn().Err(err).Msgf("torrentstream: Failed to evict %s", item.Name)
+			continue
+		}
+		m.repository.logger.Debug().Msgf("torrentstream: Evicted %s (%s)", item.Name, util.Bytes(uint64(item.Size)))
+	}
+}
+
+// getEvictionCandidates returns the items to remove, from least to most recently streamed, so that the total size is under maxSize.
+// Active torrents are skipped, as well as completed episodes if keepCompleted is true.
+func getEvictionCandidates(items []*CacheItem, totalSize int64, maxSize int64, keepCompleted bool) []*CacheItem {
+	ret := make([]*CacheItem, 0)
+
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b *CacheItem) int {
+		return cmp.Compare(a.LastStreamedAt.UnixNano(), b.LastStreamedAt.UnixNano())
+	})
+
+	for _, item := range sorted {
+		if totalSize <= maxSize {
+			break
+		}
+		if item.IsActive || (item.Completed && keepCompleted) {
+			continue
+		}
+		ret = append(ret, item)
+		totalSize -= item.Size
+	}
+
+	return ret
+}
+
+// removeItem deletes a torrent's directory and cache record.
+func (m *cacheManager) removeItem(infoHash string) error {
+	// Make sure the info hash cannot be used to escape the download directory
+	if infoHash == "" || strings.ContainsAny(infoHash, `/\.`) {
+		return errors.New("invalid info hash")
+	}
+
+	if err := os.RemoveAll(filepath.Join(m.repository.GetDownloadDir(), infoHash)); err != nil {
+		return err
+	}
+
+	if m.repository.db != nil {
+		_ = m.repository.db.DeleteTorrentstreamCacheItem(infoHash)
+	}
+	return nil
+}
+
+// moveToLibrary moves the streamed file of a cache item to the library directory.
+func (m *cacheManager) moveToLibrary(item *CacheItem) error {
+	if m.repository.db == nil {
+		return errors.New("database not set")
+	}
+
+	dbItem, err := m.repository.db.GetTorrentstreamCacheItem(item.InfoHash)
+	if err != nil {
+		return err
+	}
+
+	libraryPath, err := m.repository.db.GetLibraryPathFromSettings()
+	if err != nil || libraryPath == "" {
+		return errors.New("library path not set")
+	}
+
+	src := filepath.Join(m.repository.GetDownloadDir(), item.InfoHash, filepath.FromSlash(dbItem.FilePath))
+	// Keep the torrent's directory for batches, e.g. "{library}/{torrent name}/{file}"
+	dest := filepath.Join(libraryPath, filepath.FromSlash(dbItem.FilePath))
+	if !util.IsSubdirectory(libraryPath, dest) {
+		return errors.New("invalid file path")
+	}
+
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("file already exists: %s", dest)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := moveFile(src, dest); err != nil {
+		return err
+	}
+
+	m.repository.logger.Info().Msgf("torrentstream: Moved %s to the library", dest)
+	return nil
+}
+
+// RemoveCacheItem removes a torrent from the download directory.
+// The torrent is dropped from the client if it's not being streamed.
+func (r *Repository) RemoveCacheItem(infoHash string) (err error) {
+	defer util.HandlePanicInModuleWithError("torrentstream/RemoveCacheItem", &err)
+
+	if t, ok := r.client.currentTorrent.Get(); ok && t.InfoHash().HexString() == infoHash {
+		return errors.New("torrentstream: Cannot remove the torrent being streamed")
+	}
+
+	if client, ok := r.client.torrentClient.Get(); ok {
+		for _, t := range client.Torrents() {
+			if t.InfoHash().HexString() == infoHash {
+				t.Drop()
+			}
+		}
+	}
+
+	r.cacheManager.mu.Lock()
+	defer r.cacheManager.mu.Unlock()
+
+	return r.cacheManager.removeItem(infoHash)
+}
+
+// ClearCache removes all torrents from the download directory except the one being streamed.
+func (r *Repository) ClearCache() (err error) {
+	defer util.HandlePanicInModuleWithError("torrentstream/ClearCache", &err)
+
+	info, err := r.GetCacheInfo()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range info.Items {
+		if t, ok := r.client.currentTorrent.Get(); ok && t.InfoHash().HexString() == item.InfoHash {
+			continue
+		}
+		if err := r.RemoveCacheItem(item.InfoHash); err != nil {
+			r.logger.Warn().Err(err).Msgf("torrentstream: Failed to remove %s", item.Name)
+		}
+	}
+
+	return nil
+}
+
+// moveFile renames the file, falling back to copying it when the destination is on another device.
+func moveFile(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dest)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(dest)
+		return err
+	}
+
+	_ = in.Close()
+	return os.Remove(src)
+}
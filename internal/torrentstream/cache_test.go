@@ -0,0 +1,63 @@
+package torrentstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvictionCandidates(t *testing.T) {
+	now := time.Now()
+
+	items := []*CacheItem{
+		{InfoHash: "a", Size: 300, LastStreamedAt: now.Add(-1 * time.Hour)},
+		{InfoHash: "b", Size: 200, LastStreamedAt: now.Add(-3 * time.Hour), Completed: true},
+		{InfoHash: "c", Size: 400, LastStreamedAt: now, IsActive: true},
+		{InfoHash: "d", Size: 100, LastStreamedAt: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		maxSize       int64
+		keepCompleted bool
+		expected      []string
+	}{
+		{
+			name:     "under maximum size",
+			maxSize:  1000,
+			expected: []string{},
+		},
+		{
+			name:     "evict least recently streamed first",
+			maxSize:  800,
+			expected: []string{"b"},
+		},
+		{
+			name:     "evict until under maximum size",
+			maxSize:  650,
+			expected: []string{"b", "d", "a"},
+		},
+		{
+			name:          "keep completed episodes",
+			maxSize:       750,
+			keepCompleted: true,
+			expected:      []string{"d", "a"},
+		},
+		{
+			name:     "never evict active torrents",
+			maxSize:  0,
+			expected: []string{"b", "d", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := getEvictionCandidates(items, 1000, tt.maxSize, tt.keepCompleted)
+			require.Equal(t, tt.expected, lo.Map(ret, func(item *CacheItem, _ int) string {
+				return item.InfoHash
+			}))
+		})
+	}
+}
@@ -30,6 +30,8 @@ type (
 		currentTorrent       mo.Option[*torrent.Torrent]
 		currentFile          mo.Option[*torrent.File]
 		currentTorrentStatus TorrentStatus
+		currentMediaId       int // Used to hand over the torrent to the seeding manager when the stream stops
+		currentEpisodeNumber int
 		cancelFunc           context.CancelFunc
 
 		mu                          sync.Mutex
@@ -39,6 +41,14 @@ type (
 		lastSpeedCheck              time.Time // Track the last time we checked speeds
 		lastBytesCompleted          int64     // Track the last bytes completed
 		lastBytesWrittenData        int64     // Track the last bytes written data
+		lastCacheEviction           time.Time // Track the last time the cache was checked
+		lastBandwidthCheck          time.Time // Track the last time the bandwidth schedule was checked
+		lastSeedingCheck            time.Time // Track the last time the seeding targets were checked
+
+		downloadRateLimiter     *rate.Limiter // Updated in place by [applyBandwidthLimits]
+		uploadRateLimiter       *rate.Limiter
+		dialRateLimiter         *rate.Limiter
+		bandwidthScheduleActive bool
 	}
 
 	TorrentStatus struct {
@@ -99,10 +109,13 @@ func (c *Client) initializeClient() error {
 	// TEST ONLY: Limit download speed to 1mb/s
 	// cfg.DownloadRateLimiter = rate.NewLimiter(rate.Limit(1<<20), 1<<20)
 
-	if settings.SlowSeeding {
-		cfg.DialRateLimiter = rate.NewLimiter(rate.Limit(1), 1)
-		cfg.UploadRateLimiter = rate.NewLimiter(rate.Limit(1<<20), 2<<20)
-		// cfg.DisableAggressiveUpload = true
+	// Rate limits are applied by [applyBandwidthLimits] so that they can be changed while the client is running
+	c.newRateLimiters()
+	cfg.DownloadRateLimiter = c.downloadRateLimiter
+	cfg.UploadRateLimiter = c.uploadRateLimiter
+	cfg.DialRateLimiter = c.dialRateLimiter
+	if settings.MaxConnections > 0 {
+		cfg.EstablishedConnsPerTorrent = settings.MaxConnections
 	}
 
 	//cfg.DisableAggressiveUpload = true
@@ -130,6 +143,7 @@ func (c *Client) initializeClient() error {
 	c.repository.logger.Info().Msgf("torrentstream: Initialized torrent client on port %d", settings.TorrentClientPort)
 	c.torrentClient = mo.Some(client)
 	c.dropTorrents()
+	c.applyBandwidthLimits()
 	c.mu.Unlock()
 
 	go func(ctx context.Context) {
@@ -239,6 +253,21 @@ func (c *Client) initializeClient() error {
 				}
 				c.mu.Unlock()
 				if c.torrentClient.IsPresent() {
+					// Apply the bandwidth schedule
+					if time.Since(c.lastBandwidthCheck) > 30*time.Second {
+						c.lastBandwidthCheck = time.Now()
+						c.applyBandwidthLimits()
+					}
+					// Keep the download directory under the maximum size while streaming
+					if time.Since(c.lastCacheEviction) > 1*time.Minute {
+						c.lastCacheEviction = time.Now()
+						go c.repository.cacheManager.evict()
+					}
+					// Drop the torrents that have reached their seeding target
+					if time.Since(c.lastSeedingCheck) > 10*time.Second {
+						c.lastSeedingCheck = time.Now()
+						go c.repository.seedingManager.check()
+					}
 					if time.Since(c.timeSinceLoggedSeeding) > 20*time.Second {
 						c.timeSinceLoggedSeeding = time.Now()
 						for _, t := range c.torrentClient.MustGet().Torrents() {
@@ -306,20 +335,40 @@ func (c *Client) AddTorrent(id string) (*torrent.Torrent, error) {
 		return nil, errors.New("torrent client is not initialized")
 	}
 
-	// Drop all torrents
+	// Drop all torrents, except the ones that are still seeding
 	for _, t := range c.torrentClient.MustGet().Torrents() {
+		if c.repository.seedingManager.isSeeding(t) {
+			continue
+		}
 		t.Drop()
 	}
 
-	if strings.HasPrefix(id, "magnet") {
-		return c.addTorrentMagnet(id)
+	return c.addTorrent(id)
+}
+
+// addTorrent adds a torrent without dropping the torrents that are already added.
+// This is used to prefetch the next episode while the current one is being streamed.
+func (c *Client) addTorrent(id string) (*torrent.Torrent, error) {
+	if c.torrentClient.IsAbsent() {
+		return nil, errors.New("torrent client is not initialized")
 	}
 
-	if strings.HasPrefix(id, "http") {
-		return c.addTorrentFromDownloadURL(id)
+	var t *torrent.Torrent
+	var err error
+	switch {
+	case strings.HasPrefix(id, "magnet"):
+		t, err = c.addTorrentMagnet(id)
+	case strings.HasPrefix(id, "http"):
+		t, err = c.addTorrentFromDownloadURL(id)
+	default:
+		t, err = c.addTorrentFromFile(id)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return c.addTorrentFromFile(id)
+	c.applyMaxConnections(t, c.getMaxConnections())
+	return t, nil
 }
 
 func (c *Client) addTorrentMagnet(magnet string) (*torrent.Torrent, error) {
@@ -410,6 +459,7 @@ func (c *Client) Shutdown() (errs []error) {
 	if c.torrentClient.IsAbsent() {
 		return
 	}
+	c.repository.seedingManager.clear()
 	c.dropTorrents()
 	c.currentTorrent = mo.None[*torrent.Torrent]()
 	c.currentTorrentStatus = TorrentStatus{}
@@ -450,21 +500,75 @@ func (c *Client) RemoveTorrent(infoHash string) error {
 	return fmt.Errorf("no torrent found")
 }
 
+// dropTorrentsExcept drops all torrents except the one with the given info hash and the ones that are still seeding.
+// Unlike dropTorrents, it does not remove the downloaded data.
+func (c *Client) dropTorrentsExcept(infoHash string) {
+	if c.torrentClient.IsAbsent() {
+		return
+	}
+
+	for _, t := range c.torrentClient.MustGet().Torrents() {
+		if t.InfoHash().AsString() != infoHash && !c.repository.seedingManager.isSeeding(t) {
+			t.Drop()
+		}
+	}
+}
+
+// dropTorrent drops a single torrent, unless it is still seeding or has been prefetched for the next episode.
+// Like dropTorrents, its data is removed if the cache is disabled.
+func (c *Client) dropTorrent(t *torrent.Torrent) {
+	if c.torrentClient.IsAbsent() || t == nil {
+		return
+	}
+
+	if c.repository.seedingManager.isSeeding(t) || c.repository.prefetcher.isPrefetched(t) {
+		return
+	}
+
+	c.repository.logger.Trace().Msgf("torrentstream: Dropping torrent: %s", t.InfoHash().HexString())
+	t.Drop()
+
+	if c.repository.cacheManager.isEnabled() {
+		go c.repository.cacheManager.evict()
+		return
+	}
+
+	if c.repository.settings.IsPresent() {
+		_ = os.RemoveAll(path.Join(c.repository.settings.MustGet().DownloadDir, t.InfoHash().HexString()))
+	}
+}
+
 func (c *Client) dropTorrents() {
 	if c.torrentClient.IsAbsent() {
 		return
 	}
 	c.repository.logger.Trace().Msg("torrentstream: Dropping all torrents")
 
+	// Torrents that are still seeding are kept along with their data
+	seeding := make(map[string]struct{})
 	for _, t := range c.torrentClient.MustGet().Torrents() {
+		if c.repository.seedingManager.isSeeding(t) {
+			seeding[t.InfoHash().HexString()] = struct{}{}
+			continue
+		}
 		t.Drop()
 	}
 
+	// Keep the downloaded data if the cache is enabled, only evict torrents if it's over the maximum size
+	if c.repository.cacheManager.isEnabled() {
+		go c.repository.cacheManager.evict()
+		c.repository.logger.Debug().Msg("torrentstream: Dropped all torrents")
+		return
+	}
+
 	if c.repository.settings.IsPresent() {
 		// Delete all torrents
 		fe, err := os.ReadDir(c.repository.settings.MustGet().DownloadDir)
 		if err == nil {
 			for _, f := range fe {
+				if _, found := seeding[f.Name()]; found {
+					continue
+				}
 				if f.IsDir() {
 					_ = os.RemoveAll(path.Join(c.repository.settings.MustGet().DownloadDir, f.Name()))
 				}
@@ -58,6 +58,7 @@ func (r *Repository) listenToMediaPlayerEvents() {
 			case status := <-r.mediaPlayerRepositorySubscriber.StreamingPlaybackStatusCh:
 				go func() {
 					if status != nil && r.client.currentTorrent.IsPresent() {
+						r.prefetcher.onPlaybackStatus(status)
 						r.client.mediaPlayerPlaybackStatusCh <- status
 					}
 				}()
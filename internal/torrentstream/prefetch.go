@@ -0,0 +1,323 @@
+package torrentstream
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"seanime/internal/api/anilist"
+	"seanime/internal/extension"
+	hibiketorrent "seanime/internal/extension/hibike/torrent"
+	"seanime/internal/mediaplayers/mediaplayer"
+	torrentanalyzer "seanime/internal/torrents/analyzer"
+	itorrent "seanime/internal/torrents/torrent"
+	"seanime/internal/util"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/anacrolix/torrent"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+type (
+	// prefetcher downloads the beginning of the next episode while the current one is being streamed.
+	// When the next episode is played right after, the stream can start without waiting for the initial buffer.
+	prefetcher struct {
+		repository *Repository
+		mu         sync.Mutex
+		current    mo.Option[*prefetchEpisode] // The episode being streamed, set by [StartStream]
+		prefetched mo.Option[*prefetchEpisode] // The next episode, set once its file has been found
+		started    bool                        // Whether prefetching has started for the current episode
+	}
+
+	prefetchEpisode struct {
+		Media         *anilist.CompleteAnime
+		EpisodeNumber int
+		Torrent       *torrent.Torrent
+		File          *torrent.File
+	}
+)
+
+func newPrefetcher(repository *Repository) *prefetcher {
+	return &prefetcher{
+		repository: repository,
+		current:    mo.None[*prefetchEpisode](),
+		prefetched: mo.None[*prefetchEpisode](),
+	}
+}
+
+// setCurrent is called when a stream starts.
+func (p *prefetcher) setCurrent(media *anilist.CompleteAnime, episodeNumber int, pt *playbackTorrent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = mo.Some(&prefetchEpisode{
+		Media:         media,
+		EpisodeNumber: episodeNumber,
+		Torrent:       pt.Torrent,
+		File:          pt.File,
+	})
+	p.started = false
+}
+
+// resetCurrent is called when the stream stops.
+// The prefetched episode is kept so that it can be picked up by the next stream.
+func (p *prefetcher) resetCurrent() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = mo.None[*prefetchEpisode]()
+	p.started = false
+}
+
+// isPrefetched returns true if the torrent holds the prefetched episode.
+func (p *prefetcher) isPrefetched(t *torrent.Torrent) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep, ok := p.prefetched.Get()
+	return ok && ep.Torrent == t
+}
+
+// take returns the prefetched torrent if it corresponds to the episode that is about to be streamed.
+// The other torrents are dropped and the file is prioritized for streaming.
+func (p *prefetcher) take(mediaId int, episodeNumber int) (*playbackTorrent, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep, ok := p.prefetched.Get()
+	if !ok || ep.Media.GetID() != mediaId || ep.EpisodeNumber != episodeNumber {
+		return nil, false
+	}
+	p.prefetched = mo.None[*prefetchEpisode]()
+
+	// The torrent might have been dropped since it was prefetched
+	select {
+	case <-ep.Torrent.Closed():
+		return nil, false
+	default:
+	}
+
+	p.repository.logger.Debug().Msgf("torrentstream: Using prefetched file for episode %d: %s", episodeNumber, ep.File.DisplayPath())
+
+	p.repository.client.dropTorrentsExcept(ep.Torrent.InfoHash().AsString())
+
+	for _, f := range ep.Torrent.Files() {
+		if f != ep.File {
+			f.SetPriority(torrent.PiecePriorityNone)
+		}
+	}
+	ep.File.Download()
+	p.repository.setPriorityDownloadStrategy(ep.Torrent, ep.File)
+
+	return &playbackTorrent{
+		Torrent: ep.Torrent,
+		File:    ep.File,
+	}, true
+}
+
+// onPlaybackStatus starts prefetching the next episode once the playback has reached the threshold.
+func (p *prefetcher) onPlaybackStatus(status *mediaplayer.PlaybackStatus) {
+	settings, ok := p.repository.settings.Get()
+	if !ok || !settings.PrefetchNextEpisode || status == nil {
+		return
+	}
+
+	p.mu.Lock()
+	current, ok := p.current.Get()
+	if !ok || p.started || status.CompletionPercentage*100 < float64(settings.PrefetchThreshold) {
+		p.mu.Unlock()
+		return
+	}
+	p.started = true
+	p.mu.Unlock()
+
+	go p.prefetchNextEpisode(current, int64(settings.PrefetchSize)*1024*1024)
+}
+
+func (p *prefetcher) prefetchNextEpisode(current *prefetchEpisode, size int64) {
+	defer util.HandlePanicInModuleThen("torrentstream/prefetchNextEpisode", func() {})
+
+	r := p.repository
+
+	nextEpisodeNumber := current.EpisodeNumber + 1
+	if count := current.Media.GetCurrentEpisodeCount(); count > 0 && nextEpisodeNumber > count {
+		r.logger.Debug().Msg("torrentstream: No next episode to prefetch")
+		return
+	}
+
+	p.mu.Lock()
+	if ep, ok := p.prefetched.Get(); ok && ep.Media.GetID() == current.Media.GetID() && ep.EpisodeNumber == nextEpisodeNumber {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	r.logger.Debug().Msgf("torrentstream: Prefetching episode %d of %s", nextEpisodeNumber, current.Media.GetTitleSafe())
+
+	// Look for the next episode in the current torrent first, in case it's a batch
+	next, found := r.findPrefetchFileInTorrent(current.Media, current.Torrent, nextEpisodeNumber)
+	if !found {
+		var err error
+		next, err = r.findPrefetchTorrent(current, nextEpisodeNumber)
+		if err != nil {
+			r.logger.Warn().Err(err).Msgf("torrentstream: Could not prefetch episode %d", nextEpisodeNumber)
+			return
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another stream was started in the meantime
+	if cur, ok := p.current.Get(); ok && cur != current {
+		return
+	}
+
+	begin, end := getPrefetchPieceRange(next.File.Offset(), next.File.Length(), size, next.Torrent.Info().PieceLength, next.Torrent.NumPieces())
+	for idx := begin; idx <= end; idx++ {
+		// Do not lower the priority of pieces shared with the current file
+		if next.Torrent.Piece(idx).State().Priority > torrent.PiecePriorityNormal {
+			continue
+		}
+		next.Torrent.Piece(idx).SetPriority(torrent.PiecePriorityNormal)
+	}
+
+	r.logger.Debug().Msgf("torrentstream: Prefetching pieces %d to %d of %s", begin, end, next.File.DisplayPath())
+
+	p.prefetched = mo.Some(next)
+}
+
+// findPrefetchFileInTorrent analyzes the files of a batch torrent to find the file of the given episode.
+func (r *Repository) findPrefetchFileInTorrent(media *anilist.CompleteAnime, t *torrent.Torrent, episodeNumber int) (*prefetchEpisode, bool) {
+	if len(t.Files()) <= 1 {
+		return nil, false
+	}
+
+	filepaths := lo.Map(t.Files(), func(f *torrent.File, _ int) string {
+		return f.DisplayPath()
+	})
+
+	analyzer := torrentanalyzer.NewAnalyzer(&torrentanalyzer.NewAnalyzerOptions{
+		Logger:           r.logger,
+		Filepaths:        filepaths,
+		Media:            media,
+		Platform:         r.platform,
+		MetadataProvider: r.metadataProvider,
+		ForceMatch:       true,
+	})
+
+	analysis, err := analyzer.AnalyzeTorrentFiles()
+	if err != nil {
+		return nil, false
+	}
+
+	analysisFile, found := analysis.GetFileByAniDBEpisode(strconv.Itoa(episodeNumber))
+	if !found {
+		return nil, false
+	}
+
+	return &prefetchEpisode{
+		Media:         media,
+		EpisodeNumber: episodeNumber,
+		Torrent:       t,
+		File:          t.Files()[analysisFile.GetIndex()],
+	}, true
+}
+
+// findPrefetchTorrent searches for a torrent containing the given episode and adds it to the client without dropping the current one.
+// Unlike findBestTorrent, no loading status is sent to the client.
+func (r *Repository) findPrefetchTorrent(current *prefetchEpisode, episodeNumber int) (*prefetchEpisode, error) {
+	if r.torrentRepository == nil {
+		return nil, fmt.Errorf("torrent repository not set")
+	}
+
+	var data *itorrent.SearchData
+	var providerExtension extension.AnimeTorrentProviderExtension
+	for _, providerId := range []string{itorrent.ProviderAnimeTosho, itorrent.ProviderNyaa} {
+		ext, ok := r.torrentRepository.GetAnimeProviderExtension(providerId)
+		if !ok {
+			continue
+		}
+		res, err := r.torrentRepository.SearchAnime(context.Background(), itorrent.AnimeSearchOptions{
+			Provider:      providerId,
+			Type:          itorrent.AnimeSearchTypeSmart,
+			Media:         current.Media.ToBaseAnime(),
+			Query:         "",
+			Batch:         false,
+			EpisodeNumber: episodeNumber,
+			BestReleases:  false,
+			Resolution:    r.settings.MustGet().PreferredResolution,
+		})
+		if err != nil || res == nil || len(res.Torrents) == 0 {
+			continue
+		}
+		data = res
+		providerExtension = ext
+		break
+	}
+
+	if data == nil {
+		return nil, ErrNoTorrentsFound
+	}
+
+	slices.SortStableFunc(data.Torrents, func(a, b *hibiketorrent.AnimeTorrent) int {
+		return cmp.Compare(b.Seeders, a.Seeders)
+	})
+
+	currentInfoHash := current.Torrent.InfoHash().AsString()
+
+	for _, searchT := range data.Torrents[:min(2, len(data.Torrents))] {
+		if searchT.InfoHash != "" && searchT.InfoHash == currentInfoHash {
+			continue
+		}
+
+		magnet, err := providerExtension.GetProvider().GetTorrentMagnetLink(searchT)
+		if err != nil {
+			continue
+		}
+
+		t, err := r.client.addTorrent(magnet)
+		if err != nil {
+			continue
+		}
+
+		// Never drop the torrent being streamed
+		if t.InfoHash().AsString() == currentInfoHash {
+			continue
+		}
+
+		if len(t.Files()) == 1 {
+			return &prefetchEpisode{
+				Media:         current.Media,
+				EpisodeNumber: episodeNumber,
+				Torrent:       t,
+				File:          t.Files()[0],
+			}, nil
+		}
+
+		if ep, found := r.findPrefetchFileInTorrent(current.Media, t, episodeNumber); found {
+			return ep, nil
+		}
+
+		_ = r.client.RemoveTorrent(t.InfoHash().AsString())
+	}
+
+	return nil, ErrNoEpisodeFound
+}
+
+// getPrefetchPieceRange returns the indices of the first and last pieces covering the first `size` bytes of a file.
+func getPrefetchPieceRange(fileOffset int64, fileLength int64, size int64, pieceLength int64, numPieces int) (begin int, end int) {
+	if pieceLength <= 0 || numPieces <= 0 || fileLength <= 0 {
+		return 0, -1
+	}
+
+	size = min(size, fileLength)
+	begin = int(fileOffset / pieceLength)
+	end = int((fileOffset + size - 1) / pieceLength)
+
+	begin = min(begin, numPieces-1)
+	end = min(end, numPieces-1)
+	return
+}
@@ -0,0 +1,71 @@
+package torrentstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPrefetchPieceRange(t *testing.T) {
+	const mb = int64(1024 * 1024)
+
+	tests := []struct {
+		name          string
+		fileOffset    int64
+		fileLength    int64
+		size          int64
+		pieceLength   int64
+		numPieces     int
+		expectedBegin int
+		expectedEnd   int
+	}{
+		{
+			name:          "single file torrent",
+			fileOffset:    0,
+			fileLength:    400 * mb,
+			size:          50 * mb,
+			pieceLength:   mb,
+			numPieces:     400,
+			expectedBegin: 0,
+			expectedEnd:   49,
+		},
+		{
+			name:          "file in the middle of a batch",
+			fileOffset:    400*mb + mb/2,
+			fileLength:    400 * mb,
+			size:          10 * mb,
+			pieceLength:   mb,
+			numPieces:     1200,
+			expectedBegin: 400,
+			expectedEnd:   410,
+		},
+		{
+			name:          "file smaller than the prefetch size",
+			fileOffset:    2 * mb,
+			fileLength:    3 * mb,
+			size:          50 * mb,
+			pieceLength:   mb,
+			numPieces:     5,
+			expectedBegin: 2,
+			expectedEnd:   4,
+		},
+		{
+			name:          "empty file",
+			fileOffset:    0,
+			fileLength:    0,
+			size:          50 * mb,
+			pieceLength:   mb,
+			numPieces:     5,
+			expectedBegin: 0,
+			expectedEnd:   -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			begin, end := getPrefetchPieceRange(tt.fileOffset, tt.fileLength, tt.size, tt.pieceLength, tt.numPieces)
+			require.Equal(t, tt.expectedBegin, begin)
+			require.Equal(t, tt.expectedEnd, end)
+		})
+	}
+}
@@ -25,6 +25,9 @@ type (
 	Repository struct {
 		client                   *Client
 		serverManager            *serverManager
+		prefetcher               *prefetcher
+		cacheManager             *cacheManager
+		seedingManager           *seedingManager
 		playback                 playback
 		settings                 mo.Option[Settings]           // None by default, set and refreshed by [SetSettings]
 		currentEpisodeCollection mo.Option[*EpisodeCollection] // Refreshed in [list.go] when the user opens the streaming page for a media
@@ -87,6 +90,9 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 	}
 	ret.client = NewClient(ret)
 	ret.serverManager = newServerManager(ret)
+	ret.prefetcher = newPrefetcher(ret)
+	ret.cacheManager = newCacheManager(ret)
+	ret.seedingManager = newSeedingManager(ret)
 	return ret
 }
 
@@ -119,12 +125,12 @@ func (r *Repository) SetMediaPlayerRepository(mediaPlayerRepository *mediaplayer
 // InitModules sets the settings for the torrentstream module.
 // It should be called before any other method, to ensure the module is active.
 func (r *Repository) InitModules(settings *models.TorrentstreamSettings, host string, port int, isMainServer bool) (err error) {
-	r.client.Shutdown()
 	useSeparateServer := false
 
 	defer util.HandlePanicInModuleWithError("torrentstream/InitModules", &err)
 
 	if settings == nil {
+		r.client.Shutdown()
 		r.logger.Error().Msg("torrentstream: Cannot initialize module, no settings provided")
 		r.settings = mo.None[Settings]()
 		return errors.New("torrentstream: Cannot initialize module, no settings provided")
@@ -133,6 +139,7 @@ func (r *Repository) InitModules(settings *models.TorrentstreamSettings, host st
 	s := *settings
 
 	if s.Enabled == false {
+		r.client.Shutdown()
 		r.logger.Info().Msg("torrentstream: Module is disabled")
 		r.Shutdown()
 		r.settings = mo.None[Settings]()
@@ -158,14 +165,38 @@ func (r *Repository) InitModules(settings *models.TorrentstreamSettings, host st
 	if s.StreamingServerHost == "" {
 		s.StreamingServerHost = "127.0.0.1"
 	}
+	if s.PrefetchThreshold <= 0 || s.PrefetchThreshold > 100 {
+		s.PrefetchThreshold = 70
+	}
+	if s.PrefetchSize <= 0 {
+		s.PrefetchSize = 50
+	}
+	if s.SeedRatioLimit < 0 {
+		s.SeedRatioLimit = 0
+	}
+	if s.SeedTimeLimit < 0 {
+		s.SeedTimeLimit = 0
+	}
 
-	// Set the settings
-	r.settings = mo.Some(Settings{
+	newSettings := Settings{
 		TorrentstreamSettings: s,
 		Host:                  host,
 		Port:                  port,
 		UseSeparateServer:     useSeparateServer,
-	})
+	}
+
+	// Apply the settings to the running client if they don't require a restart, e.g. bandwidth limits
+	if prev, ok := r.settings.Get(); ok && r.client.torrentClient.IsPresent() && !requiresClientRestart(&prev, &newSettings) {
+		r.settings = mo.Some(newSettings)
+		r.client.applyBandwidthLimits()
+		r.logger.Info().Msg("torrentstream: Settings updated")
+		return nil
+	}
+
+	r.client.Shutdown()
+
+	// Set the settings
+	r.settings = mo.Some(newSettings)
 
 	// Initialize the torrent client
 	err = r.client.initializeClient()
@@ -182,6 +213,18 @@ func (r *Repository) InitModules(settings *models.TorrentstreamSettings, host st
 	return nil
 }
 
+// requiresClientRestart returns true if the settings used to create the torrent client have changed.
+func requiresClientRestart(prev *Settings, next *Settings) bool {
+	return prev.DisableIPV6 != next.DisableIPV6 ||
+		prev.DownloadDir != next.DownloadDir ||
+		prev.TorrentClientHost != next.TorrentClientHost ||
+		prev.TorrentClientPort != next.TorrentClientPort ||
+		prev.StreamingServerHost != next.StreamingServerHost ||
+		prev.StreamingServerPort != next.StreamingServerPort ||
+		prev.Host != next.Host ||
+		prev.Port != next.Port
+}
+
 func (r *Repository) HTTPStreamHandler() http.Handler {
 	return r.serverManager
 }
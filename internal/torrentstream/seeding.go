@@ -0,0 +1,279 @@
+package torrentstream
+
+import (
+	"cmp"
+	"errors"
+	"seanime/internal/util"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anacrolix/torrent"
+	"github.com/samber/lo"
+)
+
+type (
+	// seedingManager keeps streamed torrents seeding after playback until the ratio or time target is met.
+	// Torrents are only handed over to the seeding manager when a target is set in the settings.
+	seedingManager struct {
+		repository *Repository
+		mu         sync.Mutex
+		torrents   map[string]*seedingTorrent // Key: info hash
+	}
+
+	seedingTorrent struct {
+		torrent       *torrent.Torrent
+		mediaId       int
+		episodeNumber int
+		startedAt     time.Time
+	}
+
+	SeedingTorrent struct {
+		InfoHash      string    `json:"infoHash"`
+		Name          string    `json:"name"`
+		MediaId       int       `json:"mediaId"`
+		EpisodeNumber int       `json:"episodeNumber"`
+		Uploaded      int64     `json:"uploaded"`
+		Downloaded    int64     `json:"downloaded"`
+		Ratio         float64   `json:"ratio"`
+		Peers         int       `json:"peers"`
+		SeedingSince  time.Time `json:"seedingSince"`
+		// Progress towards the ratio or time target, whichever is closer, from 0 to 1
+		TargetProgress float64 `json:"targetProgress"`
+	}
+)
+
+func newSeedingManager(repository *Repository) *seedingManager {
+	return &seedingManager{
+		repository: repository,
+		torrents:   make(map[string]*seedingTorrent),
+	}
+}
+
+// isEnabled returns true if a ratio or time target is set.
+func (m *seedingManager) isEnabled() bool {
+	settings, ok := m.repository.settings.Get()
+	return ok && (settings.SeedRatioLimit > 0 || settings.SeedTimeLimit > 0)
+}
+
+// add hands over a torrent to the seeding manager after playback.
+func (m *seedingManager) add(t *torrent.Torrent, mediaId int, episodeNumber int) {
+	if !m.isEnabled() {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infoHash := t.InfoHash().HexString()
+	if _, found := m.torrents[infoHash]; found {
+		return
+	}
+
+	m.repository.logger.Debug().Msgf("torrentstream: Seeding %s", t.Name())
+
+	m.torrents[infoHash] = &seedingTorrent{
+		torrent:       t,
+		mediaId:       mediaId,
+		episodeNumber: episodeNumber,
+		startedAt:     time.Now(),
+	}
+}
+
+// remove stops tracking a torrent without dropping it, e.g. when it's streamed again.
+func (m *seedingManager) remove(t *torrent.Torrent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.torrents, t.InfoHash().HexString())
+}
+
+// isSeeding returns true if the torrent is tracked by the seeding manager.
+func (m *seedingManager) isSeeding(t *torrent.Torrent) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, found := m.torrents[t.InfoHash().HexString()]
+	return found
+}
+
+// clear stops tracking all torrents, the caller is responsible for dropping them.
+func (m *seedingManager) clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.torrents = make(map[string]*seedingTorrent)
+}
+
+// GetSeedingTorrents returns the torrents that are still seeding.
+func (r *Repository) GetSeedingTorrents() []*SeedingTorrent {
+	r.seedingManager.mu.Lock()
+	defer r.seedingManager.mu.Unlock()
+
+	return r.seedingManager.getSeedingTorrents()
+}
+
+func (m *seedingManager) getSeedingTorrents() []*SeedingTorrent {
+	settings, _ := m.repository.settings.Get()
+
+	ret := make([]*SeedingTorrent, 0, len(m.torrents))
+	for infoHash, st := range m.torrents {
+		stats := st.torrent.Stats()
+		uploaded := stats.BytesWrittenData.Int64()
+		downloaded := st.torrent.BytesCompleted()
+
+		ret = append(ret, &SeedingTorrent{
+			InfoHash:       infoHash,
+			Name:           st.torrent.Name(),
+			MediaId:        st.mediaId,
+			EpisodeNumber:  st.episodeNumber,
+			Uploaded:       uploaded,
+			Downloaded:     downloaded,
+			Ratio:          getSeedRatio(uploaded, downloaded),
+			Peers:          stats.ActivePeers,
+			SeedingSince:   st.startedAt,
+			TargetProgress: getSeedTargetProgress(getSeedRatio(uploaded, downloaded), time.Since(st.startedAt), settings.SeedRatioLimit, settings.SeedTimeLimit),
+		})
+	}
+
+	slices.SortStableFunc(ret, func(a, b *SeedingTorrent) int {
+		return a.SeedingSince.Compare(b.SeedingSince)
+	})
+
+	return ret
+}
+
+// StopSeeding drops a seeding torrent.
+func (r *Repository) StopSeeding(infoHash string) error {
+	r.seedingManager.mu.Lock()
+	st, found := r.seedingManager.torrents[infoHash]
+	if !found {
+		r.seedingManager.mu.Unlock()
+		return errors.New("torrentstream: Torrent is not seeding")
+	}
+	delete(r.seedingManager.torrents, infoHash)
+	r.seedingManager.mu.Unlock()
+
+	st.torrent.Drop()
+	r.logger.Debug().Msgf("torrentstream: Stopped seeding %s", st.torrent.Name())
+
+	r.seedingManager.sendStatus()
+	go r.cacheManager.evict()
+	return nil
+}
+
+// check drops the torrents that have reached their target and sends the seeding status to the client.
+// If the cache is over its maximum size, the oldest seeding torrents are dropped so that they can be evicted.
+func (m *seedingManager) check() {
+	defer util.HandlePanicInModuleThen("torrentstream/seedingManager/check", func() {})
+
+	settings, ok := m.repository.settings.Get()
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	if len(m.torrents) == 0 {
+		m.mu.Unlock()
+		return
+	}
+
+	// Stop seeding everything if the targets have been removed from the settings
+	disabled := settings.SeedRatioLimit <= 0 && settings.SeedTimeLimit <= 0
+
+	dropped := make([]*seedingTorrent, 0)
+	for infoHash, st := range m.torrents {
+		stats := st.torrent.Stats()
+		ratio := getSeedRatio(stats.BytesWrittenData.Int64(), st.torrent.BytesCompleted())
+		if disabled || getSeedTargetProgress(ratio, time.Since(st.startedAt), settings.SeedRatioLimit, settings.SeedTimeLimit) >= 1 {
+			m.repository.logger.Debug().Msgf("torrentstream: Seeding target reached for %s, ratio %.2f", st.torrent.Name(), ratio)
+			dropped = append(dropped, st)
+			delete(m.torrents, infoHash)
+		}
+	}
+
+	for _, st := range m.getTorrentsOverCacheBudget() {
+		m.repository.logger.Debug().Msgf("torrentstream: Cache is full, stopped seeding %s", st.torrent.Name())
+		dropped = append(dropped, st)
+		delete(m.torrents, st.torrent.InfoHash().HexString())
+	}
+	m.mu.Unlock()
+
+	for _, st := range dropped {
+		st.torrent.Drop()
+	}
+
+	m.sendStatus()
+
+	if len(dropped) > 0 {
+		go m.repository.cacheManager.evict()
+	}
+}
+
+// getTorrentsOverCacheBudget returns the oldest seeding torrents to drop so that the cache can get under its maximum size.
+// Inactive torrents are evicted first, so seeding torrents are only dropped if that's not enough.
+func (m *seedingManager) getTorrentsOverCacheBudget() []*seedingTorrent {
+	if !m.repository.cacheManager.isEnabled() {
+		return nil
+	}
+
+	m.repository.cacheManager.mu.Lock()
+	info, err := m.repository.cacheManager.getCacheInfo()
+	m.repository.cacheManager.mu.Unlock()
+	if err != nil || info.TotalSize <= info.MaxSize {
+		return nil
+	}
+
+	settings := m.repository.settings.MustGet()
+	remaining := info.TotalSize
+	for _, item := range getEvictionCandidates(info.Items, info.TotalSize, info.MaxSize, settings.CacheKeepCompleted && !settings.AddToLibrary) {
+		remaining -= item.Size
+	}
+
+	sizes := lo.SliceToMap(info.Items, func(item *CacheItem) (string, int64) {
+		return item.InfoHash, item.Size
+	})
+
+	seeding := lo.Values(m.torrents)
+	slices.SortStableFunc(seeding, func(a, b *seedingTorrent) int {
+		return cmp.Compare(a.startedAt.UnixNano(), b.startedAt.UnixNano())
+	})
+
+	ret := make([]*seedingTorrent, 0)
+	for _, st := range seeding {
+		if remaining <= info.MaxSize {
+			break
+		}
+		ret = append(ret, st)
+		remaining -= sizes[st.torrent.InfoHash().HexString()]
+	}
+	return ret
+}
+
+func (m *seedingManager) sendStatus() {
+	m.mu.Lock()
+	torrents := m.getSeedingTorrents()
+	m.mu.Unlock()
+
+	m.repository.wsEventManager.SendEvent(eventTorrentSeedingStatus, torrents)
+}
+
+func getSeedRatio(uploaded int64, downloaded int64) float64 {
+	if downloaded <= 0 {
+		return 0
+	}
+	return float64(uploaded) / float64(downloaded)
+}
+
+// getSeedTargetProgress returns the progress towards the closest target, from 0 to 1.
+// A target of 0 is ignored.
+func getSeedTargetProgress(ratio float64, elapsed time.Duration, ratioLimit float64, timeLimit int) float64 {
+	progress := 0.0
+	if ratioLimit > 0 {
+		progress = max(progress, ratio/ratioLimit)
+	}
+	if timeLimit > 0 {
+		progress = max(progress, elapsed.Minutes()/float64(timeLimit))
+	}
+	return min(progress, 1)
+}
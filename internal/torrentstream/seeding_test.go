@@ -0,0 +1,32 @@
+package torrentstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSeedTargetProgress(t *testing.T) {
+	tests := []struct {
+		name       string
+		ratio      float64
+		elapsed    time.Duration
+		ratioLimit float64
+		timeLimit  int
+		expected   float64
+	}{
+		{name: "no targets", ratio: 5, elapsed: 10 * time.Hour, expected: 0},
+		{name: "ratio target", ratio: 0.5, ratioLimit: 1, expected: 0.5},
+		{name: "ratio target reached", ratio: 1.5, ratioLimit: 1, expected: 1},
+		{name: "time target", elapsed: 30 * time.Minute, timeLimit: 60, expected: 0.5},
+		{name: "closest target wins", ratio: 0.25, elapsed: 45 * time.Minute, ratioLimit: 1, timeLimit: 60, expected: 0.75},
+		{name: "time target reached first", ratio: 0.1, elapsed: 2 * time.Hour, ratioLimit: 2, timeLimit: 60, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, getSeedTargetProgress(tt.ratio, tt.elapsed, tt.ratioLimit, tt.timeLimit), 0.0001)
+		})
+	}
+}
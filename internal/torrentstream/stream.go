@@ -65,6 +65,11 @@ func (r *Repository) StartStream(opts *StartStreamOptions) (err error) {
 	var torrentToStream *playbackTorrent
 	switch opts.AutoSelect {
 	case true:
+		// Use the file prefetched during the previous episode if there's one
+		if prefetched, found := r.prefetcher.take(opts.MediaId, episodeNumber); found {
+			torrentToStream = prefetched
+			break
+		}
 		torrentToStream, err = r.findBestTorrent(media, aniDbEpisode, episodeNumber)
 		if err != nil {
 			r.wsEventManager.SendEvent(eventTorrentLoadingFailed, nil)
@@ -91,6 +96,11 @@ func (r *Repository) StartStream(opts *StartStreamOptions) (err error) {
 	//
 	r.client.currentFile = mo.Some(torrentToStream.File)
 	r.client.currentTorrent = mo.Some(torrentToStream.Torrent)
+	r.client.currentMediaId = opts.MediaId
+	r.client.currentEpisodeNumber = episodeNumber
+	r.seedingManager.remove(torrentToStream.Torrent) // The torrent might still be seeding from a previous stream
+	r.prefetcher.setCurrent(media, episodeNumber, torrentToStream)
+	go r.cacheManager.touch(opts.MediaId, episodeNumber, torrentToStream.Torrent, torrentToStream.File)
 
 	r.sendTorrentLoadingStatus(TLSStateStartingServer, "")
 
@@ -204,15 +214,22 @@ func (r *Repository) StopStream() error {
 	// This is to prevent the client from downloading the whole torrent when the user stops watching
 	// Also, the torrent might be a batch - so we don't want to download the whole thing
 	if r.client.currentTorrent.IsPresent() {
+		if r.client.currentFile.IsPresent() {
+			r.cacheManager.markCompleted(r.client.currentTorrent.MustGet(), r.client.currentFile.MustGet())
+		}
 		if r.client.currentTorrentStatus.ProgressPercentage < 70 {
 			r.client.repository.logger.Debug().Msg("torrentstream: Dropping torrent, completion is less than 70%")
-			r.client.dropTorrents()
+			r.client.dropTorrent(r.client.currentTorrent.MustGet())
+		} else {
+			// Keep seeding the torrent until the seeding target is reached
+			r.seedingManager.add(r.client.currentTorrent.MustGet(), r.client.currentMediaId, r.client.currentEpisodeNumber)
 		}
 		r.client.repository.logger.Debug().Msg("torrentstream: Resetting current torrent and status")
 	}
 	r.client.currentTorrent = mo.None[*torrent.Torrent]() // Reset the current torrent
 	r.client.currentFile = mo.None[*torrent.File]()       // Reset the current file
 	r.client.currentTorrentStatus = TorrentStatus{}       // Reset the torrent status
+	r.prefetcher.resetCurrent()
 	settings, ok := r.client.repository.settings.Get()
 	if ok && settings.UseSeparateServer {
 		r.client.repository.serverManager.stopServer() // Stop the server
@@ -250,9 +267,11 @@ func (r *Repository) DropTorrent() error {
 		return nil
 	}
 
+	r.seedingManager.clear()
 	for _, t := range r.client.torrentClient.MustGet().Torrents() {
 		t.Drop()
 	}
+	r.seedingManager.sendStatus()
 
 	// Also stop the server, since it's dropped
 	settings, ok := r.settings.Get()
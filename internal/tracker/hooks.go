@@ -0,0 +1,104 @@
+package tracker
+
+import (
+	"seanime/internal/api/anilist"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/hook"
+	"seanime/internal/hook_resolver"
+	"seanime/internal/platforms/anilist_platform"
+)
+
+// hookPriority makes the handlers run before the plugin handlers, which can stop the chain.
+const hookPriority = -1000
+
+// bindHooks mirrors the list entry changes once AniList is updated.
+// The post update events are only triggered if the update was not prevented, and hold the values sent to AniList.
+func (m *Manager) bindHooks() {
+	if m.hookIds != nil {
+		return
+	}
+	m.hookIds = []string{
+		hook.GlobalHookManager.OnPostUpdateEntry().Bind(&hook.Handler[hook_resolver.Resolver]{
+			Func: func(e hook_resolver.Resolver) error {
+				if event, ok := e.(*anilist_platform.PostUpdateEntryEvent); ok && event.MediaID != nil {
+					m.onEntryUpdated(event)
+				}
+				return e.Next()
+			},
+			Priority: hookPriority,
+		}),
+		hook.GlobalHookManager.OnPostUpdateEntryProgress().Bind(&hook.Handler[hook_resolver.Resolver]{
+			Func: func(e hook_resolver.Resolver) error {
+				if event, ok := e.(*anilist_platform.PostUpdateEntryProgressEvent); ok && event.MediaID != nil {
+					m.onEntryProgressUpdated(event)
+				}
+				return e.Next()
+			},
+			Priority: hookPriority,
+		}),
+	}
+}
+
+func (m *Manager) unbindHooks() {
+	if m.hookIds == nil {
+		return
+	}
+	hook.GlobalHookManager.OnPostUpdateEntry().Unbind(m.hookIds[0])
+	hook.GlobalHookManager.OnPostUpdateEntryProgress().Unbind(m.hookIds[1])
+	m.hookIds = nil
+}
+
+func (m *Manager) onEntryUpdated(event *anilist_platform.PostUpdateEntryEvent) {
+	changes := &Changes{
+		Progress:    event.Progress,
+		Score:       event.ScoreRaw,
+		StartedAt:   fromFuzzyDateInput(event.StartedAt),
+		CompletedAt: fromFuzzyDateInput(event.CompletedAt),
+	}
+	if event.Status != nil {
+		changes.Status = fromMediaListStatus(*event.Status)
+	}
+	m.Enqueue(*event.MediaID, changes)
+}
+
+func (m *Manager) onEntryProgressUpdated(event *anilist_platform.PostUpdateEntryProgressEvent) {
+	changes := &Changes{
+		Progress: event.Progress,
+	}
+	if event.Status != nil {
+		changes.Status = fromMediaListStatus(*event.Status)
+	}
+	m.Enqueue(*event.MediaID, changes)
+}
+
+func fromMediaListStatus(status anilist.MediaListStatus) *hibiketracker.Status {
+	var ret hibiketracker.Status
+	switch status {
+	case anilist.MediaListStatusCurrent:
+		ret = hibiketracker.StatusCurrent
+	case anilist.MediaListStatusPlanning:
+		ret = hibiketracker.StatusPlanning
+	case anilist.MediaListStatusCompleted:
+		ret = hibiketracker.StatusCompleted
+	case anilist.MediaListStatusDropped:
+		ret = hibiketracker.StatusDropped
+	case anilist.MediaListStatusPaused:
+		ret = hibiketracker.StatusPaused
+	case anilist.MediaListStatusRepeating:
+		ret = hibiketracker.StatusRepeating
+	default:
+		return nil
+	}
+	return &ret
+}
+
+func fromFuzzyDateInput(date *anilist.FuzzyDateInput) *hibiketracker.FuzzyDate {
+	if date == nil {
+		return nil
+	}
+	return &hibiketracker.FuzzyDate{
+		Year:  date.Year,
+		Month: date.Month,
+		Day:   date.Day,
+	}
+}
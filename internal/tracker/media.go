@@ -0,0 +1,91 @@
+package tracker
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/anizip"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"time"
+)
+
+const mediaCacheTTL = 6 * time.Hour
+
+var anizipCache = anizip.NewCache()
+
+// getMedia returns the media of a list entry, with the IDs the trackers use to find it.
+// AniList list entries don't tell whether the media is an anime or a manga, the collections are checked first.
+func (m *Manager) getMedia(mediaId int) (*hibiketracker.Media, error) {
+	if media, ok := m.mediaCache.Get(mediaId); ok {
+		return media, nil
+	}
+
+	var ret *hibiketracker.Media
+	if collection, err := m.platform.GetRawAnimeCollection(false); err == nil {
+		if entry, ok := collection.GetListEntryFromAnimeId(mediaId); ok {
+			ret = m.fromAnime(entry.GetMedia())
+		}
+	}
+	if ret == nil {
+		if collection, err := m.platform.GetRawMangaCollection(false); err == nil {
+			if entry, ok := collection.GetListEntryFromMangaId(mediaId); ok {
+				ret = fromManga(entry.GetMedia())
+			}
+		}
+	}
+	if ret == nil {
+		if anime, err := m.platform.GetAnime(mediaId); err == nil {
+			ret = m.fromAnime(anime)
+		} else {
+			manga, err := m.platform.GetManga(mediaId)
+			if err != nil {
+				return nil, err
+			}
+			ret = fromManga(manga)
+		}
+	}
+
+	m.mediaCache.SetT(mediaId, ret, mediaCacheTTL)
+	return ret, nil
+}
+
+func (m *Manager) fromAnime(anime *anilist.BaseAnime) *hibiketracker.Media {
+	ret := &hibiketracker.Media{
+		Type:         hibiketracker.MediaTypeAnime,
+		ID:           anime.GetID(),
+		IDMal:        anime.GetIDMal(),
+		IDKitsu:      m.getKitsuID(anime.GetID()),
+		EnglishTitle: anime.GetTitle().GetEnglish(),
+		RomajiTitle:  anime.GetRomajiTitleSafe(),
+		Total:        max(anime.GetTotalEpisodeCount(), 0),
+	}
+	if anime.GetFormat() != nil {
+		ret.Format = string(*anime.GetFormat())
+	}
+	return ret
+}
+
+func fromManga(manga *anilist.BaseManga) *hibiketracker.Media {
+	ret := &hibiketracker.Media{
+		Type:         hibiketracker.MediaTypeManga,
+		ID:           manga.GetID(),
+		IDMal:        manga.GetIDMal(),
+		EnglishTitle: manga.GetTitle().GetEnglish(),
+		RomajiTitle:  manga.GetRomajiTitleSafe(),
+	}
+	if manga.GetChapters() != nil {
+		ret.Total = *manga.GetChapters()
+	}
+	if manga.GetFormat() != nil {
+		ret.Format = string(*manga.GetFormat())
+	}
+	return ret
+}
+
+// fetchKitsuID returns the Kitsu ID of the anime from the AniZip mappings, or nil if it is unknown.
+func (m *Manager) fetchKitsuID(mediaId int) *int {
+	media, err := anizip.FetchAniZipMediaC("anilist", mediaId, anizipCache)
+	if err != nil || media.GetMappings() == nil || media.GetMappings().KitsuID == 0 {
+		return nil
+	}
+	id := media.GetMappings().KitsuID
+	return &id
+}
@@ -0,0 +1,214 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"seanime/internal/database/models"
+	"seanime/internal/extension"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusConflict = "conflict"
+	StatusSkipped  = "skipped"
+
+	// MaxAttempts is the number of attempts after which a change is marked as failed
+	MaxAttempts = 6
+	// pollInterval is the interval at which due changes are checked
+	pollInterval = 10 * time.Second
+)
+
+// defaultBackoff returns the delay before the next attempt: 1m, 2m, 4m... up to 1h.
+func defaultBackoff(attempts int) time.Duration {
+	d := time.Minute << min(max(attempts-1, 0), 6)
+	return min(d, time.Hour)
+}
+
+func (m *Manager) wake() {
+	select {
+	case m.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.sendDue()
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-m.wakeCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue sends the pending changes that are due, one at a time.
+func (m *Manager) sendDue() {
+	for {
+		items, err := m.database.GetDueTrackerSyncItems(m.now(), 20)
+		if err != nil {
+			m.logger.Error().Err(err).Msg("tracker: Failed to get pending changes")
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+
+		for _, item := range items {
+			select {
+			case <-m.stopCh:
+				return
+			default:
+			}
+
+			m.send(item)
+			if err := m.database.UpdateTrackerSyncItem(item); err != nil {
+				m.logger.Error().Err(err).Msg("tracker: Failed to update change")
+				return
+			}
+		}
+	}
+}
+
+// send sends the change to the tracker and updates its status.
+func (m *Manager) send(item *models.TrackerSyncItem) {
+	item.Attempts++
+
+	ext, ok := extension.GetExtension[extension.TrackerExtension](m.extensionBank, item.TrackerID)
+	if !ok {
+		item.Status = StatusFailed
+		item.NextAttemptAt = nil
+		item.Error = ErrTrackerNotFound.Error()
+		return
+	}
+	if !m.isEnabled(item.TrackerID) {
+		item.Status = StatusSkipped
+		item.NextAttemptAt = nil
+		item.Error = "tracker disabled"
+		return
+	}
+
+	var changes Changes
+	if err := json.Unmarshal(item.Changes, &changes); err != nil {
+		item.Status = StatusFailed
+		item.NextAttemptAt = nil
+		item.Error = "invalid changes"
+		return
+	}
+
+	err := m.sendChanges(ext, item, &changes)
+	if err == nil {
+		if item.Status == StatusPending {
+			item.Status = StatusSuccess
+			item.Error = ""
+		}
+		item.NextAttemptAt = nil
+		return
+	}
+
+	var unsupportedErr *unsupportedMediaError
+	if errors.As(err, &unsupportedErr) {
+		item.Status = StatusSkipped
+		item.NextAttemptAt = nil
+		item.Error = err.Error()
+		return
+	}
+
+	item.Error = err.Error()
+	if item.Attempts >= MaxAttempts {
+		item.Status = StatusFailed
+		item.NextAttemptAt = nil
+		m.logger.Warn().Err(err).Str("tracker", item.TrackerID).Int("mediaId", item.MediaID).Msg("tracker: Failed to mirror changes")
+		return
+	}
+
+	next := m.now().Add(m.backoff(item.Attempts))
+	item.NextAttemptAt = &next
+	m.logger.Debug().Err(err).Str("tracker", item.TrackerID).Int("mediaId", item.MediaID).Msgf("tracker: Failed to mirror changes, retrying in %s", next.Sub(m.now()))
+}
+
+type unsupportedMediaError struct {
+	mediaType hibiketracker.MediaType
+}
+
+func (e *unsupportedMediaError) Error() string {
+	return fmt.Sprintf("the tracker does not support %s", e.mediaType)
+}
+
+// sendChanges checks the entry on the tracker and updates it.
+// The status of the item is set to StatusConflict if the changes conflict with the entry.
+func (m *Manager) sendChanges(ext extension.TrackerExtension, item *models.TrackerSyncItem, changes *Changes) error {
+	media, err := m.getMedia(item.MediaID)
+	if err != nil {
+		return fmt.Errorf("failed to get media: %w", err)
+	}
+
+	provider := ext.GetProvider()
+	settings := provider.GetSettings()
+	if (media.Type == hibiketracker.MediaTypeAnime && !settings.SupportsAnime) ||
+		(media.Type == hibiketracker.MediaTypeManga && !settings.SupportsManga) {
+		return &unsupportedMediaError{mediaType: media.Type}
+	}
+
+	if !item.Force {
+		remote, err := provider.GetEntry(*media)
+		if err != nil {
+			return fmt.Errorf("failed to get entry: %w", err)
+		}
+		if reason, ok := findConflict(remote, changes); ok {
+			item.Status = StatusConflict
+			item.Remote, _ = json.Marshal(remote)
+			item.Error = reason
+			m.logger.Info().Str("tracker", item.TrackerID).Int("mediaId", item.MediaID).Msgf("tracker: Conflict, %s", reason)
+			return nil
+		}
+	}
+
+	err = provider.UpdateEntry(hibiketracker.UpdateEntryOptions{
+		Media:       *media,
+		Status:      changes.Status,
+		Progress:    changes.Progress,
+		Score:       changes.Score,
+		StartedAt:   changes.StartedAt,
+		CompletedAt: changes.CompletedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update entry: %w", err)
+	}
+
+	m.logger.Debug().Str("tracker", item.TrackerID).Int("mediaId", item.MediaID).Msg("tracker: Mirrored changes")
+	return nil
+}
+
+// findConflict returns the reason why the changes should not overwrite the entry on the tracker.
+// The changes conflict if the tracker is ahead of AniList, e.g. progress was made on the tracker directly.
+func findConflict(remote *hibiketracker.Entry, changes *Changes) (string, bool) {
+	if remote == nil {
+		return "", false
+	}
+
+	// Progress goes back when rewatching
+	isRepeating := (changes.Status != nil && *changes.Status == hibiketracker.StatusRepeating) || remote.Status == hibiketracker.StatusRepeating
+
+	if changes.Progress != nil && remote.Progress > *changes.Progress && !isRepeating {
+		return fmt.Sprintf("progress on the tracker (%d) is ahead of AniList (%d)", remote.Progress, *changes.Progress), true
+	}
+
+	if changes.Status != nil && remote.Status == hibiketracker.StatusCompleted &&
+		(*changes.Status == hibiketracker.StatusCurrent || *changes.Status == hibiketracker.StatusPlanning) {
+		return fmt.Sprintf("the entry is completed on the tracker but %s on AniList", *changes.Status), true
+	}
+
+	return "", false
+}
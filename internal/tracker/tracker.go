@@ -0,0 +1,345 @@
+package tracker
+
+import (
+	"errors"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/extension"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util/result"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrTrackerNotFound  = errors.New("tracker not found")
+	ErrSyncItemNotFound = errors.New("sync item not found")
+)
+
+type (
+	// Manager mirrors the list entry changes made on AniList to the enabled tracker extensions.
+	// Changes are persisted in a queue per tracker and sent in the background, failed changes are retried with backoff.
+	// Changes that would overwrite newer progress on a tracker are held back and reported as conflicts.
+	Manager struct {
+		logger        *zerolog.Logger
+		database      *db.Database
+		platform      platform.Platform
+		extensionBank *extension.UnifiedBank
+
+		mu sync.RWMutex
+		// enabled holds the IDs of the enabled trackers
+		enabled map[string]bool
+
+		mediaCache *result.Cache[int, *hibiketracker.Media]
+
+		wakeCh  chan struct{}
+		stopCh  chan struct{}
+		hookIds []string
+		// now, backoff and getKitsuID are replaced in tests
+		now        func() time.Time
+		backoff    func(attempts int) time.Duration
+		getKitsuID func(mediaId int) *int
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+		// Platform is used to get the media of the changes
+		Platform platform.Platform
+	}
+
+	// TrackerItem is an installed tracker extension.
+	TrackerItem struct {
+		ID       string                 `json:"id"`
+		Name     string                 `json:"name"`
+		Icon     string                 `json:"icon"`
+		Enabled  bool                   `json:"enabled"`
+		Settings hibiketracker.Settings `json:"settings"`
+	}
+
+	// Changes are the list entry fields changed on AniList, unchanged fields are nil.
+	Changes struct {
+		Status      *hibiketracker.Status    `json:"status,omitempty"`
+		Progress    *int                     `json:"progress,omitempty"`
+		Score       *int                     `json:"score,omitempty"` // 0 to 100
+		StartedAt   *hibiketracker.FuzzyDate `json:"startedAt,omitempty"`
+		CompletedAt *hibiketracker.FuzzyDate `json:"completedAt,omitempty"`
+	}
+
+	// SyncItem is a queued or sent change, as shown in the sync log and the conflict report.
+	SyncItem struct {
+		ID            uint                 `json:"id"`
+		TrackerID     string               `json:"trackerId"`
+		MediaID       int                  `json:"mediaId"`
+		Status        string               `json:"status"`
+		Attempts      int                  `json:"attempts"`
+		NextAttemptAt *time.Time           `json:"nextAttemptAt,omitempty"`
+		Error         string               `json:"error,omitempty"`
+		UpdatedAt     time.Time            `json:"updatedAt"`
+		Changes       *Changes             `json:"changes"`
+		Remote        *hibiketracker.Entry `json:"remote,omitempty"` // Entry on the tracker, set for conflicts
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ret := &Manager{
+		logger:        opts.Logger,
+		database:      opts.Database,
+		platform:      opts.Platform,
+		extensionBank: extension.NewUnifiedBank(),
+		enabled:       make(map[string]bool),
+		mediaCache:    result.NewCache[int, *hibiketracker.Media](),
+		wakeCh:        make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		now:           time.Now,
+		backoff:       defaultBackoff,
+	}
+	ret.getKitsuID = ret.fetchKitsuID
+	return ret
+}
+
+func (m *Manager) InitExtensionBank(bank *extension.UnifiedBank) {
+	m.extensionBank = bank
+
+	m.logger.Debug().Msg("tracker: Initialized tracker extension bank")
+}
+
+// Start loads the tracker settings and starts sending the pending changes.
+func (m *Manager) Start() {
+	settings, err := m.database.GetTrackerSettings()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("tracker: Failed to load tracker settings")
+	}
+
+	m.mu.Lock()
+	for _, s := range settings {
+		m.enabled[s.TrackerID] = s.Enabled
+	}
+	m.mu.Unlock()
+
+	m.bindHooks()
+
+	go m.run()
+}
+
+// Stop stops sending changes. Pending changes are sent after the next start.
+func (m *Manager) Stop() {
+	select {
+	case <-m.stopCh:
+	default:
+		m.unbindHooks()
+		close(m.stopCh)
+	}
+}
+
+// ListTrackers returns the installed tracker extensions.
+func (m *Manager) ListTrackers() []*TrackerItem {
+	ret := make([]*TrackerItem, 0)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	extension.RangeExtensions(m.extensionBank, func(id string, ext extension.TrackerExtension) bool {
+		ret = append(ret, &TrackerItem{
+			ID:       id,
+			Name:     ext.GetName(),
+			Icon:     ext.GetIcon(),
+			Enabled:  m.enabled[id],
+			Settings: ext.GetProvider().GetSettings(),
+		})
+		return true
+	})
+
+	return ret
+}
+
+// SetEnabled enables or disables mirroring to a tracker.
+// Trackers are disabled until they are enabled by the user.
+func (m *Manager) SetEnabled(trackerId string, enabled bool) error {
+	if _, ok := extension.GetExtension[extension.TrackerExtension](m.extensionBank, trackerId); !ok {
+		return ErrTrackerNotFound
+	}
+
+	err := m.database.UpsertTrackerSettings(&models.TrackerSettings{
+		TrackerID: trackerId,
+		Enabled:   enabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.enabled[trackerId] = enabled
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Manager) isEnabled(trackerId string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled[trackerId]
+}
+
+// Enqueue queues the changes for every enabled tracker.
+// If changes to the same media are still waiting to be sent, they are merged.
+func (m *Manager) Enqueue(mediaId int, changes *Changes) {
+	if changes == nil || changes.isEmpty() {
+		return
+	}
+
+	queued := false
+	extension.RangeExtensions(m.extensionBank, func(id string, ext extension.TrackerExtension) bool {
+		if !m.isEnabled(id) {
+			return true
+		}
+		if err := m.enqueue(id, mediaId, changes); err != nil {
+			m.logger.Error().Err(err).Str("tracker", id).Int("mediaId", mediaId).Msg("tracker: Failed to queue changes")
+			return true
+		}
+		queued = true
+		return true
+	})
+
+	if queued {
+		m.database.TrimTrackerSyncItems()
+		m.wake()
+	}
+}
+
+func (m *Manager) enqueue(trackerId string, mediaId int, changes *Changes) error {
+	// Merge with the changes that were not sent yet
+	item, err := m.database.GetUnsentTrackerSyncItem(trackerId, mediaId)
+	if err != nil {
+		return err
+	}
+	if item != nil {
+		var previous Changes
+		_ = json.Unmarshal(item.Changes, &previous)
+		previous.merge(changes)
+		item.Changes, _ = json.Marshal(previous)
+		// Check the merged changes again
+		item.Status = StatusPending
+		item.Force = false
+		item.Remote = nil
+		item.Error = ""
+		item.NextAttemptAt = nil
+		return m.database.UpdateTrackerSyncItem(item)
+	}
+
+	data, _ := json.Marshal(changes)
+	return m.database.InsertTrackerSyncItem(&models.TrackerSyncItem{
+		TrackerID: trackerId,
+		MediaID:   mediaId,
+		Changes:   data,
+		Status:    StatusPending,
+	})
+}
+
+// GetSyncItems returns the latest changes sent or queued, newest first.
+// If status is not empty, only the changes with that status are returned, e.g. StatusConflict for the conflict report.
+func (m *Manager) GetSyncItems(status string, limit int) ([]*SyncItem, error) {
+	items, err := m.database.GetTrackerSyncItems(status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*SyncItem, 0, len(items))
+	for _, item := range items {
+		ret = append(ret, toSyncItem(item))
+	}
+	return ret, nil
+}
+
+// ResolveConflict sends the conflicting change anyway if overwrite is true, or skips it.
+func (m *Manager) ResolveConflict(id uint, overwrite bool) error {
+	item, err := m.database.GetTrackerSyncItem(id)
+	if err != nil {
+		return ErrSyncItemNotFound
+	}
+	if item.Status != StatusConflict {
+		return errors.New("tracker: the change is not conflicting")
+	}
+
+	if !overwrite {
+		item.Status = StatusSkipped
+		return m.database.UpdateTrackerSyncItem(item)
+	}
+
+	item.Force = true
+	return m.requeue(item)
+}
+
+// Retry queues a failed change again.
+func (m *Manager) Retry(id uint) error {
+	item, err := m.database.GetTrackerSyncItem(id)
+	if err != nil {
+		return ErrSyncItemNotFound
+	}
+	if item.Status != StatusFailed {
+		return errors.New("tracker: only failed changes can be retried")
+	}
+
+	return m.requeue(item)
+}
+
+func (m *Manager) requeue(item *models.TrackerSyncItem) error {
+	item.Status = StatusPending
+	item.Attempts = 0
+	item.NextAttemptAt = nil
+	item.Error = ""
+	if err := m.database.UpdateTrackerSyncItem(item); err != nil {
+		return err
+	}
+
+	m.wake()
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (c *Changes) isEmpty() bool {
+	return c.Status == nil && c.Progress == nil && c.Score == nil && c.StartedAt == nil && c.CompletedAt == nil
+}
+
+// merge overrides the fields with the fields set in other.
+func (c *Changes) merge(other *Changes) {
+	if other.Status != nil {
+		c.Status = other.Status
+	}
+	if other.Progress != nil {
+		c.Progress = other.Progress
+	}
+	if other.Score != nil {
+		c.Score = other.Score
+	}
+	if other.StartedAt != nil {
+		c.StartedAt = other.StartedAt
+	}
+	if other.CompletedAt != nil {
+		c.CompletedAt = other.CompletedAt
+	}
+}
+
+func toSyncItem(item *models.TrackerSyncItem) *SyncItem {
+	ret := &SyncItem{
+		ID:            item.ID,
+		TrackerID:     item.TrackerID,
+		MediaID:       item.MediaID,
+		Status:        item.Status,
+		Attempts:      item.Attempts,
+		NextAttemptAt: item.NextAttemptAt,
+		Error:         item.Error,
+		UpdatedAt:     item.UpdatedAt,
+		Changes:       &Changes{},
+	}
+	_ = json.Unmarshal(item.Changes, ret.Changes)
+	if len(item.Remote) > 0 {
+		_ = json.Unmarshal(item.Remote, &ret.Remote)
+	}
+	return ret
+}
@@ -0,0 +1,431 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"seanime/internal/extension"
+	hibiketracker "seanime/internal/extension/hibike/tracker"
+	"seanime/internal/hook"
+	"seanime/internal/hook_resolver"
+	"seanime/internal/platforms/anilist_platform"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlatform is an AniList platform with a repeating anime (1) and a repeating manga (101) in the collections.
+// Other IDs below 100 are anime, the others are manga.
+type testPlatform struct {
+	platform.Platform
+}
+
+func (p *testPlatform) GetRawAnimeCollection(bool) (*anilist.AnimeCollection, error) {
+	return &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: []*anilist.AnimeCollection_MediaListCollection_Lists{{
+				Status: lo.ToPtr(anilist.MediaListStatusRepeating),
+				Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{{
+					Status: lo.ToPtr(anilist.MediaListStatusRepeating),
+					Media:  &anilist.BaseAnime{ID: 1, IDMal: lo.ToPtr(11), Episodes: lo.ToPtr(12)},
+				}},
+			}},
+		},
+	}, nil
+}
+
+func (p *testPlatform) GetRawMangaCollection(bool) (*anilist.MangaCollection, error) {
+	return &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: []*anilist.MangaCollection_MediaListCollection_Lists{{
+				Status: lo.ToPtr(anilist.MediaListStatusRepeating),
+				Entries: []*anilist.MangaCollection_MediaListCollection_Lists_Entries{{
+					Status: lo.ToPtr(anilist.MediaListStatusRepeating),
+					Media:  &anilist.BaseManga{ID: 101},
+				}},
+			}},
+		},
+	}, nil
+}
+
+func (p *testPlatform) GetAnime(id int) (*anilist.BaseAnime, error) {
+	if id >= 100 {
+		return nil, errors.New("not found")
+	}
+	return &anilist.BaseAnime{ID: id, IDMal: lo.ToPtr(id + 10)}, nil
+}
+
+func (p *testPlatform) GetManga(id int) (*anilist.BaseManga, error) {
+	return &anilist.BaseManga{ID: id}, nil
+}
+
+// testAnilistClient returns the collections of testPlatform and records the progress updates.
+type testAnilistClient struct {
+	anilist.AnilistClient
+	mu               sync.Mutex
+	progressStatuses []anilist.MediaListStatus
+}
+
+func (c *testAnilistClient) AnimeCollection(context.Context, *string, ...clientv2.RequestInterceptor) (*anilist.AnimeCollection, error) {
+	return (&testPlatform{}).GetRawAnimeCollection(false)
+}
+
+func (c *testAnilistClient) MangaCollection(context.Context, *string, ...clientv2.RequestInterceptor) (*anilist.MangaCollection, error) {
+	return (&testPlatform{}).GetRawMangaCollection(false)
+}
+
+func (c *testAnilistClient) UpdateMediaListEntry(context.Context, *int, *anilist.MediaListStatus, *int, *int, *anilist.FuzzyDateInput, *anilist.FuzzyDateInput, ...clientv2.RequestInterceptor) (*anilist.UpdateMediaListEntry, error) {
+	return &anilist.UpdateMediaListEntry{}, nil
+}
+
+func (c *testAnilistClient) UpdateMediaListEntryProgress(_ context.Context, _ *int, _ *int, status *anilist.MediaListStatus, _ ...clientv2.RequestInterceptor) (*anilist.UpdateMediaListEntryProgress, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.progressStatuses = append(c.progressStatuses, *status)
+	return &anilist.UpdateMediaListEntryProgress{}, nil
+}
+
+func (c *testAnilistClient) getProgressStatuses() []anilist.MediaListStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]anilist.MediaListStatus{}, c.progressStatuses...)
+}
+
+type testTracker struct {
+	mu       sync.Mutex
+	entries  map[int]*hibiketracker.Entry
+	updates  []hibiketracker.UpdateEntryOptions
+	failures int // Number of updates that fail
+}
+
+func (t *testTracker) GetEntry(media hibiketracker.Media) (*hibiketracker.Entry, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.entries[media.ID], nil
+}
+
+func (t *testTracker) UpdateEntry(opts hibiketracker.UpdateEntryOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.failures > 0 {
+		t.failures--
+		return errors.New("service unavailable")
+	}
+	t.updates = append(t.updates, opts)
+	return nil
+}
+
+func (t *testTracker) GetSettings() hibiketracker.Settings {
+	return hibiketracker.Settings{SupportsAnime: true}
+}
+
+func (t *testTracker) getUpdates() []hibiketracker.UpdateEntryOptions {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]hibiketracker.UpdateEntryOptions{}, t.updates...)
+}
+
+// newTestManager returns a manager and the AniList platform whose updates are mirrored once the manager is started.
+func newTestManager(t *testing.T, trackers map[string]*testTracker) (*Manager, platform.Platform) {
+	logger := util.NewLogger()
+	database, err := db.NewDatabase(t.TempDir(), "seanime-test", logger)
+	require.NoError(t, err)
+
+	m := NewManager(&NewManagerOptions{
+		Logger:   logger,
+		Database: database,
+		Platform: &testPlatform{},
+	})
+	m.backoff = func(int) time.Duration { return 0 }
+	m.getKitsuID = func(mediaId int) *int { return lo.ToPtr(mediaId + 1000) }
+
+	bank := extension.NewUnifiedBank()
+	for id, tracker := range trackers {
+		bank.Set(id, extension.NewTrackerExtension(&extension.Extension{ID: id, Name: id, Type: extension.TypeTracker}, tracker))
+	}
+	m.InitExtensionBank(bank)
+
+	anilistPlatform := anilist_platform.NewAnilistPlatform(&testAnilistClient{}, logger)
+	anilistPlatform.SetUsername("test")
+	_, err = anilistPlatform.GetRawAnimeCollection(true)
+	require.NoError(t, err)
+	_, err = anilistPlatform.GetRawMangaCollection(true)
+	require.NoError(t, err)
+
+	return m, anilistPlatform
+}
+
+func waitForSyncItem(t *testing.T, m *Manager, status string) *SyncItem {
+	var ret *SyncItem
+	require.Eventually(t, func() bool {
+		items, err := m.GetSyncItems("", 1)
+		if err != nil || len(items) == 0 || items[0].Status != status {
+			return false
+		}
+		ret = items[0]
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+	return ret
+}
+
+func TestManager_Mirror(t *testing.T) {
+	kitsu := &testTracker{}
+	shikimori := &testTracker{}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu, "shikimori": shikimori})
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	require.NoError(t, m.SetEnabled("kitsu", true))
+	assert.ErrorIs(t, m.SetEnabled("simkl", true), ErrTrackerNotFound)
+
+	trackers := m.ListTrackers()
+	require.Len(t, trackers, 2)
+	for _, tracker := range trackers {
+		assert.Equal(t, tracker.ID == "kitsu", tracker.Enabled)
+	}
+
+	// Repeating entry in the collection
+	require.NoError(t, p.UpdateEntryProgress(1, 5, lo.ToPtr(12)))
+
+	item := waitForSyncItem(t, m, StatusSuccess)
+	assert.Equal(t, "kitsu", item.TrackerID)
+	assert.Equal(t, 1, item.Attempts)
+
+	updates := kitsu.getUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, hibiketracker.MediaTypeAnime, updates[0].Media.Type)
+	assert.Equal(t, 11, *updates[0].Media.IDMal)
+	assert.Equal(t, 1001, *updates[0].Media.IDKitsu)
+	assert.Equal(t, 12, updates[0].Media.Total)
+	assert.Equal(t, 5, *updates[0].Progress)
+	assert.Equal(t, hibiketracker.StatusRepeating, *updates[0].Status)
+	assert.Nil(t, updates[0].Score)
+
+	// Disabled trackers are not updated
+	assert.Empty(t, shikimori.getUpdates())
+
+	// Status, score and dates
+	require.NoError(t, p.UpdateEntry(2, lo.ToPtr(anilist.MediaListStatusCompleted), lo.ToPtr(85), lo.ToPtr(24), nil, &anilist.FuzzyDateInput{Year: lo.ToPtr(2024)}))
+
+	require.Eventually(t, func() bool { return len(kitsu.getUpdates()) == 2 }, 5*time.Second, 20*time.Millisecond)
+	update := kitsu.getUpdates()[1]
+	assert.Equal(t, 2, update.Media.ID)
+	assert.Equal(t, hibiketracker.StatusCompleted, *update.Status)
+	assert.Equal(t, 85, *update.Score)
+	assert.Equal(t, 24, *update.Progress)
+	assert.Nil(t, update.StartedAt)
+	assert.Equal(t, 2024, *update.CompletedAt.Year)
+
+	// Manga are skipped if the tracker only supports anime
+	require.NoError(t, p.UpdateEntryProgress(100, 3, nil))
+	item = waitForSyncItem(t, m, StatusSkipped)
+	assert.Equal(t, 100, item.MediaID)
+	assert.Len(t, kitsu.getUpdates(), 2)
+}
+
+func TestManager_RepeatingManga(t *testing.T) {
+	kitsu := &testTracker{}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu})
+	m.Start()
+	t.Cleanup(m.Stop)
+	require.NoError(t, m.SetEnabled("kitsu", true))
+
+	// The trackers get the status set on AniList
+	require.NoError(t, p.UpdateEntryProgress(101, 3, nil))
+	item := waitForSyncItem(t, m, StatusSkipped)
+	assert.Equal(t, 101, item.MediaID)
+	assert.Equal(t, hibiketracker.StatusRepeating, *item.Changes.Status)
+
+	client := p.GetAnilistClient().(*testAnilistClient)
+	assert.Equal(t, []anilist.MediaListStatus{anilist.MediaListStatusRepeating}, client.getProgressStatuses())
+}
+
+func TestManager_PreUpdateHooks(t *testing.T) {
+	kitsu := &testTracker{}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu})
+	require.NoError(t, m.SetEnabled("kitsu", true))
+	m.bindHooks()
+	t.Cleanup(m.unbindHooks)
+
+	// Prevented updates are not mirrored since AniList is not updated
+	preventId := hook.GlobalHookManager.OnPreUpdateEntryProgress().BindFunc(func(e hook_resolver.Resolver) error {
+		e.PreventDefault()
+		return e.Next()
+	})
+	require.NoError(t, p.UpdateEntryProgress(2, 5, nil))
+	hook.GlobalHookManager.OnPreUpdateEntryProgress().Unbind(preventId)
+
+	preventId = hook.GlobalHookManager.OnPreUpdateEntry().BindFunc(func(e hook_resolver.Resolver) error {
+		e.PreventDefault()
+		return e.Next()
+	})
+	require.NoError(t, p.UpdateEntry(2, nil, lo.ToPtr(70), nil, nil, nil))
+	hook.GlobalHookManager.OnPreUpdateEntry().Unbind(preventId)
+
+	items, err := m.GetSyncItems("", 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// The values changed by the hooks are mirrored
+	rewriteId := hook.GlobalHookManager.OnPreUpdateEntryProgress().BindFunc(func(e hook_resolver.Resolver) error {
+		e.(*anilist_platform.PreUpdateEntryProgressEvent).Progress = lo.ToPtr(7)
+		return e.Next()
+	})
+	require.NoError(t, p.UpdateEntryProgress(2, 5, nil))
+	hook.GlobalHookManager.OnPreUpdateEntryProgress().Unbind(rewriteId)
+
+	items, err = m.GetSyncItems("", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 7, *items[0].Changes.Progress)
+}
+
+func TestManager_Conflict(t *testing.T) {
+	kitsu := &testTracker{entries: map[int]*hibiketracker.Entry{
+		2: {Status: hibiketracker.StatusCurrent, Progress: 8},
+	}}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu})
+	m.Start()
+	t.Cleanup(m.Stop)
+	require.NoError(t, m.SetEnabled("kitsu", true))
+
+	require.NoError(t, p.UpdateEntryProgress(2, 3, nil))
+
+	item := waitForSyncItem(t, m, StatusConflict)
+	assert.Contains(t, item.Error, "ahead of AniList")
+	require.NotNil(t, item.Remote)
+	assert.Equal(t, 8, item.Remote.Progress)
+	assert.Equal(t, 3, *item.Changes.Progress)
+	assert.Empty(t, kitsu.getUpdates())
+
+	conflicts, err := m.GetSyncItems(StatusConflict, 10)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+
+	// Overwrite the entry on the tracker
+	require.NoError(t, m.ResolveConflict(item.ID, true))
+	waitForSyncItem(t, m, StatusSuccess)
+	require.Len(t, kitsu.getUpdates(), 1)
+	assert.Equal(t, 3, *kitsu.getUpdates()[0].Progress)
+
+	assert.Error(t, m.ResolveConflict(item.ID, true))
+
+	// Skip
+	require.NoError(t, p.UpdateEntryProgress(2, 4, nil))
+	item = waitForSyncItem(t, m, StatusConflict)
+	require.NoError(t, m.ResolveConflict(item.ID, false))
+
+	conflicts, err = m.GetSyncItems(StatusConflict, 10)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Len(t, kitsu.getUpdates(), 1)
+}
+
+func TestManager_Retries(t *testing.T) {
+	kitsu := &testTracker{failures: 2}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu})
+	m.Start()
+	t.Cleanup(m.Stop)
+	require.NoError(t, m.SetEnabled("kitsu", true))
+
+	require.NoError(t, p.UpdateEntryProgress(2, 1, nil))
+	item := waitForSyncItem(t, m, StatusSuccess)
+	assert.Equal(t, 3, item.Attempts)
+	assert.Empty(t, item.Error)
+
+	// Always failing
+	kitsu.mu.Lock()
+	kitsu.failures = MaxAttempts
+	kitsu.mu.Unlock()
+
+	require.NoError(t, p.UpdateEntryProgress(3, 1, nil))
+	item = waitForSyncItem(t, m, StatusFailed)
+	assert.Equal(t, MaxAttempts, item.Attempts)
+	assert.Contains(t, item.Error, "service unavailable")
+
+	require.NoError(t, m.Retry(item.ID))
+	item = waitForSyncItem(t, m, StatusSuccess)
+	assert.Equal(t, 1, item.Attempts)
+}
+
+func TestManager_MergeChanges(t *testing.T) {
+	kitsu := &testTracker{}
+	m, p := newTestManager(t, map[string]*testTracker{"kitsu": kitsu})
+	require.NoError(t, m.SetEnabled("kitsu", true))
+
+	// Queued while the changes are not sent
+	m.bindHooks()
+	require.NoError(t, p.UpdateEntryProgress(2, 1, nil))
+	require.NoError(t, p.UpdateEntry(2, nil, lo.ToPtr(70), nil, nil, nil))
+	require.NoError(t, p.UpdateEntryProgress(2, 2, nil))
+
+	items, err := m.GetSyncItems("", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, StatusPending, items[0].Status)
+	assert.Equal(t, 2, *items[0].Changes.Progress)
+	assert.Equal(t, 70, *items[0].Changes.Score)
+	assert.Equal(t, hibiketracker.StatusCurrent, *items[0].Changes.Status)
+
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	waitForSyncItem(t, m, StatusSuccess)
+	assert.Len(t, kitsu.getUpdates(), 1)
+}
+
+func TestFindConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   *hibiketracker.Entry
+		changes  *Changes
+		conflict bool
+	}{
+		{
+			name:    "not in the list",
+			changes: &Changes{Progress: lo.ToPtr(1)},
+		},
+		{
+			name:     "progress behind",
+			remote:   &hibiketracker.Entry{Status: hibiketracker.StatusCurrent, Progress: 5},
+			changes:  &Changes{Progress: lo.ToPtr(4)},
+			conflict: true,
+		},
+		{
+			name:    "progress ahead",
+			remote:  &hibiketracker.Entry{Status: hibiketracker.StatusCurrent, Progress: 5},
+			changes: &Changes{Progress: lo.ToPtr(6)},
+		},
+		{
+			name:    "rewatching",
+			remote:  &hibiketracker.Entry{Status: hibiketracker.StatusCompleted, Progress: 12},
+			changes: &Changes{Status: lo.ToPtr(hibiketracker.StatusRepeating), Progress: lo.ToPtr(1)},
+		},
+		{
+			name:     "completed on the tracker",
+			remote:   &hibiketracker.Entry{Status: hibiketracker.StatusCompleted, Progress: 0},
+			changes:  &Changes{Status: lo.ToPtr(hibiketracker.StatusPlanning)},
+			conflict: true,
+		},
+		{
+			name:    "score only",
+			remote:  &hibiketracker.Entry{Status: hibiketracker.StatusCompleted, Progress: 12, Score: 60},
+			changes: &Changes{Score: lo.ToPtr(80)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := findConflict(tt.remote, tt.changes)
+			assert.Equal(t, tt.conflict, ok)
+		})
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dop251/goja"
 	"github.com/samber/mo"
 )
 
 // Job represents a task to be executed in the VM
 type Job struct {
-	fn       func() error
-	resultCh chan error
-	async    bool // Flag to indicate if the job is async (doesn't need to wait for result)
+	fn        func() error
+	resultCh  chan error
+	async     bool      // Flag to indicate if the job is async (doesn't need to wait for result)
+	startedAt time.Time // Set when the job starts executing
+	onDone    func()    // Called after the job returns, e.g. to clear an interrupt
 }
 
 // Scheduler handles all VM operations added concurrently in a single goroutine
@@ -59,6 +62,7 @@ func (s *Scheduler) start() {
 			case job := <-s.jobQueue:
 				// Set the current job before execution
 				s.currentJobLock.Lock()
+				job.startedAt = time.Now()
 				s.currentJob = job
 				s.currentJobLock.Unlock()
 
@@ -66,6 +70,9 @@ func (s *Scheduler) start() {
 
 				// Clear the current job after execution
 				s.currentJobLock.Lock()
+				if job.onDone != nil {
+					job.onDone()
+				}
 				s.currentJob = nil
 				s.currentJobLock.Unlock()
 
@@ -91,6 +98,22 @@ func (s *Scheduler) Stop() {
 	//s.wg.Wait()
 }
 
+// Interrupt interrupts the job being executed if it has been running for at least minDuration.
+// The interrupt flag of the VM is cleared once the job returns so that the next jobs are not affected.
+// It returns false if no job was interrupted.
+func (s *Scheduler) Interrupt(vm *goja.Runtime, v interface{}, minDuration time.Duration) bool {
+	s.currentJobLock.Lock()
+	defer s.currentJobLock.Unlock()
+
+	if s.currentJob == nil || time.Since(s.currentJob.startedAt) < minDuration {
+		return false
+	}
+
+	vm.Interrupt(v)
+	s.currentJob.onDone = vm.ClearInterrupt
+	return true
+}
+
 // Schedule adds a job to the queue and waits for its completion
 func (s *Scheduler) Schedule(fn func() error) error {
 	resultCh := make(chan error, 1)
@@ -1,15 +1,105 @@
 package util
 
 import (
+	"container/list"
 	"encoding/json"
 	"io"
 	"net/http"
 	"seanime/internal/util"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 )
 
-type ImageProxy struct{}
+// maxProcessedImageCacheSize is the maximum total size of the processed images kept in memory
+const maxProcessedImageCacheSize = 100 << 20 // 100 MB
+
+type ImageProxy struct {
+	// ProcessImage transforms fetched images and returns their content type, optional.
+	// The original image is sent if it fails.
+	ProcessImage func(data []byte) ([]byte, string, error)
+	// ProcessImageKey identifies the current processing settings, optional.
+	// Processed images are cached by URL and key, they are not processed or cached if it returns an empty string.
+	ProcessImageKey func() string
+
+	cacheOnce sync.Once
+	cache     *processedImageCache
+}
+
+// processedImageCache keeps the most recently used processed images so that pages aren't re-encoded on every request.
+type processedImageCache struct {
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	order   *list.List // Most recently used first
+	size    int
+	maxSize int
+}
+
+type processedImage struct {
+	key         string
+	data        []byte
+	contentType string
+}
+
+func newProcessedImageCache(maxSize int) *processedImageCache {
+	return &processedImageCache{
+		items:   make(map[string]*list.Element),
+		order:   list.New(),
+		maxSize: maxSize,
+	}
+}
+
+func (c *processedImageCache) get(key string) (*processedImage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*processedImage), true
+}
+
+func (c *processedImageCache) set(img *processedImage) {
+	if len(img.data) > c.maxSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[img.key]; ok {
+		c.size -= len(el.Value.(*processedImage).data)
+		c.order.Remove(el)
+	}
+	c.items[img.key] = c.order.PushFront(img)
+	c.size += len(img.data)
+
+	// Evict the least recently used images
+	for c.size > c.maxSize {
+		el := c.order.Back()
+		evicted := el.Value.(*processedImage)
+		c.order.Remove(el)
+		delete(c.items, evicted.key)
+		c.size -= len(evicted.data)
+	}
+}
+
+func (ip *ImageProxy) getCache() *processedImageCache {
+	ip.cacheOnce.Do(func() {
+		ip.cache = newProcessedImageCache(maxProcessedImageCacheSize)
+	})
+	return ip.cache
+}
+
+// getProcessImageKey returns the key of the current processing settings, or an empty string if images aren't processed.
+func (ip *ImageProxy) getProcessImageKey() string {
+	if ip.ProcessImage == nil || ip.ProcessImageKey == nil {
+		return ""
+	}
+	return ip.ProcessImageKey()
+}
 
 func (ip *ImageProxy) GetImage(url string, headers map[string]string) ([]byte, error) {
 	client := &http.Client{}
@@ -61,10 +151,26 @@ func (ip *ImageProxy) ProxyImage(c echo.Context) (err error) {
 	}
 
 	ip.setHeaders(c)
+
+	processKey := ip.getProcessImageKey()
+	cacheKey := processKey + "\n" + url
+	if processKey != "" {
+		if img, ok := ip.getCache().get(cacheKey); ok {
+			return c.Blob(http.StatusOK, img.contentType, img.data)
+		}
+	}
+
 	imageBuffer, err := ip.GetImage(url, headers)
 	if err != nil {
 		return c.String(echo.ErrInternalServerError.Code, "Error fetching image")
 	}
 
+	if processKey != "" {
+		if processed, contentType, err := ip.ProcessImage(imageBuffer); err == nil {
+			ip.getCache().set(&processedImage{key: cacheKey, data: processed, contentType: contentType})
+			return c.Blob(http.StatusOK, contentType, processed)
+		}
+	}
+
 	return c.Blob(http.StatusOK, c.Response().Header().Get("Content-Type"), imageBuffer)
 }
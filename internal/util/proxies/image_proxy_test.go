@@ -0,0 +1,80 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageProxy_ProcessedCache(t *testing.T) {
+	var fetched, processed atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		_, _ = w.Write([]byte("image " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	key := "settings-1"
+	ip := &ImageProxy{
+		ProcessImage: func(data []byte) ([]byte, string, error) {
+			processed.Add(1)
+			return append([]byte("processed "), data...), "image/jpeg", nil
+		},
+		ProcessImageKey: func() string { return key },
+	}
+
+	get := func(path string) string {
+		e := echo.New()
+		q := url.Values{"url": {server.URL + path}, "headers": {"{}"}}
+		req := httptest.NewRequest(http.MethodGet, "/?"+q.Encode(), nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, ip.ProxyImage(e.NewContext(req, rec)))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	assert.Equal(t, "processed image /1.png", get("/1.png"))
+	assert.Equal(t, "processed image /1.png", get("/1.png"))
+	assert.EqualValues(t, 1, fetched.Load())
+	assert.EqualValues(t, 1, processed.Load())
+
+	// Changing the settings processes the image again
+	key = "settings-2"
+	get("/1.png")
+	assert.EqualValues(t, 2, processed.Load())
+
+	// Images are not cached if processing is disabled
+	key = ""
+	assert.Equal(t, "image /1.png", get("/1.png"))
+	get("/1.png")
+	assert.EqualValues(t, 4, fetched.Load())
+	assert.EqualValues(t, 2, processed.Load())
+}
+
+func TestProcessedImageCache_Eviction(t *testing.T) {
+	c := newProcessedImageCache(10)
+
+	c.set(&processedImage{key: "a", data: make([]byte, 4)})
+	c.set(&processedImage{key: "b", data: make([]byte, 4)})
+	_, _ = c.get("a") // "b" is now the least recently used
+	c.set(&processedImage{key: "c", data: make([]byte, 4)})
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+	_, ok = c.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 8, c.size)
+
+	// Images larger than the cache are not stored
+	c.set(&processedImage{key: "d", data: make([]byte, 11)})
+	_, ok = c.get("d")
+	assert.False(t, ok)
+}
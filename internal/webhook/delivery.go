@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"seanime/internal/constants"
+	"seanime/internal/database/models"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+
+	// MaxAttempts is the number of attempts after which a delivery is marked as failed
+	MaxAttempts = 8
+	// pollInterval is the interval at which due deliveries are checked
+	pollInterval = 5 * time.Second
+	// maxResponseSize is the maximum size of the response body stored in the delivery log
+	maxResponseSize = 1024
+)
+
+// defaultBackoff returns the delay before the next attempt: 30s, 1m, 2m, 4m... up to 1h.
+func defaultBackoff(attempts int) time.Duration {
+	d := 30 * time.Second << min(max(attempts-1, 0), 7)
+	return min(d, time.Hour)
+}
+
+// enqueue renders the body and persists a pending delivery.
+func (m *Manager) enqueue(webhook *models.Webhook, event string, data []byte) error {
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	body, err := renderBody(webhook.Template, &Payload{
+		Event:     event,
+		Timestamp: m.now().UTC().Format(time.RFC3339),
+		Data:      payload,
+	})
+	if err != nil {
+		return fmt.Errorf("webhook: failed to render template: %w", err)
+	}
+
+	headers, _ := parseHeaders(webhook.Headers)
+	contentType := headers.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+		if json.Valid(body) {
+			contentType = "application/json"
+		}
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:   webhook.ID,
+		Event:       event,
+		Body:        string(body),
+		ContentType: contentType,
+		Status:      StatusPending,
+	}
+	if err := m.database.InsertWebhookDelivery(delivery); err != nil {
+		return err
+	}
+	m.database.TrimWebhookDeliveries()
+
+	m.wake()
+	return nil
+}
+
+func (m *Manager) wake() {
+	select {
+	case m.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.sendDue()
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-m.wakeCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue sends the pending deliveries that are due, one at a time.
+func (m *Manager) sendDue() {
+	for {
+		deliveries, err := m.database.GetDueWebhookDeliveries(m.now(), 20)
+		if err != nil {
+			m.logger.Error().Err(err).Msg("webhook: Failed to get pending deliveries")
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		for _, delivery := range deliveries {
+			select {
+			case <-m.stopCh:
+				return
+			default:
+			}
+
+			m.send(delivery)
+			if err := m.database.UpdateWebhookDelivery(delivery); err != nil {
+				m.logger.Error().Err(err).Msg("webhook: Failed to update delivery")
+				return
+			}
+		}
+	}
+}
+
+// send sends the delivery and updates its status.
+func (m *Manager) send(delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+
+	webhook, err := m.database.GetWebhook(delivery.WebhookID)
+	if err != nil {
+		delivery.Status = StatusFailed
+		delivery.Error = "webhook not found"
+		return
+	}
+
+	status, response, err := m.post(webhook, delivery)
+	delivery.ResponseStatus = status
+	delivery.Response = response
+
+	if err == nil {
+		delivery.Status = StatusSuccess
+		delivery.NextAttemptAt = nil
+		delivery.Error = ""
+		return
+	}
+
+	delivery.Error = err.Error()
+	if delivery.Attempts >= MaxAttempts {
+		delivery.Status = StatusFailed
+		delivery.NextAttemptAt = nil
+		m.logger.Warn().Err(err).Uint("webhook", webhook.ID).Str("event", delivery.Event).Msg("webhook: Delivery failed")
+		return
+	}
+
+	next := m.now().Add(m.backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	m.logger.Debug().Err(err).Uint("webhook", webhook.ID).Str("event", delivery.Event).Msgf("webhook: Delivery failed, retrying in %s", next.Sub(m.now()))
+}
+
+// post sends the request and returns the response status and the truncated response body.
+func (m *Manager) post(webhook *models.Webhook, delivery *models.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Body))
+	if err != nil {
+		return 0, "", err
+	}
+
+	req.Header.Set("Content-Type", delivery.ContentType)
+	req.Header.Set("User-Agent", "Seanime/"+constants.Version)
+	req.Header.Set("X-Seanime-Event", delivery.Event)
+	req.Header.Set("X-Seanime-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	if webhook.Secret != "" {
+		req.Header.Set("X-Seanime-Signature", Sign(webhook.Secret, []byte(delivery.Body)))
+	}
+	headers, _ := parseHeaders(webhook.Headers)
+	for key := range headers {
+		req.Header.Set(key, headers.Get(key))
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(b), errors.New("unexpected status: " + resp.Status)
+	}
+
+	return resp.StatusCode, string(b), nil
+}
+
+// Sign returns the value of the X-Seanime-Signature header: "sha256=" followed by the hex-encoded HMAC-SHA256 of the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/goccy/go-json"
+)
+
+// Payload is the default body of a delivery and the data of the body template.
+//
+//	Example template for Discord:
+//	{"content": {{ json (printf "Scan completed: %d files" (len .Data.localFiles)) }}}
+type Payload struct {
+	Event     string      `json:"event"`
+	Timestamp string      `json:"timestamp"` // RFC 3339
+	Data      interface{} `json:"data"`      // The event, as sent to plugins
+}
+
+var templateFuncs = template.FuncMap{
+	// json encodes a value, strings are quoted and escaped
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// parseTemplate parses the body template, it returns nil if the template is empty.
+func parseTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	return template.New("body").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// renderBody renders the body template, or encodes the payload as JSON if there is no template.
+func renderBody(text string, payload *Payload) ([]byte, error) {
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return json.Marshal(payload)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseHeaders parses headers written as one "Key: Value" per line.
+func parseHeaders(text string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header %q", line)
+		}
+		headers.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/hook"
+	"seanime/internal/hook_resolver"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// TestEvent is the event sent by Manager.Test
+	TestEvent = "test"
+	// handlerPriority makes the webhook handlers run after the other handlers, so the payload contains their changes
+	handlerPriority = 1000
+)
+
+type (
+	// Manager sends the hook events to the webhooks subscribed to them.
+	// Deliveries are persisted and sent in the background, failed deliveries are retried with backoff.
+	Manager struct {
+		logger      *zerolog.Logger
+		database    *db.Database
+		hookManager hook.Manager
+		client      HTTPClient
+
+		mu       sync.RWMutex
+		webhooks []*models.Webhook
+		// bound holds the handler IDs of the bound hooks, by event name
+		bound map[string]string
+
+		wakeCh chan struct{}
+		stopCh chan struct{}
+		// now and backoff are replaced in tests
+		now     func() time.Time
+		backoff func(attempts int) time.Duration
+	}
+
+	HTTPClient interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	NewManagerOptions struct {
+		Logger      *zerolog.Logger
+		Database    *db.Database
+		HookManager hook.Manager // Defaults to hook.GlobalHookManager
+		Client      HTTPClient   // Defaults to a client with a 15s timeout
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ret := &Manager{
+		logger:      opts.Logger,
+		database:    opts.Database,
+		hookManager: opts.HookManager,
+		client:      opts.Client,
+		webhooks:    make([]*models.Webhook, 0),
+		bound:       make(map[string]string),
+		wakeCh:      make(chan struct{}, 1),
+		stopCh:      make(chan struct{}),
+		now:         time.Now,
+		backoff:     defaultBackoff,
+	}
+	if ret.hookManager == nil {
+		ret.hookManager = hook.GlobalHookManager
+	}
+	if ret.client == nil {
+		ret.client = &http.Client{Timeout: 15 * time.Second}
+	}
+	return ret
+}
+
+// Start loads the webhooks, binds the hooks and starts sending the pending deliveries.
+func (m *Manager) Start() {
+	if err := m.Reload(); err != nil {
+		m.logger.Error().Err(err).Msg("webhook: Failed to load webhooks")
+	}
+	go m.run()
+}
+
+// Stop stops sending deliveries. Pending deliveries are sent after the next start.
+func (m *Manager) Stop() {
+	select {
+	case <-m.stopCh:
+	default:
+		close(m.stopCh)
+	}
+}
+
+// ListEvents returns the names of the events webhooks can subscribe to, e.g. "scanCompleted".
+func (m *Manager) ListEvents() []string {
+	ret := make([]string, 0)
+	for _, method := range hookMethods(m.hookManager) {
+		ret = append(ret, eventName(method))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Reload reads the webhooks from the database and binds the hooks of the events they subscribe to.
+func (m *Manager) Reload() error {
+	webhooks, err := m.database.GetWebhooks()
+	if err != nil {
+		return err
+	}
+
+	events := make(map[string]struct{})
+	for _, w := range webhooks {
+		if !w.Enabled {
+			continue
+		}
+		for _, event := range w.GetEvents() {
+			events[event] = struct{}{}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webhooks = webhooks
+
+	// Unbind the events no webhook subscribes to anymore
+	for event, id := range m.bound {
+		if _, ok := events[event]; ok {
+			continue
+		}
+		if h, ok := m.getHook(event); ok {
+			h.Unbind(id)
+		}
+		delete(m.bound, event)
+	}
+
+	// Bind the new events
+	for event := range events {
+		if _, ok := m.bound[event]; ok {
+			continue
+		}
+		h, ok := m.getHook(event)
+		if !ok {
+			m.logger.Warn().Str("event", event).Msg("webhook: Unknown event")
+			continue
+		}
+		m.bound[event] = h.Bind(&hook.Handler[hook_resolver.Resolver]{
+			Func: func(e hook_resolver.Resolver) error {
+				m.handleEvent(event, e)
+				return e.Next()
+			},
+			Priority: handlerPriority,
+		})
+	}
+
+	return nil
+}
+
+// GetWebhooks returns all the webhooks.
+func (m *Manager) GetWebhooks() ([]*models.Webhook, error) {
+	return m.database.GetWebhooks()
+}
+
+// SaveWebhook validates and saves the webhook, it is created if it has no ID.
+func (m *Manager) SaveWebhook(webhook *models.Webhook) (*models.Webhook, error) {
+	webhook.Name = strings.TrimSpace(webhook.Name)
+	webhook.URL = strings.TrimSpace(webhook.URL)
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook: invalid URL %q", webhook.URL)
+	}
+
+	available := m.ListEvents()
+	events := make([]string, 0)
+	for _, event := range strings.Split(webhook.Events, ",") {
+		event = strings.TrimSpace(event)
+		if event == "" || slices.Contains(events, event) {
+			continue
+		}
+		if !slices.Contains(available, event) {
+			return nil, fmt.Errorf("webhook: unknown event %q", event)
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil, errors.New("webhook: no events selected")
+	}
+	webhook.Events = strings.Join(events, ",")
+
+	if _, err := parseTemplate(webhook.Template); err != nil {
+		return nil, fmt.Errorf("webhook: invalid template: %w", err)
+	}
+	if _, err := parseHeaders(webhook.Headers); err != nil {
+		return nil, fmt.Errorf("webhook: invalid headers: %w", err)
+	}
+
+	if webhook.ID != 0 {
+		existing, err := m.database.GetWebhook(webhook.ID)
+		if err != nil {
+			return nil, err
+		}
+		webhook.CreatedAt = existing.CreatedAt
+	}
+
+	if err := m.database.SaveWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, m.Reload()
+}
+
+// DeleteWebhook deletes the webhook and its deliveries.
+func (m *Manager) DeleteWebhook(id uint) error {
+	if err := m.database.DeleteWebhook(id); err != nil {
+		return err
+	}
+	return m.Reload()
+}
+
+// GetDeliveries returns the latest deliveries of the webhook, newest first.
+func (m *Manager) GetDeliveries(webhookId uint, limit int) ([]*models.WebhookDelivery, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 200
+	}
+	return m.database.GetWebhookDeliveries(webhookId, limit)
+}
+
+// Test queues a delivery of the "test" event to the webhook, even if it's disabled.
+func (m *Manager) Test(id uint) error {
+	webhook, err := m.database.GetWebhook(id)
+	if err != nil {
+		return err
+	}
+	data, _ := json.Marshal(map[string]string{"message": "This is a test event from Seanime."})
+	return m.enqueue(webhook, TestEvent, data)
+}
+
+// Redeliver queues the delivery again, resetting its attempts.
+func (m *Manager) Redeliver(deliveryId uint) error {
+	delivery, err := m.database.GetWebhookDelivery(deliveryId)
+	if err != nil {
+		return err
+	}
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = nil
+	delivery.Error = ""
+	if err := m.database.UpdateWebhookDelivery(delivery); err != nil {
+		return err
+	}
+	m.wake()
+	return nil
+}
+
+// handleEvent queues a delivery to each enabled webhook subscribed to the event.
+// The event is encoded right away since it can be modified once the hook chain continues.
+func (m *Manager) handleEvent(event string, e hook_resolver.Resolver) {
+	m.mu.RLock()
+	webhooks := make([]*models.Webhook, 0)
+	for _, w := range m.webhooks {
+		if w.Enabled && slices.Contains(w.GetEvents(), event) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		m.logger.Error().Err(err).Str("event", event).Msg("webhook: Failed to encode event")
+		return
+	}
+
+	go func() {
+		for _, w := range webhooks {
+			if err := m.enqueue(w, event, data); err != nil {
+				m.logger.Error().Err(err).Str("event", event).Uint("webhook", w.ID).Msg("webhook: Failed to queue delivery")
+			}
+		}
+	}()
+}
+
+func (m *Manager) getHook(event string) (*hook.Hook[hook_resolver.Resolver], bool) {
+	for _, method := range hookMethods(m.hookManager) {
+		if eventName(method) != event {
+			continue
+		}
+		h, ok := reflect.ValueOf(m.hookManager).MethodByName(method).Call(nil)[0].Interface().(*hook.Hook[hook_resolver.Resolver])
+		return h, ok
+	}
+	return nil, false
+}
+
+// hookMethods returns the names of the hook manager methods returning a hook, e.g. "OnScanCompleted".
+func hookMethods(manager hook.Manager) []string {
+	ret := make([]string, 0)
+	t := reflect.TypeOf(manager)
+	hookType := reflect.TypeOf(&hook.Hook[hook_resolver.Resolver]{})
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		// The receiver is the first input
+		if !strings.HasPrefix(method.Name, "On") || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != hookType {
+			continue
+		}
+		ret = append(ret, method.Name)
+	}
+	return ret
+}
+
+// eventName returns the event name of a hook method, e.g. "OnScanCompleted" -> "scanCompleted".
+func eventName(method string) string {
+	name := strings.TrimPrefix(method, "On")
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
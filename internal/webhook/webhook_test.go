@@ -0,0 +1,255 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/hook"
+	"seanime/internal/hook_resolver"
+	"seanime/internal/util"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testScanCompletedEvent struct {
+	hook_resolver.Event
+	Duration int `json:"duration"`
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type testServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*receivedRequest
+	// status returns the status of the nth request
+	status func(n int) int
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{status: func(int) int { return http.StatusOK }}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, &receivedRequest{header: r.Header, body: b})
+		status := s.status(len(s.requests))
+		s.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) received() []*receivedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*receivedRequest{}, s.requests...)
+}
+
+func newTestManager(t *testing.T) (*Manager, hook.Manager) {
+	logger := util.NewLogger()
+	database, err := db.NewDatabase(t.TempDir(), "seanime-test", logger)
+	require.NoError(t, err)
+
+	hookManager := hook.NewHookManager(hook.NewHookManagerOptions{Logger: logger})
+	m := NewManager(&NewManagerOptions{
+		Logger:      logger,
+		Database:    database,
+		HookManager: hookManager,
+	})
+	m.backoff = func(int) time.Duration { return 0 }
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	return m, hookManager
+}
+
+func waitForDelivery(t *testing.T, m *Manager, webhookId uint, status string) *models.WebhookDelivery {
+	var ret *models.WebhookDelivery
+	require.Eventually(t, func() bool {
+		deliveries, err := m.GetDeliveries(webhookId, 1)
+		if err != nil || len(deliveries) == 0 || deliveries[0].Status != status {
+			return false
+		}
+		ret = deliveries[0]
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+	return ret
+}
+
+func TestManager_Deliver(t *testing.T) {
+	server := newTestServer(t)
+	m, hookManager := newTestManager(t)
+
+	webhook, err := m.SaveWebhook(&models.Webhook{
+		Name:    "Scans",
+		Enabled: true,
+		URL:     server.URL,
+		Events:  "scanCompleted, scanCompleted",
+		Secret:  "secret",
+		Headers: "Authorization: Bearer token",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "scanCompleted", webhook.Events)
+
+	err = hookManager.OnScanCompleted().Trigger(&testScanCompletedEvent{Duration: 1200})
+	require.NoError(t, err)
+
+	delivery := waitForDelivery(t, m, webhook.ID, StatusSuccess)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Equal(t, "ok", delivery.Response)
+
+	requests := server.received()
+	require.Len(t, requests, 1)
+	req := requests[0]
+	assert.Equal(t, "scanCompleted", req.header.Get("X-Seanime-Event"))
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", req.header.Get("Authorization"))
+	assert.Equal(t, Sign("secret", req.body), req.header.Get("X-Seanime-Signature"))
+
+	var payload struct {
+		Event string                 `json:"event"`
+		Data  map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, "scanCompleted", payload.Event)
+	assert.EqualValues(t, 1200, payload.Data["duration"])
+
+	// Other events are not sent
+	err = hookManager.OnScanStarted().Trigger(&testScanCompletedEvent{})
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, server.received(), 1)
+}
+
+func TestManager_Template(t *testing.T) {
+	server := newTestServer(t)
+	m, hookManager := newTestManager(t)
+
+	webhook, err := m.SaveWebhook(&models.Webhook{
+		Enabled:  true,
+		URL:      server.URL,
+		Events:   "scanCompleted",
+		Template: `{"content": {{ json (printf "Scan completed in %vms" .Data.duration) }}}`,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, hookManager.OnScanCompleted().Trigger(&testScanCompletedEvent{Duration: 5}))
+
+	delivery := waitForDelivery(t, m, webhook.ID, StatusSuccess)
+	assert.JSONEq(t, `{"content": "Scan completed in 5ms"}`, delivery.Body)
+
+	// Plain text body
+	webhook.Template = `{{ .Event }}`
+	_, err = m.SaveWebhook(webhook)
+	require.NoError(t, err)
+	require.NoError(t, m.Test(webhook.ID))
+
+	delivery = waitForDelivery(t, m, webhook.ID, StatusSuccess)
+	assert.Equal(t, TestEvent, delivery.Body)
+	assert.Equal(t, "text/plain; charset=utf-8", delivery.ContentType)
+}
+
+func TestManager_Retries(t *testing.T) {
+	server := newTestServer(t)
+	server.status = func(n int) int {
+		if n <= 2 {
+			return http.StatusInternalServerError
+		}
+		return http.StatusOK
+	}
+	m, _ := newTestManager(t)
+
+	webhook, err := m.SaveWebhook(&models.Webhook{URL: server.URL, Events: "scanCompleted"})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Test(webhook.ID))
+	delivery := waitForDelivery(t, m, webhook.ID, StatusSuccess)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, delivery.Error)
+
+	// Always failing
+	server.mu.Lock()
+	server.status = func(int) int { return http.StatusBadGateway }
+	server.mu.Unlock()
+
+	require.NoError(t, m.Test(webhook.ID))
+	delivery = waitForDelivery(t, m, webhook.ID, StatusFailed)
+	assert.Equal(t, MaxAttempts, delivery.Attempts)
+	assert.Equal(t, http.StatusBadGateway, delivery.ResponseStatus)
+	assert.Contains(t, delivery.Error, "502")
+
+	// Redeliver
+	server.mu.Lock()
+	server.status = func(int) int { return http.StatusOK }
+	server.mu.Unlock()
+
+	require.NoError(t, m.Redeliver(delivery.ID))
+	delivery = waitForDelivery(t, m, webhook.ID, StatusSuccess)
+	assert.Equal(t, 1, delivery.Attempts)
+}
+
+func TestManager_Bindings(t *testing.T) {
+	m, hookManager := newTestManager(t)
+
+	webhook, err := m.SaveWebhook(&models.Webhook{Enabled: true, URL: "http://localhost", Events: "scanCompleted"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, hookManager.OnScanCompleted().Length())
+
+	// Disabled webhooks don't bind hooks
+	webhook.Enabled = false
+	_, err = m.SaveWebhook(webhook)
+	require.NoError(t, err)
+	assert.Equal(t, 0, hookManager.OnScanCompleted().Length())
+
+	webhook.Enabled = true
+	_, err = m.SaveWebhook(webhook)
+	require.NoError(t, err)
+	require.NoError(t, m.DeleteWebhook(webhook.ID))
+	assert.Equal(t, 0, hookManager.OnScanCompleted().Length())
+}
+
+func TestManager_Validation(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	assert.Contains(t, m.ListEvents(), "scanCompleted")
+	assert.Contains(t, m.ListEvents(), "autoDownloaderAfterDownloadTorrent")
+
+	tests := []struct {
+		name    string
+		webhook *models.Webhook
+		err     string
+	}{
+		{"invalid url", &models.Webhook{URL: "ftp://example.com", Events: "scanCompleted"}, "invalid URL"},
+		{"no events", &models.Webhook{URL: "https://example.com", Events: " , "}, "no events"},
+		{"unknown event", &models.Webhook{URL: "https://example.com", Events: "scanFinished"}, "unknown event"},
+		{"invalid template", &models.Webhook{URL: "https://example.com", Events: "scanCompleted", Template: "{{ .Event"}, "invalid template"},
+		{"invalid headers", &models.Webhook{URL: "https://example.com", Events: "scanCompleted", Headers: "Authorization"}, "invalid headers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.SaveWebhook(tt.webhook)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDefaultBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, defaultBackoff(1))
+	assert.Equal(t, time.Minute, defaultBackoff(2))
+	assert.Equal(t, 4*time.Minute, defaultBackoff(4))
+	assert.Equal(t, time.Hour, defaultBackoff(20))
+}
@@ -18,7 +18,13 @@ import type {
     DebridClient_CancelStreamOptions,
     DebridClient_StreamPlaybackType,
     Debrid_TorrentItem,
+    HibikeSubtitle_SearchResult,
     HibikeTorrent_AnimeTorrent,
+    MangaMihon_ApplyEntry,
+    MangaReadingState_MarkChapterOptions,
+    Manga_AutoDownloaderRule,
+    Manga_AutoDownloaderRuleChapterType,
+    Manga_ExportFormat,
     Mediastream_StreamType,
     Models_AnilistSettings,
     Models_DebridSettings,
@@ -685,6 +691,7 @@ export type FetchExternalExtensionData_Variables = {
  */
 export type InstallExternalExtension_Variables = {
     manifestUri: string
+    repository?: string
 }
 
 /**
@@ -754,6 +761,40 @@ export type GrantPluginPermissions_Variables = {
     id: string
 }
 
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/runtime-metrics/enable
+ * @description
+ * Route re-enables an extension that was disabled after repeated resource limit violations.
+ */
+export type EnableExtensionRuntime_Variables = {
+    id: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/trusted-keys
+ * @description
+ * Route adds a publisher key to the trusted keys.
+ */
+export type AddExtensionTrustedKey_Variables = {
+    name: string
+    publicKey: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/trusted-keys
+ * @description
+ * Route removes a publisher key from the trusted keys.
+ */
+export type RemoveExtensionTrustedKey_Variables = {
+    fingerprint: string
+}
+
 /**
  * - Filepath: internal/handlers/extensions.go
  * - Filename: extensions.go
@@ -765,6 +806,17 @@ export type RunExtensionPlaygroundCode_Variables = {
     params?: RunPlaygroundCodeParams
 }
 
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/harness/run
+ * @description
+ * Route runs a regression suite of a provider extension against recorded fetch fixtures.
+ */
+export type RunExtensionHarness_Variables = {
+    suitePath: string
+}
+
 /**
  * - Filepath: internal/handlers/extensions.go
  * - Filename: extensions.go
@@ -778,6 +830,79 @@ export type SaveExtensionUserConfig_Variables = {
     values: Record<string, string>
 }
 
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/marketplace/repositories
+ * @description
+ * Route adds a marketplace repository.
+ */
+export type AddMarketplaceRepository_Variables = {
+    name: string
+    url: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/marketplace/repositories
+ * @description
+ * Route removes a marketplace repository.
+ */
+export type RemoveMarketplaceRepository_Variables = {
+    url: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/versions/{id}
+ * @description
+ * Route returns the installed version of an external extension, its update settings and its previous versions.
+ */
+export type GetExtensionVersions_Variables = {
+    /**
+     *  The extension ID
+     */
+    id: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/rollback
+ * @description
+ * Route restores a previous version of an external extension.
+ */
+export type RollbackExtension_Variables = {
+    id: string
+    version?: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/pin
+ * @description
+ * Route pins an external extension to a version.
+ */
+export type PinExtension_Variables = {
+    id: string
+    version: string
+}
+
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/channel
+ * @description
+ * Route selects the update channel of an external extension.
+ */
+export type SetExtensionChannel_Variables = {
+    id: string
+    channel: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // filecache
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -964,6 +1089,20 @@ export type GetMangaEntryChapters_Variables = {
     provider: string
 }
 
+/**
+ * - Filepath: internal/handlers/manga.go
+ * - Filename: manga.go
+ * - Endpoint: /api/v1/manga/merged-chapters
+ * @description
+ * Route returns the chapters of a manga entry merged across the provider and its fallback providers.
+ */
+export type GetMangaMergedChapters_Variables = {
+    mediaId: number
+    provider: string
+    scanlators: Array<string>
+    languages: Array<string>
+}
+
 /**
  * - Filepath: internal/handlers/manga.go
  * - Filename: manga.go
@@ -1076,6 +1215,76 @@ export type RemoveMangaMapping_Variables = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_auto_downloader
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/manga_auto_downloader.go
+ * - Filename: manga_auto_downloader.go
+ * - Endpoint: /api/v1/manga/auto-downloader/rule/manga/{id}
+ * @description
+ * Route returns the manga auto downloader rules with the given media id.
+ */
+export type GetMangaAutoDownloaderRulesByManga_Variables = {
+    /**
+     *  The AniList manga id of the rules
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_auto_downloader.go
+ * - Filename: manga_auto_downloader.go
+ * - Endpoint: /api/v1/manga/auto-downloader/rule
+ * @description
+ * Route creates a new manga auto downloader rule.
+ */
+export type CreateMangaAutoDownloaderRule_Variables = {
+    enabled: boolean
+    mediaId: number
+    provider: string
+    scanlators: Array<string>
+    languages: Array<string>
+    chapterType: Manga_AutoDownloaderRuleChapterType
+}
+
+/**
+ * - Filepath: internal/handlers/manga_auto_downloader.go
+ * - Filename: manga_auto_downloader.go
+ * - Endpoint: /api/v1/manga/auto-downloader/rule
+ * @description
+ * Route updates a manga auto downloader rule.
+ */
+export type UpdateMangaAutoDownloaderRule_Variables = {
+    rule?: Manga_AutoDownloaderRule
+}
+
+/**
+ * - Filepath: internal/handlers/manga_auto_downloader.go
+ * - Filename: manga_auto_downloader.go
+ * - Endpoint: /api/v1/manga/auto-downloader/rule/{id}
+ * @description
+ * Route deletes a manga auto downloader rule.
+ */
+export type DeleteMangaAutoDownloaderRule_Variables = {
+    /**
+     *  The DB id of the rule
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_auto_downloader.go
+ * - Filename: manga_auto_downloader.go
+ * - Endpoint: /api/v1/manga/auto-downloader/item
+ * @description
+ * Route deletes an item from the manga auto downloader history.
+ */
+export type DeleteMangaAutoDownloaderItem_Variables = {
+    id: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // manga_download
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1117,10 +1326,176 @@ export type DeleteMangaDownloadedChapters_Variables = {
     downloadIds: Array<ChapterDownloader_DownloadID>
 }
 
+/**
+ * - Filepath: internal/handlers/manga_download.go
+ * - Filename: manga_download.go
+ * - Endpoint: /api/v1/manga/export
+ * @description
+ * Route packs downloaded chapters into CBZ or EPUB files.
+ */
+export type ExportMangaDownloadedChapters_Variables = {
+    mediaId: number
+    format: Manga_ExportFormat
+    chapters: Array<ChapterDownloader_DownloadID>
+    bundle: boolean
+}
+
+/**
+ * - Filepath: internal/handlers/manga_download.go
+ * - Filename: manga_download.go
+ * - Endpoint: /api/v1/manga/downloads/convert
+ * @description
+ * Route converts downloaded chapters stored as images to CBZ archives.
+ */
+export type ConvertMangaDownloads_Variables = {
+    mediaId: number
+    copyToInternalStorage: boolean
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // manga_image
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_local_library
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/manga_local_library.go
+ * - Filename: manga_local_library.go
+ * - Endpoint: /api/v1/manga/local-library/match
+ * @description
+ * Route manually matches a local series to an AniList manga.
+ */
+export type MatchMangaLocalLibrarySeries_Variables = {
+    seriesKey: string
+    mediaId: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_mihon_import
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/manga_mihon_import.go
+ * - Filename: manga_mihon_import.go
+ * - Endpoint: /api/v1/manga/mihon-import/preview
+ * @description
+ * Route decodes a Tachiyomi/Mihon backup and returns how its manga would be imported.
+ */
+export type PreviewMangaMihonImport_Variables = {
+    data: string
+}
+
+/**
+ * - Filepath: internal/handlers/manga_mihon_import.go
+ * - Filename: manga_mihon_import.go
+ * - Endpoint: /api/v1/manga/mihon-import/apply
+ * @description
+ * Route imports the selected entries of a Tachiyomi/Mihon backup preview.
+ */
+export type ApplyMangaMihonImport_Variables = {
+    entries: Array<MangaMihon_ApplyEntry>
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_provider_chain
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/manga_provider_chain.go
+ * - Filename: manga_provider_chain.go
+ * - Endpoint: /api/v1/manga/provider-chain/{id}
+ * @description
+ * Route returns the fallback providers of a manga, in order.
+ */
+export type GetMangaProviderChain_Variables = {
+    /**
+     *  AniList manga media ID
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_provider_chain.go
+ * - Filename: manga_provider_chain.go
+ * - Endpoint: /api/v1/manga/provider-chain
+ * @description
+ * Route sets the fallback providers of a manga, in order.
+ */
+export type SaveMangaProviderChain_Variables = {
+    mediaId: number
+    providers: Array<string>
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_reading_state
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/manga_reading_state.go
+ * - Filename: manga_reading_state.go
+ * - Endpoint: /api/v1/manga/reading-state/{id}
+ * @description
+ * Route returns the reading state of the chapters of a manga.
+ */
+export type GetMangaReadingState_Variables = {
+    /**
+     *  AniList manga media ID
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_reading_state.go
+ * - Filename: manga_reading_state.go
+ * - Endpoint: /api/v1/manga/reading-state/page
+ * @description
+ * Route saves the current page of a chapter.
+ */
+export type UpdateMangaReadingPage_Variables = {
+    mediaId: number
+    provider: string
+    chapterId: string
+    chapterNumber: string
+    currentPage: number
+    totalPages: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_reading_state.go
+ * - Filename: manga_reading_state.go
+ * - Endpoint: /api/v1/manga/reading-state/mark
+ * @description
+ * Route marks chapters as read or unread.
+ */
+export type MarkMangaChapters_Variables = {
+    mediaId: number
+    provider: string
+    chapters: Array<MangaReadingState_MarkChapterOptions>
+    read: boolean
+    syncProgress: boolean
+    totalChapters: number
+}
+
+/**
+ * - Filepath: internal/handlers/manga_reading_state.go
+ * - Filename: manga_reading_state.go
+ * - Endpoint: /api/v1/manga/reading-state/{id}
+ * @description
+ * Route deletes the reading state of the chapters of a manga.
+ */
+export type ResetMangaReadingState_Variables = {
+    /**
+     *  AniList manga media ID
+     */
+    id: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_release_tracker
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // manual_dump
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1315,6 +1690,10 @@ export type RemoveOnlinestreamMapping_Variables = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// opds
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // playback_manager
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1412,6 +1791,10 @@ export type GetPlaylistEpisodes_Variables = {
     progress: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// plugin_routes
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // releases
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1540,6 +1923,60 @@ export type DeleteLogs_Variables = {
     filenames: Array<string>
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// subtitles
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/subtitles.go
+ * - Filename: subtitles.go
+ * - Endpoint: /api/v1/subtitles/search
+ * @description
+ * Route searches subtitles for the given media, episode and language.
+ */
+export type SearchSubtitles_Variables = {
+    mediaId: number
+    episodeNumber: number
+    language: string
+    path: string
+    provider: string
+}
+
+/**
+ * - Filepath: internal/handlers/subtitles.go
+ * - Filename: subtitles.go
+ * - Endpoint: /api/v1/subtitles/download
+ * @description
+ * Route downloads a subtitle for the given video file.
+ */
+export type DownloadSubtitle_Variables = {
+    path: string
+    result?: HibikeSubtitle_SearchResult
+}
+
+/**
+ * - Filepath: internal/handlers/subtitles.go
+ * - Filename: subtitles.go
+ * - Endpoint: /api/v1/subtitles/tracks
+ * @description
+ * Route returns the subtitles downloaded for the given video file.
+ */
+export type GetSubtitleTracks_Variables = {
+    path: string
+}
+
+/**
+ * - Filepath: internal/handlers/subtitles.go
+ * - Filename: subtitles.go
+ * - Endpoint: /api/v1/subtitles/tracks
+ * @description
+ * Route deletes a subtitle downloaded for the given video file.
+ */
+export type DeleteSubtitleTrack_Variables = {
+    path: string
+    filename: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // sync
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1758,6 +2195,146 @@ export type GetTorrentstreamBatchHistory_Variables = {
     mediaId: number
 }
 
+/**
+ * - Filepath: internal/handlers/torrentstream.go
+ * - Filename: torrentstream.go
+ * - Endpoint: /api/v1/torrentstream/cache/item
+ * @description
+ * Route removes a torrent from the torrent streaming download directory.
+ */
+export type RemoveTorrentstreamCacheItem_Variables = {
+    infoHash: string
+}
+
+/**
+ * - Filepath: internal/handlers/torrentstream.go
+ * - Filename: torrentstream.go
+ * - Endpoint: /api/v1/torrentstream/seeding/stop
+ * @description
+ * Route stops seeding a torrent before its target is reached.
+ */
+export type StopTorrentstreamSeeding_Variables = {
+    infoHash: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// trackers
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/trackers.go
+ * - Filename: trackers.go
+ * - Endpoint: /api/v1/trackers/{id}/enabled
+ * @description
+ * Route enables or disables mirroring to a tracker.
+ */
+export type SetTrackerEnabled_Variables = {
+    enabled: boolean
+}
+
+/**
+ * - Filepath: internal/handlers/trackers.go
+ * - Filename: trackers.go
+ * - Endpoint: /api/v1/trackers/sync-items/{id}/resolve
+ * @description
+ * Route resolves a conflicting change.
+ */
+export type ResolveTrackerConflict_Variables = {
+    overwrite: boolean
+}
+
+/**
+ * - Filepath: internal/handlers/trackers.go
+ * - Filename: trackers.go
+ * - Endpoint: /api/v1/trackers/sync-items/{id}/retry
+ * @description
+ * Route queues a failed change again.
+ */
+export type RetryTrackerSyncItem_Variables = {
+    /**
+     *  The DB id of the change
+     */
+    id: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// webhooks
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks
+ * @description
+ * Route creates or updates a webhook.
+ */
+export type SaveWebhook_Variables = {
+    id: number
+    name: string
+    enabled: boolean
+    url: string
+    events: string
+    secret: string
+    template: string
+    headers: string
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}
+ * @description
+ * Route deletes a webhook and its delivery log.
+ */
+export type DeleteWebhook_Variables = {
+    /**
+     *  The DB id of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}/test
+ * @description
+ * Route queues a delivery of the "test" event to a webhook.
+ */
+export type TestWebhook_Variables = {
+    /**
+     *  The DB id of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}/deliveries
+ * @description
+ * Route returns the delivery log of a webhook.
+ */
+export type GetWebhookDeliveries_Variables = {
+    /**
+     *  The DB id of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/deliveries/{id}/redeliver
+ * @description
+ * Route queues a delivery again.
+ */
+export type RedeliverWebhookDelivery_Variables = {
+    /**
+     *  The DB id of the delivery
+     */
+    id: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // websocket
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
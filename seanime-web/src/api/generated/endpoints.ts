@@ -625,6 +625,11 @@ export const API_ENDPOINTS = {
             methods: ["POST"],
             endpoint: "/api/v1/extensions/external/fetch",
         },
+        /**
+         *  @description
+         *  Route installs the extension from the given manifest uri.
+         *  If the extension is already installed, the replaced version is kept in its history.
+         */
         InstallExternalExtension: {
             key: "EXTENSIONS-install-external-extension",
             methods: ["POST"],
@@ -690,6 +695,16 @@ export const API_ENDPOINTS = {
             methods: ["GET"],
             endpoint: "/api/v1/extensions/list/anime-torrent-provider",
         },
+        ListSubtitleProviderExtensions: {
+            key: "EXTENSIONS-list-subtitle-provider-extensions",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/list/subtitle-provider",
+        },
+        ListTrackerExtensions: {
+            key: "EXTENSIONS-list-tracker-extensions",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/list/tracker",
+        },
         GetPluginSettings: {
             key: "EXTENSIONS-get-plugin-settings",
             methods: ["GET"],
@@ -705,6 +720,41 @@ export const API_ENDPOINTS = {
             methods: ["POST"],
             endpoint: "/api/v1/extensions/plugin-permissions/grant",
         },
+        /**
+         *  @description
+         *  Route returns the runtime metrics of the JavaScript extensions.
+         *  This includes the number of calls, latency, errors, timeouts and resource limit violations of each extension.
+         */
+        GetExtensionRuntimeMetrics: {
+            key: "EXTENSIONS-get-extension-runtime-metrics",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/runtime-metrics",
+        },
+        EnableExtensionRuntime: {
+            key: "EXTENSIONS-enable-extension-runtime",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/runtime-metrics/enable",
+        },
+        GetExtensionTrustedKeys: {
+            key: "EXTENSIONS-get-extension-trusted-keys",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/trusted-keys",
+        },
+        /**
+         *  @description
+         *  Route adds a publisher key to the trusted keys.
+         *  The key must be a base64-encoded ed25519 public key.
+         */
+        AddExtensionTrustedKey: {
+            key: "EXTENSIONS-add-extension-trusted-key",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/trusted-keys",
+        },
+        RemoveExtensionTrustedKey: {
+            key: "EXTENSIONS-remove-extension-trusted-key",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/extensions/trusted-keys",
+        },
         /**
          *  @description
          *  Route runs the code in the extension playground.
@@ -715,6 +765,18 @@ export const API_ENDPOINTS = {
             methods: ["POST"],
             endpoint: "/api/v1/extensions/playground/run",
         },
+        /**
+         *  @description
+         *  Route runs a regression suite of a provider extension against recorded fetch fixtures.
+         *  The extension runs offline and the outputs are compared to the expected outputs.
+         *  The suite and the files it reads must be in the extension directory.
+         *  Recording the fixtures is only available through the 'extension-harness' command.
+         */
+        RunExtensionHarness: {
+            key: "EXTENSIONS-run-extension-harness",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/harness/run",
+        },
         GetExtensionUserConfig: {
             key: "EXTENSIONS-get-extension-user-config",
             methods: ["GET"],
@@ -725,11 +787,82 @@ export const API_ENDPOINTS = {
             methods: ["POST"],
             endpoint: "/api/v1/extensions/user-config",
         },
+        /**
+         *  @description
+         *  Route returns the marketplace extensions.
+         *  If no repository URL is given, the extensions of all repositories are returned.
+         */
         GetMarketplaceExtensions: {
             key: "EXTENSIONS-get-marketplace-extensions",
             methods: ["GET"],
             endpoint: "/api/v1/extensions/marketplace",
         },
+        /**
+         *  @description
+         *  Route returns the marketplace repositories.
+         *  The official repository is always first.
+         */
+        GetMarketplaceRepositories: {
+            key: "EXTENSIONS-get-marketplace-repositories",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/marketplace/repositories",
+        },
+        /**
+         *  @description
+         *  Route adds a marketplace repository.
+         *  The URL must point to a JSON list of extension manifests.
+         */
+        AddMarketplaceRepository: {
+            key: "EXTENSIONS-add-marketplace-repository",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/marketplace/repositories",
+        },
+        /**
+         *  @description
+         *  Route removes a marketplace repository.
+         *  Extensions installed from the repository are not uninstalled.
+         */
+        RemoveMarketplaceRepository: {
+            key: "EXTENSIONS-remove-marketplace-repository",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/extensions/marketplace/repositories",
+        },
+        GetExtensionVersions: {
+            key: "EXTENSIONS-get-extension-versions",
+            methods: ["GET"],
+            endpoint: "/api/v1/extensions/versions/{id}",
+        },
+        /**
+         *  @description
+         *  Route restores a previous version of an external extension.
+         *  If no version is given, the most recent previous version is restored.
+         */
+        RollbackExtension: {
+            key: "EXTENSIONS-rollback-extension",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/rollback",
+        },
+        /**
+         *  @description
+         *  Route pins an external extension to a version.
+         *  Updates are ignored while the extension is pinned. If the version is a previous version, the extension is rolled back to it.
+         *  An empty version unpins the extension.
+         */
+        PinExtension: {
+            key: "EXTENSIONS-pin-extension",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/pin",
+        },
+        /**
+         *  @description
+         *  Route selects the update channel of an external extension.
+         *  An empty channel selects the default channel.
+         */
+        SetExtensionChannel: {
+            key: "EXTENSIONS-set-extension-channel",
+            methods: ["POST"],
+            endpoint: "/api/v1/extensions/channel",
+        },
     },
     FILECACHE: {
         /**
@@ -943,11 +1076,29 @@ export const API_ENDPOINTS = {
             methods: ["DELETE"],
             endpoint: "/api/v1/manga/entry/cache",
         },
+        /**
+         *  @description
+         *  Route returns the chapters for a manga entry based on the provider.
+         *  If the manga has a provider chain, the next providers are used when the provider fails or is missing chapters.
+         *  The provider of the returned container can differ from the requested one.
+         */
         GetMangaEntryChapters: {
             key: "MANGA-get-manga-entry-chapters",
             methods: ["POST"],
             endpoint: "/api/v1/manga/chapters",
         },
+        /**
+         *  @description
+         *  Route returns the chapters of a manga entry merged across the provider and its fallback providers.
+         *  Chapters are deduplicated by number, the preferred chapter is selected by scanlator, then language, then provider.
+         *  Chapters in languages that aren't preferred are ignored.
+         *  The response also contains the gaps in the chapter numbering.
+         */
+        GetMangaMergedChapters: {
+            key: "MANGA-get-manga-merged-chapters",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/merged-chapters",
+        },
         /**
          *  @description
          *  Route returns the pages for a manga entry based on the provider and chapter id.
@@ -956,6 +1107,7 @@ export const API_ENDPOINTS = {
          *  If the app is online and the chapter is not downloaded, it will return the pages from the provider.
          *  If the chapter is downloaded, it will return the appropriate struct.
          *  If 'double page' is requested, it will fetch image sizes and include the dimensions in the response.
+         *  If the provider fails and the manga has a provider chain, the pages of the same chapter are fetched from the next providers.
          */
         GetMangaEntryPages: {
             key: "MANGA-get-manga-entry-pages",
@@ -1031,6 +1183,84 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/manga/remove-mapping",
         },
     },
+    MANGA_AUTO_DOWNLOADER: {
+        /**
+         *  @description
+         *  Route tells the manga auto downloader to check for new chapters if enabled.
+         *  It does nothing if the manga auto downloader is disabled.
+         */
+        RunMangaAutoDownloader: {
+            key: "MANGA-AUTO-DOWNLOADER-run-manga-auto-downloader",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/auto-downloader/run",
+        },
+        /**
+         *  @description
+         *  Route returns all manga auto downloader rules.
+         *  It returns an empty slice if there are no rules.
+         */
+        GetMangaAutoDownloaderRules: {
+            key: "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-rules",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/auto-downloader/rules",
+        },
+        GetMangaAutoDownloaderRulesByManga: {
+            key: "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-rules-by-manga",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/auto-downloader/rule/manga/{id}",
+        },
+        /**
+         *  @description
+         *  Route creates a new manga auto downloader rule.
+         *  It returns the created rule.
+         */
+        CreateMangaAutoDownloaderRule: {
+            key: "MANGA-AUTO-DOWNLOADER-create-manga-auto-downloader-rule",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/auto-downloader/rule",
+        },
+        /**
+         *  @description
+         *  Route updates a manga auto downloader rule.
+         *  The baseline chapter is reset if the provider changes.
+         *  It returns the updated rule.
+         */
+        UpdateMangaAutoDownloaderRule: {
+            key: "MANGA-AUTO-DOWNLOADER-update-manga-auto-downloader-rule",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/manga/auto-downloader/rule",
+        },
+        /**
+         *  @description
+         *  Route deletes a manga auto downloader rule.
+         *  It returns 'true' if the rule was deleted.
+         */
+        DeleteMangaAutoDownloaderRule: {
+            key: "MANGA-AUTO-DOWNLOADER-delete-manga-auto-downloader-rule",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/manga/auto-downloader/rule/{id}",
+        },
+        /**
+         *  @description
+         *  Route returns the chapters queued by the manga auto downloader.
+         *  The manga auto downloader uses these items in order to not download the same chapter twice.
+         */
+        GetMangaAutoDownloaderItems: {
+            key: "MANGA-AUTO-DOWNLOADER-get-manga-auto-downloader-items",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/auto-downloader/items",
+        },
+        /**
+         *  @description
+         *  Route deletes an item from the manga auto downloader history.
+         *  Returns 'true' if the item was deleted.
+         */
+        DeleteMangaAutoDownloaderItem: {
+            key: "MANGA-AUTO-DOWNLOADER-delete-manga-auto-downloader-item",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/manga/auto-downloader/item",
+        },
+    },
     MANGA_DOWNLOAD: {
         DownloadMangaChapters: {
             key: "MANGA-DOWNLOAD-download-manga-chapters",
@@ -1122,6 +1352,189 @@ export const API_ENDPOINTS = {
             methods: ["GET"],
             endpoint: "/api/v1/manga/downloads",
         },
+        /**
+         *  @description
+         *  Route packs downloaded chapters into CBZ or EPUB files.
+         *  If 'chapters' is empty, all downloaded chapters of the media are exported.
+         *  If 'bundle' is true, the chapters are exported as a single file for the whole series.
+         *  The files are written to the 'exports' folder in the download directory.
+         */
+        ExportMangaDownloadedChapters: {
+            key: "MANGA-DOWNLOAD-export-manga-downloaded-chapters",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/export",
+        },
+        /**
+         *  @description
+         *  Route converts downloaded chapters stored as images to CBZ archives.
+         *  This runs in the background, the client is notified when the conversion is done.
+         *  If 'mediaId' is 0, all downloaded chapters are converted.
+         *  If 'copyToInternalStorage' is true, the archives are also copied to the internal storage directory.
+         */
+        ConvertMangaDownloads: {
+            key: "MANGA-DOWNLOAD-convert-manga-downloads",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/downloads/convert",
+        },
+    },
+    MANGA_LOCAL_LIBRARY: {
+        /**
+         *  @description
+         *  Route returns the series found in the local manga library.
+         *  It returns the series of the last scan with their matched AniList media ID.
+         */
+        GetMangaLocalLibrary: {
+            key: "MANGA-LOCAL-LIBRARY-get-manga-local-library",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/local-library",
+        },
+        /**
+         *  @description
+         *  Route scans the local manga library and matches the series to the manga collection.
+         *  Manual matches are kept. Matched series can be read using the 'local-library' provider.
+         */
+        ScanMangaLocalLibrary: {
+            key: "MANGA-LOCAL-LIBRARY-scan-manga-local-library",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/local-library/scan",
+        },
+        /**
+         *  @description
+         *  Route manually matches a local series to an AniList manga.
+         *  A media ID of 0 unmatches the series. Manual matches are not changed by later scans.
+         */
+        MatchMangaLocalLibrarySeries: {
+            key: "MANGA-LOCAL-LIBRARY-match-manga-local-library-series",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/local-library/match",
+        },
+    },
+    MANGA_MIHON_IMPORT: {
+        /**
+         *  @description
+         *  Route decodes a Tachiyomi/Mihon backup and returns how its manga would be imported.
+         *  The backup (.tachibk or .proto.gz) is sent as base64 and decoded locally.
+         *  Manga are matched to AniList using the AniList tracker, the manga collection or a title search.
+         *  Sources are mapped to the installed manga providers using the manga URL when possible, or a title search.
+         *  Nothing is changed until the preview is applied.
+         */
+        PreviewMangaMihonImport: {
+            key: "MANGA-MIHON-IMPORT-preview-manga-mihon-import",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/mihon-import/preview",
+        },
+        /**
+         *  @description
+         *  Route imports the selected entries of a Tachiyomi/Mihon backup preview.
+         *  AniList progress is updated through the active platform when the backup is ahead, and provider mappings are created.
+         */
+        ApplyMangaMihonImport: {
+            key: "MANGA-MIHON-IMPORT-apply-manga-mihon-import",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/mihon-import/apply",
+        },
+    },
+    MANGA_PROVIDER_CHAIN: {
+        GetMangaProviderHealth: {
+            key: "MANGA-PROVIDER-CHAIN-get-manga-provider-health",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/provider-health",
+        },
+        /**
+         *  @description
+         *  Route returns the fallback providers of a manga, in order.
+         *  It returns an empty array if the manga has no provider chain.
+         */
+        GetMangaProviderChain: {
+            key: "MANGA-PROVIDER-CHAIN-get-manga-provider-chain",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/provider-chain/{id}",
+        },
+        /**
+         *  @description
+         *  Route sets the fallback providers of a manga, in order.
+         *  An empty array removes the provider chain.
+         */
+        SaveMangaProviderChain: {
+            key: "MANGA-PROVIDER-CHAIN-save-manga-provider-chain",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/provider-chain",
+        },
+    },
+    MANGA_READING_STATE: {
+        /**
+         *  @description
+         *  Route returns the reading state of the chapters of a manga.
+         *  The 'resume' field is the chapter and page to resume reading from, if the last chapter opened was not finished.
+         */
+        GetMangaReadingState: {
+            key: "MANGA-READING-STATE-get-manga-reading-state",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/reading-state/{id}",
+        },
+        /**
+         *  @description
+         *  Route saves the current page of a chapter.
+         *  The chapter is marked as read when the last page is reached.
+         */
+        UpdateMangaReadingPage: {
+            key: "MANGA-READING-STATE-update-manga-reading-page",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/manga/reading-state/page",
+        },
+        /**
+         *  @description
+         *  Route marks chapters as read or unread.
+         *  If 'syncProgress' is true and chapters are marked as read, the progress is updated to the highest chapter read if it is ahead.
+         *  When offline, the progress is updated locally and synced later.
+         */
+        MarkMangaChapters: {
+            key: "MANGA-READING-STATE-mark-manga-chapters",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/reading-state/mark",
+        },
+        /**
+         *  @description
+         *  Route deletes the reading state of the chapters of a manga.
+         *  The AniList progress is not changed.
+         */
+        ResetMangaReadingState: {
+            key: "MANGA-READING-STATE-reset-manga-reading-state",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/manga/reading-state/{id}",
+        },
+        /**
+         *  @description
+         *  Route returns the unfinished chapters, most recently read first.
+         *  Only the last chapter opened of each manga is returned.
+         */
+        GetMangaContinueReading: {
+            key: "MANGA-READING-STATE-get-manga-continue-reading",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/reading-state/continue",
+        },
+    },
+    MANGA_RELEASE_TRACKER: {
+        /**
+         *  @description
+         *  Route returns the latest MangaUpdates releases of the manga being read.
+         *  Only manga that have been matched to a MangaUpdates series by the release tracker are returned.
+         */
+        GetMangaReleases: {
+            key: "MANGA-RELEASE-TRACKER-get-manga-releases",
+            methods: ["GET"],
+            endpoint: "/api/v1/manga/release-tracker",
+        },
+        /**
+         *  @description
+         *  Route tells the manga release tracker to check for new releases if enabled.
+         *  It does nothing if the manga release tracker is disabled.
+         */
+        RunMangaReleaseTracker: {
+            key: "MANGA-RELEASE-TRACKER-run-manga-release-tracker",
+            methods: ["POST"],
+            endpoint: "/api/v1/manga/release-tracker/run",
+        },
     },
     MANUAL_DUMP: {
         TestDump: {
@@ -1131,6 +1544,11 @@ export const API_ENDPOINTS = {
         },
     },
     MEDIAPLAYER: {
+        /**
+         *  @description
+         *  Route launches the default media player (vlc or mpc-hc).
+         *  For Kodi, this only checks that it's reachable.
+         */
         StartDefaultMediaPlayer: {
             key: "MEDIAPLAYER-start-default-media-player",
             methods: ["POST"],
@@ -1301,6 +1719,19 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/onlinestream/remove-mapping",
         },
     },
+    OPDS: {
+        /**
+         *  @description
+         *  Route creates a long-lived session for OPDS clients.
+         *  The token is used as the password of the OPDS catalog, with any username.
+         *  It requires a valid session.
+         */
+        CreateOpdsToken: {
+            key: "OPDS-create-opds-token",
+            methods: ["POST"],
+            endpoint: "/api/v1/opds/token",
+        },
+    },
     PLAYBACK_MANAGER: {
         /**
          *  @description
@@ -1597,6 +2028,41 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/logs/latest",
         },
     },
+    SUBTITLES: {
+        /**
+         *  @description
+         *  Route searches subtitles for the given media, episode and language.
+         *  All subtitle providers are queried concurrently unless a provider is given.
+         *  Providers that fail are listed in the response errors, the other results are still returned.
+         *  The path of the video file is optional, it is used to match subtitles by file name.
+         */
+        SearchSubtitles: {
+            key: "SUBTITLES-search-subtitles",
+            methods: ["POST"],
+            endpoint: "/api/v1/subtitles/search",
+        },
+        /**
+         *  @description
+         *  Route downloads a subtitle for the given video file.
+         *  The subtitle is cached next to the media info of the file.
+         *  It is offered as an external track when the file is played with the transcoder, direct play, VLC or MPV.
+         */
+        DownloadSubtitle: {
+            key: "SUBTITLES-download-subtitle",
+            methods: ["POST"],
+            endpoint: "/api/v1/subtitles/download",
+        },
+        GetSubtitleTracks: {
+            key: "SUBTITLES-get-subtitle-tracks",
+            methods: ["POST"],
+            endpoint: "/api/v1/subtitles/tracks",
+        },
+        DeleteSubtitleTrack: {
+            key: "SUBTITLES-delete-subtitle-track",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/subtitles/tracks",
+        },
+    },
     SYNC: {
         SyncGetTrackedMediaItems: {
             key: "SYNC-sync-get-tracked-media-items",
@@ -1818,6 +2284,168 @@ export const API_ENDPOINTS = {
             methods: ["POST"],
             endpoint: "/api/v1/torrentstream/batch-history",
         },
+        /**
+         *  @description
+         *  Route returns the contents of the torrent streaming download directory.
+         *  This returns the torrents stored in the download directory and the total size.
+         */
+        GetTorrentstreamCache: {
+            key: "TORRENTSTREAM-get-torrentstream-cache",
+            methods: ["GET"],
+            endpoint: "/api/v1/torrentstream/cache",
+        },
+        /**
+         *  @description
+         *  Route removes a torrent from the torrent streaming download directory.
+         *  The torrent being streamed cannot be removed.
+         */
+        RemoveTorrentstreamCacheItem: {
+            key: "TORRENTSTREAM-remove-torrentstream-cache-item",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/torrentstream/cache/item",
+        },
+        /**
+         *  @description
+         *  Route removes all torrents from the torrent streaming download directory.
+         *  The torrent being streamed is kept.
+         */
+        ClearTorrentstreamCache: {
+            key: "TORRENTSTREAM-clear-torrentstream-cache",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/torrentstream/cache",
+        },
+        /**
+         *  @description
+         *  Route returns the streamed torrents that are still seeding.
+         *  Torrents are kept seeding after playback until the ratio or time target set in the settings is reached.
+         */
+        GetTorrentstreamSeeding: {
+            key: "TORRENTSTREAM-get-torrentstream-seeding",
+            methods: ["GET"],
+            endpoint: "/api/v1/torrentstream/seeding",
+        },
+        StopTorrentstreamSeeding: {
+            key: "TORRENTSTREAM-stop-torrentstream-seeding",
+            methods: ["POST"],
+            endpoint: "/api/v1/torrentstream/seeding/stop",
+        },
+    },
+    TRACKERS: {
+        /**
+         *  @description
+         *  Route returns the installed trackers.
+         *  Trackers are tracker extensions that mirror the AniList list changes to third-party services.
+         */
+        GetTrackers: {
+            key: "TRACKERS-get-trackers",
+            methods: ["GET"],
+            endpoint: "/api/v1/trackers",
+        },
+        SetTrackerEnabled: {
+            key: "TRACKERS-set-tracker-enabled",
+            methods: ["POST"],
+            endpoint: "/api/v1/trackers/{id}/enabled",
+        },
+        /**
+         *  @description
+         *  Route returns the sync log of the trackers.
+         *  It returns the latest 200 changes, newest first.
+         */
+        GetTrackerSyncItems: {
+            key: "TRACKERS-get-tracker-sync-items",
+            methods: ["GET"],
+            endpoint: "/api/v1/trackers/sync-items",
+        },
+        /**
+         *  @description
+         *  Route returns the conflict report.
+         *  These are the changes that were not sent because the tracker entry looks more recent.
+         *  'remote' holds the tracker entry at the time of the check.
+         */
+        GetTrackerConflicts: {
+            key: "TRACKERS-get-tracker-conflicts",
+            methods: ["GET"],
+            endpoint: "/api/v1/trackers/conflicts",
+        },
+        /**
+         *  @description
+         *  Route resolves a conflicting change.
+         *  If 'overwrite' is true, the change is sent anyway, otherwise it is skipped.
+         */
+        ResolveTrackerConflict: {
+            key: "TRACKERS-resolve-tracker-conflict",
+            methods: ["POST"],
+            endpoint: "/api/v1/trackers/sync-items/{id}/resolve",
+        },
+        RetryTrackerSyncItem: {
+            key: "TRACKERS-retry-tracker-sync-item",
+            methods: ["POST"],
+            endpoint: "/api/v1/trackers/sync-items/{id}/retry",
+        },
+    },
+    WEBHOOKS: {
+        GetWebhooks: {
+            key: "WEBHOOKS-get-webhooks",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks",
+        },
+        /**
+         *  @description
+         *  Route returns the names of the events webhooks can subscribe to.
+         *  The names are the hook names without the "on" prefix, e.g. "scanCompleted".
+         */
+        GetWebhookEvents: {
+            key: "WEBHOOKS-get-webhook-events",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks/events",
+        },
+        /**
+         *  @description
+         *  Route creates or updates a webhook.
+         *  The webhook is created if the ID is 0.
+         *  'events' is a comma-separated list of event names, 'headers' holds one "Key: Value" per line.
+         *  'template' is an optional Go template of the body, the data is the default JSON payload: .Event, .Timestamp and .Data.
+         */
+        SaveWebhook: {
+            key: "WEBHOOKS-save-webhook",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks",
+        },
+        DeleteWebhook: {
+            key: "WEBHOOKS-delete-webhook",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/webhooks/{id}",
+        },
+        /**
+         *  @description
+         *  Route queues a delivery of the "test" event to a webhook.
+         *  The delivery is sent even if the webhook is disabled.
+         */
+        TestWebhook: {
+            key: "WEBHOOKS-test-webhook",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks/{id}/test",
+        },
+        /**
+         *  @description
+         *  Route returns the delivery log of a webhook.
+         *  It returns the latest 200 deliveries, newest first.
+         */
+        GetWebhookDeliveries: {
+            key: "WEBHOOKS-get-webhook-deliveries",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks/{id}/deliveries",
+        },
+        /**
+         *  @description
+         *  Route queues a delivery again.
+         *  The attempts are reset and the same body is sent.
+         */
+        RedeliverWebhookDelivery: {
+            key: "WEBHOOKS-redeliver-webhook-delivery",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks/deliveries/{id}/redeliver",
+        },
     },
 } satisfies ApiEndpoints
 
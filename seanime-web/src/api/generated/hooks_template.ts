@@ -845,6 +845,24 @@
 //     })
 // }
 
+// export function useListSubtitleProviderExtensions() {
+//     return useServerQuery<Array<ExtensionRepo_SubtitleProviderExtensionItem>>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useListTrackerExtensions() {
+//     return useServerQuery<Array<ExtensionRepo_TrackerExtensionItem>>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.ListTrackerExtensions.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.ListTrackerExtensions.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.ListTrackerExtensions.key],
+//         enabled: true,
+//     })
+// }
+
 // export function useGetPluginSettings() {
 //     return useServerQuery<ExtensionRepo_StoredPluginSettingsData>({
 //         endpoint: API_ENDPOINTS.EXTENSIONS.GetPluginSettings.endpoint,
@@ -876,6 +894,57 @@
 //     })
 // }
 
+// export function useGetExtensionRuntimeMetrics() {
+//     return useServerQuery<Array<GojaRuntime_ExtensionMetrics>>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useEnableExtensionRuntime() {
+//     return useServerMutation<boolean, EnableExtensionRuntime_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetExtensionTrustedKeys() {
+//     return useServerQuery<Array<ExtensionRepo_TrustedKey>>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useAddExtensionTrustedKey() {
+//     return useServerMutation<ExtensionRepo_TrustedKey, AddExtensionTrustedKey_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useRemoveExtensionTrustedKey() {
+//     return useServerMutation<boolean, RemoveExtensionTrustedKey_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 // export function useRunExtensionPlaygroundCode() {
 //     return useServerMutation<RunPlaygroundCodeResponse, RunExtensionPlaygroundCode_Variables>({
 //         endpoint: API_ENDPOINTS.EXTENSIONS.RunExtensionPlaygroundCode.endpoint,
@@ -887,6 +956,17 @@
 //     })
 // }
 
+// export function useRunExtensionHarness() {
+//     return useServerMutation<HarnessRunResult, RunExtensionHarness_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.RunExtensionHarness.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.RunExtensionHarness.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.RunExtensionHarness.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 // export function useGetExtensionUserConfig() {
 //     return useServerQuery<ExtensionRepo_ExtensionUserConfig>({
 //         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionUserConfig.endpoint,
@@ -916,6 +996,79 @@
 //     })
 // }
 
+// export function useGetMarketplaceRepositories() {
+//     return useServerQuery<Array<ExtensionRepo_MarketplaceRepository>>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useAddMarketplaceRepository() {
+//     return useServerMutation<ExtensionRepo_MarketplaceRepository, AddMarketplaceRepository_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useRemoveMarketplaceRepository() {
+//     return useServerMutation<boolean, RemoveMarketplaceRepository_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetExtensionVersions(id: string) {
+//     return useServerQuery<ExtensionRepo_ExtensionVersions>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.methods[0],
+//         queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRollbackExtension() {
+//     return useServerMutation<ExtensionRepo_ExtensionInstallResponse, RollbackExtension_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.RollbackExtension.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.RollbackExtension.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.RollbackExtension.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function usePinExtension() {
+//     return useServerMutation<boolean, PinExtension_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.PinExtension.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.PinExtension.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.PinExtension.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useSetExtensionChannel() {
+//     return useServerMutation<boolean, SetExtensionChannel_Variables>({
+//         endpoint: API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.endpoint,
+//         method: API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.methods[0],
+//         mutationKey: [API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // filecache
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1178,6 +1331,17 @@
 //     })
 // }
 
+// export function useGetMangaMergedChapters() {
+//     return useServerMutation<Manga_MergedChapterContainer, GetMangaMergedChapters_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA.GetMangaMergedChapters.endpoint,
+//         method: API_ENDPOINTS.MANGA.GetMangaMergedChapters.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA.GetMangaMergedChapters.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 // export function useGetMangaEntryPages() {
 //     return useServerMutation<Manga_PageContainer, GetMangaEntryPages_Variables>({
 //         endpoint: API_ENDPOINTS.MANGA.GetMangaEntryPages.endpoint,
@@ -1264,6 +1428,92 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_auto_downloader
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useRunMangaAutoDownloader() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetMangaAutoDownloaderRules() {
+//     return useServerQuery<Array<Manga_AutoDownloaderRule>>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetMangaAutoDownloaderRulesByManga(id: number) {
+//     return useServerQuery<Array<Manga_AutoDownloaderRule>>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useCreateMangaAutoDownloaderRule() {
+//     return useServerMutation<Manga_AutoDownloaderRule, CreateMangaAutoDownloaderRule_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useUpdateMangaAutoDownloaderRule() {
+//     return useServerMutation<Manga_AutoDownloaderRule, UpdateMangaAutoDownloaderRule_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteMangaAutoDownloaderRule(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetMangaAutoDownloaderItems() {
+//     return useServerQuery<Array<Models_MangaAutoDownloaderItem>>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useDeleteMangaAutoDownloaderItem() {
+//     return useServerMutation<boolean, DeleteMangaAutoDownloaderItem_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.endpoint,
+//         method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // manga_download
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1363,6 +1613,201 @@
 //     })
 // }
 
+// export function useExportMangaDownloadedChapters() {
+//     return useServerMutation<Manga_ExportResult, ExportMangaDownloadedChapters_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.endpoint,
+//         method: API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useConvertMangaDownloads() {
+//     return useServerMutation<boolean, ConvertMangaDownloads_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.endpoint,
+//         method: API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_local_library
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetMangaLocalLibrary() {
+//     return useServerQuery<Array<MangaScanner_LocalSeries>>({
+//         endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.endpoint,
+//         method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useScanMangaLocalLibrary() {
+//     return useServerMutation<Array<MangaScanner_LocalSeries>>({
+//         endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.endpoint,
+//         method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useMatchMangaLocalLibrarySeries() {
+//     return useServerMutation<Array<MangaScanner_LocalSeries>, MatchMangaLocalLibrarySeries_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.endpoint,
+//         method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_mihon_import
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function usePreviewMangaMihonImport() {
+//     return useServerMutation<MangaMihon_Preview, PreviewMangaMihonImport_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.endpoint,
+//         method: API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useApplyMangaMihonImport() {
+//     return useServerMutation<MangaMihon_ApplyResult, ApplyMangaMihonImport_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.endpoint,
+//         method: API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_provider_chain
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetMangaProviderHealth() {
+//     return useServerQuery<Array<Manga_ProviderHealth>>({
+//         endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.endpoint,
+//         method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetMangaProviderChain(id: number) {
+//     return useServerQuery<Array<string>>({
+//         endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useSaveMangaProviderChain() {
+//     return useServerMutation<boolean, SaveMangaProviderChain_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.endpoint,
+//         method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_reading_state
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetMangaReadingState(id: number) {
+//     return useServerQuery<MangaReadingState_MediaReadingState>({
+//         endpoint: API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useUpdateMangaReadingPage() {
+//     return useServerMutation<Models_MangaReadingState, UpdateMangaReadingPage_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.endpoint,
+//         method: API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useMarkMangaChapters() {
+//     return useServerMutation<MangaReadingState_MediaReadingState, MarkMangaChapters_Variables>({
+//         endpoint: API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.endpoint,
+//         method: API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useResetMangaReadingState(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetMangaContinueReading() {
+//     return useServerQuery<Array<MangaReadingState_ContinueReadingItem>>({
+//         endpoint: API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.endpoint,
+//         method: API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.key],
+//         enabled: true,
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// manga_release_tracker
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetMangaReleases() {
+//     return useServerQuery<Array<MangaReleaseTracker_MediaRelease>>({
+//         endpoint: API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.endpoint,
+//         method: API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.methods[0],
+//         queryKey: [API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRunMangaReleaseTracker() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.endpoint,
+//         method: API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.methods[0],
+//         mutationKey: [API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // manual_dump
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1579,6 +2024,21 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// opds
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useCreateOpdsToken() {
+//     return useServerMutation<OpdsToken>({
+//         endpoint: API_ENDPOINTS.OPDS.CreateOpdsToken.endpoint,
+//         method: API_ENDPOINTS.OPDS.CreateOpdsToken.methods[0],
+//         mutationKey: [API_ENDPOINTS.OPDS.CreateOpdsToken.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // playback_manager
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1930,6 +2390,54 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// subtitles
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useSearchSubtitles() {
+//     return useServerMutation<Subtitles_SearchResponse, SearchSubtitles_Variables>({
+//         endpoint: API_ENDPOINTS.SUBTITLES.SearchSubtitles.endpoint,
+//         method: API_ENDPOINTS.SUBTITLES.SearchSubtitles.methods[0],
+//         mutationKey: [API_ENDPOINTS.SUBTITLES.SearchSubtitles.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDownloadSubtitle() {
+//     return useServerMutation<Subtitles_Track, DownloadSubtitle_Variables>({
+//         endpoint: API_ENDPOINTS.SUBTITLES.DownloadSubtitle.endpoint,
+//         method: API_ENDPOINTS.SUBTITLES.DownloadSubtitle.methods[0],
+//         mutationKey: [API_ENDPOINTS.SUBTITLES.DownloadSubtitle.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetSubtitleTracks() {
+//     return useServerMutation<Array<Subtitles_Track>, GetSubtitleTracks_Variables>({
+//         endpoint: API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.endpoint,
+//         method: API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.methods[0],
+//         mutationKey: [API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteSubtitleTrack() {
+//     return useServerMutation<boolean, DeleteSubtitleTrack_Variables>({
+//         endpoint: API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.endpoint,
+//         method: API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.methods[0],
+//         mutationKey: [API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // sync
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2207,3 +2715,193 @@
 //     })
 // }
 
+// export function useGetTorrentstreamCache() {
+//     return useServerQuery<Torrentstream_CacheInfo>({
+//         endpoint: API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamCache.endpoint,
+//         method: API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamCache.methods[0],
+//         queryKey: [API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamCache.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRemoveTorrentstreamCacheItem() {
+//     return useServerMutation<boolean, RemoveTorrentstreamCacheItem_Variables>({
+//         endpoint: API_ENDPOINTS.TORRENTSTREAM.RemoveTorrentstreamCacheItem.endpoint,
+//         method: API_ENDPOINTS.TORRENTSTREAM.RemoveTorrentstreamCacheItem.methods[0],
+//         mutationKey: [API_ENDPOINTS.TORRENTSTREAM.RemoveTorrentstreamCacheItem.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useClearTorrentstreamCache() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.TORRENTSTREAM.ClearTorrentstreamCache.endpoint,
+//         method: API_ENDPOINTS.TORRENTSTREAM.ClearTorrentstreamCache.methods[0],
+//         mutationKey: [API_ENDPOINTS.TORRENTSTREAM.ClearTorrentstreamCache.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetTorrentstreamSeeding() {
+//     return useServerQuery<Array<Torrentstream_SeedingTorrent>>({
+//         endpoint: API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamSeeding.endpoint,
+//         method: API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamSeeding.methods[0],
+//         queryKey: [API_ENDPOINTS.TORRENTSTREAM.GetTorrentstreamSeeding.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useStopTorrentstreamSeeding() {
+//     return useServerMutation<boolean, StopTorrentstreamSeeding_Variables>({
+//         endpoint: API_ENDPOINTS.TORRENTSTREAM.StopTorrentstreamSeeding.endpoint,
+//         method: API_ENDPOINTS.TORRENTSTREAM.StopTorrentstreamSeeding.methods[0],
+//         mutationKey: [API_ENDPOINTS.TORRENTSTREAM.StopTorrentstreamSeeding.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// trackers
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetTrackers() {
+//     return useServerQuery<Array<Tracker_TrackerItem>>({
+//         endpoint: API_ENDPOINTS.TRACKERS.GetTrackers.endpoint,
+//         method: API_ENDPOINTS.TRACKERS.GetTrackers.methods[0],
+//         queryKey: [API_ENDPOINTS.TRACKERS.GetTrackers.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useSetTrackerEnabled(id: string) {
+//     return useServerMutation<boolean, SetTrackerEnabled_Variables>({
+//         endpoint: API_ENDPOINTS.TRACKERS.SetTrackerEnabled.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.TRACKERS.SetTrackerEnabled.methods[0],
+//         mutationKey: [API_ENDPOINTS.TRACKERS.SetTrackerEnabled.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetTrackerSyncItems() {
+//     return useServerQuery<Array<Tracker_SyncItem>>({
+//         endpoint: API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.endpoint,
+//         method: API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.methods[0],
+//         queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetTrackerConflicts() {
+//     return useServerQuery<Array<Tracker_SyncItem>>({
+//         endpoint: API_ENDPOINTS.TRACKERS.GetTrackerConflicts.endpoint,
+//         method: API_ENDPOINTS.TRACKERS.GetTrackerConflicts.methods[0],
+//         queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerConflicts.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useResolveTrackerConflict(id: number) {
+//     return useServerMutation<boolean, ResolveTrackerConflict_Variables>({
+//         endpoint: API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.methods[0],
+//         mutationKey: [API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useRetryTrackerSyncItem(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.methods[0],
+//         mutationKey: [API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// webhooks
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetWebhooks() {
+//     return useServerQuery<Array<Models_Webhook>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhooks.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhooks.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhooks.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetWebhookEvents() {
+//     return useServerQuery<Array<string>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useSaveWebhook() {
+//     return useServerMutation<Models_Webhook, SaveWebhook_Variables>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.SaveWebhook.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.SaveWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.SaveWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteWebhook(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.DeleteWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useTestWebhook(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.TestWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.TestWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.TestWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetWebhookDeliveries(id: number) {
+//     return useServerQuery<Array<Models_WebhookDelivery>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRedeliverWebhookDelivery(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
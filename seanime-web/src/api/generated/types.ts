@@ -2208,10 +2208,15 @@ export type Extension_Extension = {
      * NOT IMPLEMENTED
      */
     permissions?: Array<string>
+    network?: Extension_NetworkPermissions
     userConfig?: Extension_UserConfig
     payload: string
     payloadURI?: string
     plugin?: Extension_PluginManifest
+    channels?: Record<string, string>
+    signature?: Extension_Signature
+    signatureStatus?: Extension_SignatureStatus
+    repository?: string
     isDevelopment?: boolean
 }
 
@@ -2246,7 +2251,22 @@ export type Extension_InvalidExtensionErrorCode = "invalid_manifest" |
  * - Filename: extension.go
  * - Package: extension
  */
-export type Extension_Language = "javascript" | "typescript" | "go"
+export type Extension_Language = "javascript" | "typescript" | "go" | "wasm"
+
+/**
+ * - Filepath: internal/extension/network.go
+ * - Filename: network.go
+ * - Package: extension
+ * @description
+ *  NetworkPermissions restricts the hosts an extension can reach with fetch.
+ *  
+ *  Extensions that don't declare network permissions can reach any public host.
+ *  Private, loopback and link-local addresses are always blocked unless they're covered by AllowedCIDRs.
+ */
+export type Extension_NetworkPermissions = {
+    allowedDomains?: Array<string>
+    allowedCIDRs?: Array<string>
+}
 
 /**
  * - Filepath: internal/extension/plugin.go
@@ -2300,12 +2320,43 @@ export type Extension_SavedUserConfig = {
     values?: Record<string, string>
 }
 
+/**
+ * - Filepath: internal/extension/signature.go
+ * - Filename: signature.go
+ * - Package: extension
+ * @description
+ *  Signature is an ed25519 signature of the extension, made by the publisher.
+ *  
+ *  The signed message is built by GetSignedMessage:
+ *  
+ *  seanime-extension-v2\n{hex sha256 of the payload}\n{canonical manifest}
+ *  
+ *  The canonical manifest is the whole manifest without "signature" and with an empty "payload",
+ *  encoded as compact JSON with sorted keys, no HTML escaping and empty optional fields omitted.
+ */
+export type Extension_Signature = {
+    publicKey: string
+    value: string
+}
+
+/**
+ * - Filepath: internal/extension/signature.go
+ * - Filename: signature.go
+ * - Package: extension
+ */
+export type Extension_SignatureStatus = "unsigned" | "verified" | "untrusted" | "invalid"
+
 /**
  * - Filepath: internal/extension/extension.go
  * - Filename: extension.go
  * - Package: extension
  */
-export type Extension_Type = "anime-torrent-provider" | "manga-provider" | "onlinestream-provider" | "plugin"
+export type Extension_Type = "anime-torrent-provider" |
+    "manga-provider" |
+    "onlinestream-provider" |
+    "subtitle-provider" |
+    "tracker" |
+    "plugin"
 
 /**
  * - Filepath: internal/extension/extension.go
@@ -2322,6 +2373,33 @@ export type Extension_UserConfig = {
 // ExtensionPlayground
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+/**
+ * - Filepath: internal/extension_playground/harness.go
+ * - Filename: harness.go
+ * - Package: extension_playground
+ */
+export type HarnessCaseResult = {
+    name: string
+    method: string
+    passed: boolean
+    output: string
+    expected?: string
+    difference?: string
+}
+
+/**
+ * - Filepath: internal/extension_playground/harness.go
+ * - Filename: harness.go
+ * - Package: extension_playground
+ */
+export type HarnessRunResult = {
+    extensionId: string
+    mode?: FetchFixtureMode
+    passed: number
+    failed: number
+    cases?: Array<HarnessCaseResult>
+}
+
 /**
  * - Filepath: internal/extension_playground/playground.go
  * - Filename: playground.go
@@ -2376,6 +2454,16 @@ export type ExtensionRepo_AnimeTorrentProviderExtensionItem = {
     settings?: HibikeTorrent_AnimeProviderSettings
 }
 
+/**
+ * - Filepath: internal/extension_repo/versions.go
+ * - Filename: versions.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_ExtensionHistoryEntry = {
+    version: string
+    replacedAt?: string
+}
+
 /**
  * - Filepath: internal/extension_repo/external.go
  * - Filename: external.go
@@ -2395,6 +2483,31 @@ export type ExtensionRepo_ExtensionUserConfig = {
     savedUserConfig?: Extension_SavedUserConfig
 }
 
+/**
+ * - Filepath: internal/extension_repo/versions.go
+ * - Filename: versions.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_ExtensionVersions = {
+    id: string
+    version: string
+    repository: string
+    channel: string
+    /**
+     * Channels offered by the installed version
+     */
+    channels?: Array<string>
+    pinnedVersion: string
+    /**
+     * Manifest URI of the selected channel
+     */
+    updateManifestURI: string
+    /**
+     * Newest first
+     */
+    history?: Array<ExtensionRepo_ExtensionHistoryEntry>
+}
+
 /**
  * - Filepath: internal/extension_repo/repository.go
  * - Filename: repository.go
@@ -2410,6 +2523,18 @@ export type ExtensionRepo_MangaProviderExtensionItem = {
     settings?: HibikeManga_Settings
 }
 
+/**
+ * - Filepath: internal/extension_repo/marketplace.go
+ * - Filename: marketplace.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_MarketplaceRepository = {
+    name: string
+    url: string
+    addedAt?: string
+    isDefault: boolean
+}
+
 /**
  * - Filepath: internal/extension_repo/repository.go
  * - Filename: repository.go
@@ -2439,6 +2564,45 @@ export type ExtensionRepo_StoredPluginSettingsData = {
     pluginGrantedPermissions?: Record<string, string>
 }
 
+/**
+ * - Filepath: internal/extension_repo/repository.go
+ * - Filename: repository.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_SubtitleProviderExtensionItem = {
+    id: string
+    name: string
+    /**
+     * ISO 639-1 language code
+     */
+    lang: string
+    settings?: HibikeSubtitle_Settings
+}
+
+/**
+ * - Filepath: internal/extension_repo/repository.go
+ * - Filename: repository.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_TrackerExtensionItem = {
+    id: string
+    name: string
+    icon: string
+    settings?: HibikeTracker_Settings
+}
+
+/**
+ * - Filepath: internal/extension_repo/signature.go
+ * - Filename: signature.go
+ * - Package: extension_repo
+ */
+export type ExtensionRepo_TrustedKey = {
+    name: string
+    publicKey: string
+    fingerprint: string
+    addedAt?: string
+}
+
 /**
  * - Filepath: internal/extension_repo/repository.go
  * - Filename: repository.go
@@ -2450,6 +2614,64 @@ export type ExtensionRepo_UpdateData = {
     version: string
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// GojaBindings
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/goja/goja_bindings/fetch_fixtures.go
+ * - Filename: fetch_fixtures.go
+ * - Package: goja_bindings
+ */
+export type FetchFixtureMode = "record" | "replay"
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// GojaRuntime
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/goja/goja_runtime/goja_runtime_manager.go
+ * - Filename: goja_runtime_manager.go
+ * - Package: goja_runtime
+ * @description
+ *  ExtensionMetrics are the runtime metrics of an extension.
+ */
+export type GojaRuntime_ExtensionMetrics = {
+    extensionId: string
+    pool?: Record<string, number>
+    calls: number
+    errors: number
+    timeouts: number
+    violations: number
+    /**
+     * Calls during which the heap grew over the advisory limit
+     */
+    heapWarnings: number
+    activeCalls: number
+    pendingFetches: number
+    avgLatencyMs: number
+    maxLatencyMs: number
+    disabled: boolean
+    disabledReason?: string
+    limits: GojaRuntime_Limits
+}
+
+/**
+ * - Filepath: internal/goja/goja_runtime/limiter.go
+ * - Filename: limiter.go
+ * - Package: goja_runtime
+ * @description
+ *  Limits are the resource limits of an extension.
+ *  A zero value disables the corresponding limit.
+ */
+export type GojaRuntime_Limits = {
+    maxCallDuration?: Duration
+    maxConcurrentCalls: number
+    maxPendingFetches: number
+    maxHeapGrowth: number
+    maxViolations: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Handlers
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2510,6 +2732,17 @@ export type MalAuthResponse = {
     token_type: string
 }
 
+/**
+ * - Filepath: internal/handlers/opds.go
+ * - Filename: opds.go
+ * - Package: handlers
+ */
+export type OpdsToken = {
+    token: string
+    path: string
+    expiresAt?: string
+}
+
 /**
  * - Filepath: internal/handlers/docs.go
  * - Filename: docs.go
@@ -2683,6 +2916,37 @@ export type HibikeOnlinestream_SearchResult = {
  */
 export type HibikeOnlinestream_SubOrDub = "sub" | "dub" | "both"
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Hibikesubtitle
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/extension/hibike/subtitle/types.go
+ * - Filename: types.go
+ * - Package: hibikesubtitle
+ */
+export type HibikeSubtitle_SearchResult = {
+    provider: string
+    id: string
+    name: string
+    language: string
+    format: string
+    episodeNumber?: number
+    url?: string
+    downloads?: number
+    isFilenameMatch?: boolean
+}
+
+/**
+ * - Filepath: internal/extension/hibike/subtitle/types.go
+ * - Filename: types.go
+ * - Package: hibikesubtitle
+ */
+export type HibikeSubtitle_Settings = {
+    languages?: Array<string>
+    supportsFilenameSearch: boolean
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Hibiketorrent
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2739,10 +3003,86 @@ export type HibikeTorrent_AnimeTorrent = {
     confirmed: boolean
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Hibiketracker
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/extension/hibike/tracker/types.go
+ * - Filename: types.go
+ * - Package: hibiketracker
+ */
+export type HibikeTracker_Entry = {
+    status: HibikeTracker_Status
+    progress: number
+    score: number
+    startedAt?: HibikeTracker_FuzzyDate
+    completedAt?: HibikeTracker_FuzzyDate
+}
+
+/**
+ * - Filepath: internal/extension/hibike/tracker/types.go
+ * - Filename: types.go
+ * - Package: hibiketracker
+ */
+export type HibikeTracker_FuzzyDate = {
+    year?: number
+    month?: number
+    day?: number
+}
+
+/**
+ * - Filepath: internal/extension/hibike/tracker/types.go
+ * - Filename: types.go
+ * - Package: hibiketracker
+ */
+export type HibikeTracker_Settings = {
+    supportsAnime: boolean
+    supportsManga: boolean
+}
+
+/**
+ * - Filepath: internal/extension/hibike/tracker/types.go
+ * - Filename: types.go
+ * - Package: hibiketracker
+ */
+export type HibikeTracker_Status = "current" |
+    "planning" |
+    "completed" |
+    "dropped" |
+    "paused" |
+    "repeating"
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Manga
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+/**
+ * - Filepath: internal/manga/autodownloader_rule.go
+ * - Filename: autodownloader_rule.go
+ * - Package: manga
+ */
+export type Manga_AutoDownloaderRule = {
+    /**
+     * Will be set when fetched from the database
+     */
+    dbId: number
+    enabled: boolean
+    mediaId: number
+    provider: string
+    scanlators?: Array<string>
+    languages?: Array<string>
+    chapterType: Manga_AutoDownloaderRuleChapterType
+    baselineChapter?: number
+}
+
+/**
+ * - Filepath: internal/manga/autodownloader_rule.go
+ * - Filename: autodownloader_rule.go
+ * - Package: manga
+ */
+export type Manga_AutoDownloaderRuleChapterType = "new" | "after-progress"
+
 /**
  * - Filepath: internal/manga/chapter_container.go
  * - Filename: chapter_container.go
@@ -2754,6 +3094,16 @@ export type Manga_ChapterContainer = {
     chapters?: Array<HibikeManga_ChapterDetails>
 }
 
+/**
+ * - Filepath: internal/manga/chapter_merge.go
+ * - Filename: chapter_merge.go
+ * - Package: manga
+ */
+export type Manga_ChapterGap = {
+    start: number
+    end: number
+}
+
 /**
  * - Filepath: internal/manga/collection.go
  * - Filename: collection.go
@@ -2824,6 +3174,22 @@ export type Manga_EntryListData = {
     completedAt?: string
 }
 
+/**
+ * - Filepath: internal/manga/export.go
+ * - Filename: export.go
+ * - Package: manga
+ */
+export type Manga_ExportFormat = "cbz" | "epub"
+
+/**
+ * - Filepath: internal/manga/export.go
+ * - Filename: export.go
+ * - Package: manga
+ */
+export type Manga_ExportResult = {
+    files?: Array<string>
+}
+
 /**
  * - Filepath: internal/manga/chapter_container.go
  * - Filename: chapter_container.go
@@ -2855,6 +3221,29 @@ export type Manga_MediaDownloadData = {
     queued: Manga_ProviderDownloadMap
 }
 
+/**
+ * - Filepath: internal/manga/chapter_merge.go
+ * - Filename: chapter_merge.go
+ * - Package: manga
+ */
+export type Manga_MergedChapter = {
+    number: string
+    chapter?: HibikeManga_ChapterDetails
+    alternatives?: Array<HibikeManga_ChapterDetails>
+}
+
+/**
+ * - Filepath: internal/manga/chapter_merge.go
+ * - Filename: chapter_merge.go
+ * - Package: manga
+ */
+export type Manga_MergedChapterContainer = {
+    mediaId: number
+    providers?: Array<string>
+    chapters?: Array<Manga_MergedChapter>
+    gaps?: Array<Manga_ChapterGap>
+}
+
 /**
  * - Filepath: internal/manga/chapter_page_container.go
  * - Filename: chapter_page_container.go
@@ -2902,6 +3291,162 @@ export type Manga_ProviderDownloadMapChapterInfo = {
     chapterNumber: string
 }
 
+/**
+ * - Filepath: internal/manga/provider_health.go
+ * - Filename: provider_health.go
+ * - Package: manga
+ */
+export type Manga_ProviderHealth = {
+    provider: string
+    requests: number
+    failures: number
+    successRate: number
+    averageLatency: number
+    lastError: string
+    lastErrorAt?: string
+    lastSuccessAt?: string
+    healthy: boolean
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// MangaMihon
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/manga/mihon/importer.go
+ * - Filename: importer.go
+ * - Package: manga_mihon
+ */
+export type MangaMihon_ApplyEntry = {
+    mediaId: number
+    progress: number
+    totalChapters: number
+    provider: string
+    providerMangaId: string
+}
+
+/**
+ * - Filepath: internal/manga/mihon/importer.go
+ * - Filename: importer.go
+ * - Package: manga_mihon
+ */
+export type MangaMihon_ApplyResult = {
+    progressUpdated: number
+    mapped: number
+    errors?: Array<string>
+}
+
+/**
+ * - Filepath: internal/manga/mihon/importer.go
+ * - Filename: importer.go
+ * - Package: manga_mihon
+ */
+export type MangaMihon_MatchType = "" | "tracker" | "collection" | "search" | "url"
+
+/**
+ * - Filepath: internal/manga/mihon/importer.go
+ * - Filename: importer.go
+ * - Package: manga_mihon
+ */
+export type MangaMihon_Preview = {
+    entries?: Array<MangaMihon_PreviewEntry>
+}
+
+/**
+ * - Filepath: internal/manga/mihon/importer.go
+ * - Filename: importer.go
+ * - Package: manga_mihon
+ */
+export type MangaMihon_PreviewEntry = {
+    title: string
+    sourceName: string
+    url: string
+    readChapters: number
+    progress: number
+    mediaId: number
+    media?: AL_BaseManga
+    matchedBy: MangaMihon_MatchType
+    currentProgress: number
+    inCollection: boolean
+    provider: string
+    providerMangaId: string
+    providerMatchedBy: MangaMihon_MatchType
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// MangaReadingstate
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/manga/readingstate/readingstate.go
+ * - Filename: readingstate.go
+ * - Package: manga_readingstate
+ */
+export type MangaReadingState_ContinueReadingItem = {
+    state?: Models_MangaReadingState
+    media?: AL_BaseManga
+}
+
+/**
+ * - Filepath: internal/manga/readingstate/readingstate.go
+ * - Filename: readingstate.go
+ * - Package: manga_readingstate
+ */
+export type MangaReadingState_MarkChapterOptions = {
+    chapterId: string
+    chapterNumber: string
+}
+
+/**
+ * - Filepath: internal/manga/readingstate/readingstate.go
+ * - Filename: readingstate.go
+ * - Package: manga_readingstate
+ */
+export type MangaReadingState_MediaReadingState = {
+    mediaId: number
+    chapters?: Array<Models_MangaReadingState>
+    resume?: Models_MangaReadingState
+    highestReadChapter: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// MangaReleasetracker
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/manga/releasetracker/releasetracker.go
+ * - Filename: releasetracker.go
+ * - Package: manga_releasetracker
+ */
+export type MangaReleaseTracker_MediaRelease = {
+    mediaId: number
+    seriesId: number
+    seriesTitle: string
+    seriesUrl: string
+    latestChapter: number
+    latestGroup: string
+    latestReleaseDate: string
+    newChapters: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// MangaScanner
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/manga/scanner/library.go
+ * - Filename: library.go
+ * - Package: manga_scanner
+ */
+export type MangaScanner_LocalSeries = {
+    seriesKey: string
+    seriesTitle: string
+    mediaId: number
+    locked: boolean
+    chapterCount: number
+    unsupportedChapterCount: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Mediastream
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -3134,6 +3679,51 @@ export type Models_ListSyncSettings = {
     origin: string
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ */
+export type Models_MangaAutoDownloaderItem = {
+    ruleId: number
+    mediaId: number
+    provider: string
+    chapterId: string
+    chapterNumber: string
+    scanlator: string
+    language: string
+    downloaded: boolean
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  MangaReadingState stores the reading state of a chapter for a user.
+ *  There is only one entry per user, media, provider and chapter.
+ */
+export type Models_MangaReadingState = {
+    username: string
+    mediaId: number
+    provider: string
+    chapterId: string
+    chapterNumber: string
+    /**
+     * Index of the last page read, starting from 0
+     */
+    currentPage: number
+    totalPages: number
+    read: boolean
+    readAt?: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
 /**
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
@@ -3142,6 +3732,34 @@ export type Models_ListSyncSettings = {
 export type Models_MangaSettings = {
     defaultMangaProvider: string
     mangaAutoUpdateProgress: boolean
+    /**
+     * "images" (default) or "cbz"
+     */
+    mangaDownloadFormat: string
+    mangaAutoDownloaderEnabled: boolean
+    /**
+     * In minutes
+     */
+    mangaAutoDownloaderInterval: number
+    mangaLocalLibraryPath: string
+    mangaReleaseTrackerEnabled: boolean
+    /**
+     * In minutes
+     */
+    mangaReleaseTrackerInterval: number
+    mangaPageProcessingEnabled: boolean
+    /**
+     * "" (original) or "jpeg"
+     */
+    mangaPageFormat: string
+    /**
+     * JPEG quality, 1-100
+     */
+    mangaPageQuality: number
+    mangaPageMaxWidth: number
+    mangaPageSplitDoublePages: boolean
+    mangaPageSplitLeftToRight: boolean
+    mangaPageTrimBorders: boolean
 }
 
 /**
@@ -3151,7 +3769,7 @@ export type Models_MangaSettings = {
  */
 export type Models_MediaPlayerSettings = {
     /**
-     * "vlc" or "mpc-hc"
+     * "vlc", "mpc-hc", "mpv" or "kodi"
      */
     defaultPlayer: string
     host: string
@@ -3163,6 +3781,13 @@ export type Models_MediaPlayerSettings = {
     mpcPath: string
     mpvSocket: string
     mpvPath: string
+    kodiPort: number
+    kodiUsername: string
+    kodiPassword: string
+    /**
+     * "local=remote" pairs separated by new lines
+     */
+    kodiPathMappings: string
 }
 
 /**
@@ -3324,6 +3949,116 @@ export type Models_TorrentstreamSettings = {
     includeInLibrary: boolean
     streamUrlAddress: string
     slowSeeding: boolean
+    prefetchNextEpisode: boolean
+    /**
+     * Percentage of the current episode watched before prefetching
+     */
+    prefetchThreshold: number
+    /**
+     * Size in MB of the beginning of the next episode to download
+     */
+    prefetchSize: number
+    /**
+     * Maximum size in GB of the download directory, 0 clears the directory on startup
+     */
+    cacheMaxSize: number
+    cacheKeepCompleted: boolean
+    /**
+     * KiB/s, 0 means unlimited
+     */
+    downloadRateLimit: number
+    /**
+     * KiB/s, 0 means unlimited
+     */
+    uploadRateLimit: number
+    /**
+     * Maximum connections per torrent, 0 means default
+     */
+    maxConnections: number
+    bandwidthScheduleEnabled: boolean
+    /**
+     * e.g. "09:00"
+     */
+    bandwidthScheduleStart: string
+    /**
+     * e.g. "17:00"
+     */
+    bandwidthScheduleEnd: string
+    /**
+     * Comma-separated days of the week (0 is Sunday), empty means every day
+     */
+    bandwidthScheduleDays: string
+    scheduledDownloadRateLimit: number
+    scheduledUploadRateLimit: number
+    /**
+     * Keep seeding streamed torrents until this ratio is reached, 0 means no ratio target
+     */
+    seedRatioLimit: number
+    /**
+     * Keep seeding streamed torrents for this many minutes, 0 means no time target
+     */
+    seedTimeLimit: number
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  Webhook sends the hook events it subscribes to, to a URL.
+ */
+export type Models_Webhook = {
+    name: string
+    enabled: boolean
+    url: string
+    /**
+     * Comma-separated event names, e.g. "scanCompleted,autoDownloaderAfterDownloadTorrent"
+     */
+    events: string
+    /**
+     * Optional, used to sign the body with HMAC-SHA256
+     */
+    secret: string
+    /**
+     * Optional Go template of the body
+     */
+    template: string
+    /**
+     * Optional additional headers, one "Key: Value" per line
+     */
+    headers: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  WebhookDelivery is a webhook request, queued or sent.
+ */
+export type Models_WebhookDelivery = {
+    webhookId: number
+    event: string
+    body: string
+    contentType: string
+    /**
+     * "pending", "success" or "failed"
+     */
+    status: string
+    attempts: number
+    nextAttemptAt?: string
+    responseStatus: number
+    /**
+     * Truncated response body
+     */
+    response: string
+    error: string
     id: number
     createdAt?: string
     updatedAt?: string
@@ -3491,6 +4226,37 @@ export type Report_UnlockedLocalFile = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Subtitles
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/subtitles/repository.go
+ * - Filename: repository.go
+ * - Package: subtitles
+ */
+export type Subtitles_SearchResponse = {
+    results?: Array<HibikeSubtitle_SearchResult>
+    errors?: Record<string, string>
+}
+
+/**
+ * - Filepath: internal/subtitles/cache.go
+ * - Filename: cache.go
+ * - Package: subtitles
+ */
+export type Subtitles_Track = {
+    filename: string
+    provider: string
+    name: string
+    /**
+     * ISO 639-1 language code
+     */
+    language: string
+    format: string
+    addedAt?: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Summary
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -3691,6 +4457,41 @@ export type Torrentstream_BatchHistoryResponse = {
     torrent?: HibikeTorrent_AnimeTorrent
 }
 
+/**
+ * - Filepath: internal/torrentstream/cache.go
+ * - Filename: cache.go
+ * - Package: torrentstream
+ */
+export type Torrentstream_CacheInfo = {
+    enabled: boolean
+    downloadDir: string
+    totalSize: number
+    maxSize: number
+    items?: Array<Torrentstream_CacheItem>
+}
+
+/**
+ * - Filepath: internal/torrentstream/cache.go
+ * - Filename: cache.go
+ * - Package: torrentstream
+ */
+export type Torrentstream_CacheItem = {
+    infoHash: string
+    name: string
+    mediaId: number
+    episodeNumber: number
+    size: number
+    /**
+     * Whether the streamed file was fully downloaded
+     */
+    completed: boolean
+    /**
+     * Whether the torrent is added to the torrent client
+     */
+    isActive: boolean
+    lastStreamedAt?: string
+}
+
 /**
  * - Filepath: internal/torrentstream/list.go
  * - Filename: list.go
@@ -3723,6 +4524,24 @@ export type Torrentstream_FilePreview = {
  */
 export type Torrentstream_PlaybackType = "default" | "externalPlayerLink"
 
+/**
+ * - Filepath: internal/torrentstream/seeding.go
+ * - Filename: seeding.go
+ * - Package: torrentstream
+ */
+export type Torrentstream_SeedingTorrent = {
+    infoHash: string
+    name: string
+    mediaId: number
+    episodeNumber: number
+    uploaded: number
+    downloaded: number
+    ratio: number
+    peers: number
+    seedingSince?: string
+    targetProgress: number
+}
+
 /**
  * - Filepath: internal/torrentstream/events.go
  * - Filename: events.go
@@ -3760,6 +4579,60 @@ export type Torrentstream_TorrentStatus = {
     seeders: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Tracker
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/tracker/tracker.go
+ * - Filename: tracker.go
+ * - Package: tracker
+ */
+export type Tracker_Changes = {
+    status?: HibikeTracker_Status
+    progress?: number
+    /**
+     * 0 to 100
+     */
+    score?: number
+    startedAt?: HibikeTracker_FuzzyDate
+    completedAt?: HibikeTracker_FuzzyDate
+}
+
+/**
+ * - Filepath: internal/tracker/tracker.go
+ * - Filename: tracker.go
+ * - Package: tracker
+ */
+export type Tracker_SyncItem = {
+    id: number
+    trackerId: string
+    mediaId: number
+    status: string
+    attempts: number
+    nextAttemptAt?: string
+    error?: string
+    updatedAt?: string
+    changes?: Tracker_Changes
+    /**
+     * Entry on the tracker, set for conflicts
+     */
+    remote?: HibikeTracker_Entry
+}
+
+/**
+ * - Filepath: internal/tracker/tracker.go
+ * - Filename: tracker.go
+ * - Package: tracker
+ */
+export type Tracker_TrackerItem = {
+    id: string
+    name: string
+    icon: string
+    enabled: boolean
+    settings?: HibikeTracker_Settings
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Tvdb
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
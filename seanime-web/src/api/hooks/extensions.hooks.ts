@@ -1,12 +1,20 @@
 import { useServerMutation, useServerQuery } from "@/api/client/requests"
 import {
+    AddExtensionTrustedKey_Variables,
+    AddMarketplaceRepository_Variables,
+    EnableExtensionRuntime_Variables,
     FetchExternalExtensionData_Variables,
     GetAllExtensions_Variables,
     GrantPluginPermissions_Variables,
     InstallExternalExtension_Variables,
+    PinExtension_Variables,
     ReloadExternalExtension_Variables,
+    RemoveExtensionTrustedKey_Variables,
+    RemoveMarketplaceRepository_Variables,
+    RollbackExtension_Variables,
     RunExtensionPlaygroundCode_Variables,
     SaveExtensionUserConfig_Variables,
+    SetExtensionChannel_Variables,
     SetPluginSettingsPinnedTrays_Variables,
     UninstallExternalExtension_Variables,
     UpdateExtensionCode_Variables,
@@ -18,10 +26,15 @@ import {
     ExtensionRepo_AnimeTorrentProviderExtensionItem,
     ExtensionRepo_ExtensionInstallResponse,
     ExtensionRepo_ExtensionUserConfig,
+    ExtensionRepo_ExtensionVersions,
     ExtensionRepo_MangaProviderExtensionItem,
+    ExtensionRepo_MarketplaceRepository,
     ExtensionRepo_OnlinestreamProviderExtensionItem,
     ExtensionRepo_StoredPluginSettingsData,
+    ExtensionRepo_SubtitleProviderExtensionItem,
+    ExtensionRepo_TrustedKey,
     ExtensionRepo_UpdateData,
+    GojaRuntime_ExtensionMetrics,
     Nullish,
     RunPlaygroundCodeResponse,
 } from "@/api/generated/types"
@@ -143,6 +156,15 @@ export function useAnimeListTorrentProviderExtensions() {
     })
 }
 
+export function useListSubtitleProviderExtensions() {
+    return useServerQuery<Array<ExtensionRepo_SubtitleProviderExtensionItem>>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.methods[0],
+        queryKey: [API_ENDPOINTS.EXTENSIONS.ListSubtitleProviderExtensions.key],
+        enabled: true,
+    })
+}
+
 export function useRunExtensionPlaygroundCode() {
     return useServerMutation<RunPlaygroundCodeResponse, RunExtensionPlaygroundCode_Variables>({
         endpoint: API_ENDPOINTS.EXTENSIONS.RunExtensionPlaygroundCode.endpoint,
@@ -234,6 +256,66 @@ export function useGrantPluginPermissions() {
     })
 }
 
+export function useGetExtensionRuntimeMetrics(enabled: boolean) {
+    return useServerQuery<Array<GojaRuntime_ExtensionMetrics>>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.methods[0],
+        queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.key],
+        enabled: enabled,
+        refetchInterval: enabled ? 5000 : false,
+    })
+}
+
+export function useEnableExtensionRuntime() {
+    const queryClient = useQueryClient()
+    return useServerMutation<boolean, EnableExtensionRuntime_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.EnableExtensionRuntime.key],
+        onSuccess: async () => {
+            toast.success("Extension enabled")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionRuntimeMetrics.key] })
+        },
+    })
+}
+
+export function useGetExtensionTrustedKeys() {
+    return useServerQuery<Array<ExtensionRepo_TrustedKey>>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.methods[0],
+        queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.key],
+        enabled: true,
+    })
+}
+
+export function useAddExtensionTrustedKey() {
+    const queryClient = useQueryClient()
+    return useServerMutation<ExtensionRepo_TrustedKey, AddExtensionTrustedKey_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.AddExtensionTrustedKey.key],
+        onSuccess: async () => {
+            toast.success("Key added")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetAllExtensions.key] })
+        },
+    })
+}
+
+export function useRemoveExtensionTrustedKey() {
+    const queryClient = useQueryClient()
+    return useServerMutation<boolean, RemoveExtensionTrustedKey_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.RemoveExtensionTrustedKey.key],
+        onSuccess: async () => {
+            toast.success("Key removed")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionTrustedKeys.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetAllExtensions.key] })
+        },
+    })
+}
+
 export function useGetMarketplaceExtensions(marketplaceUrl?: string) {
     const url = marketplaceUrl ? `?marketplace=${encodeURIComponent(marketplaceUrl)}` : ""
     return useServerQuery<Array<Extension_Extension>>({
@@ -252,3 +334,91 @@ export function useGetExtensionUpdateData() {
         enabled: true,
     })
 }
+
+export function useGetMarketplaceRepositories() {
+    return useServerQuery<Array<ExtensionRepo_MarketplaceRepository>>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.methods[0],
+        queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.key],
+        enabled: true,
+    })
+}
+
+export function useAddMarketplaceRepository() {
+    const queryClient = useQueryClient()
+    return useServerMutation<ExtensionRepo_MarketplaceRepository, AddMarketplaceRepository_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.AddMarketplaceRepository.key],
+        onSuccess: async () => {
+            toast.success("Repository added")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceExtensions.key] })
+        },
+    })
+}
+
+export function useRemoveMarketplaceRepository() {
+    const queryClient = useQueryClient()
+    return useServerMutation<boolean, RemoveMarketplaceRepository_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.RemoveMarketplaceRepository.key],
+        onSuccess: async () => {
+            toast.success("Repository removed")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceRepositories.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetMarketplaceExtensions.key] })
+        },
+    })
+}
+
+export function useGetExtensionVersions(id: Nullish<string>) {
+    return useServerQuery<ExtensionRepo_ExtensionVersions>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.methods[0],
+        queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.key, id],
+        enabled: !!id,
+    })
+}
+
+export function useRollbackExtension() {
+    const queryClient = useQueryClient()
+    return useServerMutation<ExtensionRepo_ExtensionInstallResponse, RollbackExtension_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.RollbackExtension.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.RollbackExtension.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.RollbackExtension.key],
+        onSuccess: async (data) => {
+            toast.success(data?.message ?? "Extension rolled back")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.key] })
+            // DEVNOTE: No need to refetch the extensions, the websocket listener will do it
+        },
+    })
+}
+
+export function usePinExtension() {
+    const queryClient = useQueryClient()
+    return useServerMutation<boolean, PinExtension_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.PinExtension.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.PinExtension.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.PinExtension.key],
+        onSuccess: async (_, variables) => {
+            toast.success(variables.version ? `Pinned to ${variables.version}` : "Extension unpinned")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetAllExtensions.key] })
+        },
+    })
+}
+
+export function useSetExtensionChannel() {
+    const queryClient = useQueryClient()
+    return useServerMutation<boolean, SetExtensionChannel_Variables>({
+        endpoint: API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.endpoint,
+        method: API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.methods[0],
+        mutationKey: [API_ENDPOINTS.EXTENSIONS.SetExtensionChannel.key],
+        onSuccess: async () => {
+            toast.success("Update channel changed")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionVersions.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.EXTENSIONS.GetAllExtensions.key] })
+        },
+    })
+}
@@ -6,6 +6,7 @@ import {
     GetMangaEntryChapters_Variables,
     GetMangaEntryPages_Variables,
     GetMangaMapping_Variables,
+    GetMangaMergedChapters_Variables,
     MangaManualMapping_Variables,
     MangaManualSearch_Variables,
     RefetchMangaChapterContainers_Variables,
@@ -23,6 +24,7 @@ import {
     Manga_Entry,
     Manga_MangaLatestChapterNumberItem,
     Manga_MappingResponse,
+    Manga_MergedChapterContainer,
     Manga_PageContainer,
     Nullish,
 } from "@/api/generated/types"
@@ -85,6 +87,7 @@ export function useEmptyMangaEntryCache() {
         onSuccess: async () => {
             await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryChapters.key] })
             await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryPages.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaMergedChapters.key] })
         },
     })
 }
@@ -99,6 +102,16 @@ export function useGetMangaEntryChapters(variables: Partial<GetMangaEntryChapter
     })
 }
 
+export function useGetMangaMergedChapters(variables: Partial<GetMangaMergedChapters_Variables>, enabled: boolean) {
+    return useServerQuery<Manga_MergedChapterContainer, GetMangaMergedChapters_Variables>({
+        endpoint: API_ENDPOINTS.MANGA.GetMangaMergedChapters.endpoint,
+        method: API_ENDPOINTS.MANGA.GetMangaMergedChapters.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA.GetMangaMergedChapters.key, String(variables.mediaId), variables.provider, variables.scanlators, variables.languages],
+        data: variables as GetMangaMergedChapters_Variables,
+        enabled: enabled && !!variables.mediaId && !!variables.provider,
+    })
+}
+
 export function useGetMangaEntryPages(variables: Partial<GetMangaEntryPages_Variables>) {
     return useServerQuery<Manga_PageContainer, GetMangaEntryPages_Variables>({
         endpoint: API_ENDPOINTS.MANGA.GetMangaEntryPages.endpoint,
@@ -0,0 +1,112 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import {
+    CreateMangaAutoDownloaderRule_Variables,
+    DeleteMangaAutoDownloaderItem_Variables,
+    UpdateMangaAutoDownloaderRule_Variables,
+} from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { Manga_AutoDownloaderRule, Models_MangaAutoDownloaderItem, Nullish } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useRunMangaAutoDownloader() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.RunMangaAutoDownloader.key],
+        onSuccess: async () => {
+            toast.success("Auto downloader started")
+            setTimeout(() => {
+                queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.key] })
+            }, 1000)
+        },
+    })
+}
+
+export function useGetMangaAutoDownloaderRules() {
+    return useServerQuery<Array<Manga_AutoDownloaderRule>>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.key],
+        enabled: true,
+    })
+}
+
+export function useGetMangaAutoDownloaderRulesByManga(id: number, enabled: boolean) {
+    return useServerQuery<Array<Manga_AutoDownloaderRule>>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.key, String(id)],
+        enabled: enabled,
+    })
+}
+
+export function useCreateMangaAutoDownloaderRule() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Manga_AutoDownloaderRule, CreateMangaAutoDownloaderRule_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.CreateMangaAutoDownloaderRule.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.key] })
+            toast.success("Rule created")
+        },
+    })
+}
+
+export function useUpdateMangaAutoDownloaderRule() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Manga_AutoDownloaderRule, UpdateMangaAutoDownloaderRule_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.UpdateMangaAutoDownloaderRule.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.key] })
+            toast.success("Rule updated")
+        },
+    })
+}
+
+export function useDeleteMangaAutoDownloaderRule(id: Nullish<number>) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderRule.key, String(id)],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRules.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderRulesByManga.key] })
+            toast.success("Rule deleted")
+        },
+    })
+}
+
+export function useGetMangaAutoDownloaderItems(enabled: boolean = true) {
+    return useServerQuery<Array<Models_MangaAutoDownloaderItem>>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.key],
+        enabled: enabled,
+    })
+}
+
+export function useDeleteMangaAutoDownloaderItem() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean, DeleteMangaAutoDownloaderItem_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.endpoint,
+        method: API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.DeleteMangaAutoDownloaderItem.key],
+        onSuccess: async () => {
+            toast.success("Item deleted")
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_AUTO_DOWNLOADER.GetMangaAutoDownloaderItems.key] })
+        },
+    })
+}
@@ -1,11 +1,19 @@
 import { useServerMutation, useServerQuery } from "@/api/client/requests"
 import {
+    ConvertMangaDownloads_Variables,
     DeleteMangaDownloadedChapters_Variables,
     DownloadMangaChapters_Variables,
+    ExportMangaDownloadedChapters_Variables,
     GetMangaDownloadData_Variables,
 } from "@/api/generated/endpoint.types"
 import { API_ENDPOINTS } from "@/api/generated/endpoints"
-import { Manga_DownloadListItem, Manga_MediaDownloadData, Models_ChapterDownloadQueueItem, Nullish } from "@/api/generated/types"
+import {
+    Manga_DownloadListItem,
+    Manga_ExportResult,
+    Manga_MediaDownloadData,
+    Models_ChapterDownloadQueueItem,
+    Nullish,
+} from "@/api/generated/types"
 import { useQueryClient } from "@tanstack/react-query"
 import { toast } from "sonner"
 
@@ -120,3 +128,25 @@ export function useGetMangaDownloadsList() {
     })
 }
 
+
+export function useExportMangaDownloadedChapters() {
+    return useServerMutation<Manga_ExportResult, ExportMangaDownloadedChapters_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.endpoint,
+        method: API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_DOWNLOAD.ExportMangaDownloadedChapters.key],
+        onSuccess: async (data) => {
+            toast.success(`Exported ${data?.files?.length ?? 0} file(s)`)
+        },
+    })
+}
+
+export function useConvertMangaDownloads() {
+    return useServerMutation<boolean, ConvertMangaDownloads_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.endpoint,
+        method: API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_DOWNLOAD.ConvertMangaDownloads.key],
+        onSuccess: async () => {
+            toast.info("Converting downloads...")
+        },
+    })
+}
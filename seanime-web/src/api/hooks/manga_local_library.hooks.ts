@@ -0,0 +1,45 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { MatchMangaLocalLibrarySeries_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { MangaScanner_LocalSeries } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useGetMangaLocalLibrary(enabled: boolean = true) {
+    return useServerQuery<Array<MangaScanner_LocalSeries>>({
+        endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.endpoint,
+        method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.key],
+        enabled: enabled,
+    })
+}
+
+export function useScanMangaLocalLibrary() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Array<MangaScanner_LocalSeries>>({
+        endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.endpoint,
+        method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.ScanMangaLocalLibrary.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryChapters.key] })
+            toast.success("Local library scanned")
+        },
+    })
+}
+
+export function useMatchMangaLocalLibrarySeries() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Array<MangaScanner_LocalSeries>, MatchMangaLocalLibrarySeries_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.endpoint,
+        method: API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.MatchMangaLocalLibrarySeries.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_LOCAL_LIBRARY.GetMangaLocalLibrary.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryChapters.key] })
+            toast.success("Match updated")
+        },
+    })
+}
@@ -0,0 +1,33 @@
+import { useServerMutation } from "@/api/client/requests"
+import { ApplyMangaMihonImport_Variables, PreviewMangaMihonImport_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { MangaMihon_ApplyResult, MangaMihon_Preview } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function usePreviewMangaMihonImport() {
+    return useServerMutation<MangaMihon_Preview, PreviewMangaMihonImport_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.endpoint,
+        method: API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_MIHON_IMPORT.PreviewMangaMihonImport.key],
+    })
+}
+
+export function useApplyMangaMihonImport() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<MangaMihon_ApplyResult, ApplyMangaMihonImport_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.endpoint,
+        method: API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_MIHON_IMPORT.ApplyMangaMihonImport.key],
+        onSuccess: async (data) => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaCollection.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntry.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryChapters.key] })
+            toast.success(`Updated ${data?.progressUpdated ?? 0} entries, mapped ${data?.mapped ?? 0} sources`)
+            if (data?.errors?.length) {
+                toast.error(`${data.errors.length} entries could not be imported`)
+            }
+        },
+    })
+}
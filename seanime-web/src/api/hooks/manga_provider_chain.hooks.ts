@@ -0,0 +1,40 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { SaveMangaProviderChain_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { Manga_ProviderHealth, Nullish } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useGetMangaProviderHealth(enabled: boolean = true) {
+    return useServerQuery<Array<Manga_ProviderHealth>>({
+        endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.endpoint,
+        method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderHealth.key],
+        enabled: enabled,
+    })
+}
+
+export function useGetMangaProviderChain(mId: Nullish<string | number>) {
+    return useServerQuery<Array<string>>({
+        endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.endpoint.replace("{id}", String(mId)),
+        method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.key, String(mId)],
+        enabled: !!mId,
+    })
+}
+
+export function useSaveMangaProviderChain() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean, SaveMangaProviderChain_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.endpoint,
+        method: API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.SaveMangaProviderChain.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_PROVIDER_CHAIN.GetMangaProviderChain.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntryChapters.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaMergedChapters.key] })
+            toast.success("Fallback sources saved")
+        },
+    })
+}
@@ -0,0 +1,77 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { MarkMangaChapters_Variables, UpdateMangaReadingPage_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import {
+    MangaReadingState_ContinueReadingItem,
+    MangaReadingState_MediaReadingState,
+    Models_MangaReadingState,
+    Nullish,
+} from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useGetMangaReadingState(mediaId: Nullish<string | number>) {
+    return useServerQuery<MangaReadingState_MediaReadingState>({
+        endpoint: API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.endpoint.replace("{id}", String(mediaId)),
+        method: API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.key, String(mediaId)],
+        enabled: !!mediaId,
+    })
+}
+
+export function useGetMangaContinueReading() {
+    return useServerQuery<Array<MangaReadingState_ContinueReadingItem>>({
+        endpoint: API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.endpoint,
+        method: API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.key],
+        enabled: true,
+    })
+}
+
+export function useUpdateMangaReadingPage() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Models_MangaReadingState, UpdateMangaReadingPage_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.endpoint,
+        method: API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.UpdateMangaReadingPage.key],
+        onSuccess: async (data) => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.key, String(data?.mediaId)] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.key] })
+        },
+    })
+}
+
+export function useMarkMangaChapters(mediaId: Nullish<string | number>) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<MangaReadingState_MediaReadingState, MarkMangaChapters_Variables>({
+        endpoint: API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.endpoint,
+        method: API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.MarkMangaChapters.key],
+        onSuccess: async (_, variables) => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.key, String(mediaId)] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.key] })
+            if (variables.syncProgress) {
+                await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaEntry.key] })
+                await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA.GetMangaCollection.key] })
+            }
+            toast.success(variables.read ? "Marked as read" : "Marked as unread")
+        },
+    })
+}
+
+export function useResetMangaReadingState(mediaId: Nullish<string | number>) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.endpoint.replace("{id}", String(mediaId)),
+        method: API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_READING_STATE.ResetMangaReadingState.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaReadingState.key, String(mediaId)] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.MANGA_READING_STATE.GetMangaContinueReading.key] })
+            toast.success("Reading history cleared")
+        },
+    })
+}
@@ -0,0 +1,24 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { MangaReleaseTracker_MediaRelease } from "@/api/generated/types"
+import { toast } from "sonner"
+
+export function useGetMangaReleases(enabled: boolean = true) {
+    return useServerQuery<Array<MangaReleaseTracker_MediaRelease>>({
+        endpoint: API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.endpoint,
+        method: API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.methods[0],
+        queryKey: [API_ENDPOINTS.MANGA_RELEASE_TRACKER.GetMangaReleases.key],
+        enabled: enabled,
+    })
+}
+
+export function useRunMangaReleaseTracker() {
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.endpoint,
+        method: API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.methods[0],
+        mutationKey: [API_ENDPOINTS.MANGA_RELEASE_TRACKER.RunMangaReleaseTracker.key],
+        onSuccess: async () => {
+            toast.info("Checking for new releases...")
+        },
+    })
+}
@@ -0,0 +1,11 @@
+import { useServerMutation } from "@/api/client/requests"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { OpdsToken } from "@/api/generated/types"
+
+export function useCreateOpdsToken() {
+    return useServerMutation<OpdsToken>({
+        endpoint: API_ENDPOINTS.OPDS.CreateOpdsToken.endpoint,
+        method: API_ENDPOINTS.OPDS.CreateOpdsToken.methods[0],
+        mutationKey: [API_ENDPOINTS.OPDS.CreateOpdsToken.key],
+    })
+}
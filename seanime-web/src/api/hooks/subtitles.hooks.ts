@@ -0,0 +1,66 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import {
+    DeleteSubtitleTrack_Variables,
+    DownloadSubtitle_Variables,
+    GetSubtitleTracks_Variables,
+    SearchSubtitles_Variables,
+} from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { Nullish, Subtitles_SearchResponse, Subtitles_Track } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useSearchSubtitles() {
+    return useServerMutation<Subtitles_SearchResponse, SearchSubtitles_Variables>({
+        endpoint: API_ENDPOINTS.SUBTITLES.SearchSubtitles.endpoint,
+        method: API_ENDPOINTS.SUBTITLES.SearchSubtitles.methods[0],
+        mutationKey: [API_ENDPOINTS.SUBTITLES.SearchSubtitles.key],
+        onSuccess: async (data) => {
+            for (const [provider, error] of Object.entries(data?.errors ?? {})) {
+                toast.warning(`${provider}: ${error}`)
+            }
+        },
+    })
+}
+
+export function useGetSubtitleTracks(path: Nullish<string>) {
+    return useServerQuery<Array<Subtitles_Track>, GetSubtitleTracks_Variables>({
+        endpoint: API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.endpoint,
+        method: API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.methods[0],
+        queryKey: [API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.key, path],
+        data: {
+            path: path!,
+        },
+        enabled: !!path,
+    })
+}
+
+export function useDownloadSubtitle() {
+    const qc = useQueryClient()
+
+    return useServerMutation<Subtitles_Track, DownloadSubtitle_Variables>({
+        endpoint: API_ENDPOINTS.SUBTITLES.DownloadSubtitle.endpoint,
+        method: API_ENDPOINTS.SUBTITLES.DownloadSubtitle.methods[0],
+        mutationKey: [API_ENDPOINTS.SUBTITLES.DownloadSubtitle.key],
+        onSuccess: async () => {
+            toast.success("Subtitle downloaded")
+            await qc.invalidateQueries({ queryKey: [API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.key] })
+            await qc.invalidateQueries({ queryKey: [API_ENDPOINTS.MEDIASTREAM.RequestMediastreamMediaContainer.key] })
+        },
+    })
+}
+
+export function useDeleteSubtitleTrack() {
+    const qc = useQueryClient()
+
+    return useServerMutation<boolean, DeleteSubtitleTrack_Variables>({
+        endpoint: API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.endpoint,
+        method: API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.methods[0],
+        mutationKey: [API_ENDPOINTS.SUBTITLES.DeleteSubtitleTrack.key],
+        onSuccess: async () => {
+            toast.info("Subtitle deleted")
+            await qc.invalidateQueries({ queryKey: [API_ENDPOINTS.SUBTITLES.GetSubtitleTracks.key] })
+            await qc.invalidateQueries({ queryKey: [API_ENDPOINTS.MEDIASTREAM.RequestMediastreamMediaContainer.key] })
+        },
+    })
+}
@@ -0,0 +1,76 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { ResolveTrackerConflict_Variables, SetTrackerEnabled_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { Tracker_SyncItem, Tracker_TrackerItem } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useGetTrackers() {
+    return useServerQuery<Array<Tracker_TrackerItem>>({
+        endpoint: API_ENDPOINTS.TRACKERS.GetTrackers.endpoint,
+        method: API_ENDPOINTS.TRACKERS.GetTrackers.methods[0],
+        queryKey: [API_ENDPOINTS.TRACKERS.GetTrackers.key],
+        enabled: true,
+    })
+}
+
+export function useSetTrackerEnabled(id: string) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean, SetTrackerEnabled_Variables>({
+        endpoint: API_ENDPOINTS.TRACKERS.SetTrackerEnabled.endpoint.replace("{id}", id),
+        method: API_ENDPOINTS.TRACKERS.SetTrackerEnabled.methods[0],
+        mutationKey: [API_ENDPOINTS.TRACKERS.SetTrackerEnabled.key, id],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.TRACKERS.GetTrackers.key] })
+        },
+    })
+}
+
+export function useGetTrackerSyncItems(enabled: boolean) {
+    return useServerQuery<Array<Tracker_SyncItem>>({
+        endpoint: API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.endpoint,
+        method: API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.methods[0],
+        queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.key],
+        enabled: enabled,
+        refetchInterval: 5000,
+    })
+}
+
+export function useGetTrackerConflicts() {
+    return useServerQuery<Array<Tracker_SyncItem>>({
+        endpoint: API_ENDPOINTS.TRACKERS.GetTrackerConflicts.endpoint,
+        method: API_ENDPOINTS.TRACKERS.GetTrackerConflicts.methods[0],
+        queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerConflicts.key],
+        enabled: true,
+        refetchInterval: 10000,
+    })
+}
+
+export function useResolveTrackerConflict(id: number) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean, ResolveTrackerConflict_Variables>({
+        endpoint: API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.methods[0],
+        mutationKey: [API_ENDPOINTS.TRACKERS.ResolveTrackerConflict.key, String(id)],
+        onSuccess: async (_, variables) => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerConflicts.key] })
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.key] })
+            toast.success(variables.overwrite ? "Change queued" : "Change skipped")
+        },
+    })
+}
+
+export function useRetryTrackerSyncItem(id: number) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.methods[0],
+        mutationKey: [API_ENDPOINTS.TRACKERS.RetryTrackerSyncItem.key, String(id)],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.TRACKERS.GetTrackerSyncItems.key] })
+        },
+    })
+}
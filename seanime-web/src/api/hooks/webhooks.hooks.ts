@@ -0,0 +1,89 @@
+import { useServerMutation, useServerQuery } from "@/api/client/requests"
+import { SaveWebhook_Variables } from "@/api/generated/endpoint.types"
+import { API_ENDPOINTS } from "@/api/generated/endpoints"
+import { Models_Webhook, Models_WebhookDelivery, Nullish } from "@/api/generated/types"
+import { useQueryClient } from "@tanstack/react-query"
+import { toast } from "sonner"
+
+export function useGetWebhooks() {
+    return useServerQuery<Array<Models_Webhook>>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhooks.endpoint,
+        method: API_ENDPOINTS.WEBHOOKS.GetWebhooks.methods[0],
+        queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhooks.key],
+        enabled: true,
+    })
+}
+
+export function useGetWebhookEvents() {
+    return useServerQuery<Array<string>>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.endpoint,
+        method: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.methods[0],
+        queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.key],
+        enabled: true,
+    })
+}
+
+export function useSaveWebhook() {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<Models_Webhook, SaveWebhook_Variables>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.SaveWebhook.endpoint,
+        method: API_ENDPOINTS.WEBHOOKS.SaveWebhook.methods[0],
+        mutationKey: [API_ENDPOINTS.WEBHOOKS.SaveWebhook.key],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhooks.key] })
+            toast.success("Webhook saved")
+        },
+    })
+}
+
+export function useDeleteWebhook(id: number) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.methods[0],
+        mutationKey: [API_ENDPOINTS.WEBHOOKS.DeleteWebhook.key, String(id)],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhooks.key] })
+            toast.success("Webhook deleted")
+        },
+    })
+}
+
+export function useTestWebhook(id: number) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.TestWebhook.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.WEBHOOKS.TestWebhook.methods[0],
+        mutationKey: [API_ENDPOINTS.WEBHOOKS.TestWebhook.key, String(id)],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.key] })
+            toast.info("Test event queued")
+        },
+    })
+}
+
+export function useGetWebhookDeliveries(id: Nullish<number>) {
+    return useServerQuery<Array<Models_WebhookDelivery>>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.methods[0],
+        queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.key, String(id)],
+        enabled: !!id,
+        refetchInterval: 5000,
+    })
+}
+
+export function useRedeliverWebhookDelivery(id: number) {
+    const queryClient = useQueryClient()
+
+    return useServerMutation<boolean>({
+        endpoint: API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.endpoint.replace("{id}", String(id)),
+        method: API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.methods[0],
+        mutationKey: [API_ENDPOINTS.WEBHOOKS.RedeliverWebhookDelivery.key, String(id)],
+        onSuccess: async () => {
+            await queryClient.invalidateQueries({ queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.key] })
+        },
+    })
+}
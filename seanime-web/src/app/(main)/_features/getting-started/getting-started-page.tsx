@@ -92,6 +92,19 @@ export function GettingStartedPage({ status }: { status: Status }) {
                                     manga: {
                                         defaultMangaProvider: "",
                                         mangaAutoUpdateProgress: false,
+                                        mangaDownloadFormat: "images",
+                                        mangaAutoDownloaderEnabled: false,
+                                        mangaAutoDownloaderInterval: 60,
+                                        mangaLocalLibraryPath: "",
+                                        mangaReleaseTrackerEnabled: false,
+                                        mangaReleaseTrackerInterval: 360,
+                                        mangaPageProcessingEnabled: false,
+                                        mangaPageFormat: "",
+                                        mangaPageQuality: 85,
+                                        mangaPageMaxWidth: 0,
+                                        mangaPageSplitDoublePages: false,
+                                        mangaPageSplitLeftToRight: false,
+                                        mangaPageTrimBorders: false,
                                     },
                                     mediaPlayer: {
                                         host: data.mediaPlayerHost,
@@ -104,6 +117,10 @@ export function GettingStartedPage({ status }: { status: Status }) {
                                         mpcPath: data.mpcPath || "",
                                         mpvSocket: data.mpvSocket || "",
                                         mpvPath: data.mpvPath || "",
+                                        kodiPort: data.kodiPort,
+                                        kodiUsername: data.kodiUsername || "",
+                                        kodiPassword: data.kodiPassword || "",
+                                        kodiPathMappings: data.kodiPathMappings || "",
                                     },
                                     discord: {
                                         enableRichPresence: data.enableRichPresence,
@@ -2,6 +2,7 @@ import { getServerBaseUrl } from "@/api/client/server-url"
 import { AL_BaseAnime, Anime_Episode, Anime_LocalFileType } from "@/api/generated/types"
 import { useUpdateLocalFileData } from "@/api/hooks/localfiles.hooks"
 import { useExternalPlayerLink } from "@/app/(main)/_atoms/playback.atoms"
+import { EpisodeSubtitlesModal } from "@/app/(main)/entry/_containers/episode-list/episode-subtitles-modal"
 import { EpisodeGridItem } from "@/app/(main)/_features/anime/_components/episode-grid-item"
 import { PluginEpisodeGridItemMenuItems } from "@/app/(main)/_features/plugin/actions/plugin-actions"
 import { IconButton } from "@/components/ui/button"
@@ -16,7 +17,7 @@ import Image from "next/image"
 import React, { memo } from "react"
 import { AiFillWarning } from "react-icons/ai"
 import { BiDotsHorizontal, BiLockOpenAlt } from "react-icons/bi"
-import { MdInfo, MdOutlineOndemandVideo, MdOutlineRemoveDone } from "react-icons/md"
+import { MdInfo, MdOutlineOndemandVideo, MdOutlineRemoveDone, MdOutlineSubtitles } from "react-icons/md"
 import { RiEdit2Line } from "react-icons/ri"
 import { VscVerified } from "react-icons/vsc"
 import { useCopyToClipboard } from "react-use"
@@ -25,6 +26,7 @@ import { toast } from "sonner"
 export const EpisodeItemIsolation = createIsolation()
 
 const __metadataModalIsOpenAtom = atom(false)
+const __subtitlesModalIsOpenAtom = atom(false)
 
 export const EpisodeItem = memo(({ episode, media, isWatched, onPlay, percentageComplete, minutesRemaining }: {
     episode: Anime_Episode,
@@ -101,6 +103,7 @@ export const EpisodeItem = memo(({ episode, media, isWatched, onPlay, percentage
                             <MdOutlineOndemandVideo />
                             Copy stream URL
                         </DropdownMenuItem>}
+                        {episode.localFile && <SubtitlesModalButton />}
 
                         <PluginEpisodeGridItemMenuItems isDropdownMenu={false} type="library" episode={episode} />
 
@@ -128,6 +131,9 @@ export const EpisodeItem = memo(({ episode, media, isWatched, onPlay, percentage
             <MetadataModal
                 episode={episode}
             />
+            <SubtitlesModal
+                episode={episode}
+            />
         </EpisodeItemIsolation.Provider>
     )
 
@@ -212,6 +218,19 @@ function MetadataModalButton() {
     </DropdownMenuItem>
 }
 
+function SubtitlesModal({ episode }: { episode: Anime_Episode }) {
+    const [isOpen, setIsOpen] = EpisodeItemIsolation.useAtom(__subtitlesModalIsOpenAtom)
+    return <EpisodeSubtitlesModal episode={episode} open={isOpen} onOpenChange={setIsOpen} />
+}
+
+function SubtitlesModalButton() {
+    const [, setIsOpen] = EpisodeItemIsolation.useAtom(__subtitlesModalIsOpenAtom)
+    return <DropdownMenuItem onClick={() => setIsOpen(true)}>
+        <MdOutlineSubtitles />
+        Subtitles
+    </DropdownMenuItem>
+}
+
 export function EpisodeItemInfoModalButton({ episode }: { episode: Anime_Episode }) {
     return <Modal
         title={episode.displayTitle}
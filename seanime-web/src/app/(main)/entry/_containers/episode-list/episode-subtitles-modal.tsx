@@ -0,0 +1,167 @@
+import { Anime_Episode } from "@/api/generated/types"
+import { useListSubtitleProviderExtensions } from "@/api/hooks/extensions.hooks"
+import { useDeleteSubtitleTrack, useDownloadSubtitle, useGetSubtitleTracks, useSearchSubtitles } from "@/api/hooks/subtitles.hooks"
+import { SeaLink } from "@/components/shared/sea-link"
+import { AppLayoutStack } from "@/components/ui/app-layout"
+import { Badge } from "@/components/ui/badge"
+import { IconButton } from "@/components/ui/button"
+import { defineSchema, Field, Form, InferType } from "@/components/ui/form"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { Separator } from "@/components/ui/separator"
+import { Tooltip } from "@/components/ui/tooltip"
+import React from "react"
+import { BiLinkExternal, BiTrash } from "react-icons/bi"
+import { FiDownload } from "react-icons/fi"
+
+type EpisodeSubtitlesModalProps = {
+    episode: Anime_Episode
+    open: boolean
+    onOpenChange: (open: boolean) => void
+}
+
+export function EpisodeSubtitlesModal(props: EpisodeSubtitlesModalProps) {
+
+    const {
+        episode,
+        open,
+        onOpenChange,
+    } = props
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title="Subtitles"
+            titleClass="text-center"
+            contentClass="max-w-3xl"
+        >
+            <p className="w-full line-clamp-2 text-sm px-4 text-center py-2 flex-none">{episode.localFile?.name}</p>
+            {open && !!episode.localFile && <Content episode={episode} />}
+        </Modal>
+    )
+}
+
+const searchSchema = defineSchema(({ z }) => z.object({
+    provider: z.string(),
+    language: z.string().max(3),
+}))
+
+const ALL_PROVIDERS = "-"
+
+function Content({ episode }: { episode: Anime_Episode }) {
+    const path = episode.localFile?.path
+
+    const { data: providers } = useListSubtitleProviderExtensions()
+    const { data: tracks, isLoading: tracksLoading } = useGetSubtitleTracks(path)
+
+    const { mutate: search, data: searchResponse, isPending: isSearching } = useSearchSubtitles()
+    const { mutate: download, isPending: isDownloading } = useDownloadSubtitle()
+    const { mutate: deleteTrack, isPending: isDeleting } = useDeleteSubtitleTrack()
+
+    function handleSearch(data: InferType<typeof searchSchema>) {
+        search({
+            mediaId: episode.baseAnime?.id ?? 0,
+            episodeNumber: episode.episodeNumber,
+            language: data.language.trim(),
+            path: path ?? "",
+            provider: data.provider === ALL_PROVIDERS ? "" : data.provider,
+        })
+    }
+
+    return (
+        <AppLayoutStack>
+            <div className="space-y-2">
+                <p className="font-semibold">Downloaded</p>
+                {tracksLoading ? <LoadingSpinner /> : !tracks?.length ? (
+                    <p className="text-[--muted] italic">No subtitles downloaded</p>
+                ) : tracks.map(track => (
+                    <div key={track.filename} className="flex justify-between items-center gap-2">
+                        <p className="line-clamp-1">
+                            {track.name} <span className="text-[--muted]">({track.provider})</span>
+                        </p>
+                        <div className="flex items-center gap-2">
+                            {!!track.language && <Badge>{track.language}</Badge>}
+                            <Badge intent="gray">{track.format}</Badge>
+                            <IconButton
+                                icon={<BiTrash />}
+                                intent="alert-basic"
+                                size="xs"
+                                loading={isDeleting}
+                                onClick={() => deleteTrack({ path: path!, filename: track.filename })}
+                            />
+                        </div>
+                    </div>
+                ))}
+                <p className="text-[--muted] text-sm">
+                    Downloaded subtitles are offered as external tracks when the file is streamed or played with VLC or MPV.
+                </p>
+            </div>
+
+            <Separator />
+
+            <Form
+                schema={searchSchema}
+                onSubmit={handleSearch}
+                defaultValues={{ provider: ALL_PROVIDERS, language: "" }}
+            >
+                <div className="flex gap-2 items-end">
+                    <Field.Select
+                        name="provider"
+                        label="Provider"
+                        options={[
+                            { label: "All providers", value: ALL_PROVIDERS },
+                            ...(providers?.map(provider => ({ label: provider.name, value: provider.id })) ?? []),
+                        ]}
+                        fieldClass="w-full"
+                    />
+                    <Field.Text
+                        name="language"
+                        label="Language"
+                        placeholder="e.g. en"
+                        fieldClass="w-40"
+                    />
+                    <Field.Submit intent="white" loading={isSearching}>Search</Field.Submit>
+                </div>
+            </Form>
+
+            {isSearching ? <LoadingSpinner /> : (
+                <div className="space-y-2">
+                    {searchResponse?.results?.length === 0 && <p className="text-[--muted] italic">No subtitles found</p>}
+                    {searchResponse?.results?.map(result => (
+                        <div key={result.provider + result.id} className="flex justify-between items-center gap-2">
+                            <div>
+                                <p className="line-clamp-1">{result.name}</p>
+                                <p className="text-[--muted] text-sm">
+                                    {result.provider}{!!result.downloads && ` · ${result.downloads} downloads`}
+                                </p>
+                            </div>
+                            <div className="flex items-center gap-2">
+                                {result.isFilenameMatch && <Badge intent="success">File match</Badge>}
+                                {!!result.language && <Badge>{result.language}</Badge>}
+                                {!!result.url && <SeaLink href={result.url} target="_blank">
+                                    <Tooltip
+                                        trigger={<IconButton
+                                            icon={<BiLinkExternal />}
+                                            intent="primary-basic"
+                                            size="xs"
+                                        />}
+                                    >
+                                        Open in browser
+                                    </Tooltip>
+                                </SeaLink>}
+                                <IconButton
+                                    icon={<FiDownload />}
+                                    intent="primary-subtle"
+                                    size="xs"
+                                    loading={isDownloading}
+                                    onClick={() => download({ path: path!, result })}
+                                />
+                            </div>
+                        </div>
+                    ))}
+                </div>
+            )}
+        </AppLayoutStack>
+    )
+}
@@ -1,4 +1,5 @@
 import { Extension_Extension } from "@/api/generated/types"
+import { ExtensionSignatureBadge } from "@/app/(main)/extensions/_components/extension-signature-badge"
 import { LANGUAGES_LIST } from "@/app/(main)/manga/_lib/language-map"
 import { SeaLink } from "@/components/shared/sea-link"
 import { Badge } from "@/components/ui/badge"
@@ -82,9 +83,32 @@ export function ExtensionDetails(props: ExtensionDetailsProps) {
                 <p className="text-md line-clamp-1">
                     <span className="text-[--muted]">Programming language:</span> <span className="">{capitalize(extension.language)}</span>
                 </p>
+                {!!extension.signature && <div className="text-md w-full flex items-center gap-2 flex-wrap">
+                    <span className="text-[--muted]">Signature:</span>
+                    <ExtensionSignatureBadge extension={extension} />
+                    <span className="text-[--muted] text-sm break-all">{extension.signature.publicKey}</span>
+                </div>}
                 {(!!extension.manifestURI && !isBuiltin) && <p className="text-md w-full">
                     <span className="text-[--muted]">Manifest URL:</span> <span className="">{extension.manifestURI}</span>
                 </p>}
+                {(!!extension.repository && !isBuiltin) && <p className="text-md w-full">
+                    <span className="text-[--muted]">Repository:</span> <span className="">{extension.repository}</span>
+                </p>}
+                {(!!extension.network?.allowedDomains?.length || !!extension.network?.allowedCIDRs?.length) && <div className="text-md w-full">
+                    <span className="text-[--muted]">Network access:</span>
+                    <div className="flex flex-wrap gap-1 pt-1">
+                        {extension.network?.allowedDomains?.map(domain => (
+                            <Badge key={domain} className="rounded-[--radius-md]">
+                                {domain === "*" ? "Any website" : domain}
+                            </Badge>
+                        ))}
+                        {extension.network?.allowedCIDRs?.map(cidr => (
+                            <Badge key={cidr} intent="warning" className="rounded-[--radius-md]">
+                                {cidr}
+                            </Badge>
+                        ))}
+                    </div>
+                </div>}
             </div>
         </>
     )
@@ -0,0 +1,45 @@
+import { Extension_Extension } from "@/api/generated/types"
+import { Badge } from "@/components/ui/badge"
+import { Tooltip } from "@/components/ui/tooltip"
+import React from "react"
+import { LuShieldAlert, LuShieldCheck, LuShieldQuestion } from "react-icons/lu"
+
+type ExtensionSignatureBadgeProps = {
+    extension: Extension_Extension
+}
+
+export function ExtensionSignatureBadge(props: ExtensionSignatureBadgeProps) {
+
+    const {
+        extension,
+    } = props
+
+    switch (extension.signatureStatus) {
+        case "verified":
+            return <Tooltip
+                trigger={<Badge className="rounded-md" intent="success" leftIcon={<LuShieldCheck />}>
+                    Verified
+                </Badge>}
+            >
+                Signed with a trusted key
+            </Tooltip>
+        case "untrusted":
+            return <Tooltip
+                trigger={<Badge className="rounded-md" intent="gray" leftIcon={<LuShieldQuestion />}>
+                    Signed
+                </Badge>}
+            >
+                Signed with a key you haven't added to your trusted keys ({extension.signature?.publicKey})
+            </Tooltip>
+        case "invalid":
+            return <Tooltip
+                trigger={<Badge className="rounded-md" intent="alert" leftIcon={<LuShieldAlert />}>
+                    Invalid signature
+                </Badge>}
+            >
+                The code or manifest doesn't match the publisher's signature
+            </Tooltip>
+        default:
+            return null
+    }
+}
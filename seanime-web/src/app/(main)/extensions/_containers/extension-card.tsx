@@ -1,13 +1,16 @@
 import { Extension_Extension, Extension_InvalidExtension, ExtensionRepo_UpdateData } from "@/api/generated/types"
 import {
     useFetchExternalExtensionData,
+    useGetExtensionVersions,
     useInstallExternalExtension,
     useReloadExternalExtension,
     useUninstallExternalExtension,
 } from "@/api/hooks/extensions.hooks"
 import { ExtensionDetails } from "@/app/(main)/extensions/_components/extension-details"
+import { ExtensionSignatureBadge } from "@/app/(main)/extensions/_components/extension-signature-badge"
 import { ExtensionCodeModal } from "@/app/(main)/extensions/_containers/extension-code"
 import { ExtensionUserConfigModal } from "@/app/(main)/extensions/_containers/extension-user-config"
+import { ExtensionVersions } from "@/app/(main)/extensions/_containers/extension-versions"
 import { LANGUAGES_LIST } from "@/app/(main)/manga/_lib/language-map"
 import { ConfirmationDialog, useConfirmationDialog } from "@/components/shared/confirmation-dialog"
 import { AppLayoutStack } from "@/components/ui/app-layout"
@@ -101,7 +104,7 @@ export function ExtensionCard(props: ExtensionCardProps) {
                     </ExtensionSettings>
                 </div>
                 <div className="flex flex-row gap-1 z-[2] flex-wrap">
-                    {!isBuiltin && (
+                    {(!isBuiltin && extension.language !== "wasm") && (
                         <ExtensionCodeModal extension={extension}>
                             <div>
                                 <Tooltip
@@ -182,6 +185,7 @@ export function ExtensionCard(props: ExtensionCardProps) {
                     {/*<Badge className="rounded-md" intent="unstyled">*/}
                     {/*    {capitalize(extension.language)}*/}
                     {/*</Badge>*/}
+                    {!isBuiltin && <ExtensionSignatureBadge extension={extension} />}
                     {!!updateData && <Badge className="rounded-md" intent="success">
                         Update available
                     </Badge>}
@@ -215,6 +219,8 @@ export function ExtensionSettings(props: ExtensionSettingsProps) {
 
     const { mutate: fetchExtensionData, data: fetchedExtensionData, isPending: isFetchingData, reset } = useFetchExternalExtensionData(extension.id)
 
+    const { data: versions } = useGetExtensionVersions(isInstalled && !isBuiltin && !!extension.manifestURI ? extension.id : null)
+
     const confirmUninstall = useConfirmationDialog({
         title: `Remove ${extension.name}`,
         description: "This action cannot be undone.",
@@ -242,7 +248,8 @@ export function ExtensionSettings(props: ExtensionSettingsProps) {
 
     function handleCheckUpdate() {
         fetchExtensionData({
-            manifestUri: extension.manifestURI,
+            // Follow the selected update channel
+            manifestUri: versions?.updateManifestURI || extension.manifestURI,
         })
         checkingForUpdatesRef.current = true
     }
@@ -275,7 +282,7 @@ export function ExtensionSettings(props: ExtensionSettingsProps) {
                     {isInstalled && (
                         <div className="flex gap-2">
                             <>
-                                {!!extension.manifestURI && <Button
+                                {(!!extension.manifestURI && !versions?.pinnedVersion) && <Button
                                     intent="gray-outline"
                                     leftIcon={<GrUpdate className="text-lg" />}
                                     disabled={!extension.manifestURI}
@@ -297,6 +304,8 @@ export function ExtensionSettings(props: ExtensionSettingsProps) {
                     )}
 
 
+                    {(isInstalled && !!extension.manifestURI) && <ExtensionVersions extension={extension} />}
+
                     {((!!fetchedExtensionData && fetchedExtensionData?.version !== extension.version) || !!updateData) && (
                         <AppLayoutStack>
                             <p className="">
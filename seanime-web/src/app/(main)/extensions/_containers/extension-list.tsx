@@ -3,6 +3,8 @@ import { useGetAllExtensions, useInstallExternalExtension } from "@/api/hooks/ex
 import { AddExtensionModal } from "@/app/(main)/extensions/_containers/add-extension-modal"
 import { ExtensionCard } from "@/app/(main)/extensions/_containers/extension-card"
 import { InvalidExtensionCard, UnauthorizedExtensionPluginCard } from "@/app/(main)/extensions/_containers/invalid-extension-card"
+import { RuntimeMetricsModal } from "@/app/(main)/extensions/_containers/runtime-metrics-modal"
+import { TrustedKeysModal } from "@/app/(main)/extensions/_containers/trusted-keys-modal"
 import { LuffyError } from "@/components/shared/luffy-error"
 import { AppLayoutStack } from "@/components/ui/app-layout"
 import { Button, IconButton } from "@/components/ui/button"
@@ -16,7 +18,8 @@ import React from "react"
 import { BiDotsVerticalRounded } from "react-icons/bi"
 import { CgMediaPodcast } from "react-icons/cg"
 import { GrInstallOption } from "react-icons/gr"
-import { LuBlocks, LuDownload } from "react-icons/lu"
+import { LuBlocks, LuDownload, LuListChecks } from "react-icons/lu"
+import { MdOutlineSubtitles } from "react-icons/md"
 import { PiBookFill } from "react-icons/pi"
 import { RiFolderDownloadFill } from "react-icons/ri"
 import { TbReload } from "react-icons/tb"
@@ -38,6 +41,8 @@ export function ExtensionList(props: ExtensionListProps) {
     const router = useRouter()
 
     const [checkForUpdates, setCheckForUpdates] = React.useState(false)
+    const [trustedKeysOpen, setTrustedKeysOpen] = React.useState(false)
+    const [runtimeMetricsOpen, setRuntimeMetricsOpen] = React.useState(false)
 
     const { data: allExtensions, isPending: isLoading, refetch } = useGetAllExtensions(checkForUpdates)
 
@@ -64,6 +69,8 @@ export function ExtensionList(props: ExtensionListProps) {
     const animeTorrentExtensions = orderExtensions(allExtensions?.extensions ?? []).filter(n => n.type === "anime-torrent-provider")
     const mangaExtensions = orderExtensions(allExtensions?.extensions ?? []).filter(n => n.type === "manga-provider")
     const onlinestreamExtensions = orderExtensions(allExtensions?.extensions ?? []).filter(n => n.type === "onlinestream-provider")
+    const subtitleExtensions = orderExtensions(allExtensions?.extensions ?? []).filter(n => n.type === "subtitle-provider")
+    const trackerExtensions = orderExtensions(allExtensions?.extensions ?? []).filter(n => n.type === "tracker")
 
     const nonvalidExtensions = (allExtensions?.invalidExtensions ?? []).filter(n => n.code !== "plugin_permissions_not_granted")
         .sort((a, b) => a.id.localeCompare(b.id))
@@ -150,10 +157,29 @@ export function ExtensionList(props: ExtensionListProps) {
                         >
                             <span>Marketplace</span>
                         </DropdownMenuItem>
+
+                        <DropdownMenuItem
+                            onClick={() => {
+                                setTrustedKeysOpen(true)
+                            }}
+                        >
+                            <span>Trusted keys</span>
+                        </DropdownMenuItem>
+
+                        <DropdownMenuItem
+                            onClick={() => {
+                                setRuntimeMetricsOpen(true)
+                            }}
+                        >
+                            <span>Runtime metrics</span>
+                        </DropdownMenuItem>
                     </DropdownMenu>
                 </div>
             </div>
 
+            <TrustedKeysModal open={trustedKeysOpen} onOpenChange={setTrustedKeysOpen} />
+            <RuntimeMetricsModal open={runtimeMetricsOpen} onOpenChange={setRuntimeMetricsOpen} />
+
 
             {!!pluginPermissionsNotGrantedExtensions?.length && (
                 <Card className="p-4 space-y-6">
@@ -262,6 +288,42 @@ export function ExtensionList(props: ExtensionListProps) {
                 </Card>
             )}
 
+            {!!subtitleExtensions?.length && (
+                <Card className="p-4 space-y-6">
+                    <h3 className="flex gap-3 items-center"><MdOutlineSubtitles /> Subtitles</h3>
+                    <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
+                        {subtitleExtensions.map(extension => (
+                            <ExtensionCard
+                                key={extension.id}
+                                extension={extension}
+                                updateData={allExtensions?.hasUpdate?.find(n => n.extensionID === extension.id)}
+                                isInstalled={isExtensionInstalled(extension.id)}
+                                userConfigError={allExtensions?.invalidUserConfigExtensions?.find(n => n.id == extension.id)}
+                                allowReload
+                            />
+                        ))}
+                    </div>
+                </Card>
+            )}
+
+            {!!trackerExtensions?.length && (
+                <Card className="p-4 space-y-6">
+                    <h3 className="flex gap-3 items-center"><LuListChecks /> Trackers</h3>
+                    <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
+                        {trackerExtensions.map(extension => (
+                            <ExtensionCard
+                                key={extension.id}
+                                extension={extension}
+                                updateData={allExtensions?.hasUpdate?.find(n => n.extensionID === extension.id)}
+                                isInstalled={isExtensionInstalled(extension.id)}
+                                userConfigError={allExtensions?.invalidUserConfigExtensions?.find(n => n.id == extension.id)}
+                                allowReload
+                            />
+                        ))}
+                    </div>
+                </Card>
+            )}
+
             {/*</Card>*/}
         </AppLayoutStack>
     )
@@ -0,0 +1,93 @@
+import { Extension_Extension } from "@/api/generated/types"
+import { useGetExtensionVersions, usePinExtension, useRollbackExtension, useSetExtensionChannel } from "@/api/hooks/extensions.hooks"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { Select } from "@/components/ui/select"
+import React from "react"
+import { LuHistory, LuPin, LuPinOff } from "react-icons/lu"
+
+type ExtensionVersionsProps = {
+    extension: Extension_Extension
+}
+
+/**
+ * Update channel, version pinning and rollback of an installed external extension.
+ */
+export function ExtensionVersions(props: ExtensionVersionsProps) {
+
+    const {
+        extension,
+        ...rest
+    } = props
+
+    const { data: versions } = useGetExtensionVersions(extension.id)
+
+    const { mutate: rollback, isPending: isRollingBack } = useRollbackExtension()
+    const { mutate: pin, isPending: isPinning } = usePinExtension()
+    const { mutate: setChannel, isPending: isSettingChannel } = useSetExtensionChannel()
+
+    if (!versions) return null
+
+    const isPinned = !!versions.pinnedVersion
+
+    return (
+        <div className="space-y-3 border rounded-[--radius-md] p-3">
+            <div className="flex items-center gap-2 flex-wrap">
+                <h5>Versions</h5>
+                {isPinned && <Badge intent="warning" className="rounded-[--radius-md]">
+                    Pinned to {versions.pinnedVersion}
+                </Badge>}
+                <div className="flex flex-1"></div>
+                <Button
+                    size="sm"
+                    intent="gray-outline"
+                    leftIcon={isPinned ? <LuPinOff /> : <LuPin />}
+                    loading={isPinning}
+                    onClick={() => pin({ id: extension.id, version: isPinned ? "" : versions.version })}
+                >
+                    {isPinned ? "Unpin" : "Pin this version"}
+                </Button>
+            </div>
+
+            {isPinned && <p className="text-sm text-[--muted]">
+                Updates are ignored while the extension is pinned.
+            </p>}
+
+            {!!versions.channels?.length && <Select
+                label="Update channel"
+                value={versions.channel || "default"}
+                onValueChange={v => setChannel({ id: extension.id, channel: v === "default" ? "" : v })}
+                options={[
+                    { value: "default", label: "Default" },
+                    ...versions.channels.map(channel => ({ value: channel, label: channel })),
+                ]}
+                disabled={isSettingChannel}
+            />}
+
+            <div className="space-y-2">
+                <p className="text-sm text-[--muted]">Previous versions</p>
+                {!versions.history?.length && <p className="text-sm text-[--muted] italic">
+                    No previous versions. Previous versions are kept when the extension is updated.
+                </p>}
+                {versions.history?.map(entry => (
+                    <div key={entry.version} className="flex items-center gap-2">
+                        <Badge className="rounded-[--radius-md] tracking-wide">{entry.version}</Badge>
+                        {!!entry.replacedAt && <span className="text-xs text-[--muted]">
+                            Replaced {new Date(entry.replacedAt).toLocaleString()}
+                        </span>}
+                        <div className="flex flex-1"></div>
+                        <Button
+                            size="sm"
+                            intent="gray-subtle"
+                            leftIcon={<LuHistory />}
+                            loading={isRollingBack}
+                            onClick={() => rollback({ id: extension.id, version: entry.version })}
+                        >
+                            Roll back
+                        </Button>
+                    </div>
+                ))}
+            </div>
+        </div>
+    )
+}
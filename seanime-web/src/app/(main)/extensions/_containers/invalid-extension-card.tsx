@@ -81,13 +81,15 @@ export function InvalidExtensionCard(props: InvalidExtensionCardProps) {
                     </>
                 )}
 
-                <ExtensionCodeModal extension={extension.extension}>
-                    <IconButton
-                        size="sm"
-                        intent="gray-basic"
-                        icon={<FaCode />}
-                    />
-                </ExtensionCodeModal>
+                {extension.extension.language !== "wasm" && (
+                    <ExtensionCodeModal extension={extension.extension}>
+                        <IconButton
+                            size="sm"
+                            intent="gray-basic"
+                            icon={<FaCode />}
+                        />
+                    </ExtensionCodeModal>
+                )}
 
                 <IconButton
                     size="sm"
@@ -201,7 +203,7 @@ export function UnauthorizedExtensionPluginCard(props: UnauthorizedExtensionPlug
                     contentClass="max-w-2xl"
                 >
                     <p>
-                        The plugin <span className="font-bold">{extension.extension?.name}</span> is requesting the following permissions:
+                        The extension <span className="font-bold">{extension.extension?.name}</span> is requesting the following permissions:
                     </p>
 
                     <p className="whitespace-pre-wrap w-full max-w-full overflow-x-auto text-md leading-relaxed text-left bg-[--subtle] p-2 rounded-md">
@@ -1,9 +1,12 @@
-import { Extension_Extension } from "@/api/generated/types"
+import { Extension_Extension, ExtensionRepo_MarketplaceRepository } from "@/api/generated/types"
 import {
+    useAddMarketplaceRepository,
     useGetAllExtensions,
     useGetMarketplaceExtensions,
+    useGetMarketplaceRepositories,
     useInstallExternalExtension,
     useReloadExternalExtension,
+    useRemoveMarketplaceRepository,
 } from "@/api/hooks/extensions.hooks"
 import { DEFAULT_MARKETPLACE_URL, marketplaceUrlAtom } from "@/app/(main)/extensions/_lib/marketplace.atoms"
 import { LANGUAGES_LIST } from "@/app/(main)/manga/_lib/language-map"
@@ -25,7 +28,8 @@ import Image from "next/image"
 import React, { useMemo } from "react"
 import { BiSearch } from "react-icons/bi"
 import { CgMediaPodcast } from "react-icons/cg"
-import { LuBlocks, LuCheck, LuDownload, LuSettings } from "react-icons/lu"
+import { LuBlocks, LuCheck, LuDownload, LuListChecks, LuSettings, LuTrash } from "react-icons/lu"
+import { MdOutlineSubtitles } from "react-icons/md"
 import { PiBookFill } from "react-icons/pi"
 import { RiFolderDownloadFill } from "react-icons/ri"
 import { toast } from "sonner"
@@ -44,13 +48,22 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
     const [filterType, setFilterType] = React.useState<string>("all")
     const [filterLanguage, setFilterLanguage] = React.useState<string>("all")
     const [marketplaceUrl, setMarketplaceUrl] = useAtom(marketplaceUrlAtom)
-    const [isUrlModalOpen, setIsUrlModalOpen] = React.useState(false)
-    const [tempUrl, setTempUrl] = React.useState(marketplaceUrl)
-    const [urlError, setUrlError] = React.useState("")
-    const [isUpdatingUrl, setIsUpdatingUrl] = React.useState(false)
+    const [isRepositoriesModalOpen, setIsRepositoriesModalOpen] = React.useState(false)
 
     const { data: marketplaceExtensions, isPending: isLoadingMarketplace, refetch } = useGetMarketplaceExtensions(marketplaceUrl)
     const { data: allExtensions, isPending: isLoadingAllExtensions } = useGetAllExtensions(false)
+    const { data: repositories } = useGetMarketplaceRepositories()
+
+    // Reset the filter if the selected repository was removed
+    React.useEffect(() => {
+        if (!!repositories && marketplaceUrl !== DEFAULT_MARKETPLACE_URL && !repositories.find(n => n.url === marketplaceUrl)) {
+            setMarketplaceUrl(DEFAULT_MARKETPLACE_URL)
+        }
+    }, [repositories, marketplaceUrl])
+
+    function getRepository(url: string | undefined) {
+        return repositories?.find(n => n.url === url)
+    }
 
     function orderExtensions(extensions: Extension_Extension[] | undefined) {
         return extensions ?
@@ -133,129 +146,18 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
     const animeTorrentExtensions = filteredExtensions.filter(n => n.type === "anime-torrent-provider")
     const mangaExtensions = filteredExtensions.filter(n => n.type === "manga-provider")
     const onlinestreamExtensions = filteredExtensions.filter(n => n.type === "onlinestream-provider")
+    const subtitleExtensions = filteredExtensions.filter(n => n.type === "subtitle-provider")
+    const trackerExtensions = filteredExtensions.filter(n => n.type === "tracker")
 
     // if (isLoadingMarketplace || isLoadingAllExtensions) return <LoadingSpinner />
 
-    // validate URL
-    const validateUrl = (url: string): boolean => {
-        try {
-            new URL(url)
-            setUrlError("")
-            return true
-        }
-        catch (e) {
-            setUrlError("Please enter a valid URL")
-            return false
-        }
-    }
-
-    // handle URL change
-    const handleUrlChange = async () => {
-        if (validateUrl(tempUrl)) {
-            setIsUpdatingUrl(true)
-            try {
-                setMarketplaceUrl(tempUrl)
-                await refetch()
-                setIsUrlModalOpen(false)
-                toast.success("Marketplace URL updated")
-            }
-            catch (error) {
-                toast.error("Failed to fetch extensions from the provided URL")
-                console.error("Error fetching extensions:", error)
-            }
-            finally {
-                setIsUpdatingUrl(false)
-            }
-        }
-    }
-
-    // reset URL to default
-    const resetToDefaultUrl = async () => {
-        setTempUrl(DEFAULT_MARKETPLACE_URL)
-        setUrlError("")
-    }
-
-    // apply default URL immediately
-    const applyDefaultUrl = async () => {
-        setIsUpdatingUrl(true)
-        try {
-            setMarketplaceUrl(DEFAULT_MARKETPLACE_URL)
-            await refetch()
-            setIsUrlModalOpen(false)
-            toast.success("Reset to default marketplace URL")
-        }
-        catch (error) {
-            toast.error("Failed to fetch extensions from the default URL")
-            console.error("Error fetching extensions:", error)
-        }
-        finally {
-            setIsUpdatingUrl(false)
-        }
-    }
-
     return (
         <AppLayoutStack className="gap-6">
-            {/* URL Change Modal */}
-            <Modal
-                open={isUrlModalOpen}
-                onOpenChange={setIsUrlModalOpen}
-                title="Repository URL"
-            >
-                <div className="space-y-4">
-                    <p className="text-sm text-[--muted]">
-                        Enter the URL of the repository JSON file.
-                    </p>
-
-                    <TextInput
-                        label="Marketplace URL"
-                        value={tempUrl}
-                        onValueChange={(value) => {
-                            setTempUrl(value)
-                            // Validate as user types, but only if there's some input
-                            if (value) validateUrl(value)
-                        }}
-                        error={urlError}
-                        placeholder="Enter marketplace URL"
-                    />
-
-                    <div className="flex justify-between">
-                        <div className="flex gap-2">
-                            {/*<Button*/}
-                            {/*    intent="gray-outline"*/}
-                            {/*    onClick={resetToDefaultUrl}*/}
-                            {/*>*/}
-                            {/*    Set to Default*/}
-                            {/*</Button>*/}
-                            <Button
-                                intent="primary-subtle"
-                                onClick={applyDefaultUrl}
-                                loading={isUpdatingUrl}
-                                disabled={isUpdatingUrl}
-                            >
-                                Apply Default
-                            </Button>
-                        </div>
-
-                        <div className="flex gap-2">
-                            <Button
-                                intent="gray-outline"
-                                onClick={() => setIsUrlModalOpen(false)}
-                            >
-                                Cancel
-                            </Button>
-
-                            <Button
-                                intent="primary"
-                                onClick={handleUrlChange}
-                                disabled={!tempUrl || !!urlError || isUpdatingUrl}
-                                loading={isUpdatingUrl}
-                            >
-                                Save
-                            </Button>
-                        </div>
-                    </div>
-                </div>
-            </Modal>
+            <MarketplaceRepositoriesModal
+                open={isRepositoriesModalOpen}
+                onOpenChange={setIsRepositoriesModalOpen}
+                repositories={repositories}
+            />
 
             <div className="flex items-center gap-2 flex-wrap">
                 <div>
@@ -263,12 +165,12 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                         Marketplace
                     </h2>
                     <p className="text-[--muted] text-sm">
-                        Browse and install extensions from the repository.
+                        Browse and install extensions from your repositories.
                     </p>
                     <p className="text-[--muted] text-xs mt-1">
                         Source: {marketplaceUrl === DEFAULT_MARKETPLACE_URL ?
-                        <span>Official repository</span> :
-                        <span>{marketplaceUrl}</span>
+                        <span>All repositories</span> :
+                        <span>{getRepository(marketplaceUrl)?.name ?? marketplaceUrl}</span>
                     }
                     </p>
                 </div>
@@ -290,13 +192,9 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                         className="rounded-full"
                         intent="gray-outline"
                         leftIcon={<LuSettings />}
-                        onClick={() => {
-                            setTempUrl(marketplaceUrl)
-                            setUrlError("")
-                            setIsUrlModalOpen(true)
-                        }}
+                        onClick={() => setIsRepositoriesModalOpen(true)}
                     >
-                        Change repository
+                        Repositories
                     </Button>
                 </div>
             </div>
@@ -305,6 +203,15 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
             <div className="flex flex-wrap gap-4">
 
                 <div className="flex flex-col lg:flex-row w-full gap-2">
+                    {(repositories?.length ?? 0) > 1 && <Select
+                        value={marketplaceUrl || "all"}
+                        onValueChange={v => setMarketplaceUrl(v === "all" ? DEFAULT_MARKETPLACE_URL : v)}
+                        options={[
+                            { value: "all", label: "All Repositories" },
+                            ...(repositories ?? []).map(n => ({ value: n.url, label: n.name })),
+                        ]}
+                        fieldClass="lg:max-w-[200px]"
+                    />}
                     <Select
                         value={filterType}
                         onValueChange={setFilterType}
@@ -314,6 +221,8 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                             { value: "anime-torrent-provider", label: "Anime Torrents" },
                             { value: "manga-provider", label: "Manga" },
                             { value: "onlinestream-provider", label: "Online Streaming" },
+                            { value: "subtitle-provider", label: "Subtitles" },
+                            { value: "tracker", label: "Trackers" },
                         ]}
                         fieldClass="lg:max-w-[200px]"
                     />
@@ -364,9 +273,10 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                     <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
                         {pluginExtensions.map(extension => (
                             <MarketplaceExtensionCard
-                                key={extension.id}
+                                key={`${extension.repository}:${extension.id}`}
                                 extension={extension}
                                 isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
                             />
                         ))}
                     </div>
@@ -379,9 +289,10 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                     <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
                         {animeTorrentExtensions.map(extension => (
                             <MarketplaceExtensionCard
-                                key={extension.id}
+                                key={`${extension.repository}:${extension.id}`}
                                 extension={extension}
                                 isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
                             />
                         ))}
                     </div>
@@ -394,9 +305,10 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                     <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
                         {mangaExtensions.map(extension => (
                             <MarketplaceExtensionCard
-                                key={extension.id}
+                                key={`${extension.repository}:${extension.id}`}
                                 extension={extension}
                                 isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
                             />
                         ))}
                     </div>
@@ -409,9 +321,42 @@ export function MarketplaceExtensions(props: MarketplaceExtensionsProps) {
                     <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
                         {onlinestreamExtensions.map(extension => (
                             <MarketplaceExtensionCard
-                                key={extension.id}
+                                key={`${extension.repository}:${extension.id}`}
                                 extension={extension}
                                 isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
+                            />
+                        ))}
+                    </div>
+                </Card>
+            )}
+
+            {!!subtitleExtensions?.length && (
+                <Card className="p-4 space-y-6">
+                    <h3 className="flex gap-3 items-center"><MdOutlineSubtitles /> Subtitles</h3>
+                    <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
+                        {subtitleExtensions.map(extension => (
+                            <MarketplaceExtensionCard
+                                key={`${extension.repository}:${extension.id}`}
+                                extension={extension}
+                                isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
+                            />
+                        ))}
+                    </div>
+                </Card>
+            )}
+
+            {!!trackerExtensions?.length && (
+                <Card className="p-4 space-y-6">
+                    <h3 className="flex gap-3 items-center"><LuListChecks /> Trackers</h3>
+                    <div className="grid grid-cols-1 lg:grid-cols-3 2xl:grid-cols-4 gap-4">
+                        {trackerExtensions.map(extension => (
+                            <MarketplaceExtensionCard
+                                key={`${extension.repository}:${extension.id}`}
+                                extension={extension}
+                                isInstalled={isExtensionInstalled(extension.id)}
+                                repository={(repositories?.length ?? 0) > 1 ? getRepository(extension.repository) : undefined}
                             />
                         ))}
                     </div>
@@ -425,6 +370,8 @@ type MarketplaceExtensionCardProps = {
     extension: Extension_Extension
     updateData?: Extension_Extension | undefined
     isInstalled: boolean
+    // Shown when there are multiple repositories
+    repository?: ExtensionRepo_MarketplaceRepository
 }
 
 function MarketplaceExtensionCard(props: MarketplaceExtensionCardProps) {
@@ -433,6 +380,7 @@ function MarketplaceExtensionCard(props: MarketplaceExtensionCardProps) {
         extension,
         updateData,
         isInstalled,
+        repository,
         ...rest
     } = props
 
@@ -468,7 +416,7 @@ function MarketplaceExtensionCard(props: MarketplaceExtensionCardProps) {
                         intent="primary-subtle"
                         icon={<LuDownload />}
                         loading={isInstalling}
-                        onClick={() => installExtension({ manifestUri: extension.manifestURI })}
+                        onClick={() => installExtension({ manifestUri: extension.manifestURI, repository: extension.repository })}
                     /> : <IconButton
                         size="sm"
                         disabled
@@ -535,6 +483,9 @@ function MarketplaceExtensionCard(props: MarketplaceExtensionCardProps) {
                     <Badge className="rounded-md" intent="unstyled">
                         {capitalize(extension.language)}
                     </Badge>
+                    {!!repository && <Badge className="rounded-md" intent="unstyled">
+                        {repository.name}
+                    </Badge>}
                     {!!updateData && <Badge className="rounded-md" intent="success">
                         Update available
                     </Badge>}
@@ -544,3 +495,97 @@ function MarketplaceExtensionCard(props: MarketplaceExtensionCardProps) {
         </div>
     )
 }
+
+type MarketplaceRepositoriesModalProps = {
+    open: boolean
+    onOpenChange: (open: boolean) => void
+    repositories: ExtensionRepo_MarketplaceRepository[] | undefined
+}
+
+function MarketplaceRepositoriesModal(props: MarketplaceRepositoriesModalProps) {
+
+    const {
+        open,
+        onOpenChange,
+        repositories,
+    } = props
+
+    const [name, setName] = React.useState("")
+    const [url, setUrl] = React.useState("")
+
+    const { mutate: addRepository, isPending: isAdding } = useAddMarketplaceRepository()
+    const { mutate: removeRepository, isPending: isRemoving } = useRemoveMarketplaceRepository()
+
+    function handleAdd() {
+        addRepository({ name, url }, {
+            onSuccess: () => {
+                setName("")
+                setUrl("")
+            },
+        })
+    }
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title="Repositories"
+            contentClass="max-w-2xl"
+        >
+            <div className="space-y-4">
+                <p className="text-sm text-[--muted]">
+                    Extensions from all repositories are listed in the marketplace.
+                    Removing a repository does not uninstall its extensions.
+                </p>
+
+                <div className="space-y-2">
+                    {repositories?.map(repository => (
+                        <div key={repository.url} className="flex items-center gap-2 border rounded-[--radius] p-2">
+                            <div className="flex-1 min-w-0">
+                                <p className="font-semibold flex items-center gap-2">
+                                    {repository.name}
+                                    {repository.isDefault && <Badge intent="primary" size="sm">Official</Badge>}
+                                </p>
+                                <p className="text-xs text-[--muted] break-all">{repository.url}</p>
+                            </div>
+                            {!repository.isDefault && <IconButton
+                                size="sm"
+                                intent="alert-subtle"
+                                icon={<LuTrash />}
+                                loading={isRemoving}
+                                onClick={() => removeRepository({ url: repository.url })}
+                            />}
+                        </div>
+                    ))}
+                </div>
+
+                <div className="space-y-2">
+                    <h5>Add a repository</h5>
+                    <TextInput
+                        label="Name"
+                        value={name}
+                        onValueChange={setName}
+                        placeholder="Optional"
+                    />
+                    <TextInput
+                        label="URL"
+                        value={url}
+                        onValueChange={setUrl}
+                        placeholder="https://example.com/marketplace.json"
+                        help="The URL of the repository JSON file."
+                    />
+                    <div className="flex justify-end">
+                        <Button
+                            intent="primary"
+                            onClick={handleAdd}
+                            disabled={!url}
+                            loading={isAdding}
+                        >
+                            Add
+                        </Button>
+                    </div>
+                </div>
+            </div>
+        </Modal>
+    )
+}
@@ -0,0 +1,88 @@
+import { useEnableExtensionRuntime, useGetExtensionRuntimeMetrics } from "@/api/hooks/extensions.hooks"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import React from "react"
+
+type RuntimeMetricsModalProps = {
+    open: boolean
+    onOpenChange: (open: boolean) => void
+}
+
+export function RuntimeMetricsModal(props: RuntimeMetricsModalProps) {
+
+    const {
+        open,
+        onOpenChange,
+    } = props
+
+    const { data: metrics, isLoading } = useGetExtensionRuntimeMetrics(open)
+    const { mutate: enableExtension, isPending: isEnabling } = useEnableExtensionRuntime()
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title="Runtime metrics"
+            contentClass="max-w-5xl"
+        >
+            <p className="text-[--muted] text-sm">
+                Extensions that repeatedly exceed their execution time limit are disabled until they are re-enabled or reloaded.
+            </p>
+
+            {isLoading && <LoadingSpinner />}
+
+            {!isLoading && !metrics?.length && <p className="text-[--muted] text-sm italic">No extension has been called yet</p>}
+
+            {!!metrics?.length && <div className="overflow-x-auto">
+                <table className="w-full text-sm">
+                    <thead>
+                    <tr className="text-[--muted] text-left">
+                        <th className="p-2">Extension</th>
+                        <th className="p-2">Calls</th>
+                        <th className="p-2">Errors</th>
+                        <th className="p-2">Timeouts</th>
+                        <th className="p-2">Violations</th>
+                        <th className="p-2" title="Calls during which the heap grew over the memory limit, this is advisory only">Heap warnings</th>
+                        <th className="p-2">Avg. latency</th>
+                        <th className="p-2">Max. latency</th>
+                        <th className="p-2">Active</th>
+                        <th className="p-2">Fetches</th>
+                        <th className="p-2"></th>
+                    </tr>
+                    </thead>
+                    <tbody>
+                    {metrics.map(item => (
+                        <tr key={item.extensionId} className="border-t">
+                            <td className="p-2 font-semibold">{item.extensionId}</td>
+                            <td className="p-2">{item.calls}</td>
+                            <td className="p-2">{item.errors}</td>
+                            <td className="p-2">{item.timeouts}</td>
+                            <td className="p-2">{item.violations}</td>
+                            <td className="p-2">{item.heapWarnings}</td>
+                            <td className="p-2">{item.avgLatencyMs} ms</td>
+                            <td className="p-2">{item.maxLatencyMs} ms</td>
+                            <td className="p-2">{item.activeCalls}/{item.limits.maxConcurrentCalls}</td>
+                            <td className="p-2">{item.pendingFetches}/{item.limits.maxPendingFetches}</td>
+                            <td className="p-2">
+                                {item.disabled && <div className="flex items-center gap-2">
+                                    <Badge intent="alert" className="rounded-md" title={item.disabledReason}>Disabled</Badge>
+                                    <Button
+                                        size="sm"
+                                        intent="gray-outline"
+                                        loading={isEnabling}
+                                        onClick={() => enableExtension({ id: item.extensionId })}
+                                    >
+                                        Enable
+                                    </Button>
+                                </div>}
+                            </td>
+                        </tr>
+                    ))}
+                    </tbody>
+                </table>
+            </div>}
+        </Modal>
+    )
+}
@@ -0,0 +1,94 @@
+import { useAddExtensionTrustedKey, useGetExtensionTrustedKeys, useRemoveExtensionTrustedKey } from "@/api/hooks/extensions.hooks"
+import { Button, IconButton } from "@/components/ui/button"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { TextInput } from "@/components/ui/text-input"
+import React from "react"
+import { BiTrash } from "react-icons/bi"
+
+type TrustedKeysModalProps = {
+    open: boolean
+    onOpenChange: (open: boolean) => void
+}
+
+export function TrustedKeysModal(props: TrustedKeysModalProps) {
+
+    const {
+        open,
+        onOpenChange,
+    } = props
+
+    const { data: keys, isLoading } = useGetExtensionTrustedKeys()
+    const { mutate: addKey, isPending: isAdding } = useAddExtensionTrustedKey()
+    const { mutate: removeKey, isPending: isRemoving } = useRemoveExtensionTrustedKey()
+
+    const [name, setName] = React.useState("")
+    const [publicKey, setPublicKey] = React.useState("")
+
+    function handleAdd() {
+        addKey({ name, publicKey }, {
+            onSuccess: () => {
+                setName("")
+                setPublicKey("")
+            },
+        })
+    }
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title="Trusted keys"
+            contentClass="max-w-2xl"
+        >
+            <p className="text-[--muted] text-sm">
+                Extensions signed with these keys are marked as verified.
+                Updates signed with a different key than the one used at install are refused, unless the new key is trusted.
+            </p>
+
+            {isLoading && <LoadingSpinner />}
+
+            <div className="space-y-2">
+                {keys?.map(key => (
+                    <div key={key.fingerprint} className="flex items-center gap-2 border rounded-[--radius-md] p-2">
+                        <div className="flex-1 min-w-0">
+                            <p className="font-semibold">{key.name || "Unnamed key"}</p>
+                            <p className="text-[--muted] text-sm break-all">{key.publicKey}</p>
+                        </div>
+                        <IconButton
+                            icon={<BiTrash />}
+                            intent="alert-basic"
+                            size="sm"
+                            disabled={isRemoving}
+                            onClick={() => removeKey({ fingerprint: key.fingerprint })}
+                        />
+                    </div>
+                ))}
+                {!isLoading && !keys?.length && <p className="text-[--muted] text-sm italic">No trusted keys</p>}
+            </div>
+
+            <div className="space-y-2">
+                <TextInput
+                    label="Name"
+                    placeholder="Publisher"
+                    value={name}
+                    onValueChange={setName}
+                />
+                <TextInput
+                    label="Public key"
+                    placeholder="Base64-encoded ed25519 public key"
+                    value={publicKey}
+                    onValueChange={setPublicKey}
+                />
+                <Button
+                    intent="primary-subtle"
+                    disabled={!publicKey}
+                    loading={isAdding}
+                    onClick={handleAdd}
+                >
+                    Add key
+                </Button>
+            </div>
+        </Modal>
+    )
+}
@@ -1,9 +1,9 @@
 import { atomWithStorage } from "jotai/utils"
 
-// Default marketplace URL
+// Empty URL lists the extensions of all repositories
 export const DEFAULT_MARKETPLACE_URL = ""
 
-// Atom to store the marketplace URL in localStorage
+// Atom to store the selected repository URL in localStorage
 export const marketplaceUrlAtom = atomWithStorage<string>(
     "marketplace-url",
     DEFAULT_MARKETPLACE_URL,
@@ -2,10 +2,16 @@ import { HibikeManga_ChapterDetails } from "@/api/generated/types"
 import { Button } from "@/components/ui/button"
 import React from "react"
 import { FaDownload } from "react-icons/fa"
+import { IoCheckmarkDone } from "react-icons/io5"
+import { MdOutlineRemoveDone } from "react-icons/md"
 
 type ChapterListBulkActionsProps = {
     rowSelectedChapters: HibikeManga_ChapterDetails[] | undefined
     onDownloadSelected: (chapters: HibikeManga_ChapterDetails[]) => void
+    onMarkSelected: (chapters: HibikeManga_ChapterDetails[], read: boolean) => void
+    // Returns true if the chapter can be downloaded
+    isDownloadable: (chapter: HibikeManga_ChapterDetails) => boolean
+    isMarking?: boolean
 }
 
 export function ChapterListBulkActions(props: ChapterListBulkActionsProps) {
@@ -13,18 +19,25 @@ export function ChapterListBulkActions(props: ChapterListBulkActionsProps) {
     const {
         rowSelectedChapters,
         onDownloadSelected,
+        onMarkSelected,
+        isDownloadable,
+        isMarking,
         ...rest
     } = props
 
+    const downloadableChapters = React.useMemo(() => {
+        return rowSelectedChapters?.filter(ch => isDownloadable(ch)) ?? []
+    }, [rowSelectedChapters, isDownloadable])
+
     const handleDownloadSelected = React.useCallback(() => {
-        onDownloadSelected(rowSelectedChapters || [])
-    }, [onDownloadSelected, rowSelectedChapters])
+        onDownloadSelected(downloadableChapters)
+    }, [onDownloadSelected, downloadableChapters])
 
-    if (rowSelectedChapters?.length === 0) return null
+    if (!rowSelectedChapters?.length) return null
 
     return (
-        <>
-            <Button
+        <div className="flex flex-wrap gap-2 items-center">
+            {!!downloadableChapters.length && <Button
                 onClick={handleDownloadSelected}
                 intent="white"
                 size="sm"
@@ -32,8 +45,28 @@ export function ChapterListBulkActions(props: ChapterListBulkActionsProps) {
                 className="animate-pulse"
                 data-download-selected-chapters-button
             >
-                Download selected chapters ({rowSelectedChapters?.length})
+                Download selected chapters ({downloadableChapters.length})
+            </Button>}
+            <Button
+                onClick={() => onMarkSelected(rowSelectedChapters, true)}
+                intent="gray-outline"
+                size="sm"
+                leftIcon={<IoCheckmarkDone />}
+                loading={isMarking}
+                data-mark-selected-chapters-read-button
+            >
+                Mark as read
+            </Button>
+            <Button
+                onClick={() => onMarkSelected(rowSelectedChapters, false)}
+                intent="gray-outline"
+                size="sm"
+                leftIcon={<MdOutlineRemoveDone />}
+                disabled={isMarking}
+                data-mark-selected-chapters-unread-button
+            >
+                Mark as unread
             </Button>
-        </>
+        </div>
     )
 }
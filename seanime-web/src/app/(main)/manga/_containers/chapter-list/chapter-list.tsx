@@ -1,9 +1,18 @@
-import { AL_MangaDetailsById_Media, HibikeManga_ChapterDetails, Manga_Entry, Manga_MediaDownloadData } from "@/api/generated/types"
+import {
+    AL_MangaDetailsById_Media,
+    HibikeManga_ChapterDetails,
+    Manga_Entry,
+    Manga_MediaDownloadData,
+    Models_MangaReadingState,
+} from "@/api/generated/types"
 import { useEmptyMangaEntryCache } from "@/api/hooks/manga.hooks"
+import { useGetMangaReadingState, useMarkMangaChapters } from "@/api/hooks/manga_reading_state.hooks"
 import { SeaCommandInjectableItem, useSeaCommandInject } from "@/app/(main)/_features/sea-command/use-inject"
 import { ChapterListBulkActions } from "@/app/(main)/manga/_containers/chapter-list/_components/chapter-list-bulk-actions"
 import { DownloadedChapterList } from "@/app/(main)/manga/_containers/chapter-list/downloaded-chapter-list"
+import { MangaAutoDownloaderModal } from "@/app/(main)/manga/_containers/chapter-list/manga-auto-downloader-modal"
 import { MangaManualMappingModal } from "@/app/(main)/manga/_containers/chapter-list/manga-manual-mapping-modal"
+import { MangaProviderChainModal } from "@/app/(main)/manga/_containers/chapter-list/manga-provider-chain-modal"
 import { ChapterReaderDrawer } from "@/app/(main)/manga/_containers/chapter-reader/chapter-reader-drawer"
 import { __manga_selectedChapterAtom } from "@/app/(main)/manga/_lib/handle-chapter-reader"
 import { useHandleMangaChapters } from "@/app/(main)/manga/_lib/handle-manga-chapters"
@@ -13,9 +22,12 @@ import { LANGUAGES_LIST } from "@/app/(main)/manga/_lib/language-map"
 import { primaryPillCheckboxClasses } from "@/components/shared/classnames"
 import { ConfirmationDialog, useConfirmationDialog } from "@/components/shared/confirmation-dialog"
 import { LuffyError } from "@/components/shared/luffy-error"
+import { Alert } from "@/components/ui/alert"
+import { Badge } from "@/components/ui/badge"
 import { Button, IconButton } from "@/components/ui/button"
 import { Checkbox } from "@/components/ui/checkbox"
 import { DataGrid, defineDataGridColumns } from "@/components/ui/datagrid"
+import { DropdownMenu, DropdownMenuItem, DropdownMenuLabel } from "@/components/ui/dropdown-menu"
 import { LoadingSpinner } from "@/components/ui/loading-spinner"
 import { Select } from "@/components/ui/select"
 import { useSetAtom } from "jotai/react"
@@ -23,7 +35,8 @@ import React from "react"
 import { FaRedo } from "react-icons/fa"
 import { GiOpenBook } from "react-icons/gi"
 import { HiOutlineSearchCircle } from "react-icons/hi"
-import { IoBookOutline, IoLibrary } from "react-icons/io5"
+import { IoMdCheckmark } from "react-icons/io"
+import { IoBookOutline, IoLibrary, IoSwapVertical } from "react-icons/io5"
 import { MdOutlineDownloadForOffline, MdOutlineOfflinePin } from "react-icons/md"
 
 type ChapterListProps = {
@@ -65,8 +78,28 @@ export function ChapterList(props: ChapterListProps) {
         chapterContainer,
         chapterContainerLoading,
         chapterContainerError,
+        // Merged chapters
+        isMerged,
+        setIsMerged,
+        mergedChapterContainer,
     } = useHandleMangaChapters(mediaId)
 
+    // Other chapters with the same number when chapters are merged
+    // [provider$chapterId]: alternatives
+    const alternativesMap = React.useMemo(() => {
+        const map = new Map<string, HibikeManga_ChapterDetails[]>()
+        for (const merged of mergedChapterContainer?.chapters ?? []) {
+            if (merged.chapter && !!merged.alternatives?.length) {
+                map.set(`${merged.chapter.provider}$${merged.chapter.id}`, merged.alternatives)
+            }
+        }
+        return map
+    }, [mergedChapterContainer])
+
+    const getProviderLabel = React.useCallback((provider: string) => {
+        return providerOptions.find(n => n.value === provider)?.label ?? provider
+    }, [providerOptions])
+
 
     // Keep track of chapter numbers as integers
     // This is used to filter the chapters
@@ -106,15 +139,52 @@ export function ChapterList(props: ChapterListProps) {
 
     const { inject, remove } = useSeaCommandInject()
 
+    /**
+     * Reading state
+     * [provider$chapterId]: state
+     */
+    const { data: readingState } = useGetMangaReadingState(mediaId)
+    const { mutate: markChapters, isPending: isMarkingChapters } = useMarkMangaChapters(mediaId)
+
+    const readingStateMap = React.useMemo(() => {
+        const map = new Map<string, Models_MangaReadingState>()
+        for (const state of readingState?.chapters ?? []) {
+            map.set(`${state.provider}$${state.chapterId}`, state)
+        }
+        return map
+    }, [readingState])
+
+    const getChapterReadingState = React.useCallback((chapter: HibikeManga_ChapterDetails) => {
+        return readingStateMap.get(`${chapter.provider}$${chapter.id}`)
+    }, [readingStateMap])
+
+    const handleMarkChapters = React.useCallback((chapters: HibikeManga_ChapterDetails[], read: boolean) => {
+        if (!mediaId || !chapters.length) return
+        markChapters({
+            mediaId: Number(mediaId),
+            provider: chapters[0].provider,
+            chapters: chapters.map(ch => ({ chapterId: ch.id, chapterNumber: ch.chapter })),
+            read: read,
+            // Update the progress when marking chapters past the current progress as read
+            syncProgress: read && !!entry.listData,
+            totalChapters: entry.media?.chapters || 0,
+        }, {
+            onSuccess: () => {
+                resetRowSelection()
+            },
+        })
+    }, [mediaId, entry])
+
     /**
      * Function to filter unread chapters
      */
     const retainUnreadChapters = React.useCallback((chapter: HibikeManga_ChapterDetails) => {
+        if (getChapterReadingState(chapter)?.read) return false
         if (!entry.listData || !chapterIdToNumbersMap.has(chapter.id) || !entry.listData?.progress) return true
 
         const chapterNumber = chapterIdToNumbersMap.get(chapter.id)
         return !!chapterNumber && chapterNumber > entry.listData?.progress
-    }, [chapterIdToNumbersMap, chapterContainer, entry])
+    }, [chapterIdToNumbersMap, chapterContainer, entry, getChapterReadingState])
 
     const confirmReloadSource = useConfirmationDialog({
         title: "Reload sources",
@@ -137,14 +207,21 @@ export function ChapterList(props: ChapterListProps) {
             header: "Name",
             size: 90,
         },
-        ...(selectedExtension?.settings?.supportsMultiScanlator ? [{
+        ...(isMerged ? [{
+            id: "source",
+            header: "Source",
+            size: 30,
+            accessorFn: (row: any) => getProviderLabel(row.provider),
+            enableSorting: true,
+        }] : []),
+        ...((selectedExtension?.settings?.supportsMultiScanlator || isMerged) ? [{
             id: "scanlator",
             header: "Scanlator",
             size: 40,
             accessorFn: (row: any) => row.scanlator,
             enableSorting: true,
         }] : []),
-        ...(selectedExtension?.settings?.supportsMultiLanguage ? [{
+        ...((selectedExtension?.settings?.supportsMultiLanguage || isMerged) ? [{
             id: "language",
             header: "Language",
             size: 20,
@@ -160,6 +237,20 @@ export function ChapterList(props: ChapterListProps) {
                 return chapterIdToNumbersMap.get(row.id)
             },
         },
+        {
+            id: "progress",
+            header: "Progress",
+            size: 15,
+            enableSorting: false,
+            enableGlobalFilter: false,
+            cell: ({ row }) => {
+                const state = getChapterReadingState(row.original)
+                if (!state) return null
+                if (state.read) return <p className="text-[--muted] flex items-center gap-1"><IoMdCheckmark /> Read</p>
+                if (!state.totalPages) return null
+                return <p className="text-[--muted]">Page {state.currentPage + 1} / {state.totalPages}</p>
+            },
+        },
         {
             id: "_actions",
             size: 10,
@@ -177,6 +268,28 @@ export function ChapterList(props: ChapterListProps) {
                         />}
                         {isChapterQueued(row.original) && <p className="text-[--muted]">Queued</p>}
                         {isChapterDownloaded(row.original) && <p className="text-[--muted] px-1"><MdOutlineOfflinePin className="text-2xl" /></p>}
+                        {alternativesMap.has(`${row.original.provider}$${row.original.id}`) && <DropdownMenu
+                            trigger={<IconButton
+                                intent="gray-basic"
+                                size="sm"
+                                icon={<IoSwapVertical />}
+                            />}
+                        >
+                            <DropdownMenuLabel>Alternatives</DropdownMenuLabel>
+                            {alternativesMap.get(`${row.original.provider}$${row.original.id}`)?.map(alt => (
+                                <DropdownMenuItem
+                                    key={`${alt.provider}$${alt.id}`}
+                                    onClick={() => setSelectedChapter({
+                                        chapterId: alt.id,
+                                        chapterNumber: alt.chapter,
+                                        provider: alt.provider,
+                                        mediaId: Number(mediaId),
+                                    })}
+                                >
+                                    {[getProviderLabel(alt.provider), alt.scanlator, alt.language].filter(Boolean).join(" · ")}
+                                </DropdownMenuItem>
+                            ))}
+                        </DropdownMenu>}
                         <IconButton
                             intent="gray-subtle"
                             size="sm"
@@ -192,9 +305,24 @@ export function ChapterList(props: ChapterListProps) {
                 )
             },
         },
-    ]), [chapterIdToNumbersMap, selectedExtension, isSendingDownloadRequest, isChapterDownloaded, downloadData, mediaId])
+    ]), [chapterIdToNumbersMap, selectedExtension, isSendingDownloadRequest, isChapterDownloaded, downloadData, mediaId, getChapterReadingState, isMerged,
+        alternativesMap, getProviderLabel])
+
+    const unreadChapters = React.useMemo(() => chapterContainer?.chapters?.filter(ch => retainUnreadChapters(ch)) ?? [],
+        [chapterContainer, entry, retainUnreadChapters])
 
-    const unreadChapters = React.useMemo(() => chapterContainer?.chapters?.filter(ch => retainUnreadChapters(ch)) ?? [], [chapterContainer, entry])
+    /**
+     * Chapter to open with "Continue reading"
+     * The last chapter left unfinished, or the first unread chapter
+     */
+    const continueChapter = React.useMemo(() => {
+        const resume = readingState?.resume
+        if (resume && !resume.read && resume.currentPage > 0) {
+            const chapter = chapterContainer?.chapters?.find(ch => ch.id === resume.chapterId && ch.provider === resume.provider)
+            if (chapter) return chapter
+        }
+        return unreadChapters[0]
+    }, [readingState, chapterContainer, unreadChapters])
     const allChapters = React.useMemo(() => chapterContainer?.chapters?.toReversed() ?? [], [chapterContainer])
 
     /**
@@ -346,6 +474,37 @@ export function ChapterList(props: ChapterListProps) {
                         Manual match
                     </Button>
                 </MangaManualMappingModal>
+
+                <MangaProviderChainModal entry={entry}>
+                    <Button
+                        leftIcon={<IoSwapVertical className="text-lg" />}
+                        intent="gray-outline"
+                        size="sm"
+                    >
+                        Fallback sources
+                    </Button>
+                </MangaProviderChainModal>
+
+                {!!chapterContainer?.provider && !!selectedProvider && chapterContainer.provider !== selectedProvider && (
+                    <Badge intent="warning" size="lg" data-chapter-list-fallback-provider-badge>
+                        Using fallback: {providerOptions.find(n => n.value === chapterContainer.provider)?.label ?? chapterContainer.provider}
+                    </Badge>
+                )}
+
+                <MangaAutoDownloaderModal
+                    entry={entry}
+                    provider={selectedProvider}
+                    scanlator={selectedFilters.scanlators[0] || undefined}
+                    language={selectedFilters.language || undefined}
+                >
+                    <Button
+                        leftIcon={<MdOutlineDownloadForOffline className="text-lg" />}
+                        intent="gray-outline"
+                        size="sm"
+                    >
+                        Auto download
+                    </Button>
+                </MangaAutoDownloaderModal>
             </div>
 
             {(selectedExtension?.settings?.supportsMultiLanguage || selectedExtension?.settings?.supportsMultiScanlator) && (
@@ -399,15 +558,15 @@ export function ChapterList(props: ChapterListProps) {
                                     <h2 className="px-1">Chapters</h2>
                                     <div className="flex flex-1"></div>
                                     <div>
-                                        {!!unreadChapters?.length && <Button
+                                        {!!continueChapter && <Button
                                             intent="white"
                                             rounded
                                             leftIcon={<IoBookOutline />}
                                             onClick={() => {
                                                 setSelectedChapter({
-                                                    chapterId: unreadChapters[0].id,
-                                                    chapterNumber: unreadChapters[0].chapter,
-                                                    provider: unreadChapters[0].provider,
+                                                    chapterId: continueChapter.id,
+                                                    chapterNumber: continueChapter.chapter,
+                                                    provider: continueChapter.provider,
                                                     mediaId: Number(mediaId),
                                                 })
                                             }}
@@ -434,14 +593,34 @@ export function ChapterList(props: ChapterListProps) {
                                             fieldClass="w-fit"
                                             {...primaryPillCheckboxClasses}
                                         />
+                                        <Checkbox
+                                            label={<span className="flex gap-2 items-center"><IoSwapVertical /> Merge sources</span>}
+                                            value={isMerged}
+                                            onValueChange={v => setIsMerged(v as boolean)}
+                                            fieldClass="w-fit"
+                                            {...primaryPillCheckboxClasses}
+                                        />
                                     </div>
 
+                                    {!!mergedChapterContainer?.gaps?.length && (
+                                        <Alert
+                                            intent="warning-basic"
+                                            title="Missing chapters"
+                                            description={`Chapters ${mergedChapterContainer.gaps.map(gap => gap.start === gap.end
+                                                ? gap.start
+                                                : `${gap.start}–${gap.end}`).join(", ")} could not be found. Add fallback sources to find them.`}
+                                        />
+                                    )}
+
                                     <ChapterListBulkActions
                                         rowSelectedChapters={rowSelectedChapters}
                                         onDownloadSelected={chapters => {
                                             downloadChapters(chapters)
                                             resetRowSelection()
                                         }}
+                                        onMarkSelected={handleMarkChapters}
+                                        isDownloadable={ch => !isChapterDownloaded(ch) && !isChapterQueued(ch)}
+                                        isMarking={isMarkingChapters}
                                     />
 
                                     <DataGrid<HibikeManga_ChapterDetails>
@@ -450,7 +629,7 @@ export function ChapterList(props: ChapterListProps) {
                                         rowCount={chapters.length}
                                         isLoading={chapterContainerLoading}
                                         rowSelectionPrimaryKey="id"
-                                        enableRowSelection={row => (!isChapterDownloaded(row.original) && !isChapterQueued(row.original))}
+                                        enableRowSelection
                                         initialState={{
                                             pagination: {
                                                 pageIndex: 0,
@@ -463,7 +642,7 @@ export function ChapterList(props: ChapterListProps) {
                                         hideColumns={[
                                             {
                                                 below: 1000,
-                                                hide: ["number"],
+                                                hide: ["number", "progress"],
                                             },
                                             {
                                                 below: 600,
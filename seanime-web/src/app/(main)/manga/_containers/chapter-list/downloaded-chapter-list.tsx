@@ -4,7 +4,11 @@
 
 
 import { Manga_Entry, Manga_MediaDownloadData } from "@/api/generated/types"
-import { useDeleteMangaDownloadedChapters } from "@/api/hooks/manga_download.hooks"
+import {
+    useConvertMangaDownloads,
+    useDeleteMangaDownloadedChapters,
+    useExportMangaDownloadedChapters,
+} from "@/api/hooks/manga_download.hooks"
 
 import { useSetCurrentChapter } from "@/app/(main)/manga/_lib/handle-chapter-reader"
 import { MangaDownloadChapterItem, useMangaEntryDownloadedChapters } from "@/app/(main)/manga/_lib/handle-manga-downloads"
@@ -45,6 +49,10 @@ export function DownloadedChapterList(props: DownloadedChapterListProps) {
 
     const { mutate: deleteChapters, isPending: isDeletingChapter } = useDeleteMangaDownloadedChapters(String(entry.mediaId), selectedProvider)
 
+    const { mutate: exportChapters, isPending: isExporting } = useExportMangaDownloadedChapters()
+
+    const { mutate: convertDownloads, isPending: isConverting } = useConvertMangaDownloads()
+
     const downloadedOrQueuedChapters = useMangaEntryDownloadedChapters()
 
     /**
@@ -159,6 +167,23 @@ export function DownloadedChapterList(props: DownloadedChapterListProps) {
         }
     }, [selectedChapters])
 
+    /**
+     * Export the selected chapters, or all downloaded chapters if none are selected
+     */
+    const handleExport = React.useCallback((format: "cbz" | "epub", bundle: boolean) => {
+        exportChapters({
+            mediaId: entry.mediaId,
+            format,
+            bundle,
+            chapters: selectedChapters.map(chapter => ({
+                mediaId: entry.mediaId,
+                provider: chapter.provider,
+                chapterId: chapter.chapterId,
+                chapterNumber: chapter.chapterNumber,
+            })),
+        })
+    }, [selectedChapters, entry.mediaId])
+
     if (!data || Object.keys(data.downloaded).length === 0 && Object.keys(data.queued).length === 0) return null
 
     return (
@@ -175,6 +200,23 @@ export function DownloadedChapterList(props: DownloadedChapterListProps) {
                         fieldClass="w-fit"
                         {...primaryPillCheckboxClasses}
                     />
+
+                    <div className="flex flex-1 flex-wrap justify-end gap-2">
+                        <Button intent="gray-subtle" size="sm" loading={isExporting} onClick={() => handleExport("cbz", false)}>
+                            Export as CBZ
+                        </Button>
+                        <Button intent="gray-subtle" size="sm" loading={isExporting} onClick={() => handleExport("epub", true)}>
+                            Export as EPUB
+                        </Button>
+                        <Button
+                            intent="gray-subtle"
+                            size="sm"
+                            loading={isConverting}
+                            onClick={() => convertDownloads({ mediaId: entry.mediaId, copyToInternalStorage: false })}
+                        >
+                            Convert to CBZ
+                        </Button>
+                    </div>
                 </div>
 
                 {!!selectedChapters.length && <div
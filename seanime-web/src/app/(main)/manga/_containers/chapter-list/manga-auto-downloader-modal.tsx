@@ -0,0 +1,133 @@
+import { Manga_AutoDownloaderRuleChapterType, Manga_Entry } from "@/api/generated/types"
+import {
+    useCreateMangaAutoDownloaderRule,
+    useDeleteMangaAutoDownloaderRule,
+    useGetMangaAutoDownloaderRulesByManga,
+    useUpdateMangaAutoDownloaderRule,
+} from "@/api/hooks/manga_auto_downloader.hooks"
+import { useServerStatus } from "@/app/(main)/_hooks/use-server-status"
+import { AppLayoutStack } from "@/components/ui/app-layout"
+import { Button } from "@/components/ui/button"
+import { Modal } from "@/components/ui/modal"
+import { Select } from "@/components/ui/select"
+import { Switch } from "@/components/ui/switch"
+import React from "react"
+
+type MangaAutoDownloaderModalProps = {
+    entry: Manga_Entry
+    provider: string | null | undefined
+    scanlator: string | undefined
+    language: string | undefined
+    children: React.ReactElement
+}
+
+export function MangaAutoDownloaderModal(props: MangaAutoDownloaderModalProps) {
+
+    const {
+        children,
+        ...rest
+    } = props
+
+    return (
+        <Modal
+            data-manga-auto-downloader-modal
+            title="Auto download new chapters"
+            trigger={children}
+            contentClass="max-w-xl"
+        >
+            <Content {...rest} />
+        </Modal>
+    )
+}
+
+function Content(props: Omit<MangaAutoDownloaderModalProps, "children">) {
+    const { entry, provider, scanlator, language } = props
+
+    const serverStatus = useServerStatus()
+
+    const { data: rules } = useGetMangaAutoDownloaderRulesByManga(entry.mediaId, true)
+    const rule = rules?.[0]
+
+    const { mutate: createRule, isPending: isCreating } = useCreateMangaAutoDownloaderRule()
+    const { mutate: updateRule, isPending: isUpdating } = useUpdateMangaAutoDownloaderRule()
+    const { mutate: deleteRule, isPending: isDeleting } = useDeleteMangaAutoDownloaderRule(rule?.dbId)
+
+    const [chapterType, setChapterType] = React.useState<Manga_AutoDownloaderRuleChapterType>(rule?.chapterType || "new")
+
+    React.useEffect(() => {
+        if (rule) setChapterType(rule.chapterType)
+    }, [rule])
+
+    const scanlators = !!scanlator ? [scanlator] : []
+    const languages = !!language ? [language] : []
+
+    function handleSave() {
+        if (!provider) return
+        if (!rule) {
+            createRule({
+                enabled: true,
+                mediaId: entry.mediaId,
+                provider,
+                scanlators,
+                languages,
+                chapterType,
+            })
+        } else {
+            updateRule({
+                rule: {
+                    ...rule,
+                    provider,
+                    scanlators,
+                    languages,
+                    chapterType,
+                },
+            })
+        }
+    }
+
+    return (
+        <AppLayoutStack>
+            {!serverStatus?.settings?.manga?.mangaAutoDownloaderEnabled && (
+                <p className="text-[--muted] text-sm">
+                    The manga auto downloader is disabled. You can enable it in the settings.
+                </p>
+            )}
+
+            <p className="text-sm">
+                New chapters from <strong>{provider || "-"}</strong>
+                {!!scanlator && <> by <strong>{scanlator}</strong></>}
+                {!!language && <> in <strong>{language}</strong></>} will be added to the download queue.
+                The current source filters are used.
+            </p>
+
+            <Select
+                label="Chapters"
+                value={chapterType}
+                onValueChange={v => setChapterType(v as Manga_AutoDownloaderRuleChapterType)}
+                options={[
+                    { value: "new", label: "Only chapters released from now on" },
+                    { value: "after-progress", label: "All chapters after my progress" },
+                ]}
+            />
+
+            {!!rule && (
+                <Switch
+                    side="right"
+                    label="Enabled"
+                    value={rule.enabled}
+                    onValueChange={v => updateRule({ rule: { ...rule, enabled: v } })}
+                    disabled={isUpdating}
+                />
+            )}
+
+            <div className="flex gap-2 justify-end">
+                {!!rule && <Button intent="alert-subtle" loading={isDeleting} onClick={() => deleteRule()}>
+                    Delete
+                </Button>}
+                <Button intent="white" loading={isCreating || isUpdating} disabled={!provider} onClick={handleSave}>
+                    {rule ? "Update" : "Create"}
+                </Button>
+            </div>
+        </AppLayoutStack>
+    )
+}
@@ -0,0 +1,130 @@
+import { Manga_Entry } from "@/api/generated/types"
+import { useGetMangaProviderChain, useGetMangaProviderHealth, useSaveMangaProviderChain } from "@/api/hooks/manga_provider_chain.hooks"
+import { useHandleMangaProviderExtensions } from "@/app/(main)/manga/_lib/handle-manga-providers"
+import { useSelectedMangaProvider } from "@/app/(main)/manga/_lib/handle-manga-selected-provider"
+import { AppLayoutStack } from "@/components/ui/app-layout"
+import { Badge } from "@/components/ui/badge"
+import { Button, IconButton } from "@/components/ui/button"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { Select } from "@/components/ui/select"
+import React from "react"
+import { BiDownArrowAlt, BiUpArrowAlt, BiX } from "react-icons/bi"
+
+type MangaProviderChainModalProps = {
+    entry: Manga_Entry
+    children: React.ReactElement
+}
+
+export function MangaProviderChainModal(props: MangaProviderChainModalProps) {
+
+    const {
+        children,
+        entry,
+        ...rest
+    } = props
+
+    return (
+        <>
+            <Modal
+                data-manga-provider-chain-modal
+                title="Fallback sources"
+                trigger={children}
+                contentClass="max-w-xl"
+            >
+                <Content entry={entry} />
+            </Modal>
+        </>
+    )
+}
+
+function Content({ entry }: { entry: Manga_Entry }) {
+    const { providerOptions } = useHandleMangaProviderExtensions(String(entry.mediaId))
+    const { selectedProvider } = useSelectedMangaProvider(entry.mediaId)
+
+    const { data: chain, isLoading } = useGetMangaProviderChain(entry.mediaId)
+    const { data: health } = useGetMangaProviderHealth()
+    const { mutate: save, isPending } = useSaveMangaProviderChain()
+
+    const [providers, setProviders] = React.useState<string[]>([])
+
+    React.useEffect(() => {
+        if (chain) setProviders(chain)
+    }, [chain])
+
+    const getLabel = (provider: string) => providerOptions.find(n => n.value === provider)?.label ?? provider
+
+    function move(idx: number, offset: number) {
+        setProviders(prev => {
+            const next = [...prev]
+            const [item] = next.splice(idx, 1)
+            next.splice(idx + offset, 0, item)
+            return next
+        })
+    }
+
+    if (isLoading) return <LoadingSpinner />
+
+    return (
+        <AppLayoutStack>
+            <p className="text-[--muted]">
+                When the current source fails or is missing chapters, the next sources are tried in order.
+            </p>
+
+            <div className="space-y-2">
+                {providers.map((provider, idx) => {
+                    const providerHealth = health?.find(n => n.provider === provider)
+                    return (
+                        <div key={provider} className="flex items-center gap-2 border rounded-[--radius-md] px-3 py-2">
+                            <span className="text-[--muted] w-6">{idx + 1}.</span>
+                            <span className="flex-1">{getLabel(provider)}</span>
+                            {providerHealth && !providerHealth.healthy && (
+                                <Badge intent="alert" size="sm">Unhealthy</Badge>
+                            )}
+                            <IconButton
+                                icon={<BiUpArrowAlt />}
+                                intent="gray-basic"
+                                size="sm"
+                                disabled={idx === 0}
+                                onClick={() => move(idx, -1)}
+                            />
+                            <IconButton
+                                icon={<BiDownArrowAlt />}
+                                intent="gray-basic"
+                                size="sm"
+                                disabled={idx === providers.length - 1}
+                                onClick={() => move(idx, 1)}
+                            />
+                            <IconButton
+                                icon={<BiX />}
+                                intent="alert-basic"
+                                size="sm"
+                                onClick={() => setProviders(prev => prev.filter(n => n !== provider))}
+                            />
+                        </div>
+                    )
+                })}
+                {!providers.length && <p className="text-sm text-[--muted]">No fallback sources</p>}
+            </div>
+
+            <Select
+                placeholder="Add a source"
+                options={providerOptions.filter(n => n.value !== selectedProvider && !providers.includes(n.value))}
+                value=""
+                onValueChange={v => {
+                    if (v) setProviders(prev => [...prev, v])
+                }}
+            />
+
+            <div className="flex justify-end">
+                <Button
+                    intent="primary"
+                    loading={isPending}
+                    onClick={() => save({ mediaId: entry.mediaId, providers })}
+                >
+                    Save
+                </Button>
+            </div>
+        </AppLayoutStack>
+    )
+}
@@ -1,9 +1,10 @@
 import { AL_BaseManga, Manga_ChapterContainer, Manga_EntryListData } from "@/api/generated/types"
 import { useGetMangaEntryPages, useUpdateMangaProgress } from "@/api/hooks/manga.hooks"
+import { useGetMangaReadingState, useUpdateMangaReadingPage } from "@/api/hooks/manga_reading_state.hooks"
 import { useSeaCommandInject } from "@/app/(main)/_features/sea-command/use-inject"
 import { useServerStatus } from "@/app/(main)/_hooks/use-server-status"
 import { MangaHorizontalReader } from "@/app/(main)/manga/_containers/chapter-reader/_components/chapter-horizontal-reader"
-import { MangaVerticalReader } from "@/app/(main)/manga/_containers/chapter-reader/_components/chapter-vertical-reader"
+import { ___manga_scrollSignalAtom, MangaVerticalReader } from "@/app/(main)/manga/_containers/chapter-reader/_components/chapter-vertical-reader"
 import { MangaReaderBar } from "@/app/(main)/manga/_containers/chapter-reader/manga-reader-bar"
 import {
     useCurrentChapter,
@@ -30,6 +31,7 @@ import { Button, IconButton } from "@/components/ui/button"
 import { cn } from "@/components/ui/core/styling"
 import { Drawer } from "@/components/ui/drawer"
 import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { useDebounce } from "@/hooks/use-debounce"
 import { useAtom, useAtomValue, useSetAtom } from "jotai/react"
 import mousetrap from "mousetrap"
 import React from "react"
@@ -60,7 +62,7 @@ export function ChapterReaderDrawer(props: ChapterDrawerProps) {
     const currentChapter = useCurrentChapter()
     const setCurrentChapter = useSetCurrentChapter()
 
-    const setCurrentPageIndex = useSetAtom(__manga_currentPageIndexAtom)
+    const [currentPageIndex, setCurrentPageIndex] = useAtom(__manga_currentPageIndexAtom)
     const setCurrentPaginationMapIndex = useSetAtom(__manga_currentPaginationMapIndexAtom)
 
     const [readingMode, setReadingMode] = useAtom(__manga_readingModeAtom)
@@ -185,6 +187,66 @@ export function ChapterReaderDrawer(props: ChapterDrawerProps) {
         }
     }, [pageContainer?.pages, chapterContainer?.chapters])
 
+    /**
+     * Reading state
+     * The last page read is saved and restored when the chapter is opened again
+     */
+    const { data: readingState } = useGetMangaReadingState(entry.mediaId)
+    const { mutate: updateReadingPage } = useUpdateMangaReadingPage()
+    const setScrollSignal = useSetAtom(___manga_scrollSignalAtom)
+    const [restoredChapterId, setRestoredChapterId] = React.useState<string | null>(null)
+
+    // Restore the saved page once the pagination map of the chapter is ready
+    React.useEffect(() => {
+        // Restore again when the reader is reopened
+        if (!currentChapter?.chapterId) {
+            setRestoredChapterId(null)
+            return
+        }
+        if (!readingState || !pageContainer?.pages?.length) return
+        if (restoredChapterId === currentChapter.chapterId) return
+        if (Object.values(paginationMap).flat().length !== pageContainer.pages.length) return
+
+        setRestoredChapterId(currentChapter.chapterId)
+
+        const state = readingState.chapters?.find(n => n.chapterId === currentChapter.chapterId && n.provider === currentChapter.provider)
+        if (!state || state.read || state.currentPage <= 0 || state.currentPage >= pageContainer.pages.length) return
+
+        const mapIndex = Object.keys(paginationMap).find(key => paginationMap[Number(key)].includes(state.currentPage))
+        setCurrentPaginationMapIndex(Number(mapIndex ?? state.currentPage))
+        setCurrentPageIndex(state.currentPage)
+        setScrollSignal(p => p + 1)
+    }, [currentChapter?.chapterId, readingState, pageContainer?.pages, paginationMap])
+
+    // Save the current page
+    const pageProgress = React.useMemo(() => {
+        if (!currentChapter || restoredChapterId !== currentChapter.chapterId || !pageContainer?.pages?.length) return null
+        return {
+            chapterId: currentChapter.chapterId,
+            provider: currentChapter.provider,
+            chapterNumber: currentChapter.chapterNumber,
+            currentPage: currentPageIndex,
+            totalPages: pageContainer.pages.length,
+        }
+    }, [currentChapter, restoredChapterId, currentPageIndex, pageContainer?.pages?.length])
+    const debouncedPageProgress = useDebounce(pageProgress, 1000)
+
+    const lastSavedPageRef = React.useRef<string | null>(null)
+    React.useEffect(() => {
+        if (!debouncedPageProgress) return
+        const key = `${debouncedPageProgress.provider}$${debouncedPageProgress.chapterId}$${debouncedPageProgress.currentPage}`
+        if (lastSavedPageRef.current === key) return
+        // Avoid creating a state for chapters that were only opened
+        const hasState = readingState?.chapters?.some(n => n.chapterId === debouncedPageProgress.chapterId && n.provider === debouncedPageProgress.provider)
+        if (debouncedPageProgress.currentPage === 0 && !hasState) return
+
+        lastSavedPageRef.current = key
+        updateReadingPage({
+            mediaId: entry.mediaId,
+            ...debouncedPageProgress,
+        })
+    }, [debouncedPageProgress])
+
     // Progress update keyboard shortcuts
     React.useEffect(() => {
         mousetrap.bind("u", () => {
@@ -0,0 +1,112 @@
+import { MangaScanner_LocalSeries } from "@/api/generated/types"
+import { useGetMangaCollection } from "@/api/hooks/manga.hooks"
+import { useGetMangaLocalLibrary, useMatchMangaLocalLibrarySeries, useScanMangaLocalLibrary } from "@/api/hooks/manga_local_library.hooks"
+import { AppLayoutStack } from "@/components/ui/app-layout"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { Select } from "@/components/ui/select"
+import React from "react"
+
+type MangaLocalLibraryModalProps = {
+    children: React.ReactElement
+}
+
+export function MangaLocalLibraryModal(props: MangaLocalLibraryModalProps) {
+
+    const {
+        children,
+    } = props
+
+    return (
+        <Modal
+            data-manga-local-library-modal
+            title="Local library"
+            trigger={children}
+            contentClass="max-w-3xl"
+        >
+            <Content />
+        </Modal>
+    )
+}
+
+function Content() {
+
+    const { data: series, isLoading } = useGetMangaLocalLibrary()
+    const { data: collection } = useGetMangaCollection()
+
+    const { mutate: scan, isPending: isScanning } = useScanMangaLocalLibrary()
+    const { mutate: match, isPending: isMatching } = useMatchMangaLocalLibrarySeries()
+
+    const options = React.useMemo(() => {
+        const seen = new Set<number>()
+        const ret = [{ value: "0", label: "Unmatched" }]
+        for (const list of collection?.lists ?? []) {
+            for (const entry of list.entries ?? []) {
+                if (seen.has(entry.mediaId)) continue
+                seen.add(entry.mediaId)
+                ret.push({
+                    value: String(entry.mediaId),
+                    label: entry.media?.title?.userPreferred || entry.media?.title?.romaji || String(entry.mediaId),
+                })
+            }
+        }
+        return ret.sort((a, b) => a.value === "0" ? -1 : b.value === "0" ? 1 : a.label.localeCompare(b.label))
+    }, [collection])
+
+    function handleMatch(s: MangaScanner_LocalSeries, mediaId: string) {
+        match({ seriesKey: s.seriesKey, mediaId: Number(mediaId) })
+    }
+
+    return (
+        <AppLayoutStack>
+            <p className="text-sm text-[--muted]">
+                Series are matched to your AniList manga collection automatically.
+                Manual matches are kept when the library is scanned again.
+                Matched series can be read using the "Local Library" source.
+            </p>
+
+            <div>
+                <Button intent="primary-subtle" size="sm" loading={isScanning} onClick={() => scan()}>
+                    Scan library
+                </Button>
+            </div>
+
+            {isLoading && <LoadingSpinner />}
+
+            {!isLoading && !series?.length && <p className="text-sm text-[--muted]">
+                No series found. Set the local library directory in the settings and scan the library.
+            </p>}
+
+            <div className="space-y-2">
+                {series?.map(s => (
+                    <div key={s.seriesKey} className="flex flex-col md:flex-row gap-2 md:items-center border rounded-[--radius] p-2">
+                        <div className="flex-1 min-w-0">
+                            <p className="font-medium truncate">{s.seriesTitle}</p>
+                            <div className="flex gap-1 items-center">
+                                <p className="text-sm text-[--muted]">{s.chapterCount} {s.chapterCount === 1 ? "file" : "files"}</p>
+                                {s.unsupportedChapterCount > 0 && <Badge
+                                    size="sm"
+                                    intent="warning"
+                                    title="CB7 and PDF files are not supported by the reader"
+                                >
+                                    {s.unsupportedChapterCount} unsupported
+                                </Badge>}
+                                {s.locked && <Badge size="sm">Manual</Badge>}
+                            </div>
+                        </div>
+                        <div className="w-full md:w-72">
+                            <Select
+                                value={String(s.mediaId)}
+                                onValueChange={v => handleMatch(s, v)}
+                                options={options}
+                                disabled={isMatching}
+                            />
+                        </div>
+                    </div>
+                ))}
+            </div>
+        </AppLayoutStack>
+    )
+}
@@ -0,0 +1,44 @@
+import { useGetMangaContinueReading } from "@/api/hooks/manga_reading_state.hooks"
+import { MediaCardLazyGrid } from "@/app/(main)/_features/media/_components/media-card-grid"
+import { MediaEntryCard } from "@/app/(main)/_features/media/_components/media-entry-card"
+import { Badge } from "@/components/ui/badge"
+import React from "react"
+
+/**
+ * Manga with a chapter left unfinished, most recently read first.
+ * The entry's "Continue reading" button reopens the chapter at the last page read.
+ */
+export function MangaContinueReading() {
+
+    const { data: items } = useGetMangaContinueReading()
+
+    const entries = React.useMemo(() => items?.filter(n => !!n.media && !!n.state) ?? [], [items])
+
+    if (!entries.length) return null
+
+    return (
+        <React.Fragment>
+            <h2 data-manga-continue-reading-title>Resume reading</h2>
+
+            <MediaCardLazyGrid itemCount={entries.length}>
+                {entries.map(item => {
+                    return <div key={item.media!.id}>
+                        <MediaEntryCard
+                            media={item.media!}
+                            withAudienceScore={false}
+                            hideAnilistEntryEditButton
+                            type="manga"
+                            overlay={<Badge
+                                className="font-semibold text-white bg-gray-950 !bg-opacity-90 rounded-[--radius-md] text-base rounded-bl-none rounded-tr-none"
+                                intent="gray"
+                                size="lg"
+                            >
+                                Ch. {item.state!.chapterNumber} · p. {item.state!.currentPage + 1}{!!item.state!.totalPages && `/${item.state!.totalPages}`}
+                            </Badge>}
+                        />
+                    </div>
+                })}
+            </MediaCardLazyGrid>
+        </React.Fragment>
+    )
+}
@@ -1,3 +1,5 @@
+import { useGetMangaReleases } from "@/api/hooks/manga_release_tracker.hooks"
+import { useServerStatus } from "@/app/(main)/_hooks/use-server-status"
 import { __mangaLibrary_latestChapterNumbersAtom as __mangaLibrary_currentMangaDataAtom } from "@/app/(main)/manga/_lib/handle-manga-collection"
 import { Badge } from "@/components/ui/badge"
 import { useThemeSettings } from "@/lib/theme/hooks"
@@ -23,6 +25,8 @@ export function MangaEntryCardUnreadBadge(props: MangaEntryCardUnreadBadgeProps)
 
     const { showMangaUnreadCount } = useThemeSettings()
     const [mangaData] = useAtom(__mangaLibrary_currentMangaDataAtom)
+    const serverStatus = useServerStatus()
+    const { data: releases } = useGetMangaReleases(!!serverStatus?.settings?.manga?.mangaReleaseTrackerEnabled)
 
     const [progressTotal, setProgressTotal] = React.useState(_progressTotal || 0)
 
@@ -31,10 +35,13 @@ export function MangaEntryCardUnreadBadge(props: MangaEntryCardUnreadBadgeProps)
             mangaData.latestChapterNumbers,
             mangaData.storedProviders,
             mangaData.storedFilters)
-        if (latestChapterNumber) {
-            setProgressTotal(latestChapterNumber)
+        // Chapters released on MangaUpdates may not be available on the provider yet
+        const release = releases?.find(r => r.mediaId === mediaId)
+        const latestReleasedChapter = Math.floor(release?.latestChapter ?? 0)
+        if (latestChapterNumber || latestReleasedChapter) {
+            setProgressTotal(Math.max(latestChapterNumber || 0, latestReleasedChapter))
         }
-    }, [mangaData])
+    }, [mangaData, releases])
 
     if (!showMangaUnreadCount) return null
 
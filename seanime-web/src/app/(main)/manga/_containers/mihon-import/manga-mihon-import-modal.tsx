@@ -0,0 +1,165 @@
+import { MangaMihon_MatchType, MangaMihon_PreviewEntry } from "@/api/generated/types"
+import { useApplyMangaMihonImport, usePreviewMangaMihonImport } from "@/api/hooks/manga_mihon_import.hooks"
+import { AppLayoutStack } from "@/components/ui/app-layout"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { Checkbox } from "@/components/ui/checkbox"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { TextInput } from "@/components/ui/text-input"
+import React from "react"
+
+type MangaMihonImportModalProps = {
+    children: React.ReactElement
+}
+
+export function MangaMihonImportModal(props: MangaMihonImportModalProps) {
+
+    const {
+        children,
+    } = props
+
+    return (
+        <Modal
+            data-manga-mihon-import-modal
+            title="Import from Tachiyomi / Mihon"
+            trigger={children}
+            contentClass="max-w-4xl"
+        >
+            <Content />
+        </Modal>
+    )
+}
+
+const matchLabels: Record<MangaMihon_MatchType, string> = {
+    "": "Not matched",
+    "tracker": "Tracker",
+    "collection": "Collection",
+    "search": "Search",
+    "url": "URL",
+}
+
+function getEntryKey(entry: MangaMihon_PreviewEntry) {
+    return `${entry.sourceName}$${entry.url}$${entry.title}`
+}
+
+function Content() {
+
+    const { mutate: preview, data: previewData, isPending: isPreviewing, reset } = usePreviewMangaMihonImport()
+    const { mutate: apply, isPending: isApplying } = useApplyMangaMihonImport()
+
+    const [selected, setSelected] = React.useState<Set<string>>(new Set())
+
+    const entries = previewData?.entries ?? []
+
+    // Select all matched entries by default
+    React.useEffect(() => {
+        setSelected(new Set(entries.filter(e => !!e.mediaId).map(getEntryKey)))
+    }, [previewData])
+
+    const handleFileChange = (event: React.ChangeEvent<HTMLInputElement>) => {
+        const file = event.target.files?.[0]
+        if (!file) return
+        reset()
+        const reader = new FileReader()
+        reader.onload = (e) => {
+            // Remove the data URL prefix, e.g. "data:application/octet-stream;base64,"
+            const result = (e.target?.result as string) ?? ""
+            preview({ data: result.substring(result.indexOf(",") + 1) })
+        }
+        reader.readAsDataURL(file)
+    }
+
+    const toggle = (entry: MangaMihon_PreviewEntry, value: boolean) => {
+        setSelected(prev => {
+            const next = new Set(prev)
+            if (value) next.add(getEntryKey(entry))
+            else next.delete(getEntryKey(entry))
+            return next
+        })
+    }
+
+    const handleApply = () => {
+        apply({
+            entries: entries.filter(e => !!e.mediaId && selected.has(getEntryKey(e))).map(e => ({
+                mediaId: e.mediaId,
+                progress: e.progress,
+                totalChapters: e.media?.chapters || 0,
+                provider: e.provider,
+                providerMangaId: e.providerMangaId,
+            })),
+        })
+    }
+
+    const selectedCount = entries.filter(e => !!e.mediaId && selected.has(getEntryKey(e))).length
+
+    return (
+        <AppLayoutStack>
+            <p className="text-sm text-[--muted]">
+                Select a backup file (.tachibk or .proto.gz). The backup is read locally and nothing is changed until you apply the import.
+                Progress is only updated when the backup is ahead of your list.
+            </p>
+
+            <TextInput
+                type="file"
+                accept=".tachibk,.gz,.proto"
+                onChange={handleFileChange}
+                className="p-1"
+                disabled={isPreviewing || isApplying}
+            />
+
+            {isPreviewing && <LoadingSpinner />}
+
+            {!!previewData && !entries.length && <p className="text-sm text-[--muted]">No manga with reading history found in this backup.</p>}
+
+            {!!entries.length && <>
+                <div className="flex gap-2 items-center">
+                    <p className="text-sm text-[--muted] flex-1">{selectedCount} / {entries.length} selected</p>
+                    <Button
+                        intent="primary"
+                        size="sm"
+                        loading={isApplying}
+                        disabled={!selectedCount}
+                        onClick={handleApply}
+                    >
+                        Apply import
+                    </Button>
+                </div>
+
+                <div className="space-y-2">
+                    {entries.map(entry => {
+                        const isAhead = entry.progress > entry.currentProgress
+                        return (
+                            <div key={getEntryKey(entry)} className="flex gap-3 items-center border rounded-[--radius] p-2">
+                                <Checkbox
+                                    value={!!entry.mediaId && selected.has(getEntryKey(entry))}
+                                    onValueChange={v => toggle(entry, v as boolean)}
+                                    disabled={!entry.mediaId}
+                                    fieldClass="w-fit"
+                                />
+                                <div className="flex-1 min-w-0 space-y-1">
+                                    <p className="font-medium truncate">{entry.title}</p>
+                                    <p className="text-sm text-[--muted] truncate">
+                                        {entry.sourceName || "Unknown source"} · {entry.readChapters} read
+                                    </p>
+                                </div>
+                                <div className="flex-1 min-w-0 space-y-1">
+                                    <p className="truncate">
+                                        {entry.media?.title?.userPreferred || (entry.mediaId ? entry.mediaId : "-")}
+                                    </p>
+                                    <div className="flex gap-1 items-center flex-wrap">
+                                        <Badge size="sm" intent={entry.mediaId ? "gray" : "alert"}>{matchLabels[entry.matchedBy]}</Badge>
+                                        {!!entry.provider && <Badge size="sm">{entry.provider} ({matchLabels[entry.providerMatchedBy]})</Badge>}
+                                    </div>
+                                </div>
+                                <p className={isAhead ? "text-sm" : "text-sm text-[--muted]"}>
+                                    {entry.inCollection ? entry.currentProgress : "-"} → {entry.progress}
+                                </p>
+                            </div>
+                        )
+                    })}
+                </div>
+            </>}
+        </AppLayoutStack>
+    )
+}
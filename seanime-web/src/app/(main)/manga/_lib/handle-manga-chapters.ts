@@ -1,10 +1,20 @@
-import { useGetMangaEntryChapters } from "@/api/hooks/manga.hooks"
+import { useGetMangaEntryChapters, useGetMangaMergedChapters } from "@/api/hooks/manga.hooks"
 import { useHandleMangaProviderExtensions } from "@/app/(main)/manga/_lib/handle-manga-providers"
 import { useSelectedMangaFilters, useSelectedMangaProvider } from "@/app/(main)/manga/_lib/handle-manga-selected-provider"
 import { LANGUAGES_LIST } from "@/app/(main)/manga/_lib/language-map"
+import { useAtom } from "jotai/react"
+import { atomWithStorage } from "jotai/utils"
 import { uniq, uniqBy } from "lodash"
 import React from "react"
 
+/**
+ * Stores whether chapters are merged across sources for each manga entry
+ */
+export const __manga_entryMergedChaptersAtom = atomWithStorage<Record<string, boolean>>("sea-manga-entry-merged-chapters",
+    {},
+    undefined,
+    { getOnInit: true })
+
 export function useHandleMangaChapters(
     mediaId: string | null,
 ) {
@@ -76,11 +86,39 @@ export function useHandleMangaChapters(
     )
 
     /**
-     * 5. Filter chapters based on language and scanlator
+     * 5. Merge chapters across sources
+     * The selected filters are used as preferences instead of filtering the chapters
+     */
+    const [mergedChaptersRecord, setMergedChaptersRecord] = useAtom(__manga_entryMergedChaptersAtom)
+    const isMerged = !!mediaId && !!mergedChaptersRecord[mediaId]
+    const setIsMerged = React.useCallback((value: boolean) => {
+        if (!mediaId) return
+        setMergedChaptersRecord(prev => ({ ...prev, [mediaId]: value }))
+    }, [mediaId])
+
+    const {
+        data: mergedChapterContainer,
+        isLoading: mergedChapterContainerLoading,
+    } = useGetMangaMergedChapters({
+        mediaId: Number(mediaId),
+        provider: selectedProvider || undefined,
+        scanlators: selectedFilters.scanlators.filter(Boolean),
+        languages: selectedFilters.language ? [selectedFilters.language] : [],
+    }, isMerged)
+
+    /**
+     * 6. Filter chapters based on language and scanlator
      */
     const filteredChapterContainer = React.useMemo(() => {
         if (!chapterContainer) return chapterContainer
 
+        if (isMerged && mergedChapterContainer) {
+            return {
+                ...chapterContainer,
+                chapters: mergedChapterContainer.chapters?.map(ch => ch.chapter!).filter(Boolean) ?? [],
+            }
+        }
+
         const filteredChapters = chapterContainer.chapters?.filter(ch => {
             if (selectedExtension?.settings?.supportsMultiLanguage && selectedFilters.language) {
                 if (ch.language !== selectedFilters.language) return false
@@ -95,7 +133,7 @@ export function useHandleMangaChapters(
             ...chapterContainer,
             chapters: filteredChapters,
         }
-    }, [chapterContainer, selectedExtension, selectedFilters])
+    }, [chapterContainer, selectedExtension, selectedFilters, isMerged, mergedChapterContainer])
 
     // Filter language options based on scanlator
     const languageOptions = React.useMemo(() => {
@@ -136,7 +174,11 @@ export function useHandleMangaChapters(
         scanlatorOptions,
         // Chapters
         chapterContainer: filteredChapterContainer,
-        chapterContainerLoading,
+        chapterContainerLoading: chapterContainerLoading || (isMerged && mergedChapterContainerLoading),
         chapterContainerError,
+        // Merged chapters
+        isMerged,
+        setIsMerged,
+        mergedChapterContainer: isMerged ? mergedChapterContainer : undefined,
     }
 }
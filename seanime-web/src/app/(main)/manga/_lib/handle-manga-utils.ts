@@ -33,6 +33,10 @@ export function useMangaReaderUtils() {
                 return `${getServerBaseUrl()}/api/v1/image-proxy?url=${encodeURIComponent(url)}&headers=${encodeURIComponent(
                     JSON.stringify(headers))}`
             }
+            // Pages served by the server, e.g. local library pages
+            if (url.startsWith("/api/")) {
+                return `${getServerBaseUrl()}${url}`
+            }
             return url
         }
 
@@ -6,6 +6,7 @@ import { MediaGenreSelector } from "@/app/(main)/_features/media/_components/med
 import { SeaCommandInjectableItem, useSeaCommandInject } from "@/app/(main)/_features/sea-command/use-inject"
 import { seaCommand_compareMediaTitles } from "@/app/(main)/_features/sea-command/utils"
 import { __mangaLibraryHeaderImageAtom, __mangaLibraryHeaderMangaAtom } from "@/app/(main)/manga/_components/library-header"
+import { MangaContinueReading } from "@/app/(main)/manga/_containers/manga-continue-reading"
 import { __mangaLibrary_paramsAtom, __mangaLibrary_paramsInputAtom } from "@/app/(main)/manga/_lib/handle-manga-collection"
 import { PageWrapper } from "@/components/shared/page-wrapper"
 import { TextGenerateEffect } from "@/components/shared/text-generate-effect"
@@ -100,6 +101,8 @@ export function CollectionLists({ collectionList, genres, storedProviders }: {
                     <React.Fragment key={collection.type}>
                         <CollectionListItem list={collection} storedProviders={storedProviders} />
 
+                        {collection.type === "CURRENT" && <MangaContinueReading />}
+
                         {(collection.type === "CURRENT" && !!genres?.length) && <GenreSelector genres={genres} />}
                     </React.Fragment>
                 )
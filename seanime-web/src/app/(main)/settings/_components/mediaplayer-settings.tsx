@@ -30,7 +30,7 @@ export function MediaplayerSettings(props: MediaplayerSettingsProps) {
                 <h3>Desktop Media Player</h3>
 
                 <p className="text-[--muted]">
-                    Seanime has built-in support for MPV, VLC, and MPC-HC.
+                    Seanime has built-in support for MPV, VLC, MPC-HC, and Kodi.
                 </p>
             </div>
 
@@ -43,6 +43,7 @@ export function MediaplayerSettings(props: MediaplayerSettingsProps) {
                         { label: "MPV", value: "mpv" },
                         { label: "VLC", value: "vlc" },
                         { label: "MPC-HC", value: "mpc-hc" },
+                        { label: "Kodi", value: "kodi" },
                     ]}
                     help="Player that will be used to open files and track your progress automatically."
                 />
@@ -63,7 +64,7 @@ export function MediaplayerSettings(props: MediaplayerSettingsProps) {
                 <Field.Text
                     name="mediaPlayerHost"
                     label="Host"
-                    help="VLC/MPC-HC"
+                    help="VLC/MPC-HC/Kodi"
                 />
 
                 <Accordion
@@ -149,6 +150,38 @@ export function MediaplayerSettings(props: MediaplayerSettingsProps) {
                             </div>
                         </AccordionContent>
                     </AccordionItem>
+
+                    <AccordionItem value="kodi">
+                        <AccordionTrigger>
+                            <h4 className="flex gap-2 items-center"><HiPlay className="mr-1 text-blue-200" /> Kodi</h4>
+                        </AccordionTrigger>
+                        <AccordionContent className="space-y-4">
+                            <div className="flex flex-col md:flex-row gap-4">
+                                <Field.Text
+                                    name="kodiUsername"
+                                    label="Username"
+                                />
+                                <Field.Text
+                                    name="kodiPassword"
+                                    label="Password"
+                                />
+                                <Field.Number
+                                    name="kodiPort"
+                                    label="Port"
+                                    formatOptions={{
+                                        useGrouping: false,
+                                    }}
+                                    hideControls
+                                />
+                            </div>
+                            <Field.Textarea
+                                name="kodiPathMappings"
+                                label="Path mappings"
+                                placeholder={"/mnt/anime=smb://nas/anime"}
+                                help="One mapping per line. Maps library paths on this device to paths Kodi can access. Enable 'Allow remote control via HTTP' in Kodi."
+                            />
+                        </AccordionContent>
+                    </AccordionItem>
                 </Accordion>
             </SettingsCard>
 
@@ -1,8 +1,18 @@
+import { getServerBaseUrl } from "@/api/client/server-url"
 import { useListMangaProviderExtensions } from "@/api/hooks/extensions.hooks"
+import { useGetMangaProviderHealth } from "@/api/hooks/manga_provider_chain.hooks"
+import { useRunMangaReleaseTracker } from "@/api/hooks/manga_release_tracker.hooks"
+import { useCreateOpdsToken } from "@/api/hooks/opds.hooks"
+import { MangaLocalLibraryModal } from "@/app/(main)/manga/_containers/local-library/manga-local-library-modal"
+import { MangaMihonImportModal } from "@/app/(main)/manga/_containers/mihon-import/manga-mihon-import-modal"
 import { SettingsCard } from "@/app/(main)/settings/_components/settings-card"
 import { SettingsSubmitButton } from "@/app/(main)/settings/_components/settings-submit-button"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
 import { Field } from "@/components/ui/form"
+import { TextInput } from "@/components/ui/text-input"
 import React from "react"
+import { FcFolder } from "react-icons/fc"
 
 type MangaSettingsProps = {
     isPending: boolean
@@ -17,6 +27,12 @@ export function MangaSettings(props: MangaSettingsProps) {
 
     const { data: extensions } = useListMangaProviderExtensions()
 
+    const { mutate: createOpdsToken, data: opdsToken, isPending: isCreatingOpdsToken } = useCreateOpdsToken()
+
+    const { mutate: runReleaseTracker, isPending: isRunningReleaseTracker } = useRunMangaReleaseTracker()
+
+    const { data: providerHealth } = useGetMangaProviderHealth()
+
     const options = React.useMemo(() => {
         return [
             { label: "Auto", value: "-" },
@@ -55,6 +71,168 @@ export function MangaSettings(props: MangaSettingsProps) {
                 />
             </SettingsCard>
 
+            <SettingsCard title="Downloads">
+                <Field.Select
+                    name="mangaDownloadFormat"
+                    label="Storage format"
+                    help="CBZ archives include a ComicInfo.xml file and can be read by other comic readers."
+                    options={[
+                        { label: "Images", value: "images" },
+                        { label: "CBZ", value: "cbz" },
+                    ]}
+                />
+            </SettingsCard>
+
+            <SettingsCard title="Page Processing" description="Transform pages after they are downloaded or fetched through the image proxy.">
+                <Field.Switch
+                    side="right"
+                    name="mangaPageProcessingEnabled"
+                    label="Enable"
+                />
+                <Field.Select
+                    name="mangaPageFormat"
+                    label="Format"
+                    help="JPEG uses the quality setting. With 'Original', modified pages are saved as JPEG or PNG."
+                    options={[
+                        { label: "Original", value: "-" },
+                        { label: "JPEG", value: "jpeg" },
+                    ]}
+                />
+                <Field.Number
+                    name="mangaPageQuality"
+                    label="JPEG quality"
+                    min={1}
+                    max={100}
+                />
+                <Field.Number
+                    name="mangaPageMaxWidth"
+                    label="Maximum width"
+                    help="Larger pages are scaled down (in pixels). 0 to disable."
+                    min={0}
+                />
+                <Field.Switch
+                    side="right"
+                    name="mangaPageTrimBorders"
+                    label="Trim white borders"
+                />
+                <Field.Switch
+                    side="right"
+                    name="mangaPageSplitDoublePages"
+                    label="Split double pages"
+                    help="Landscape pages are split in two when downloaded."
+                />
+                <Field.Switch
+                    side="right"
+                    name="mangaPageSplitLeftToRight"
+                    label="Left page first"
+                    help="By default the right page comes first, as manga are read right to left."
+                />
+            </SettingsCard>
+
+            <SettingsCard title="Local Library">
+                <Field.DirectorySelector
+                    name="mangaLocalLibraryPath"
+                    label="Local library directory"
+                    leftIcon={<FcFolder />}
+                    help="Directory containing your manga. CBZ, CBR and image folders can be read. Each series should be in its own folder."
+                    shouldExist
+                />
+                <div>
+                    <MangaLocalLibraryModal>
+                        <Button intent="gray-outline" size="sm">
+                            Manage local library
+                        </Button>
+                    </MangaLocalLibraryModal>
+                </div>
+            </SettingsCard>
+
+            <SettingsCard title="Import" description="Import your reading history and sources from a Tachiyomi or Mihon backup.">
+                <div>
+                    <MangaMihonImportModal>
+                        <Button intent="gray-outline" size="sm">
+                            Import backup
+                        </Button>
+                    </MangaMihonImportModal>
+                </div>
+            </SettingsCard>
+
+            <SettingsCard title="Auto Downloader">
+                <Field.Switch
+                    side="right"
+                    name="mangaAutoDownloaderEnabled"
+                    label="Enable"
+                    help="Automatically queue new chapters of the series you are reading. Rules are created from the manga's page."
+                />
+                <Field.Number
+                    name="mangaAutoDownloaderInterval"
+                    label="Interval"
+                    help="How often to check for new chapters (in minutes). Minimum 15 minutes."
+                    min={15}
+                />
+            </SettingsCard>
+
+            <SettingsCard title="Release Tracker" description="Get notified when new chapters of the manga you are reading are released, regardless of the provider.">
+                <Field.Switch
+                    side="right"
+                    name="mangaReleaseTrackerEnabled"
+                    label="Enable"
+                    help="Releases are fetched from MangaUpdates."
+                />
+                <Field.Number
+                    name="mangaReleaseTrackerInterval"
+                    label="Interval"
+                    help="How often to check for new releases (in minutes). Minimum 60 minutes."
+                    min={60}
+                />
+                <div>
+                    <Button intent="gray-outline" size="sm" loading={isRunningReleaseTracker} onClick={() => runReleaseTracker()}>
+                        Check now
+                    </Button>
+                </div>
+            </SettingsCard>
+
+            <SettingsCard title="Source Health" description="Sources that fail often are tried last when using fallback sources.">
+                {!providerHealth?.length && <p className="text-sm text-[--muted]">No sources have been used yet.</p>}
+                {providerHealth?.map(health => (
+                    <div key={health.provider} className="flex flex-wrap items-center gap-2 text-sm">
+                        <span className="font-medium w-40 truncate">
+                            {extensions?.find(n => n.id === health.provider)?.name ?? health.provider}
+                        </span>
+                        <Badge intent={health.healthy ? "success" : "alert"} size="sm">
+                            {health.healthy ? "Healthy" : "Unhealthy"}
+                        </Badge>
+                        <span className="text-[--muted]">
+                            {Math.round(health.successRate * 100)}% success, {health.averageLatency}ms, {health.requests} requests
+                        </span>
+                        {!!health.lastError && !health.healthy && (
+                            <span className="text-[--red] truncate max-w-full">{health.lastError}</span>
+                        )}
+                    </div>
+                ))}
+            </SettingsCard>
+
+            <SettingsCard title="OPDS" description="Browse and read your manga library from e-readers and apps that support OPDS catalogs.">
+                <p className="text-sm text-[--muted]">
+                    Use the catalog URL below with any username and the generated token as the password.
+                    Tokens are valid for one year.
+                </p>
+                <TextInput
+                    label="Catalog URL"
+                    value={`${getServerBaseUrl()}/opds`}
+                    readOnly
+                />
+                {!!opdsToken && <TextInput
+                    label="Token"
+                    value={opdsToken.token}
+                    readOnly
+                />}
+                <div>
+                    <Button intent="gray-outline" size="sm" loading={isCreatingOpdsToken} onClick={() => createOpdsToken()}>
+                        Generate token
+                    </Button>
+                </div>
+            </SettingsCard>
+
             <SettingsSubmitButton isPending={isPending} />
         </>
     )
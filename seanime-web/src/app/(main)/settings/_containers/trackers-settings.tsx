@@ -0,0 +1,175 @@
+import { HibikeTracker_FuzzyDate, Tracker_Changes, Tracker_SyncItem, Tracker_TrackerItem } from "@/api/generated/types"
+import {
+    useGetTrackerConflicts,
+    useGetTrackers,
+    useGetTrackerSyncItems,
+    useResolveTrackerConflict,
+    useRetryTrackerSyncItem,
+    useSetTrackerEnabled,
+} from "@/api/hooks/trackers.hooks"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { Switch } from "@/components/ui/switch"
+import React from "react"
+import { SettingsCard } from "../_components/settings-card"
+
+export function TrackersSettings() {
+
+    const { data: trackers, isLoading } = useGetTrackers()
+    const { data: conflicts } = useGetTrackerConflicts()
+
+    const [showSyncItems, setShowSyncItems] = React.useState(false)
+
+    if (isLoading) return <LoadingSpinner />
+
+    const trackerNames = Object.fromEntries((trackers ?? []).map(tracker => [tracker.id, tracker.name]))
+
+    return (
+        <>
+            <SettingsCard
+                title="Trackers"
+                description="Mirror the progress, status and score changes made on AniList to other services (Kitsu, Simkl, Shikimori...). Install tracker extensions to add services."
+            >
+                {!trackers?.length && <p className="text-[--muted]">No tracker extensions installed</p>}
+
+                <div className="space-y-2">
+                    {trackers?.map(tracker => <TrackerItem key={tracker.id} tracker={tracker} />)}
+                </div>
+
+                <div>
+                    <Button intent="gray-subtle" size="sm" onClick={() => setShowSyncItems(true)}>Sync log</Button>
+                </div>
+            </SettingsCard>
+
+            <SettingsCard
+                title="Conflicts"
+                description="Changes that were not sent because the entry on the tracker looks more recent."
+            >
+                {!conflicts?.length && <p className="text-[--muted]">No conflicts</p>}
+
+                <div className="space-y-2">
+                    {conflicts?.map(item => <TrackerConflictItem key={item.id} item={item} trackerName={trackerNames[item.trackerId]} />)}
+                </div>
+            </SettingsCard>
+
+            <TrackerSyncItemsModal trackerNames={trackerNames} open={showSyncItems} onOpenChange={setShowSyncItems} />
+        </>
+    )
+}
+
+function TrackerItem({ tracker }: { tracker: Tracker_TrackerItem }) {
+
+    const { mutate: setEnabled, isPending } = useSetTrackerEnabled(tracker.id)
+
+    return (
+        <div className="border rounded-[--radius] p-3 flex flex-wrap items-center gap-2">
+            {!!tracker.icon && <img src={tracker.icon} alt="" className="size-6 rounded-[--radius-md] object-contain" />}
+            <p className="font-semibold">{tracker.name}</p>
+            {tracker.settings?.supportsAnime && <Badge intent="gray" size="sm">Anime</Badge>}
+            {tracker.settings?.supportsManga && <Badge intent="gray" size="sm">Manga</Badge>}
+            <div className="flex flex-1" />
+            <Switch
+                value={tracker.enabled}
+                disabled={isPending}
+                onValueChange={v => setEnabled({ enabled: v })}
+            />
+        </div>
+    )
+}
+
+function TrackerConflictItem({ item, trackerName }: { item: Tracker_SyncItem, trackerName: string | undefined }) {
+
+    const { mutate: resolve, isPending } = useResolveTrackerConflict(item.id)
+
+    return (
+        <div className="border rounded-[--radius] p-3 space-y-2 text-sm">
+            <div className="flex flex-wrap items-center gap-2">
+                <span className="font-semibold">{trackerName || item.trackerId}</span>
+                <span className="text-[--muted]">Media {item.mediaId}</span>
+                <div className="flex flex-1" />
+                <Button intent="warning-subtle" size="sm" loading={isPending} onClick={() => resolve({ overwrite: true })}>Overwrite</Button>
+                <Button intent="gray-subtle" size="sm" loading={isPending} onClick={() => resolve({ overwrite: false })}>Skip</Button>
+            </div>
+            {!!item.error && <p className="text-[--muted]">{item.error}</p>}
+            <div className="grid grid-cols-1 md:grid-cols-2 gap-2">
+                <div>
+                    <p className="font-semibold">AniList</p>
+                    <p className="text-[--muted]">{formatChanges(item.changes)}</p>
+                </div>
+                <div>
+                    <p className="font-semibold">{trackerName || item.trackerId}</p>
+                    <p className="text-[--muted]">{formatChanges(item.remote)}</p>
+                </div>
+            </div>
+        </div>
+    )
+}
+
+function TrackerSyncItemsModal({ trackerNames, open, onOpenChange }: {
+    trackerNames: Record<string, string>,
+    open: boolean,
+    onOpenChange: (v: boolean) => void
+}) {
+
+    const { data: items, isLoading } = useGetTrackerSyncItems(open)
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title="Sync log"
+            contentClass="max-w-4xl"
+        >
+            {isLoading && <LoadingSpinner />}
+            {!isLoading && !items?.length && <p className="text-[--muted]">No changes</p>}
+            <div className="space-y-2 max-h-[70vh] overflow-y-auto">
+                {items?.map(item => <TrackerSyncItem key={item.id} item={item} trackerName={trackerNames[item.trackerId]} />)}
+            </div>
+        </Modal>
+    )
+}
+
+function TrackerSyncItem({ item, trackerName }: { item: Tracker_SyncItem, trackerName: string | undefined }) {
+
+    const { mutate: retry, isPending } = useRetryTrackerSyncItem(item.id)
+
+    return (
+        <div className="border rounded-[--radius] p-2 space-y-1 text-sm">
+            <div className="flex flex-wrap items-center gap-2">
+                <Badge intent={item.status === "success" ? "success" : item.status === "failed" ? "alert" : item.status === "skipped" ? "gray" : "warning"}>
+                    {item.status}
+                </Badge>
+                <span className="font-semibold">{trackerName || item.trackerId}</span>
+                <span className="text-[--muted]">Media {item.mediaId}</span>
+                <span className="text-[--muted]">{item.updatedAt ? new Date(item.updatedAt).toLocaleString() : ""}</span>
+                <span className="text-[--muted]">{item.attempts} attempt{item.attempts !== 1 ? "s" : ""}</span>
+                <div className="flex flex-1" />
+                {item.status === "failed" && (
+                    <Button intent="gray-subtle" size="sm" loading={isPending} onClick={() => retry()}>Retry</Button>
+                )}
+            </div>
+            <p className="text-[--muted]">{formatChanges(item.changes)}</p>
+            {!!item.error && <p className={item.status === "failed" ? "text-red-300 break-all" : "text-[--muted] break-all"}>{item.error}</p>}
+            {item.status === "pending" && !!item.nextAttemptAt && (
+                <p className="text-[--muted]">Next attempt: {new Date(item.nextAttemptAt).toLocaleString()}</p>
+            )}
+        </div>
+    )
+}
+
+function formatChanges(changes: Tracker_Changes | undefined) {
+    if (!changes) return "-"
+    const parts: string[] = []
+    if (changes.status) parts.push(`Status: ${changes.status}`)
+    if (changes.progress !== undefined && changes.progress !== null) parts.push(`Progress: ${changes.progress}`)
+    if (changes.score !== undefined && changes.score !== null) parts.push(`Score: ${changes.score}`)
+    if (changes.startedAt) parts.push(`Started: ${formatFuzzyDate(changes.startedAt)}`)
+    if (changes.completedAt) parts.push(`Completed: ${formatFuzzyDate(changes.completedAt)}`)
+    return parts.join(" · ") || "-"
+}
+
+function formatFuzzyDate(date: HibikeTracker_FuzzyDate) {
+    return [date.year, date.month, date.day].filter(v => v !== undefined && v !== null).join("-") || "?"
+}
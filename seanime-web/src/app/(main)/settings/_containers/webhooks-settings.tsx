@@ -0,0 +1,228 @@
+import { Models_Webhook, Models_WebhookDelivery } from "@/api/generated/types"
+import {
+    useDeleteWebhook,
+    useGetWebhookDeliveries,
+    useGetWebhookEvents,
+    useGetWebhooks,
+    useRedeliverWebhookDelivery,
+    useSaveWebhook,
+    useTestWebhook,
+} from "@/api/hooks/webhooks.hooks"
+import { Badge } from "@/components/ui/badge"
+import { Button } from "@/components/ui/button"
+import { Combobox } from "@/components/ui/combobox"
+import { LoadingSpinner } from "@/components/ui/loading-spinner"
+import { Modal } from "@/components/ui/modal"
+import { Switch } from "@/components/ui/switch"
+import { TextInput } from "@/components/ui/text-input"
+import { Textarea } from "@/components/ui/textarea"
+import React from "react"
+import { SettingsCard } from "../_components/settings-card"
+
+const EMPTY_WEBHOOK: Models_Webhook = {
+    id: 0,
+    name: "",
+    enabled: true,
+    url: "",
+    events: "",
+    secret: "",
+    template: "",
+    headers: "",
+}
+
+export function WebhooksSettings() {
+
+    const { data: webhooks, isLoading } = useGetWebhooks()
+
+    const [editedWebhook, setEditedWebhook] = React.useState<Models_Webhook | null>(null)
+
+    if (isLoading) return <LoadingSpinner />
+
+    return (
+        <>
+            <SettingsCard
+                title="Webhooks"
+                description="Send events to a URL (Discord, ntfy, Home Assistant, n8n...) when they happen. Failed deliveries are retried with backoff."
+            >
+                <div>
+                    <Button intent="white-subtle" size="sm" onClick={() => setEditedWebhook({ ...EMPTY_WEBHOOK })}>
+                        Add webhook
+                    </Button>
+                </div>
+
+                {!webhooks?.length && <p className="text-[--muted]">No webhooks</p>}
+
+                <div className="space-y-2">
+                    {webhooks?.map(webhook => (
+                        <WebhookItem key={webhook.id} webhook={webhook} onEdit={() => setEditedWebhook(webhook)} />
+                    ))}
+                </div>
+            </SettingsCard>
+
+            {editedWebhook && <WebhookModal webhook={editedWebhook} onClose={() => setEditedWebhook(null)} />}
+        </>
+    )
+}
+
+function WebhookItem({ webhook, onEdit }: { webhook: Models_Webhook, onEdit: () => void }) {
+
+    const { mutate: deleteWebhook, isPending: isDeleting } = useDeleteWebhook(webhook.id)
+    const { mutate: testWebhook, isPending: isTesting } = useTestWebhook(webhook.id)
+
+    const [showDeliveries, setShowDeliveries] = React.useState(false)
+
+    return (
+        <div className="border rounded-[--radius] p-3 space-y-2">
+            <div className="flex flex-wrap items-center gap-2">
+                <p className="font-semibold">{webhook.name || webhook.url}</p>
+                {!webhook.enabled && <Badge intent="gray">Disabled</Badge>}
+                <div className="flex flex-1" />
+                <Button intent="gray-subtle" size="sm" onClick={() => setShowDeliveries(true)}>Deliveries</Button>
+                <Button intent="gray-subtle" size="sm" loading={isTesting} onClick={() => testWebhook()}>Test</Button>
+                <Button intent="white-subtle" size="sm" onClick={onEdit}>Edit</Button>
+                <Button intent="alert-subtle" size="sm" loading={isDeleting} onClick={() => deleteWebhook()}>Delete</Button>
+            </div>
+            <p className="text-sm text-[--muted] break-all">{webhook.url}</p>
+            <div className="flex flex-wrap gap-1">
+                {webhook.events.split(",").filter(Boolean).map(event => (
+                    <Badge key={event} intent="primary" size="sm">{event}</Badge>
+                ))}
+            </div>
+
+            <WebhookDeliveriesModal webhook={webhook} open={showDeliveries} onOpenChange={setShowDeliveries} />
+        </div>
+    )
+}
+
+function WebhookModal({ webhook, onClose }: { webhook: Models_Webhook, onClose: () => void }) {
+
+    const { data: events } = useGetWebhookEvents()
+    const { mutate: saveWebhook, isPending } = useSaveWebhook()
+
+    const [value, setValue] = React.useState<Models_Webhook>(webhook)
+
+    function handleSave() {
+        saveWebhook({
+            id: value.id,
+            name: value.name,
+            enabled: value.enabled,
+            url: value.url,
+            events: value.events,
+            secret: value.secret,
+            template: value.template,
+            headers: value.headers,
+        }, {
+            onSuccess: () => onClose(),
+        })
+    }
+
+    return (
+        <Modal
+            open
+            onOpenChange={v => !v && onClose()}
+            title={webhook.id ? "Edit webhook" : "Add webhook"}
+            contentClass="max-w-2xl"
+        >
+            <div className="space-y-4">
+                <Switch
+                    label="Enabled"
+                    value={value.enabled}
+                    onValueChange={v => setValue(p => ({ ...p, enabled: v }))}
+                />
+                <TextInput
+                    label="Name"
+                    value={value.name}
+                    onValueChange={v => setValue(p => ({ ...p, name: v }))}
+                />
+                <TextInput
+                    label="URL"
+                    placeholder="https://"
+                    value={value.url}
+                    onValueChange={v => setValue(p => ({ ...p, url: v }))}
+                />
+                <Combobox
+                    multiple
+                    label="Events"
+                    emptyMessage="No events found"
+                    placeholder="Select events"
+                    options={(events ?? []).map(event => ({ value: event, label: event, textValue: event }))}
+                    value={value.events.split(",").filter(Boolean)}
+                    onValueChange={v => setValue(p => ({ ...p, events: v.join(",") }))}
+                />
+                <TextInput
+                    label="Secret"
+                    help="Optional. The body is signed with HMAC-SHA256 and the signature is sent in the 'X-Seanime-Signature' header."
+                    value={value.secret}
+                    onValueChange={v => setValue(p => ({ ...p, secret: v }))}
+                />
+                <Textarea
+                    label="Headers"
+                    help="Optional. One 'Key: Value' per line."
+                    placeholder="Authorization: Bearer ..."
+                    value={value.headers}
+                    onValueChange={v => setValue(p => ({ ...p, headers: v }))}
+                />
+                <Textarea
+                    label="Payload template"
+                    help={<>Optional Go template of the body. Available fields: <code>{"{{ .Event }}"}</code>, <code>{"{{ .Timestamp }}"}</code> and <code>
+                        {"{{ .Data }}"}</code>. Use <code>{"{{ json .Data }}"}</code> to encode a value as JSON. By default, the event is sent as JSON.</>}
+                    placeholder={"{\"content\": {{ json (printf \"Seanime: %s\" .Event) }}}"}
+                    className="font-mono text-sm"
+                    value={value.template}
+                    onValueChange={v => setValue(p => ({ ...p, template: v }))}
+                />
+                <div className="flex justify-end">
+                    <Button intent="primary" loading={isPending} onClick={handleSave}>Save</Button>
+                </div>
+            </div>
+        </Modal>
+    )
+}
+
+function WebhookDeliveriesModal({ webhook, open, onOpenChange }: { webhook: Models_Webhook, open: boolean, onOpenChange: (v: boolean) => void }) {
+
+    const { data: deliveries, isLoading } = useGetWebhookDeliveries(open ? webhook.id : null)
+
+    return (
+        <Modal
+            open={open}
+            onOpenChange={onOpenChange}
+            title={`Deliveries: ${webhook.name || webhook.url}`}
+            contentClass="max-w-4xl"
+        >
+            {isLoading && <LoadingSpinner />}
+            {!isLoading && !deliveries?.length && <p className="text-[--muted]">No deliveries</p>}
+            <div className="space-y-2 max-h-[70vh] overflow-y-auto">
+                {deliveries?.map(delivery => <WebhookDeliveryItem key={delivery.id} delivery={delivery} />)}
+            </div>
+        </Modal>
+    )
+}
+
+function WebhookDeliveryItem({ delivery }: { delivery: Models_WebhookDelivery }) {
+
+    const { mutate: redeliver, isPending } = useRedeliverWebhookDelivery(delivery.id)
+
+    return (
+        <div className="border rounded-[--radius] p-2 space-y-1 text-sm">
+            <div className="flex flex-wrap items-center gap-2">
+                <Badge intent={delivery.status === "success" ? "success" : delivery.status === "failed" ? "alert" : "warning"}>
+                    {delivery.status}
+                </Badge>
+                <span className="font-semibold">{delivery.event}</span>
+                <span className="text-[--muted]">{delivery.createdAt ? new Date(delivery.createdAt).toLocaleString() : ""}</span>
+                <span className="text-[--muted]">{delivery.attempts} attempt{delivery.attempts !== 1 ? "s" : ""}</span>
+                {!!delivery.responseStatus && <span className="text-[--muted]">HTTP {delivery.responseStatus}</span>}
+                <div className="flex flex-1" />
+                {delivery.status !== "pending" && (
+                    <Button intent="gray-subtle" size="sm" loading={isPending} onClick={() => redeliver()}>Redeliver</Button>
+                )}
+            </div>
+            {!!delivery.error && <p className="text-red-300 break-all">{delivery.error}</p>}
+            {delivery.status === "pending" && !!delivery.nextAttemptAt && (
+                <p className="text-[--muted]">Next attempt: {new Date(delivery.nextAttemptAt).toLocaleString()}</p>
+            )}
+            <pre className="text-xs bg-[--subtle] rounded-[--radius] p-2 overflow-x-auto max-h-40">{delivery.body}</pre>
+        </div>
+    )
+}
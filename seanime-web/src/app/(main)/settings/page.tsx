@@ -19,6 +19,8 @@ import { MediastreamSettings } from "@/app/(main)/settings/_containers/mediastre
 import { ServerSettings } from "@/app/(main)/settings/_containers/server-settings"
 import { TorrentstreamSettings } from "@/app/(main)/settings/_containers/torrentstream-settings"
 import { UISettings } from "@/app/(main)/settings/_containers/ui-settings"
+import { TrackersSettings } from "@/app/(main)/settings/_containers/trackers-settings"
+import { WebhooksSettings } from "@/app/(main)/settings/_containers/webhooks-settings"
 import { PageWrapper } from "@/components/shared/page-wrapper"
 import { Accordion, AccordionContent, AccordionItem, AccordionTrigger } from "@/components/ui/accordion"
 import { Button } from "@/components/ui/button"
@@ -38,7 +40,7 @@ import { FaShareFromSquare } from "react-icons/fa6"
 import { HiOutlineServerStack } from "react-icons/hi2"
 import { ImDownload } from "react-icons/im"
 import { IoLibrary, IoPlayBackCircleSharp } from "react-icons/io5"
-import { LuBookKey, LuWandSparkles } from "react-icons/lu"
+import { LuBookKey, LuListChecks, LuWandSparkles, LuWebhook } from "react-icons/lu"
 import { MdNoAdultContent, MdOutlineBroadcastOnHome, MdOutlineDownloading, MdOutlinePalette } from "react-icons/md"
 import { PiVideoFill } from "react-icons/pi"
 import { RiFolderDownloadFill } from "react-icons/ri"
@@ -152,8 +154,10 @@ export default function Page() {
                                 <TabsTrigger value="discord"><FaDiscord className="text-lg mr-3" /> Discord</TabsTrigger>
                                 <TabsTrigger value="nsfw"><MdNoAdultContent className="text-lg mr-3" /> NSFW</TabsTrigger>
                                 <TabsTrigger value="anilist"><SiAnilist className="text-lg mr-3" /> AniList</TabsTrigger>
+                                <TabsTrigger value="trackers"><LuListChecks className="text-lg mr-3" /> Trackers</TabsTrigger>
                                 {/* <Separator className="hidden lg:block my-2" /> */}
                                 <TabsTrigger value="cache"><TbDatabaseExclamation className="text-lg mr-3" /> Cache</TabsTrigger>
+                                <TabsTrigger value="webhooks"><LuWebhook className="text-lg mr-3" /> Webhooks</TabsTrigger>
                                 <TabsTrigger value="logs"><LuBookKey className="text-lg mr-3" /> Logs</TabsTrigger>
                                 {/*<TabsTrigger value="data"><FiDatabase className="text-lg mr-3" /> Data</TabsTrigger>*/}
                                 {/* <Separator className="hidden lg:block my-2" /> */}
@@ -192,6 +196,19 @@ export default function Page() {
                                     manga: {
                                         defaultMangaProvider: data.defaultMangaProvider === "-" ? "" : data.defaultMangaProvider,
                                         mangaAutoUpdateProgress: data.mangaAutoUpdateProgress ?? false,
+                                        mangaDownloadFormat: data.mangaDownloadFormat || "images",
+                                        mangaAutoDownloaderEnabled: data.mangaAutoDownloaderEnabled ?? false,
+                                        mangaAutoDownloaderInterval: data.mangaAutoDownloaderInterval || 60,
+                                        mangaLocalLibraryPath: data.mangaLocalLibraryPath || "",
+                                        mangaReleaseTrackerEnabled: data.mangaReleaseTrackerEnabled ?? false,
+                                        mangaReleaseTrackerInterval: data.mangaReleaseTrackerInterval || 360,
+                                        mangaPageProcessingEnabled: data.mangaPageProcessingEnabled ?? false,
+                                        mangaPageFormat: data.mangaPageFormat === "-" ? "" : data.mangaPageFormat,
+                                        mangaPageQuality: data.mangaPageQuality || 85,
+                                        mangaPageMaxWidth: data.mangaPageMaxWidth || 0,
+                                        mangaPageSplitDoublePages: data.mangaPageSplitDoublePages ?? false,
+                                        mangaPageSplitLeftToRight: data.mangaPageSplitLeftToRight ?? false,
+                                        mangaPageTrimBorders: data.mangaPageTrimBorders ?? false,
                                     },
                                     mediaPlayer: {
                                         host: data.mediaPlayerHost,
@@ -204,6 +221,10 @@ export default function Page() {
                                         mpcPath: data.mpcPath || "",
                                         mpvSocket: data.mpvSocket || "",
                                         mpvPath: data.mpvPath || "",
+                                        kodiPort: data.kodiPort,
+                                        kodiUsername: data.kodiUsername || "",
+                                        kodiPassword: data.kodiPassword || "",
+                                        kodiPathMappings: data.kodiPathMappings || "",
                                     },
                                     torrent: {
                                         defaultTorrentClient: data.defaultTorrentClient,
@@ -259,6 +280,10 @@ export default function Page() {
                                 mpcPath: status?.settings?.mediaPlayer?.mpcPath,
                                 mpvSocket: status?.settings?.mediaPlayer?.mpvSocket,
                                 mpvPath: status?.settings?.mediaPlayer?.mpvPath,
+                                kodiPort: status?.settings?.mediaPlayer?.kodiPort || 8080,
+                                kodiUsername: status?.settings?.mediaPlayer?.kodiUsername,
+                                kodiPassword: status?.settings?.mediaPlayer?.kodiPassword,
+                                kodiPathMappings: status?.settings?.mediaPlayer?.kodiPathMappings,
                                 defaultTorrentClient: status?.settings?.torrent?.defaultTorrentClient || DEFAULT_TORRENT_CLIENT, // (Backwards
                                 // compatibility)
                                 hideTorrentList: status?.settings?.torrent?.hideTorrentList ?? false,
@@ -297,6 +322,19 @@ export default function Page() {
                                 disableAutoScannerNotifications: status?.settings?.notifications?.disableAutoScannerNotifications ?? false,
                                 defaultMangaProvider: status?.settings?.manga?.defaultMangaProvider || "-",
                                 mangaAutoUpdateProgress: status?.settings?.manga?.mangaAutoUpdateProgress ?? false,
+                                mangaDownloadFormat: status?.settings?.manga?.mangaDownloadFormat || "images",
+                                mangaAutoDownloaderEnabled: status?.settings?.manga?.mangaAutoDownloaderEnabled ?? false,
+                                mangaAutoDownloaderInterval: status?.settings?.manga?.mangaAutoDownloaderInterval || 60,
+                                mangaLocalLibraryPath: status?.settings?.manga?.mangaLocalLibraryPath || "",
+                                mangaReleaseTrackerEnabled: status?.settings?.manga?.mangaReleaseTrackerEnabled ?? false,
+                                mangaReleaseTrackerInterval: status?.settings?.manga?.mangaReleaseTrackerInterval || 360,
+                                mangaPageProcessingEnabled: status?.settings?.manga?.mangaPageProcessingEnabled ?? false,
+                                mangaPageFormat: status?.settings?.manga?.mangaPageFormat || "-",
+                                mangaPageQuality: status?.settings?.manga?.mangaPageQuality || 85,
+                                mangaPageMaxWidth: status?.settings?.manga?.mangaPageMaxWidth || 0,
+                                mangaPageSplitDoublePages: status?.settings?.manga?.mangaPageSplitDoublePages ?? false,
+                                mangaPageSplitLeftToRight: status?.settings?.manga?.mangaPageSplitLeftToRight ?? false,
+                                mangaPageTrimBorders: status?.settings?.manga?.mangaPageTrimBorders ?? false,
                                 showActiveTorrentCount: status?.settings?.torrent?.showActiveTorrentCount ?? false,
                                 autoPlayNextEpisode: status?.settings?.library?.autoPlayNextEpisode ?? false,
                                 enableWatchContinuity: status?.settings?.library?.enableWatchContinuity ?? false,
@@ -645,6 +683,22 @@ export default function Page() {
 
                         </TabsContent>
 
+                        <TabsContent value="trackers" className="space-y-4">
+
+                            <h3>Trackers</h3>
+
+                            <TrackersSettings />
+
+                        </TabsContent>
+
+                        <TabsContent value="webhooks" className="space-y-4">
+
+                            <h3>Webhooks</h3>
+
+                            <WebhooksSettings />
+
+                        </TabsContent>
+
                         <TabsContent value="logs" className="space-y-4">
 
                             <h3>Logs</h3>
@@ -41,6 +41,10 @@ export const settingsSchema = z.object({
     mpcPath: z.string().optional().default(""),
     mpvSocket: z.string().optional().default(""),
     mpvPath: z.string().optional().default(""),
+    kodiPort: z.number().optional().default(8080),
+    kodiUsername: z.string().optional().default(""),
+    kodiPassword: z.string().optional().default(""),
+    kodiPathMappings: z.string().optional().default(""),
     defaultTorrentClient: z.string().optional().default(DEFAULT_TORRENT_CLIENT),
     hideTorrentList: z.boolean().optional().default(false),
     qbittorrentPath: z.string().optional().default(""),
@@ -78,6 +82,19 @@ export const settingsSchema = z.object({
     disableAutoScannerNotifications: z.boolean().optional().default(false),
     defaultMangaProvider: z.string().optional().default(""),
     mangaAutoUpdateProgress: z.boolean().optional().default(false),
+    mangaDownloadFormat: z.string().optional().default("images"),
+    mangaAutoDownloaderEnabled: z.boolean().optional().default(false),
+    mangaAutoDownloaderInterval: z.number().min(15).optional().default(60),
+    mangaLocalLibraryPath: z.string().optional().default(""),
+    mangaReleaseTrackerEnabled: z.boolean().optional().default(false),
+    mangaReleaseTrackerInterval: z.number().min(60).optional().default(360),
+    mangaPageProcessingEnabled: z.boolean().optional().default(false),
+    mangaPageFormat: z.string().optional().default(""),
+    mangaPageQuality: z.number().min(1).max(100).optional().default(85),
+    mangaPageMaxWidth: z.number().min(0).optional().default(0),
+    mangaPageSplitDoublePages: z.boolean().optional().default(false),
+    mangaPageSplitLeftToRight: z.boolean().optional().default(false),
+    mangaPageTrimBorders: z.boolean().optional().default(false),
     autoPlayNextEpisode: z.boolean().optional().default(false),
     showActiveTorrentCount: z.boolean().optional().default(false),
     enableWatchContinuity: z.boolean().optional().default(false),